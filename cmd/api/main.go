@@ -2,37 +2,85 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"io"
 	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
 
 	"cloud.google.com/go/storage"
+	"github.com/gin-gonic/gin"
 	"github.com/yourusername/college-event-backend/internal/api"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/grpcapi"
+	"github.com/yourusername/college-event-backend/internal/jobs"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+	"github.com/yourusername/college-event-backend/internal/realtime"
 	"github.com/yourusername/college-event-backend/internal/services/auth"
+	"github.com/yourusername/college-event-backend/internal/services/bootstrap"
+	"github.com/yourusername/college-event-backend/internal/services/moderation"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
+	"github.com/yourusername/college-event-backend/internal/services/payments"
+	"github.com/yourusername/college-event-backend/internal/services/security"
+	"github.com/yourusername/college-event-backend/internal/settings"
 	localstorage "github.com/yourusername/college-event-backend/internal/storage"
+	"github.com/yourusername/college-event-backend/internal/webhooks"
 	"github.com/yourusername/college-event-backend/pkg/config"
 	"github.com/yourusername/college-event-backend/pkg/database"
+	"github.com/yourusername/college-event-backend/pkg/version"
+	"golang.org/x/crypto/acme/autocert"
 )
 
 func main() {
+	// Tag every log line with the build, so support can tell which build a
+	// log came from
+	logPrefix := "[" + version.String() + "] "
+	log.SetPrefix(logPrefix)
+	log.SetFlags(log.LstdFlags | log.Lmsgprefix)
+	gin.DefaultWriter = prefixWriter{w: os.Stdout, prefix: []byte(logPrefix)}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	gin.SetMode(cfg.GinMode())
 	log.Printf("Starting College Event Management API in %s mode...", cfg.Env)
 
 	// Connect to database
-	db, err := database.Connect(cfg.GetDatabaseDSN())
+	database.SetSlowQueryThreshold(time.Duration(cfg.DBSlowQueryMs) * time.Millisecond)
+	db, err := database.Connect(cfg.GetDatabaseDSN(), cfg.GetDatabasePoolConfig())
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	defer db.Close()
 
-	log.Println("✓ Connected to database")
+	db.RegisterPoolMetrics()
+	log.Printf("✓ Connected to database (max %d connections)", cfg.DBMaxOpenConns)
+
+	if cfg.DBReplicaDSNs != "" {
+		if err := db.AttachReplicas(cfg.DBReplicaDSNs); err != nil {
+			log.Fatalf("Failed to configure read replicas: %v", err)
+		}
+		log.Println("✓ Read replicas attached")
+	}
+
+	if err := cfg.CheckDependencies(context.Background(), db.DB); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
 
 	// Initialize auth service
 	authService := auth.NewService(cfg.JWTSecret, cfg.JWTExpiryHours, cfg.RefreshTokenExpiryDays)
+	authProviders, err := initAuthProviders(cfg)
+	if err != nil {
+		log.Fatalf("Failed to configure login methods: %v", err)
+	}
+	log.Printf("✓ Login methods: %s", strings.Join(cfg.GetAuthProviders(), ", "))
 
 	// Initialize storage service based on configuration
 	storageService, err := initStorageService(cfg)
@@ -41,28 +89,300 @@ func main() {
 	}
 	log.Printf("✓ Storage service initialized (provider: %s)", cfg.StorageProvider)
 
+	// Start background job queue
+	jobQueue := jobs.NewQueue(db.DB, cfg.JobWorkers, time.Duration(cfg.JobPollIntervalSeconds)*time.Second)
+	jobQueue.Start()
+	defer jobQueue.Stop()
+	log.Printf("✓ Job queue started (%d workers)", cfg.JobWorkers)
+
+	// Runtime settings: config provides the defaults, which admins can
+	// override without a restart
+	runtimeDefaults := settings.Settings{
+		QuietHoursStart:     cfg.QuietHoursStart,
+		QuietHoursEnd:       cfg.QuietHoursEnd,
+		TrashRetentionDays:  cfg.TrashRetentionDays,
+		FailedLoginLimit:    cfg.SecurityFailedLoginLimit,
+		SignupLimit:         cfg.SecuritySignupLimit,
+		CommentLimit:        cfg.SecurityCommentLimit,
+		SecurityAutoRespond: cfg.SecurityAutoRespond,
+		Features:            settings.DefaultFeatures(),
+	}
+	if err := runtimeDefaults.Validate(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	settingsStore := settings.NewStore(db.DB, runtimeDefaults)
+
+	// Start scheduled cleanup (expired stories, post archiving, lost and found expiry, trash)
+	cleanupService := jobs.NewCleanupService(db.DB, storageService, settingsStore)
+	cleanupService.Start()
+	defer cleanupService.Stop()
+
+	// Start academic term archival
+	termArchiveService := jobs.NewTermArchiveService(db.DB)
+	termArchiveService.Start()
+	defer termArchiveService.Stop()
+
+	emailSender := initEmailSender(cfg)
+
+	// Start weekly digest email
+	if cfg.DigestEnabled {
+		digestService := jobs.NewDigestService(db.DB, emailSender, cfg.PublicBaseURL)
+		digestService.Start()
+		defer digestService.Stop()
+	}
+
+	// Start outbox relay for notification delivery
+	notificationService := notifications.NewService(db.DB, emailSender, notifications.LogPushSender{})
+	notificationService.SetSettings(settingsStore)
+	notificationService.SetPublicBaseURL(cfg.PublicBaseURL)
+	capacityThresholds, err := notifications.ParseCapacityThresholds(cfg.CapacityAlertThresholds)
+	if err != nil {
+		log.Fatalf("Invalid capacity alert thresholds: %v", err)
+	}
+	notificationService.SetCapacityThresholds(capacityThresholds)
+	outboxRelay := outbox.NewRelay(db.DB)
+	notificationService.RegisterOutboxHandlers(outboxRelay)
+	webhookDispatcher := webhooks.NewDispatcher(db.DB)
+	webhookDispatcher.RegisterOutboxHandlers(outboxRelay)
+	razorpay := payments.NewClient()
+	payments.NewRefunder(db.DB, razorpay).RegisterOutboxHandlers(outboxRelay)
+	outboxRelay.Start()
+	defer outboxRelay.Stop()
+	log.Println("✓ Outbox relay started")
+
+	// Start outbound webhook delivery
+	webhookDispatcher.Start()
+	defer webhookDispatcher.Stop()
+
+	// Start delivery of pushes held back during quiet hours
+	quietHoursService := jobs.NewQuietHoursService(notificationService)
+	quietHoursService.Start()
+	defer quietHoursService.Stop()
+
+	// Relay chat events between API instances through Redis when configured
+	var redisClient *realtime.RedisClient
+	if cfg.RedisHost != "" {
+		redisClient = realtime.NewRedisClient(cfg.GetRedisAddr(), cfg.RedisPassword)
+		defer redisClient.Close()
+		// The hub rides out Redis outages, so an unreachable Redis fails
+		// startup only when required
+		if err := redisClient.Ping(context.Background()); err != nil {
+			if cfg.RedisRequired {
+				log.Fatalf("Redis at %s is unreachable: %v (check REDIS_HOST, REDIS_PORT and REDIS_PASSWORD)", cfg.GetRedisAddr(), err)
+			}
+			log.Printf("  → Redis at %s is unreachable, relaying realtime events to this instance until it is: %v", cfg.GetRedisAddr(), err)
+		} else {
+			log.Printf("✓ Realtime events relayed through Redis at %s", cfg.GetRedisAddr())
+		}
+	}
+	realtimeHub := realtime.NewHub(redisClient)
+	realtimeCtx, stopRealtime := context.WithCancel(context.Background())
+	defer stopRealtime()
+	go realtimeHub.Run(realtimeCtx)
+
 	// Setup router
-	router := api.NewRouter(db, authService, storageService, cfg.CORSAllowedOrigins)
+	router := api.NewRouter(db, authService, storageService, jobQueue, cfg.CORSAllowedOrigins)
+	router.SetSettings(settingsStore)
+	router.SetDebugEndpoints(cfg.DebugEndpoints)
+	if cfg.DebugEndpoints {
+		log.Println("✓ Profiler at /debug/pprof (admins only)")
+	}
+	router.SetMaintenanceForced(cfg.MaintenanceMode)
+	router.SetAuthProviders(authProviders)
+	router.SetGraphQLEnabled(cfg.GraphQLEnabled)
+	router.SetShareLinks(cfg.PublicBaseURL, cfg.AppLinkScheme)
+	router.SetContentFilter(moderation.NewFilter(
+		moderation.ParseWordList(cfg.ContentBannedWords),
+		moderation.ParseWordList(cfg.ContentFlaggedWords),
+		cfg.ContentMaxLinks,
+	))
+	imageChecker, err := initImageChecker(cfg)
+	if err != nil {
+		log.Fatalf("Failed to configure image moderation: %v", err)
+	}
+	router.SetImageChecker(imageChecker)
+	router.SetBodyLimits(middleware.BodyLimits{
+		JSON:       int64(cfg.MaxJSONBodyBytes),
+		Upload:     int64(cfg.MaxUploadBodyBytes),
+		BulkUpload: int64(cfg.MaxBulkUploadBodyBytes),
+		Video:      int64(cfg.MaxVideoBodyBytes),
+	})
+	router.SetStrictJSON(cfg.StrictJSON)
+	securityRules := security.DefaultRules()
+	securityRules.FailedLoginLimit = cfg.SecurityFailedLoginLimit
+	securityRules.SignupLimit = cfg.SecuritySignupLimit
+	securityRules.CommentLimit = cfg.SecurityCommentLimit
+	securityRules.AutoRespond = cfg.SecurityAutoRespond
+	router.SetSecurityRules(securityRules)
+	router.SetRealtimeHub(realtimeHub)
+	if local, ok := storageService.(*localstorage.LocalStorage); ok {
+		uploadsHandler, err := local.Handler()
+		if err != nil {
+			log.Fatalf("Failed to serve local uploads: %v", err)
+		}
+		router.SetUploadsHandler(uploadsHandler)
+	}
+	if proxies := cfg.GetTrustedProxies(); proxies != nil {
+		if err := router.SetTrustedProxies(proxies); err != nil {
+			log.Fatalf("Invalid trusted proxies: %v", err)
+		}
+		log.Printf("✓ Client addresses from X-Forwarded-For via %s", cfg.TrustedProxies)
+	}
+	if cfg.TrustedPlatform != "" {
+		if err := router.SetTrustedPlatform(cfg.TrustedPlatform); err != nil {
+			log.Fatalf("Invalid trusted platform: %v", err)
+		}
+		log.Printf("✓ Client addresses from %s", cfg.TrustedPlatform)
+	}
+	adminAllowlist, err := middleware.ParseCIDRs(cfg.AdminAllowedCIDRs)
+	if err != nil {
+		log.Fatalf("Invalid admin allowed networks: %v", err)
+	}
+	if len(adminAllowlist) > 0 {
+		router.SetAdminAllowlist(adminAllowlist)
+		log.Printf("✓ Admin API restricted to %s", cfg.AdminAllowedCIDRs)
+	}
+	if cfg.SessionCookies {
+		sameSite, err := middleware.ParseSameSite(cfg.SessionCookieSameSite)
+		if err != nil {
+			log.Fatalf("Invalid session cookie settings: %v", err)
+		}
+		router.SetSessionCookies(middleware.SessionCookies{
+			Domain:   cfg.SessionCookieDomain,
+			Secure:   cfg.SessionCookieSecure,
+			SameSite: sameSite,
+		})
+		log.Println("✓ Cookie sessions enabled for web clients")
+	}
 	router.Setup()
 
 	log.Println("✓ API routes configured")
 	log.Println("✓ Middleware initialized")
 
-	// Create initial admin user if configured
-	if cfg.InitialAdminEmail != "" && cfg.InitialAdminPassword != "" {
-		createInitialAdmin(db, authService, cfg)
+	// Create the initial admin and default data on first run
+	if err := runBootstrap(db, authService, cfg); err != nil {
+		log.Fatalf("Failed to bootstrap initial data: %v", err)
+	}
+
+	// Start the internal gRPC API for other campus services
+	if cfg.GRPCPort != "" {
+		lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+		if err != nil {
+			log.Fatalf("Failed to listen for gRPC: %v", err)
+		}
+		grpcServer := grpcapi.NewServer(db, cfg.GRPCAuthToken)
+		go func() {
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Printf("gRPC server stopped: %v", err)
+			}
+		}()
+		defer grpcServer.GracefulStop()
+		log.Printf("✓ gRPC API listening on :%s", cfg.GRPCPort)
 	}
 
 	// Start server
 	addr := fmt.Sprintf(":%s", cfg.Port)
-	log.Printf("🚀 Server running on http://localhost%s", addr)
-	log.Println("API Documentation: http://localhost" + addr + "/health")
+	scheme := "http"
+	if cfg.TLSEnabled() {
+		scheme = "https"
+	}
+	log.Printf("🚀 Server running on %s://localhost%s", scheme, addr)
+	log.Println("API Documentation: " + scheme + "://localhost" + addr + "/health")
 
-	if err := router.Run(addr); err != nil {
+	if err := runServer(cfg, addr, router.Handler()); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
 
+// runServer serves the API on addr: over TLS with HTTP/2 when a
+// certificate or Let's Encrypt domains are configured, otherwise over plain
+// HTTP/1.1, and HTTP/2 too if cleartext HTTP/2 is enabled
+func runServer(cfg *config.Config, addr string, handler http.Handler) error {
+	server := &http.Server{
+		Addr:    addr,
+		Handler: handler,
+		// No overall read timeout: uploads can take minutes
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       2 * time.Minute,
+	}
+
+	if !cfg.TLSEnabled() {
+		if cfg.HTTP2Cleartext {
+			server.Protocols = new(http.Protocols)
+			server.Protocols.SetHTTP1(true)
+			server.Protocols.SetUnencryptedHTTP2(true)
+			log.Println("✓ Cleartext HTTP/2 (h2c) enabled")
+		}
+		return server.ListenAndServe()
+	}
+
+	// Go serves HTTP/2 over TLS by default
+	server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	var redirect http.Handler = http.HandlerFunc(redirectToHTTPS(cfg.Port))
+
+	if cfg.TLSAutocertDomains != "" {
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.GetTLSAutocertDomains()...),
+			Cache:      autocert.DirCache(cfg.TLSAutocertCacheDir),
+			Email:      cfg.TLSAutocertEmail,
+		}
+		server.TLSConfig = manager.TLSConfig()
+		server.TLSConfig.MinVersion = tls.VersionTLS12
+		// Answers HTTP-01 challenges on the redirect port
+		redirect = manager.HTTPHandler(redirect)
+		log.Printf("✓ TLS certificates from Let's Encrypt for %s", cfg.TLSAutocertDomains)
+	} else {
+		log.Printf("✓ TLS certificate %s", cfg.TLSCertFile)
+	}
+
+	if cfg.HTTPRedirectPort != "" {
+		redirectServer := &http.Server{
+			Addr:              ":" + cfg.HTTPRedirectPort,
+			Handler:           redirect,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			if err := redirectServer.ListenAndServe(); err != nil {
+				log.Printf("HTTP redirect server stopped: %v", err)
+			}
+		}()
+		log.Printf("✓ Redirecting HTTP on :%s to HTTPS", cfg.HTTPRedirectPort)
+	}
+
+	// With autocert, the certificate comes from TLSConfig
+	return server.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile)
+}
+
+// prefixWriter prefixes each write, for gin's request log, which writes a
+// line at a time
+type prefixWriter struct {
+	w      io.Writer
+	prefix []byte
+}
+
+func (p prefixWriter) Write(b []byte) (int, error) {
+	if _, err := p.w.Write(append(p.prefix[:len(p.prefix):len(p.prefix)], b...)); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// redirectToHTTPS sends plain HTTP requests to the same URL over HTTPS on
+// the API's port
+func redirectToHTTPS(port string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if port != "443" {
+			host = net.JoinHostPort(host, port)
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	}
+}
+
 // initStorageService creates the appropriate storage service based on configuration
 func initStorageService(cfg *config.Config) (localstorage.StorageService, error) {
 	switch cfg.StorageProvider {
@@ -90,30 +410,94 @@ func initStorageService(cfg *config.Config) (localstorage.StorageService, error)
 	}
 }
 
-func createInitialAdmin(db *database.DB, authService *auth.Service, cfg *config.Config) {
-	// Check if admin already exists
-	var exists bool
-	err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)", cfg.InitialAdminEmail).Scan(&exists)
-	if err != nil || exists {
-		return
+// initEmailSender returns an SMTP sender when configured, otherwise a logging sender
+func initEmailSender(cfg *config.Config) notifications.EmailSender {
+	if cfg.SMTPHost == "" {
+		log.Println("  → SMTP not configured, emails will be logged")
+		return notifications.LogEmailSender{}
 	}
+	log.Printf("  → SMTP relay: %s:%s", cfg.SMTPHost, cfg.SMTPPort)
+	return notifications.NewSMTPEmailSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+}
 
-	// Hash password
-	passwordHash, err := authService.HashPassword(cfg.InitialAdminPassword)
-	if err != nil {
-		log.Printf("Warning: Failed to create initial admin: %v", err)
-		return
+// initImageChecker returns the configured checker for post and story
+// images, or nil to publish them unchecked
+func initImageChecker(cfg *config.Config) (moderation.ImageChecker, error) {
+	switch cfg.ImageModerationProvider {
+	case "":
+		return nil, nil
+	case "vision":
+		if cfg.VisionAPIKey == "" {
+			return nil, fmt.Errorf("VISION_API_KEY is required for the vision provider")
+		}
+		log.Println("  → Image moderation: Cloud Vision SafeSearch")
+		return moderation.NewVisionChecker(cfg.VisionAPIKey), nil
+	case "hook":
+		if cfg.ImageModerationHookURL == "" {
+			return nil, fmt.Errorf("IMAGE_MODERATION_HOOK_URL is required for the hook provider")
+		}
+		log.Printf("  → Image moderation: %s", cfg.ImageModerationHookURL)
+		return moderation.NewHookChecker(cfg.ImageModerationHookURL), nil
 	}
+	return nil, fmt.Errorf("unknown provider %q", cfg.ImageModerationProvider)
+}
 
-	// Create admin user
-	_, err = db.Exec(`
-		INSERT INTO users (email, password_hash, full_name, role)
-		VALUES ($1, $2, $3, $4)
-	`, cfg.InitialAdminEmail, passwordHash, "Initial Admin", "admin")
+// initAuthProviders sets up the enabled login methods
+func initAuthProviders(cfg *config.Config) (auth.Providers, error) {
+	providers := auth.Providers{Enabled: cfg.GetAuthProviders()}
 
-	if err != nil {
-		log.Printf("Warning: Failed to create initial admin: %v", err)
-	} else {
-		log.Printf("✓ Created initial admin user: %s", cfg.InitialAdminEmail)
+	if providers.IsEnabled("ldap") {
+		groupRoles, err := auth.ParseGroupRoles(cfg.LDAPGroupRoles)
+		if err != nil {
+			return providers, err
+		}
+		providers.LDAP, err = auth.NewLDAPProvider(auth.LDAPConfig{
+			URL:            cfg.LDAPURL,
+			StartTLS:       cfg.LDAPStartTLS,
+			AllowInsecure:  cfg.LDAPAllowInsecure,
+			BindDNPatterns: cfg.GetLDAPBindDNPatterns(),
+			BaseDN:         cfg.LDAPBaseDN,
+			UserAttr:       cfg.LDAPUserAttr,
+			EmailDomain:    cfg.LDAPEmailDomain,
+			GroupRoles:     groupRoles,
+		})
+		if err != nil {
+			return providers, err
+		}
+	}
+
+	if providers.IsEnabled("oidc") {
+		roleMap, err := auth.ParseGroupRoles(cfg.OIDCRoleMap)
+		if err != nil {
+			return providers, err
+		}
+		providers.OIDC, err = auth.NewOIDCProvider(auth.OIDCConfig{
+			IssuerURL:    cfg.OIDCIssuerURL,
+			ClientID:     cfg.OIDCClientID,
+			ClientSecret: cfg.OIDCClientSecret,
+			RoleClaim:    cfg.OIDCRoleClaim,
+			RoleMap:      roleMap,
+			TrustEmail:   cfg.OIDCTrustEmail,
+		})
+		if err != nil {
+			return providers, err
+		}
+	}
+	return providers, nil
+}
+
+// runBootstrap creates the initial admin and seeds default data if configured
+func runBootstrap(db *database.DB, authService *auth.Service, cfg *config.Config) error {
+	bootstrapCfg := bootstrap.Config{
+		AdminEmail:    cfg.InitialAdminEmail,
+		AdminPassword: cfg.InitialAdminPassword,
+	}
+	if cfg.BootstrapFile != "" {
+		seed, err := bootstrap.LoadSeed(cfg.BootstrapFile)
+		if err != nil {
+			return err
+		}
+		bootstrapCfg.Seed = seed
 	}
+	return bootstrap.NewService(db.DB, authService).Run(context.Background(), bootstrapCfg)
 }
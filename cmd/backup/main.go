@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/robfig/cron/v3"
+	"github.com/yourusername/college-event-backend/internal/backup"
+	localstorage "github.com/yourusername/college-event-backend/internal/storage"
+	"github.com/yourusername/college-event-backend/pkg/config"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// How often backups requested by admins are picked up
+const requestPollInterval = 30 * time.Second
+
+func main() {
+	schedule := flag.Bool("schedule", false,
+		"keep running: back up on BACKUP_SCHEDULE and whenever an admin requests one, instead of once")
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	if _, err := exec.LookPath("pg_dump"); err != nil {
+		log.Fatalf("pg_dump not found; install the PostgreSQL client tools: %v", err)
+	}
+
+	// Connect to database
+	db, err := database.Connect(cfg.GetDatabaseDSN(), cfg.GetDatabasePoolConfig())
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+	log.Println("✓ Connected to database")
+
+	storageService, err := initBackupStorage(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize backup storage: %v", err)
+	}
+	log.Printf("✓ Backup storage initialized (provider: %s, keeping %d)", cfg.StorageProvider, cfg.BackupRetention)
+
+	service := backup.NewService(db.DB, storageService, cfg.StorageProvider, cfg.BackupRetention, pgDump(cfg))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !*schedule {
+		if err := service.Run(ctx, backup.SourceManual); err != nil {
+			log.Fatalf("Backup failed: %v", err)
+		}
+		return
+	}
+
+	c := cron.New()
+	if _, err := c.AddFunc(cfg.BackupSchedule, func() {
+		if err := service.Run(ctx, backup.SourceSchedule); err != nil {
+			log.Printf("[CRON] Scheduled backup failed: %v", err)
+		}
+	}); err != nil {
+		log.Fatalf("Invalid BACKUP_SCHEDULE %q: %v", cfg.BackupSchedule, err)
+	}
+	c.Start()
+	log.Printf("✓ Backing up on schedule %q", cfg.BackupSchedule)
+	log.Printf("  → Taking requested backups every %s", requestPollInterval)
+
+	ticker := time.NewTicker(requestPollInterval)
+	defer ticker.Stop()
+	for {
+		if err := service.RunRequested(ctx); err != nil {
+			log.Printf("[BACKUP] Requested backup failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			// Wait for a scheduled backup in progress, which ctx cancels
+			<-c.Stop().Done()
+			log.Println("✓ Backup service stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// initBackupStorage creates the storage backups are written to: the
+// configured provider, but never the public uploads bucket or directory
+func initBackupStorage(cfg *config.Config) (localstorage.StorageService, error) {
+	switch cfg.StorageProvider {
+	case "gcs":
+		if cfg.BackupGCSBucket == "" {
+			return nil, fmt.Errorf("BACKUP_GCS_BUCKET is required when STORAGE_PROVIDER=gcs")
+		}
+		if cfg.BackupGCSBucket == cfg.GCSBucketName {
+			return nil, fmt.Errorf("BACKUP_GCS_BUCKET must be a private bucket, not the public GCS_BUCKET_NAME")
+		}
+		client, err := storage.NewClient(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GCS client: %w", err)
+		}
+		log.Printf("  → GCS bucket: %s", cfg.BackupGCSBucket)
+		return localstorage.NewGCSStorage(client, cfg.BackupGCSBucket, ""), nil
+
+	default:
+		log.Printf("  → Local directory: %s", cfg.BackupDir)
+		return localstorage.NewLocalStorage(cfg.BackupDir, ""), nil
+	}
+}
+
+// pgDump runs pg_dump in its custom format, which pg_restore restores from,
+// selectively and in parallel if need be. The connection is passed in the
+// environment, keeping the password off the command line.
+func pgDump(cfg *config.Config) backup.DumpFunc {
+	return func(ctx context.Context, w io.Writer) error {
+		cmd := exec.CommandContext(ctx, "pg_dump", "--format=custom", "--no-password")
+		cmd.Env = append(os.Environ(),
+			"PGHOST="+cfg.DBHost,
+			"PGPORT="+cfg.DBPort,
+			"PGUSER="+cfg.DBUser,
+			"PGPASSWORD="+cfg.DBPassword,
+			"PGDATABASE="+cfg.DBName,
+			"PGSSLMODE="+cfg.DBSSLMode,
+		)
+		cmd.Stdout = w
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("pg_dump failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// acknowledgeMarker in a comment before a destructive statement marks it as
+// reviewed, so it runs without --allow-destructive. Every migration runs on
+// every deploy, so a destructive statement that has shipped needs it.
+const acknowledgeMarker = "lint: destructive-ok"
+
+// statement is one SQL statement of a migration
+type statement struct {
+	Line int    // Where it starts in the file
+	SQL  string // As written, from its first line of code
+	// normalized is the SQL without comments, with whitespace collapsed and
+	// upper-cased, for matching
+	normalized   string
+	acknowledged bool
+}
+
+// finding is a statement that isn't safe while the previous release (the
+// "blue" deployment) still runs against the same database
+type finding struct {
+	File   string
+	Line   int
+	Rule   string
+	Advice string
+	// Acknowledged findings were reviewed in the migration itself
+	Acknowledged bool
+}
+
+func (f finding) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s", f.File, f.Line, f.Rule, f.Advice)
+}
+
+// destructiveRules are the statements that lose data, or break the previous
+// release's queries, with how to get the same result safely
+var destructiveRules = []struct {
+	name   string
+	match  func(normalized string) bool
+	advice string
+}{
+	{"DROP TABLE", regexp.MustCompile(`^DROP (TABLE|SCHEMA|VIEW|MATERIALIZED VIEW|TYPE) `).MatchString,
+		"the previous release may still use it; drop it a release after the code stops using it"},
+	{"DROP COLUMN", dropsColumn,
+		"the previous release still reads it; stop using it first and drop it in a later release"},
+	{"column type change", regexp.MustCompile(`^ALTER TABLE .* ALTER (COLUMN )?"?\w+"? (SET DATA )?TYPE `).MatchString,
+		"rewrites the table under an exclusive lock and breaks code expecting the old type; add a new column and backfill it instead"},
+	{"RENAME", regexp.MustCompile(`^ALTER TABLE .* RENAME `).MatchString,
+		"the previous release uses the old name; add the new column or table, copy, and drop the old one later"},
+	{"TRUNCATE", regexp.MustCompile(`^TRUNCATE `).MatchString,
+		"deletes every row"},
+}
+
+var dropRe = regexp.MustCompile(`[ ,]DROP (COLUMN )?(IF EXISTS )?"?(\w+)"?`)
+
+// dropsColumn reports whether an ALTER TABLE drops a column, as opposed to a
+// constraint, default or NOT NULL, where COLUMN is optional
+func dropsColumn(normalized string) bool {
+	if !strings.HasPrefix(normalized, "ALTER TABLE ") {
+		return false
+	}
+	for _, m := range dropRe.FindAllStringSubmatch(normalized, -1) {
+		if m[1] != "" {
+			return true
+		}
+		switch m[3] {
+		case "CONSTRAINT", "DEFAULT", "NOT", "IDENTITY", "EXPRESSION":
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// lintMigration returns the destructive statements in a migration
+func lintMigration(file string, statements []statement) []finding {
+	var findings []finding
+	for _, s := range statements {
+		for _, rule := range destructiveRules {
+			if rule.match(s.normalized) {
+				findings = append(findings, finding{
+					File:         file,
+					Line:         s.Line,
+					Rule:         rule.name,
+					Advice:       rule.advice,
+					Acknowledged: s.acknowledged,
+				})
+				break
+			}
+		}
+	}
+	return findings
+}
+
+// lockRules give the strongest table lock a statement takes, and what it
+// blocks, by the start of the statement. The first match wins.
+var lockRules = []struct {
+	re   *regexp.Regexp
+	lock string
+}{
+	{regexp.MustCompile(`^CREATE (UNIQUE )?INDEX CONCURRENTLY `), "SHARE UPDATE EXCLUSIVE on the table (reads and writes continue)"},
+	{regexp.MustCompile(`^CREATE (UNIQUE )?INDEX `), "SHARE on the table (blocks writes while the index builds)"},
+	{regexp.MustCompile(`^CREATE (TABLE|SEQUENCE|TYPE|EXTENSION|SCHEMA|OR REPLACE FUNCTION|FUNCTION|OR REPLACE VIEW|VIEW) `), "none on existing tables"},
+	{regexp.MustCompile(`^CREATE (OR REPLACE )?TRIGGER `), "SHARE ROW EXCLUSIVE on the table (blocks writes, briefly)"},
+	{regexp.MustCompile(`^ALTER TABLE .* ALTER (COLUMN )?"?\w+"? (SET DATA )?TYPE `), "ACCESS EXCLUSIVE on the table while it is rewritten (blocks reads and writes)"},
+	{regexp.MustCompile(`^ALTER TABLE .* SET NOT NULL`), "ACCESS EXCLUSIVE on the table while every row is checked (blocks reads and writes)"},
+	{regexp.MustCompile(`^ALTER TABLE .* ADD (CONSTRAINT \S+ )?(FOREIGN KEY|CHECK).* NOT VALID`), "ACCESS EXCLUSIVE on the table, briefly (no validation scan)"},
+	{regexp.MustCompile(`^ALTER TABLE .* ADD (CONSTRAINT \S+ )?(FOREIGN KEY|CHECK|UNIQUE|PRIMARY KEY)`), "ACCESS EXCLUSIVE on the table while existing rows are validated (blocks reads and writes)"},
+	{regexp.MustCompile(`^(ALTER TABLE|DROP TABLE|DROP TRIGGER|TRUNCATE|LOCK TABLE) `), "ACCESS EXCLUSIVE on the table, briefly (blocks reads and writes)"},
+	{regexp.MustCompile(`^DROP INDEX CONCURRENTLY `), "SHARE UPDATE EXCLUSIVE on the table (reads and writes continue)"},
+	{regexp.MustCompile(`^DROP INDEX `), "ACCESS EXCLUSIVE on the table, briefly (blocks reads and writes)"},
+	{regexp.MustCompile(`^(INSERT|UPDATE|DELETE) `), "ROW EXCLUSIVE on the table (row locks on the rows written)"},
+	{regexp.MustCompile(`^(DROP FUNCTION|DROP TYPE|DROP VIEW|COMMENT|DO |SELECT |GRANT |REVOKE )`), "none on tables (beyond what a DO block runs)"},
+}
+
+// lockExpectation describes the locks a statement takes
+func lockExpectation(s statement) string {
+	for _, rule := range lockRules {
+		if rule.re.MatchString(s.normalized) {
+			return rule.lock
+		}
+	}
+	return "unknown; check the PostgreSQL documentation"
+}
+
+// printDryRun writes each migration's statements, with the locks they take,
+// and its destructive statements
+func printDryRun(w io.Writer, file string, statements []statement, findings []finding) {
+	fmt.Fprintf(w, "-- ===== %s =====\n", file)
+	flagged := map[int]finding{}
+	for _, f := range findings {
+		flagged[f.Line] = f
+	}
+	for _, s := range statements {
+		fmt.Fprintf(w, "-- line %d, lock: %s\n", s.Line, lockExpectation(s))
+		if f, ok := flagged[s.Line]; ok {
+			note := "needs --allow-destructive"
+			if f.Acknowledged {
+				note = "acknowledged"
+			}
+			fmt.Fprintf(w, "-- DESTRUCTIVE (%s, %s): %s\n", f.Rule, note, f.Advice)
+		}
+		fmt.Fprintf(w, "%s;\n\n", s.SQL)
+	}
+}
+
+// splitStatements splits a migration into statements. Semicolons inside
+// strings, quoted identifiers, comments and dollar-quoted bodies (functions,
+// DO blocks) don't end a statement.
+func splitStatements(sql string) []statement {
+	var statements []statement
+	var raw, norm strings.Builder
+	var comments strings.Builder
+	line, startLine := 1, 0
+
+	flush := func() {
+		text := strings.TrimSpace(raw.String())
+		normalized := strings.ToUpper(strings.Join(strings.Fields(norm.String()), " "))
+		if normalized != "" {
+			statements = append(statements, statement{
+				Line:         startLine,
+				SQL:          text,
+				normalized:   normalized,
+				acknowledged: strings.Contains(comments.String(), acknowledgeMarker),
+			})
+		}
+		raw.Reset()
+		norm.Reset()
+		comments.Reset()
+		startLine = 0
+	}
+	// write adds code (not comments) to the current statement
+	write := func(s string) {
+		if startLine == 0 && strings.TrimSpace(s) != "" {
+			startLine = line
+		}
+		if startLine != 0 {
+			raw.WriteString(s)
+		}
+		norm.WriteString(s)
+	}
+
+	for i := 0; i < len(sql); {
+		rest := sql[i:]
+		switch {
+		case strings.HasPrefix(rest, "--"):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			comments.WriteString(rest[:end] + "\n")
+			if startLine != 0 {
+				raw.WriteString(rest[:end])
+			}
+			norm.WriteString(" ")
+			i += end
+
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				end = len(rest)
+			} else {
+				end += 4
+			}
+			comments.WriteString(rest[:end] + "\n")
+			if startLine != 0 {
+				raw.WriteString(rest[:end])
+			}
+			norm.WriteString(" ")
+			line += strings.Count(rest[:end], "\n")
+			i += end
+
+		case rest[0] == '\'' || rest[0] == '"':
+			end := quotedEnd(rest, rest[0])
+			write(rest[:end])
+			line += strings.Count(rest[:end], "\n")
+			i += end
+
+		case rest[0] == '$':
+			if tag := dollarTag(rest); tag != "" {
+				end := strings.Index(rest[len(tag):], tag)
+				if end < 0 {
+					end = len(rest)
+				} else {
+					end += 2 * len(tag)
+				}
+				write(rest[:end])
+				line += strings.Count(rest[:end], "\n")
+				i += end
+				continue
+			}
+			write("$")
+			i++
+
+		case rest[0] == ';':
+			flush()
+			i++
+
+		default:
+			if rest[0] == '\n' {
+				line++
+			}
+			write(rest[:1])
+			i++
+		}
+	}
+	flush()
+	return statements
+}
+
+// quotedEnd returns the length of the string or quoted identifier at the
+// start of s, where a doubled quote is an escaped one
+func quotedEnd(s string, quote byte) int {
+	for i := 1; i < len(s); i++ {
+		if s[i] == quote {
+			if i+1 < len(s) && s[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+var dollarTagRe = regexp.MustCompile(`^\$[A-Za-z_]*\$`)
+
+// dollarTag returns the dollar-quote tag ($$ or $name$) at the start of s,
+// or "" for a positional parameter like $1
+func dollarTag(s string) string {
+	return dollarTagRe.FindString(s)
+}
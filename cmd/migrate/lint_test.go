@@ -0,0 +1,88 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	sql := `-- Migration 999: Test
+CREATE TABLE t (id INT, note TEXT DEFAULT 'a;b');
+
+CREATE OR REPLACE FUNCTION f() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE t SET note = 'x'; -- inside the body
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+/* block; comment */
+UPDATE t SET id = $1`
+
+	statements := splitStatements(sql)
+	if len(statements) != 3 {
+		t.Fatalf("got %d statements, want 3: %+v", len(statements), statements)
+	}
+	for i, want := range []struct {
+		line   int
+		prefix string
+	}{
+		{2, "CREATE TABLE T"},
+		{4, "CREATE OR REPLACE FUNCTION F()"},
+		{11, "UPDATE T SET ID = $1"},
+	} {
+		if statements[i].Line != want.line || !strings.HasPrefix(statements[i].normalized, want.prefix) {
+			t.Errorf("statement %d = line %d %q, want line %d %q...",
+				i, statements[i].Line, statements[i].normalized, want.line, want.prefix)
+		}
+	}
+}
+
+func TestLintMigration(t *testing.T) {
+	destructive := map[string]string{
+		"DROP TABLE old_events":                                  "DROP TABLE",
+		"ALTER TABLE events DROP COLUMN venue":                   "DROP COLUMN",
+		"ALTER TABLE events DROP IF EXISTS venue CASCADE":        "DROP COLUMN",
+		"ALTER TABLE events ADD COLUMN a INT, DROP COLUMN b":     "DROP COLUMN",
+		"ALTER TABLE events ALTER COLUMN capacity TYPE BIGINT":   "column type change",
+		"ALTER TABLE events ALTER capacity SET DATA TYPE BIGINT": "column type change",
+		"ALTER TABLE events RENAME COLUMN venue TO location":     "RENAME",
+		"TRUNCATE audit_log":                                     "TRUNCATE",
+	}
+	for sql, rule := range destructive {
+		findings := lintMigration("999_test.sql", splitStatements(sql))
+		if len(findings) != 1 || findings[0].Rule != rule {
+			t.Errorf("%q: got %v, want a %s finding", sql, findings, rule)
+		}
+	}
+
+	safe := []string{
+		"ALTER TABLE content_flags DROP CONSTRAINT IF EXISTS content_flags_content_type_check",
+		"ALTER TABLE events ALTER COLUMN venue DROP DEFAULT",
+		"ALTER TABLE events ALTER COLUMN venue DROP NOT NULL",
+		"ALTER TABLE events ADD COLUMN IF NOT EXISTS venue TEXT",
+		"DROP TRIGGER IF EXISTS update_events_updated_at ON events",
+		"DROP INDEX IF EXISTS idx_events_venue",
+		"CREATE INDEX IF NOT EXISTS idx_events_drop ON events(drop_reason)",
+	}
+	for _, sql := range safe {
+		if findings := lintMigration("999_test.sql", splitStatements(sql)); len(findings) != 0 {
+			t.Errorf("%q: got %v, want no findings", sql, findings)
+		}
+	}
+}
+
+func TestLintMigrationAcknowledged(t *testing.T) {
+	sql := `CREATE TABLE a (id INT);
+-- Unused since 041. lint: destructive-ok
+ALTER TABLE events DROP COLUMN venue;
+ALTER TABLE events DROP COLUMN room;`
+
+	findings := lintMigration("999_test.sql", splitStatements(sql))
+	if len(findings) != 2 {
+		t.Fatalf("got %d findings, want 2", len(findings))
+	}
+	if !findings[0].Acknowledged || findings[1].Acknowledged {
+		t.Errorf("acknowledged = %v, %v; want only the marked statement",
+			findings[0].Acknowledged, findings[1].Acknowledged)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -13,20 +14,11 @@ import (
 )
 
 func main() {
-	// Load configuration
-	cfg, err := config.Load()
-	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
-	}
-
-	// Connect to database
-	db, err := database.Connect(cfg.GetDatabaseDSN())
-	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
-	}
-	defer db.Close()
-
-	log.Println("✓ Connected to database")
+	allowDestructive := flag.Bool("allow-destructive", false,
+		"run migrations that drop, rename or retype columns or tables")
+	dryRun := flag.Bool("dry-run", false,
+		"print the SQL each migration runs and the locks it takes, without connecting")
+	flag.Parse()
 
 	// Read all migration files
 	migrationDir := "migrations"
@@ -44,16 +36,67 @@ func main() {
 	}
 	sort.Strings(migrationFiles)
 
-	// Execute each migration
+	migrations := make(map[string]string, len(migrationFiles))
 	for _, fileName := range migrationFiles {
-		migrationPath := filepath.Join(migrationDir, fileName)
-		migration, err := os.ReadFile(migrationPath)
+		migration, err := os.ReadFile(filepath.Join(migrationDir, fileName))
 		if err != nil {
 			log.Fatalf("Failed to read migration file %s: %v", fileName, err)
 		}
+		migrations[fileName] = string(migration)
+	}
+
+	// Lint for statements that would break the previous release while it
+	// still runs (blue/green deploys), or lose data
+	var unacknowledged []finding
+	for _, fileName := range migrationFiles {
+		statements := splitStatements(migrations[fileName])
+		findings := lintMigration(fileName, statements)
+		if *dryRun {
+			printDryRun(os.Stdout, fileName, statements, findings)
+		}
+		for _, f := range findings {
+			if !f.Acknowledged {
+				unacknowledged = append(unacknowledged, f)
+			}
+		}
+	}
+	if *dryRun {
+		if len(unacknowledged) > 0 {
+			fmt.Printf("-- %d destructive statement(s) need --allow-destructive, or a %q comment once reviewed\n",
+				len(unacknowledged), "-- "+acknowledgeMarker)
+		}
+		return
+	}
+	if len(unacknowledged) > 0 {
+		for _, f := range unacknowledged {
+			log.Printf("✗ %s", f)
+		}
+		if !*allowDestructive {
+			log.Fatalf("Refusing to run %d destructive statement(s): review them and rerun with --allow-destructive, or mark them with a %q comment",
+				len(unacknowledged), "-- "+acknowledgeMarker)
+		}
+		log.Printf("  → Running %d destructive statement(s) (--allow-destructive)", len(unacknowledged))
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
 
+	// Connect to database
+	db, err := database.Connect(cfg.GetDatabaseDSN(), cfg.GetDatabasePoolConfig())
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	log.Println("✓ Connected to database")
+
+	// Execute each migration
+	for _, fileName := range migrationFiles {
 		// Execute migration (ignore errors if schema already exists)
-		_, err = db.Exec(string(migration))
+		_, err = db.Exec(migrations[fileName])
 		if err != nil {
 			// Check if it's just a "already exists" error
 			if strings.Contains(err.Error(), "already exists") ||
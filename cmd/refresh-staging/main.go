@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/lib/pq"
+	"github.com/yourusername/college-event-backend/internal/anonymize"
+	"github.com/yourusername/college-event-backend/pkg/config"
+	"github.com/yourusername/college-event-backend/pkg/database"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Refreshes staging's database with a copy of production's, with personal
+// data scrambled. Run it with staging's configuration: DB_* is the database
+// replaced, REFRESH_SOURCE_URL the one copied.
+//
+// The copy is restored and scrubbed in a scratch database next to staging's,
+// which replaces staging's only once scrubbed, so staging never serves
+// unscrubbed data, and a failed refresh leaves it as it was.
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	if cfg.Env == "production" {
+		log.Fatalf("Refusing to replace the database with ENV=production; run with staging's configuration")
+	}
+	if cfg.RefreshSourceURL == "" {
+		log.Fatalf("REFRESH_SOURCE_URL, the database to copy, is required")
+	}
+	if len(cfg.RefreshScrambleKey) < 32 {
+		log.Fatalf("REFRESH_SCRAMBLE_KEY of at least 32 characters is required")
+	}
+	source, err := sourceEnv(cfg.RefreshSourceURL)
+	if err != nil {
+		log.Fatalf("Invalid REFRESH_SOURCE_URL: %v", err)
+	}
+	if source["PGHOST"] == cfg.DBHost && source["PGDATABASE"] == cfg.DBName {
+		log.Fatalf("REFRESH_SOURCE_URL is the database being replaced (DB_HOST and DB_NAME)")
+	}
+	for _, tool := range []string{"pg_dump", "pg_restore"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			log.Fatalf("%s not found; install the PostgreSQL client tools: %v", tool, err)
+		}
+	}
+
+	// Without a password, nobody can log in with one
+	passwordHash := "!"
+	if cfg.RefreshPassword != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(cfg.RefreshPassword), bcrypt.DefaultCost)
+		if err != nil {
+			log.Fatalf("Failed to hash REFRESH_PASSWORD: %v", err)
+		}
+		passwordHash = string(hash)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := refresh(ctx, cfg, source, passwordHash); err != nil {
+		log.Fatalf("Refresh failed: %v", err)
+	}
+	log.Printf("✓ %s refreshed. Run the migrations if staging is ahead of production.", cfg.DBName)
+	if cfg.RefreshPassword == "" {
+		log.Println("  → REFRESH_PASSWORD not set: password logins are disabled")
+	}
+}
+
+// refresh copies the source into a scratch database, scrubs it, and
+// replaces staging's database with it
+func refresh(ctx context.Context, cfg *config.Config, source map[string]string, passwordHash string) error {
+	// Databases are created, dropped and renamed from the maintenance
+	// database
+	adminCfg := *cfg
+	adminCfg.DBName = "postgres"
+	admin, err := database.Connect(adminCfg.GetDatabaseDSN(), cfg.GetDatabasePoolConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer admin.Close()
+	log.Printf("✓ Connected to %s", cfg.DBHost)
+
+	scratch := cfg.DBName + "_refresh"
+	if _, err := admin.ExecContext(ctx, "DROP DATABASE IF EXISTS "+pq.QuoteIdentifier(scratch)+" WITH (FORCE)"); err != nil {
+		return fmt.Errorf("failed to drop leftover %s: %w", scratch, err)
+	}
+	if _, err := admin.ExecContext(ctx, "CREATE DATABASE "+pq.QuoteIdentifier(scratch)); err != nil {
+		return fmt.Errorf("failed to create %s: %w", scratch, err)
+	}
+	swapped := false
+	defer func() {
+		if swapped {
+			return
+		}
+		// It may hold unscrubbed data
+		if _, dropErr := admin.Exec("DROP DATABASE IF EXISTS " + pq.QuoteIdentifier(scratch) + " WITH (FORCE)"); dropErr != nil {
+			log.Printf("Failed to drop %s, which may hold unscrubbed data; drop it by hand: %v", scratch, dropErr)
+		}
+	}()
+
+	log.Printf("Copying %s into %s...", source["PGDATABASE"], scratch)
+	if err := copyDatabase(ctx, source, scratch, cfg); err != nil {
+		return err
+	}
+	log.Println("✓ Copied")
+
+	scratchCfg := *cfg
+	scratchCfg.DBName = scratch
+	db, err := database.Connect(scratchCfg.GetDatabaseDSN(), cfg.GetDatabasePoolConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", scratch, err)
+	}
+	log.Println("Scrubbing personal data...")
+	err = anonymize.Run(ctx, db.DB, anonymize.NewScrambler(cfg.RefreshScrambleKey), passwordHash)
+	db.Close()
+	if err != nil {
+		return err
+	}
+	log.Println("✓ Scrubbed")
+
+	// Disconnects staging's API instances; they reconnect to the new copy
+	if _, err := admin.ExecContext(ctx, "DROP DATABASE IF EXISTS "+pq.QuoteIdentifier(cfg.DBName)+" WITH (FORCE)"); err != nil {
+		return fmt.Errorf("failed to drop %s: %w", cfg.DBName, err)
+	}
+	// The scrubbed copy is kept if this fails
+	swapped = true
+	if _, err := admin.ExecContext(ctx, "ALTER DATABASE "+pq.QuoteIdentifier(scratch)+" RENAME TO "+pq.QuoteIdentifier(cfg.DBName)); err != nil {
+		return fmt.Errorf("failed to rename %s to %s; rename it by hand: %w", scratch, cfg.DBName, err)
+	}
+	return nil
+}
+
+// sourceEnv turns a postgres:// URL into libpq's environment variables, so
+// the password stays off pg_dump's command line
+func sourceEnv(raw string) (map[string]string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+		return nil, fmt.Errorf("want a postgres:// URL")
+	}
+	env := map[string]string{
+		"PGHOST":     u.Hostname(),
+		"PGPORT":     u.Port(),
+		"PGUSER":     u.User.Username(),
+		"PGDATABASE": strings.TrimPrefix(u.Path, "/"),
+		"PGSSLMODE":  u.Query().Get("sslmode"),
+	}
+	env["PGPASSWORD"], _ = u.User.Password()
+	if env["PGHOST"] == "" || env["PGDATABASE"] == "" {
+		return nil, fmt.Errorf("want a host and a database name")
+	}
+	return env, nil
+}
+
+// copyDatabase pipes pg_dump of the source into pg_restore into the
+// (empty) scratch database
+func copyDatabase(ctx context.Context, source map[string]string, scratch string, cfg *config.Config) error {
+	dump := exec.CommandContext(ctx, "pg_dump", "--format=custom", "--no-password")
+	dump.Env = os.Environ()
+	for k, v := range source {
+		if v != "" {
+			dump.Env = append(dump.Env, k+"="+v)
+		}
+	}
+
+	restore := exec.CommandContext(ctx, "pg_restore", "--no-owner", "--no-privileges", "--exit-on-error",
+		"--no-password", "--dbname="+scratch)
+	restore.Env = append(os.Environ(),
+		"PGHOST="+cfg.DBHost,
+		"PGPORT="+cfg.DBPort,
+		"PGUSER="+cfg.DBUser,
+		"PGPASSWORD="+cfg.DBPassword,
+		"PGSSLMODE="+cfg.DBSSLMode,
+	)
+
+	var dumpErr, restoreErr bytes.Buffer
+	dump.Stderr = &dumpErr
+	restore.Stderr = &restoreErr
+	pipe, err := dump.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	restore.Stdin = pipe
+
+	if err := dump.Start(); err != nil {
+		return fmt.Errorf("failed to start pg_dump: %w", err)
+	}
+	if err := restore.Run(); err != nil {
+		dump.Process.Kill()
+		dump.Wait()
+		return fmt.Errorf("pg_restore failed: %w: %s", err, strings.TrimSpace(restoreErr.String()))
+	}
+	if err := dump.Wait(); err != nil {
+		return fmt.Errorf("pg_dump failed: %w: %s", err, strings.TrimSpace(dumpErr.String()))
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package anonymize
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+// scrub scrambles one column, or a value within a JSONB column, of every
+// row of a table
+type scrub struct {
+	table  string
+	column string // Or an expression selecting the value
+	// set assigns $2, the scrambled value; empty sets column
+	set      string
+	scramble func(*Scrambler, string) string
+}
+
+// scrubs are the personal values and secrets scrambled
+var scrubs = []scrub{
+	{table: "users", column: "email", scramble: (*Scrambler).Email},
+	{table: "users", column: "full_name", scramble: (*Scrambler).Name},
+	{table: "user_identities", column: "email", scramble: (*Scrambler).Email},
+	{table: "user_identities", column: "subject", scramble: (*Scrambler).Token},
+	{table: "clubs", column: "email", scramble: (*Scrambler).Email},
+	{table: "clubs", column: "phone", scramble: (*Scrambler).Phone},
+	{table: "house_roles", column: "member_name", scramble: (*Scrambler).Name},
+
+	{table: "event_payments", column: "razorpay_order_id", scramble: (*Scrambler).PaymentID},
+	{table: "event_payments", column: "razorpay_payment_id", scramble: (*Scrambler).PaymentID},
+	{table: "event_payments", column: "razorpay_refund_id", scramble: (*Scrambler).PaymentID},
+	{table: "event_payments", column: "razorpay_signature", scramble: (*Scrambler).Token},
+	{table: "fest_passes", column: "razorpay_order_id", scramble: (*Scrambler).PaymentID},
+	{table: "fest_passes", column: "razorpay_payment_id", scramble: (*Scrambler).PaymentID},
+	{table: "fest_passes", column: "razorpay_signature", scramble: (*Scrambler).Token},
+	{table: "donations", column: "razorpay_order_id", scramble: (*Scrambler).PaymentID},
+	{table: "donations", column: "razorpay_payment_id", scramble: (*Scrambler).PaymentID},
+	{table: "donations", column: "razorpay_signature", scramble: (*Scrambler).Token},
+
+	{table: "registration_transfers", column: "token", scramble: (*Scrambler).Token},
+	{table: "digest_subscriptions", column: "unsubscribe_token", scramble: (*Scrambler).Token},
+	{table: "webhook_subscriptions", column: "secret", scramble: (*Scrambler).Token},
+
+	{table: "audit_log", column: "ip_address", scramble: (*Scrambler).IP},
+	{table: "audit_log", column: "details->>'email'", set: "details = jsonb_set(details, '{email}', to_jsonb($2::text))",
+		scramble: (*Scrambler).Email},
+	{table: "security_alerts", column: "ip_address", scramble: (*Scrambler).IP},
+	{table: "security_lockouts", column: "ip_address", scramble: (*Scrambler).IP},
+}
+
+// cleared are statements removing what is unsafe to keep on staging at all:
+// sessions, photos of people, work that would email or notify real people,
+// webhooks to production receivers, and production's backups
+var cleared = []string{
+	`UPDATE users SET avatar_url = NULL`,
+	`DELETE FROM refresh_tokens`,
+	`DELETE FROM jobs`,
+	`DELETE FROM outbox_events`,
+	`DELETE FROM deferred_pushes`,
+	`DELETE FROM notifications`, // Their text names people
+	`DELETE FROM webhook_deliveries`,
+	`UPDATE webhook_subscriptions SET active = FALSE`,
+	`DELETE FROM upload_sessions`,
+	`DELETE FROM backups`,
+}
+
+// Run scrubs db, a copy of the production database, in one transaction.
+// Every password becomes passwordHash, a bcrypt hash, so developers can log
+// in as anyone by their scrambled email.
+//
+// Free text people wrote (posts, comments, chat) is kept, as it is what
+// makes staging realistic; registration form answers are scrubbed but for
+// the choices.
+func Run(ctx context.Context, db *sql.DB, s *Scrambler, passwordHash string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range cleared {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to run %q: %w", stmt, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET password_hash = $1`, passwordHash); err != nil {
+		return fmt.Errorf("failed to reset passwords: %w", err)
+	}
+
+	for _, sc := range scrubs {
+		n, err := sc.run(ctx, tx, s)
+		if err != nil {
+			return fmt.Errorf("failed to scrub %s.%s: %w", sc.table, sc.column, err)
+		}
+		log.Printf("  → %s.%s: %d scrambled", sc.table, sc.column, n)
+	}
+
+	if err := scrubFormResponses(ctx, tx, s); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// run scrambles every non-null value. Rows are addressed by ctid, which
+// every table has whatever its key; each row is updated once, so the ctids
+// read stay valid.
+func (sc scrub) run(ctx context.Context, tx *sql.Tx, s *Scrambler) (int, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(
+		`SELECT ctid::text, %s FROM %s WHERE %s IS NOT NULL`, sc.column, sc.table, sc.column))
+	if err != nil {
+		return 0, err
+	}
+	type row struct{ ctid, value string }
+	var values []row
+	for rows.Next() {
+		var r row
+		if err := rows.Scan(&r.ctid, &r.value); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		values = append(values, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	set := sc.set
+	if set == "" {
+		set = sc.column + " = $2"
+	}
+	stmt, err := tx.PrepareContext(ctx, fmt.Sprintf(`UPDATE %s SET %s WHERE ctid = $1::tid`, sc.table, set))
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	for _, r := range values {
+		if _, err := stmt.ExecContext(ctx, r.ctid, sc.scramble(s, r.value)); err != nil {
+			return 0, err
+		}
+	}
+	return len(values), nil
+}
+
+// scrubFormResponses scrambles registrants' registration form answers,
+// which may hold phone numbers and addresses, keeping the choices and
+// numbers that make the answers look real
+func scrubFormResponses(ctx context.Context, tx *sql.Tx, s *Scrambler) error {
+	forms := map[uuid.UUID]map[string]string{} // Event -> field key -> type
+	rows, err := tx.QueryContext(ctx, `SELECT id, registration_form FROM events WHERE registration_form <> '[]'`)
+	if err != nil {
+		return fmt.Errorf("failed to read registration forms: %w", err)
+	}
+	for rows.Next() {
+		var eventID uuid.UUID
+		var raw []byte
+		if err := rows.Scan(&eventID, &raw); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to read registration forms: %w", err)
+		}
+		var fields []models.FormField
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to read registration form of event %s: %w", eventID, err)
+		}
+		types := make(map[string]string, len(fields))
+		for _, f := range fields {
+			types[f.Key] = f.Type
+		}
+		forms[eventID] = types
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read registration forms: %w", err)
+	}
+
+	for _, table := range []string{"event_registrations", "event_payments"} {
+		n, err := scrubResponses(ctx, tx, s, table, forms)
+		if err != nil {
+			return fmt.Errorf("failed to scrub %s.form_responses: %w", table, err)
+		}
+		log.Printf("  → %s.form_responses: %d scrubbed", table, n)
+	}
+	return nil
+}
+
+func scrubResponses(ctx context.Context, tx *sql.Tx, s *Scrambler, table string, forms map[uuid.UUID]map[string]string) (int, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(
+		`SELECT ctid::text, event_id, form_responses FROM %s WHERE form_responses IS NOT NULL`, table))
+	if err != nil {
+		return 0, err
+	}
+	type row struct {
+		ctid      string
+		responses []byte
+	}
+	var updates []row
+	for rows.Next() {
+		var r row
+		var eventID uuid.UUID
+		if err := rows.Scan(&r.ctid, &eventID, &r.responses); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		var responses models.FormResponses
+		if err := json.Unmarshal(r.responses, &responses); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("row %s: %w", r.ctid, err)
+		}
+		for key, v := range responses {
+			text, ok := v.(string)
+			if !ok {
+				continue // Numbers, checkboxes and multiselect choices
+			}
+			switch forms[eventID][key] {
+			case models.FormFieldSelect:
+			case models.FormFieldEmail:
+				responses[key] = s.Email(text)
+			default:
+				// Including answers to fields since removed from the form
+				responses[key] = s.Text(text)
+			}
+		}
+		if r.responses, err = json.Marshal(responses); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		updates = append(updates, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, fmt.Sprintf(`UPDATE %s SET form_responses = $2 WHERE ctid = $1::tid`, table))
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	for _, r := range updates {
+		if _, err := stmt.ExecContext(ctx, r.ctid, r.responses); err != nil {
+			return 0, err
+		}
+	}
+	return len(updates), nil
+}
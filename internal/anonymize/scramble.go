@@ -0,0 +1,123 @@
+// Package anonymize scrubs personal data from a copy of the production
+// database, so staging can run on realistic volumes without student PII.
+//
+// Values are scrambled deterministically with a keyed hash: an email
+// becomes the same fake email in every table and on every refresh, so
+// joins, unique constraints and test accounts survive, while the key stops
+// anyone recovering the originals by hashing guesses such as roll-number
+// emails.
+package anonymize
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Scrambler maps personal values to fake ones
+type Scrambler struct {
+	key []byte
+}
+
+// NewScrambler creates a scrambler. The same key gives the same values on
+// every refresh; keep it secret.
+func NewScrambler(key string) *Scrambler {
+	return &Scrambler{key: []byte(key)}
+}
+
+// sum is the keyed hash of value, separated by kind so an email and a name
+// with the same text scramble independently
+func (s *Scrambler) sum(kind, value string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(kind))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
+
+// Email returns a fake address at example.com, a domain that never receives
+// mail. Addresses differing only in case scramble alike, as they log in
+// alike.
+func (s *Scrambler) Email(email string) string {
+	sum := s.sum("email", strings.ToLower(strings.TrimSpace(email)))
+	return fmt.Sprintf("user.%s@example.com", hex.EncodeToString(sum[:6]))
+}
+
+var (
+	firstNames = []string{
+		"Aarav", "Aditi", "Ananya", "Arjun", "Diya", "Ishaan", "Kabir", "Kavya", "Meera", "Nikhil",
+		"Priya", "Rahul", "Riya", "Rohan", "Saanvi", "Sahil", "Sneha", "Tara", "Varun", "Vihaan",
+		"Alex", "Chris", "Dana", "Elena", "Hana", "Jordan", "Leo", "Maya", "Noah", "Sara",
+	}
+	lastNames = []string{
+		"Bose", "Chopra", "Das", "Desai", "Gupta", "Iyer", "Joshi", "Kapoor", "Khan", "Kumar",
+		"Menon", "Mehta", "Nair", "Patel", "Rao", "Reddy", "Shah", "Sharma", "Singh", "Verma",
+		"Fernandes", "George", "Kim", "Lee", "Martin", "Mendes", "Park", "Silva", "Thomas", "Wong",
+	}
+)
+
+// Name returns a fake full name. Names repeat across people, as real ones
+// do; Email is what tells accounts apart.
+func (s *Scrambler) Name(name string) string {
+	sum := s.sum("name", strings.TrimSpace(name))
+	first := binary.BigEndian.Uint32(sum[0:4]) % uint32(len(firstNames))
+	last := binary.BigEndian.Uint32(sum[4:8]) % uint32(len(lastNames))
+	return firstNames[first] + " " + lastNames[last]
+}
+
+// Phone replaces every digit, keeping the number's length and formatting
+func (s *Scrambler) Phone(phone string) string {
+	sum := s.sum("phone", phone)
+	var b strings.Builder
+	i := 0
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			r = rune('0' + sum[i%len(sum)]%10)
+			i++
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+const base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// PaymentID scrambles a Razorpay order, payment or refund ID, keeping its
+// prefix (order_, pay_, rfnd_) and length
+func (s *Scrambler) PaymentID(id string) string {
+	prefix, rest := "", id
+	if i := strings.IndexByte(id, '_'); i >= 0 {
+		prefix, rest = id[:i+1], id[i+1:]
+	}
+	sum := s.sum("payment", id)
+	b := make([]byte, len(rest))
+	for i := range b {
+		b[i] = base62[sum[i%len(sum)]%62]
+	}
+	return prefix + string(b)
+}
+
+// Token replaces a secret, such as a signature or an unsubscribe token,
+// with hex of the same length
+func (s *Scrambler) Token(token string) string {
+	sum := hex.EncodeToString(s.sum("token", token))
+	for len(sum) < len(token) {
+		sum += sum
+	}
+	return sum[:len(token)]
+}
+
+// IP returns an address in 10.0.0.0/8, the same for the same address
+func (s *Scrambler) IP(ip string) string {
+	sum := s.sum("ip", ip)
+	return net.IPv4(10, sum[0], sum[1], sum[2]).String()
+}
+
+// Text replaces free text, such as a registration form answer
+func (s *Scrambler) Text(text string) string {
+	return "scrubbed-" + hex.EncodeToString(s.sum("text", text)[:4])
+}
@@ -0,0 +1,57 @@
+package anonymize
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestScramblerDeterministic(t *testing.T) {
+	a := NewScrambler("staging-key-0123456789abcdef0123")
+	b := NewScrambler("staging-key-0123456789abcdef0123")
+	other := NewScrambler("another-key-0123456789abcdef0123")
+
+	if a.Email("Asha.Rao@college.edu") != b.Email("asha.rao@college.edu ") {
+		t.Error("the same address, differing in case and spaces, scrambled differently")
+	}
+	if a.Email("asha.rao@college.edu") == a.Email("ravi.rao@college.edu") {
+		t.Error("different addresses scrambled alike")
+	}
+	if a.Email("asha.rao@college.edu") == other.Email("asha.rao@college.edu") {
+		t.Error("different keys scrambled alike")
+	}
+	if a.Name("Asha Rao") != b.Name("Asha Rao") {
+		t.Error("the same name scrambled differently")
+	}
+	if a.Token("abc") == a.Text("abc") {
+		t.Error("kinds of value scrambled alike")
+	}
+}
+
+func TestScramblerFormats(t *testing.T) {
+	s := NewScrambler("staging-key-0123456789abcdef0123")
+
+	if got := s.Email("asha.rao@college.edu"); !regexp.MustCompile(`^user\.[0-9a-f]{12}@example\.com$`).MatchString(got) {
+		t.Errorf("Email = %q", got)
+	}
+	if got := s.Name("Asha Rao"); len(strings.Fields(got)) != 2 {
+		t.Errorf("Name = %q, want a first and last name", got)
+	}
+	if got := s.Phone("+91 98450-12345"); !regexp.MustCompile(`^\+\d\d \d{5}-\d{5}$`).MatchString(got) || got == "+91 98450-12345" {
+		t.Errorf("Phone = %q, want the same format with other digits", got)
+	}
+	for _, id := range []string{"order_Mv3bzxUbA1d2Ef", "pay_29QQoUBi66xm2f", "rfnd_FgRAHdNOM4ZVbO"} {
+		got := s.PaymentID(id)
+		prefix := id[:strings.IndexByte(id, '_')+1]
+		if got == id || len(got) != len(id) || !strings.HasPrefix(got, prefix) {
+			t.Errorf("PaymentID(%q) = %q, want another %s ID of the same length", id, got, prefix)
+		}
+	}
+	long := strings.Repeat("f", 100)
+	if got := s.Token(long); len(got) != 100 || got == long {
+		t.Errorf("Token = %q, want 100 other characters", got)
+	}
+	if got := s.IP("203.0.113.7"); !strings.HasPrefix(got, "10.") {
+		t.Errorf("IP = %q, want an address in 10.0.0.0/8", got)
+	}
+}
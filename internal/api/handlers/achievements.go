@@ -0,0 +1,420 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/gamification"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+const recentActivityLimit = 20
+
+// AchievementHandler serves activity points, badges and leaderboards
+type AchievementHandler struct {
+	db *database.DB
+}
+
+// NewAchievementHandler creates a new achievement handler
+func NewAchievementHandler(db *database.DB) *AchievementHandler {
+	return &AchievementHandler{db: db}
+}
+
+// GetMyAchievements returns the current user's points, badges and recent activity
+// GET /api/v1/me/achievements
+func (h *AchievementHandler) GetMyAchievements(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+	ctx := c.Request.Context()
+
+	resp := models.AchievementsResponse{
+		PointsBySource: map[string]int{},
+		Badges:         []models.EarnedBadge{},
+		Recent:         []models.ActivityPoint{},
+	}
+
+	rows, err := h.db.QueryContext(ctx, `
+		SELECT source, SUM(points) FROM activity_points WHERE user_id = $1 GROUP BY source
+	`, userID)
+	if err != nil {
+		internalError(c, "Failed to fetch achievements", err)
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var source string
+		var points int
+		if err := rows.Scan(&source, &points); err != nil {
+			internalError(c, "Failed to fetch achievements", err)
+			return
+		}
+		resp.PointsBySource[source] = points
+		resp.TotalPoints += points
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch achievements", err)
+		return
+	}
+
+	badgeRows, err := h.db.QueryContext(ctx, `
+		SELECT b.id, b.code, b.name, b.description, b.icon_url, b.rule_source, b.threshold,
+		       b.is_active, b.created_at, b.updated_at, ub.awarded_at
+		FROM user_badges ub
+		JOIN badges b ON b.id = ub.badge_id
+		WHERE ub.user_id = $1
+		ORDER BY ub.awarded_at DESC
+	`, userID)
+	if err != nil {
+		internalError(c, "Failed to fetch achievements", err)
+		return
+	}
+	defer badgeRows.Close()
+	for badgeRows.Next() {
+		var b models.EarnedBadge
+		if err := badgeRows.Scan(
+			&b.ID, &b.Code, &b.Name, &b.Description, &b.IconURL, &b.RuleSource, &b.Threshold,
+			&b.IsActive, &b.CreatedAt, &b.UpdatedAt, &b.AwardedAt,
+		); err != nil {
+			internalError(c, "Failed to fetch achievements", err)
+			return
+		}
+		resp.Badges = append(resp.Badges, b)
+	}
+	if err := badgeRows.Err(); err != nil {
+		internalError(c, "Failed to fetch achievements", err)
+		return
+	}
+
+	recentRows, err := h.db.QueryContext(ctx, `
+		SELECT id, source, source_id, points, reason, created_at
+		FROM activity_points
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, userID, recentActivityLimit)
+	if err != nil {
+		internalError(c, "Failed to fetch achievements", err)
+		return
+	}
+	defer recentRows.Close()
+	for recentRows.Next() {
+		var p models.ActivityPoint
+		if err := recentRows.Scan(&p.ID, &p.Source, &p.SourceID, &p.Points, &p.Reason, &p.CreatedAt); err != nil {
+			internalError(c, "Failed to fetch achievements", err)
+			return
+		}
+		resp.Recent = append(resp.Recent, p)
+	}
+	if err := recentRows.Err(); err != nil {
+		internalError(c, "Failed to fetch achievements", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    resp,
+	})
+}
+
+// GetLeaderboard ranks users by activity points, campus-wide or within a
+// department or house. Points count from the start of the current term
+// unless ?term= names another term or "all".
+// GET /api/v1/leaderboard?department=CSE
+// GET /api/v1/leaderboard?house_id=...
+// GET /api/v1/leaderboard?term=all
+func (h *AchievementHandler) GetLeaderboard(c *gin.Context) {
+	var query models.LeaderboardQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid query parameters"),
+		})
+		return
+	}
+	if query.Limit < 1 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	var houseID *uuid.UUID
+	if query.HouseID != "" {
+		id, err := uuid.Parse(query.HouseID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Invalid house ID"),
+			})
+			return
+		}
+		houseID = &id
+	}
+
+	// Leaderboards reset each term
+	if query.Term == "" {
+		query.Term = "current"
+	}
+	ctx := c.Request.Context()
+	termID, err := termFilter(ctx, h.db.Reader(), query.Term)
+	if err != nil {
+		respondTermFilterError(c, err)
+		return
+	}
+
+	rows, err := h.db.Reader().QueryContext(ctx, `
+		SELECT RANK() OVER (ORDER BY SUM(ap.points) DESC), u.id, u.full_name, u.avatar_url, u.department, SUM(ap.points)
+		FROM activity_points ap
+		JOIN users u ON u.id = ap.user_id AND u.deleted_at IS NULL
+		WHERE ($1 = '' OR u.department = $1)
+		  AND ($2::uuid IS NULL OR u.id IN (SELECT user_id FROM house_members WHERE house_id = $2))
+		  AND ($4::uuid IS NULL OR ap.term_id = $4)
+		GROUP BY u.id, u.full_name, u.avatar_url, u.department
+		ORDER BY SUM(ap.points) DESC, u.full_name ASC
+		LIMIT $3
+	`, query.Department, houseID, query.Limit, termID)
+	if err != nil {
+		internalError(c, "Failed to fetch leaderboard", err)
+		return
+	}
+	defer rows.Close()
+
+	entries := []models.LeaderboardEntry{}
+	for rows.Next() {
+		var e models.LeaderboardEntry
+		if err := rows.Scan(&e.Rank, &e.UserID, &e.FullName, &e.AvatarURL, &e.Department, &e.Points); err != nil {
+			internalError(c, "Failed to fetch leaderboard", err)
+			return
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch leaderboard", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    entries,
+	})
+}
+
+// ListBadges returns every badge, including inactive ones (admin only)
+// GET /api/v1/admin/badges
+func (h *AchievementHandler) ListBadges(c *gin.Context) {
+	rows, err := h.db.QueryContext(c.Request.Context(), `
+		SELECT id, code, name, description, icon_url, rule_source, threshold, is_active, created_at, updated_at
+		FROM badges
+		ORDER BY created_at ASC
+	`)
+	if err != nil {
+		internalError(c, "Failed to fetch badges", err)
+		return
+	}
+	defer rows.Close()
+
+	badges := []models.Badge{}
+	for rows.Next() {
+		var b models.Badge
+		if err := rows.Scan(
+			&b.ID, &b.Code, &b.Name, &b.Description, &b.IconURL, &b.RuleSource, &b.Threshold,
+			&b.IsActive, &b.CreatedAt, &b.UpdatedAt,
+		); err != nil {
+			internalError(c, "Failed to fetch badges", err)
+			return
+		}
+		badges = append(badges, b)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch badges", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    badges,
+	})
+}
+
+// CreateBadge adds a badge rule (admin only). Users who already meet the
+// rule receive the badge the next time they earn points.
+// POST /api/v1/admin/badges
+func (h *AchievementHandler) CreateBadge(c *gin.Context) {
+	var req models.CreateBadgeRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	if req.RuleSource != nil && !gamification.ValidSource(*req.RuleSource) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid rule_source"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	var exists bool
+	if err := h.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM badges WHERE code = $1)", req.Code).Scan(&exists); err != nil {
+		internalError(c, "Failed to create badge", err)
+		return
+	}
+	if exists {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("A badge with this code already exists"),
+		})
+		return
+	}
+
+	var b models.Badge
+	err := h.db.QueryRowContext(ctx, `
+		INSERT INTO badges (code, name, description, icon_url, rule_source, threshold)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, code, name, description, icon_url, rule_source, threshold, is_active, created_at, updated_at
+	`, req.Code, req.Name, req.Description, req.IconURL, req.RuleSource, req.Threshold).Scan(
+		&b.ID, &b.Code, &b.Name, &b.Description, &b.IconURL, &b.RuleSource, &b.Threshold,
+		&b.IsActive, &b.CreatedAt, &b.UpdatedAt,
+	)
+	if err != nil {
+		internalError(c, "Failed to create badge", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Badge created successfully",
+		Data:    b,
+	})
+}
+
+// UpdateBadge edits a badge's display fields or deactivates it (admin only)
+// PUT /api/v1/admin/badges/:id
+func (h *AchievementHandler) UpdateBadge(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid badge ID"),
+		})
+		return
+	}
+
+	var req models.UpdateBadgeRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	var b models.Badge
+	err = h.db.QueryRowContext(c.Request.Context(), `
+		UPDATE badges
+		SET name = COALESCE($1, name),
+		    description = COALESCE($2, description),
+		    icon_url = COALESCE($3, icon_url),
+		    is_active = COALESCE($4, is_active)
+		WHERE id = $5
+		RETURNING id, code, name, description, icon_url, rule_source, threshold, is_active, created_at, updated_at
+	`, req.Name, req.Description, req.IconURL, req.IsActive, id).Scan(
+		&b.ID, &b.Code, &b.Name, &b.Description, &b.IconURL, &b.RuleSource, &b.Threshold,
+		&b.IsActive, &b.CreatedAt, &b.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Badge not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to update badge", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Badge updated successfully",
+		Data:    b,
+	})
+}
+
+// AwardCompetitionWin records a competition win for a user and credits their
+// house (admin only). Awarding the same competition to the same user twice is
+// rejected.
+// POST /api/v1/admin/achievements/competition-wins
+func (h *AchievementHandler) AwardCompetitionWin(c *gin.Context) {
+	var req models.CompetitionWinRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	adminID, _ := middleware.UserID(c)
+	ctx := c.Request.Context()
+
+	sourceID := uuid.New()
+	if req.EventID != nil {
+		sourceID = *req.EventID
+
+		var exists bool
+		if err := h.db.QueryRowContext(ctx, `
+			SELECT EXISTS(SELECT 1 FROM activity_points WHERE user_id = $1 AND source = $2 AND source_id = $3)
+		`, req.UserID, gamification.SourceCompetitionWin, sourceID).Scan(&exists); err != nil {
+			internalError(c, "Failed to award competition win", err)
+			return
+		}
+		if exists {
+			c.JSON(http.StatusConflict, models.APIResponse{
+				Success: false,
+				Error:   strPtr("This win has already been awarded"),
+			})
+			return
+		}
+	}
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to award competition win", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if err := gamification.Award(ctx, tx, gamification.Entry{
+		UserID:   req.UserID,
+		Source:   gamification.SourceCompetitionWin,
+		SourceID: sourceID,
+		Points:   req.Points,
+		Reason:   req.Title,
+	}); err != nil {
+		internalError(c, "Failed to award competition win", err)
+		return
+	}
+
+	if req.HousePoints > 0 {
+		if err := gamification.CreditHouse(ctx, tx, req.UserID, req.HousePoints, req.Title,
+			gamification.SourceCompetitionWin, sourceID, adminID); err != nil {
+			internalError(c, "Failed to award competition win", err)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to award competition win", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Competition win awarded",
+	})
+}
@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
+)
+
+// BroadcastHandler serves admin broadcasts to cohorts of users. Broadcasts
+// to a house can also be posted to its chat channel, through chat.
+type BroadcastHandler struct {
+	db   *sql.DB
+	chat *ChatHandler
+}
+
+// NewBroadcastHandler creates a new BroadcastHandler
+func NewBroadcastHandler(db *sql.DB, chat *ChatHandler) *BroadcastHandler {
+	return &BroadcastHandler{db: db, chat: chat}
+}
+
+// adminBroadcastColumns selects a broadcast, b, with the number of its
+// notifications read
+const adminBroadcastColumns = `b.id, b.sent_by, b.cohort, b.department, b.year, b.house_id, b.event_id,
+	b.title, b.body, b.send_email, b.chat_message_id, b.recipient_count, b.delivered_count, b.failed_count,
+	(SELECT COUNT(*) FROM notifications n
+	 WHERE n.data ? 'admin_broadcast_id' AND n.data->>'admin_broadcast_id' = b.id::text AND n.read_at IS NOT NULL),
+	b.delivered_at, b.created_at`
+
+func scanAdminBroadcast(row interface{ Scan(...interface{}) error }) (models.AdminBroadcast, error) {
+	var b models.AdminBroadcast
+	err := row.Scan(
+		&b.ID, &b.SentBy, &b.Cohort, &b.Department, &b.Year, &b.HouseID, &b.EventID,
+		&b.Title, &b.Body, &b.SendEmail, &b.ChatMessageID, &b.RecipientCount, &b.DeliveredCount, &b.FailedCount,
+		&b.ReadCount, &b.DeliveredAt, &b.CreatedAt,
+	)
+	return b, err
+}
+
+// CreateBroadcast sends a message to a cohort: all students, the students
+// of a department or a year, a house's members or an event's registrants.
+// Recipients are notified in the app and by push, and by email if asked;
+// a broadcast to a house can also be posted to its chat channel.
+// POST /api/v1/admin/broadcasts
+func (h *BroadcastHandler) CreateBroadcast(c *gin.Context) {
+	var req models.CreateAdminBroadcastRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid request: " + err.Error()),
+		})
+		return
+	}
+
+	// Keep only what names the cohort
+	department, year, houseID, eventID := req.Department, req.Year, req.HouseID, req.EventID
+	req.Department, req.Year, req.HouseID, req.EventID = nil, nil, nil, nil
+	missing := ""
+	switch req.Cohort {
+	case models.BroadcastCohortDepartment:
+		if req.Department = department; department == nil {
+			missing = "department"
+		}
+	case models.BroadcastCohortYear:
+		if req.Year = year; year == nil {
+			missing = "year"
+		}
+	case models.BroadcastCohortHouse:
+		if req.HouseID = houseID; houseID == nil {
+			missing = "house_id"
+		}
+	case models.BroadcastCohortEventRegistrants:
+		if req.EventID = eventID; eventID == nil {
+			missing = "event_id"
+		}
+	}
+	if missing != "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(missing + " is required for the " + req.Cohort + " cohort"),
+		})
+		return
+	}
+	if req.PostToChat && req.Cohort != models.BroadcastCohortHouse {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Only broadcasts to a house can be posted to chat"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if req.HouseID != nil || req.EventID != nil {
+		var exists bool
+		query, what := "SELECT EXISTS(SELECT 1 FROM houses WHERE id = $1 AND deleted_at IS NULL)", "House"
+		target := req.HouseID
+		if req.EventID != nil {
+			query, what = "SELECT EXISTS(SELECT 1 FROM events WHERE id = $1 AND deleted_at IS NULL)", "Event"
+			target = req.EventID
+		}
+		if err := h.db.QueryRowContext(ctx, query, *target).Scan(&exists); err != nil {
+			internalError(c, "Failed to send broadcast", err)
+			return
+		}
+		if !exists {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   strPtr(what + " not found"),
+			})
+			return
+		}
+	}
+
+	userID, _ := middleware.UserID(c)
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to send broadcast", err)
+		return
+	}
+	defer tx.Rollback()
+
+	var chatMessage *models.ChatChannelMessage
+	if req.PostToChat {
+		m, err := scanChatMessage(tx.QueryRowContext(ctx, `
+			WITH m AS (
+				INSERT INTO chat_channel_messages (channel_id, user_id, content)
+				SELECT id, $2, $3 FROM chat_channels WHERE house_id = $1
+				RETURNING *
+			)
+			SELECT `+chatMessageColumns+`
+			FROM m
+			LEFT JOIN users u ON u.id = m.user_id
+		`, *req.HouseID, userID, req.Title+"\n\n"+req.Body))
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			internalError(c, "Failed to send broadcast", err)
+			return
+		}
+		if err == nil {
+			chatMessage = &m
+		}
+	}
+	var chatMessageID *uuid.UUID
+	if chatMessage != nil {
+		chatMessageID = &chatMessage.ID
+	}
+
+	broadcast, err := scanAdminBroadcast(tx.QueryRowContext(ctx, `
+		WITH b AS (
+			INSERT INTO admin_broadcasts (sent_by, cohort, department, year, house_id, event_id,
+				title, body, send_email, chat_message_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			RETURNING *
+		)
+		SELECT `+adminBroadcastColumns+`
+		FROM b
+	`, userID, req.Cohort, req.Department, req.Year, req.HouseID, req.EventID,
+		req.Title, req.Body, req.SendEmail, chatMessageID))
+	if err != nil {
+		internalError(c, "Failed to send broadcast", err)
+		return
+	}
+
+	err = outbox.Write(ctx, tx, notifications.TopicAdminBroadcastCreated, notifications.AdminBroadcastCreatedPayload{
+		BroadcastID: broadcast.ID,
+	})
+	if err != nil {
+		internalError(c, "Failed to send broadcast", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to send broadcast", err)
+		return
+	}
+
+	if chatMessage != nil {
+		h.chat.publishChatEvent(ctx, models.ChatEvent{
+			Type:      models.ChatEventMessage,
+			ChannelID: chatMessage.ChannelID,
+			UserID:    &userID,
+			Message:   chatMessage,
+		})
+	}
+
+	c.JSON(http.StatusAccepted, models.APIResponse{
+		Success: true,
+		Message: "Broadcast queued for delivery",
+		Data:    broadcast,
+	})
+}
+
+// ListBroadcasts returns the send log of broadcasts with their delivery
+// stats, newest first
+// GET /api/v1/admin/broadcasts
+func (h *BroadcastHandler) ListBroadcasts(c *gin.Context) {
+	rows, err := h.db.QueryContext(c.Request.Context(), `
+		SELECT `+adminBroadcastColumns+`
+		FROM admin_broadcasts b
+		ORDER BY b.created_at DESC
+		LIMIT 100
+	`)
+	if err != nil {
+		internalError(c, "Failed to fetch broadcasts", err)
+		return
+	}
+	defer rows.Close()
+
+	broadcasts := []models.AdminBroadcast{}
+	for rows.Next() {
+		b, err := scanAdminBroadcast(rows)
+		if err != nil {
+			internalError(c, "Failed to fetch broadcasts", err)
+			return
+		}
+		broadcasts = append(broadcasts, b)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch broadcasts", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    broadcasts,
+	})
+}
+
+// GetBroadcast returns a broadcast with its delivery stats
+// GET /api/v1/admin/broadcasts/:id
+func (h *BroadcastHandler) GetBroadcast(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid broadcast ID"),
+		})
+		return
+	}
+
+	broadcast, err := scanAdminBroadcast(h.db.QueryRowContext(c.Request.Context(), `
+		SELECT `+adminBroadcastColumns+`
+		FROM admin_broadcasts b
+		WHERE b.id = $1
+	`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Broadcast not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to fetch broadcast", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    broadcast,
+	})
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/auth"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// TestAlumniRestrictions verifies alumni can't join clubs, hold house roles
+// or enroll in house events, can register only for events open to them, and
+// that students can't find alumni events to register for
+func TestAlumniRestrictions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authService := auth.NewService("test-secret", 1, 1)
+	clubID, houseID, eventID := uuid.New(), uuid.New(), uuid.New()
+
+	userRole := func(role models.UserRole) fakeResult {
+		return fakeResult{columns: []string{"role"}, rows: [][]driver.Value{{string(role)}}}
+	}
+	event := func(alumniAccess string) fakeResult {
+		return fakeResult{
+			columns: []string{"id", "title", "status", "is_paid_event", "event_amount", "currency",
+				"end_date", "registration_deadline", "alumni_access"},
+			rows: [][]driver.Value{{eventID.String(), "Homecoming", models.EventStatusUpcoming, true, 500.0, "INR",
+				time.Now().Add(72 * time.Hour), nil, alumniAccess}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		role   models.UserRole
+		result fakeResult
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{
+			name: "admin adds an alumnus to a club", role: models.RoleAdmin, result: userRole(models.RoleAlumni),
+			method: http.MethodPost, path: "/clubs/" + clubID.String() + "/members",
+			body: `{"user_id": "` + uuid.NewString() + `"}`, want: http.StatusConflict,
+		},
+		{
+			name: "admin gives an alumnus a house role", role: models.RoleAdmin,
+			result: fakeResult{columns: []string{"full_name", "role"}, rows: [][]driver.Value{{"Asha Rao", string(models.RoleAlumni)}}},
+			method: http.MethodPost, path: "/houses/" + houseID.String() + "/roles",
+			body: `{"role_title": "Captain", "user_id": "` + uuid.NewString() + `"}`, want: http.StatusConflict,
+		},
+		{
+			name: "alumnus enrolls in a house event", role: models.RoleAlumni, result: fakeResult{},
+			method: http.MethodPost, path: "/houses/" + houseID.String() + "/events/" + uuid.NewString() + "/enroll",
+			want: http.StatusForbidden,
+		},
+		{
+			name: "alumnus registers for a students' event", role: models.RoleAlumni, result: event(models.AlumniAccessNone),
+			method: http.MethodPost, path: "/payments/orders",
+			body: `{"event_id": "` + eventID.String() + `"}`, want: http.StatusForbidden,
+		},
+		{
+			name: "student registers for an alumni event", role: models.RoleStudent, result: event(models.AlumniAccessOnly),
+			method: http.MethodPost, path: "/payments/orders",
+			body: `{"event_id": "` + eventID.String() + `"}`, want: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openFakeDB(t, tt.result)
+			clubs := &ClubHandler{DB: db}
+			houses := &HouseHandler{DB: db}
+			payments := NewPaymentHandler(&database.DB{DB: db})
+			router := gin.New()
+			router.Use(middleware.AuthMiddleware(authService))
+			router.POST("/clubs/:id/members", clubs.AddClubMember)
+			router.POST("/houses/:id/roles", houses.AddHouseRole)
+			router.POST("/houses/:id/events/:event_id/enroll", houses.EnrollInEvent)
+			router.POST("/payments/orders", payments.CreateOrder)
+
+			token, err := authService.GenerateAccessToken(&models.User{
+				ID: uuid.New(), Email: "user@college.edu", Role: tt.role,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d; body: %s", w.Code, tt.want, w.Body.String())
+			}
+			for _, q := range fakeQueriesRun(t) {
+				if strings.Contains(q, "INSERT") {
+					t.Errorf("a registration or membership was written: %s", q)
+				}
+			}
+		})
+	}
+}
+
+// TestGraduationYearCanOnlyMoveEarlier verifies students can set their
+// graduation year and bring it forward, but not put it off
+func TestGraduationYearCanOnlyMoveEarlier(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authService := auth.NewService("test-secret", 1, 1)
+	thisYear := time.Now().Year()
+
+	user := func(graduationYear interface{}) fakeResult {
+		return fakeResult{
+			columns: []string{"role", "graduation_year"},
+			rows:    [][]driver.Value{{string(models.RoleStudent), graduationYear}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		result  fakeResult
+		year    int
+		allowed bool
+	}{
+		{"postpone graduating", user(int64(thisYear + 2)), thisYear + 3, false},
+		{"postpone graduating to the limit", user(int64(thisYear)), thisYear + maxYearsToGraduation, false},
+		{"bring graduating forward", user(int64(thisYear + 2)), thisYear + 1, true},
+		{"keep the same year", user(int64(thisYear + 2)), thisYear + 2, true},
+		{"set the year for the first time", user(nil), thisYear + 4, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &AuthHandler{db: &database.DB{DB: openFakeDB(t, tt.result)}, authService: authService}
+			router := gin.New()
+			router.Use(middleware.AuthMiddleware(authService))
+			router.PUT("/profile", h.UpdateProfile)
+
+			token, err := authService.GenerateAccessToken(&models.User{
+				ID: uuid.New(), Email: "student@college.edu", Role: models.RoleStudent,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			body := `{"graduation_year": ` + strconv.Itoa(tt.year) + `}`
+			req := httptest.NewRequest(http.MethodPut, "/profile", strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			var updated bool
+			for _, q := range fakeQueriesRun(t) {
+				if strings.Contains(q, "graduation_year = $") {
+					updated = true
+				}
+			}
+			if tt.allowed {
+				// The fake database can't complete the update; getting to it
+				// is what counts
+				if !updated {
+					t.Errorf("status = %d, want the update attempted; body: %s", w.Code, w.Body.String())
+				}
+				return
+			}
+			if w.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want 403; body: %s", w.Code, w.Body.String())
+			}
+			if updated {
+				t.Error("the graduation year was updated")
+			}
+		})
+	}
+}
@@ -1,32 +1,85 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
+	"log"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/audit"
 	"github.com/yourusername/college-event-backend/internal/models"
 	"github.com/yourusername/college-event-backend/internal/services/auth"
+	"github.com/yourusername/college-event-backend/internal/services/security"
+	"github.com/yourusername/college-event-backend/internal/webhooks"
 	"github.com/yourusername/college-event-backend/pkg/database"
 )
 
 type AuthHandler struct {
 	db          *database.DB
 	authService *auth.Service
+	providers   auth.Providers
+	security    *security.Detector
+	// sessions offers cookie sessions to web clients; nil for bearer
+	// tokens only
+	sessions *middleware.SessionCookies
 }
 
-func NewAuthHandler(db *database.DB, authService *auth.Service) *AuthHandler {
+func NewAuthHandler(db *database.DB, authService *auth.Service, providers auth.Providers, detector *security.Detector, sessions *middleware.SessionCookies) *AuthHandler {
 	return &AuthHandler{
 		db:          db,
 		authService: authService,
+		providers:   providers,
+		security:    detector,
+		sessions:    sessions,
 	}
 }
 
+// createUser inserts a student account and emits user.registered in the
+// same transaction. method is how they signed up: password, google, ldap or
+// oidc.
+func (h *AuthHandler) createUser(ctx context.Context, email, passwordHash, fullName string, department *string, year *int, method string) (models.User, error) {
+	var user models.User
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		return user, err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO users (email, password_hash, full_name, role, department, year)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, email, full_name, role, avatar_url, department, year, created_at, updated_at
+	`, email, passwordHash, fullName, models.RoleStudent, department, year).Scan(
+		&user.ID, &user.Email, &user.FullName, &user.Role,
+		&user.AvatarURL, &user.Department, &user.Year, &user.CreatedAt, &user.UpdatedAt,
+	)
+	if err != nil {
+		return user, err
+	}
+
+	if err := webhooks.Emit(ctx, tx, webhooks.UserRegistered, webhooks.UserRegisteredData{
+		UserID:     user.ID,
+		Email:      user.Email,
+		FullName:   user.FullName,
+		Department: user.Department,
+		Year:       user.Year,
+		Method:     method,
+	}); err != nil {
+		return user, err
+	}
+	return user, tx.Commit()
+}
+
 // Register handles user registration
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req models.RegisterRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Error:   strPtr("invalid request body"),
@@ -53,6 +106,17 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
+	ctx := c.Request.Context()
+	until, err := h.security.SignupBlockedUntil(ctx, c.ClientIP())
+	if err != nil {
+		internalError(c, "failed to create user", err)
+		return
+	}
+	if until != nil {
+		tooManyAttempts(c, *until, "too many sign-ups from your network, please try again later")
+		return
+	}
+
 	// Hash password
 	passwordHash, err := h.authService.HashPassword(req.Password)
 	if err != nil {
@@ -64,16 +128,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	// Create user
-	var user models.User
-	err = h.db.QueryRow(`
-		INSERT INTO users (email, password_hash, full_name, role, department, year)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, email, full_name, role, department, year, created_at, updated_at
-	`, req.Email, passwordHash, req.FullName, models.RoleStudent, req.Department, req.Year).Scan(
-		&user.ID, &user.Email, &user.FullName, &user.Role,
-		&user.Department, &user.Year, &user.CreatedAt, &user.UpdatedAt,
-	)
-
+	user, err := h.createUser(ctx, req.Email, passwordHash, req.FullName, req.Department, req.Year, "password")
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
@@ -81,6 +136,9 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		})
 		return
 	}
+	if err := h.security.SignedUp(ctx, user.ID, c.ClientIP(), middleware.GetRequestID(c)); err != nil {
+		logInternalError(c, "Failed to record sign-up", err)
+	}
 
 	// Generate tokens
 	accessToken, err := h.authService.GenerateAccessToken(&user)
@@ -115,21 +173,52 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, models.APIResponse{
-		Success: true,
-		Message: "user registered successfully",
-		Data: models.LoginResponse{
-			User:         user,
-			AccessToken:  accessToken,
-			RefreshToken: refreshToken,
-		},
+	h.respondLogin(c, http.StatusCreated, "user registered successfully", models.LoginResponse{
+		User:         user,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+}
+
+// recordLogin adds a sign-in to the audit log, for the user's activity
+// timeline. A failure is logged rather than failing the sign-in.
+func (h *AuthHandler) recordLogin(c *gin.Context, userID uuid.UUID, method string) {
+	err := audit.Record(c.Request.Context(), h.db, audit.Entry{
+		Action:        audit.ActionUserLogin,
+		ActorID:       &userID,
+		SubjectUserID: &userID,
+		RequestID:     middleware.GetRequestID(c),
+		IPAddress:     c.ClientIP(),
+		Details:       map[string]interface{}{"method": method},
+	})
+	if err != nil {
+		log.Printf("[AUDIT] request_id=%s: %v", middleware.GetRequestID(c), err)
+	}
+}
+
+// loginFailed records a failed password login for suspicious activity
+// detection. A failure is logged rather than changing the response.
+func (h *AuthHandler) loginFailed(c *gin.Context, userID *uuid.UUID, email string) {
+	err := h.security.LoginFailed(c.Request.Context(), userID, email, c.ClientIP(), middleware.GetRequestID(c))
+	if err != nil {
+		logInternalError(c, "Failed to record failed login", err)
+	}
+}
+
+// tooManyAttempts rejects a request locked out until the given time
+func tooManyAttempts(c *gin.Context, until time.Time, message string) {
+	c.Header("Retry-After", strconv.Itoa(int(time.Until(until).Seconds())+1))
+	c.JSON(http.StatusTooManyRequests, models.APIResponse{
+		Success: false,
+		Error:   strPtr(message),
+		Data:    gin.H{"retry_at": until},
 	})
 }
 
 // Login handles user login
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req models.LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Error:   strPtr("invalid request body"),
@@ -149,6 +238,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	)
 
 	if err == sql.ErrNoRows {
+		h.loginFailed(c, nil, req.Email)
 		c.JSON(http.StatusUnauthorized, models.APIResponse{
 			Success: false,
 			Error:   strPtr("invalid credentials"),
@@ -164,8 +254,19 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	until, err := h.security.LoginLockedUntil(c.Request.Context(), user.ID)
+	if err != nil {
+		internalError(c, "database error", err)
+		return
+	}
+	if until != nil {
+		tooManyAttempts(c, *until, "too many failed login attempts, please try again later")
+		return
+	}
+
 	// Check password
 	if !h.authService.CheckPasswordHash(req.Password, user.PasswordHash) {
+		h.loginFailed(c, &user.ID, req.Email)
 		c.JSON(http.StatusUnauthorized, models.APIResponse{
 			Success: false,
 			Error:   strPtr("invalid credentials"),
@@ -206,29 +307,27 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
-		Success: true,
-		Message: "login successful",
-		Data: models.LoginResponse{
-			User:         user,
-			AccessToken:  accessToken,
-			RefreshToken: refreshToken,
-		},
+	h.recordLogin(c, user.ID, "password")
+
+	h.respondLogin(c, http.StatusOK, "login successful", models.LoginResponse{
+		User:         user,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
 	})
 }
 
 // GetProfile returns the current user's profile
 func (h *AuthHandler) GetProfile(c *gin.Context) {
-	userID, _ := c.Get("user_id")
+	userID, _ := middleware.UserID(c)
 
 	var user models.User
 	err := h.db.QueryRow(`
-		SELECT id, email, full_name, role, avatar_url, department, year, created_at, updated_at
+		SELECT id, email, full_name, role, avatar_url, department, year, graduation_year, created_at, updated_at
 		FROM users
 		WHERE id = $1 AND deleted_at IS NULL
-	`, userID.(uuid.UUID)).Scan(
+	`, userID).Scan(
 		&user.ID, &user.Email, &user.FullName, &user.Role,
-		&user.AvatarURL, &user.Department, &user.Year, &user.CreatedAt, &user.UpdatedAt,
+		&user.AvatarURL, &user.Department, &user.Year, &user.GraduationYear, &user.CreatedAt, &user.UpdatedAt,
 	)
 
 	if err != nil {
@@ -245,9 +344,12 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 	})
 }
 
+// maxYearsToGraduation is how far ahead a student's graduation year may be
+const maxYearsToGraduation = 8
+
 // UpdateProfile updates the current user's profile
 func (h *AuthHandler) UpdateProfile(c *gin.Context) {
-	userID, _ := c.Get("user_id")
+	userID, _ := middleware.UserID(c)
 
 	var req struct {
 		FullName   *string  `json:"full_name"`
@@ -258,9 +360,12 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 		Username   *string  `json:"username"`
 		Interests  []string `json:"interests"`
 		AvatarURL  *string  `json:"avatar_url"`
+
+		// GraduationYear is when the student becomes alumni
+		GraduationYear *int `json:"graduation_year"`
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Error:   strPtr("invalid request body"),
@@ -288,6 +393,45 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 		args = append(args, *req.Year)
 		argCount++
 	}
+	if req.GraduationYear != nil {
+		// Graduating makes alumni of students and takes them out of their
+		// houses and clubs, so the year can't be in the past, can't be
+		// changed once it has come, and, once set, can only be brought
+		// forward: otherwise a student could put off graduating forever
+		thisYear := time.Now().Year()
+		if *req.GraduationYear < thisYear || *req.GraduationYear > thisYear+maxYearsToGraduation {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("graduation_year must be between " + strconv.Itoa(thisYear) + " and " + strconv.Itoa(thisYear+maxYearsToGraduation)),
+			})
+			return
+		}
+		var role models.UserRole
+		var graduationYear *int
+		if err := h.db.QueryRow(
+			"SELECT role, graduation_year FROM users WHERE id = $1 AND deleted_at IS NULL", userID,
+		).Scan(&role, &graduationYear); err != nil {
+			internalError(c, "failed to update profile", err)
+			return
+		}
+		if role == models.RoleAlumni {
+			c.JSON(http.StatusForbidden, models.APIResponse{
+				Success: false,
+				Error:   strPtr("alumni can't change their graduation year"),
+			})
+			return
+		}
+		if graduationYear != nil && *req.GraduationYear > *graduationYear {
+			c.JSON(http.StatusForbidden, models.APIResponse{
+				Success: false,
+				Error:   strPtr("graduation_year can only be moved earlier"),
+			})
+			return
+		}
+		updates = append(updates, "graduation_year = $"+string(rune('0'+argCount)))
+		args = append(args, *req.GraduationYear)
+		argCount++
+	}
 	if req.AvatarURL != nil {
 		updates = append(updates, "avatar_url = $"+string(rune('0'+argCount)))
 		args = append(args, *req.AvatarURL)
@@ -304,7 +448,7 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 
 	// Add updated_at
 	updates = append(updates, "updated_at = CURRENT_TIMESTAMP")
-	args = append(args, userID.(uuid.UUID))
+	args = append(args, userID)
 
 	query := "UPDATE users SET " + joinStrings(updates, ", ") + " WHERE id = $" + string(rune('0'+argCount)) + " AND deleted_at IS NULL"
 
@@ -331,7 +475,7 @@ func (h *AuthHandler) GoogleAuth(c *gin.Context) {
 		Name    string `json:"name" binding:"required"`
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Error:   strPtr("invalid request body"),
@@ -358,15 +502,7 @@ func (h *AuthHandler) GoogleAuth(c *gin.Context) {
 		randomPassword := uuid.New().String() // Random password since Google users don't need it
 		passwordHash, _ := h.authService.HashPassword(randomPassword)
 
-		err = h.db.QueryRow(`
-			INSERT INTO users (email, password_hash, full_name, role)
-			VALUES ($1, $2, $3, $4)
-			RETURNING id, email, full_name, role, avatar_url, department, year, created_at, updated_at
-		`, req.Email, passwordHash, req.Name, models.RoleStudent).Scan(
-			&user.ID, &user.Email, &user.FullName, &user.Role,
-			&user.AvatarURL, &user.Department, &user.Year, &user.CreatedAt, &user.UpdatedAt,
-		)
-
+		user, err = h.createUser(c.Request.Context(), req.Email, passwordHash, req.Name, nil, nil, "google")
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, models.APIResponse{
 				Success: false,
@@ -415,14 +551,12 @@ func (h *AuthHandler) GoogleAuth(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
-		Success: true,
-		Message: "google auth successful",
-		Data: models.LoginResponse{
-			User:         user,
-			AccessToken:  accessToken,
-			RefreshToken: refreshToken,
-		},
+	h.recordLogin(c, user.ID, "google")
+
+	h.respondLogin(c, http.StatusOK, "google auth successful", models.LoginResponse{
+		User:         user,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
 	})
 }
 
@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/auth"
+)
+
+// ListAuthProviders returns the enabled login methods so clients can show
+// the right sign-in options, with what they need to start an SSO sign-in
+// GET /api/v1/auth/providers
+func (h *AuthHandler) ListAuthProviders(c *gin.Context) {
+	resp := models.AuthProvidersResponse{Providers: h.providers.Enabled}
+	if oidc := h.providers.OIDC; oidc != nil {
+		resp.OIDC = &models.OIDCProviderInfo{Issuer: oidc.Issuer(), ClientID: oidc.ClientID()}
+		endpoint, err := oidc.AuthorizationEndpoint(c.Request.Context())
+		if err != nil {
+			// Clients can still discover it from the issuer themselves
+			logInternalError(c, "OIDC discovery failed", err)
+		}
+		resp.OIDC.AuthorizationEndpoint = endpoint
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    resp,
+	})
+}
+
+// LDAPLogin signs a user in with their directory (LDAP / Active Directory)
+// credentials. First-time users are created from their directory entry. When
+// their groups map to a role, the directory is authoritative and the role is
+// updated on every login.
+// POST /api/v1/auth/ldap
+func (h *AuthHandler) LDAPLogin(c *gin.Context) {
+	var req models.LDAPLoginRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid request body"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	identity, err := h.providers.LDAP.Authenticate(ctx, req.Username, req.Password)
+	if errors.Is(err, auth.ErrInvalidCredentials) {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid credentials"),
+		})
+		return
+	}
+	if errors.Is(err, auth.ErrLDAPNoEmail) {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("directory account has no email address"),
+		})
+		return
+	}
+	if err != nil {
+		requestID := logInternalError(c, "LDAP login failed", err)
+		c.JSON(http.StatusServiceUnavailable, models.APIResponse{
+			Success:   false,
+			Error:     strPtr("directory service unavailable"),
+			RequestID: requestID,
+		})
+		return
+	}
+
+	user, err := h.findOrCreateDirectoryUser(ctx, identity.Email, identity.FullName, identity.Role, "ldap")
+	if err != nil {
+		internalError(c, "Failed to sign in", err)
+		return
+	}
+
+	resp, err := h.issueLogin(ctx, &user)
+	if err != nil {
+		internalError(c, "Failed to sign in", err)
+		return
+	}
+	h.recordLogin(c, user.ID, "ldap")
+
+	h.respondLogin(c, http.StatusOK, "login successful", resp)
+}
+
+// OIDCLogin signs a user in through institutional SSO. Clients send either
+// the ID token they obtained from the provider or the authorization code to
+// be redeemed here. The first sign-in links the SSO identity to the account
+// with the same (verified) email, creating the account if there is none;
+// later sign-ins follow the link even if the email changes. Roles are synced
+// from the configured claim as for LDAP.
+// POST /api/v1/auth/oidc
+func (h *AuthHandler) OIDCLogin(c *gin.Context) {
+	var req models.OIDCLoginRequest
+	if err := bindJSON(c, &req); err != nil || (req.IDToken == "") == (req.Code == "") ||
+		(req.Code != "" && req.RedirectURI == "") {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("provide either id_token, or code and redirect_uri"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	oidc := h.providers.OIDC
+	var identity *auth.OIDCIdentity
+	var err error
+	if req.IDToken != "" {
+		identity, err = oidc.VerifyIDToken(ctx, req.IDToken, req.Nonce)
+	} else {
+		identity, err = oidc.Exchange(ctx, req.Code, req.RedirectURI, req.CodeVerifier, req.Nonce)
+	}
+	if errors.Is(err, auth.ErrInvalidToken) {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid or expired sign-in"),
+		})
+		return
+	}
+	if errors.Is(err, auth.ErrOIDCEmailUnverified) {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("identity provider has not verified your email address"),
+		})
+		return
+	}
+	if err != nil {
+		requestID := logInternalError(c, "OIDC login failed", err)
+		c.JSON(http.StatusServiceUnavailable, models.APIResponse{
+			Success:   false,
+			Error:     strPtr("identity provider unavailable"),
+			RequestID: requestID,
+		})
+		return
+	}
+
+	user, err := h.findOrLinkSSOUser(ctx, identity)
+	if err != nil {
+		internalError(c, "Failed to sign in", err)
+		return
+	}
+
+	resp, err := h.issueLogin(ctx, &user)
+	if err != nil {
+		internalError(c, "Failed to sign in", err)
+		return
+	}
+	h.recordLogin(c, user.ID, "oidc")
+
+	h.respondLogin(c, http.StatusOK, "login successful", resp)
+}
+
+// findOrLinkSSOUser returns the account linked to an SSO identity, linking
+// or provisioning one by email on first sign-in
+func (h *AuthHandler) findOrLinkSSOUser(ctx context.Context, identity *auth.OIDCIdentity) (models.User, error) {
+	var user models.User
+	err := h.db.QueryRowContext(ctx, `
+		SELECT u.id, u.email, u.full_name, u.role, u.avatar_url, u.department, u.year, u.created_at, u.updated_at
+		FROM user_identities i
+		JOIN users u ON u.id = i.user_id
+		WHERE i.provider = $1 AND i.subject = $2 AND u.deleted_at IS NULL
+	`, identity.Issuer, identity.Subject).Scan(
+		&user.ID, &user.Email, &user.FullName, &user.Role,
+		&user.AvatarURL, &user.Department, &user.Year, &user.CreatedAt, &user.UpdatedAt,
+	)
+	switch {
+	case err == nil:
+		if err := h.syncRole(ctx, &user, identity.Role); err != nil {
+			return user, err
+		}
+	case err == sql.ErrNoRows:
+		user, err = h.findOrCreateDirectoryUser(ctx, identity.Email, identity.FullName, identity.Role, "oidc")
+		if err != nil {
+			return user, err
+		}
+	default:
+		return user, err
+	}
+
+	_, err = h.db.ExecContext(ctx, `
+		INSERT INTO user_identities (provider, subject, user_id, email)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (provider, subject)
+		DO UPDATE SET user_id = EXCLUDED.user_id, email = EXCLUDED.email, last_login_at = NOW()
+	`, identity.Issuer, identity.Subject, user.ID, identity.Email)
+	return user, err
+}
+
+// findOrCreateDirectoryUser returns the account with email, creating it for
+// a first login. A non-empty role overrides the account's current one.
+func (h *AuthHandler) findOrCreateDirectoryUser(ctx context.Context, email, fullName string, role models.UserRole, method string) (models.User, error) {
+	var user models.User
+	err := h.db.QueryRowContext(ctx, `
+		SELECT id, email, full_name, role, avatar_url, department, year, created_at, updated_at
+		FROM users
+		WHERE email = $1 AND deleted_at IS NULL
+	`, email).Scan(
+		&user.ID, &user.Email, &user.FullName, &user.Role,
+		&user.AvatarURL, &user.Department, &user.Year, &user.CreatedAt, &user.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		// Directory users never sign in with a password here
+		passwordHash, err := h.authService.HashPassword(uuid.New().String())
+		if err != nil {
+			return user, err
+		}
+		user, err = h.createUser(ctx, email, passwordHash, fullName, nil, nil, method)
+		if err != nil {
+			return user, err
+		}
+	} else if err != nil {
+		return user, err
+	}
+
+	return user, h.syncRole(ctx, &user, role)
+}
+
+// syncRole sets the user's role to one mapped from their directory or SSO
+// groups. An empty role leaves it as it is, as does student for a graduate
+// whose directory account hasn't caught up.
+func (h *AuthHandler) syncRole(ctx context.Context, user *models.User, role models.UserRole) error {
+	if role == "" || role == user.Role {
+		return nil
+	}
+	if role == models.RoleStudent && user.Role == models.RoleAlumni {
+		return nil
+	}
+	return h.db.QueryRowContext(ctx,
+		"UPDATE users SET role = $2 WHERE id = $1 RETURNING role, updated_at",
+		user.ID, role,
+	).Scan(&user.Role, &user.UpdatedAt)
+}
+
+// issueLogin creates and stores a new access and refresh token pair
+func (h *AuthHandler) issueLogin(ctx context.Context, user *models.User) (models.LoginResponse, error) {
+	accessToken, err := h.authService.GenerateAccessToken(user)
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+	refreshToken, expiresAt, err := h.authService.GenerateRefreshToken()
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+	_, err = h.db.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (user_id, token, expires_at)
+		VALUES ($1, $2, $3)
+	`, user.ID, refreshToken, expiresAt)
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	return models.LoginResponse{
+		User:         *user,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
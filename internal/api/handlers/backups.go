@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/audit"
+	"github.com/yourusername/college-event-backend/internal/backup"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// BackupHandler lets admins see the database backups and ask for one. The
+// backups themselves are taken by cmd/backup.
+type BackupHandler struct {
+	db *database.DB
+}
+
+// NewBackupHandler creates a new BackupHandler
+func NewBackupHandler(db *database.DB) *BackupHandler {
+	return &BackupHandler{db: db}
+}
+
+const backupColumns = `id, status, source, requested_by, storage_provider, path, size_bytes, error,
+	started_at, completed_at, created_at`
+
+func scanBackup(row interface{ Scan(...interface{}) error }) (models.Backup, error) {
+	var b models.Backup
+	err := row.Scan(&b.ID, &b.Status, &b.Source, &b.RequestedBy, &b.StorageProvider, &b.Path, &b.SizeBytes,
+		&b.Error, &b.StartedAt, &b.CompletedAt, &b.CreatedAt)
+	return b, err
+}
+
+// ListBackups returns backups, newest first, optionally with one status
+// GET /api/v1/admin/backups
+func (h *BackupHandler) ListBackups(c *gin.Context) {
+	var query models.ListBackupsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid query parameters"),
+		})
+		return
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 || query.PageSize > 100 {
+		query.PageSize = 50
+	}
+
+	ctx := c.Request.Context()
+	db := h.db.Reader()
+	const where = ` WHERE ($1 = '' OR status = $1)`
+
+	var total int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM backups"+where, query.Status).Scan(&total); err != nil {
+		internalError(c, "Failed to fetch backups", err)
+		return
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+backupColumns+`
+		FROM backups`+where+`
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`, query.Status, query.PageSize, (query.Page-1)*query.PageSize)
+	if err != nil {
+		internalError(c, "Failed to fetch backups", err)
+		return
+	}
+	defer rows.Close()
+
+	backups := []models.Backup{}
+	for rows.Next() {
+		b, err := scanBackup(rows)
+		if err != nil {
+			internalError(c, "Failed to fetch backups", err)
+			return
+		}
+		backups = append(backups, b)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch backups", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: models.PaginatedResponse{
+			Data:       backups,
+			Page:       query.Page,
+			PageSize:   query.PageSize,
+			TotalItems: int64(total),
+			TotalPages: (total + query.PageSize - 1) / query.PageSize,
+		},
+	})
+}
+
+// RequestBackup queues a backup, e.g. before a risky change. cmd/backup
+// --schedule takes it within a minute; until then it stays requested.
+// POST /api/v1/admin/backups
+func (h *BackupHandler) RequestBackup(c *gin.Context) {
+	ctx := c.Request.Context()
+	adminID, _ := middleware.UserID(c)
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to request backup", err)
+		return
+	}
+	defer tx.Rollback()
+
+	b, err := scanBackup(tx.QueryRowContext(ctx, `
+		INSERT INTO backups (status, source, requested_by)
+		SELECT 'requested', $1, $2
+		WHERE NOT EXISTS (SELECT 1 FROM backups WHERE status IN ('requested', 'running'))
+		RETURNING `+backupColumns,
+		backup.SourceAdmin, adminID))
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("A backup is already requested or running"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to request backup", err)
+		return
+	}
+
+	if err := audit.Record(ctx, tx, audit.Entry{
+		Action:    audit.ActionBackupRequest,
+		ActorID:   &adminID,
+		Method:    c.Request.Method,
+		Path:      c.Request.URL.RequestURI(),
+		Status:    http.StatusAccepted,
+		RequestID: middleware.GetRequestID(c),
+		IPAddress: c.ClientIP(),
+		Details:   map[string]interface{}{"backup_id": b.ID},
+	}); err != nil {
+		internalError(c, "Failed to request backup", err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to request backup", err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, models.APIResponse{
+		Success: true,
+		Message: "Backup requested",
+		Data:    b,
+	})
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+)
+
+// unknownFieldsError names every field of a JSON body its endpoint doesn't
+// know
+type unknownFieldsError struct {
+	fields []string
+}
+
+func (e unknownFieldsError) Error() string {
+	quoted := make([]string, len(e.fields))
+	for i, f := range e.fields {
+		quoted[i] = strconv.Quote(f)
+	}
+	if len(quoted) == 1 {
+		return "unknown field " + quoted[0]
+	}
+	return "unknown fields " + strings.Join(quoted, ", ")
+}
+
+// bindJSON binds and validates the request's JSON body into obj, like
+// ShouldBindJSON. On strict JSON routes (see middleware.StrictJSON) a body
+// with fields obj doesn't have is rejected, naming them all, instead of
+// having them silently dropped.
+func bindJSON(c *gin.Context, obj interface{}) error {
+	if middleware.IsStrictJSON(c) && c.Request.Body != nil {
+		data, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return err
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(data))
+
+		if len(bytes.TrimSpace(data)) > 0 {
+			if unknown := unknownJSONFields(data, reflect.TypeOf(obj), ""); len(unknown) > 0 {
+				return unknownFieldsError{fields: unknown}
+			}
+		}
+	}
+	return c.ShouldBindJSON(obj)
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// unknownJSONFields returns the paths of the fields in data that t has no
+// field for, such as "tiers[1].prise". Keys match fields without regard to
+// case, as encoding/json matches them. Values that don't fit t at all are
+// left for binding to report.
+func unknownJSONFields(data []byte, t reflect.Type, path string) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return nil
+	}
+
+	var unknown []string
+	switch t.Kind() {
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(data, &obj) != nil {
+			return nil
+		}
+		fields := jsonFields(t)
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			ft, ok := fields[strings.ToLower(k)]
+			if !ok {
+				unknown = append(unknown, joinJSONPath(path, k))
+				continue
+			}
+			unknown = append(unknown, unknownJSONFields(obj[k], ft, joinJSONPath(path, k))...)
+		}
+
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return nil // Base64 bytes
+		}
+		var items []json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return nil
+		}
+		for i, item := range items {
+			unknown = append(unknown, unknownJSONFields(item, t.Elem(), path+"["+strconv.Itoa(i)+"]")...)
+		}
+
+	case reflect.Map:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(data, &obj) != nil {
+			return nil
+		}
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			unknown = append(unknown, unknownJSONFields(obj[k], t.Elem(), joinJSONPath(path, k))...)
+		}
+	}
+	return unknown
+}
+
+// jsonFields maps the lowercased JSON names of a struct's fields, including
+// those of embedded structs, to their types
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k, v := range jsonFields(ft) {
+					if _, ok := fields[k]; !ok {
+						fields[k] = v
+					}
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+	return fields
+}
+
+func joinJSONPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+type bindTestBase struct {
+	Name string `json:"name"`
+}
+
+type bindTestTier struct {
+	Price float64 `json:"price"`
+}
+
+type bindTestRequest struct {
+	bindTestBase
+	Tiers    []bindTestTier          `json:"tiers"`
+	Extra    map[string]bindTestTier `json:"extra"`
+	Starts   *time.Time              `json:"starts"`
+	Ignored  string                  `json:"-"`
+	Untagged int
+}
+
+func TestUnknownJSONFields(t *testing.T) {
+	for _, tc := range []struct {
+		body string
+		want []string
+	}{
+		{`{"name": "x", "tiers": [{"price": 1}], "starts": "2026-01-01T00:00:00Z", "untagged": 1}`, nil},
+		{`{"NAME": "x"}`, nil},
+		{`{"nmae": "x", "Ignored": "y"}`, []string{"Ignored", "nmae"}},
+		{`{"tiers": [{"price": 1}, {"prise": 2}]}`, []string{"tiers[1].prise"}},
+		{`{"extra": {"vip": {"price": 1, "seats": 2}}}`, []string{"extra.vip.seats"}},
+		{`{"tiers": "not a list"}`, nil},
+	} {
+		got := unknownJSONFields([]byte(tc.body), reflect.TypeOf(&bindTestRequest{}), "")
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("unknownJSONFields(%s) = %q, want %q", tc.body, got, tc.want)
+		}
+	}
+}
+
+func TestBindJSONStrict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	body := `{"title": "Hackathon", "start_date": "2026-03-01T10:00:00Z", "end_date": "2026-03-01T18:00:00Z", "max_participants": 100}`
+
+	bind := func(strict bool) (models.CreateEventRequest, error) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		if strict {
+			middleware.StrictJSON()(c)
+		}
+		var req models.CreateEventRequest
+		return req, bindJSON(c, &req)
+	}
+
+	req, err := bind(false)
+	if err != nil || req.Title != "Hackathon" {
+		t.Fatalf("lenient bind = %+v, %v", req, err)
+	}
+
+	_, err = bind(true)
+	if err == nil || err.Error() != `unknown field "max_participants"` {
+		t.Errorf("strict bind error = %v", err)
+	}
+}
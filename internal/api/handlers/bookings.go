@@ -0,0 +1,605 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+const resourceColumns = `
+	id, name, kind, description, location, capacity, is_active, created_at, updated_at`
+
+func scanResource(row interface{ Scan(...interface{}) error }) (models.Resource, error) {
+	var r models.Resource
+	err := row.Scan(
+		&r.ID, &r.Name, &r.Kind, &r.Description, &r.Location, &r.Capacity, &r.IsActive, &r.CreatedAt, &r.UpdatedAt,
+	)
+	return r, err
+}
+
+const bookingColumns = `
+	id, resource_id, club_id, event_id, requested_by, starts_at, ends_at, purpose, status,
+	reviewed_by, reviewed_at, review_note, created_at, updated_at`
+
+func scanBooking(row interface{ Scan(...interface{}) error }) (models.ResourceBooking, error) {
+	var b models.ResourceBooking
+	err := row.Scan(
+		&b.ID, &b.ResourceID, &b.ClubID, &b.EventID, &b.RequestedBy, &b.StartsAt, &b.EndsAt, &b.Purpose, &b.Status,
+		&b.ReviewedBy, &b.ReviewedAt, &b.ReviewNote, &b.CreatedAt, &b.UpdatedAt,
+	)
+	return b, err
+}
+
+// queryRower is satisfied by *sql.DB and *sql.Tx
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// bookingConflict returns the ID of an approved booking of the resource that
+// overlaps [start, end), ignoring the booking being checked. Slots are
+// half-open, so back-to-back bookings don't conflict.
+func bookingConflict(ctx context.Context, q queryRower, resourceID uuid.UUID, start, end time.Time, ignore uuid.UUID) (*uuid.UUID, error) {
+	var id uuid.UUID
+	err := q.QueryRowContext(ctx, `
+		SELECT id FROM resource_bookings
+		WHERE resource_id = $1 AND status = 'approved' AND starts_at < $3 AND ends_at > $2 AND id <> $4
+		ORDER BY starts_at
+		LIMIT 1
+	`, resourceID, start, end, ignore).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// BookingHandler handles resources and club booking requests
+type BookingHandler struct {
+	db *database.DB
+}
+
+// NewBookingHandler creates a new booking handler
+func NewBookingHandler(db *database.DB) *BookingHandler {
+	return &BookingHandler{db: db}
+}
+
+// ============================================================================
+// RESOURCES
+// ============================================================================
+
+// ListResources lists active resources, or all resources with include_inactive=true
+// GET /api/v1/resources
+func (h *BookingHandler) ListResources(c *gin.Context) {
+	includeInactive := c.Query("include_inactive") == "true"
+
+	rows, err := h.db.QueryContext(c.Request.Context(), `
+		SELECT `+resourceColumns+` FROM resources
+		WHERE is_active OR $1
+		ORDER BY kind, name
+	`, includeInactive)
+	if err != nil {
+		internalError(c, "Failed to fetch resources", err)
+		return
+	}
+	defer rows.Close()
+
+	resources := []models.Resource{}
+	for rows.Next() {
+		r, err := scanResource(rows)
+		if err != nil {
+			internalError(c, "Failed to fetch resources", err)
+			return
+		}
+		resources = append(resources, r)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch resources", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    resources,
+	})
+}
+
+// GetResourceAvailability returns a resource's approved bookings and pending
+// requests bucketed by day
+// GET /api/v1/resources/:id/availability?from=2025-03-01&to=2025-03-31
+func (h *BookingHandler) GetResourceAvailability(c *gin.Context) {
+	resourceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid resource ID"),
+		})
+		return
+	}
+
+	from, last, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+	to := last.AddDate(0, 0, 1)
+	ctx := c.Request.Context()
+
+	var exists bool
+	if err := h.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM resources WHERE id = $1)", resourceID).Scan(&exists); err != nil {
+		internalError(c, "Failed to fetch availability", err)
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Resource not found"),
+		})
+		return
+	}
+
+	rows, err := h.db.QueryContext(ctx, `
+		SELECT b.id, b.club_id, cl.name, b.starts_at, b.ends_at, b.status
+		FROM resource_bookings b
+		JOIN clubs cl ON cl.id = b.club_id
+		WHERE b.resource_id = $1 AND b.status IN ('pending', 'approved')
+		  AND b.starts_at < $3 AND b.ends_at > $2
+		ORDER BY b.starts_at
+	`, resourceID, from, to)
+	if err != nil {
+		internalError(c, "Failed to fetch availability", err)
+		return
+	}
+	defer rows.Close()
+
+	cal := newCalendarBuilder(from, to)
+	for rows.Next() {
+		var item models.CalendarItem
+		var clubID uuid.UUID
+		var start, end time.Time
+		var status string
+		if err := rows.Scan(&item.ID, &clubID, &item.Title, &start, &end, &status); err != nil {
+			internalError(c, "Failed to fetch availability", err)
+			return
+		}
+		item.Type = models.CalendarItemBooking
+		if status == models.BookingPending {
+			item.Type = models.CalendarItemBookingRequest
+		}
+		item.StartsAt, item.EndsAt = &start, &end
+		item.ClubID = &clubID
+		cal.addSpan(start, end, item)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch availability", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: models.ResourceAvailabilityResponse{
+			ResourceID: resourceID,
+			From:       from.Format(dateLayout),
+			To:         last.Format(dateLayout),
+			Days:       cal.result(),
+		},
+	})
+}
+
+// CreateResource adds a bookable resource (admin only)
+// POST /api/v1/admin/resources
+func (h *BookingHandler) CreateResource(c *gin.Context) {
+	var req models.CreateResourceRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	r, err := scanResource(h.db.QueryRowContext(c.Request.Context(), `
+		INSERT INTO resources (name, kind, description, location, capacity)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+resourceColumns,
+		req.Name, req.Kind, req.Description, req.Location, req.Capacity))
+	if err != nil {
+		internalError(c, "Failed to create resource", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Resource created successfully",
+		Data:    r,
+	})
+}
+
+// UpdateResource edits or deactivates a resource (admin only)
+// PUT /api/v1/admin/resources/:id
+func (h *BookingHandler) UpdateResource(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid resource ID"),
+		})
+		return
+	}
+
+	var req models.UpdateResourceRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	r, err := scanResource(h.db.QueryRowContext(c.Request.Context(), `
+		UPDATE resources
+		SET name = COALESCE($1, name),
+		    description = COALESCE($2, description),
+		    location = COALESCE($3, location),
+		    capacity = COALESCE($4, capacity),
+		    is_active = COALESCE($5, is_active)
+		WHERE id = $6
+		RETURNING `+resourceColumns,
+		req.Name, req.Description, req.Location, req.Capacity, req.IsActive, id))
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Resource not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to update resource", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Resource updated successfully",
+		Data:    r,
+	})
+}
+
+// ============================================================================
+// CLUB BOOKINGS
+// ============================================================================
+
+// CreateClubBooking requests a resource for a time slot (club leads only).
+// Slots that overlap an approved booking are rejected up front; overlapping
+// pending requests are allowed and settled at review.
+// POST /api/v1/clubs/:id/bookings
+func (h *BookingHandler) CreateClubBooking(c *gin.Context) {
+	clubID, ok := h.requireClubLead(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateBookingRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("ends_at must be after starts_at"),
+		})
+		return
+	}
+	if req.StartsAt.Before(time.Now()) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Bookings must start in the future"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	var active bool
+	err := h.db.QueryRowContext(ctx, "SELECT is_active FROM resources WHERE id = $1", req.ResourceID).Scan(&active)
+	if err == sql.ErrNoRows || (err == nil && !active) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Resource not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to create booking", err)
+		return
+	}
+
+	if req.EventID != nil {
+		var eventClubID *uuid.UUID
+		err := h.db.QueryRowContext(ctx,
+			"SELECT club_id FROM events WHERE id = $1 AND deleted_at IS NULL", *req.EventID,
+		).Scan(&eventClubID)
+		if err != nil && err != sql.ErrNoRows {
+			internalError(c, "Failed to create booking", err)
+			return
+		}
+		if err == sql.ErrNoRows || eventClubID == nil || *eventClubID != clubID {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Event not found for this club"),
+			})
+			return
+		}
+	}
+
+	conflict, err := bookingConflict(ctx, h.db, req.ResourceID, req.StartsAt, req.EndsAt, uuid.Nil)
+	if err != nil {
+		internalError(c, "Failed to create booking", err)
+		return
+	}
+	if conflict != nil {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Resource is already booked for part of this time"),
+			Data:    gin.H{"conflicting_booking_id": conflict},
+		})
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+	b, err := scanBooking(h.db.QueryRowContext(ctx, `
+		INSERT INTO resource_bookings (resource_id, club_id, event_id, requested_by, starts_at, ends_at, purpose)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+bookingColumns,
+		req.ResourceID, clubID, req.EventID, userID, req.StartsAt, req.EndsAt, req.Purpose))
+	if err != nil {
+		internalError(c, "Failed to create booking", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Booking requested",
+		Data:    b,
+	})
+}
+
+// ListClubBookings lists a club's bookings, newest first (club leads only)
+// GET /api/v1/clubs/:id/bookings
+func (h *BookingHandler) ListClubBookings(c *gin.Context) {
+	clubID, ok := h.requireClubLead(c)
+	if !ok {
+		return
+	}
+
+	h.list(c, `
+		SELECT `+bookingColumns+` FROM resource_bookings
+		WHERE club_id = $1
+		ORDER BY starts_at DESC
+		LIMIT 200
+	`, clubID)
+}
+
+// CancelClubBooking withdraws a pending or approved booking (club leads only)
+// DELETE /api/v1/clubs/:id/bookings/:booking_id
+func (h *BookingHandler) CancelClubBooking(c *gin.Context) {
+	clubID, ok := h.requireClubLead(c)
+	if !ok {
+		return
+	}
+
+	bookingID, err := uuid.Parse(c.Param("booking_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid booking ID"),
+		})
+		return
+	}
+
+	result, err := h.db.ExecContext(c.Request.Context(), `
+		UPDATE resource_bookings SET status = 'cancelled'
+		WHERE id = $1 AND club_id = $2 AND status IN ('pending', 'approved')
+	`, bookingID, clubID)
+	if err != nil {
+		internalError(c, "Failed to cancel booking", err)
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Active booking not found"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Booking cancelled",
+	})
+}
+
+// ============================================================================
+// ADMIN REVIEW
+// ============================================================================
+
+// ListBookings lists bookings by status, pending by default (admin only)
+// GET /api/v1/admin/bookings?status=pending
+func (h *BookingHandler) ListBookings(c *gin.Context) {
+	status := c.DefaultQuery("status", models.BookingPending)
+
+	h.list(c, `
+		SELECT `+bookingColumns+` FROM resource_bookings
+		WHERE status = $1
+		ORDER BY starts_at ASC
+		LIMIT 200
+	`, status)
+}
+
+// ReviewBooking approves or rejects a pending booking (admin only). Approval
+// re-checks for conflicts with the resource locked, so two overlapping
+// requests can't both be approved.
+// PUT /api/v1/admin/bookings/:id
+func (h *BookingHandler) ReviewBooking(c *gin.Context) {
+	bookingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid booking ID"),
+		})
+		return
+	}
+
+	var req models.ReviewBookingRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	adminID, _ := middleware.UserID(c)
+	ctx := c.Request.Context()
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to review booking", err)
+		return
+	}
+	defer tx.Rollback()
+
+	var resourceID uuid.UUID
+	var start, end time.Time
+	var status string
+	err = tx.QueryRowContext(ctx,
+		"SELECT resource_id, starts_at, ends_at, status FROM resource_bookings WHERE id = $1", bookingID,
+	).Scan(&resourceID, &start, &end, &status)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Booking not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to review booking", err)
+		return
+	}
+	if status != models.BookingPending {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Booking has already been " + status),
+		})
+		return
+	}
+
+	if req.Status == models.BookingApproved {
+		// Serialise approvals per resource
+		if _, err := tx.ExecContext(ctx, "SELECT 1 FROM resources WHERE id = $1 FOR UPDATE", resourceID); err != nil {
+			internalError(c, "Failed to review booking", err)
+			return
+		}
+		conflict, err := bookingConflict(ctx, tx, resourceID, start, end, bookingID)
+		if err != nil {
+			internalError(c, "Failed to review booking", err)
+			return
+		}
+		if conflict != nil {
+			c.JSON(http.StatusConflict, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Resource is already booked for part of this time"),
+				Data:    gin.H{"conflicting_booking_id": conflict},
+			})
+			return
+		}
+	}
+
+	b, err := scanBooking(tx.QueryRowContext(ctx, `
+		UPDATE resource_bookings
+		SET status = $1, review_note = $2, reviewed_by = $3, reviewed_at = NOW()
+		WHERE id = $4
+		RETURNING `+bookingColumns,
+		req.Status, req.Note, adminID, bookingID))
+	if err != nil {
+		internalError(c, "Failed to review booking", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to review booking", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Booking " + req.Status,
+		Data:    b,
+	})
+}
+
+// requireClubLead parses the club ID and checks the user can manage the
+// club. Returns false if the request has been answered.
+func (h *BookingHandler) requireClubLead(c *gin.Context) (uuid.UUID, bool) {
+	clubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid club ID"),
+		})
+		return uuid.Nil, false
+	}
+
+	ok, err := canManageClub(c, h.db.DB, clubID)
+	if err != nil {
+		internalError(c, "Failed to check club role", err)
+		return uuid.Nil, false
+	}
+	if !ok {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Only club leads can manage bookings"),
+		})
+		return uuid.Nil, false
+	}
+	return clubID, true
+}
+
+func (h *BookingHandler) list(c *gin.Context, query string, args ...interface{}) {
+	rows, err := h.db.QueryContext(c.Request.Context(), query, args...)
+	if err != nil {
+		internalError(c, "Failed to fetch bookings", err)
+		return
+	}
+	defer rows.Close()
+
+	bookings := []models.ResourceBooking{}
+	for rows.Next() {
+		b, err := scanBooking(rows)
+		if err != nil {
+			internalError(c, "Failed to fetch bookings", err)
+			return
+		}
+		bookings = append(bookings, b)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch bookings", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    bookings,
+	})
+}
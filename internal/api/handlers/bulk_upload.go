@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+const (
+	// maxImageBytes bounds each image, as for a single upload
+	maxImageBytes = 10 << 20
+	// maxBulkUploadFiles bounds the files in one bulk upload request
+	maxBulkUploadFiles = 50
+	// uploadConcurrency is how many images of a bulk upload are optimized
+	// and stored at once. Resizing is CPU-bound, so more mostly queues up.
+	uploadConcurrency = 4
+)
+
+// forEachConcurrently calls fn for 0..n-1 with at most limit calls running
+// at once, and returns when all are done
+func forEachConcurrently(n, limit int, fn func(i int)) {
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// UploadImages uploads many images at once, optimizing several
+// concurrently, and reports each file's result in the order sent. Files
+// that fail don't stop the rest.
+// POST /api/v1/admin/upload/bulk
+// Form fields:
+//   - files: the image files (required, up to 50, max 10MB each)
+//   - folder, type: as for UploadImage, applied to every file
+func (h *UploadHandler) UploadImages(c *gin.Context) {
+	if err := c.Request.ParseMultipartForm(32 << 20); err != nil {
+		if strings.Contains(err.Error(), "http: request body too large") {
+			c.JSON(http.StatusRequestEntityTooLarge, models.APIResponse{
+				Success: false,
+				Error:   strPtr("upload too large: send the files in smaller batches"),
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid form data"),
+		})
+		return
+	}
+	defer c.Request.MultipartForm.RemoveAll()
+
+	files := c.Request.MultipartForm.File["files"]
+	if len(files) == 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("no files provided"),
+		})
+		return
+	}
+	if len(files) > maxBulkUploadFiles {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(fmt.Sprintf("at most %d files can be uploaded at once", maxBulkUploadFiles)),
+		})
+		return
+	}
+	folder, imageType := uploadOptions(c)
+
+	ctx := c.Request.Context()
+	results := make([]models.UploadedImage, len(files))
+	forEachConcurrently(len(files), uploadConcurrency, func(i int) {
+		header := files[i]
+		r := &results[i]
+		r.Filename = header.Filename
+
+		if header.Size > maxImageBytes {
+			r.Error = "file too large: maximum size is 10MB"
+			return
+		}
+		if !isValidImageType(header.Header.Get("Content-Type")) {
+			r.Error = "invalid file type: allowed are JPEG, PNG, GIF and WebP"
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			r.Error = "failed to read file"
+			return
+		}
+		defer file.Close()
+
+		uploaded, err := h.storage.UploadImage(ctx, file, header.Filename, folder, imageType)
+		if err != nil {
+			log.Printf("Bulk upload of %q failed: %v", header.Filename, err)
+			r.Error = "failed to upload image"
+			return
+		}
+		r.Success = true
+		r.URL, r.Path, r.SizeBytes = uploaded.URL, uploaded.Path, uploaded.SizeBytes
+		r.Width, r.Height = uploaded.Width, uploaded.Height
+	})
+
+	result := models.BulkUploadResult{Results: results}
+	for _, r := range results {
+		if r.Success {
+			result.Uploaded++
+		} else {
+			result.Failed++
+		}
+	}
+
+	status := http.StatusOK
+	if result.Uploaded == 0 {
+		status = http.StatusBadRequest
+	}
+	c.JSON(status, models.APIResponse{
+		Success: result.Uploaded > 0,
+		Message: fmt.Sprintf("%d of %d images uploaded", result.Uploaded, len(files)),
+		Data:    result,
+	})
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEachConcurrently(t *testing.T) {
+	const n, limit = 20, 3
+	var running, peak int32
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+
+	forEachConcurrently(n, limit, func(i int) {
+		now := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if now <= p || atomic.CompareAndSwapInt32(&peak, p, now) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		seen[i] = true
+		mu.Unlock()
+		atomic.AddInt32(&running, -1)
+	})
+
+	if len(seen) != n {
+		t.Errorf("called for %d indexes, want %d", len(seen), n)
+	}
+	if peak > limit {
+		t.Errorf("%d calls ran at once, want at most %d", peak, limit)
+	}
+}
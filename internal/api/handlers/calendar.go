@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+const dateLayout = "2006-01-02"
+
+// calendarBuilder buckets items into days within [from, to). Days are
+// computed in the server's local time zone, which is the campus time zone.
+type calendarBuilder struct {
+	from time.Time
+	to   time.Time
+	days map[string][]models.CalendarItem
+}
+
+func newCalendarBuilder(from, to time.Time) *calendarBuilder {
+	return &calendarBuilder{
+		from: startOfDay(from),
+		to:   startOfDay(to),
+		days: make(map[string][]models.CalendarItem),
+	}
+}
+
+// add places an item on a single day
+func (b *calendarBuilder) add(day time.Time, item models.CalendarItem) {
+	day = startOfDay(day)
+	if day.Before(b.from) || !day.Before(b.to) {
+		return
+	}
+	key := day.Format(dateLayout)
+	b.days[key] = append(b.days[key], item)
+}
+
+// addSpan places an item on every day from start to end inclusive, so
+// multi-day events show on each day they run
+func (b *calendarBuilder) addSpan(start, end time.Time, item models.CalendarItem) {
+	day := startOfDay(start)
+	if day.Before(b.from) {
+		day = b.from
+	}
+	last := startOfDay(end)
+	for !day.After(last) && day.Before(b.to) {
+		b.add(day, item)
+		day = day.AddDate(0, 0, 1)
+	}
+}
+
+// result returns the date-keyed items, all-day items first, then by start time
+func (b *calendarBuilder) result() map[string][]models.CalendarItem {
+	for _, items := range b.days {
+		sort.SliceStable(items, func(i, j int) bool {
+			a, c := items[i], items[j]
+			if a.AllDay != c.AllDay {
+				return a.AllDay
+			}
+			if a.StartsAt == nil || c.StartsAt == nil {
+				return false
+			}
+			return a.StartsAt.Before(*c.StartsAt)
+		})
+	}
+	return b.days
+}
+
+// startOfDay truncates t to local midnight
+func startOfDay(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// dateOnly reinterprets a DATE column (returned by the driver as UTC midnight)
+// as local midnight on the same calendar date
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// atClock combines a DATE column with a TIME column rendered as text
+// ("15:04:05"). Returns nil if clock is missing or malformed.
+func atClock(date time.Time, clock *string) *time.Time {
+	if clock == nil {
+		return nil
+	}
+	t, err := time.Parse("15:04:05", *clock)
+	if err != nil {
+		return nil
+	}
+	at := time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local)
+	return &at
+}
+
+// addHouseEvents adds every house's events dated within [from, to)
+func addHouseEvents(ctx context.Context, db *sql.DB, cal *calendarBuilder, from, to time.Time) error {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, house_id, title, event_date, start_time::text, end_time::text, venue
+		FROM house_events
+		WHERE deleted_at IS NULL AND event_date >= $1::date AND event_date < $2::date
+	`, from.Format(dateLayout), to.Format(dateLayout))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.CalendarItem
+		var houseID uuid.UUID
+		var date time.Time
+		var startTime, endTime *string
+		if err := rows.Scan(&item.ID, &houseID, &item.Title, &date, &startTime, &endTime, &item.Location); err != nil {
+			return err
+		}
+		date = dateOnly(date)
+		item.Type = models.CalendarItemHouseEvent
+		item.HouseID = &houseID
+		item.StartsAt = atClock(date, startTime)
+		item.EndsAt = atClock(date, endTime)
+		item.AllDay = item.StartsAt == nil
+		cal.add(date, item)
+	}
+	return rows.Err()
+}
+
+// addEvents adds every event running within [from, to) and, when
+// withDeadlines is set, each event's registration deadline. Alumni events
+// are added only with alumniEvents.
+func addEvents(ctx context.Context, db *sql.DB, cal *calendarBuilder, from, to time.Time, withDeadlines, alumniEvents bool) error {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, title, start_date, end_date, location, club_id, registration_deadline
+		FROM events
+		WHERE deleted_at IS NULL
+		  AND ((start_date < $2 AND end_date >= $1)
+		       OR (registration_deadline >= $1 AND registration_deadline < $2))
+		  AND (alumni_access <> 'only' OR $3)
+		ORDER BY start_date ASC
+	`, from, to, alumniEvents)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.CalendarItem
+		var start, end time.Time
+		var deadline *time.Time
+		if err := rows.Scan(&item.ID, &item.Title, &start, &end, &item.Location, &item.ClubID, &deadline); err != nil {
+			return err
+		}
+		item.Type = models.CalendarItemEvent
+		item.StartsAt, item.EndsAt = &start, &end
+		cal.addSpan(start, end, item)
+
+		if withDeadlines && deadline != nil {
+			cal.add(*deadline, models.CalendarItem{
+				Type:     models.CalendarItemRegistrationDeadline,
+				ID:       item.ID,
+				Title:    item.Title,
+				StartsAt: deadline,
+				ClubID:   item.ClubID,
+			})
+		}
+	}
+	return rows.Err()
+}
+
+// addOfficialSchedules adds official schedules dated within [from, to)
+func addOfficialSchedules(ctx context.Context, db *sql.DB, cal *calendarBuilder, from, to time.Time) error {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, title, schedule_date, start_time::text, end_time::text, location
+		FROM schedules
+		WHERE schedule_type = 'official' AND schedule_date >= $1::date AND schedule_date < $2::date
+	`, from.Format(dateLayout), to.Format(dateLayout))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.CalendarItem
+		var date time.Time
+		var startTime, endTime *string
+		if err := rows.Scan(&item.ID, &item.Title, &date, &startTime, &endTime, &item.Location); err != nil {
+			return err
+		}
+		date = dateOnly(date)
+		item.Type = models.CalendarItemSchedule
+		item.StartsAt = atClock(date, startTime)
+		item.EndsAt = atClock(date, endTime)
+		item.AllDay = item.StartsAt == nil
+		cal.add(date, item)
+	}
+	return rows.Err()
+}
+
+// maxCalendarDays bounds the range of a single calendar request
+const maxCalendarDays = 92
+
+// parseDateRange reads the inclusive from and to dates (YYYY-MM-DD) of a
+// calendar request, defaulting to the 30 days from today. Returns false if
+// the request has been answered.
+func parseDateRange(c *gin.Context) (from, last time.Time, ok bool) {
+	from = startOfDay(time.Now())
+	if s := c.Query("from"); s != "" {
+		t, err := time.ParseInLocation(dateLayout, s, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("invalid from date, use YYYY-MM-DD"),
+			})
+			return from, last, false
+		}
+		from = t
+	}
+
+	last = from.AddDate(0, 0, 29)
+	if s := c.Query("to"); s != "" {
+		t, err := time.ParseInLocation(dateLayout, s, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("invalid to date, use YYYY-MM-DD"),
+			})
+			return from, last, false
+		}
+		last = t
+	}
+	if last.Before(from) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("to must not be before from"),
+		})
+		return from, last, false
+	}
+	if last.AddDate(0, 0, 1).After(from.AddDate(0, 0, maxCalendarDays)) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("date range is limited to 92 days"),
+		})
+		return from, last, false
+	}
+	return from, last, true
+}
+
+// CalendarHandler serves the campus-wide calendar
+type CalendarHandler struct {
+	db *database.DB
+}
+
+// NewCalendarHandler creates a new calendar handler
+func NewCalendarHandler(db *database.DB) *CalendarHandler {
+	return &CalendarHandler{db: db}
+}
+
+// GetCalendar merges events, house events, official schedules and
+// registration deadlines into a single date-keyed view. from and to are
+// inclusive dates (YYYY-MM-DD); the default is the 30 days from today.
+// Alumni events are shown only to those who see them.
+// GET /api/v1/calendar?from=2025-03-01&to=2025-03-31
+func (h *CalendarHandler) GetCalendar(c *gin.Context) {
+	from, last, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+	to := last.AddDate(0, 0, 1)
+
+	ctx := c.Request.Context()
+	db := h.db.Reader()
+	cal := newCalendarBuilder(from, to)
+
+	role, _ := middleware.Role(c)
+	if err := addEvents(ctx, db, cal, from, to, true, models.SeesAlumniEvents(role)); err != nil {
+		internalError(c, "Failed to fetch calendar", err)
+		return
+	}
+	if err := addHouseEvents(ctx, db, cal, from, to); err != nil {
+		internalError(c, "Failed to fetch calendar", err)
+		return
+	}
+	if err := addOfficialSchedules(ctx, db, cal, from, to); err != nil {
+		internalError(c, "Failed to fetch calendar", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: models.CampusCalendarResponse{
+			From: from.Format(dateLayout),
+			To:   last.Format(dateLayout),
+			Days: cal.result(),
+		},
+	})
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+func TestCalendarBuilderSpanIsClampedToRange(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 1, 0)
+	b := newCalendarBuilder(from, to)
+
+	// Runs from the last two days of February into the first two of March
+	start := time.Date(2025, 2, 27, 18, 0, 0, 0, time.Local)
+	end := time.Date(2025, 3, 2, 12, 0, 0, 0, time.Local)
+	b.addSpan(start, end, models.CalendarItem{Title: "Fest"})
+
+	days := b.result()
+	if len(days) != 2 {
+		t.Fatalf("got %d days, want 2: %v", len(days), days)
+	}
+	for _, key := range []string{"2025-03-01", "2025-03-02"} {
+		if len(days[key]) != 1 {
+			t.Errorf("day %s has %d items, want 1", key, len(days[key]))
+		}
+	}
+}
+
+func TestCalendarBuilderOrdersAllDayFirst(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	b := newCalendarBuilder(day, day.AddDate(0, 0, 1))
+
+	late := day.Add(15 * time.Hour)
+	early := day.Add(9 * time.Hour)
+	b.add(day, models.CalendarItem{Title: "late", StartsAt: &late})
+	b.add(day, models.CalendarItem{Title: "early", StartsAt: &early})
+	b.add(day, models.CalendarItem{Title: "all day", AllDay: true})
+
+	items := b.result()["2025-03-10"]
+	var got []string
+	for _, item := range items {
+		got = append(got, item.Title)
+	}
+	want := []string{"all day", "early", "late"}
+	for i := range want {
+		if i >= len(got) || got[i] != want[i] {
+			t.Fatalf("order = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,435 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// publicDonationsLimit is how many donations the donor wall shows
+const publicDonationsLimit = 50
+
+const campaignColumns = `
+	c.id, c.club_id, cl.name, c.title, c.description, c.target_amount, c.currency, c.ends_at,
+	c.status, c.created_by, c.created_at, c.updated_at, COALESCE(d.raised, 0), COALESCE(d.donations, 0)`
+
+// campaignFrom joins each campaign with its club and what it has raised
+const campaignFrom = `
+	FROM campaigns c
+	JOIN clubs cl ON cl.id = c.club_id
+	LEFT JOIN (
+		SELECT campaign_id, SUM(amount) AS raised, COUNT(*) AS donations
+		FROM donations
+		WHERE status = 'paid'
+		GROUP BY campaign_id
+	) d ON d.campaign_id = c.id`
+
+func scanCampaign(row interface{ Scan(...interface{}) error }) (models.Campaign, error) {
+	var c models.Campaign
+	err := row.Scan(
+		&c.ID, &c.ClubID, &c.ClubName, &c.Title, &c.Description, &c.TargetAmount, &c.Currency, &c.EndsAt,
+		&c.Status, &c.CreatedBy, &c.CreatedAt, &c.UpdatedAt, &c.Progress.Raised, &c.Progress.Donations,
+	)
+	if err == nil && c.TargetAmount > 0 {
+		c.Progress.Percent = math.Round(c.Progress.Raised/c.TargetAmount*1000) / 10
+	}
+	return c, err
+}
+
+// getCampaign returns a campaign. Returns sql.ErrNoRows if it doesn't exist.
+func getCampaign(ctx context.Context, db *sql.DB, id uuid.UUID) (models.Campaign, error) {
+	return scanCampaign(db.QueryRowContext(ctx, `SELECT `+campaignColumns+campaignFrom+` WHERE c.id = $1`, id))
+}
+
+// CampaignHandler handles clubs' fundraising campaigns. Donations are paid
+// through the PaymentHandler.
+type CampaignHandler struct {
+	db *database.DB
+}
+
+// NewCampaignHandler creates a new campaign handler
+func NewCampaignHandler(db *database.DB) *CampaignHandler {
+	return &CampaignHandler{db: db}
+}
+
+// loadCampaign parses :id and fetches the campaign. Returns false if the
+// request has been answered.
+func loadCampaign(c *gin.Context, db *sql.DB) (models.Campaign, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid campaign ID"),
+		})
+		return models.Campaign{}, false
+	}
+	campaign, err := getCampaign(c.Request.Context(), db, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("campaign not found"),
+		})
+		return campaign, false
+	}
+	if err != nil {
+		internalError(c, "failed to fetch campaign", err)
+		return campaign, false
+	}
+	return campaign, true
+}
+
+// requireCampaignManager fetches the campaign and checks the user can
+// manage its club. Returns false if the request has been answered.
+func requireCampaignManager(c *gin.Context, db *sql.DB) (models.Campaign, bool) {
+	campaign, ok := loadCampaign(c, db)
+	if !ok {
+		return campaign, false
+	}
+	allowed, err := canManageClub(c, db, campaign.ClubID)
+	if err != nil {
+		internalError(c, "failed to check club role", err)
+		return campaign, false
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("only the club's leads can do this"),
+		})
+		return campaign, false
+	}
+	return campaign, true
+}
+
+// ListCampaigns lists fundraising campaigns, newest first. Only active ones
+// unless ?status=closed or ?status=all; ?club_id= limits it to one club.
+// GET /api/v1/campaigns
+func (h *CampaignHandler) ListCampaigns(c *gin.Context) {
+	status := c.DefaultQuery("status", models.CampaignActive)
+	if status != models.CampaignActive && status != models.CampaignClosed && status != "all" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("status must be active, closed or all"),
+		})
+		return
+	}
+	var clubID *uuid.UUID
+	if s := c.Query("club_id"); s != "" {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("invalid club ID"),
+			})
+			return
+		}
+		clubID = &id
+	}
+
+	rows, err := h.db.Reader().QueryContext(c.Request.Context(), `
+		SELECT `+campaignColumns+campaignFrom+`
+		WHERE ($1 = 'all' OR c.status = $1) AND ($2::uuid IS NULL OR c.club_id = $2)
+		ORDER BY c.created_at DESC
+	`, status, clubID)
+	if err != nil {
+		internalError(c, "failed to fetch campaigns", err)
+		return
+	}
+	defer rows.Close()
+
+	campaigns := []models.Campaign{}
+	for rows.Next() {
+		campaign, err := scanCampaign(rows)
+		if err != nil {
+			internalError(c, "failed to fetch campaigns", err)
+			return
+		}
+		campaigns = append(campaigns, campaign)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "failed to fetch campaigns", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    campaigns,
+	})
+}
+
+// GetCampaign returns a campaign with its progress
+// GET /api/v1/campaigns/:id
+func (h *CampaignHandler) GetCampaign(c *gin.Context) {
+	campaign, ok := loadCampaign(c, h.db.Reader())
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    campaign,
+	})
+}
+
+// ListCampaignDonations returns a campaign's latest donations for its donor
+// wall, leaving anonymous donors unnamed
+// GET /api/v1/campaigns/:id/donations
+func (h *CampaignHandler) ListCampaignDonations(c *gin.Context) {
+	campaign, ok := loadCampaign(c, h.db.Reader())
+	if !ok {
+		return
+	}
+
+	rows, err := h.db.Reader().QueryContext(c.Request.Context(), `
+		SELECT d.id, CASE WHEN d.anonymous THEN NULL ELSE u.full_name END,
+		       d.amount, d.currency, d.anonymous, d.message, d.paid_at
+		FROM donations d
+		LEFT JOIN users u ON u.id = d.user_id
+		WHERE d.campaign_id = $1 AND d.status = 'paid'
+		ORDER BY d.paid_at DESC
+		LIMIT $2
+	`, campaign.ID, publicDonationsLimit)
+	if err != nil {
+		internalError(c, "failed to fetch donations", err)
+		return
+	}
+	defer rows.Close()
+
+	donations := []models.Donation{}
+	for rows.Next() {
+		var d models.Donation
+		if err := rows.Scan(&d.ID, &d.DonorName, &d.Amount, &d.Currency, &d.Anonymous, &d.Message, &d.PaidAt); err != nil {
+			internalError(c, "failed to fetch donations", err)
+			return
+		}
+		donations = append(donations, d)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "failed to fetch donations", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    donations,
+	})
+}
+
+// CreateCampaign starts a fundraising campaign for a club (club leads only)
+// POST /api/v1/clubs/:id/campaigns
+func (h *CampaignHandler) CreateCampaign(c *gin.Context) {
+	clubID, ok := requireClub(c, h.db.DB)
+	if !ok {
+		return
+	}
+	allowed, err := canManageClub(c, h.db.DB, clubID)
+	if err != nil {
+		internalError(c, "failed to check club role", err)
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("only the club's leads can do this"),
+		})
+		return
+	}
+
+	var req models.CreateCampaignRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("title is required"),
+		})
+		return
+	}
+	if req.EndsAt != nil && !req.EndsAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("ends_at must be in the future"),
+		})
+		return
+	}
+	currency := "INR"
+	if req.Currency != "" {
+		currency = strings.ToUpper(req.Currency)
+	}
+
+	userID, _ := middleware.UserID(c)
+	ctx := c.Request.Context()
+	var id uuid.UUID
+	err = h.db.QueryRowContext(ctx, `
+		INSERT INTO campaigns (club_id, title, description, target_amount, currency, ends_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`, clubID, req.Title, req.Description, req.TargetAmount, currency, req.EndsAt, userID).Scan(&id)
+	if err != nil {
+		internalError(c, "failed to create campaign", err)
+		return
+	}
+	campaign, err := getCampaign(ctx, h.db.DB, id)
+	if err != nil {
+		internalError(c, "failed to fetch campaign", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "campaign created",
+		Data:    campaign,
+	})
+}
+
+// UpdateCampaign changes a campaign, including closing it to donations
+// (club leads only)
+// PUT /api/v1/campaigns/:id
+func (h *CampaignHandler) UpdateCampaign(c *gin.Context) {
+	campaign, ok := requireCampaignManager(c, h.db.DB)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateCampaignRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	if req.Title != nil {
+		*req.Title = strings.TrimSpace(*req.Title)
+		if *req.Title == "" {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("title cannot be empty"),
+			})
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	_, err := h.db.ExecContext(ctx, `
+		UPDATE campaigns
+		SET title = COALESCE($1, title), description = COALESCE($2, description),
+		    target_amount = COALESCE($3, target_amount), ends_at = COALESCE($4, ends_at),
+		    status = COALESCE($5, status)
+		WHERE id = $6
+	`, req.Title, req.Description, req.TargetAmount, req.EndsAt, req.Status, campaign.ID)
+	if err != nil {
+		internalError(c, "failed to update campaign", err)
+		return
+	}
+	if campaign, err = getCampaign(ctx, h.db.DB, campaign.ID); err != nil {
+		internalError(c, "failed to fetch campaign", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "campaign updated",
+		Data:    campaign,
+	})
+}
+
+// GetCampaignDonors returns the report of a campaign's paid donations,
+// oldest first, as CSV with ?format=csv (club leads only). Anonymous donors
+// stay unnamed.
+// GET /api/v1/campaigns/:id/donors
+func (h *CampaignHandler) GetCampaignDonors(c *gin.Context) {
+	campaign, ok := requireCampaignManager(c, h.db.DB)
+	if !ok {
+		return
+	}
+
+	rows, err := h.db.Reader().QueryContext(c.Request.Context(), `
+		SELECT d.id,
+		       CASE WHEN d.anonymous THEN NULL ELSE u.full_name END,
+		       CASE WHEN d.anonymous THEN NULL ELSE u.email END,
+		       d.amount, d.currency, d.anonymous, d.message, d.razorpay_payment_id, d.paid_at
+		FROM donations d
+		LEFT JOIN users u ON u.id = d.user_id
+		WHERE d.campaign_id = $1 AND d.status = 'paid'
+		ORDER BY d.paid_at
+	`, campaign.ID)
+	if err != nil {
+		internalError(c, "failed to fetch donors", err)
+		return
+	}
+	defer rows.Close()
+
+	donors := []models.Donor{}
+	for rows.Next() {
+		var d models.Donor
+		if err := rows.Scan(&d.DonationID, &d.FullName, &d.Email, &d.Amount, &d.Currency, &d.Anonymous,
+			&d.Message, &d.RazorpayPaymentID, &d.PaidAt); err != nil {
+			internalError(c, "failed to fetch donors", err)
+			return
+		}
+		donors = append(donors, d)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "failed to fetch donors", err)
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		writeDonorsCSV(c, donors)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    donors,
+	})
+}
+
+func writeDonorsCSV(c *gin.Context, donors []models.Donor) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="donors.csv"`)
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	w.Write([]string{"donation_id", "paid_at", "full_name", "email", "amount", "currency", "message", "razorpay_payment_id"})
+	for _, d := range donors {
+		name, email, message := "Anonymous", "", ""
+		if d.FullName != nil {
+			name = *d.FullName
+		}
+		if d.Email != nil {
+			email = *d.Email
+		}
+		if d.Message != nil {
+			message = *d.Message
+		}
+		w.Write([]string{
+			d.DonationID.String(),
+			d.PaidAt.Format("2006-01-02 15:04"),
+			name,
+			email,
+			strconv.FormatFloat(d.Amount, 'f', 2, 64),
+			d.Currency,
+			message,
+			d.RazorpayPaymentID,
+		})
+	}
+	w.Flush()
+}
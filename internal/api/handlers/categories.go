@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+type CategoryHandler struct {
+	db *database.DB
+}
+
+func NewCategoryHandler(db *database.DB) *CategoryHandler {
+	return &CategoryHandler{db: db}
+}
+
+// ListCategories returns the event categories in display order
+// GET /api/v1/categories
+func (h *CategoryHandler) ListCategories(c *gin.Context) {
+	rows, err := h.db.Reader().QueryContext(c.Request.Context(), `
+		SELECT id, name, description, color_hex, sort_order, created_at, updated_at
+		FROM event_categories
+		ORDER BY sort_order ASC, name ASC
+	`)
+	if err != nil {
+		internalError(c, "Failed to fetch categories", err)
+		return
+	}
+	defer rows.Close()
+
+	categories := []models.EventCategory{}
+	for rows.Next() {
+		var cat models.EventCategory
+		if err := rows.Scan(
+			&cat.ID, &cat.Name, &cat.Description, &cat.ColorHex, &cat.SortOrder, &cat.CreatedAt, &cat.UpdatedAt,
+		); err != nil {
+			internalError(c, "Failed to fetch categories", err)
+			return
+		}
+		categories = append(categories, cat)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch categories", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    categories,
+	})
+}
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+// CheckInAttendee checks a registrant in at an event (event organizers
+// only). Checking in again keeps the first check-in time.
+// POST /api/v1/events/:id/registrations/:user_id/check-in
+func (h *EventHandler) CheckInAttendee(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid user ID"),
+		})
+		return
+	}
+
+	organizerID, _ := middleware.UserID(c)
+	checkIn := models.EventCheckIn{EventID: event.ID, UserID: userID}
+	err = h.db.QueryRowContext(c.Request.Context(), `
+		UPDATE event_registrations
+		SET checked_in_at = COALESCE(checked_in_at, CURRENT_TIMESTAMP),
+		    checked_in_by = CASE WHEN checked_in_at IS NULL THEN $3 ELSE checked_in_by END
+		WHERE event_id = $1 AND user_id = $2
+		RETURNING checked_in_at
+	`, event.ID, userID, organizerID).Scan(&checkIn.CheckedInAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("user is not registered for this event"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to check in attendee", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "attendee checked in",
+		Data:    checkIn,
+	})
+}
+
+// UndoCheckIn clears a registrant's check-in, for one made by mistake
+// (event organizers only)
+// DELETE /api/v1/events/:id/registrations/:user_id/check-in
+func (h *EventHandler) UndoCheckIn(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid user ID"),
+		})
+		return
+	}
+
+	res, err := h.db.ExecContext(c.Request.Context(), `
+		UPDATE event_registrations SET checked_in_at = NULL, checked_in_by = NULL
+		WHERE event_id = $1 AND user_id = $2
+	`, event.ID, userID)
+	if err != nil {
+		internalError(c, "failed to undo check-in", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("user is not registered for this event"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "check-in undone",
+	})
+}
+
+// GetCertificateRules returns an event's certificate eligibility rules
+// (event organizers only)
+// GET /api/v1/events/:id/certificate-rules
+func (h *EventHandler) GetCertificateRules(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+
+	var rules models.CertificateRules
+	err := h.db.QueryRowContext(c.Request.Context(),
+		"SELECT certificate_requires_check_in FROM events WHERE id = $1", event.ID,
+	).Scan(&rules.RequireCheckIn)
+	if err != nil {
+		internalError(c, "failed to fetch certificate rules", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    rules,
+	})
+}
+
+// UpdateCertificateRules sets an event's certificate eligibility rules
+// (event organizers only)
+// PUT /api/v1/events/:id/certificate-rules
+func (h *EventHandler) UpdateCertificateRules(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+
+	var rules models.CertificateRules
+	if err := bindJSON(c, &rules); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	_, err := h.db.ExecContext(c.Request.Context(),
+		"UPDATE events SET certificate_requires_check_in = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2",
+		rules.RequireCheckIn, event.ID)
+	if err != nil {
+		internalError(c, "failed to update certificate rules", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "certificate rules updated",
+		Data:    rules,
+	})
+}
+
+// GetCertificateEligibility reports how many of an event's registrants are
+// eligible for a certificate and lists those who aren't, with why (event
+// organizers only)
+// GET /api/v1/events/:id/certificate-eligibility
+func (h *EventHandler) GetCertificateEligibility(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	var report models.CertificateEligibility
+	err := h.db.QueryRowContext(ctx,
+		"SELECT certificate_requires_check_in FROM events WHERE id = $1", event.ID,
+	).Scan(&report.Rules.RequireCheckIn)
+	if err != nil {
+		internalError(c, "failed to fetch certificate eligibility", err)
+		return
+	}
+
+	rows, err := h.db.QueryContext(ctx, `
+		SELECT u.id, u.full_name, u.email, r.registered_at, r.checked_in_at IS NOT NULL
+		FROM event_registrations r
+		JOIN users u ON u.id = r.user_id
+		WHERE r.event_id = $1
+		ORDER BY r.registered_at ASC
+	`, event.ID)
+	if err != nil {
+		internalError(c, "failed to fetch certificate eligibility", err)
+		return
+	}
+	defer rows.Close()
+
+	report.Ineligible = []models.IneligibleRegistrant{}
+	for rows.Next() {
+		var r models.IneligibleRegistrant
+		var checkedIn bool
+		if err := rows.Scan(&r.UserID, &r.FullName, &r.Email, &r.RegisteredAt, &checkedIn); err != nil {
+			internalError(c, "failed to fetch certificate eligibility", err)
+			return
+		}
+		report.Registered++
+		if checkedIn {
+			report.CheckedIn++
+		}
+		if r.Reason = certificateIneligibility(report.Rules, checkedIn); r.Reason != "" {
+			report.Ineligible = append(report.Ineligible, r)
+		} else {
+			report.Eligible++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "failed to fetch certificate eligibility", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    report,
+	})
+}
+
+// certificateIneligibility returns why a registrant is not eligible for a
+// certificate under rules, or "" if they are
+func certificateIneligibility(rules models.CertificateRules, checkedIn bool) string {
+	if rules.RequireCheckIn && !checkedIn {
+		return models.IneligibleNotCheckedIn
+	}
+	return ""
+}
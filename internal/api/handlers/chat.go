@@ -0,0 +1,648 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/realtime"
+	"github.com/yourusername/college-event-backend/internal/services/moderation"
+	"github.com/yourusername/college-event-backend/internal/storage"
+)
+
+const defaultChatMessageLimit = 50
+
+// ChatHandler serves the group chat channels of houses and clubs. Members
+// of the house or club can read and post; admins, and club leads in their
+// club's channel, can also pin and remove messages. New messages, typing
+// and receipts reach connected members through hub. Attachments are kept
+// in storage.
+type ChatHandler struct {
+	db      *sql.DB
+	filter  *moderation.Filter
+	hub     *realtime.Hub
+	storage storage.StorageService
+}
+
+// NewChatHandler creates a new ChatHandler. Messages are screened by
+// filter, which may be nil.
+func NewChatHandler(db *sql.DB, filter *moderation.Filter, hub *realtime.Hub, storage storage.StorageService) *ChatHandler {
+	return &ChatHandler{db: db, filter: filter, hub: hub, storage: storage}
+}
+
+// chatMessageColumns selects a channel message, m, with its author, u
+const chatMessageColumns = `m.id, m.channel_id, m.user_id, COALESCE(u.full_name, ''), COALESCE(u.avatar_url, ''),
+	m.content, m.pinned_at, m.pinned_by, m.created_at`
+
+func scanChatMessage(row interface{ Scan(...interface{}) error }) (models.ChatChannelMessage, error) {
+	var m models.ChatChannelMessage
+	err := row.Scan(&m.ID, &m.ChannelID, &m.UserID, &m.UserName, &m.AvatarURL,
+		&m.Content, &m.PinnedAt, &m.PinnedBy, &m.CreatedAt)
+	return m, err
+}
+
+// chatAccess is what the current user may do in a channel
+type chatAccess struct {
+	member   bool // Belongs to the channel's house or club
+	moderate bool
+}
+
+// requireChatAccess parses the :id channel param and aborts unless the
+// user is a member of the channel or can moderate it. Returns false if the
+// request has been answered.
+func (h *ChatHandler) requireChatAccess(c *gin.Context) (uuid.UUID, chatAccess, bool) {
+	channelID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid channel ID"),
+		})
+		return uuid.Nil, chatAccess{}, false
+	}
+	userID, _ := middleware.UserID(c)
+
+	var access chatAccess
+	err = h.db.QueryRowContext(c.Request.Context(), `
+		SELECT EXISTS (SELECT 1 FROM house_members WHERE house_id = ch.house_id AND user_id = $2)
+		           OR EXISTS (SELECT 1 FROM club_members WHERE club_id = ch.club_id AND user_id = $2),
+		       EXISTS (SELECT 1 FROM club_members WHERE club_id = ch.club_id AND user_id = $2
+		               AND COALESCE(role, 'member') <> 'member')
+		FROM chat_channels ch
+		LEFT JOIN houses h ON h.id = ch.house_id
+		LEFT JOIN clubs cl ON cl.id = ch.club_id
+		WHERE ch.id = $1 AND h.deleted_at IS NULL AND cl.deleted_at IS NULL
+	`, channelID, userID).Scan(&access.member, &access.moderate)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Channel not found"),
+		})
+		return uuid.Nil, chatAccess{}, false
+	}
+	if err != nil {
+		internalError(c, "Failed to check channel membership", err)
+		return uuid.Nil, chatAccess{}, false
+	}
+	if role, _ := middleware.Role(c); role == models.RoleAdmin {
+		access.moderate = true
+	}
+	if !access.member && !access.moderate {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Only members can see this channel"),
+		})
+		return uuid.Nil, chatAccess{}, false
+	}
+	return channelID, access, true
+}
+
+// requireChatModerator aborts with 403 unless the user can moderate the
+// channel. Returns false if the request has been answered.
+func requireChatModerator(c *gin.Context, access chatAccess) bool {
+	if !access.moderate {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Only channel moderators can do this"),
+		})
+		return false
+	}
+	return true
+}
+
+// chatMessageParam parses the :message_id route param. Returns false if
+// the request was aborted.
+func chatMessageParam(c *gin.Context) (uuid.UUID, bool) {
+	messageID, err := uuid.Parse(c.Param("message_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid message ID"),
+		})
+		return uuid.Nil, false
+	}
+	return messageID, true
+}
+
+// markChannelRead records that a member has read, and so received, a
+// channel up to now, and tells the channel
+func (h *ChatHandler) markChannelRead(ctx context.Context, channelID, userID uuid.UUID) error {
+	var readAt time.Time
+	err := h.db.QueryRowContext(ctx, `
+		INSERT INTO chat_channel_reads (channel_id, user_id, last_read_at, last_delivered_at)
+		VALUES ($1, $2, NOW(), NOW())
+		ON CONFLICT (channel_id, user_id) DO UPDATE
+		SET last_read_at = EXCLUDED.last_read_at, last_delivered_at = EXCLUDED.last_delivered_at
+		RETURNING last_read_at
+	`, channelID, userID).Scan(&readAt)
+	if err != nil {
+		return err
+	}
+	h.publishChatEvent(ctx, models.ChatEvent{
+		Type:      models.ChatEventRead,
+		ChannelID: channelID,
+		UserID:    &userID,
+		UpTo:      &readAt,
+	})
+	return nil
+}
+
+// chatTopic is the realtime topic of a channel's events
+func chatTopic(channelID uuid.UUID) string {
+	return "chat:" + channelID.String()
+}
+
+// publishChatEvent sends an event to the members connected to its channel
+func (h *ChatHandler) publishChatEvent(ctx context.Context, event models.ChatEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("[CHAT] Failed to encode %s event: %v", event.Type, err)
+		return
+	}
+	h.hub.Publish(ctx, chatTopic(event.ChannelID), payload)
+}
+
+// ListChatChannels returns the channels of the user's house and clubs, the
+// most recently active first, with their unread counts
+// GET /api/v1/chat/channels
+func (h *ChatHandler) ListChatChannels(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+	isAdmin := false
+	if role, _ := middleware.Role(c); role == models.RoleAdmin {
+		isAdmin = true
+	}
+
+	rows, err := h.db.QueryContext(c.Request.Context(), `
+		SELECT ch.id, ch.house_id, ch.club_id, COALESCE(h.name, cl.name), ch.created_at, r.last_read_at,
+		       (SELECT COUNT(*) FROM chat_channel_messages m
+		        WHERE m.channel_id = ch.id AND m.deleted_at IS NULL
+		          AND m.user_id IS DISTINCT FROM $1
+		          AND m.created_at > COALESCE(r.last_read_at, '-infinity')
+		          AND `+moderation.NotBlockedBy("$1", "m.user_id")+`
+		          AND `+moderation.VisibleTo("$1", "m.user_id")+`),
+		       COALESCE(cm.role, 'member') <> 'member',
+		       lm.id, lm.user_id, lm.user_name, lm.avatar_url, lm.content, lm.pinned_at, lm.pinned_by, lm.created_at
+		FROM chat_channels ch
+		LEFT JOIN houses h ON h.id = ch.house_id AND h.deleted_at IS NULL
+		LEFT JOIN clubs cl ON cl.id = ch.club_id AND cl.deleted_at IS NULL
+		LEFT JOIN house_members hm ON hm.house_id = h.id AND hm.user_id = $1
+		LEFT JOIN club_members cm ON cm.club_id = cl.id AND cm.user_id = $1
+		LEFT JOIN chat_channel_reads r ON r.channel_id = ch.id AND r.user_id = $1
+		LEFT JOIN LATERAL (
+			SELECT m.id, m.user_id, COALESCE(u.full_name, '') AS user_name, COALESCE(u.avatar_url, '') AS avatar_url,
+			       m.content, m.pinned_at, m.pinned_by, m.created_at
+			FROM chat_channel_messages m
+			LEFT JOIN users u ON u.id = m.user_id
+			WHERE m.channel_id = ch.id AND m.deleted_at IS NULL
+			  AND `+moderation.NotBlockedBy("$1", "m.user_id")+`
+			  AND `+moderation.VisibleTo("$1", "m.user_id")+`
+			ORDER BY m.created_at DESC
+			LIMIT 1
+		) lm ON TRUE
+		WHERE hm.id IS NOT NULL OR cm.id IS NOT NULL
+		ORDER BY COALESCE(lm.created_at, ch.created_at) DESC
+	`, userID)
+	if err != nil {
+		internalError(c, "Failed to fetch channels", err)
+		return
+	}
+	defer rows.Close()
+
+	channels := []models.ChatChannel{}
+	for rows.Next() {
+		var ch models.ChatChannel
+		var last struct {
+			id                  *uuid.UUID
+			userID              *uuid.UUID
+			userName, avatarURL *string
+			content             *string
+			pinnedAt            *time.Time
+			pinnedBy            *uuid.UUID
+			createdAt           *time.Time
+		}
+		if err := rows.Scan(&ch.ID, &ch.HouseID, &ch.ClubID, &ch.Name, &ch.CreatedAt, &ch.LastReadAt,
+			&ch.UnreadCount, &ch.CanModerate,
+			&last.id, &last.userID, &last.userName, &last.avatarURL, &last.content, &last.pinnedAt, &last.pinnedBy, &last.createdAt,
+		); err != nil {
+			internalError(c, "Failed to fetch channels", err)
+			return
+		}
+		if last.id != nil {
+			ch.LastMessage = &models.ChatChannelMessage{
+				ID:        *last.id,
+				ChannelID: ch.ID,
+				UserID:    last.userID,
+				UserName:  *last.userName,
+				AvatarURL: *last.avatarURL,
+				Content:   *last.content,
+				PinnedAt:  last.pinnedAt,
+				PinnedBy:  last.pinnedBy,
+				CreatedAt: *last.createdAt,
+			}
+		}
+		ch.CanModerate = ch.CanModerate || isAdmin
+		channels = append(channels, ch)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch channels", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    channels,
+	})
+}
+
+// GetChatMessages returns a channel's messages, newest first. Fetching the
+// newest page marks the channel read.
+// GET /api/v1/chat/channels/:id/messages
+func (h *ChatHandler) GetChatMessages(c *gin.Context) {
+	channelID, access, ok := h.requireChatAccess(c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.UserID(c)
+
+	var q models.ListChatMessagesQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid query: " + err.Error()),
+		})
+		return
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultChatMessageLimit
+	}
+
+	rows, err := h.db.QueryContext(c.Request.Context(), `
+		SELECT `+chatMessageColumns+`
+		FROM chat_channel_messages m
+		LEFT JOIN users u ON u.id = m.user_id
+		WHERE m.channel_id = $1 AND m.deleted_at IS NULL
+		  AND ($3::timestamptz IS NULL OR m.created_at < $3)
+		  AND `+moderation.NotBlockedBy("$2", "m.user_id")+`
+		  AND `+moderation.VisibleTo("$2", "m.user_id")+`
+		ORDER BY m.created_at DESC
+		LIMIT $4
+	`, channelID, userID, q.Before, q.Limit)
+	if err != nil {
+		internalError(c, "Failed to fetch messages", err)
+		return
+	}
+	defer rows.Close()
+
+	messages := []models.ChatChannelMessage{}
+	for rows.Next() {
+		m, err := scanChatMessage(rows)
+		if err != nil {
+			internalError(c, "Failed to fetch messages", err)
+			return
+		}
+		messages = append(messages, m)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch messages", err)
+		return
+	}
+	if err := loadChatAttachments(c.Request.Context(), h.db, messages); err != nil {
+		internalError(c, "Failed to fetch messages", err)
+		return
+	}
+
+	if q.Before == nil && access.member {
+		if err := h.markChannelRead(c.Request.Context(), channelID, userID); err != nil {
+			// The messages are still worth returning
+			logInternalError(c, "Failed to mark channel read", err)
+		}
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    messages,
+	})
+}
+
+// PostChatMessage posts a message to a channel. The body is either JSON or
+// multipart/form-data with the text as "content" and up to 5 files as
+// "attachments": images of at most 10MB, which get a thumbnail, or PDFs,
+// Office documents, text and ZIP files of at most 20MB. A message needs
+// content, attachments or both.
+// POST /api/v1/chat/channels/:id/messages
+func (h *ChatHandler) PostChatMessage(c *gin.Context) {
+	channelID, _, ok := h.requireChatAccess(c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.UserID(c)
+
+	var req models.PostChatMessageRequest
+	var files []*multipart.FileHeader
+	if c.ContentType() == "multipart/form-data" {
+		if err := c.ShouldBind(&req); err != nil {
+			if strings.Contains(err.Error(), "http: request body too large") {
+				c.JSON(http.StatusRequestEntityTooLarge, models.APIResponse{
+					Success: false,
+					Error:   strPtr("Attachments too large"),
+				})
+				return
+			}
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Invalid request: " + err.Error()),
+			})
+			return
+		}
+		defer c.Request.MultipartForm.RemoveAll()
+		files = c.Request.MultipartForm.File["attachments"]
+	} else if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid request: " + err.Error()),
+		})
+		return
+	}
+	if strings.TrimSpace(req.Content) == "" && len(files) == 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("A message needs content or an attachment"),
+		})
+		return
+	}
+	if err := checkChatAttachments(files); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	if !requireCanInteract(c, h.db, userID, nil, true) {
+		return
+	}
+	screened, ok := screenContent(c, h.filter, "message", req.Content)
+	if !ok {
+		return
+	}
+
+	uploads, err := h.uploadChatAttachments(c.Request.Context(), channelID, files)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	saved := false
+	defer func() {
+		if saved {
+			return
+		}
+		for _, u := range uploads {
+			h.deleteChatFiles(context.Background(), u.paths())
+		}
+	}()
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to post message", err)
+		return
+	}
+	defer tx.Rollback()
+
+	message, err := scanChatMessage(tx.QueryRowContext(ctx, `
+		WITH m AS (
+			INSERT INTO chat_channel_messages (channel_id, user_id, content)
+			VALUES ($1, $2, $3)
+			RETURNING *
+		)
+		SELECT `+chatMessageColumns+`
+		FROM m
+		LEFT JOIN users u ON u.id = m.user_id
+	`, channelID, userID, req.Content))
+	if err != nil {
+		internalError(c, "Failed to post message", err)
+		return
+	}
+
+	if message.Attachments, err = saveChatAttachments(ctx, tx, message.ID, channelID, uploads); err != nil {
+		internalError(c, "Failed to post message", err)
+		return
+	}
+
+	if screened.Verdict == moderation.Flag {
+		if err := moderation.RecordFlag(ctx, tx, moderation.ContentChatMessage, message.ID, userID, screened.Reasons); err != nil {
+			internalError(c, "Failed to post message", err)
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to post message", err)
+		return
+	}
+	saved = true
+
+	// Shadow banned authors' messages are seen by themselves only
+	banned, err := moderation.ShadowBanned(ctx, h.db, userID)
+	if err != nil {
+		logInternalError(c, "Failed to check shadow ban", err)
+	} else if !banned {
+		h.publishChatEvent(ctx, models.ChatEvent{
+			Type:      models.ChatEventMessage,
+			ChannelID: channelID,
+			UserID:    &userID,
+			Message:   &message,
+		})
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Message posted",
+		Data:    message,
+	})
+}
+
+// DeleteChatMessage removes a message, by its author or a moderator
+// DELETE /api/v1/chat/channels/:id/messages/:message_id
+func (h *ChatHandler) DeleteChatMessage(c *gin.Context) {
+	channelID, access, ok := h.requireChatAccess(c)
+	if !ok {
+		return
+	}
+	messageID, ok := chatMessageParam(c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.UserID(c)
+
+	ctx := c.Request.Context()
+	var authorID *uuid.UUID
+	err := h.db.QueryRowContext(ctx, `
+		SELECT user_id FROM chat_channel_messages
+		WHERE id = $1 AND channel_id = $2 AND deleted_at IS NULL
+	`, messageID, channelID).Scan(&authorID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Message not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to delete message", err)
+		return
+	}
+	if authorID == nil || *authorID != userID {
+		if !requireChatModerator(c, access) {
+			return
+		}
+	}
+
+	if err := moderation.RemoveContent(ctx, h.db, moderation.ContentChatMessage, messageID); err != nil {
+		internalError(c, "Failed to delete message", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Message deleted",
+	})
+}
+
+// GetPinnedChatMessages returns a channel's pinned messages, most recently
+// pinned first
+// GET /api/v1/chat/channels/:id/pins
+func (h *ChatHandler) GetPinnedChatMessages(c *gin.Context) {
+	channelID, _, ok := h.requireChatAccess(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.db.QueryContext(c.Request.Context(), `
+		SELECT `+chatMessageColumns+`
+		FROM chat_channel_messages m
+		LEFT JOIN users u ON u.id = m.user_id
+		WHERE m.channel_id = $1 AND m.pinned_at IS NOT NULL AND m.deleted_at IS NULL
+		ORDER BY m.pinned_at DESC
+	`, channelID)
+	if err != nil {
+		internalError(c, "Failed to fetch pinned messages", err)
+		return
+	}
+	defer rows.Close()
+
+	messages := []models.ChatChannelMessage{}
+	for rows.Next() {
+		m, err := scanChatMessage(rows)
+		if err != nil {
+			internalError(c, "Failed to fetch pinned messages", err)
+			return
+		}
+		messages = append(messages, m)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch pinned messages", err)
+		return
+	}
+	if err := loadChatAttachments(c.Request.Context(), h.db, messages); err != nil {
+		internalError(c, "Failed to fetch pinned messages", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    messages,
+	})
+}
+
+// PinChatMessage pins a message to the top of its channel (moderators only)
+// POST /api/v1/chat/channels/:id/messages/:message_id/pin
+func (h *ChatHandler) PinChatMessage(c *gin.Context) {
+	h.setPinned(c, true)
+}
+
+// UnpinChatMessage unpins a message (moderators only)
+// DELETE /api/v1/chat/channels/:id/messages/:message_id/pin
+func (h *ChatHandler) UnpinChatMessage(c *gin.Context) {
+	h.setPinned(c, false)
+}
+
+func (h *ChatHandler) setPinned(c *gin.Context, pinned bool) {
+	channelID, access, ok := h.requireChatAccess(c)
+	if !ok {
+		return
+	}
+	if !requireChatModerator(c, access) {
+		return
+	}
+	messageID, ok := chatMessageParam(c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.UserID(c)
+
+	result, err := h.db.ExecContext(c.Request.Context(), `
+		UPDATE chat_channel_messages
+		SET pinned_at = CASE WHEN $3 THEN COALESCE(pinned_at, NOW()) END,
+		    pinned_by = CASE WHEN $3 THEN COALESCE(pinned_by, $4) END
+		WHERE id = $1 AND channel_id = $2 AND deleted_at IS NULL
+	`, messageID, channelID, pinned, userID)
+	if err != nil {
+		internalError(c, "Failed to update pin", err)
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Message not found"),
+		})
+		return
+	}
+
+	message := "Message pinned"
+	if !pinned {
+		message = "Message unpinned"
+	}
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: message,
+	})
+}
+
+// MarkChatChannelRead marks a channel read up to now, for apps that show
+// new messages without fetching the message list
+// POST /api/v1/chat/channels/:id/read
+func (h *ChatHandler) MarkChatChannelRead(c *gin.Context) {
+	channelID, access, ok := h.requireChatAccess(c)
+	if !ok {
+		return
+	}
+	if !access.member {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Only members can mark a channel read"),
+		})
+		return
+	}
+	userID, _ := middleware.UserID(c)
+
+	if err := h.markChannelRead(c.Request.Context(), channelID, userID); err != nil {
+		internalError(c, "Failed to mark channel read", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Channel marked as read",
+	})
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/storage"
+)
+
+const (
+	// maxChatAttachments bounds the files sent with one message
+	maxChatAttachments = 5
+	// maxChatFileBytes bounds each non-image attachment. Images are held
+	// to maxImageBytes.
+	maxChatFileBytes = 20 << 20
+)
+
+// isChatFileType reports whether a document can be sent in chat besides
+// images
+func isChatFileType(contentType string) bool {
+	switch contentType {
+	case "application/pdf",
+		"text/plain",
+		"text/csv",
+		"application/msword",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		"application/vnd.ms-excel",
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		"application/vnd.ms-powerpoint",
+		"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+		"application/zip":
+		return true
+	}
+	return false
+}
+
+// errChatAttachmentUpload is reported for an attachment that failed to
+// store, without the storage error itself
+var errChatAttachmentUpload = errors.New("failed to upload attachment")
+
+// chatUpload is an attachment stored but not yet saved with its message
+type chatUpload struct {
+	kind        string
+	fileName    string
+	contentType string
+	file        *storage.UploadResult
+	thumbnail   *storage.UploadResult
+}
+
+// paths returns the storage paths of an upload's files
+func (u chatUpload) paths() []string {
+	paths := []string{u.file.Path}
+	if u.thumbnail != nil {
+		paths = append(paths, u.thumbnail.Path)
+	}
+	return paths
+}
+
+// checkChatAttachments validates attachments' count, types and sizes
+// before any is stored. The error is shown to the sender.
+func checkChatAttachments(headers []*multipart.FileHeader) error {
+	if len(headers) > maxChatAttachments {
+		return fmt.Errorf("at most %d attachments can be sent with a message", maxChatAttachments)
+	}
+	for _, h := range headers {
+		contentType := h.Header.Get("Content-Type")
+		switch {
+		case isValidImageType(contentType):
+			if h.Size > maxImageBytes {
+				return fmt.Errorf("%s is too large: images can be up to %dMB", h.Filename, maxImageBytes>>20)
+			}
+		case isChatFileType(contentType):
+			if h.Size > maxChatFileBytes {
+				return fmt.Errorf("%s is too large: files can be up to %dMB", h.Filename, maxChatFileBytes>>20)
+			}
+		default:
+			return fmt.Errorf("%s can't be sent: allowed are images, PDFs, Office documents, text and ZIP files", h.Filename)
+		}
+	}
+	return nil
+}
+
+// uploadChatAttachments stores checked attachments, with a thumbnail for
+// each image. If any fails, those already stored are deleted.
+func (h *ChatHandler) uploadChatAttachments(ctx context.Context, channelID uuid.UUID, headers []*multipart.FileHeader) ([]chatUpload, error) {
+	uploads := make([]chatUpload, 0, len(headers))
+	for _, header := range headers {
+		u, err := h.uploadChatAttachment(ctx, channelID, header)
+		if err != nil {
+			for _, done := range uploads {
+				h.deleteChatFiles(context.Background(), done.paths())
+			}
+			return nil, err
+		}
+		uploads = append(uploads, u)
+	}
+	return uploads, nil
+}
+
+func (h *ChatHandler) uploadChatAttachment(ctx context.Context, channelID uuid.UUID, header *multipart.FileHeader) (chatUpload, error) {
+	file, err := header.Open()
+	if err != nil {
+		return chatUpload{}, errChatAttachmentUpload
+	}
+	defer file.Close()
+
+	u := chatUpload{
+		kind:        "file",
+		fileName:    header.Filename,
+		contentType: header.Header.Get("Content-Type"),
+	}
+	folder := "chat/" + channelID.String()
+
+	if !isValidImageType(u.contentType) {
+		u.file, err = h.storage.UploadFile(ctx, file, header.Filename, folder, u.contentType)
+		if err != nil {
+			log.Printf("Failed to upload chat attachment %q for channel %s: %v", header.Filename, channelID, err)
+			return chatUpload{}, errChatAttachmentUpload
+		}
+		return u, nil
+	}
+
+	u.kind = "image"
+	u.file, err = h.storage.UploadImage(ctx, file, header.Filename, folder, storage.ImageTypeOriginal)
+	if err != nil {
+		log.Printf("Failed to upload chat image %q for channel %s: %v", header.Filename, channelID, err)
+		return chatUpload{}, errChatAttachmentUpload
+	}
+	if _, err = file.Seek(0, io.SeekStart); err == nil {
+		u.thumbnail, err = h.storage.UploadImage(ctx, file, header.Filename, folder, storage.ImageTypeThumbnail)
+	}
+	if err != nil {
+		log.Printf("Failed to upload thumbnail of chat image %q for channel %s: %v", header.Filename, channelID, err)
+		h.deleteChatFiles(context.Background(), u.paths())
+		return chatUpload{}, errChatAttachmentUpload
+	}
+	return u, nil
+}
+
+// deleteChatFiles deletes attachment files. Files left behind by a failed
+// delete are only storage.
+func (h *ChatHandler) deleteChatFiles(ctx context.Context, paths []string) {
+	for _, p := range paths {
+		if err := h.storage.Delete(ctx, p); err != nil {
+			log.Printf("Failed to delete chat attachment file %s: %v", p, err)
+		}
+	}
+}
+
+// saveChatAttachments records stored attachments against their message
+func saveChatAttachments(ctx context.Context, tx *sql.Tx, messageID, channelID uuid.UUID, uploads []chatUpload) ([]models.ChatAttachment, error) {
+	attachments := make([]models.ChatAttachment, 0, len(uploads))
+	for _, u := range uploads {
+		a := models.ChatAttachment{
+			Kind:        u.kind,
+			FileName:    u.fileName,
+			ContentType: u.contentType,
+			URL:         u.file.URL,
+			SizeBytes:   u.file.SizeBytes,
+		}
+		var thumbnailPath *string
+		if u.thumbnail != nil {
+			a.ThumbnailURL = &u.thumbnail.URL
+			thumbnailPath = &u.thumbnail.Path
+			a.Width, a.Height = &u.file.Width, &u.file.Height
+		}
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO chat_message_attachments (message_id, channel_id, kind, file_name, content_type,
+				url, path, thumbnail_url, thumbnail_path, width, height, size_bytes)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			RETURNING id
+		`, messageID, channelID, a.Kind, a.FileName, a.ContentType,
+			a.URL, u.file.Path, a.ThumbnailURL, thumbnailPath, a.Width, a.Height, a.SizeBytes,
+		).Scan(&a.ID)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, a)
+	}
+	return attachments, nil
+}
+
+// loadChatAttachments fills in the attachments of messages
+func loadChatAttachments(ctx context.Context, db *sql.DB, messages []models.ChatChannelMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(messages))
+	index := make(map[uuid.UUID]int, len(messages))
+	for i, m := range messages {
+		ids[i] = m.ID
+		index[m.ID] = i
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT message_id, id, kind, file_name, content_type, url, thumbnail_url, width, height, size_bytes
+		FROM chat_message_attachments
+		WHERE message_id = ANY($1::uuid[])
+		ORDER BY created_at ASC
+	`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID uuid.UUID
+		var a models.ChatAttachment
+		if err := rows.Scan(&messageID, &a.ID, &a.Kind, &a.FileName, &a.ContentType, &a.URL,
+			&a.ThumbnailURL, &a.Width, &a.Height, &a.SizeBytes); err != nil {
+			return err
+		}
+		i := index[messageID]
+		messages[i].Attachments = append(messages[i].Attachments, a)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+func attachment(name, contentType string, size int64) *multipart.FileHeader {
+	return &multipart.FileHeader{
+		Filename: name,
+		Header:   textproto.MIMEHeader{"Content-Type": {contentType}},
+		Size:     size,
+	}
+}
+
+func TestCheckChatAttachments(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []*multipart.FileHeader
+		wantErr string
+	}{
+		{"none", nil, ""},
+		{"image and pdf", []*multipart.FileHeader{
+			attachment("a.jpg", "image/jpeg", 1<<20),
+			attachment("b.pdf", "application/pdf", 15<<20),
+		}, ""},
+		{"large image", []*multipart.FileHeader{attachment("a.png", "image/png", maxImageBytes+1)}, "a.png is too large"},
+		{"large file", []*multipart.FileHeader{attachment("b.zip", "application/zip", maxChatFileBytes+1)}, "b.zip is too large"},
+		{"executable", []*multipart.FileHeader{attachment("c.exe", "application/x-msdownload", 10)}, "c.exe can't be sent"},
+		{"too many", []*multipart.FileHeader{
+			attachment("1.txt", "text/plain", 1), attachment("2.txt", "text/plain", 1),
+			attachment("3.txt", "text/plain", 1), attachment("4.txt", "text/plain", 1),
+			attachment("5.txt", "text/plain", 1), attachment("6.txt", "text/plain", 1),
+		}, "at most 5 attachments"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkChatAttachments(tt.files)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// chatFrameLimit bounds what a client can send in one frame
+	chatFrameLimit = 4 << 10
+	// typingExpiry is how long clients show a typing indicator unless
+	// it's repeated
+	typingExpiry = 5 * time.Second
+	// typingThrottle drops typing frames repeated sooner than this
+	typingThrottle   = 2 * time.Second
+	chatWriteTimeout = 10 * time.Second
+)
+
+// ServeChatSocket upgrades to a WebSocket carrying the events of every
+// channel the user is a member of: new messages, typing indicators and
+// delivery and read receipts. Clients send typing, delivered and read
+// frames (models.ChatClientFrame). Channels joined after connecting need a
+// reconnect.
+// GET /api/v1/chat/ws
+func (h *ChatHandler) ServeChatSocket(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+	ctx := c.Request.Context()
+
+	var userName string
+	if err := h.db.QueryRowContext(ctx, "SELECT full_name FROM users WHERE id = $1", userID).Scan(&userName); err != nil {
+		internalError(c, "Failed to open chat connection", err)
+		return
+	}
+	channels, err := h.memberChannels(ctx, userID)
+	if err != nil {
+		internalError(c, "Failed to open chat connection", err)
+		return
+	}
+	blocked, err := h.blockedUsers(ctx, userID)
+	if err != nil {
+		internalError(c, "Failed to open chat connection", err)
+		return
+	}
+
+	conn := &chatConn{
+		h:          h,
+		userID:     userID,
+		userName:   userName,
+		channels:   channels,
+		blocked:    blocked,
+		lastTyping: make(map[uuid.UUID]time.Time),
+	}
+	websocket.Server{
+		// Apps don't send an Origin; the bearer token authenticates them
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler:   conn.serve,
+	}.ServeHTTP(c.Writer, c.Request)
+}
+
+// memberChannels returns the channels of the user's house and clubs
+func (h *ChatHandler) memberChannels(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]bool, error) {
+	rows, err := h.db.QueryContext(ctx, `
+		SELECT ch.id
+		FROM chat_channels ch
+		JOIN house_members hm ON hm.house_id = ch.house_id AND hm.user_id = $1
+		JOIN houses h ON h.id = ch.house_id AND h.deleted_at IS NULL
+		UNION
+		SELECT ch.id
+		FROM chat_channels ch
+		JOIN club_members cm ON cm.club_id = ch.club_id AND cm.user_id = $1
+		JOIN clubs cl ON cl.id = ch.club_id AND cl.deleted_at IS NULL
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	channels := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		channels[id] = true
+	}
+	return channels, rows.Err()
+}
+
+// blockedUsers returns the users the user has blocked, whose messages and
+// typing they don't see
+func (h *ChatHandler) blockedUsers(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]bool, error) {
+	var ids []string
+	err := h.db.QueryRowContext(ctx,
+		"SELECT COALESCE(array_agg(blocked_id), '{}') FROM blocked_users WHERE blocker_id = $1", userID,
+	).Scan(pq.Array(&ids))
+	if err != nil {
+		return nil, err
+	}
+	blocked := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if parsed, err := uuid.Parse(id); err == nil {
+			blocked[parsed] = true
+		}
+	}
+	return blocked, nil
+}
+
+// chatConn is one user's chat WebSocket
+type chatConn struct {
+	h          *ChatHandler
+	userID     uuid.UUID
+	userName   string
+	channels   map[uuid.UUID]bool
+	blocked    map[uuid.UUID]bool
+	lastTyping map[uuid.UUID]time.Time
+}
+
+func (cc *chatConn) serve(ws *websocket.Conn) {
+	defer ws.Close()
+	ws.MaxPayloadBytes = chatFrameLimit
+
+	ctx, cancel := context.WithCancel(ws.Request().Context())
+	defer cancel()
+
+	topics := make([]string, 0, len(cc.channels))
+	for id := range cc.channels {
+		topics = append(topics, chatTopic(id))
+	}
+	sub := cc.h.hub.Subscribe(topics...)
+	defer sub.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer ws.Close() // Unblocks the read below
+		cc.writeEvents(ctx, ws, sub.C)
+	}()
+
+	for ctx.Err() == nil {
+		var frame models.ChatClientFrame
+		err := websocket.JSON.Receive(ws, &frame)
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+			continue
+		}
+		if err != nil {
+			break
+		}
+		if !cc.channels[frame.ChannelID] {
+			continue
+		}
+		cc.handleFrame(ctx, frame)
+	}
+
+	cancel()
+	ws.Close() // Unblocks a pending write
+	wg.Wait()
+}
+
+// writeEvents sends the channels' events to the client, leaving out those
+// of users it has blocked, until ctx is done or a write fails
+func (cc *chatConn) writeEvents(ctx context.Context, ws *websocket.Conn, events <-chan []byte) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case payload := <-events:
+			var event struct {
+				Type   string     `json:"type"`
+				UserID *uuid.UUID `json:"user_id"`
+			}
+			if err := json.Unmarshal(payload, &event); err != nil {
+				continue
+			}
+			if event.UserID != nil && cc.blocked[*event.UserID] &&
+				(event.Type == models.ChatEventMessage || event.Type == models.ChatEventTyping) {
+				continue
+			}
+			ws.SetWriteDeadline(time.Now().Add(chatWriteTimeout))
+			if err := websocket.Message.Send(ws, string(payload)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (cc *chatConn) handleFrame(ctx context.Context, frame models.ChatClientFrame) {
+	switch frame.Type {
+	case models.ChatEventTyping:
+		now := time.Now()
+		if now.Sub(cc.lastTyping[frame.ChannelID]) < typingThrottle {
+			return
+		}
+		cc.lastTyping[frame.ChannelID] = now
+		cc.h.publishChatEvent(ctx, models.ChatEvent{
+			Type:      models.ChatEventTyping,
+			ChannelID: frame.ChannelID,
+			UserID:    &cc.userID,
+			UserName:  cc.userName,
+			ExpiresIn: int(typingExpiry / time.Second),
+		})
+
+	case models.ChatEventDelivered:
+		if frame.MessageID == nil {
+			return
+		}
+		var deliveredAt time.Time
+		err := cc.h.db.QueryRowContext(ctx, `
+			INSERT INTO chat_channel_reads (channel_id, user_id, last_delivered_at)
+			SELECT channel_id, $2, created_at FROM chat_channel_messages
+			WHERE id = $3 AND channel_id = $1
+			ON CONFLICT (channel_id, user_id) DO UPDATE
+			SET last_delivered_at = GREATEST(chat_channel_reads.last_delivered_at, EXCLUDED.last_delivered_at)
+			RETURNING last_delivered_at
+		`, frame.ChannelID, cc.userID, *frame.MessageID).Scan(&deliveredAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			return
+		}
+		if err != nil {
+			log.Printf("[CHAT] Failed to record delivery for %s: %v", cc.userID, err)
+			return
+		}
+		cc.h.publishChatEvent(ctx, models.ChatEvent{
+			Type:      models.ChatEventDelivered,
+			ChannelID: frame.ChannelID,
+			UserID:    &cc.userID,
+			UpTo:      &deliveredAt,
+		})
+
+	case models.ChatEventRead:
+		if err := cc.h.markChannelRead(ctx, frame.ChannelID, cc.userID); err != nil {
+			log.Printf("[CHAT] Failed to mark channel read for %s: %v", cc.userID, err)
+		}
+	}
+}
+
+// GetChatMessageReceipts returns which members have received and read a
+// message (its author and moderators only)
+// GET /api/v1/chat/channels/:id/messages/:message_id/receipts
+func (h *ChatHandler) GetChatMessageReceipts(c *gin.Context) {
+	channelID, access, ok := h.requireChatAccess(c)
+	if !ok {
+		return
+	}
+	messageID, ok := chatMessageParam(c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.UserID(c)
+
+	ctx := c.Request.Context()
+	var authorID *uuid.UUID
+	var sentAt time.Time
+	err := h.db.QueryRowContext(ctx, `
+		SELECT user_id, created_at FROM chat_channel_messages
+		WHERE id = $1 AND channel_id = $2 AND deleted_at IS NULL
+	`, messageID, channelID).Scan(&authorID, &sentAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Message not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to fetch receipts", err)
+		return
+	}
+	if authorID == nil || *authorID != userID {
+		if !requireChatModerator(c, access) {
+			return
+		}
+	}
+
+	rows, err := h.db.QueryContext(ctx, `
+		WITH members AS (
+			SELECT hm.user_id FROM chat_channels ch
+			JOIN house_members hm ON hm.house_id = ch.house_id
+			WHERE ch.id = $1
+			UNION
+			SELECT cm.user_id FROM chat_channels ch
+			JOIN club_members cm ON cm.club_id = ch.club_id
+			WHERE ch.id = $1
+		)
+		SELECT u.id, u.full_name, r.last_delivered_at, r.last_read_at
+		FROM members m
+		JOIN users u ON u.id = m.user_id
+		LEFT JOIN chat_channel_reads r ON r.channel_id = $1 AND r.user_id = m.user_id
+		WHERE m.user_id IS DISTINCT FROM $2
+		ORDER BY u.full_name ASC
+	`, channelID, authorID)
+	if err != nil {
+		internalError(c, "Failed to fetch receipts", err)
+		return
+	}
+	defer rows.Close()
+
+	receipts := models.ChatMessageReceipts{
+		MessageID: messageID,
+		Read:      []models.ChatReceiptUser{},
+		Delivered: []models.ChatReceiptUser{},
+	}
+	for rows.Next() {
+		var u models.ChatReceiptUser
+		var deliveredAt, readAt *time.Time
+		if err := rows.Scan(&u.UserID, &u.FullName, &deliveredAt, &readAt); err != nil {
+			internalError(c, "Failed to fetch receipts", err)
+			return
+		}
+		receipts.MemberCount++
+		switch {
+		case readAt != nil && !readAt.Before(sentAt):
+			u.At = *readAt
+			receipts.Read = append(receipts.Read, u)
+			receipts.ReadCount++
+			receipts.DeliveredCount++
+		case deliveredAt != nil && !deliveredAt.Before(sentAt):
+			u.At = *deliveredAt
+			receipts.Delivered = append(receipts.Delivered, u)
+			receipts.DeliveredCount++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch receipts", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    receipts,
+	})
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+// ============================================================================
+// CLUB ANNOUNCEMENT READ RECEIPTS
+// ============================================================================
+
+// recordAnnouncementReads marks the club's announcements as read by the user
+// if they are a member. Reads by anyone else aren't recorded.
+func recordAnnouncementReads(ctx context.Context, db *sql.DB, clubID, userID uuid.UUID, announcementIDs []uuid.UUID) error {
+	if len(announcementIDs) == 0 {
+		return nil
+	}
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO club_announcement_reads (announcement_id, user_id)
+		SELECT a.id, $2
+		FROM club_announcements a
+		WHERE a.club_id = $1 AND a.id = ANY($3::uuid[])
+		  AND EXISTS (SELECT 1 FROM club_members WHERE club_id = $1 AND user_id = $2)
+		ON CONFLICT DO NOTHING
+	`, clubID, userID, pq.Array(announcementIDs))
+	return err
+}
+
+// clubAnnouncementParams parses the :id and :announcement_id route params.
+// Returns false if the request was aborted.
+func clubAnnouncementParams(c *gin.Context) (clubID, announcementID uuid.UUID, ok bool) {
+	clubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	announcementID, err = uuid.Parse(c.Param("announcement_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid announcement ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return clubID, announcementID, true
+}
+
+// AcknowledgeClubAnnouncement records that a member has read an
+// announcement, for apps that show it outside the club's announcement list
+// POST /api/v1/clubs/:id/announcements/:announcement_id/read
+func (h *ClubHandler) AcknowledgeClubAnnouncement(c *gin.Context) {
+	clubID, announcementID, ok := clubAnnouncementParams(c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.UserID(c)
+
+	var isMember bool
+	err := h.DB.QueryRowContext(c.Request.Context(), `
+		SELECT EXISTS (SELECT 1 FROM club_members WHERE club_id = $1 AND user_id = $2)
+		FROM club_announcements
+		WHERE id = $3 AND club_id = $1
+	`, clubID, userID, announcementID).Scan(&isMember)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Announcement not found"})
+		return
+	}
+	if err != nil {
+		internalErrorJSON(c, "Failed to record read receipt", err)
+		return
+	}
+	if !isMember {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only club members can acknowledge announcements"})
+		return
+	}
+
+	if err := recordAnnouncementReads(c.Request.Context(), h.DB, clubID, userID, []uuid.UUID{announcementID}); err != nil {
+		internalErrorJSON(c, "Failed to record read receipt", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Announcement marked as read"})
+}
+
+// GetAnnouncementReads returns what share of the club's current members have
+// read an announcement, and who hasn't (club leads only)
+// GET /api/v1/clubs/:id/announcements/:announcement_id/reads
+func (h *ClubHandler) GetAnnouncementReads(c *gin.Context) {
+	clubID, announcementID, ok := clubAnnouncementParams(c)
+	if !ok {
+		return
+	}
+
+	if !requireClubLead(c, h.DB, clubID) {
+		return
+	}
+
+	var exists bool
+	err := h.DB.QueryRowContext(c.Request.Context(),
+		"SELECT EXISTS (SELECT 1 FROM club_announcements WHERE id = $1 AND club_id = $2)", announcementID, clubID,
+	).Scan(&exists)
+	if err != nil {
+		internalErrorJSON(c, "Failed to fetch read receipts", err)
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Announcement not found"})
+		return
+	}
+
+	rows, err := h.DB.QueryContext(c.Request.Context(), `
+		SELECT u.id, u.full_name, u.email, r.user_id IS NOT NULL
+		FROM club_members cm
+		JOIN users u ON u.id = cm.user_id
+		LEFT JOIN club_announcement_reads r ON r.announcement_id = $2 AND r.user_id = cm.user_id
+		WHERE cm.club_id = $1
+		ORDER BY u.full_name ASC
+	`, clubID, announcementID)
+	if err != nil {
+		internalErrorJSON(c, "Failed to fetch read receipts", err)
+		return
+	}
+	defer rows.Close()
+
+	reads := models.AnnouncementReads{
+		AnnouncementID: announcementID,
+		Unread:         []models.AnnouncementReader{},
+	}
+	for rows.Next() {
+		var m models.AnnouncementReader
+		var read bool
+		if err := rows.Scan(&m.UserID, &m.FullName, &m.Email, &read); err != nil {
+			internalErrorJSON(c, "Failed to fetch read receipts", err)
+			return
+		}
+		reads.MemberCount++
+		if read {
+			reads.ReadCount++
+		} else {
+			reads.Unread = append(reads.Unread, m)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		internalErrorJSON(c, "Failed to fetch read receipts", err)
+		return
+	}
+	if reads.MemberCount > 0 {
+		reads.ReadPercentage = math.Round(float64(reads.ReadCount)*1000/float64(reads.MemberCount)) / 10
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": reads})
+}
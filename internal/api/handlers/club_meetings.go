@@ -0,0 +1,444 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/gamification"
+)
+
+const (
+	defaultCheckInMinutes = 10
+	maxCheckInMinutes     = 60
+)
+
+// ============================================================================
+// CLUB MEETINGS & ATTENDANCE
+// ============================================================================
+
+// canManageClub reports whether the current user is an app admin, or one of
+// the club's leads or its faculty advisor (see models.ClubRoleManages)
+func canManageClub(c *gin.Context, db *sql.DB, clubID uuid.UUID) (bool, error) {
+	if role, _ := middleware.Role(c); role == models.RoleAdmin {
+		return true, nil
+	}
+	userID, _ := middleware.UserID(c)
+
+	var role string
+	err := db.QueryRowContext(c.Request.Context(),
+		"SELECT COALESCE(role, 'member') FROM club_members WHERE club_id = $1 AND user_id = $2", clubID, userID,
+	).Scan(&role)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return models.ClubRoleManages(role), nil
+}
+
+// requireClubLead aborts with 403 unless the user can manage the club.
+// Returns false if the request has been answered.
+func requireClubLead(c *gin.Context, db *sql.DB, clubID uuid.UUID) bool {
+	ok, err := canManageClub(c, db, clubID)
+	if err != nil {
+		internalErrorJSON(c, "Failed to check club role", err)
+		return false
+	}
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only club leads can do this"})
+		return false
+	}
+	return true
+}
+
+// CreateClubMeeting schedules a new club meeting (club leads only)
+// POST /api/v1/clubs/:id/meetings
+func (h *ClubHandler) CreateClubMeeting(c *gin.Context) {
+	clubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+
+	var req models.CreateClubMeetingRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !requireClubLead(c, h.DB, clubID) {
+		return
+	}
+	userID, _ := middleware.UserID(c)
+
+	var m models.ClubMeeting
+	err = h.DB.QueryRowContext(c.Request.Context(), `
+		INSERT INTO club_meetings (club_id, title, description, location, starts_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, club_id, title, description, location, starts_at, created_by, created_at, updated_at
+	`, clubID, req.Title, req.Description, req.Location, req.StartsAt, userID).Scan(
+		&m.ID, &m.ClubID, &m.Title, &m.Description, &m.Location, &m.StartsAt,
+		&m.CreatedBy, &m.CreatedAt, &m.UpdatedAt,
+	)
+	if err != nil {
+		internalErrorJSON(c, "Failed to create meeting", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": m})
+}
+
+// GetClubMeetings lists a club's meetings with attendee counts, newest first
+// GET /api/v1/clubs/:id/meetings
+func (h *ClubHandler) GetClubMeetings(c *gin.Context) {
+	clubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+
+	rows, err := h.DB.QueryContext(c.Request.Context(), `
+		SELECT m.id, m.club_id, m.title, m.description, m.location, m.starts_at,
+		       m.created_by, m.created_at, m.updated_at,
+		       (SELECT COUNT(*) FROM club_meeting_attendance a WHERE a.meeting_id = m.id)
+		FROM club_meetings m
+		WHERE m.club_id = $1
+		ORDER BY m.starts_at DESC
+	`, clubID)
+	if err != nil {
+		internalErrorJSON(c, "Failed to fetch meetings", err)
+		return
+	}
+	defer rows.Close()
+
+	meetings := []models.ClubMeeting{}
+	for rows.Next() {
+		var m models.ClubMeeting
+		if err := rows.Scan(
+			&m.ID, &m.ClubID, &m.Title, &m.Description, &m.Location, &m.StartsAt,
+			&m.CreatedBy, &m.CreatedAt, &m.UpdatedAt, &m.AttendeeCount,
+		); err != nil {
+			internalErrorJSON(c, "Failed to scan meeting", err)
+			return
+		}
+		meetings = append(meetings, m)
+	}
+	if err := rows.Err(); err != nil {
+		internalErrorJSON(c, "Failed to read meetings", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": meetings})
+}
+
+// OpenMeetingCheckIn generates a fresh 6-digit check-in code for a meeting,
+// replacing any previous one (club leads only)
+// POST /api/v1/clubs/:id/meetings/:meeting_id/code
+func (h *ClubHandler) OpenMeetingCheckIn(c *gin.Context) {
+	clubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+	meetingID, err := uuid.Parse(c.Param("meeting_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid meeting ID"})
+		return
+	}
+
+	var req models.OpenCheckInRequest
+	if c.Request.ContentLength > 0 {
+		if err := bindJSON(c, &req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if req.ValidMinutes <= 0 {
+		req.ValidMinutes = defaultCheckInMinutes
+	}
+	if req.ValidMinutes > maxCheckInMinutes {
+		req.ValidMinutes = maxCheckInMinutes
+	}
+
+	if !requireClubLead(c, h.DB, clubID) {
+		return
+	}
+
+	code, err := newCheckInCode()
+	if err != nil {
+		internalErrorJSON(c, "Failed to generate code", err)
+		return
+	}
+	expiresAt := time.Now().Add(time.Duration(req.ValidMinutes) * time.Minute)
+
+	result, err := h.DB.ExecContext(c.Request.Context(), `
+		UPDATE club_meetings SET checkin_code = $1, checkin_expires_at = $2
+		WHERE id = $3 AND club_id = $4
+	`, code, expiresAt, meetingID, clubID)
+	if err != nil {
+		internalErrorJSON(c, "Failed to open check-in", err)
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Meeting not found"})
+		return
+	}
+
+	qr, _ := json.Marshal(map[string]string{
+		"type":       "club_meeting_check_in",
+		"club_id":    clubID.String(),
+		"meeting_id": meetingID.String(),
+		"code":       code,
+	})
+
+	c.JSON(http.StatusOK, gin.H{"data": models.CheckInCode{
+		MeetingID: meetingID,
+		Code:      code,
+		ExpiresAt: expiresAt,
+		QRPayload: string(qr),
+	}})
+}
+
+// CheckInToMeeting records the current member's attendance. Checking in
+// twice is a no-op.
+// POST /api/v1/clubs/:id/meetings/:meeting_id/check-in
+func (h *ClubHandler) CheckInToMeeting(c *gin.Context) {
+	clubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+	meetingID, err := uuid.Parse(c.Param("meeting_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid meeting ID"})
+		return
+	}
+
+	var req models.CheckInRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+	ctx := c.Request.Context()
+
+	var isMember bool
+	if err := h.DB.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM club_members WHERE club_id = $1 AND user_id = $2)", clubID, userID,
+	).Scan(&isMember); err != nil {
+		internalErrorJSON(c, "Failed to check in", err)
+		return
+	}
+	if !isMember {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only club members can check in"})
+		return
+	}
+
+	var code sql.NullString
+	var expiresAt sql.NullTime
+	err = h.DB.QueryRowContext(ctx,
+		"SELECT checkin_code, checkin_expires_at FROM club_meetings WHERE id = $1 AND club_id = $2", meetingID, clubID,
+	).Scan(&code, &expiresAt)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Meeting not found"})
+		return
+	}
+	if err != nil {
+		internalErrorJSON(c, "Failed to check in", err)
+		return
+	}
+
+	if !code.Valid || !expiresAt.Valid || time.Now().After(expiresAt.Time) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Check-in is not open for this meeting"})
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(code.String), []byte(req.Code)) != 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid check-in code"})
+		return
+	}
+
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		internalErrorJSON(c, "Failed to check in", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO club_meeting_attendance (meeting_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (meeting_id, user_id) DO NOTHING
+	`, meetingID, userID); err != nil {
+		internalErrorJSON(c, "Failed to check in", err)
+		return
+	}
+
+	if err := gamification.Award(ctx, tx, gamification.Entry{
+		UserID:   userID,
+		Source:   gamification.SourceMeetingAttendance,
+		SourceID: meetingID,
+	}); err != nil {
+		internalErrorJSON(c, "Failed to check in", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalErrorJSON(c, "Failed to check in", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Checked in successfully"})
+}
+
+// GetClubAttendance returns each member's attendance across all of the
+// club's meetings. With format=csv it downloads a spreadsheet with one column
+// per meeting (club leads only).
+// GET /api/v1/clubs/:id/attendance
+func (h *ClubHandler) GetClubAttendance(c *gin.Context) {
+	clubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+
+	if !requireClubLead(c, h.DB, clubID) {
+		return
+	}
+
+	meetings, attendance, err := h.loadAttendance(c.Request.Context(), clubID)
+	if err != nil {
+		internalErrorJSON(c, "Failed to fetch attendance", err)
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		writeAttendanceCSV(c, meetings, attendance)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": attendance})
+}
+
+// loadAttendance returns the club's meetings, oldest first, and each
+// current member's attendance
+func (h *ClubHandler) loadAttendance(ctx context.Context, clubID uuid.UUID) ([]models.ClubMeeting, []models.MemberAttendance, error) {
+	meetingRows, err := h.DB.QueryContext(ctx, `
+		SELECT id, title, starts_at FROM club_meetings WHERE club_id = $1 ORDER BY starts_at ASC
+	`, clubID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer meetingRows.Close()
+
+	meetings := []models.ClubMeeting{}
+	for meetingRows.Next() {
+		m := models.ClubMeeting{ClubID: clubID}
+		if err := meetingRows.Scan(&m.ID, &m.Title, &m.StartsAt); err != nil {
+			return nil, nil, err
+		}
+		meetings = append(meetings, m)
+	}
+	if err := meetingRows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := h.DB.QueryContext(ctx, `
+		SELECT u.id, u.full_name, u.email, a.meeting_id
+		FROM club_members cm
+		JOIN users u ON u.id = cm.user_id
+		LEFT JOIN club_meetings m ON m.club_id = cm.club_id
+		LEFT JOIN club_meeting_attendance a ON a.meeting_id = m.id AND a.user_id = cm.user_id
+		WHERE cm.club_id = $1
+		ORDER BY u.full_name ASC, u.id, m.starts_at ASC
+	`, clubID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	attendance := []models.MemberAttendance{}
+	for rows.Next() {
+		var userID uuid.UUID
+		var fullName, email string
+		var meetingID *uuid.UUID
+		if err := rows.Scan(&userID, &fullName, &email, &meetingID); err != nil {
+			return nil, nil, err
+		}
+		if n := len(attendance); n == 0 || attendance[n-1].UserID != userID {
+			attendance = append(attendance, models.MemberAttendance{
+				UserID:        userID,
+				FullName:      fullName,
+				Email:         email,
+				TotalMeetings: len(meetings),
+				MeetingIDs:    []uuid.UUID{},
+			})
+		}
+		if meetingID != nil {
+			a := &attendance[len(attendance)-1]
+			a.Attended++
+			a.MeetingIDs = append(a.MeetingIDs, *meetingID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return meetings, attendance, nil
+}
+
+func writeAttendanceCSV(c *gin.Context, meetings []models.ClubMeeting, attendance []models.MemberAttendance) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="attendance.csv"`)
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	header := []string{"full_name", "email"}
+	for _, m := range meetings {
+		header = append(header, m.StartsAt.In(time.Local).Format(dateLayout)+" "+m.Title)
+	}
+	header = append(header, "attended", "total_meetings")
+	w.Write(header)
+
+	for _, a := range attendance {
+		present := make(map[uuid.UUID]bool, len(a.MeetingIDs))
+		for _, id := range a.MeetingIDs {
+			present[id] = true
+		}
+
+		record := []string{a.FullName, a.Email}
+		for _, m := range meetings {
+			if present[m.ID] {
+				record = append(record, "1")
+			} else {
+				record = append(record, "0")
+			}
+		}
+		record = append(record, strconv.Itoa(a.Attended), strconv.Itoa(a.TotalMeetings))
+		w.Write(record)
+	}
+	w.Flush()
+}
+
+// newCheckInCode returns a uniformly random 6-digit code
+func newCheckInCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/moderation"
+)
+
+const clubReviewColumns = `
+	r.id, r.club_id, r.rating, r.body, r.held_for_review, r.created_at, r.updated_at,
+	u.id, u.full_name, u.avatar_url, u.role`
+
+func scanClubReview(row interface{ Scan(...interface{}) error }) (models.ClubReview, error) {
+	var r models.ClubReview
+	err := row.Scan(
+		&r.ID, &r.ClubID, &r.Rating, &r.Body, &r.HeldForReview, &r.CreatedAt, &r.UpdatedAt,
+		&r.Author.ID, &r.Author.FullName, &r.Author.AvatarURL, &r.Author.Role,
+	)
+	return r, err
+}
+
+// ClubReviewHandler handles reviews of clubs
+type ClubReviewHandler struct {
+	db     *sql.DB
+	filter *moderation.Filter
+}
+
+// NewClubReviewHandler creates a new club review handler. Reviews are
+// screened by filter, which may be nil.
+func NewClubReviewHandler(db *sql.DB, filter *moderation.Filter) *ClubReviewHandler {
+	return &ClubReviewHandler{db: db, filter: filter}
+}
+
+// ListClubReviews lists a club's reviews, newest first. Signed in, the
+// caller also sees their own review while it's held for moderation.
+// GET /api/v1/clubs/:id/reviews
+func (h *ClubReviewHandler) ListClubReviews(c *gin.Context) {
+	clubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid club ID"),
+		})
+		return
+	}
+
+	var query models.ListClubReviewsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid query parameters"),
+		})
+		return
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 {
+		query.PageSize = 20
+	}
+	if query.PageSize > 100 {
+		query.PageSize = 100
+	}
+
+	var viewerID *uuid.UUID
+	if userID, exists := middleware.UserID(c); exists {
+		viewerID = &userID
+	}
+
+	where := `
+		FROM club_reviews r
+		JOIN users u ON u.id = r.user_id
+		WHERE r.club_id = $1 AND r.deleted_at IS NULL
+		  AND (NOT r.held_for_review OR r.user_id = $2)
+		  AND ` + moderation.VisibleTo("$2", "r.user_id")
+
+	ctx := c.Request.Context()
+	var total int
+	if err := h.db.QueryRowContext(ctx, "SELECT COUNT(*) "+where, clubID, viewerID).Scan(&total); err != nil {
+		internalError(c, "Failed to fetch reviews", err)
+		return
+	}
+
+	rows, err := h.db.QueryContext(ctx, `
+		SELECT `+clubReviewColumns+where+`
+		ORDER BY r.created_at DESC
+		LIMIT $3 OFFSET $4
+	`, clubID, viewerID, query.PageSize, (query.Page-1)*query.PageSize)
+	if err != nil {
+		internalError(c, "Failed to fetch reviews", err)
+		return
+	}
+	defer rows.Close()
+	reviews := []models.ClubReview{}
+	for rows.Next() {
+		r, err := scanClubReview(rows)
+		if err != nil {
+			internalError(c, "Failed to fetch reviews", err)
+			return
+		}
+		reviews = append(reviews, r)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch reviews", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: models.PaginatedResponse{
+			Data:       reviews,
+			Page:       query.Page,
+			PageSize:   query.PageSize,
+			TotalItems: int64(total),
+			TotalPages: (total + query.PageSize - 1) / query.PageSize,
+		},
+	})
+}
+
+// ReviewClub writes the caller's review of a club, replacing the one they
+// wrote before, if any. Only members of the club and those registered for
+// one of its events once it has started may review it. A review the
+// content filter flags is held until a moderator approves it; rewriting a
+// review dismisses the flags on what it said before.
+// PUT /api/v1/clubs/:id/review
+func (h *ClubReviewHandler) ReviewClub(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	clubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid club ID"),
+		})
+		return
+	}
+
+	var req models.ClubReviewRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid request: " + err.Error()),
+		})
+		return
+	}
+	req.Body = strings.TrimSpace(req.Body)
+
+	ctx := c.Request.Context()
+	var exists, eligible bool
+	err = h.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM clubs WHERE id = $1),
+		       EXISTS (SELECT 1 FROM club_members WHERE club_id = $1 AND user_id = $2)
+		       OR EXISTS (
+		           SELECT 1 FROM event_registrations er
+		           JOIN events e ON e.id = er.event_id
+		           WHERE e.club_id = $1 AND er.user_id = $2
+		             AND e.deleted_at IS NULL AND e.status <> 'cancelled' AND e.start_date <= NOW()
+		       )
+	`, clubID, userID).Scan(&exists, &eligible)
+	if err != nil {
+		internalError(c, "Failed to save review", err)
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Club not found"),
+		})
+		return
+	}
+	if !eligible {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Only members of the club and those who've been to its events can review it"),
+		})
+		return
+	}
+	if !requireCanInteract(c, h.db, userID, nil, true) {
+		return
+	}
+	screened, ok := screenContent(c, h.filter, "review", req.Body)
+	if !ok {
+		return
+	}
+	held := screened.Verdict == moderation.Flag
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to save review", err)
+		return
+	}
+	defer tx.Rollback()
+
+	var reviewID uuid.UUID
+	var created bool
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO club_reviews (club_id, user_id, rating, body, held_for_review)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (club_id, user_id) WHERE deleted_at IS NULL DO UPDATE
+		SET rating = EXCLUDED.rating, body = EXCLUDED.body, held_for_review = EXCLUDED.held_for_review
+		RETURNING id, xmax = 0
+	`, clubID, userID, req.Rating, req.Body, held).Scan(&reviewID, &created)
+	if err != nil {
+		internalError(c, "Failed to save review", err)
+		return
+	}
+
+	if !created {
+		if err := moderation.DismissFlags(ctx, tx, moderation.ContentClubReview, reviewID); err != nil {
+			internalError(c, "Failed to save review", err)
+			return
+		}
+	}
+	if held {
+		if err := moderation.RecordFlag(ctx, tx, moderation.ContentClubReview, reviewID, userID, screened.Reasons); err != nil {
+			internalError(c, "Failed to save review", err)
+			return
+		}
+	}
+
+	review, err := scanClubReview(tx.QueryRowContext(ctx, `
+		SELECT `+clubReviewColumns+`
+		FROM club_reviews r
+		JOIN users u ON u.id = r.user_id
+		WHERE r.id = $1
+	`, reviewID))
+	if err != nil {
+		internalError(c, "Failed to save review", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to save review", err)
+		return
+	}
+
+	status, message := http.StatusOK, "Review updated"
+	if created {
+		status, message = http.StatusCreated, "Review added"
+	}
+	if held {
+		message = "Review saved and held for moderation"
+	}
+	c.JSON(status, models.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    review,
+	})
+}
+
+// DeleteClubReview deletes the caller's review of a club
+// DELETE /api/v1/clubs/:id/review
+func (h *ClubReviewHandler) DeleteClubReview(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	clubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid club ID"),
+		})
+		return
+	}
+
+	var reviewID uuid.UUID
+	err = h.db.QueryRowContext(c.Request.Context(), `
+		UPDATE club_reviews SET deleted_at = NOW()
+		WHERE club_id = $1 AND user_id = $2 AND deleted_at IS NULL
+		RETURNING id
+	`, clubID, userID).Scan(&reviewID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("You haven't reviewed this club"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to delete review", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Review deleted",
+	})
+}
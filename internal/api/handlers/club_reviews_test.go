@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/auth"
+)
+
+// TestReviewClubRequiresMembershipOrAttendance verifies a club can only be
+// reviewed by its members and those who have been to its events, and that
+// a missing club is reported as such.
+func TestReviewClubRequiresMembershipOrAttendance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authService := auth.NewService("test-secret", 1, 1)
+
+	tests := []struct {
+		name     string
+		exists   bool
+		eligible bool
+		want     int
+	}{
+		{"no such club", false, false, http.StatusNotFound},
+		{"neither member nor attendee", true, false, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openFakeDB(t, fakeResult{
+				columns: []string{"exists", "eligible"},
+				rows:    [][]driver.Value{{tt.exists, tt.eligible}},
+			})
+			h := NewClubReviewHandler(db, nil)
+			router := gin.New()
+			router.Use(middleware.AuthMiddleware(authService))
+			router.PUT("/clubs/:id/review", h.ReviewClub)
+
+			token, err := authService.GenerateAccessToken(&models.User{
+				ID: uuid.New(), Email: "student@college.edu", Role: models.RoleStudent,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := httptest.NewRequest(http.MethodPut, "/clubs/"+uuid.NewString()+"/review",
+				strings.NewReader(`{"rating": 4, "body": "Great workshops"}`))
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
@@ -2,11 +2,17 @@ package handlers
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
 	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+	"github.com/yourusername/college-event-backend/internal/repository"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
 )
 
 type ClubHandler struct {
@@ -15,36 +21,11 @@ type ClubHandler struct {
 
 // GetClubs retrieves all clubs
 func (h *ClubHandler) GetClubs(c *gin.Context) {
-	query := `
-		SELECT id, department_id, name, tagline, description, logo_url,
-		       primary_color, secondary_color, member_count, event_count,
-		       awards_count, rating, email, phone, website, social_links,
-		       created_at, updated_at
-		FROM clubs
-		ORDER BY name ASC
-	`
-
-	rows, err := h.DB.Query(query)
+	clubs, err := repository.New(h.DB).ListClubs(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch clubs"})
+		internalErrorJSON(c, "Failed to fetch clubs", err)
 		return
 	}
-	defer rows.Close()
-
-	clubs := []models.Club{}
-	for rows.Next() {
-		var club models.Club
-		if err := rows.Scan(
-			&club.ID, &club.DepartmentID, &club.Name, &club.Tagline, &club.Description,
-			&club.LogoURL, &club.PrimaryColor, &club.SecondaryColor, &club.MemberCount,
-			&club.EventCount, &club.AwardsCount, &club.Rating, &club.Email, &club.Phone,
-			&club.Website, &club.SocialLinks, &club.CreatedAt, &club.UpdatedAt,
-		); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan club"})
-			return
-		}
-		clubs = append(clubs, club)
-	}
 
 	c.JSON(http.StatusOK, gin.H{"data": clubs})
 }
@@ -58,22 +39,7 @@ func (h *ClubHandler) GetClub(c *gin.Context) {
 		return
 	}
 
-	query := `
-		SELECT id, department_id, name, tagline, description, logo_url,
-		       primary_color, secondary_color, member_count, event_count,
-		       awards_count, rating, email, phone, website, social_links,
-		       created_at, updated_at
-		FROM clubs
-		WHERE id = $1
-	`
-
-	var club models.Club
-	err = h.DB.QueryRow(query, clubID).Scan(
-		&club.ID, &club.DepartmentID, &club.Name, &club.Tagline, &club.Description,
-		&club.LogoURL, &club.PrimaryColor, &club.SecondaryColor, &club.MemberCount,
-		&club.EventCount, &club.AwardsCount, &club.Rating, &club.Email, &club.Phone,
-		&club.Website, &club.SocialLinks, &club.CreatedAt, &club.UpdatedAt,
-	)
+	club, err := repository.New(h.DB).GetClub(c.Request.Context(), clubID)
 
 	if err == sql.ErrNoRows {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Club not found"})
@@ -84,13 +50,35 @@ func (h *ClubHandler) GetClub(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": club})
+	detail := models.ClubDetail{Club: club}
+	detail.FollowerCount, err = followerCount(c.Request.Context(), h.DB, models.FollowTargetClub, clubID)
+	if err != nil {
+		internalErrorJSON(c, "Failed to fetch club", err)
+		return
+	}
+	if userID, ok := middleware.UserID(c); ok {
+		var m models.ClubMember
+		err := h.DB.QueryRowContext(c.Request.Context(), `
+			SELECT id, club_id, user_id, role, position, joined_at, created_at
+			FROM club_members
+			WHERE club_id = $1 AND user_id = $2
+		`, clubID, userID).Scan(&m.ID, &m.ClubID, &m.UserID, &m.Role, &m.Position, &m.JoinedAt, &m.CreatedAt)
+		switch {
+		case err == nil:
+			detail.Membership = &m
+		case err != sql.ErrNoRows:
+			internalErrorJSON(c, "Failed to fetch club", err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": detail})
 }
 
 // CreateClub creates a new club (admin only)
 func (h *ClubHandler) CreateClub(c *gin.Context) {
 	var req models.CreateClubRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -136,7 +124,8 @@ func (h *ClubHandler) CreateClub(c *gin.Context) {
 	c.JSON(http.StatusCreated, gin.H{"data": club})
 }
 
-// UpdateClub updates a club (admin only)
+// UpdateClub updates a club (admin only). If the body names the version it
+// was edited from and the club has changed since, it's refused with 409.
 func (h *ClubHandler) UpdateClub(c *gin.Context) {
 	id := c.Param("id")
 	clubID, err := uuid.Parse(id)
@@ -146,7 +135,7 @@ func (h *ClubHandler) UpdateClub(c *gin.Context) {
 	}
 
 	var req models.UpdateClubRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -164,27 +153,41 @@ func (h *ClubHandler) UpdateClub(c *gin.Context) {
 		    phone = COALESCE($9, phone),
 		    website = COALESCE($10, website),
 		    social_links = COALESCE($11, social_links),
+		    version = version + 1,
 		    updated_at = CURRENT_TIMESTAMP
 		WHERE id = $12
+		  AND ($13::int IS NULL OR version = $13)
 		RETURNING id, department_id, name, tagline, description, logo_url,
 		          primary_color, secondary_color, member_count, event_count,
 		          awards_count, rating, email, phone, website, social_links,
-		          created_at, updated_at
+		          version, created_at, updated_at
 	`
 
 	var club models.Club
 	err = h.DB.QueryRow(
 		query, req.DepartmentID, req.Name, req.Tagline, req.Description,
 		req.LogoURL, req.PrimaryColor, req.SecondaryColor, req.Email,
-		req.Phone, req.Website, req.SocialLinks, clubID,
+		req.Phone, req.Website, req.SocialLinks, clubID, req.Version,
 	).Scan(
 		&club.ID, &club.DepartmentID, &club.Name, &club.Tagline, &club.Description,
 		&club.LogoURL, &club.PrimaryColor, &club.SecondaryColor, &club.MemberCount,
 		&club.EventCount, &club.AwardsCount, &club.Rating, &club.Email, &club.Phone,
-		&club.Website, &club.SocialLinks, &club.CreatedAt, &club.UpdatedAt,
+		&club.Website, &club.SocialLinks, &club.Version, &club.CreatedAt, &club.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
+		current, ok, err := staleVersion(c.Request.Context(), h.DB, clubVersionQuery, clubID)
+		if err != nil {
+			internalErrorJSON(c, "Failed to update club", err)
+			return
+		}
+		if ok {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "Club was edited by someone else since you loaded it; reload it and reapply your changes",
+				"version": current,
+			})
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{"error": "Club not found"})
 		return
 	}
@@ -233,19 +236,30 @@ func (h *ClubHandler) GetClubMembers(c *gin.Context) {
 		return
 	}
 
+	// ?term= lists the members who joined in a term
+	termID, err := termFilter(c.Request.Context(), h.DB, c.Query("term"))
+	if errors.Is(err, errInvalidTerm) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid term"})
+		return
+	}
+	if err != nil {
+		internalErrorJSON(c, "Failed to fetch members", err)
+		return
+	}
+
 	query := `
 		SELECT cm.id, cm.club_id, cm.user_id, cm.role, cm.position, cm.joined_at, cm.created_at,
 		       u.id, u.email, u.full_name, u.role, u.avatar_url, u.department, u.year,
 		       u.created_at, u.updated_at
 		FROM club_members cm
 		JOIN users u ON cm.user_id = u.id
-		WHERE cm.club_id = $1
+		WHERE cm.club_id = $1 AND ($2::uuid IS NULL OR cm.term_id = $2)
 		ORDER BY cm.joined_at DESC
 	`
 
-	rows, err := h.DB.Query(query, clubID)
+	rows, err := h.DB.Query(query, clubID, termID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch members"})
+		internalErrorJSON(c, "Failed to fetch members", err)
 		return
 	}
 	defer rows.Close()
@@ -258,16 +272,22 @@ func (h *ClubHandler) GetClubMembers(c *gin.Context) {
 			&m.User.ID, &m.User.Email, &m.User.FullName, &m.User.Role, &m.User.AvatarURL,
 			&m.User.Department, &m.User.Year, &m.User.CreatedAt, &m.User.UpdatedAt,
 		); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan member"})
+			internalErrorJSON(c, "Failed to scan member", err)
 			return
 		}
 		members = append(members, m)
 	}
+	if err := rows.Err(); err != nil {
+		internalErrorJSON(c, "Failed to read members", err)
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{"data": members})
 }
 
-// AddClubMember adds a member to a club
+// AddClubMember adds a member to a club (club leads only). Alumni can't be
+// added.
+// POST /api/v1/clubs/:id/members
 func (h *ClubHandler) AddClubMember(c *gin.Context) {
 	id := c.Param("id")
 	clubID, err := uuid.Parse(id)
@@ -275,14 +295,34 @@ func (h *ClubHandler) AddClubMember(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
 		return
 	}
+	if !requireClubLead(c, h.DB, clubID) {
+		return
+	}
 
 	var req models.AddClubMemberRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	role := "member"
+	var userRole models.UserRole
+	err = h.DB.QueryRowContext(c.Request.Context(),
+		"SELECT role FROM users WHERE id = $1 AND deleted_at IS NULL", req.UserID,
+	).Scan(&userRole)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	if err != nil {
+		internalErrorJSON(c, "Failed to add member", err)
+		return
+	}
+	if userRole == models.RoleAlumni {
+		c.JSON(http.StatusConflict, gin.H{"error": "Alumni can't be club members"})
+		return
+	}
+
+	role := models.ClubRoleMember
 	if req.Role != nil {
 		role = *req.Role
 	}
@@ -307,13 +347,17 @@ func (h *ClubHandler) AddClubMember(c *gin.Context) {
 	c.JSON(http.StatusCreated, gin.H{"data": member})
 }
 
-// UpdateClubMember updates a club member
+// UpdateClubMember changes a member's role or position (club leads only)
+// PUT /api/v1/clubs/:id/members/:user_id
 func (h *ClubHandler) UpdateClubMember(c *gin.Context) {
 	clubID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
 		return
 	}
+	if !requireClubLead(c, h.DB, clubID) {
+		return
+	}
 
 	userID, err := uuid.Parse(c.Param("user_id"))
 	if err != nil {
@@ -322,7 +366,7 @@ func (h *ClubHandler) UpdateClubMember(c *gin.Context) {
 	}
 
 	var req models.UpdateClubMemberRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -353,13 +397,18 @@ func (h *ClubHandler) UpdateClubMember(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": member})
 }
 
-// RemoveClubMember removes a member from a club
+// RemoveClubMember removes a member from a club (club leads only; members
+// leave with DELETE /me/clubs/:id)
+// DELETE /api/v1/clubs/:id/members/:user_id
 func (h *ClubHandler) RemoveClubMember(c *gin.Context) {
 	clubID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
 		return
 	}
+	if !requireClubLead(c, h.DB, clubID) {
+		return
+	}
 
 	userID, err := uuid.Parse(c.Param("user_id"))
 	if err != nil {
@@ -382,11 +431,85 @@ func (h *ClubHandler) RemoveClubMember(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Member removed successfully"})
 }
 
+// GetMyClubs retrieves the clubs the current user belongs to
+// GET /api/v1/me/clubs
+func (h *ClubHandler) GetMyClubs(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	rows, err := h.DB.QueryContext(c.Request.Context(), `
+		SELECT cm.id, cm.club_id, cm.user_id, cm.role, cm.position, cm.joined_at, cm.created_at,
+		       cl.id, cl.department_id, cl.name, cl.tagline, cl.description, cl.logo_url,
+		       cl.primary_color, cl.secondary_color, cl.member_count, cl.event_count,
+		       cl.awards_count, cl.rating, cl.email, cl.phone, cl.website, cl.social_links,
+		       cl.created_at, cl.updated_at
+		FROM club_members cm
+		JOIN clubs cl ON cm.club_id = cl.id
+		WHERE cm.user_id = $1
+		ORDER BY cl.name ASC
+	`, userID)
+	if err != nil {
+		internalErrorJSON(c, "Failed to fetch clubs", err)
+		return
+	}
+	defer rows.Close()
+
+	memberships := []models.ClubMembership{}
+	for rows.Next() {
+		var m models.ClubMembership
+		if err := rows.Scan(
+			&m.ID, &m.ClubID, &m.UserID, &m.Role, &m.Position, &m.JoinedAt, &m.CreatedAt,
+			&m.Club.ID, &m.Club.DepartmentID, &m.Club.Name, &m.Club.Tagline, &m.Club.Description, &m.Club.LogoURL,
+			&m.Club.PrimaryColor, &m.Club.SecondaryColor, &m.Club.MemberCount, &m.Club.EventCount,
+			&m.Club.AwardsCount, &m.Club.Rating, &m.Club.Email, &m.Club.Phone, &m.Club.Website, &m.Club.SocialLinks,
+			&m.Club.CreatedAt, &m.Club.UpdatedAt,
+		); err != nil {
+			internalErrorJSON(c, "Failed to scan club", err)
+			return
+		}
+		memberships = append(memberships, m)
+	}
+	if err := rows.Err(); err != nil {
+		internalErrorJSON(c, "Failed to read clubs", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": memberships})
+}
+
+// LeaveClub removes the current user from a club
+// DELETE /api/v1/me/clubs/:id
+func (h *ClubHandler) LeaveClub(c *gin.Context) {
+	clubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+
+	result, err := h.DB.ExecContext(c.Request.Context(),
+		"DELETE FROM club_members WHERE club_id = $1 AND user_id = $2", clubID, userID)
+	if err != nil {
+		internalErrorJSON(c, "Failed to leave club", err)
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "You are not a member of this club"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Left club successfully"})
+}
+
 // ============================================================================
 // CLUB ANNOUNCEMENTS
 // ============================================================================
 
-// GetClubAnnouncements retrieves all announcements for a club
+// GetClubAnnouncements retrieves all announcements for a club. When a
+// member fetches them, they are recorded as read, unless an admin is
+// impersonating them.
 func (h *ClubHandler) GetClubAnnouncements(c *gin.Context) {
 	id := c.Param("id")
 	clubID, err := uuid.Parse(id)
@@ -404,7 +527,7 @@ func (h *ClubHandler) GetClubAnnouncements(c *gin.Context) {
 
 	rows, err := h.DB.Query(query, clubID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch announcements"})
+		internalErrorJSON(c, "Failed to fetch announcements", err)
 		return
 	}
 	defer rows.Close()
@@ -416,11 +539,28 @@ func (h *ClubHandler) GetClubAnnouncements(c *gin.Context) {
 			&a.ID, &a.ClubID, &a.Title, &a.Content, &a.Priority,
 			&a.IsPinned, &a.CreatedBy, &a.CreatedAt, &a.UpdatedAt,
 		); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan announcement"})
+			internalErrorJSON(c, "Failed to scan announcement", err)
 			return
 		}
 		announcements = append(announcements, a)
 	}
+	if err := rows.Err(); err != nil {
+		internalErrorJSON(c, "Failed to read announcements", err)
+		return
+	}
+
+	// A member fetching the announcements has read them. An admin browsing
+	// as the member hasn't, and support sessions mustn't write anyway.
+	_, impersonating := middleware.Impersonation(c)
+	if userID, ok := middleware.UserID(c); ok && !impersonating {
+		ids := make([]uuid.UUID, len(announcements))
+		for i, a := range announcements {
+			ids[i] = a.ID
+		}
+		if err := recordAnnouncementReads(c.Request.Context(), h.DB, clubID, userID, ids); err != nil {
+			logInternalError(c, "Failed to record read receipts", err)
+		}
+	}
 
 	c.JSON(http.StatusOK, gin.H{"data": announcements})
 }
@@ -435,7 +575,7 @@ func (h *ClubHandler) CreateClubAnnouncement(c *gin.Context) {
 	}
 
 	var req models.CreateAnnouncementRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -450,7 +590,7 @@ func (h *ClubHandler) CreateClubAnnouncement(c *gin.Context) {
 	}
 
 	// Get user ID from context (set by auth middleware)
-	userID, _ := c.Get("userID")
+	userID, _ := middleware.UserID(c)
 
 	query := `
 		INSERT INTO club_announcements (club_id, title, content, priority, is_pinned, created_by)
@@ -458,8 +598,17 @@ func (h *ClubHandler) CreateClubAnnouncement(c *gin.Context) {
 		RETURNING id, club_id, title, content, priority, is_pinned, created_by, created_at, updated_at
 	`
 
+	// Member notifications are queued in the same transaction as the announcement
+	ctx := c.Request.Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create announcement"})
+		return
+	}
+	defer tx.Rollback()
+
 	var announcement models.ClubAnnouncement
-	err = h.DB.QueryRow(query, clubID, req.Title, req.Content, priority, isPinned, userID).Scan(
+	err = tx.QueryRowContext(ctx, query, clubID, req.Title, req.Content, priority, isPinned, userID).Scan(
 		&announcement.ID, &announcement.ClubID, &announcement.Title, &announcement.Content,
 		&announcement.Priority, &announcement.IsPinned, &announcement.CreatedBy,
 		&announcement.CreatedAt, &announcement.UpdatedAt,
@@ -470,6 +619,21 @@ func (h *ClubHandler) CreateClubAnnouncement(c *gin.Context) {
 		return
 	}
 
+	err = outbox.Write(ctx, tx, notifications.TopicClubAnnouncementCreated, notifications.ClubAnnouncementCreatedPayload{
+		AnnouncementID: announcement.ID,
+		ClubID:         announcement.ClubID,
+		Title:          announcement.Title,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create announcement"})
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create announcement"})
+		return
+	}
+
 	c.JSON(http.StatusCreated, gin.H{"data": announcement})
 }
 
@@ -488,7 +652,7 @@ func (h *ClubHandler) UpdateClubAnnouncement(c *gin.Context) {
 	}
 
 	var req models.UpdateAnnouncementRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -556,7 +720,8 @@ func (h *ClubHandler) DeleteClubAnnouncement(c *gin.Context) {
 // CLUB AWARDS
 // ============================================================================
 
-// GetClubAwards retrieves all awards for a club
+// GetClubAwards retrieves all awards for a club, or with ?term= those won in
+// an academic term
 func (h *ClubHandler) GetClubAwards(c *gin.Context) {
 	id := c.Param("id")
 	clubID, err := uuid.Parse(id)
@@ -565,17 +730,27 @@ func (h *ClubHandler) GetClubAwards(c *gin.Context) {
 		return
 	}
 
+	termID, err := termFilter(c.Request.Context(), h.DB, c.Query("term"))
+	if errors.Is(err, errInvalidTerm) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid term"})
+		return
+	}
+	if err != nil {
+		internalErrorJSON(c, "Failed to fetch awards", err)
+		return
+	}
+
 	query := `
 		SELECT id, club_id, award_name, description, position, prize_amount,
-		       event_name, awarded_date, certificate_url, created_at
+		       event_name, awarded_date, certificate_url, term_id, created_at
 		FROM club_awards
-		WHERE club_id = $1
+		WHERE club_id = $1 AND ($2::uuid IS NULL OR term_id = $2)
 		ORDER BY awarded_date DESC NULLS LAST
 	`
 
-	rows, err := h.DB.Query(query, clubID)
+	rows, err := h.DB.Query(query, clubID, termID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch awards"})
+		internalErrorJSON(c, "Failed to fetch awards", err)
 		return
 	}
 	defer rows.Close()
@@ -585,18 +760,23 @@ func (h *ClubHandler) GetClubAwards(c *gin.Context) {
 		var a models.ClubAward
 		if err := rows.Scan(
 			&a.ID, &a.ClubID, &a.AwardName, &a.Description, &a.Position,
-			&a.PrizeAmount, &a.EventName, &a.AwardedDate, &a.CertificateURL, &a.CreatedAt,
+			&a.PrizeAmount, &a.EventName, &a.AwardedDate, &a.CertificateURL, &a.TermID, &a.CreatedAt,
 		); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan award"})
+			internalErrorJSON(c, "Failed to scan award", err)
 			return
 		}
 		awards = append(awards, a)
 	}
+	if err := rows.Err(); err != nil {
+		internalErrorJSON(c, "Failed to read awards", err)
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{"data": awards})
 }
 
-// CreateClubAward adds a new award
+// CreateClubAward adds a new award (club leads only)
+// POST /api/v1/clubs/:id/awards
 func (h *ClubHandler) CreateClubAward(c *gin.Context) {
 	id := c.Param("id")
 	clubID, err := uuid.Parse(id)
@@ -604,9 +784,12 @@ func (h *ClubHandler) CreateClubAward(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
 		return
 	}
+	if !requireClubLead(c, h.DB, clubID) {
+		return
+	}
 
 	var req models.CreateAwardRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -618,7 +801,7 @@ func (h *ClubHandler) CreateClubAward(c *gin.Context) {
 		)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id, club_id, award_name, description, position, prize_amount,
-		          event_name, awarded_date, certificate_url, created_at
+		          event_name, awarded_date, certificate_url, term_id, created_at
 	`
 
 	var award models.ClubAward
@@ -628,7 +811,7 @@ func (h *ClubHandler) CreateClubAward(c *gin.Context) {
 	).Scan(
 		&award.ID, &award.ClubID, &award.AwardName, &award.Description,
 		&award.Position, &award.PrizeAmount, &award.EventName, &award.AwardedDate,
-		&award.CertificateURL, &award.CreatedAt,
+		&award.CertificateURL, &award.TermID, &award.CreatedAt,
 	)
 
 	if err != nil {
@@ -643,7 +826,8 @@ func (h *ClubHandler) CreateClubAward(c *gin.Context) {
 // CLUB EVENTS
 // ============================================================================
 
-// GetClubEvents retrieves all events for a club
+// GetClubEvents retrieves all events for a club, leaving out alumni events
+// for those who don't see them
 func (h *ClubHandler) GetClubEvents(c *gin.Context) {
 	id := c.Param("id")
 	clubID, err := uuid.Parse(id)
@@ -655,15 +839,16 @@ func (h *ClubHandler) GetClubEvents(c *gin.Context) {
 	query := `
 		SELECT id, title, description, start_date, end_date, location,
 		       banner_url, category, status, max_participants, current_participants,
-		       registration_deadline, is_featured, club_id, created_at, updated_at
+		       registration_deadline, is_featured, alumni_access, club_id, created_at, updated_at
 		FROM events
-		WHERE club_id = $1
+		WHERE club_id = $1 AND (alumni_access <> 'only' OR $2)
 		ORDER BY start_date DESC
 	`
 
-	rows, err := h.DB.Query(query, clubID)
+	role, _ := middleware.Role(c)
+	rows, err := h.DB.Query(query, clubID, models.SeesAlumniEvents(role))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch events"})
+		internalErrorJSON(c, "Failed to fetch events", err)
 		return
 	}
 	defer rows.Close()
@@ -674,13 +859,126 @@ func (h *ClubHandler) GetClubEvents(c *gin.Context) {
 		if err := rows.Scan(
 			&e.ID, &e.Title, &e.Description, &e.StartDate, &e.EndDate, &e.Location,
 			&e.BannerURL, &e.Category, &e.Status, &e.MaxParticipants, &e.CurrentParticipants,
-			&e.RegistrationDeadline, &e.IsFeatured, &e.ClubID, &e.CreatedAt, &e.UpdatedAt,
+			&e.RegistrationDeadline, &e.IsFeatured, &e.AlumniAccess, &e.ClubID, &e.CreatedAt, &e.UpdatedAt,
 		); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan event"})
+			internalErrorJSON(c, "Failed to scan event", err)
 			return
 		}
 		events = append(events, e)
 	}
+	if err := rows.Err(); err != nil {
+		internalErrorJSON(c, "Failed to read events", err)
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{"data": events})
 }
+
+// GetClubCalendar returns a month of the club's events and announcements
+// bucketed by day. Pass include=house_events to cross-promote house events.
+// GET /api/v1/clubs/:id/calendar?month=2025-03
+func (h *ClubHandler) GetClubCalendar(c *gin.Context) {
+	clubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+
+	month := time.Now()
+	if m := c.Query("month"); m != "" {
+		month, err = time.ParseInLocation("2006-01", m, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid month, use YYYY-MM"})
+			return
+		}
+	}
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 1, 0)
+
+	ctx := c.Request.Context()
+
+	var exists bool
+	if err := h.DB.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM clubs WHERE id = $1)", clubID).Scan(&exists); err != nil {
+		internalErrorJSON(c, "Failed to fetch calendar", err)
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Club not found"})
+		return
+	}
+
+	cal := newCalendarBuilder(from, to)
+
+	// Events overlapping the month, alumni events only for those who see
+	// them
+	role, _ := middleware.Role(c)
+	rows, err := h.DB.QueryContext(ctx, `
+		SELECT id, title, start_date, end_date, location
+		FROM events
+		WHERE club_id = $1 AND deleted_at IS NULL AND start_date < $3 AND end_date >= $2
+		  AND (alumni_access <> 'only' OR $4)
+		ORDER BY start_date ASC
+	`, clubID, from, to, models.SeesAlumniEvents(role))
+	if err != nil {
+		internalErrorJSON(c, "Failed to fetch calendar", err)
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var item models.CalendarItem
+		var start, end time.Time
+		if err := rows.Scan(&item.ID, &item.Title, &start, &end, &item.Location); err != nil {
+			internalErrorJSON(c, "Failed to fetch calendar", err)
+			return
+		}
+		item.Type = models.CalendarItemEvent
+		item.StartsAt, item.EndsAt = &start, &end
+		item.ClubID = &clubID
+		cal.addSpan(start, end, item)
+	}
+	if err := rows.Err(); err != nil {
+		internalErrorJSON(c, "Failed to fetch calendar", err)
+		return
+	}
+
+	// Announcements posted during the month
+	annRows, err := h.DB.QueryContext(ctx, `
+		SELECT id, title, created_at
+		FROM club_announcements
+		WHERE club_id = $1 AND created_at >= $2 AND created_at < $3
+	`, clubID, from, to)
+	if err != nil {
+		internalErrorJSON(c, "Failed to fetch calendar", err)
+		return
+	}
+	defer annRows.Close()
+	for annRows.Next() {
+		var item models.CalendarItem
+		var created time.Time
+		if err := annRows.Scan(&item.ID, &item.Title, &created); err != nil {
+			internalErrorJSON(c, "Failed to fetch calendar", err)
+			return
+		}
+		item.Type = models.CalendarItemClubAnnouncement
+		item.StartsAt = &created
+		item.ClubID = &clubID
+		cal.add(created, item)
+	}
+	if err := annRows.Err(); err != nil {
+		internalErrorJSON(c, "Failed to fetch calendar", err)
+		return
+	}
+
+	if c.Query("include") == "house_events" {
+		if err := addHouseEvents(ctx, h.DB, cal, from, to); err != nil {
+			internalErrorJSON(c, "Failed to fetch calendar", err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": models.ClubCalendarResponse{
+		ClubID: clubID,
+		Month:  from.Format("2006-01"),
+		Days:   cal.result(),
+	}})
+}
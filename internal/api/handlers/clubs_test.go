@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/auth"
+)
+
+// TestClubManagementRequiresClubLead verifies only club leads (and the
+// faculty advisor), and admins, can change a club's members and awards: a
+// student can't make themselves president.
+func TestClubManagementRequiresClubLead(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authService := auth.NewService("test-secret", 1, 1)
+	clubID := uuid.New()
+	studentID := uuid.New()
+
+	noMembership := fakeResult{columns: []string{"role"}}
+	membership := func(role string) fakeResult {
+		return fakeResult{columns: []string{"role"}, rows: [][]driver.Value{{role}}}
+	}
+
+	tests := []struct {
+		name    string
+		role    models.UserRole
+		result  fakeResult // The user's club role, if a member
+		method  string
+		path    string
+		body    string
+		allowed bool
+	}{
+		{
+			name: "student adds themselves as president", role: models.RoleStudent, result: noMembership,
+			method: http.MethodPost, path: "/clubs/" + clubID.String() + "/members",
+			body: `{"user_id": "` + studentID.String() + `", "role": "president"}`,
+		},
+		{
+			name: "member promotes themselves to president", role: models.RoleStudent, result: membership("member"),
+			method: http.MethodPut, path: "/clubs/" + clubID.String() + "/members/" + studentID.String(),
+			body: `{"role": "president"}`,
+		},
+		{
+			name: "member removes another member", role: models.RoleStudent, result: membership("member"),
+			method: http.MethodDelete, path: "/clubs/" + clubID.String() + "/members/" + uuid.New().String(),
+		},
+		{
+			name: "treasurer adds a member", role: models.RoleStudent, result: membership("treasurer"),
+			method: http.MethodPost, path: "/clubs/" + clubID.String() + "/members",
+			body: `{"user_id": "` + uuid.New().String() + `"}`,
+		},
+		{
+			name: "volunteer adds an award", role: models.RoleStudent, result: membership("volunteer"),
+			method: http.MethodPost, path: "/clubs/" + clubID.String() + "/awards",
+			body: `{"award_name": "Best Club"}`,
+		},
+		{
+			name: "faculty outside the club adds an award", role: models.RoleFaculty, result: noMembership,
+			method: http.MethodPost, path: "/clubs/" + clubID.String() + "/awards",
+			body: `{"award_name": "Best Club"}`,
+		},
+		{
+			name: "president adds a member", role: models.RoleStudent, result: membership("president"),
+			method: http.MethodPost, path: "/clubs/" + clubID.String() + "/members",
+			body:    `{"user_id": "` + uuid.New().String() + `"}`,
+			allowed: true,
+		},
+		{
+			name: "faculty advisor adds an award", role: models.RoleFaculty, result: membership("advisor"),
+			method: http.MethodPost, path: "/clubs/" + clubID.String() + "/awards",
+			body:    `{"award_name": "Best Club"}`,
+			allowed: true,
+		},
+		{
+			name: "admin appoints a president", role: models.RoleAdmin, result: noMembership,
+			method: http.MethodPut, path: "/clubs/" + clubID.String() + "/members/" + studentID.String(),
+			body:    `{"role": "president"}`,
+			allowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &ClubHandler{DB: openFakeDB(t, tt.result)}
+			router := gin.New()
+			router.Use(middleware.AuthMiddleware(authService))
+			router.POST("/clubs/:id/members", h.AddClubMember)
+			router.PUT("/clubs/:id/members/:user_id", h.UpdateClubMember)
+			router.DELETE("/clubs/:id/members/:user_id", h.RemoveClubMember)
+			router.POST("/clubs/:id/awards", h.CreateClubAward)
+
+			token, err := authService.GenerateAccessToken(&models.User{
+				ID: studentID, Email: "user@college.edu", Role: tt.role,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			var wrote bool
+			for _, q := range fakeQueriesRun(t) {
+				if strings.Contains(q, "INSERT") || strings.Contains(q, "UPDATE") {
+					wrote = true
+				}
+			}
+			if tt.allowed {
+				// The fake database can't complete the write; getting to it
+				// is what counts
+				if w.Code == http.StatusForbidden || !wrote {
+					t.Errorf("status = %d, wrote = %v; want the change attempted", w.Code, wrote)
+				}
+				return
+			}
+			if w.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want 403; body: %s", w.Code, w.Body.String())
+			}
+			if wrote {
+				t.Error("the change was attempted")
+			}
+		})
+	}
+}
+
+// TestClubMemberRolesAreFixed verifies leads can only hand out the club
+// roles there are, so none can grant control of the club by accident
+func TestClubMemberRolesAreFixed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authService := auth.NewService("test-secret", 1, 1)
+	clubID := uuid.New()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"add a treasurer", http.MethodPost, "/clubs/" + clubID.String() + "/members",
+			`{"user_id": "` + uuid.New().String() + `", "role": "treasurer"}`},
+		{"make a member a volunteer", http.MethodPut, "/clubs/" + clubID.String() + "/members/" + uuid.New().String(),
+			`{"role": "volunteer"}`},
+		{"misspell president", http.MethodPut, "/clubs/" + clubID.String() + "/members/" + uuid.New().String(),
+			`{"role": "presdient"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &ClubHandler{DB: openFakeDB(t, fakeResult{columns: []string{"role"}, rows: [][]driver.Value{{"president"}}})}
+			router := gin.New()
+			router.Use(middleware.AuthMiddleware(authService))
+			router.POST("/clubs/:id/members", h.AddClubMember)
+			router.PUT("/clubs/:id/members/:user_id", h.UpdateClubMember)
+
+			token, err := authService.GenerateAccessToken(&models.User{
+				ID: uuid.New(), Email: "lead@college.edu", Role: models.RoleStudent,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400; body: %s", w.Code, w.Body.String())
+			}
+			for _, q := range fakeQueriesRun(t) {
+				if strings.Contains(q, "INSERT") || strings.Contains(q, "UPDATE") {
+					t.Errorf("the change was attempted: %s", q)
+				}
+			}
+		})
+	}
+}
+
+// TestClubAnnouncementReadsSkipImpersonation verifies fetching a club's
+// announcements records them as read by the member, but not when an admin
+// is browsing as the member
+func TestClubAnnouncementReadsSkipImpersonation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authService := auth.NewService("test-secret", 1, 1)
+	clubID := uuid.New()
+	member := &models.User{ID: uuid.New(), Email: "member@college.edu", Role: models.RoleStudent}
+
+	memberToken, err := authService.GenerateAccessToken(member)
+	if err != nil {
+		t.Fatal(err)
+	}
+	impersonationToken, _, err := authService.GenerateImpersonationToken(member, auth.Impersonation{
+		SessionID: uuid.New(), AdminID: uuid.New(), ReadOnly: true,
+	}, "", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		records bool
+	}{
+		{"member", memberToken, true},
+		{"admin impersonating the member", impersonationToken, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &ClubHandler{DB: openFakeDB(t, fakeResult{
+				columns: []string{"id", "club_id", "title", "content", "priority", "is_pinned", "created_by", "created_at", "updated_at"},
+				rows: [][]driver.Value{{uuid.NewString(), clubID.String(), "Auditions", "Friday at 5", "normal", false,
+					nil, time.Now(), time.Now()}},
+			})}
+			router := gin.New()
+			router.GET("/clubs/:id/announcements", middleware.OptionalAuthMiddleware(authService), h.GetClubAnnouncements)
+
+			req := httptest.NewRequest(http.MethodGet, "/clubs/"+clubID.String()+"/announcements", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200; body: %s", w.Code, w.Body.String())
+			}
+			var recorded bool
+			for _, q := range fakeQueriesRun(t) {
+				if strings.Contains(q, "club_announcement_reads") {
+					recorded = true
+				}
+			}
+			if recorded != tt.records {
+				t.Errorf("reads recorded = %v, want %v", recorded, tt.records)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/auth"
+	"github.com/yourusername/college-event-backend/internal/services/moderation"
+)
+
+// commentEditWindow is how long after posting a comment can be edited
+const commentEditWindow = 15 * time.Minute
+
+// commentTables maps each comment type to its table and the column holding
+// the post or announcement it is on
+var commentTables = map[string]struct{ table, parent string }{
+	moderation.ContentPostComment:         {"post_comments", "post_id"},
+	moderation.ContentAnnouncementComment: {"announcement_comments", "announcement_id"},
+}
+
+// commentIDs parses the :id and :comment_id route params. Returns false if
+// the request was aborted.
+func commentIDs(c *gin.Context) (parentID, commentID uuid.UUID, ok bool) {
+	parentID, err := uuid.Parse(c.Param("id"))
+	if err == nil {
+		commentID, err = uuid.Parse(c.Param("comment_id"))
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid ID"),
+		})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return parentID, commentID, true
+}
+
+// editComment replaces the content of the user's own comment, keeping the
+// old content in its edit history. Comments can only be edited within
+// commentEditWindow of posting, and edits are screened like new comments.
+func editComment(c *gin.Context, db *sql.DB, filter *moderation.Filter, commentType string) {
+	t := commentTables[commentType]
+	parentID, commentID, ok := commentIDs(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateCommentRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid request: " + err.Error()),
+		})
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+	if !requireCanInteract(c, db, userID, nil, true) {
+		return
+	}
+	screened, ok := screenContent(c, filter, "comment", req.Content)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to edit comment", err)
+		return
+	}
+	defer tx.Rollback()
+
+	var authorID uuid.UUID
+	var previous string
+	var editable bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT user_id, content, created_at > NOW() - make_interval(secs => $3)
+		FROM `+t.table+`
+		WHERE id = $1 AND `+t.parent+` = $2 AND deleted_at IS NULL
+		FOR UPDATE
+	`, commentID, parentID, commentEditWindow.Seconds()).Scan(&authorID, &previous, &editable)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Comment not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to edit comment", err)
+		return
+	}
+	if authorID != userID {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("You can only edit your own comments"),
+		})
+		return
+	}
+	if !editable {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Comments can only be edited within 15 minutes of posting"),
+		})
+		return
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO comment_edits (comment_type, comment_id, previous_content, edited_by)
+		VALUES ($1, $2, $3, $4)
+	`, commentType, commentID, previous, userID); err != nil {
+		internalError(c, "Failed to edit comment", err)
+		return
+	}
+
+	edited := models.EditedComment{ID: commentID, Content: req.Content}
+	if err := tx.QueryRowContext(ctx, `
+		UPDATE `+t.table+`
+		SET content = $2, edited_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+		RETURNING edited_at
+	`, commentID, req.Content).Scan(&edited.EditedAt); err != nil {
+		internalError(c, "Failed to edit comment", err)
+		return
+	}
+
+	if screened.Verdict == moderation.Flag {
+		if err := moderation.RecordFlag(ctx, tx, commentType, commentID, userID, screened.Reasons); err != nil {
+			internalError(c, "Failed to edit comment", err)
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to edit comment", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Comment updated",
+		Data:    edited,
+	})
+}
+
+// listCommentEdits returns a comment's edit history, oldest first. Only
+// the comment's author and admins can see it.
+func listCommentEdits(c *gin.Context, db *sql.DB, commentType string) {
+	t := commentTables[commentType]
+	parentID, commentID, ok := commentIDs(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	var authorID uuid.UUID
+	err := db.QueryRowContext(ctx, `
+		SELECT user_id FROM `+t.table+` WHERE id = $1 AND `+t.parent+` = $2 AND deleted_at IS NULL
+	`, commentID, parentID).Scan(&authorID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Comment not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to fetch comment history", err)
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+	role, _ := middleware.Role(c)
+	if authorID != userID && !auth.IsAdmin(role) {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Only the author can see a comment's history"),
+		})
+		return
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, previous_content, edited_by, edited_at
+		FROM comment_edits
+		WHERE comment_type = $1 AND comment_id = $2
+		ORDER BY edited_at
+	`, commentType, commentID)
+	if err != nil {
+		internalError(c, "Failed to fetch comment history", err)
+		return
+	}
+	defer rows.Close()
+
+	edits := []models.CommentEdit{}
+	for rows.Next() {
+		var e models.CommentEdit
+		if err := rows.Scan(&e.ID, &e.PreviousContent, &e.EditedBy, &e.EditedAt); err != nil {
+			internalError(c, "Failed to fetch comment history", err)
+			return
+		}
+		edits = append(edits, e)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch comment history", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    edits,
+	})
+}
+
+// EditComment edits the user's own comment on a post
+// PUT /api/v1/posts/:id/comments/:comment_id
+func (h *PostsHandler) EditComment(c *gin.Context) {
+	editComment(c, h.db.DB, h.filter, moderation.ContentPostComment)
+}
+
+// ListCommentEdits returns the edit history of a comment on a post
+// GET /api/v1/posts/:id/comments/:comment_id/edits
+func (h *PostsHandler) ListCommentEdits(c *gin.Context) {
+	listCommentEdits(c, h.db.DB, moderation.ContentPostComment)
+}
+
+// EditComment edits the user's own comment on an announcement
+// PUT /api/v1/announcements/:id/comments/:comment_id
+func (h *HouseHandler) EditComment(c *gin.Context) {
+	editComment(c, h.DB, h.filter, moderation.ContentAnnouncementComment)
+}
+
+// ListCommentEdits returns the edit history of a comment on an announcement
+// GET /api/v1/announcements/:id/comments/:comment_id/edits
+func (h *HouseHandler) ListCommentEdits(c *gin.Context) {
+	listCommentEdits(c, h.DB, moderation.ContentAnnouncementComment)
+}
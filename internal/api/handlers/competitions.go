@@ -0,0 +1,411 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/gamification"
+)
+
+// CompetitionHandler manages inter-house competitions. Each competition
+// is run through one house event per house, so enrollment works as it does
+// for any house event.
+type CompetitionHandler struct {
+	DB *sql.DB
+}
+
+// NewCompetitionHandler creates a new CompetitionHandler
+func NewCompetitionHandler(db *sql.DB) *CompetitionHandler {
+	return &CompetitionHandler{DB: db}
+}
+
+const houseCompetitionColumns = `id, title, description, event_date, venue, term_id, results_recorded_at, created_by, created_at, updated_at`
+
+func scanHouseCompetition(row interface{ Scan(...interface{}) error }) (models.HouseCompetition, error) {
+	var hc models.HouseCompetition
+	err := row.Scan(&hc.ID, &hc.Title, &hc.Description, &hc.EventDate, &hc.Venue, &hc.TermID,
+		&hc.ResultsRecordedAt, &hc.CreatedBy, &hc.CreatedAt, &hc.UpdatedAt)
+	return hc, err
+}
+
+// CreateCompetition creates a competition and an event for it in every
+// house (admin only)
+// POST /api/v1/admin/house-competitions
+func (h *CompetitionHandler) CreateCompetition(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	var req models.CreateHouseCompetitionRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid request: " + err.Error()),
+		})
+		return
+	}
+
+	eventDate, err := time.Parse("2006-01-02", req.EventDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid event date format"),
+		})
+		return
+	}
+	var regDeadline *time.Time
+	if req.RegistrationDeadline != nil {
+		parsed, err := time.Parse("2006-01-02", *req.RegistrationDeadline)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Invalid registration deadline format"),
+			})
+			return
+		}
+		regDeadline = &parsed
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to create competition", err)
+		return
+	}
+	defer tx.Rollback()
+
+	competition, err := scanHouseCompetition(tx.QueryRowContext(ctx, `
+		INSERT INTO house_competitions (title, description, event_date, venue, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+houseCompetitionColumns,
+		req.Title, req.Description, eventDate, req.Venue, userID,
+	))
+	if err != nil {
+		internalError(c, "Failed to create competition", err)
+		return
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO house_events (house_id, competition_id, title, description, event_date, start_time, end_time, venue, max_participants, registration_deadline, created_by)
+		SELECT id, $1, $2, $3, $4, $5::time, $6::time, $7, $8, $9, $10
+		FROM houses
+		WHERE deleted_at IS NULL
+	`, competition.ID, req.Title, req.Description, eventDate, req.StartTime, req.EndTime, req.Venue, req.MaxParticipants, regDeadline, userID)
+	if err != nil {
+		internalError(c, "Failed to create competition", err)
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("There are no houses to compete"),
+		})
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to create competition", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Competition created successfully",
+		Data:    competition,
+	})
+}
+
+// ListCompetitions returns the competitions of a term, newest first. The
+// term query parameter takes a term ID, "current" or "all" (the default).
+// GET /api/v1/house-competitions
+func (h *CompetitionHandler) ListCompetitions(c *gin.Context) {
+	ctx := c.Request.Context()
+	termID, err := termFilter(ctx, h.DB, c.Query("term"))
+	if err != nil {
+		respondTermFilterError(c, err)
+		return
+	}
+
+	rows, err := h.DB.QueryContext(ctx, `
+		SELECT `+houseCompetitionColumns+` FROM house_competitions
+		WHERE $1::uuid IS NULL OR term_id = $1
+		ORDER BY event_date DESC, created_at DESC
+	`, termID)
+	if err != nil {
+		internalError(c, "Failed to fetch competitions", err)
+		return
+	}
+	defer rows.Close()
+
+	competitions := []models.HouseCompetition{}
+	for rows.Next() {
+		hc, err := scanHouseCompetition(rows)
+		if err != nil {
+			internalError(c, "Failed to fetch competitions", err)
+			return
+		}
+		competitions = append(competitions, hc)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch competitions", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    competitions,
+	})
+}
+
+// GetCompetition returns a competition with each house's event and score
+// GET /api/v1/house-competitions/:id
+func (h *CompetitionHandler) GetCompetition(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid competition ID"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	competition, err := scanHouseCompetition(h.DB.QueryRowContext(ctx,
+		"SELECT "+houseCompetitionColumns+" FROM house_competitions WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Competition not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to fetch competition", err)
+		return
+	}
+
+	// Houses that took part, or still can, in finishing order once scored
+	rows, err := h.DB.QueryContext(ctx, `
+		SELECT h.id, h.name, h.color, he.id,
+		       (SELECT COUNT(*) FROM house_event_enrollments WHERE event_id = he.id),
+		       s.position, s.score, s.points
+		FROM houses h
+		LEFT JOIN house_events he ON he.house_id = h.id AND he.competition_id = $1 AND he.deleted_at IS NULL
+		LEFT JOIN house_competition_scores s ON s.house_id = h.id AND s.competition_id = $1
+		WHERE he.id IS NOT NULL OR s.house_id IS NOT NULL
+		ORDER BY s.position ASC NULLS LAST, h.name ASC
+	`, id)
+	if err != nil {
+		internalError(c, "Failed to fetch competition", err)
+		return
+	}
+	defer rows.Close()
+
+	detail := models.HouseCompetitionDetail{
+		HouseCompetition: competition,
+		Houses:           []models.CompetitionHouse{},
+	}
+	for rows.Next() {
+		var ch models.CompetitionHouse
+		if err := rows.Scan(&ch.HouseID, &ch.Name, &ch.Color, &ch.HouseEventID, &ch.EnrollmentCount,
+			&ch.Position, &ch.Score, &ch.Points); err != nil {
+			internalError(c, "Failed to fetch competition", err)
+			return
+		}
+		detail.Houses = append(detail.Houses, ch)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch competition", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    detail,
+	})
+}
+
+// RecordCompetitionResults scores each house in a competition and credits
+// the points to the house point ledger (admin only). Results are recorded
+// once; the competition's house events are marked completed.
+// POST /api/v1/admin/house-competitions/:id/results
+func (h *CompetitionHandler) RecordCompetitionResults(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid competition ID"),
+		})
+		return
+	}
+	userID, _ := middleware.UserID(c)
+
+	var req models.RecordHouseScoresRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid request: " + err.Error()),
+		})
+		return
+	}
+	seen := make(map[uuid.UUID]bool, len(req.Scores))
+	for _, s := range req.Scores {
+		if seen[s.HouseID] {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Each house can only be scored once"),
+			})
+			return
+		}
+		seen[s.HouseID] = true
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to record results", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Locking the competition keeps results from being recorded twice
+	var title string
+	var recordedAt *time.Time
+	err = tx.QueryRowContext(ctx,
+		"SELECT title, results_recorded_at FROM house_competitions WHERE id = $1 FOR UPDATE", id,
+	).Scan(&title, &recordedAt)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Competition not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to record results", err)
+		return
+	}
+	if recordedAt != nil {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Results have already been recorded for this competition"),
+		})
+		return
+	}
+
+	for _, s := range req.Scores {
+		// Placements outside the top three earn nothing unless given points
+		points := placementHousePoints[s.Position]
+		if s.Points != nil {
+			points = *s.Points
+		}
+
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO house_competition_scores (competition_id, house_id, position, score, points)
+			SELECT $1, id, $3, $4, $5 FROM houses WHERE id = $2 AND deleted_at IS NULL
+		`, id, s.HouseID, s.Position, s.Score, points)
+		if err != nil {
+			internalError(c, "Failed to record results", err)
+			return
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("House not found: " + s.HouseID.String()),
+			})
+			return
+		}
+
+		if points > 0 {
+			reason := fmt.Sprintf("%s place in %s", ordinal(s.Position), title)
+			if err := gamification.CreditHouseDirectly(ctx, tx, s.HouseID, points, reason,
+				gamification.SourceHouseCompetition, id, userID); err != nil {
+				internalError(c, "Failed to record results", err)
+				return
+			}
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE house_competitions SET results_recorded_at = CURRENT_TIMESTAMP WHERE id = $1", id); err != nil {
+		internalError(c, "Failed to record results", err)
+		return
+	}
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE house_events SET status = 'completed' WHERE competition_id = $1 AND deleted_at IS NULL", id); err != nil {
+		internalError(c, "Failed to record results", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to record results", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Results recorded successfully",
+	})
+}
+
+// GetCompetitionStandings ranks the houses by the points they have won in
+// competitions over a season. The term query parameter takes a term ID,
+// "current" (the default) or "all"; outside any term the standings are
+// all-time.
+// GET /api/v1/house-competitions/standings
+func (h *CompetitionHandler) GetCompetitionStandings(c *gin.Context) {
+	ctx := c.Request.Context()
+	termID, err := termFilter(ctx, h.DB, c.DefaultQuery("term", "current"))
+	if err != nil {
+		respondTermFilterError(c, err)
+		return
+	}
+
+	rows, err := h.DB.QueryContext(ctx, `
+		SELECT RANK() OVER (ORDER BY COALESCE(SUM(s.points), 0) DESC),
+		       h.id, h.name, h.color,
+		       COALESCE(SUM(s.points), 0),
+		       COUNT(*) FILTER (WHERE s.position = 1),
+		       COUNT(s.competition_id)
+		FROM houses h
+		LEFT JOIN (
+			SELECT s.* FROM house_competition_scores s
+			JOIN house_competitions hc ON hc.id = s.competition_id
+			WHERE $1::uuid IS NULL OR hc.term_id = $1
+		) s ON s.house_id = h.id
+		WHERE h.deleted_at IS NULL
+		GROUP BY h.id, h.name, h.color
+		ORDER BY 5 DESC, h.name
+	`, termID)
+	if err != nil {
+		internalError(c, "Failed to fetch standings", err)
+		return
+	}
+	defer rows.Close()
+
+	standings := models.HouseSeasonStandings{
+		TermID: termID,
+		Houses: []models.HouseSeasonStanding{},
+	}
+	for rows.Next() {
+		var s models.HouseSeasonStanding
+		if err := rows.Scan(&s.Rank, &s.HouseID, &s.Name, &s.Color, &s.Points, &s.Wins, &s.Competitions); err != nil {
+			internalError(c, "Failed to fetch standings", err)
+			return
+		}
+		standings.Houses = append(standings.Houses, s)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch standings", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    standings,
+	})
+}
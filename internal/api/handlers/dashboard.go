@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+const (
+	dashboardEventLimit        = 5
+	dashboardAnnouncementLimit = 5
+)
+
+// DashboardHandler serves the per-user home screen summary
+type DashboardHandler struct {
+	db *database.DB
+}
+
+// NewDashboardHandler creates a new dashboard handler
+func NewDashboardHandler(db *database.DB) *DashboardHandler {
+	return &DashboardHandler{db: db}
+}
+
+// GetDashboard returns the current user's upcoming registered events, today's
+// schedules, unpaid orders, unread notification count, house standing and
+// their clubs' latest announcements. Reads go to the primary: the app loads
+// this right after registering or paying, so replica lag would show stale data.
+// GET /api/v1/me/dashboard
+func (h *DashboardHandler) GetDashboard(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+	ctx := c.Request.Context()
+	q := repository.New(h.db.DB)
+	now := time.Now()
+
+	var resp models.DashboardResponse
+	var err error
+
+	if resp.UpcomingEvents, err = q.ListRegisteredUpcomingEvents(ctx, userID, now, dashboardEventLimit); err != nil {
+		internalError(c, "Failed to load dashboard", err)
+		return
+	}
+	if resp.TodaySchedules, err = q.ListSchedulesForDay(ctx, userID, now); err != nil {
+		internalError(c, "Failed to load dashboard", err)
+		return
+	}
+	if resp.UnpaidOrders, err = q.ListPendingPayments(ctx, userID); err != nil {
+		internalError(c, "Failed to load dashboard", err)
+		return
+	}
+	if resp.ClubAnnouncements, err = q.ListMemberClubAnnouncements(ctx, userID, dashboardAnnouncementLimit); err != nil {
+		internalError(c, "Failed to load dashboard", err)
+		return
+	}
+
+	if err := h.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL", userID,
+	).Scan(&resp.UnreadNotifications); err != nil {
+		internalError(c, "Failed to load dashboard", err)
+		return
+	}
+
+	var house models.DashboardHouse
+	err = h.db.QueryRowContext(ctx, `
+		SELECT h.id, h.name, h.color, h.points,
+		       (SELECT COUNT(*) + 1 FROM houses o WHERE o.deleted_at IS NULL AND o.points > h.points),
+		       (SELECT COUNT(*) FROM houses WHERE deleted_at IS NULL)
+		FROM house_members m
+		JOIN houses h ON h.id = m.house_id
+		WHERE m.user_id = $1 AND h.deleted_at IS NULL
+	`, userID).Scan(&house.ID, &house.Name, &house.Color, &house.Points, &house.Rank, &house.TotalHouses)
+	switch {
+	case err == nil:
+		resp.House = &house
+	case !errors.Is(err, sql.ErrNoRows):
+		internalError(c, "Failed to load dashboard", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    resp,
+	})
+}
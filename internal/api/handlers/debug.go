@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/realtime"
+	"github.com/yourusername/college-event-backend/pkg/database"
+	"github.com/yourusername/college-event-backend/pkg/version"
+)
+
+// startedAt is when the process started, for its uptime
+var startedAt = time.Now()
+
+// DebugHandler serves diagnostics for performance investigations
+type DebugHandler struct {
+	db  *database.DB
+	hub *realtime.Hub
+}
+
+// NewDebugHandler creates a new debug handler
+func NewDebugHandler(db *database.DB, hub *realtime.Hub) *DebugHandler {
+	return &DebugHandler{db: db, hub: hub}
+}
+
+// debugVars is a snapshot of the process, for spotting leaks between
+// profiles: memory that keeps growing, goroutines that pile up
+type debugVars struct {
+	Build         version.Info       `json:"build"`
+	StartedAt     time.Time          `json:"started_at"`
+	UptimeSeconds float64            `json:"uptime_seconds"`
+	Goroutines    int                `json:"goroutines"`
+	NumCPU        int                `json:"num_cpu"`
+	GOMAXPROCS    int                `json:"gomaxprocs"`
+	Memory        debugMemory        `json:"memory"`
+	Database      database.PoolStats `json:"database"`
+	Realtime      realtime.HubStats  `json:"realtime"`
+}
+
+type debugMemory struct {
+	HeapAllocBytes  uint64     `json:"heap_alloc_bytes"`
+	HeapInuseBytes  uint64     `json:"heap_inuse_bytes"`
+	HeapObjects     uint64     `json:"heap_objects"`
+	StackInuseBytes uint64     `json:"stack_inuse_bytes"`
+	SysBytes        uint64     `json:"sys_bytes"`
+	TotalAllocBytes uint64     `json:"total_alloc_bytes"`
+	NumGC           uint32     `json:"num_gc"`
+	GCPauseTotalMs  float64    `json:"gc_pause_total_ms"`
+	LastGC          *time.Time `json:"last_gc,omitempty"`
+}
+
+// Vars returns runtime diagnostics: build, uptime, goroutines, memory, the
+// database pool and realtime subscriptions. Profiles, and goroutine dumps
+// (goroutine?debug=2), are at /debug/pprof when DEBUG_ENDPOINTS is on.
+// GET /api/v1/admin/debug/vars
+func (h *DebugHandler) Vars(c *gin.Context) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	vars := debugVars{
+		Build:         version.Get(),
+		StartedAt:     startedAt,
+		UptimeSeconds: time.Since(startedAt).Seconds(),
+		Goroutines:    runtime.NumGoroutine(),
+		NumCPU:        runtime.NumCPU(),
+		GOMAXPROCS:    runtime.GOMAXPROCS(0),
+		Memory: debugMemory{
+			HeapAllocBytes:  mem.HeapAlloc,
+			HeapInuseBytes:  mem.HeapInuse,
+			HeapObjects:     mem.HeapObjects,
+			StackInuseBytes: mem.StackInuse,
+			SysBytes:        mem.Sys,
+			TotalAllocBytes: mem.TotalAlloc,
+			NumGC:           mem.NumGC,
+			GCPauseTotalMs:  float64(mem.PauseTotalNs) / float64(time.Millisecond),
+		},
+		Database: h.db.PoolStats(),
+		Realtime: h.hub.Stats(),
+	}
+	if mem.LastGC > 0 {
+		lastGC := time.Unix(0, int64(mem.LastGC))
+		vars.Memory.LastGC = &lastGC
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    vars,
+	})
+}
+
+// Pprof serves Go's profiler: the index of profiles, and each profile by
+// name (heap, goroutine, profile for CPU, trace...). Fetch a profile with
+// the admin's token and open it with go tool pprof.
+// GET /debug/pprof/*name
+func (h *DebugHandler) Pprof(c *gin.Context) {
+	switch strings.TrimPrefix(c.Param("name"), "/") {
+	case "cmdline":
+		pprof.Cmdline(c.Writer, c.Request)
+	case "profile":
+		pprof.Profile(c.Writer, c.Request)
+	case "symbol":
+		pprof.Symbol(c.Writer, c.Request)
+	case "trace":
+		pprof.Trace(c.Writer, c.Request)
+	default:
+		// The index, and the named profiles, which it serves by path
+		pprof.Index(c.Writer, c.Request)
+	}
+}
@@ -3,10 +3,13 @@ package handlers
 import (
 	"database/sql"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
 	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository"
 )
 
 type DepartmentHandler struct {
@@ -24,7 +27,7 @@ func (h *DepartmentHandler) GetDepartments(c *gin.Context) {
 
 	rows, err := h.DB.Query(query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch departments"})
+		internalErrorJSON(c, "Failed to fetch departments", err)
 		return
 	}
 	defer rows.Close()
@@ -37,11 +40,15 @@ func (h *DepartmentHandler) GetDepartments(c *gin.Context) {
 			&dept.IconName, &dept.ColorHex, &dept.TotalMembers, &dept.TotalClubs,
 			&dept.TotalEvents, &dept.CreatedAt, &dept.UpdatedAt,
 		); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan department"})
+			internalErrorJSON(c, "Failed to scan department", err)
 			return
 		}
 		departments = append(departments, dept)
 	}
+	if err := rows.Err(); err != nil {
+		internalErrorJSON(c, "Failed to read departments", err)
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{"data": departments})
 }
@@ -102,7 +109,7 @@ func (h *DepartmentHandler) GetDepartmentClubs(c *gin.Context) {
 
 	rows, err := h.DB.Query(query, departmentID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch clubs"})
+		internalErrorJSON(c, "Failed to fetch clubs", err)
 		return
 	}
 	defer rows.Close()
@@ -116,19 +123,57 @@ func (h *DepartmentHandler) GetDepartmentClubs(c *gin.Context) {
 			&club.EventCount, &club.AwardsCount, &club.Rating, &club.Email, &club.Phone,
 			&club.Website, &club.SocialLinks, &club.CreatedAt, &club.UpdatedAt,
 		); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan club"})
+			internalErrorJSON(c, "Failed to scan club", err)
 			return
 		}
 		clubs = append(clubs, club)
 	}
+	if err := rows.Err(); err != nil {
+		internalErrorJSON(c, "Failed to read clubs", err)
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{"data": clubs})
 }
 
+// GetDepartmentEvents retrieves a department's upcoming events, soonest
+// first: those it hosts itself and those of its clubs. Alumni events are
+// left out for those who don't see them.
+// GET /api/v1/departments/:id/events
+func (h *DepartmentHandler) GetDepartmentEvents(c *gin.Context) {
+	departmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid department ID"})
+		return
+	}
+
+	var exists bool
+	err = h.DB.QueryRowContext(c.Request.Context(),
+		"SELECT EXISTS (SELECT 1 FROM departments WHERE id = $1)", departmentID,
+	).Scan(&exists)
+	if err != nil {
+		internalErrorJSON(c, "Failed to fetch events", err)
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Department not found"})
+		return
+	}
+
+	role, _ := middleware.Role(c)
+	events, err := repository.New(h.DB).ListUpcomingEvents(c.Request.Context(), time.Now(), &departmentID, models.SeesAlumniEvents(role))
+	if err != nil {
+		internalErrorJSON(c, "Failed to fetch events", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": events})
+}
+
 // CreateDepartment creates a new department (admin only)
 func (h *DepartmentHandler) CreateDepartment(c *gin.Context) {
 	var req models.CreateDepartmentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -172,7 +217,7 @@ func (h *DepartmentHandler) UpdateDepartment(c *gin.Context) {
 	}
 
 	var req models.UpdateDepartmentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
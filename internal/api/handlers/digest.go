@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// DigestHandler manages weekly digest subscriptions. The digest itself is
+// sent by jobs.DigestService.
+type DigestHandler struct {
+	db *database.DB
+}
+
+// NewDigestHandler creates a new digest handler
+func NewDigestHandler(db *database.DB) *DigestHandler {
+	return &DigestHandler{db: db}
+}
+
+// newUnsubscribeToken returns a random 64-character hex token
+func newUnsubscribeToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// GetDigestSubscription returns whether the current user receives the digest
+// GET /api/v1/me/digest
+func (h *DigestHandler) GetDigestSubscription(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	var subscribed bool
+	if err := h.db.QueryRowContext(c.Request.Context(),
+		"SELECT EXISTS(SELECT 1 FROM digest_subscriptions WHERE user_id = $1)", userID,
+	).Scan(&subscribed); err != nil {
+		internalError(c, "Failed to fetch digest subscription", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    models.DigestSubscription{Subscribed: subscribed},
+	})
+}
+
+// UpdateDigestSubscription opts the current user in to or out of the digest.
+// Resubscribing keeps the existing unsubscribe token.
+// PUT /api/v1/me/digest
+func (h *DigestHandler) UpdateDigestSubscription(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	var req models.UpdateDigestSubscriptionRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if *req.Subscribed {
+		token, err := newUnsubscribeToken()
+		if err != nil {
+			internalError(c, "Failed to update digest subscription", err)
+			return
+		}
+		if _, err := h.db.ExecContext(ctx, `
+			INSERT INTO digest_subscriptions (user_id, unsubscribe_token)
+			VALUES ($1, $2)
+			ON CONFLICT (user_id) DO NOTHING
+		`, userID, token); err != nil {
+			internalError(c, "Failed to update digest subscription", err)
+			return
+		}
+	} else {
+		if _, err := h.db.ExecContext(ctx,
+			"DELETE FROM digest_subscriptions WHERE user_id = $1", userID,
+		); err != nil {
+			internalError(c, "Failed to update digest subscription", err)
+			return
+		}
+	}
+
+	message := "Unsubscribed from the weekly digest"
+	if *req.Subscribed {
+		message = "Subscribed to the weekly digest"
+	}
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    models.DigestSubscription{Subscribed: *req.Subscribed},
+	})
+}
+
+const unsubscribePage = `<!DOCTYPE html>
+<html><body style="font-family: Arial, sans-serif; text-align: center; padding: 48px;">
+<h2>%s</h2><p>%s</p>
+</body></html>`
+
+// Unsubscribe is the one-click unsubscribe link in each digest email. It
+// renders a small HTML page, since it is opened from a mail client.
+// GET /api/v1/digest/unsubscribe?token=...
+func (h *DigestHandler) Unsubscribe(c *gin.Context) {
+	c.Header("Content-Type", "text/html; charset=utf-8")
+
+	token := c.Query("token")
+	if token == "" {
+		c.String(http.StatusBadRequest, unsubscribePage,
+			"Invalid link", "This unsubscribe link is incomplete.")
+		return
+	}
+
+	if _, err := h.db.ExecContext(c.Request.Context(),
+		"DELETE FROM digest_subscriptions WHERE unsubscribe_token = $1", token,
+	); err != nil {
+		logInternalError(c, "Failed to unsubscribe from digest", err)
+		c.String(http.StatusInternalServerError, unsubscribePage,
+			"Something went wrong", "We couldn't process your request. Please try again later.")
+		return
+	}
+
+	// An unknown token is most likely an already used link, so it is not an error
+	c.String(http.StatusOK, unsubscribePage,
+		"You've been unsubscribed", "You will no longer receive the weekly campus digest.")
+}
+
+// GetDigestStats reports subscriber count and send status for the last 12 weeks
+// GET /api/v1/admin/digest/stats
+func (h *DigestHandler) GetDigestStats(c *gin.Context) {
+	ctx := c.Request.Context()
+	db := h.db.Reader()
+
+	var stats models.DigestStatsResponse
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM digest_subscriptions").Scan(&stats.Subscribers); err != nil {
+		internalError(c, "Failed to fetch digest stats", err)
+		return
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT to_char(week_start, 'YYYY-MM-DD'),
+		       COUNT(*) FILTER (WHERE status = 'pending'),
+		       COUNT(*) FILTER (WHERE status = 'sending'),
+		       COUNT(*) FILTER (WHERE status = 'sent'),
+		       COUNT(*) FILTER (WHERE status = 'failed')
+		FROM digest_sends
+		WHERE week_start >= CURRENT_DATE - 84
+		GROUP BY week_start
+		ORDER BY week_start DESC
+	`)
+	if err != nil {
+		internalError(c, "Failed to fetch digest stats", err)
+		return
+	}
+	defer rows.Close()
+
+	stats.Weeks = []models.DigestWeekStats{}
+	for rows.Next() {
+		var w models.DigestWeekStats
+		if err := rows.Scan(&w.WeekStart, &w.Pending, &w.Sending, &w.Sent, &w.Failed); err != nil {
+			internalError(c, "Failed to fetch digest stats", err)
+			return
+		}
+		stats.Weeks = append(stats.Weeks, w)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch digest stats", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    stats,
+	})
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/webhooks"
+)
+
+// CreateDonationOrder starts a donation to a campaign and returns the
+// Razorpay order to complete with VerifyDonation
+// POST /api/v1/campaigns/:id/donations
+func (h *PaymentHandler) CreateDonationOrder(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	campaign, ok := loadCampaign(c, h.db.DB)
+	if !ok {
+		return
+	}
+	if !campaign.Open(time.Now()) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("this campaign is no longer taking donations"),
+		})
+		return
+	}
+
+	var req models.DonateRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	if req.Message != nil {
+		if trimmed := strings.TrimSpace(*req.Message); trimmed == "" {
+			req.Message = nil
+		} else {
+			req.Message = &trimmed
+		}
+	}
+
+	amountInPaise := int(math.Round(req.Amount * 100))
+	orderID, err := h.createRazorpayOrder(amountInPaise, campaign.Currency)
+	if err != nil {
+		internalError(c, "failed to create payment order", err)
+		return
+	}
+
+	var donationID uuid.UUID
+	err = h.db.QueryRowContext(c.Request.Context(), `
+		INSERT INTO donations (campaign_id, user_id, amount, currency, anonymous, message, razorpay_order_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`, campaign.ID, userID, req.Amount, campaign.Currency, req.Anonymous, req.Message, orderID).Scan(&donationID)
+	if err != nil {
+		internalError(c, "failed to create donation", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: models.DonationOrderResponse{
+			DonationID: donationID,
+			OrderID:    orderID,
+			Amount:     amountInPaise,
+			Currency:   campaign.Currency,
+			KeyID:      h.keyID,
+			CampaignID: campaign.ID.String(),
+		},
+	})
+}
+
+// VerifyDonation verifies a donation's payment, counting it towards the
+// campaign
+// POST /api/v1/campaigns/:id/donations/verify
+func (h *PaymentHandler) VerifyDonation(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	campaignID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid campaign ID"),
+		})
+		return
+	}
+
+	var req models.VerifyDonationRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid request body"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	mac := hmac.New(sha256.New, []byte(h.keySecret))
+	mac.Write([]byte(req.RazorpayOrderID + "|" + req.RazorpayPaymentID))
+	if !hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(req.RazorpaySignature)) {
+		if _, err := h.db.ExecContext(ctx, `
+			UPDATE donations SET status = 'failed'
+			WHERE razorpay_order_id = $1 AND user_id = $2 AND status = 'pending'
+		`, req.RazorpayOrderID, userID); err != nil {
+			logInternalError(c, "failed to mark donation failed", err)
+		}
+
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("payment verification failed: invalid signature"),
+		})
+		return
+	}
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to update donation", err)
+		return
+	}
+	defer tx.Rollback()
+
+	var d models.Donation
+	err = tx.QueryRowContext(ctx, `
+		UPDATE donations
+		SET razorpay_payment_id = $1, razorpay_signature = $2, status = 'paid', paid_at = CURRENT_TIMESTAMP
+		WHERE razorpay_order_id = $3 AND user_id = $4 AND campaign_id = $5 AND status <> 'paid'
+		RETURNING id, amount, currency, anonymous, message, paid_at
+	`, req.RazorpayPaymentID, req.RazorpaySignature, req.RazorpayOrderID, userID, campaignID).Scan(
+		&d.ID, &d.Amount, &d.Currency, &d.Anonymous, &d.Message, &d.PaidAt,
+	)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("payment order not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to update donation", err)
+		return
+	}
+
+	if err := webhooks.Emit(ctx, tx, webhooks.PaymentCaptured, webhooks.PaymentCapturedData{
+		PaymentID:         d.ID,
+		Kind:              "donation",
+		CampaignID:        &campaignID,
+		UserID:            userID,
+		Amount:            d.Amount,
+		Currency:          d.Currency,
+		Method:            models.PaymentMethodRazorpay,
+		RazorpayPaymentID: req.RazorpayPaymentID,
+	}); err != nil {
+		internalError(c, "failed to update donation", err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to update donation", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "thank you for your donation",
+		Data:    d,
+	})
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Queries for the current version of an editable record, used when a
+// versioned update matched no row
+const (
+	eventVersionQuery = `SELECT version FROM events WHERE id = $1 AND deleted_at IS NULL`
+	clubVersionQuery  = `SELECT version FROM clubs WHERE id = $1`
+	postVersionQuery  = `SELECT version FROM posts WHERE id = $1 AND deleted_at IS NULL`
+)
+
+// staleVersion tells apart the two reasons an update guarded by
+// "AND ($n::int IS NULL OR version = $n)" matched no row. If the record
+// exists, it was edited since the version the caller sent, which is
+// returned so the response can ask them to reload. If it doesn't, ok is
+// false and the caller should answer 404.
+func staleVersion(ctx context.Context, db *sql.DB, query string, id uuid.UUID) (current int, ok bool, err error) {
+	err = db.QueryRowContext(ctx, query, id).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return current, true, nil
+}
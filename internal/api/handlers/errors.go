@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/pkg/version"
+)
+
+// internalError logs err against the request ID and responds 500 without
+// exposing the underlying error to the client. The response carries the
+// request ID and API version for the client's bug report.
+func internalError(c *gin.Context, message string, err error) {
+	requestID := logInternalError(c, message, err)
+	c.JSON(http.StatusInternalServerError, models.APIResponse{
+		Success:   false,
+		Error:     strPtr(message),
+		RequestID: requestID,
+		Version:   version.String(),
+	})
+}
+
+// internalErrorJSON is internalError for handlers using the plain
+// {"error": ...} envelope (clubs, departments)
+func internalErrorJSON(c *gin.Context, message string, err error) {
+	requestID := logInternalError(c, message, err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message, "request_id": requestID, "version": version.String()})
+}
+
+func logInternalError(c *gin.Context, message string, err error) string {
+	requestID := middleware.GetRequestID(c)
+	log.Printf("[ERROR] request_id=%s %s %s: %s: %v", requestID, c.Request.Method, c.FullPath(), message, err)
+	return requestID
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
+)
+
+// Broadcast rate limits, over a rolling window. The per-event limit stops
+// attendees being spammed; the per-sender limit stops one organizer
+// spamming across many events.
+const (
+	broadcastRateWindow     = 24 * time.Hour
+	broadcastEventRateLimit = 3
+	broadcastUserRateLimit  = 10
+)
+
+const eventBroadcastColumns = `
+	id, event_id, sent_by, segment, title, body, send_email, recipient_count, delivered_at, created_at`
+
+func scanEventBroadcast(row interface{ Scan(...interface{}) error }) (models.EventBroadcast, error) {
+	var b models.EventBroadcast
+	err := row.Scan(
+		&b.ID, &b.EventID, &b.SentBy, &b.Segment, &b.Title, &b.Body, &b.SendEmail,
+		&b.RecipientCount, &b.DeliveredAt, &b.CreatedAt,
+	)
+	return b, err
+}
+
+// CreateEventBroadcast sends a message by push and email to an event's
+// registered attendees, or to a segment of them (organizers only: admins,
+// the event's creator and its club's leads)
+// POST /api/v1/admin/events/:id/broadcast
+func (h *EventHandler) CreateEventBroadcast(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+
+	var req models.CreateEventBroadcastRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	if req.Title == "" {
+		req.Title = event.Title
+	}
+	if req.Segment == "" {
+		req.Segment = models.BroadcastSegmentAll
+	}
+	sendEmail := req.SendEmail == nil || *req.SendEmail
+
+	userID, _ := middleware.UserID(c)
+	ctx := c.Request.Context()
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to send broadcast", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Locking the event serializes broadcasts to it, so concurrent requests
+	// cannot both slip under the limit
+	if _, err := tx.ExecContext(ctx, "SELECT 1 FROM events WHERE id = $1 FOR UPDATE", event.ID); err != nil {
+		internalError(c, "failed to send broadcast", err)
+		return
+	}
+
+	since := time.Now().Add(-broadcastRateWindow)
+	var eventRecent, userRecent int
+	var eventOldest, userOldest sql.NullTime
+	if err := tx.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*) FILTER (WHERE event_id = $1), MIN(created_at) FILTER (WHERE event_id = $1),
+			COUNT(*) FILTER (WHERE sent_by = $2), MIN(created_at) FILTER (WHERE sent_by = $2)
+		FROM event_broadcasts
+		WHERE (event_id = $1 OR sent_by = $2) AND created_at > $3
+	`, event.ID, userID, since).Scan(&eventRecent, &eventOldest, &userRecent, &userOldest); err != nil {
+		internalError(c, "failed to send broadcast", err)
+		return
+	}
+
+	limited, oldest := false, sql.NullTime{}
+	switch {
+	case eventRecent >= broadcastEventRateLimit:
+		limited, oldest = true, eventOldest
+	case userRecent >= broadcastUserRateLimit:
+		limited, oldest = true, userOldest
+	}
+	if limited {
+		if oldest.Valid {
+			retryAfter := time.Until(oldest.Time.Add(broadcastRateWindow))
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+		}
+		c.JSON(http.StatusTooManyRequests, models.APIResponse{
+			Success: false,
+			Error:   strPtr("too many broadcasts, please try again later"),
+		})
+		return
+	}
+
+	broadcast, err := scanEventBroadcast(tx.QueryRowContext(ctx, `
+		INSERT INTO event_broadcasts (event_id, sent_by, segment, title, body, send_email)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+eventBroadcastColumns,
+		event.ID, userID, req.Segment, req.Title, req.Body, sendEmail))
+	if err != nil {
+		internalError(c, "failed to send broadcast", err)
+		return
+	}
+
+	err = outbox.Write(ctx, tx, notifications.TopicEventBroadcastCreated, notifications.EventBroadcastCreatedPayload{
+		BroadcastID: broadcast.ID,
+	})
+	if err != nil {
+		internalError(c, "failed to send broadcast", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to send broadcast", err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, models.APIResponse{
+		Success: true,
+		Message: "Broadcast queued for delivery",
+		Data:    broadcast,
+	})
+}
+
+// ListEventBroadcasts returns the send log of an event's broadcasts, newest
+// first (organizers only)
+// GET /api/v1/admin/events/:id/broadcasts
+func (h *EventHandler) ListEventBroadcasts(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+
+	rows, err := h.db.QueryContext(c.Request.Context(), `
+		SELECT `+eventBroadcastColumns+`
+		FROM event_broadcasts
+		WHERE event_id = $1
+		ORDER BY created_at DESC
+		LIMIT 100
+	`, event.ID)
+	if err != nil {
+		internalError(c, "failed to fetch broadcasts", err)
+		return
+	}
+	defer rows.Close()
+
+	broadcasts := []models.EventBroadcast{}
+	for rows.Next() {
+		b, err := scanEventBroadcast(rows)
+		if err != nil {
+			internalError(c, "failed to fetch broadcasts", err)
+			return
+		}
+		broadcasts = append(broadcasts, b)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "failed to fetch broadcasts", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    broadcasts,
+	})
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
+	"github.com/yourusername/college-event-backend/internal/services/payments"
+)
+
+// CancelEvent cancels an event (admin only): it stops taking registrations,
+// every paid registration is queued for a refund and attendees are
+// notified. Registrations are kept for the record. Payments taken offline
+// are marked for refunding but have to be paid back by hand.
+// POST /api/v1/admin/events/:id/cancel
+func (h *EventHandler) CancelEvent(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid event ID"),
+		})
+		return
+	}
+
+	var req models.CancelEventRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	var reason string
+	if req.Reason != nil {
+		reason = strings.TrimSpace(*req.Reason)
+	}
+	var storedReason *string
+	if reason != "" {
+		storedReason = &reason
+	}
+
+	// The cancellation, the refunds and the notice commit together
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to cancel event", err)
+		return
+	}
+	defer tx.Rollback()
+
+	result := models.EventCancellation{EventID: eventID}
+	var title, status string
+	err = tx.QueryRowContext(ctx, `
+		SELECT title, status FROM events WHERE id = $1 AND deleted_at IS NULL FOR UPDATE
+	`, eventID).Scan(&title, &status)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("event not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to cancel event", err)
+		return
+	}
+	if status == models.EventStatusCancelled {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("event is already cancelled"),
+		})
+		return
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE events
+		SET status = $1, cancelled_at = CURRENT_TIMESTAMP, cancellation_reason = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3
+		RETURNING cancelled_at
+	`, models.EventStatusCancelled, storedReason, eventID).Scan(&result.CancelledAt)
+	if err != nil {
+		internalError(c, "failed to cancel event", err)
+		return
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		UPDATE event_payments SET status = 'refund_pending', updated_at = CURRENT_TIMESTAMP
+		WHERE event_id = $1 AND status = 'paid'
+		RETURNING id, method
+	`, eventID)
+	if err != nil {
+		internalError(c, "failed to queue refunds", err)
+		return
+	}
+	var refunds []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		var method string
+		if err := rows.Scan(&id, &method); err != nil {
+			rows.Close()
+			internalError(c, "failed to queue refunds", err)
+			return
+		}
+		if method == models.PaymentMethodCash || method == models.PaymentMethodOffline {
+			result.OfflineRefunds++
+			continue
+		}
+		refunds = append(refunds, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		internalError(c, "failed to queue refunds", err)
+		return
+	}
+	for _, id := range refunds {
+		if err := outbox.Write(ctx, tx, payments.TopicRefundRequested, payments.RefundRequestedPayload{PaymentID: id}); err != nil {
+			internalError(c, "failed to queue refunds", err)
+			return
+		}
+	}
+	result.RefundsQueued = len(refunds)
+
+	err = outbox.Write(ctx, tx, notifications.TopicEventCancelled, notifications.EventCancelledPayload{
+		EventID:    eventID,
+		EventTitle: title,
+		Reason:     reason,
+	})
+	if err != nil {
+		internalError(c, "failed to cancel event", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to cancel event", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "event cancelled",
+		Data:    result,
+	})
+}
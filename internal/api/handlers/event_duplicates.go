@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+const (
+	// duplicateWindow is how far apart two events' dates may be and still
+	// be taken for the same event
+	duplicateWindow = 24 * time.Hour
+
+	// Titles at least this similar are a duplicate on their own; titles at
+	// least looselySimilar are one when the club or venue matches too
+	nearlyIdentical = 0.85
+	looselySimilar  = 0.6
+)
+
+// findDuplicateEvents returns the events, neither deleted nor cancelled,
+// that an event being created with req from start to end looks like a copy
+// of, most similar first
+func findDuplicateEvents(ctx context.Context, db *sql.DB, req models.CreateEventRequest, start, end time.Time) ([]models.DuplicateEvent, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, title, start_date, end_date, location, club_id
+		FROM events
+		WHERE deleted_at IS NULL AND status <> 'cancelled'
+		  AND start_date < $2 AND end_date > $1
+	`, start.Add(-duplicateWindow), end.Add(duplicateWindow))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dups []models.DuplicateEvent
+	for rows.Next() {
+		var d models.DuplicateEvent
+		if err := rows.Scan(&d.ID, &d.Title, &d.StartDate, &d.EndDate, &d.Location, &d.ClubID); err != nil {
+			return nil, err
+		}
+		d.TitleSimilarity = titleSimilarity(req.Title, d.Title)
+		d.SameClub = sameUUID(req.ClubID, d.ClubID)
+		d.SameVenue = req.Location != nil && d.Location != nil && normalizeTitle(*req.Location) != "" &&
+			normalizeTitle(*req.Location) == normalizeTitle(*d.Location)
+		if d.TitleSimilarity >= nearlyIdentical ||
+			(d.TitleSimilarity >= looselySimilar && (d.SameClub || d.SameVenue)) {
+			dups = append(dups, d)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := 1; i < len(dups); i++ {
+		for j := i; j > 0 && dups[j].TitleSimilarity > dups[j-1].TitleSimilarity; j-- {
+			dups[j], dups[j-1] = dups[j-1], dups[j]
+		}
+	}
+	return dups, nil
+}
+
+func sameUUID(a, b *uuid.UUID) bool {
+	return a != nil && b != nil && *a == *b
+}
+
+// normalizeTitle lowercases s and keeps only its letters and digits, so
+// "Hack-A-Thon 2025" and "hackathon 2025!" compare equal
+func normalizeTitle(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// titleSimilarity scores how alike two titles are from 0 to 1, as the Dice
+// coefficient of their normalized titles' letter pairs
+func titleSimilarity(a, b string) float64 {
+	x, y := []rune(normalizeTitle(a)), []rune(normalizeTitle(b))
+	if string(x) == string(y) {
+		if len(x) == 0 {
+			return 0
+		}
+		return 1
+	}
+	if len(x) < 2 || len(y) < 2 {
+		return 0
+	}
+
+	pairs := make(map[[2]rune]int, len(x)-1)
+	for i := 0; i+1 < len(x); i++ {
+		pairs[[2]rune{x[i], x[i+1]}]++
+	}
+	shared := 0
+	for i := 0; i+1 < len(y); i++ {
+		p := [2]rune{y[i], y[i+1]}
+		if pairs[p] > 0 {
+			pairs[p]--
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(x)-1+len(y)-1)
+}
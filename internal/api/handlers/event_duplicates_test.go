@@ -0,0 +1,26 @@
+package handlers
+
+import "testing"
+
+func TestTitleSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		min, max float64
+	}{
+		{"Hack-A-Thon 2025", "hackathon 2025!", 1, 1},
+		{"Annual Tech Fest", "Annual Tech Fest 2025", nearlyIdentical, 1},
+		{"Robotics Workshop", "Robotics Club Meetup", looselySimilar - 0.2, looselySimilar},
+		{"Cultural Night", "Culturals Night", nearlyIdentical, 1},
+		{"Robotics Workshop", "Poetry Slam", 0, 0.2},
+		{"", "", 0, 0},
+	}
+	for _, tt := range tests {
+		got := titleSimilarity(tt.a, tt.b)
+		if got < tt.min || got > tt.max {
+			t.Errorf("titleSimilarity(%q, %q) = %.2f, want between %.2f and %.2f", tt.a, tt.b, got, tt.min, tt.max)
+		}
+		if back := titleSimilarity(tt.b, tt.a); back != got {
+			t.Errorf("titleSimilarity(%q, %q) = %.2f, but %.2f the other way round", tt.a, tt.b, got, back)
+		}
+	}
+}
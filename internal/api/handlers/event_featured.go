@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository"
+)
+
+// ListFeaturedEvents lists the events on the home screen carousel, in the
+// order admins gave them, leaving out alumni events for those who don't see
+// them
+// GET /api/v1/events/featured
+func (h *EventHandler) ListFeaturedEvents(c *gin.Context) {
+	role, _ := middleware.Role(c)
+	events, err := repository.New(h.db.Reader()).ListFeaturedEvents(c.Request.Context(), time.Now(), models.SeesAlumniEvents(role))
+	if err != nil {
+		internalError(c, "failed to fetch featured events", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    events,
+	})
+}
+
+// lockFeaturedEvents serializes changes to the carousel's order, as
+// lockFeatured does for posts
+func lockFeaturedEvents(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('events:featured'))")
+	return err
+}
+
+// FeatureEvent adds an event to the featured carousel at a position, or
+// last without one, shown between starts_at and ends_at when given. An
+// event already featured is moved there and given the new window.
+// POST /api/v1/admin/events/:id/feature
+func (h *EventHandler) FeatureEvent(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid event ID"),
+		})
+		return
+	}
+
+	var req models.FeatureEventRequest
+	if err := bindJSON(c, &req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid request: " + err.Error()),
+		})
+		return
+	}
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("ends_at must be after starts_at"),
+		})
+		return
+	}
+	if req.EndsAt != nil && !req.EndsAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("ends_at must be in the future"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to feature event", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if err := lockFeaturedEvents(ctx, tx); err != nil {
+		internalError(c, "failed to feature event", err)
+		return
+	}
+
+	var status string
+	err = tx.QueryRowContext(ctx,
+		"SELECT status FROM events WHERE id = $1 AND deleted_at IS NULL", eventID,
+	).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("event not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to feature event", err)
+		return
+	}
+	if status == models.EventStatusCancelled {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("a cancelled event can't be featured"),
+		})
+		return
+	}
+
+	// Number the other featured events 0, 1, 2... in their current order,
+	// leaving a gap at the new position
+	var others int
+	if err := tx.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM events WHERE featured_position IS NOT NULL AND id <> $1", eventID,
+	).Scan(&others); err != nil {
+		internalError(c, "failed to feature event", err)
+		return
+	}
+	position := others
+	if req.Position != nil && *req.Position < others {
+		position = *req.Position
+	}
+	if _, err := tx.ExecContext(ctx, `
+		WITH ordered AS (
+			SELECT id, ROW_NUMBER() OVER (ORDER BY featured_position) - 1 AS pos
+			FROM events
+			WHERE featured_position IS NOT NULL AND id <> $1
+		)
+		UPDATE events e
+		SET featured_position = CASE WHEN o.pos >= $2 THEN o.pos + 1 ELSE o.pos END
+		FROM ordered o
+		WHERE e.id = o.id
+		  AND e.featured_position <> CASE WHEN o.pos >= $2 THEN o.pos + 1 ELSE o.pos END
+	`, eventID, position); err != nil {
+		internalError(c, "failed to feature event", err)
+		return
+	}
+	var from, until *time.Time
+	err = tx.QueryRowContext(ctx, `
+		UPDATE events
+		SET is_featured = true, featured_position = $2, featured_from = $3, featured_until = $4
+		WHERE id = $1
+		RETURNING featured_from, featured_until
+	`, eventID, position, req.StartsAt, req.EndsAt).Scan(&from, &until)
+	if err != nil {
+		internalError(c, "failed to feature event", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to feature event", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Event featured",
+		Data: gin.H{
+			"id":                eventID,
+			"featured_position": position,
+			"featured_from":     from,
+			"featured_until":    until,
+		},
+	})
+}
+
+// UnfeatureEvent takes an event out of the featured carousel, closing up
+// the gap it leaves
+// DELETE /api/v1/admin/events/:id/feature
+func (h *EventHandler) UnfeatureEvent(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid event ID"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to unfeature event", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if err := lockFeaturedEvents(ctx, tx); err != nil {
+		internalError(c, "failed to unfeature event", err)
+		return
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE events
+		SET is_featured = false, featured_position = NULL, featured_from = NULL, featured_until = NULL
+		WHERE id = $1 AND featured_position IS NOT NULL
+	`, eventID)
+	if err != nil {
+		internalError(c, "failed to unfeature event", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("event is not featured"),
+		})
+		return
+	}
+	if _, err := tx.ExecContext(ctx, `
+		WITH ordered AS (
+			SELECT id, ROW_NUMBER() OVER (ORDER BY featured_position) - 1 AS pos
+			FROM events
+			WHERE featured_position IS NOT NULL
+		)
+		UPDATE events e
+		SET featured_position = o.pos
+		FROM ordered o
+		WHERE e.id = o.id AND e.featured_position <> o.pos
+	`); err != nil {
+		internalError(c, "failed to unfeature event", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to unfeature event", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Event removed from featured",
+	})
+}
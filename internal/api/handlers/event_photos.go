@@ -0,0 +1,625 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository"
+	"github.com/yourusername/college-event-backend/internal/storage"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+const (
+	// maxPhotosPerUpload bounds the files in one bulk upload request.
+	// Galleries larger than this are uploaded in several batches.
+	maxPhotosPerUpload = 25
+)
+
+const eventPhotoColumns = `id, event_id, url, thumbnail_url, width, height, size_bytes, uploaded_by, created_at`
+
+func scanEventPhoto(row interface{ Scan(...interface{}) error }) (models.EventPhoto, error) {
+	var p models.EventPhoto
+	err := row.Scan(&p.ID, &p.EventID, &p.URL, &p.ThumbnailURL, &p.Width, &p.Height, &p.SizeBytes,
+		&p.UploadedBy, &p.CreatedAt)
+	return p, err
+}
+
+// PhotoHandler handles event photo galleries
+type PhotoHandler struct {
+	db      *database.DB
+	storage storage.StorageService
+}
+
+// NewPhotoHandler creates a new photo handler
+func NewPhotoHandler(db *database.DB, storage storage.StorageService) *PhotoHandler {
+	return &PhotoHandler{db: db, storage: storage}
+}
+
+// requireGalleryAccess loads the event named by :id and checks the user may
+// see its photos: its organizers and registered attendees. Returns false if
+// the request has been answered.
+func requireGalleryAccess(c *gin.Context, db *database.DB) (models.Event, bool) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid event ID"),
+		})
+		return models.Event{}, false
+	}
+	ctx := c.Request.Context()
+	event, err := repository.New(db).GetEvent(ctx, eventID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("event not found"),
+		})
+		return event, false
+	}
+	if err != nil {
+		internalError(c, "failed to fetch event", err)
+		return event, false
+	}
+
+	organizer, err := canOrganizeEvent(c, db.DB, event)
+	if err != nil {
+		internalError(c, "failed to check event organizers", err)
+		return event, false
+	}
+	if organizer {
+		return event, true
+	}
+
+	userID, _ := middleware.UserID(c)
+	var registered bool
+	if err := db.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM event_registrations WHERE event_id = $1 AND user_id = $2)", eventID, userID,
+	).Scan(&registered); err != nil {
+		internalError(c, "failed to check registration", err)
+		return event, false
+	}
+	if !registered {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("only the event's attendees can see its photos"),
+		})
+		return event, false
+	}
+	return event, true
+}
+
+// parsePhotoID parses :photo_id. Returns false if the request has been
+// answered.
+func parsePhotoID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("photo_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid photo ID"),
+		})
+		return id, false
+	}
+	return id, true
+}
+
+// getEventPhoto returns a photo of the event still in its gallery. Returns
+// sql.ErrNoRows if there is none.
+func getEventPhoto(ctx context.Context, db *sql.DB, eventID, photoID uuid.UUID) (models.EventPhoto, error) {
+	return scanEventPhoto(db.QueryRowContext(ctx, `
+		SELECT `+eventPhotoColumns+`
+		FROM event_photos
+		WHERE id = $1 AND event_id = $2 AND removed_at IS NULL
+	`, photoID, eventID))
+}
+
+// UploadEventPhotos adds photos to an event's gallery (organizers only). Up
+// to 25 files, each at most 10MB, are sent as the multipart field "photos";
+// larger galleries are uploaded in batches. Each photo is stored full size
+// for download with a thumbnail for browsing, and the result reports every
+// file that couldn't be added.
+// POST /api/v1/events/:id/photos
+func (h *PhotoHandler) UploadEventPhotos(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+
+	if err := c.Request.ParseMultipartForm(32 << 20); err != nil {
+		if strings.Contains(err.Error(), "http: request body too large") {
+			c.JSON(http.StatusRequestEntityTooLarge, models.APIResponse{
+				Success: false,
+				Error:   strPtr("upload too large: send the photos in smaller batches"),
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid form data"),
+		})
+		return
+	}
+	defer c.Request.MultipartForm.RemoveAll()
+
+	files := c.Request.MultipartForm.File["photos"]
+	if len(files) == 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("no photos provided"),
+		})
+		return
+	}
+	if len(files) > maxPhotosPerUpload {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(fmt.Sprintf("at most %d photos can be uploaded at once", maxPhotosPerUpload)),
+		})
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+	result := models.PhotoUploadResult{
+		Uploaded: []models.EventPhoto{},
+		Failed:   []models.PhotoUploadFailure{},
+	}
+	photos := make([]models.EventPhoto, len(files))
+	errs := make([]error, len(files))
+	forEachConcurrently(len(files), uploadConcurrency, func(i int) {
+		photos[i], errs[i] = h.uploadPhoto(c.Request.Context(), event.ID, userID, files[i])
+	})
+	for i, err := range errs {
+		if err != nil {
+			result.Failed = append(result.Failed, models.PhotoUploadFailure{Filename: files[i].Filename, Error: err.Error()})
+			continue
+		}
+		result.Uploaded = append(result.Uploaded, photos[i])
+	}
+
+	status := http.StatusCreated
+	if len(result.Uploaded) == 0 {
+		status = http.StatusBadRequest
+	}
+	c.JSON(status, models.APIResponse{
+		Success: len(result.Uploaded) > 0,
+		Message: fmt.Sprintf("%d of %d photos uploaded", len(result.Uploaded), len(files)),
+		Data:    result,
+	})
+}
+
+// errPhotoUpload is reported for a photo that failed to store, without the
+// storage error itself
+var errPhotoUpload = errors.New("failed to upload photo")
+
+// uploadPhoto stores one photo and its thumbnail and adds it to the gallery.
+// Errors are reported to the uploader.
+func (h *PhotoHandler) uploadPhoto(ctx context.Context, eventID, userID uuid.UUID, header *multipart.FileHeader) (models.EventPhoto, error) {
+	if header.Size > maxImageBytes {
+		return models.EventPhoto{}, errors.New("file too large: maximum size is 10MB")
+	}
+	if !isValidImageType(header.Header.Get("Content-Type")) {
+		return models.EventPhoto{}, errors.New("invalid file type: allowed are JPEG, PNG, GIF and WebP")
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return models.EventPhoto{}, errPhotoUpload
+	}
+	defer file.Close()
+
+	folder := "event-photos/" + eventID.String()
+	full, err := h.storage.UploadImage(ctx, file, header.Filename, folder, storage.ImageTypeOriginal)
+	if err != nil {
+		log.Printf("Failed to upload photo %q for event %s: %v", header.Filename, eventID, err)
+		return models.EventPhoto{}, errPhotoUpload
+	}
+	cleanup := []string{full.Path}
+	defer func() {
+		for _, path := range cleanup {
+			if err := h.storage.Delete(context.Background(), path); err != nil {
+				log.Printf("Failed to delete orphaned photo %s: %v", path, err)
+			}
+		}
+	}()
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return models.EventPhoto{}, errPhotoUpload
+	}
+	thumb, err := h.storage.UploadImage(ctx, file, header.Filename, folder, storage.ImageTypeThumbnail)
+	if err != nil {
+		log.Printf("Failed to upload thumbnail of %q for event %s: %v", header.Filename, eventID, err)
+		return models.EventPhoto{}, errPhotoUpload
+	}
+	cleanup = append(cleanup, thumb.Path)
+
+	photo, err := scanEventPhoto(h.db.QueryRowContext(ctx, `
+		INSERT INTO event_photos (event_id, url, path, thumbnail_url, thumbnail_path, width, height, size_bytes, uploaded_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING `+eventPhotoColumns,
+		eventID, full.URL, full.Path, thumb.URL, thumb.Path, full.Width, full.Height, full.SizeBytes, userID))
+	if err != nil {
+		log.Printf("Failed to save photo %q for event %s: %v", header.Filename, eventID, err)
+		return models.EventPhoto{}, errPhotoUpload
+	}
+	cleanup = nil
+	return photo, nil
+}
+
+// ListEventPhotos returns an event's gallery in upload order (organizers
+// and attendees)
+// GET /api/v1/events/:id/photos
+func (h *PhotoHandler) ListEventPhotos(c *gin.Context) {
+	event, ok := requireGalleryAccess(c, h.db)
+	if !ok {
+		return
+	}
+
+	rows, err := h.db.Reader().QueryContext(c.Request.Context(), `
+		SELECT `+eventPhotoColumns+`
+		FROM event_photos
+		WHERE event_id = $1 AND removed_at IS NULL
+		ORDER BY created_at, id
+	`, event.ID)
+	if err != nil {
+		internalError(c, "failed to fetch photos", err)
+		return
+	}
+	defer rows.Close()
+
+	photos := []models.EventPhoto{}
+	for rows.Next() {
+		photo, err := scanEventPhoto(rows)
+		if err != nil {
+			internalError(c, "failed to fetch photos", err)
+			return
+		}
+		photos = append(photos, photo)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "failed to fetch photos", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    photos,
+	})
+}
+
+// DownloadEventPhoto redirects to the full-size photo (organizers and
+// attendees)
+// GET /api/v1/events/:id/photos/:photo_id/download
+func (h *PhotoHandler) DownloadEventPhoto(c *gin.Context) {
+	event, ok := requireGalleryAccess(c, h.db)
+	if !ok {
+		return
+	}
+	photoID, ok := parsePhotoID(c)
+	if !ok {
+		return
+	}
+
+	photo, err := getEventPhoto(c.Request.Context(), h.db.Reader(), event.ID, photoID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("photo not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to fetch photo", err)
+		return
+	}
+	c.Redirect(http.StatusFound, photo.URL)
+}
+
+// removeEventPhoto takes a photo out of the gallery and returns its files,
+// to delete once the transaction commits. Returns no files if the photo
+// wasn't in the gallery.
+func removeEventPhoto(ctx context.Context, tx *sql.Tx, eventID, photoID uuid.UUID) ([]string, error) {
+	var path, thumbnailPath string
+	err := tx.QueryRowContext(ctx, `
+		UPDATE event_photos SET removed_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND event_id = $2 AND removed_at IS NULL
+		RETURNING path, thumbnail_path
+	`, photoID, eventID).Scan(&path, &thumbnailPath)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []string{path, thumbnailPath}, nil
+}
+
+// deletePhotoFiles deletes the files of removed photos. Files left behind by
+// a failed delete are only storage; the photo is out of the gallery either
+// way.
+func (h *PhotoHandler) deletePhotoFiles(ctx context.Context, paths []string) {
+	for _, p := range paths {
+		if err := h.storage.Delete(ctx, p); err != nil {
+			log.Printf("Failed to delete photo file %s: %v", p, err)
+		}
+	}
+}
+
+// DeleteEventPhoto removes a photo from an event's gallery (organizers only)
+// DELETE /api/v1/events/:id/photos/:photo_id
+func (h *PhotoHandler) DeleteEventPhoto(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+	photoID, ok := parsePhotoID(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to delete photo", err)
+		return
+	}
+	defer tx.Rollback()
+
+	files, err := removeEventPhoto(ctx, tx, event.ID, photoID)
+	if err != nil {
+		internalError(c, "failed to delete photo", err)
+		return
+	}
+	if files == nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("photo not found"),
+		})
+		return
+	}
+	// Takedowns still pending are settled by the removal
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE photo_takedown_requests SET status = $1, reviewed_at = CURRENT_TIMESTAMP
+		WHERE photo_id = $2 AND status = $3
+	`, models.TakedownApproved, photoID, models.TakedownPending); err != nil {
+		internalError(c, "failed to delete photo", err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to delete photo", err)
+		return
+	}
+	h.deletePhotoFiles(ctx, files)
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "photo deleted",
+	})
+}
+
+// RequestPhotoTakedown asks the event's organizers to remove a photo, such
+// as one the student appears in and doesn't want shared (attendees)
+// POST /api/v1/events/:id/photos/:photo_id/takedown
+func (h *PhotoHandler) RequestPhotoTakedown(c *gin.Context) {
+	event, ok := requireGalleryAccess(c, h.db)
+	if !ok {
+		return
+	}
+	photoID, ok := parsePhotoID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreatePhotoTakedownRequest
+	if err := bindJSON(c, &req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	if req.Reason != nil {
+		if trimmed := strings.TrimSpace(*req.Reason); trimmed == "" {
+			req.Reason = nil
+		} else {
+			req.Reason = &trimmed
+		}
+	}
+
+	ctx := c.Request.Context()
+	if _, err := getEventPhoto(ctx, h.db.DB, event.ID, photoID); errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("photo not found"),
+		})
+		return
+	} else if err != nil {
+		internalError(c, "failed to fetch photo", err)
+		return
+	}
+
+	// Asking again reopens a rejected request with the new reason
+	userID, _ := middleware.UserID(c)
+	var requestID uuid.UUID
+	err := h.db.QueryRowContext(ctx, `
+		INSERT INTO photo_takedown_requests (photo_id, requested_by, reason)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (photo_id, requested_by) DO UPDATE
+		SET reason = EXCLUDED.reason, status = 'pending', reviewed_by = NULL, reviewed_at = NULL,
+		    created_at = CURRENT_TIMESTAMP
+		RETURNING id
+	`, photoID, userID, req.Reason).Scan(&requestID)
+	if err != nil {
+		internalError(c, "failed to request takedown", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "takedown requested; the organizers will review it",
+		Data:    gin.H{"id": requestID},
+	})
+}
+
+// ListPhotoTakedowns returns takedown requests for an event's photos,
+// oldest first (organizers only). ?status= filters them, pending by
+// default; ?status=all returns every request.
+// GET /api/v1/events/:id/photo-takedowns
+func (h *PhotoHandler) ListPhotoTakedowns(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+	status := c.DefaultQuery("status", models.TakedownPending)
+	switch status {
+	case models.TakedownPending, models.TakedownApproved, models.TakedownRejected, "all":
+	default:
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("status must be pending, approved, rejected or all"),
+		})
+		return
+	}
+
+	rows, err := h.db.QueryContext(c.Request.Context(), `
+		SELECT t.id, t.photo_id, p.thumbnail_url, t.requested_by, u.full_name, t.reason, t.status,
+		       t.reviewed_by, t.reviewed_at, t.created_at
+		FROM photo_takedown_requests t
+		JOIN event_photos p ON p.id = t.photo_id
+		JOIN users u ON u.id = t.requested_by
+		WHERE p.event_id = $1 AND ($2 = 'all' OR t.status = $2)
+		ORDER BY t.created_at
+	`, event.ID, status)
+	if err != nil {
+		internalError(c, "failed to fetch takedown requests", err)
+		return
+	}
+	defer rows.Close()
+
+	requests := []models.PhotoTakedownRequest{}
+	for rows.Next() {
+		var t models.PhotoTakedownRequest
+		if err := rows.Scan(&t.ID, &t.PhotoID, &t.ThumbnailURL, &t.RequestedBy, &t.RequesterName, &t.Reason,
+			&t.Status, &t.ReviewedBy, &t.ReviewedAt, &t.CreatedAt); err != nil {
+			internalError(c, "failed to fetch takedown requests", err)
+			return
+		}
+		requests = append(requests, t)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "failed to fetch takedown requests", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    requests,
+	})
+}
+
+// ResolvePhotoTakedown approves a takedown request, removing the photo from
+// the gallery and deleting it, or rejects it (organizers only)
+// POST /api/v1/events/:id/photo-takedowns/:request_id/resolve
+func (h *PhotoHandler) ResolvePhotoTakedown(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+	requestID, err := uuid.Parse(c.Param("request_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid request ID"),
+		})
+		return
+	}
+	var req models.ResolvePhotoTakedownRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to resolve takedown request", err)
+		return
+	}
+	defer tx.Rollback()
+
+	var photoID uuid.UUID
+	var status string
+	err = tx.QueryRowContext(ctx, `
+		SELECT t.photo_id, t.status
+		FROM photo_takedown_requests t
+		JOIN event_photos p ON p.id = t.photo_id
+		WHERE t.id = $1 AND p.event_id = $2
+		FOR UPDATE OF t
+	`, requestID, event.ID).Scan(&photoID, &status)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("takedown request not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to resolve takedown request", err)
+		return
+	}
+	if status != models.TakedownPending {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("this takedown request has already been resolved"),
+		})
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+	var files []string
+	if *req.Approve {
+		if files, err = removeEventPhoto(ctx, tx, event.ID, photoID); err != nil {
+			internalError(c, "failed to remove photo", err)
+			return
+		}
+		// Everyone who asked for this photo's removal has their answer
+		_, err = tx.ExecContext(ctx, `
+			UPDATE photo_takedown_requests SET status = $1, reviewed_by = $2, reviewed_at = CURRENT_TIMESTAMP
+			WHERE photo_id = $3 AND status = $4
+		`, models.TakedownApproved, userID, photoID, models.TakedownPending)
+	} else {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE photo_takedown_requests SET status = $1, reviewed_by = $2, reviewed_at = CURRENT_TIMESTAMP
+			WHERE id = $3
+		`, models.TakedownRejected, userID, requestID)
+	}
+	if err != nil {
+		internalError(c, "failed to resolve takedown request", err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to resolve takedown request", err)
+		return
+	}
+	h.deletePhotoFiles(ctx, files)
+
+	message := "takedown rejected"
+	if *req.Approve {
+		message = "photo taken down"
+	}
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: message,
+	})
+}
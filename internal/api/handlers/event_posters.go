@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/poster"
+	"github.com/yourusername/college-event-backend/internal/storage"
+	"github.com/yourusername/college-event-backend/pkg/database"
+	"github.com/yourusername/college-event-backend/pkg/qrcode"
+)
+
+const (
+	// posterLinkSource tags the short link the poster's QR code opens, so
+	// scans of printed posters show up as their own source
+	posterLinkSource = "poster"
+	// posterBannerMaxBytes bounds the banner downloaded for a poster
+	posterBannerMaxBytes = 10 << 20
+)
+
+// PosterHandler generates shareable event posters
+type PosterHandler struct {
+	db      *database.DB
+	storage storage.StorageService
+	baseURL string
+	client  *http.Client
+}
+
+// NewPosterHandler creates a new poster handler. baseURL is the public URL
+// of the API, which serves the short link on the poster.
+func NewPosterHandler(db *database.DB, storage storage.StorageService, baseURL string) *PosterHandler {
+	return &PosterHandler{
+		db:      db,
+		storage: storage,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// posterLink returns the event's poster short link, creating it the first
+// time
+func (h *PosterHandler) posterLink(ctx context.Context, eventID, userID uuid.UUID) (models.ShortLink, error) {
+	link, err := scanShortLink(h.db.QueryRowContext(ctx, `
+		SELECT `+shortLinkColumns+`
+		FROM short_links
+		WHERE event_id = $1 AND source = $2
+		ORDER BY created_at
+		LIMIT 1
+	`, eventID, posterLinkSource))
+	if errors.Is(err, sql.ErrNoRows) {
+		return createShortLink(ctx, h.db.DB, eventID, posterLinkSource, nil, userID)
+	}
+	return link, err
+}
+
+// fetchBanner downloads and decodes an event banner. Banners stored locally
+// are served by this API under a relative URL.
+func (h *PosterHandler) fetchBanner(ctx context.Context, bannerURL string) (image.Image, error) {
+	if strings.HasPrefix(bannerURL, "/") && !strings.HasPrefix(bannerURL, "//") {
+		bannerURL = h.baseURL + bannerURL
+	}
+	if !strings.HasPrefix(bannerURL, "http://") && !strings.HasPrefix(bannerURL, "https://") {
+		return nil, fmt.Errorf("unsupported banner URL %q", bannerURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, bannerURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching banner: %s", resp.Status)
+	}
+	img, _, err := image.Decode(io.LimitReader(resp.Body, posterBannerMaxBytes))
+	return img, err
+}
+
+// CreateEventPoster composes a poster for an event (its banner, title, date,
+// venue and a QR code to register), uploads it and returns its URL
+// (organizers only). The QR code opens the event's poster short link.
+// POST /api/v1/admin/events/:id/poster
+func (h *PosterHandler) CreateEventPoster(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+	ctx := c.Request.Context()
+	link, err := h.posterLink(ctx, event.ID, userID)
+	if err != nil {
+		internalError(c, "failed to create poster", err)
+		return
+	}
+	link.URL = h.baseURL + "/e/" + link.Code
+
+	qr, err := qrcode.Encode(link.URL)
+	if err != nil {
+		internalError(c, "failed to create poster", err)
+		return
+	}
+
+	content := poster.Content{
+		Title:   event.Title,
+		When:    event.StartDate.Format("Mon, 2 Jan 2006 3:04 PM"),
+		Caption: strings.TrimPrefix(strings.TrimPrefix(link.URL, "https://"), "http://"),
+		QR:      qr,
+	}
+	if event.Location != nil {
+		content.Where = *event.Location
+	}
+	if event.BannerURL != nil && *event.BannerURL != "" {
+		// A poster without the banner beats no poster
+		if content.Banner, err = h.fetchBanner(ctx, *event.BannerURL); err != nil {
+			log.Printf("Poster for event %s drawn without its banner: %v", event.ID, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, poster.Render(content)); err != nil {
+		internalError(c, "failed to create poster", err)
+		return
+	}
+	result, err := h.storage.UploadImage(ctx, storage.BytesFile(buf.Bytes()), "poster.png", "posters", storage.ImageTypeBanner)
+	if err != nil {
+		internalError(c, "failed to upload poster", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "poster created",
+		Data: models.EventPoster{
+			URL:       result.URL,
+			Width:     result.Width,
+			Height:    result.Height,
+			ShortLink: link,
+		},
+	})
+}
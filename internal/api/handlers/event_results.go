@@ -0,0 +1,326 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/gamification"
+)
+
+// placementHousePoints are the house points a placement earns when the
+// request doesn't say
+var placementHousePoints = map[int]int{1: 50, 2: 30, 3: 20}
+
+// ordinal renders 1 as "1st", 2 as "2nd", ...
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 100 {
+	case 11, 12, 13:
+	default:
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// RecordEventResults records an event's placements (admin only). Each
+// placement awards activity points to its members, credits their houses
+// and, when the winner represented a club, adds a club award. Results are
+// recorded once per event.
+// POST /api/v1/admin/events/:id/results
+func (h *EventHandler) RecordEventResults(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid event ID"),
+		})
+		return
+	}
+
+	var req models.RecordResultsRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	var userIDs []string
+	var clubIDs []string
+	for i, p := range req.Results {
+		if p.TeamName == nil && len(p.UserIDs) != 1 {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr(fmt.Sprintf("results[%d]: team placements need a team_name", i)),
+			})
+			return
+		}
+		seen := make(map[uuid.UUID]bool, len(p.UserIDs))
+		for _, id := range p.UserIDs {
+			if seen[id] {
+				c.JSON(http.StatusBadRequest, models.APIResponse{
+					Success: false,
+					Error:   strPtr(fmt.Sprintf("results[%d]: duplicate user %s", i, id)),
+				})
+				return
+			}
+			seen[id] = true
+			userIDs = append(userIDs, id.String())
+		}
+		if p.ClubID != nil {
+			clubIDs = append(clubIDs, p.ClubID.String())
+		}
+	}
+
+	ctx := c.Request.Context()
+
+	var title string
+	err = h.db.QueryRowContext(ctx, "SELECT title FROM events WHERE id = $1 AND deleted_at IS NULL", eventID).Scan(&title)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("event not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to record results", err)
+		return
+	}
+
+	var missingUsers, missingClubs int
+	err = h.db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(DISTINCT u) FROM unnest($1::uuid[]) u WHERE u NOT IN (SELECT id FROM users WHERE deleted_at IS NULL)),
+			(SELECT COUNT(DISTINCT cl) FROM unnest($2::uuid[]) cl WHERE cl NOT IN (SELECT id FROM clubs))
+	`, pq.Array(userIDs), pq.Array(clubIDs)).Scan(&missingUsers, &missingClubs)
+	if err != nil {
+		internalError(c, "failed to record results", err)
+		return
+	}
+	if missingUsers > 0 || missingClubs > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("results reference unknown users or clubs"),
+		})
+		return
+	}
+
+	adminID, _ := middleware.UserID(c)
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to record results", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Lock the event so concurrent submissions can't both record results
+	var recorded bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM event_results WHERE event_id = e.id)
+		FROM events e WHERE e.id = $1
+		FOR UPDATE
+	`, eventID).Scan(&recorded)
+	if err != nil {
+		internalError(c, "failed to record results", err)
+		return
+	}
+	if recorded {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("results have already been recorded for this event"),
+		})
+		return
+	}
+
+	for _, p := range req.Results {
+		housePoints := placementHousePoints[p.Position]
+		if p.HousePoints != nil {
+			housePoints = *p.HousePoints
+		}
+		place := ordinal(p.Position)
+		reason := fmt.Sprintf("%s place in %s", place, title)
+
+		var awardID *uuid.UUID
+		if p.ClubID != nil {
+			awardName := fmt.Sprintf("%s - %s place", title, place)
+			if p.TeamName != nil {
+				awardName += " (" + *p.TeamName + ")"
+			}
+			if err := tx.QueryRowContext(ctx, `
+				INSERT INTO club_awards (club_id, award_name, position, prize_amount, event_name, awarded_date)
+				VALUES ($1, $2, $3, $4, $5, CURRENT_DATE)
+				RETURNING id
+			`, p.ClubID, awardName, place, p.PrizeAmount, title).Scan(&awardID); err != nil {
+				internalError(c, "failed to record results", err)
+				return
+			}
+		}
+
+		var resultID uuid.UUID
+		if err := tx.QueryRowContext(ctx, `
+			INSERT INTO event_results (event_id, position, team_name, club_id, club_award_id, house_points, prize_amount, recorded_by)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id
+		`, eventID, p.Position, p.TeamName, p.ClubID, awardID, housePoints, p.PrizeAmount, adminID).Scan(&resultID); err != nil {
+			internalError(c, "failed to record results", err)
+			return
+		}
+
+		for _, userID := range p.UserIDs {
+			if _, err := tx.ExecContext(ctx,
+				"INSERT INTO event_result_members (result_id, user_id) VALUES ($1, $2)", resultID, userID,
+			); err != nil {
+				internalError(c, "failed to record results", err)
+				return
+			}
+			if err := gamification.Award(ctx, tx, gamification.Entry{
+				UserID:   userID,
+				Source:   gamification.SourceCompetitionWin,
+				SourceID: resultID,
+				Points:   p.Points,
+				Reason:   reason,
+			}); err != nil {
+				internalError(c, "failed to record results", err)
+				return
+			}
+		}
+
+		if housePoints > 0 {
+			if err := gamification.CreditHousesOf(ctx, tx, p.UserIDs, housePoints, reason,
+				gamification.SourceCompetitionWin, resultID, adminID); err != nil {
+				internalError(c, "failed to record results", err)
+				return
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to record results", err)
+		return
+	}
+
+	results, err := loadEventResults(ctx, h.db.DB, eventID)
+	if err != nil {
+		internalError(c, "failed to fetch results", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Results recorded",
+		Data:    results,
+	})
+}
+
+// ListEventResults returns an event's placements, best first
+// GET /api/v1/events/:id/results
+func (h *EventHandler) ListEventResults(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid event ID"),
+		})
+		return
+	}
+
+	results, err := loadEventResults(c.Request.Context(), h.db.Reader(), eventID)
+	if err != nil {
+		internalError(c, "failed to fetch results", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    results,
+	})
+}
+
+// loadEventResults returns an event's placements with their members, best first
+func loadEventResults(ctx context.Context, db *sql.DB, eventID uuid.UUID) ([]models.EventResult, error) {
+	return loadResults(ctx, db, "r.event_id = $1", eventID)
+}
+
+// loadFestResults returns the placements of every sub-event of a fest
+func loadFestResults(ctx context.Context, db *sql.DB, festID uuid.UUID) ([]models.EventResult, error) {
+	return loadResults(ctx, db,
+		"r.event_id IN (SELECT id FROM events WHERE fest_id = $1 AND deleted_at IS NULL)", festID)
+}
+
+// loadResults returns the placements matching filter, a condition on
+// event_results r with a single argument, with their members
+func loadResults(ctx context.Context, db *sql.DB, filter string, arg interface{}) ([]models.EventResult, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT r.id, r.event_id, r.position, r.team_name, r.club_id, cl.name, r.club_award_id,
+		       r.house_points, r.prize_amount, r.created_at
+		FROM event_results r
+		LEFT JOIN clubs cl ON cl.id = r.club_id
+		WHERE `+filter+`
+		ORDER BY r.event_id, r.position, r.created_at
+	`, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []models.EventResult{}
+	index := make(map[uuid.UUID]int)
+	for rows.Next() {
+		r := models.EventResult{Members: []models.EventResultMember{}}
+		if err := rows.Scan(&r.ID, &r.EventID, &r.Position, &r.TeamName, &r.ClubID, &r.ClubName, &r.ClubAwardID,
+			&r.HousePoints, &r.PrizeAmount, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		index[r.ID] = len(results)
+		results = append(results, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return results, nil
+	}
+
+	members, err := db.QueryContext(ctx, `
+		SELECT m.result_id, u.id, u.full_name, u.avatar_url, hm.house_id
+		FROM event_result_members m
+		JOIN event_results r ON r.id = m.result_id
+		JOIN users u ON u.id = m.user_id
+		LEFT JOIN house_members hm ON hm.user_id = u.id
+		WHERE `+filter+`
+		ORDER BY u.full_name
+	`, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer members.Close()
+
+	for members.Next() {
+		var resultID uuid.UUID
+		var m models.EventResultMember
+		if err := members.Scan(&resultID, &m.UserID, &m.FullName, &m.AvatarURL, &m.HouseID); err != nil {
+			return nil, err
+		}
+		if i, ok := index[resultID]; ok {
+			results[i].Members = append(results[i].Members, m)
+		}
+	}
+	return results, members.Err()
+}
@@ -0,0 +1,16 @@
+package handlers
+
+import "testing"
+
+func TestOrdinal(t *testing.T) {
+	tests := map[int]string{
+		1: "1st", 2: "2nd", 3: "3rd", 4: "4th",
+		11: "11th", 12: "12th", 13: "13th",
+		21: "21st", 22: "22nd", 101: "101st", 111: "111th",
+	}
+	for n, want := range tests {
+		if got := ordinal(n); got != want {
+			t.Errorf("ordinal(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
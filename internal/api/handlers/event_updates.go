@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+	"github.com/yourusername/college-event-backend/internal/repository"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
+)
+
+const (
+	// eventDetailUpdates is how many live updates are returned with an event
+	eventDetailUpdates = 20
+
+	// updateStreamPoll is how often the live stream checks for new updates.
+	// Polling the database keeps the stream correct across API instances.
+	updateStreamPoll = 3 * time.Second
+
+	// updateStreamHeartbeat keeps idle streams open through proxies
+	updateStreamHeartbeat = 25 * time.Second
+)
+
+// PostEventUpdate posts a live update and notifies everyone registered for
+// the event (organizers only: admins, the event's creator and its club's leads)
+// POST /api/v1/events/:id/updates
+func (h *EventHandler) PostEventUpdate(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+	eventID := event.ID
+
+	var req models.CreateEventUpdateRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+	ctx := c.Request.Context()
+
+	// Notifications to registered users are queued with the update
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to post update", err)
+		return
+	}
+	defer tx.Rollback()
+
+	var update models.EventUpdate
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO event_updates (event_id, posted_by, body)
+		VALUES ($1, $2, $3)
+		RETURNING id, event_id, posted_by, body, created_at
+	`, eventID, userID, req.Body).Scan(&update.ID, &update.EventID, &update.PostedBy, &update.Body, &update.CreatedAt)
+	if err != nil {
+		internalError(c, "failed to post update", err)
+		return
+	}
+
+	err = outbox.Write(ctx, tx, notifications.TopicEventUpdatePosted, notifications.EventUpdatePostedPayload{
+		UpdateID:   update.ID,
+		EventID:    eventID,
+		EventTitle: event.Title,
+		Body:       update.Body,
+	})
+	if err != nil {
+		internalError(c, "failed to post update", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to post update", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Update posted",
+		Data:    update,
+	})
+}
+
+// ListEventUpdates returns an event's live updates, newest first
+// GET /api/v1/events/:id/updates
+func (h *EventHandler) ListEventUpdates(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid event ID"),
+		})
+		return
+	}
+
+	updates, err := repository.New(h.db.Reader()).ListEventUpdates(c.Request.Context(), eventID, 200)
+	if err != nil {
+		internalError(c, "failed to fetch updates", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    updates,
+	})
+}
+
+// StreamEventUpdates streams new live updates as server-sent events. Each
+// message's id is its created_at, so a reconnecting client that sends
+// Last-Event-ID (or ?since=) resumes where it left off.
+// GET /api/v1/events/:id/updates/stream
+func (h *EventHandler) StreamEventUpdates(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid event ID"),
+		})
+		return
+	}
+
+	since := time.Now()
+	cursor := c.GetHeader("Last-Event-ID")
+	if cursor == "" {
+		cursor = c.Query("since")
+	}
+	if cursor != "" {
+		t, err := time.Parse(time.RFC3339Nano, cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("invalid since, use an RFC 3339 timestamp"),
+			})
+			return
+		}
+		since = t
+	}
+
+	ctx := c.Request.Context()
+	q := repository.New(h.db.Reader())
+	if _, err := q.GetEvent(ctx, eventID); err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("event not found"),
+		})
+		return
+	} else if err != nil {
+		internalError(c, "failed to fetch updates", err)
+		return
+	}
+
+	startEventStream(c)
+
+	poll := time.NewTicker(updateStreamPoll)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(updateStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+			c.Writer.Flush()
+		case <-poll.C:
+			updates, err := q.ListEventUpdatesSince(ctx, eventID, since)
+			if err != nil {
+				if ctx.Err() == nil {
+					logInternalError(c, "failed to poll event updates", err)
+				}
+				return
+			}
+			for _, u := range updates {
+				data, err := json.Marshal(u)
+				if err != nil {
+					logInternalError(c, "failed to encode event update", err)
+					return
+				}
+				fmt.Fprintf(c.Writer, "id: %s\nevent: update\ndata: %s\n\n", u.CreatedAt.Format(time.RFC3339Nano), data)
+				since = u.CreatedAt
+			}
+			if len(updates) > 0 {
+				c.Writer.Flush()
+			}
+		}
+	}
+}
@@ -8,7 +8,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
 	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
+	"github.com/yourusername/college-event-backend/internal/webhooks"
 	"github.com/yourusername/college-event-backend/pkg/database"
 )
 
@@ -20,42 +24,43 @@ func NewEventHandler(db *database.DB) *EventHandler {
 	return &EventHandler{db: db}
 }
 
-// ListEvents returns all events
+// ListEvents returns upcoming events, or with ?term= every event in an
+// academic term (a term ID or "current"). ?department= narrows them to the
+// events a department hosts itself or through its clubs. Alumni events are
+// listed only for those who see them (see models.SeesAlumniEvents).
 func (h *EventHandler) ListEvents(c *gin.Context) {
-	rows, err := h.db.Query(`
-		SELECT id, title, description, banner_url, start_date, end_date, location, category, 
-		       status, max_participants, current_participants, registration_deadline, is_featured,
-		       is_paid_event, event_amount, currency,
-		       club_id, created_by, created_at, updated_at
-		FROM events
-		WHERE deleted_at IS NULL AND end_date >= $1
-		ORDER BY start_date ASC
-	`, time.Now())
-
+	ctx := c.Request.Context()
+	termID, err := termFilter(ctx, h.db.Reader(), c.Query("term"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Error:   strPtr("failed to fetch events"),
-		})
+		respondTermFilterError(c, err)
 		return
 	}
-	defer rows.Close()
 
-	var events []models.Event
-	for rows.Next() {
-		var event models.Event
-		err := rows.Scan(
-			&event.ID, &event.Title, &event.Description, &event.BannerURL,
-			&event.StartDate, &event.EndDate, &event.Location, &event.Category,
-			&event.Status, &event.MaxParticipants, &event.CurrentParticipants,
-			&event.RegistrationDeadline, &event.IsFeatured,
-			&event.IsPaidEvent, &event.EventAmount, &event.Currency,
-			&event.ClubID, &event.CreatedBy, &event.CreatedAt, &event.UpdatedAt,
-		)
+	var departmentID *uuid.UUID
+	if d := c.Query("department"); d != "" {
+		id, err := uuid.Parse(d)
 		if err != nil {
-			continue
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("invalid department ID"),
+			})
+			return
 		}
-		events = append(events, event)
+		departmentID = &id
+	}
+
+	role, _ := middleware.Role(c)
+	alumniEvents := models.SeesAlumniEvents(role)
+
+	var events []models.Event
+	if termID != nil {
+		events, err = repository.New(h.db.Reader()).ListTermEvents(ctx, *termID, departmentID, alumniEvents)
+	} else {
+		events, err = repository.New(h.db.Reader()).ListUpcomingEvents(ctx, time.Now(), departmentID, alumniEvents)
+	}
+	if err != nil {
+		internalError(c, "failed to fetch events", err)
+		return
 	}
 
 	c.JSON(http.StatusOK, models.APIResponse{
@@ -64,7 +69,8 @@ func (h *EventHandler) ListEvents(c *gin.Context) {
 	})
 }
 
-// GetEvent returns a single event by ID
+// GetEvent returns a single event by ID. Alumni events aren't found by those
+// who don't see them.
 func (h *EventHandler) GetEvent(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
@@ -76,22 +82,11 @@ func (h *EventHandler) GetEvent(c *gin.Context) {
 		return
 	}
 
-	var event models.Event
-	err = h.db.QueryRow(`
-		SELECT id, title, description, banner_url, start_date, end_date, location, category,
-		       status, max_participants, current_participants, registration_deadline, is_featured,
-		       is_paid_event, event_amount, currency,
-		       club_id, created_by, created_at, updated_at
-		FROM events
-		WHERE id = $1 AND deleted_at IS NULL
-	`, id).Scan(
-		&event.ID, &event.Title, &event.Description, &event.BannerURL,
-		&event.StartDate, &event.EndDate, &event.Location, &event.Category,
-		&event.Status, &event.MaxParticipants, &event.CurrentParticipants,
-		&event.RegistrationDeadline, &event.IsFeatured,
-		&event.IsPaidEvent, &event.EventAmount, &event.Currency,
-		&event.ClubID, &event.CreatedBy, &event.CreatedAt, &event.UpdatedAt,
-	)
+	q := repository.New(h.db.Reader())
+	event, err := q.GetEvent(c.Request.Context(), id)
+	if role, _ := middleware.Role(c); err == nil && !event.VisibleTo(role) {
+		err = sql.ErrNoRows
+	}
 
 	if err == sql.ErrNoRows {
 		c.JSON(http.StatusNotFound, models.APIResponse{
@@ -109,18 +104,50 @@ func (h *EventHandler) GetEvent(c *gin.Context) {
 		return
 	}
 
+	updates, err := q.ListEventUpdates(c.Request.Context(), id, eventDetailUpdates)
+	if err != nil {
+		internalError(c, "failed to fetch event", err)
+		return
+	}
+
+	sponsors, err := loadSponsors(c.Request.Context(), h.db.Reader(), &event.ID, event.FestID)
+	if err != nil {
+		internalError(c, "failed to fetch event", err)
+		return
+	}
+
+	form, err := loadRegistrationForm(c.Request.Context(), h.db.Reader(), event.ID)
+	if err != nil {
+		internalError(c, "failed to fetch event", err)
+		return
+	}
+
+	tiers, err := loadTicketTiers(c.Request.Context(), h.db.Reader(), event.ID)
+	if err != nil {
+		internalError(c, "failed to fetch event", err)
+		return
+	}
+
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
-		Data:    event,
+		Data: models.EventDetail{
+			Event:            event,
+			Updates:          updates,
+			Sponsors:         sponsors,
+			RegistrationForm: form,
+			TicketTiers:      tiers,
+		},
 	})
 }
 
-// CreateEvent creates a new event (admin only)
+// CreateEvent creates a new event (admin only). One that looks like a
+// duplicate of an existing event is refused with 409 and the events it
+// matches unless ?allow_duplicate=true.
 func (h *EventHandler) CreateEvent(c *gin.Context) {
-	userID, _ := c.Get("user_id")
+	userID, _ := middleware.UserID(c)
 
 	var req models.CreateEventRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		// Log the error for debugging
 		fmt.Printf("CreateEvent validation error: %v\n", err.Error())
 		c.JSON(http.StatusBadRequest, models.APIResponse{
@@ -142,6 +169,18 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 		})
 		return
 	}
+	var deadline *time.Time
+	if req.RegistrationDeadline != nil {
+		t := req.RegistrationDeadline.Time()
+		if t.After(endTime) {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("registration_deadline must not be after end_date"),
+			})
+			return
+		}
+		deadline = &t
+	}
 
 	// Use BannerURL if provided, otherwise use ImageURL for backward compatibility
 	bannerURL := req.BannerURL
@@ -149,6 +188,25 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 		bannerURL = req.ImageURL
 	}
 
+	// An event that looks like one already listed is refused, showing the
+	// ones it matches, until it's sent again with ?allow_duplicate=true
+	ctx := c.Request.Context()
+	if c.Query("allow_duplicate") != "true" {
+		dups, err := findDuplicateEvents(ctx, h.db.DB, req, startTime, endTime)
+		if err != nil {
+			internalError(c, "failed to create event", err)
+			return
+		}
+		if len(dups) > 0 {
+			c.JSON(http.StatusConflict, models.APIResponse{
+				Success: false,
+				Error:   strPtr("this looks like an event that's already listed; send it again with allow_duplicate=true to create it anyway"),
+				Data:    gin.H{"duplicates": dups},
+			})
+			return
+		}
+	}
+
 	// Default currency to INR if not provided
 	currency := req.Currency
 	if currency == nil {
@@ -156,21 +214,29 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 		currency = &defaultCurrency
 	}
 
+	// The event and its webhook notification commit together
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to create event", err)
+		return
+	}
+	defer tx.Rollback()
+
 	var event models.Event
-	err := h.db.QueryRow(`
-		INSERT INTO events (title, description, banner_url, start_date, end_date, location, category, max_participants, is_paid_event, event_amount, currency, club_id, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO events (title, description, banner_url, start_date, end_date, location, category, max_participants, is_paid_event, event_amount, currency, club_id, department_id, created_by, registration_deadline, alumni_access)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, COALESCE($16, 'none'))
 		RETURNING id, title, description, banner_url, start_date, end_date, location, category,
-		          status, max_participants, current_participants, registration_deadline, is_featured,
+		          status, max_participants, current_participants, registration_deadline, is_featured, alumni_access,
 		          is_paid_event, event_amount, currency,
-		          club_id, created_by, created_at, updated_at
-	`, req.Title, req.Description, bannerURL, startTime, endTime, req.Location, req.Category, req.MaxCapacity, req.IsPaidEvent, req.EventAmount, currency, req.ClubID, userID.(uuid.UUID)).Scan(
+		          club_id, department_id, created_by, version, created_at, updated_at
+	`, req.Title, req.Description, bannerURL, startTime, endTime, req.Location, req.Category, req.MaxCapacity, req.IsPaidEvent, req.EventAmount, currency, req.ClubID, req.DepartmentID, userID, deadline, req.AlumniAccess).Scan(
 		&event.ID, &event.Title, &event.Description, &event.BannerURL,
 		&event.StartDate, &event.EndDate, &event.Location, &event.Category,
 		&event.Status, &event.MaxParticipants, &event.CurrentParticipants,
-		&event.RegistrationDeadline, &event.IsFeatured,
+		&event.RegistrationDeadline, &event.IsFeatured, &event.AlumniAccess,
 		&event.IsPaidEvent, &event.EventAmount, &event.Currency,
-		&event.ClubID, &event.CreatedBy, &event.CreatedAt, &event.UpdatedAt,
+		&event.ClubID, &event.DepartmentID, &event.CreatedBy, &event.Version, &event.CreatedAt, &event.UpdatedAt,
 	)
 
 	if err != nil {
@@ -182,6 +248,24 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 		return
 	}
 
+	if err := webhooks.Emit(ctx, tx, webhooks.EventCreated, event); err != nil {
+		internalError(c, "failed to create event", err)
+		return
+	}
+	if err := notifyFollowers(ctx, tx, models.FollowTargetClub, event.ClubID, notifications.FollowedContentPublishedPayload{
+		ContentType: notifications.FollowedEvent,
+		ContentID:   event.ID,
+		Title:       event.Title,
+		AuthorID:    event.CreatedBy,
+	}); err != nil {
+		internalError(c, "failed to create event", err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to create event", err)
+		return
+	}
+
 	c.JSON(http.StatusCreated, models.APIResponse{
 		Success: true,
 		Message: "event created successfully",
@@ -189,7 +273,9 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 	})
 }
 
-// UpdateEvent updates an existing event (admin only)
+// UpdateEvent updates an existing event (admin only). If the body names
+// the version it was edited from and the event has changed since, it's
+// refused with 409. Without alumni_access the event keeps the one it has.
 func (h *EventHandler) UpdateEvent(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
@@ -201,8 +287,8 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 		return
 	}
 
-	var req models.CreateEventRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	var req models.UpdateEventRequest
+	if err := bindJSON(c, &req); err != nil {
 		fmt.Printf("UpdateEvent validation error: %v\n", err.Error())
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
@@ -223,6 +309,18 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 		})
 		return
 	}
+	var deadline *time.Time
+	if req.RegistrationDeadline != nil {
+		t := req.RegistrationDeadline.Time()
+		if t.After(endTime) {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("registration_deadline must not be after end_date"),
+			})
+			return
+		}
+		deadline = &t
+	}
 
 	// Use BannerURL if provided, otherwise use ImageURL for backward compatibility
 	bannerURL := req.BannerURL
@@ -243,22 +341,38 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 		SET title = $1, description = $2, banner_url = $3, start_date = $4, end_date = $5, 
 		    location = $6, category = $7, max_participants = $8, 
 		    is_paid_event = $9, event_amount = $10, currency = $11,
-		    club_id = $12, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $13 AND deleted_at IS NULL
+		    club_id = $12, department_id = $13, registration_deadline = $16,
+		    alumni_access = COALESCE($17, alumni_access),
+		    version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $14 AND deleted_at IS NULL
+		  AND ($15::int IS NULL OR version = $15)
 		RETURNING id, title, description, banner_url, start_date, end_date, location, category,
-		          status, max_participants, current_participants, registration_deadline, is_featured,
+		          status, max_participants, current_participants, registration_deadline, is_featured, alumni_access,
 		          is_paid_event, event_amount, currency,
-		          club_id, created_by, created_at, updated_at
-	`, req.Title, req.Description, bannerURL, startTime, endTime, req.Location, req.Category, req.MaxCapacity, req.IsPaidEvent, req.EventAmount, currency, req.ClubID, id).Scan(
+		          club_id, department_id, created_by, version, created_at, updated_at
+	`, req.Title, req.Description, bannerURL, startTime, endTime, req.Location, req.Category, req.MaxCapacity, req.IsPaidEvent, req.EventAmount, currency, req.ClubID, req.DepartmentID, id, req.Version, deadline, req.AlumniAccess).Scan(
 		&event.ID, &event.Title, &event.Description, &event.BannerURL,
 		&event.StartDate, &event.EndDate, &event.Location, &event.Category,
 		&event.Status, &event.MaxParticipants, &event.CurrentParticipants,
-		&event.RegistrationDeadline, &event.IsFeatured,
+		&event.RegistrationDeadline, &event.IsFeatured, &event.AlumniAccess,
 		&event.IsPaidEvent, &event.EventAmount, &event.Currency,
-		&event.ClubID, &event.CreatedBy, &event.CreatedAt, &event.UpdatedAt,
+		&event.ClubID, &event.DepartmentID, &event.CreatedBy, &event.Version, &event.CreatedAt, &event.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
+		current, ok, err := staleVersion(c.Request.Context(), h.db.DB, eventVersionQuery, id)
+		if err != nil {
+			internalError(c, "failed to update event", err)
+			return
+		}
+		if ok {
+			c.JSON(http.StatusConflict, models.APIResponse{
+				Success: false,
+				Error:   strPtr("event was edited by someone else since you loaded it; reload it and reapply your changes"),
+				Data:    gin.H{"version": current},
+			})
+			return
+		}
 		c.JSON(http.StatusNotFound, models.APIResponse{
 			Success: false,
 			Error:   strPtr("event not found"),
@@ -322,3 +436,58 @@ func (h *EventHandler) DeleteEvent(c *gin.Context) {
 		Message: "event deleted successfully",
 	})
 }
+
+// requireEventOrganizer loads the event named by :id and checks the user
+// organizes it: admins, the event's creator and its club's leads. Returns
+// false if the request has been answered.
+func requireEventOrganizer(c *gin.Context, db *database.DB) (models.Event, bool) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid event ID"),
+		})
+		return models.Event{}, false
+	}
+
+	event, err := repository.New(db).GetEvent(c.Request.Context(), eventID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("event not found"),
+		})
+		return event, false
+	}
+	if err != nil {
+		internalError(c, "failed to fetch event", err)
+		return event, false
+	}
+
+	allowed, err := canOrganizeEvent(c, db.DB, event)
+	if err != nil {
+		internalError(c, "failed to check event organizers", err)
+		return event, false
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("only the event's organizers can do this"),
+		})
+		return event, false
+	}
+	return event, true
+}
+
+// canOrganizeEvent reports whether the user organizes the event: its
+// creator, an admin or a lead of its club
+func canOrganizeEvent(c *gin.Context, db *sql.DB, event models.Event) (bool, error) {
+	userID, _ := middleware.UserID(c)
+	role, _ := middleware.Role(c)
+	if (event.CreatedBy != nil && *event.CreatedBy == userID) || role == models.RoleAdmin {
+		return true, nil
+	}
+	if event.ClubID == nil {
+		return false, nil
+	}
+	return canManageClub(c, db, *event.ClubID)
+}
@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/webhooks"
+)
+
+// CreateFestPassOrder requests the combined pass of a fest. A free pass is
+// active at once; a paid pass returns a Razorpay order to be completed with
+// VerifyFestPass. Either way, an active pass registers the holder for every
+// sub-event.
+// POST /api/v1/fests/:id/pass
+func (h *PaymentHandler) CreateFestPassOrder(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	festID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid fest ID"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	var passAmount *float64
+	var currency string
+	err = h.db.QueryRowContext(ctx, `
+		SELECT pass_amount, COALESCE(currency, 'INR') FROM fests WHERE id = $1 AND deleted_at IS NULL
+	`, festID).Scan(&passAmount, &currency)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("fest not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to create pass", err)
+		return
+	}
+	if passAmount == nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("this fest does not offer a pass"),
+		})
+		return
+	}
+
+	var status string
+	err = h.db.QueryRowContext(ctx,
+		"SELECT status FROM fest_passes WHERE fest_id = $1 AND user_id = $2", festID, userID,
+	).Scan(&status)
+	if err != nil && err != sql.ErrNoRows {
+		internalError(c, "failed to create pass", err)
+		return
+	}
+	if status == models.FestPassPaid {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("you already have a pass for this fest"),
+		})
+		return
+	}
+
+	if *passAmount == 0 {
+		tx, err := h.db.BeginTx(ctx, nil)
+		if err != nil {
+			internalError(c, "failed to create pass", err)
+			return
+		}
+		defer tx.Rollback()
+
+		pass, err := scanFestPass(tx.QueryRowContext(ctx, `
+			INSERT INTO fest_passes (fest_id, user_id, amount, currency, status)
+			VALUES ($1, $2, 0, $3, 'paid')
+			ON CONFLICT (fest_id, user_id) DO UPDATE
+			SET amount = 0, status = 'paid', razorpay_order_id = NULL
+			RETURNING `+festPassColumns,
+			festID, userID, currency))
+		if err != nil {
+			internalError(c, "failed to create pass", err)
+			return
+		}
+		if err := registerPassHolders(ctx, tx, festID, &userID); err != nil {
+			internalError(c, "failed to create pass", err)
+			return
+		}
+		if err := tx.Commit(); err != nil {
+			internalError(c, "failed to create pass", err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, models.APIResponse{
+			Success: true,
+			Message: "pass activated and registration complete",
+			Data:    models.FestPassOrderResponse{Pass: &pass, FestID: festID.String()},
+		})
+		return
+	}
+
+	amountInPaise := int(*passAmount * 100)
+	orderID, err := h.createRazorpayOrder(amountInPaise, currency)
+	if err != nil {
+		internalError(c, "failed to create payment order", err)
+		return
+	}
+
+	// A retried purchase replaces the earlier unpaid order
+	_, err = h.db.ExecContext(ctx, `
+		INSERT INTO fest_passes (fest_id, user_id, razorpay_order_id, amount, currency, status)
+		VALUES ($1, $2, $3, $4, $5, 'pending')
+		ON CONFLICT (fest_id, user_id) DO UPDATE
+		SET razorpay_order_id = EXCLUDED.razorpay_order_id, amount = EXCLUDED.amount,
+		    currency = EXCLUDED.currency, status = 'pending'
+		WHERE fest_passes.status <> 'paid'
+	`, festID, userID, orderID, *passAmount, currency)
+	if err != nil {
+		internalError(c, "failed to create payment record", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: models.FestPassOrderResponse{
+			OrderID:  orderID,
+			Amount:   amountInPaise,
+			Currency: currency,
+			KeyID:    h.keyID,
+			FestID:   festID.String(),
+		},
+	})
+}
+
+// VerifyFestPass verifies a pass payment and registers the holder for every
+// sub-event of the fest
+// POST /api/v1/fests/:id/pass/verify
+func (h *PaymentHandler) VerifyFestPass(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	festID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid fest ID"),
+		})
+		return
+	}
+
+	var req models.VerifyFestPassRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid request body"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	mac := hmac.New(sha256.New, []byte(h.keySecret))
+	mac.Write([]byte(req.RazorpayOrderID + "|" + req.RazorpayPaymentID))
+	if !hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(req.RazorpaySignature)) {
+		if _, err := h.db.ExecContext(ctx, `
+			UPDATE fest_passes SET status = 'failed'
+			WHERE razorpay_order_id = $1 AND user_id = $2 AND status = 'pending'
+		`, req.RazorpayOrderID, userID); err != nil {
+			logInternalError(c, "failed to mark pass payment failed", err)
+		}
+
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("payment verification failed: invalid signature"),
+		})
+		return
+	}
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to update payment record", err)
+		return
+	}
+	defer tx.Rollback()
+
+	pass, err := scanFestPass(tx.QueryRowContext(ctx, `
+		UPDATE fest_passes
+		SET razorpay_payment_id = $1, razorpay_signature = $2, status = 'paid'
+		WHERE razorpay_order_id = $3 AND user_id = $4 AND fest_id = $5 AND status <> 'paid'
+		RETURNING `+festPassColumns,
+		req.RazorpayPaymentID, req.RazorpaySignature, req.RazorpayOrderID, userID, festID))
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("payment order not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to update payment record", err)
+		return
+	}
+
+	if err := registerPassHolders(ctx, tx, festID, &userID); err != nil {
+		internalError(c, "failed to register for fest events", err)
+		return
+	}
+	if err := webhooks.Emit(ctx, tx, webhooks.PaymentCaptured, webhooks.PaymentCapturedData{
+		PaymentID:         pass.ID,
+		Kind:              "fest_pass",
+		FestID:            &festID,
+		UserID:            userID,
+		Amount:            pass.Amount,
+		Currency:          pass.Currency,
+		Method:            models.PaymentMethodRazorpay,
+		RazorpayPaymentID: req.RazorpayPaymentID,
+	}); err != nil {
+		internalError(c, "failed to update payment record", err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to update payment record", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "payment verified and registration complete",
+		Data:    pass,
+	})
+}
@@ -0,0 +1,616 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+	"github.com/yourusername/college-event-backend/internal/repository"
+	"github.com/yourusername/college-event-backend/internal/services/gamification"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+const festColumns = `
+	id, name, tagline, description, logo_url, banner_url, color_hex, start_date, end_date,
+	pass_amount, COALESCE(currency, 'INR'), created_by, created_at, updated_at`
+
+func scanFest(row interface{ Scan(...interface{}) error }) (models.Fest, error) {
+	var f models.Fest
+	err := row.Scan(
+		&f.ID, &f.Name, &f.Tagline, &f.Description, &f.LogoURL, &f.BannerURL, &f.ColorHex, &f.StartDate, &f.EndDate,
+		&f.PassAmount, &f.Currency, &f.CreatedBy, &f.CreatedAt, &f.UpdatedAt,
+	)
+	return f, err
+}
+
+const festPassColumns = `id, fest_id, user_id, amount, COALESCE(currency, 'INR'), status, created_at`
+
+func scanFestPass(row interface{ Scan(...interface{}) error }) (models.FestPass, error) {
+	var p models.FestPass
+	err := row.Scan(&p.ID, &p.FestID, &p.UserID, &p.Amount, &p.Currency, &p.Status, &p.CreatedAt)
+	return p, err
+}
+
+// registerPassHolders registers paid pass holders of a fest, or just userID
+// when set, for every sub-event they aren't registered for yet and may
+// attend (see models.Event.OpenTo). Pass holders bypass max_participants:
+// the pass was sold for every sub-event.
+func registerPassHolders(ctx context.Context, tx *sql.Tx, festID uuid.UUID, userID *uuid.UUID) error {
+	rows, err := tx.QueryContext(ctx, `
+		INSERT INTO event_registrations (event_id, user_id)
+		SELECT e.id, p.user_id
+		FROM events e
+		JOIN fest_passes p ON p.fest_id = e.fest_id AND p.status = 'paid'
+		JOIN users u ON u.id = p.user_id
+		WHERE e.fest_id = $1 AND e.deleted_at IS NULL AND e.status <> 'cancelled'
+		  AND ($2::uuid IS NULL OR p.user_id = $2)
+		  AND NOT (u.role = 'alumni' AND e.alumni_access = 'none')
+		  AND NOT (u.role = 'student' AND e.alumni_access = 'only')
+		ON CONFLICT (event_id, user_id) DO NOTHING
+		RETURNING event_id, user_id
+	`, festID, userID)
+	if err != nil {
+		return err
+	}
+
+	type registration struct{ eventID, userID uuid.UUID }
+	var registered []registration
+	for rows.Next() {
+		var r registration
+		if err := rows.Scan(&r.eventID, &r.userID); err != nil {
+			rows.Close()
+			return err
+		}
+		registered = append(registered, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	added := make(map[uuid.UUID]int)
+	for _, r := range registered {
+		added[r.eventID]++
+		if err := gamification.Award(ctx, tx, gamification.Entry{
+			UserID:   r.userID,
+			Source:   gamification.SourceEventAttendance,
+			SourceID: r.eventID,
+		}); err != nil {
+			return err
+		}
+	}
+	for eventID, n := range added {
+		var participants int
+		if err := tx.QueryRowContext(ctx, `
+			UPDATE events
+			SET current_participants = current_participants + $2, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $1
+			RETURNING current_participants
+		`, eventID, n).Scan(&participants); err != nil {
+			return err
+		}
+		if err := outbox.Write(ctx, tx, notifications.TopicEventRegistrationsAdded, notifications.EventRegistrationsAddedPayload{
+			EventID: eventID,
+			Before:  participants - n,
+			After:   participants,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FestHandler handles fests and their sub-events
+type FestHandler struct {
+	db *database.DB
+}
+
+// NewFestHandler creates a new fest handler
+func NewFestHandler(db *database.DB) *FestHandler {
+	return &FestHandler{db: db}
+}
+
+// ListFests lists fests, latest first
+// GET /api/v1/fests
+func (h *FestHandler) ListFests(c *gin.Context) {
+	rows, err := h.db.Reader().QueryContext(c.Request.Context(), `
+		SELECT `+festColumns+` FROM fests
+		WHERE deleted_at IS NULL
+		ORDER BY start_date DESC
+		LIMIT 100
+	`)
+	if err != nil {
+		internalError(c, "Failed to fetch fests", err)
+		return
+	}
+	defer rows.Close()
+
+	fests := []models.Fest{}
+	for rows.Next() {
+		f, err := scanFest(rows)
+		if err != nil {
+			internalError(c, "Failed to fetch fests", err)
+			return
+		}
+		fests = append(fests, f)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch fests", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    fests,
+	})
+}
+
+// GetFest returns a fest with its full schedule, each sub-event's results,
+// the fest leaderboard and, when signed in, the caller's pass. Alumni
+// events are left out for those who don't see them.
+// GET /api/v1/fests/:id
+func (h *FestHandler) GetFest(c *gin.Context) {
+	festID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid fest ID"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	db := h.db.Reader()
+
+	fest, err := scanFest(db.QueryRowContext(ctx,
+		"SELECT "+festColumns+" FROM fests WHERE id = $1 AND deleted_at IS NULL", festID))
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Fest not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to fetch fest", err)
+		return
+	}
+	detail := models.FestDetail{Fest: fest, Events: []models.FestEvent{}}
+
+	role, _ := middleware.Role(c)
+	events, err := repository.New(db).ListFestEvents(ctx, festID, models.SeesAlumniEvents(role))
+	if err != nil {
+		internalError(c, "Failed to fetch fest", err)
+		return
+	}
+	results, err := loadFestResults(ctx, db, festID)
+	if err != nil {
+		internalError(c, "Failed to fetch fest", err)
+		return
+	}
+	byEvent := make(map[uuid.UUID][]models.EventResult)
+	for _, r := range results {
+		byEvent[r.EventID] = append(byEvent[r.EventID], r)
+	}
+	for _, e := range events {
+		eventResults := byEvent[e.ID]
+		if eventResults == nil {
+			eventResults = []models.EventResult{}
+		}
+		detail.Events = append(detail.Events, models.FestEvent{Event: e, Results: eventResults})
+	}
+
+	if detail.Leaderboard, err = festLeaderboard(ctx, db, festID); err != nil {
+		internalError(c, "Failed to fetch fest", err)
+		return
+	}
+	if detail.Sponsors, err = loadSponsors(ctx, db, nil, &festID); err != nil {
+		internalError(c, "Failed to fetch fest", err)
+		return
+	}
+
+	if userID, ok := middleware.UserID(c); ok {
+		pass, err := scanFestPass(h.db.QueryRowContext(ctx,
+			"SELECT "+festPassColumns+" FROM fest_passes WHERE fest_id = $1 AND user_id = $2", festID, userID))
+		if err != nil && err != sql.ErrNoRows {
+			internalError(c, "Failed to fetch fest", err)
+			return
+		}
+		if err == nil {
+			detail.Pass = &pass
+		}
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    detail,
+	})
+}
+
+// festLeaderboard ranks houses by the competition points their members won
+// across the fest, and clubs by their placements
+func festLeaderboard(ctx context.Context, db *sql.DB, festID uuid.UUID) (models.FestLeaderboard, error) {
+	board := models.FestLeaderboard{
+		Houses: []models.FestHouseStanding{},
+		Clubs:  []models.FestClubStanding{},
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT h.id, h.name, h.color, SUM(l.points)
+		FROM house_point_ledger l
+		JOIN houses h ON h.id = l.house_id
+		JOIN event_results r ON r.id = l.source_id
+		JOIN events e ON e.id = r.event_id
+		WHERE l.source = $2 AND e.fest_id = $1 AND e.deleted_at IS NULL
+		GROUP BY h.id, h.name, h.color
+		ORDER BY 4 DESC, h.name
+	`, festID, gamification.SourceCompetitionWin)
+	if err != nil {
+		return board, err
+	}
+	for rows.Next() {
+		var s models.FestHouseStanding
+		if err := rows.Scan(&s.HouseID, &s.Name, &s.Color, &s.Points); err != nil {
+			rows.Close()
+			return board, err
+		}
+		board.Houses = append(board.Houses, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return board, err
+	}
+
+	rows, err = db.QueryContext(ctx, `
+		SELECT cl.id, cl.name,
+		       COUNT(*) FILTER (WHERE r.position = 1),
+		       COUNT(*) FILTER (WHERE r.position = 2),
+		       COUNT(*) FILTER (WHERE r.position = 3),
+		       COUNT(*)
+		FROM event_results r
+		JOIN clubs cl ON cl.id = r.club_id
+		JOIN events e ON e.id = r.event_id
+		WHERE e.fest_id = $1 AND e.deleted_at IS NULL
+		GROUP BY cl.id, cl.name
+		ORDER BY 3 DESC, 4 DESC, 5 DESC, 6 DESC, cl.name
+	`, festID)
+	if err != nil {
+		return board, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var s models.FestClubStanding
+		if err := rows.Scan(&s.ClubID, &s.Name, &s.First, &s.Second, &s.Third, &s.Placements); err != nil {
+			return board, err
+		}
+		board.Clubs = append(board.Clubs, s)
+	}
+	return board, rows.Err()
+}
+
+// CreateFest creates a fest (admin only)
+// POST /api/v1/admin/fests
+func (h *FestHandler) CreateFest(c *gin.Context) {
+	var req models.CreateFestRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	start, errStart := time.Parse(dateLayout, req.StartDate)
+	end, errEnd := time.Parse(dateLayout, req.EndDate)
+	if errStart != nil || errEnd != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("start_date and end_date must be YYYY-MM-DD"),
+		})
+		return
+	}
+	if end.Before(start) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("end_date must not be before start_date"),
+		})
+		return
+	}
+
+	currency := "INR"
+	if req.Currency != nil {
+		currency = *req.Currency
+	}
+	userID, _ := middleware.UserID(c)
+
+	fest, err := scanFest(h.db.QueryRowContext(c.Request.Context(), `
+		INSERT INTO fests (name, tagline, description, logo_url, banner_url, color_hex, start_date, end_date,
+		                   pass_amount, currency, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING `+festColumns,
+		req.Name, req.Tagline, req.Description, req.LogoURL, req.BannerURL, req.ColorHex,
+		req.StartDate, req.EndDate, req.PassAmount, currency, userID))
+	if err != nil {
+		internalError(c, "Failed to create fest", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Fest created successfully",
+		Data:    fest,
+	})
+}
+
+// UpdateFest updates a fest's branding, dates or pass (admin only)
+// PUT /api/v1/admin/fests/:id
+func (h *FestHandler) UpdateFest(c *gin.Context) {
+	festID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid fest ID"),
+		})
+		return
+	}
+
+	var req models.UpdateFestRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	current, err := scanFest(h.db.QueryRowContext(ctx,
+		"SELECT "+festColumns+" FROM fests WHERE id = $1 AND deleted_at IS NULL", festID))
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Fest not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to update fest", err)
+		return
+	}
+
+	start, end := current.StartDate, current.EndDate
+	for _, d := range []struct {
+		value *string
+		dest  *time.Time
+	}{{req.StartDate, &start}, {req.EndDate, &end}} {
+		if d.value == nil {
+			continue
+		}
+		t, err := time.Parse(dateLayout, *d.value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("start_date and end_date must be YYYY-MM-DD"),
+			})
+			return
+		}
+		*d.dest = t
+	}
+	if end.Before(start) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("end_date must not be before start_date"),
+		})
+		return
+	}
+
+	fest, err := scanFest(h.db.QueryRowContext(ctx, `
+		UPDATE fests
+		SET name = COALESCE($1, name),
+		    tagline = COALESCE($2, tagline),
+		    description = COALESCE($3, description),
+		    logo_url = COALESCE($4, logo_url),
+		    banner_url = COALESCE($5, banner_url),
+		    color_hex = COALESCE($6, color_hex),
+		    start_date = $7,
+		    end_date = $8,
+		    pass_amount = CASE WHEN $10 THEN NULL ELSE COALESCE($9, pass_amount) END
+		WHERE id = $11 AND deleted_at IS NULL
+		RETURNING `+festColumns,
+		req.Name, req.Tagline, req.Description, req.LogoURL, req.BannerURL, req.ColorHex,
+		start.Format(dateLayout), end.Format(dateLayout), req.PassAmount, req.DisablePass, festID))
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Fest not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to update fest", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Fest updated successfully",
+		Data:    fest,
+	})
+}
+
+// DeleteFest soft-deletes a fest and releases its sub-events (admin only)
+// DELETE /api/v1/admin/fests/:id
+func (h *FestHandler) DeleteFest(c *gin.Context) {
+	festID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid fest ID"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to delete fest", err)
+		return
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		"UPDATE fests SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL", festID)
+	if err != nil {
+		internalError(c, "Failed to delete fest", err)
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Fest not found"),
+		})
+		return
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE events SET fest_id = NULL WHERE fest_id = $1", festID); err != nil {
+		internalError(c, "Failed to delete fest", err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to delete fest", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Fest deleted successfully",
+	})
+}
+
+// AttachFestEvents adds events to a fest (admin only). Existing pass holders
+// are registered for the newly added events.
+// POST /api/v1/admin/fests/:id/events
+func (h *FestHandler) AttachFestEvents(c *gin.Context) {
+	festID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid fest ID"),
+		})
+		return
+	}
+
+	var req models.AttachFestEventsRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	ids := make([]string, len(req.EventIDs))
+	for i, id := range req.EventIDs {
+		ids[i] = id.String()
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to add events", err)
+		return
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM fests WHERE id = $1 AND deleted_at IS NULL)", festID,
+	).Scan(&exists); err != nil {
+		internalError(c, "Failed to add events", err)
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Fest not found"),
+		})
+		return
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE events SET fest_id = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ANY($2::uuid[]) AND deleted_at IS NULL
+	`, festID, pq.Array(ids))
+	if err != nil {
+		internalError(c, "Failed to add events", err)
+		return
+	}
+	if n, _ := result.RowsAffected(); int(n) != len(req.EventIDs) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("One or more events were not found"),
+		})
+		return
+	}
+
+	if err := registerPassHolders(ctx, tx, festID, nil); err != nil {
+		internalError(c, "Failed to add events", err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to add events", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Events added to fest",
+	})
+}
+
+// DetachFestEvent removes an event from a fest (admin only). Registrations
+// made through a pass are kept.
+// DELETE /api/v1/admin/fests/:id/events/:event_id
+func (h *FestHandler) DetachFestEvent(c *gin.Context) {
+	festID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid fest ID"),
+		})
+		return
+	}
+	eventID, err := uuid.Parse(c.Param("event_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid event ID"),
+		})
+		return
+	}
+
+	result, err := h.db.ExecContext(c.Request.Context(),
+		"UPDATE events SET fest_id = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND fest_id = $2", eventID, festID)
+	if err != nil {
+		internalError(c, "Failed to remove event", err)
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Event is not part of this fest"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Event removed from fest",
+	})
+}
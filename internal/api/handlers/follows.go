@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// FollowHandler manages the clubs and houses students follow
+type FollowHandler struct {
+	db *database.DB
+}
+
+// NewFollowHandler creates a new follow handler
+func NewFollowHandler(db *database.DB) *FollowHandler {
+	return &FollowHandler{db: db}
+}
+
+// followTarget is a kind of thing that can be followed
+type followTarget struct {
+	name  string // Capitalized, for messages
+	kind  string // user_follows.target_type
+	table string // Table of the target, soft deleted through deleted_at
+}
+
+var (
+	followClubs  = followTarget{name: "Club", kind: models.FollowTargetClub, table: "clubs"}
+	followHouses = followTarget{name: "House", kind: models.FollowTargetHouse, table: "houses"}
+)
+
+// followerCount returns how many users follow a club or house
+func followerCount(ctx context.Context, db *sql.DB, targetType string, targetID uuid.UUID) (int, error) {
+	var n int
+	err := db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM user_follows WHERE target_type = $1 AND target_id = $2", targetType, targetID).Scan(&n)
+	return n, err
+}
+
+// notifyFollowers queues a notification to the followers of the club or
+// house that published some content, in the transaction creating it. A nil
+// targetID, for content published by neither, does nothing.
+func notifyFollowers(ctx context.Context, tx database.Execer, targetType string, targetID *uuid.UUID, p notifications.FollowedContentPublishedPayload) error {
+	if targetID == nil {
+		return nil
+	}
+	p.TargetType, p.TargetID = targetType, *targetID
+	return outbox.Write(ctx, tx, notifications.TopicFollowedContentPublished, p)
+}
+
+// follow follows the club or house in the :id param. Following it again
+// succeeds.
+func (h *FollowHandler) follow(c *gin.Context, t followTarget) {
+	userID, _ := middleware.UserID(c)
+
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid " + t.kind + " ID"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	var exists bool
+	if err := h.db.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM "+t.table+" WHERE id = $1 AND deleted_at IS NULL)", targetID).Scan(&exists); err != nil {
+		internalError(c, "Failed to follow "+t.kind, err)
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr(t.name + " not found"),
+		})
+		return
+	}
+
+	if _, err := h.db.ExecContext(ctx, `
+		INSERT INTO user_follows (user_id, target_type, target_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`, userID, t.kind, targetID); err != nil {
+		internalError(c, "Failed to follow "+t.kind, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: t.name + " followed",
+		Data:    gin.H{"following": true},
+	})
+}
+
+// unfollow stops following the club or house in the :id param. Unfollowing
+// something not followed succeeds.
+func (h *FollowHandler) unfollow(c *gin.Context, t followTarget) {
+	userID, _ := middleware.UserID(c)
+
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid " + t.kind + " ID"),
+		})
+		return
+	}
+
+	if _, err := h.db.ExecContext(c.Request.Context(),
+		"DELETE FROM user_follows WHERE user_id = $1 AND target_type = $2 AND target_id = $3",
+		userID, t.kind, targetID); err != nil {
+		internalError(c, "Failed to unfollow "+t.kind, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: t.name + " unfollowed",
+		Data:    gin.H{"following": false},
+	})
+}
+
+// FollowClub follows a club, to be notified of its new events and posts
+// POST /api/v1/clubs/:id/follow
+func (h *FollowHandler) FollowClub(c *gin.Context) {
+	h.follow(c, followClubs)
+}
+
+// UnfollowClub stops following a club
+// DELETE /api/v1/clubs/:id/follow
+func (h *FollowHandler) UnfollowClub(c *gin.Context) {
+	h.unfollow(c, followClubs)
+}
+
+// FollowHouse follows a house, to be notified of its new events and posts
+// POST /api/v1/houses/:id/follow
+func (h *FollowHandler) FollowHouse(c *gin.Context) {
+	h.follow(c, followHouses)
+}
+
+// UnfollowHouse stops following a house
+// DELETE /api/v1/houses/:id/follow
+func (h *FollowHandler) UnfollowHouse(c *gin.Context) {
+	h.unfollow(c, followHouses)
+}
+
+// ListFollows returns the clubs and houses the user follows, most recently
+// followed first. Deleted clubs and houses drop out.
+// GET /api/v1/me/follows
+func (h *FollowHandler) ListFollows(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	// Read from the primary so something just followed is listed
+	rows, err := h.db.QueryContext(c.Request.Context(), `
+		SELECT f.target_type, f.target_id, COALESCE(cl.name, ho.name), COALESCE(cl.logo_url, ho.logo_url), f.created_at
+		FROM user_follows f
+		LEFT JOIN clubs cl ON f.target_type = 'club' AND cl.id = f.target_id AND cl.deleted_at IS NULL
+		LEFT JOIN houses ho ON f.target_type = 'house' AND ho.id = f.target_id AND ho.deleted_at IS NULL
+		WHERE f.user_id = $1 AND (cl.id IS NOT NULL OR ho.id IS NOT NULL)
+		ORDER BY f.created_at DESC
+	`, userID)
+	if err != nil {
+		internalError(c, "Failed to fetch follows", err)
+		return
+	}
+	defer rows.Close()
+
+	follows := []models.Follow{}
+	for rows.Next() {
+		var f models.Follow
+		if err := rows.Scan(&f.TargetType, &f.TargetID, &f.Name, &f.LogoURL, &f.CreatedAt); err != nil {
+			internalError(c, "Failed to fetch follows", err)
+			return
+		}
+		follows = append(follows, f)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch follows", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    follows,
+	})
+}
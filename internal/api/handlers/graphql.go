@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/graphql"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// GraphQLHandler serves the read-only GraphQL API. Responses follow the
+// GraphQL over HTTP format rather than the usual API envelope.
+type GraphQLHandler struct {
+	server *handler.Server
+}
+
+func NewGraphQLHandler(db *database.DB) *GraphQLHandler {
+	return &GraphQLHandler{server: graphql.NewServer(db)}
+}
+
+// Query runs a GraphQL query as the signed-in user, if any
+// POST /api/v1/graphql
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	ctx := c.Request.Context()
+	if userID, ok := middleware.UserID(c); ok {
+		role, _ := middleware.Role(c)
+		ctx = graphql.WithViewer(ctx, graphql.Viewer{UserID: userID, Role: role})
+	}
+	h.server.ServeHTTP(c.Writer, c.Request.WithContext(ctx))
+}
+
+// Schema returns the schema in GraphQL SDL, for client code generation
+// GET /api/v1/graphql/schema
+func (h *GraphQLHandler) Schema(c *gin.Context) {
+	c.String(http.StatusOK, graphql.SDL)
+}
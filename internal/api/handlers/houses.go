@@ -2,41 +2,59 @@ package handlers
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
 	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/moderation"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
 )
 
 // HouseHandler handles house-related requests
 type HouseHandler struct {
-	DB *sql.DB
+	DB     *sql.DB
+	filter *moderation.Filter
 }
 
-// NewHouseHandler creates a new HouseHandler
-func NewHouseHandler(db *sql.DB) *HouseHandler {
-	return &HouseHandler{DB: db}
+// NewHouseHandler creates a new HouseHandler. Announcements and their
+// comments are screened by filter, which may be nil.
+func NewHouseHandler(db *sql.DB, filter *moderation.Filter) *HouseHandler {
+	return &HouseHandler{DB: db, filter: filter}
 }
 
 // ============================================================================
 // HOUSES CRUD
 // ============================================================================
 
-// GetHouses returns all houses
+// GetHouses returns all houses, ranked by points. With ?term= (a term ID or
+// "current") points are those earned in that term rather than all-time.
 func (h *HouseHandler) GetHouses(c *gin.Context) {
+	ctx := c.Request.Context()
+	termID, err := termFilter(ctx, h.DB, c.Query("term"))
+	if err != nil {
+		respondTermFilterError(c, err)
+		return
+	}
+
 	query := `
-		SELECT id, name, color, description, logo_url, points, created_at, updated_at
+		SELECT id, name, color, description, logo_url,
+		       CASE WHEN $1::uuid IS NULL THEN points
+		            ELSE (SELECT COALESCE(SUM(l.points), 0) FROM house_point_ledger l
+		                  WHERE l.house_id = houses.id AND l.term_id = $1)
+		       END AS points,
+		       created_at, updated_at
 		FROM houses
 		WHERE deleted_at IS NULL
-		ORDER BY points DESC
+		ORDER BY 6 DESC
 	`
-	rows, err := h.DB.QueryContext(c.Request.Context(), query)
+	rows, err := h.DB.QueryContext(ctx, query, termID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Error:   strPtr("Failed to fetch houses"),
-		})
+		internalError(c, "Failed to fetch houses", err)
 		return
 	}
 	defer rows.Close()
@@ -45,10 +63,15 @@ func (h *HouseHandler) GetHouses(c *gin.Context) {
 	for rows.Next() {
 		var house models.House
 		if err := rows.Scan(&house.ID, &house.Name, &house.Color, &house.Description, &house.LogoURL, &house.Points, &house.CreatedAt, &house.UpdatedAt); err != nil {
-			continue
+			internalError(c, "Failed to fetch houses", err)
+			return
 		}
 		houses = append(houses, house)
 	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch houses", err)
+		return
+	}
 
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
@@ -86,21 +109,36 @@ func (h *HouseHandler) GetHouse(c *gin.Context) {
 
 	// Fetch roles
 	roleQuery := `
-		SELECT id, house_id, member_name, role_title, display_order, created_at
+		SELECT ` + houseRoleColumns + `
 		FROM house_roles
 		WHERE house_id = $1
 		ORDER BY display_order ASC, created_at ASC
 	`
 	roleRows, err := h.DB.QueryContext(c.Request.Context(), roleQuery, houseID)
-	if err == nil {
-		defer roleRows.Close()
-		for roleRows.Next() {
-			var role models.HouseRole
-			if err := roleRows.Scan(&role.ID, &role.HouseID, &role.MemberName, &role.RoleTitle, &role.DisplayOrder, &role.CreatedAt); err == nil {
-				house.Roles = append(house.Roles, role)
-			}
+	if err != nil {
+		internalError(c, "Failed to fetch house roles", err)
+		return
+	}
+	defer roleRows.Close()
+	for roleRows.Next() {
+		role, err := scanHouseRole(roleRows)
+		if err != nil {
+			internalError(c, "Failed to fetch house roles", err)
+			return
 		}
+		house.Roles = append(house.Roles, role)
 	}
+	if err := roleRows.Err(); err != nil {
+		internalError(c, "Failed to fetch house roles", err)
+		return
+	}
+
+	followers, err := followerCount(c.Request.Context(), h.DB, models.FollowTargetHouse, house.ID)
+	if err != nil {
+		internalError(c, "Failed to fetch house", err)
+		return
+	}
+	house.FollowerCount = &followers
 
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
@@ -111,7 +149,7 @@ func (h *HouseHandler) GetHouse(c *gin.Context) {
 // CreateHouse creates a new house (admin only)
 func (h *HouseHandler) CreateHouse(c *gin.Context) {
 	var req models.CreateHouseRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Error:   strPtr("Invalid request: " + err.Error()),
@@ -151,7 +189,7 @@ func (h *HouseHandler) UpdateHouse(c *gin.Context) {
 	houseID := c.Param("id")
 
 	var req models.UpdateHouseRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Error:   strPtr("Invalid request: " + err.Error()),
@@ -234,12 +272,105 @@ func (h *HouseHandler) DeleteHouse(c *gin.Context) {
 // HOUSE ROLES
 // ============================================================================
 
-// AddHouseRole adds a role to a house
+const houseRoleColumns = `id, house_id, user_id, member_name, role_title, display_order, permissions, created_at, updated_at`
+
+func scanHouseRole(row interface{ Scan(...interface{}) error }) (models.HouseRole, error) {
+	var r models.HouseRole
+	err := row.Scan(&r.ID, &r.HouseID, &r.UserID, &r.MemberName, &r.RoleTitle, &r.DisplayOrder,
+		pq.Array(&r.Permissions), &r.CreatedAt, &r.UpdatedAt)
+	return r, err
+}
+
+// hasHousePermission reports whether the current user is an app admin or
+// is linked to one of the house's roles with permission
+func hasHousePermission(c *gin.Context, db *sql.DB, houseID uuid.UUID, permission string) (bool, error) {
+	if role, _ := middleware.Role(c); role == models.RoleAdmin {
+		return true, nil
+	}
+	userID, _ := middleware.UserID(c)
+
+	var ok bool
+	err := db.QueryRowContext(c.Request.Context(), `
+		SELECT EXISTS (
+			SELECT 1 FROM house_roles
+			WHERE house_id = $1 AND user_id = $2 AND $3 = ANY(permissions)
+		)
+	`, houseID, userID, permission).Scan(&ok)
+	return ok, err
+}
+
+// requireHousePermission aborts with 403 unless the user holds permission
+// in the house. Returns false if the request has been answered.
+func requireHousePermission(c *gin.Context, db *sql.DB, houseID uuid.UUID, permission string) bool {
+	ok, err := hasHousePermission(c, db, houseID, permission)
+	if err != nil {
+		internalError(c, "Failed to check house role", err)
+		return false
+	}
+	if !ok {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Your house role doesn't allow this"),
+		})
+		return false
+	}
+	return true
+}
+
+// parseHouseID reads the :id parameter, answering 400 if it isn't a UUID
+func parseHouseID(c *gin.Context) (uuid.UUID, bool) {
+	houseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid house ID"),
+		})
+		return uuid.Nil, false
+	}
+	return houseID, true
+}
+
+// linkedUserName returns the name of the user a role is being linked to,
+// answering 400 if there is no such user and 409 if they're alumni, who
+// belong to no house
+func linkedUserName(c *gin.Context, db *sql.DB, userID uuid.UUID) (string, bool) {
+	var name string
+	var role models.UserRole
+	err := db.QueryRowContext(c.Request.Context(),
+		"SELECT full_name, role FROM users WHERE id = $1 AND deleted_at IS NULL", userID,
+	).Scan(&name, &role)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("User not found"),
+		})
+		return "", false
+	}
+	if err != nil {
+		internalError(c, "Failed to look up user", err)
+		return "", false
+	}
+	if role == models.RoleAlumni {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Alumni can't hold house roles"),
+		})
+		return "", false
+	}
+	return name, true
+}
+
+// AddHouseRole adds a role to a house (admin only). A role linked to a user
+// gives them its permissions; its name defaults to theirs.
+// POST /api/v1/admin/houses/:id/roles
 func (h *HouseHandler) AddHouseRole(c *gin.Context) {
-	houseID := c.Param("id")
+	houseID, ok := parseHouseID(c)
+	if !ok {
+		return
+	}
 
 	var req models.CreateHouseRoleRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Error:   strPtr("Invalid request: " + err.Error()),
@@ -247,30 +378,41 @@ func (h *HouseHandler) AddHouseRole(c *gin.Context) {
 		return
 	}
 
+	if req.UserID != nil {
+		name, ok := linkedUserName(c, h.DB, *req.UserID)
+		if !ok {
+			return
+		}
+		if req.MemberName == "" {
+			req.MemberName = name
+		}
+	}
+	if req.Permissions == nil {
+		req.Permissions = []string{}
+	}
+
 	displayOrder := 0
 	if req.DisplayOrder != nil {
 		displayOrder = *req.DisplayOrder
 	}
 
-	var role models.HouseRole
-	query := `
-		INSERT INTO house_roles (house_id, member_name, role_title, display_order)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, house_id, member_name, role_title, display_order, created_at
-	`
-	err := h.DB.QueryRowContext(
-		c.Request.Context(),
-		query,
-		houseID, req.MemberName, req.RoleTitle, displayOrder,
-	).Scan(&role.ID, &role.HouseID, &role.MemberName, &role.RoleTitle, &role.DisplayOrder, &role.CreatedAt)
-
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
+	role, err := scanHouseRole(h.DB.QueryRowContext(c.Request.Context(), `
+		INSERT INTO house_roles (house_id, user_id, member_name, role_title, display_order, permissions)
+		SELECT id, $2, $3, $4, $5, $6 FROM houses WHERE id = $1 AND deleted_at IS NULL
+		RETURNING `+houseRoleColumns,
+		houseID, req.UserID, req.MemberName, req.RoleTitle, displayOrder, pq.Array(req.Permissions),
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
 			Success: false,
-			Error:   strPtr("Failed to add role: " + err.Error()),
+			Error:   strPtr("House not found"),
 		})
 		return
 	}
+	if err != nil {
+		internalError(c, "Failed to add role", err)
+		return
+	}
 
 	c.JSON(http.StatusCreated, models.APIResponse{
 		Success: true,
@@ -279,12 +421,136 @@ func (h *HouseHandler) AddHouseRole(c *gin.Context) {
 	})
 }
 
-// RemoveHouseRole removes a role from a house
+// UpdateHouseRole edits a house role, links or unlinks its user, and moves
+// it to a new position among the house's roles (admin only)
+// PUT /api/v1/admin/houses/:id/roles/:role_id
+func (h *HouseHandler) UpdateHouseRole(c *gin.Context) {
+	houseID, ok := parseHouseID(c)
+	if !ok {
+		return
+	}
+	roleID, err := uuid.Parse(c.Param("role_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid role ID"),
+		})
+		return
+	}
+
+	var req models.UpdateHouseRoleRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid request: " + err.Error()),
+		})
+		return
+	}
+	if req.UnlinkUser && req.UserID != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Give either user_id or unlink_user, not both"),
+		})
+		return
+	}
+	if req.UserID != nil {
+		if _, ok := linkedUserName(c, h.DB, *req.UserID); !ok {
+			return
+		}
+	}
+	var permissions interface{}
+	if req.Permissions != nil {
+		permissions = pq.Array(*req.Permissions)
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to update role", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Roles of a house are reordered one edit at a time; lock them so two
+	// reorders don't interleave
+	if _, err := tx.ExecContext(ctx, "SELECT 1 FROM house_roles WHERE house_id = $1 FOR UPDATE", houseID); err != nil {
+		internalError(c, "Failed to update role", err)
+		return
+	}
+
+	role, err := scanHouseRole(tx.QueryRowContext(ctx, `
+		UPDATE house_roles SET
+			user_id = CASE WHEN $3 THEN NULL ELSE COALESCE($4, user_id) END,
+			member_name = COALESCE($5, member_name),
+			role_title = COALESCE($6, role_title),
+			permissions = COALESCE($7, permissions)
+		WHERE id = $1 AND house_id = $2
+		RETURNING `+houseRoleColumns,
+		roleID, houseID, req.UnlinkUser, req.UserID, req.MemberName, req.RoleTitle, permissions,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Role not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to update role", err)
+		return
+	}
+
+	if req.DisplayOrder != nil {
+		// Number the other roles 0, 1, 2... in their current order, leaving
+		// a gap at the new position
+		if _, err := tx.ExecContext(ctx, `
+			WITH ordered AS (
+				SELECT id, ROW_NUMBER() OVER (ORDER BY display_order, created_at) - 1 AS pos
+				FROM house_roles
+				WHERE house_id = $1 AND id <> $2
+			)
+			UPDATE house_roles r
+			SET display_order = CASE WHEN o.pos >= $3 THEN o.pos + 1 ELSE o.pos END
+			FROM ordered o
+			WHERE r.id = o.id
+		`, houseID, roleID, *req.DisplayOrder); err != nil {
+			internalError(c, "Failed to reorder roles", err)
+			return
+		}
+		// Past the end means last
+		role, err = scanHouseRole(tx.QueryRowContext(ctx, `
+			UPDATE house_roles
+			SET display_order = LEAST($2, (SELECT COUNT(*) - 1 FROM house_roles WHERE house_id = $3))
+			WHERE id = $1
+			RETURNING `+houseRoleColumns,
+			roleID, *req.DisplayOrder, houseID,
+		))
+		if err != nil {
+			internalError(c, "Failed to reorder roles", err)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to update role", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Role updated successfully",
+		Data:    role,
+	})
+}
+
+// RemoveHouseRole removes a role from a house (admin only)
+// DELETE /api/v1/admin/houses/:id/roles/:role_id
 func (h *HouseHandler) RemoveHouseRole(c *gin.Context) {
+	houseID := c.Param("id")
 	roleID := c.Param("role_id")
 
-	query := `DELETE FROM house_roles WHERE id = $1`
-	result, err := h.DB.ExecContext(c.Request.Context(), query, roleID)
+	query := `DELETE FROM house_roles WHERE id = $1 AND house_id = $2`
+	result, err := h.DB.ExecContext(c.Request.Context(), query, roleID, houseID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
@@ -312,47 +578,91 @@ func (h *HouseHandler) RemoveHouseRole(c *gin.Context) {
 // HOUSE ANNOUNCEMENTS
 // ============================================================================
 
-// GetAnnouncements returns all announcements for a house
+// inlineComments is how many comments each listed announcement comes with
+const inlineComments = 3
+
+// GetAnnouncements returns all announcements for a house, each with its
+// first comments
 func (h *HouseHandler) GetAnnouncements(c *gin.Context) {
 	houseID := c.Param("id")
-	userID, _ := c.Get("user_id")
+	var viewerID *uuid.UUID
+	if id, ok := middleware.UserID(c); ok {
+		viewerID = &id
+	}
+	ctx := c.Request.Context()
 
 	query := `
 		SELECT 
 			ha.id, ha.house_id, ha.title, ha.content, ha.created_by, ha.created_at, ha.updated_at,
 			COALESCE(u.full_name, 'Unknown') as author_name,
-			(SELECT COUNT(*) FROM announcement_likes WHERE announcement_id = ha.id) as like_count,
-			(SELECT COUNT(*) FROM announcement_comments WHERE announcement_id = ha.id AND deleted_at IS NULL) as comment_count
+			ha.like_count, ha.reaction_counts, ha.comment_count,
+			(SELECT reaction FROM announcement_likes WHERE announcement_id = ha.id AND user_id = $2) as my_reaction
 		FROM house_announcements ha
 		LEFT JOIN users u ON ha.created_by = u.id
 		WHERE ha.house_id = $1 AND ha.deleted_at IS NULL
 		ORDER BY ha.created_at DESC
 	`
-	rows, err := h.DB.QueryContext(c.Request.Context(), query, houseID)
+	rows, err := h.DB.QueryContext(ctx, query, houseID, viewerID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Error:   strPtr("Failed to fetch announcements"),
-		})
+		internalError(c, "Failed to fetch announcements", err)
 		return
 	}
 	defer rows.Close()
 
 	announcements := []models.HouseAnnouncement{}
+	index := map[uuid.UUID]int{}
+	var ids []uuid.UUID
 	for rows.Next() {
 		var a models.HouseAnnouncement
-		if err := rows.Scan(&a.ID, &a.HouseID, &a.Title, &a.Content, &a.CreatedBy, &a.CreatedAt, &a.UpdatedAt, &a.AuthorName, &a.LikeCount, &a.CommentCount); err == nil {
-			announcements = append(announcements, a)
+		if err := rows.Scan(&a.ID, &a.HouseID, &a.Title, &a.Content, &a.CreatedBy, &a.CreatedAt, &a.UpdatedAt, &a.AuthorName, &a.LikeCount, &a.ReactionCounts, &a.CommentCount, &a.MyReaction); err != nil {
+			internalError(c, "Failed to fetch announcements", err)
+			return
 		}
+		a.IsLikedByMe = a.MyReaction != nil
+		index[a.ID] = len(announcements)
+		ids = append(ids, a.ID)
+		announcements = append(announcements, a)
 	}
-
-	// Check if current user liked each announcement
-	if userID != nil {
-		for i := range announcements {
-			var count int
-			likeQuery := `SELECT COUNT(*) FROM announcement_likes WHERE announcement_id = $1 AND user_id = $2`
-			h.DB.QueryRowContext(c.Request.Context(), likeQuery, announcements[i].ID, userID).Scan(&count)
-			announcements[i].IsLikedByMe = count > 0
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch announcements", err)
+		return
+	}
+	rows.Close()
+
+	if len(ids) > 0 {
+		// The first few comments of every announcement, in one query
+		commentRows, err := h.DB.QueryContext(ctx, `
+			SELECT `+commentColumns+`
+			FROM unnest($1::uuid[]) AS a(id)
+			CROSS JOIN LATERAL (
+				SELECT ac.*
+				FROM announcement_comments ac
+				WHERE ac.announcement_id = a.id AND ac.deleted_at IS NULL
+				  AND `+moderation.NotBlockedBy("$2", "ac.user_id")+`
+				  AND `+moderation.VisibleTo("$2", "ac.user_id")+`
+				ORDER BY ac.created_at ASC, ac.id
+				LIMIT $3
+			) ac
+			LEFT JOIN users u ON ac.user_id = u.id
+			ORDER BY ac.created_at ASC, ac.id
+		`, pq.Array(ids), viewerID, inlineComments)
+		if err != nil {
+			internalError(c, "Failed to fetch comments", err)
+			return
+		}
+		defer commentRows.Close()
+		for commentRows.Next() {
+			cm, err := scanComment(commentRows)
+			if err != nil {
+				internalError(c, "Failed to fetch comments", err)
+				return
+			}
+			a := &announcements[index[cm.AnnouncementID]]
+			a.Comments = append(a.Comments, cm)
+		}
+		if err := commentRows.Err(); err != nil {
+			internalError(c, "Failed to fetch comments", err)
+			return
 		}
 	}
 
@@ -362,13 +672,21 @@ func (h *HouseHandler) GetAnnouncements(c *gin.Context) {
 	})
 }
 
-// CreateAnnouncement creates a new announcement (admin only)
+// CreateAnnouncement creates a new announcement, by an admin or a house
+// role with the post_announcements permission, such as the house captain
+// POST /api/v1/houses/:id/announcements
 func (h *HouseHandler) CreateAnnouncement(c *gin.Context) {
-	houseID := c.Param("id")
-	userID, _ := c.Get("user_id")
+	houseID, ok := parseHouseID(c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.UserID(c)
+	if !requireHousePermission(c, h.DB, houseID, models.HousePermissionPostAnnouncements) {
+		return
+	}
 
 	var req models.CreateHouseAnnouncementRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Error:   strPtr("Invalid request: " + err.Error()),
@@ -376,14 +694,27 @@ func (h *HouseHandler) CreateAnnouncement(c *gin.Context) {
 		return
 	}
 
+	screened, ok := screenContent(c, h.filter, "announcement", req.Title, req.Content)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to create announcement", err)
+		return
+	}
+	defer tx.Rollback()
+
 	var announcement models.HouseAnnouncement
 	query := `
 		INSERT INTO house_announcements (house_id, title, content, created_by)
 		VALUES ($1, $2, $3, $4)
 		RETURNING id, house_id, title, content, created_by, created_at, updated_at
 	`
-	err := h.DB.QueryRowContext(
-		c.Request.Context(),
+	err = tx.QueryRowContext(
+		ctx,
 		query,
 		houseID, req.Title, req.Content, userID,
 	).Scan(&announcement.ID, &announcement.HouseID, &announcement.Title, &announcement.Content, &announcement.CreatedBy, &announcement.CreatedAt, &announcement.UpdatedAt)
@@ -396,6 +727,17 @@ func (h *HouseHandler) CreateAnnouncement(c *gin.Context) {
 		return
 	}
 
+	if screened.Verdict == moderation.Flag {
+		if err := moderation.RecordFlag(ctx, tx, moderation.ContentAnnouncement, announcement.ID, userID, screened.Reasons); err != nil {
+			internalError(c, "Failed to create announcement", err)
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to create announcement", err)
+		return
+	}
+
 	c.JSON(http.StatusCreated, models.APIResponse{
 		Success: true,
 		Message: "Announcement created successfully",
@@ -403,10 +745,11 @@ func (h *HouseHandler) CreateAnnouncement(c *gin.Context) {
 	})
 }
 
-// LikeAnnouncement toggles like on an announcement
+// LikeAnnouncement removes the user's reaction to an announcement if they
+// have one, and likes it otherwise
 func (h *HouseHandler) LikeAnnouncement(c *gin.Context) {
 	announcementID := c.Param("id")
-	userID, exists := c.Get("user_id")
+	userID, exists := middleware.UserID(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, models.APIResponse{
 			Success: false,
@@ -431,6 +774,24 @@ func (h *HouseHandler) LikeAnnouncement(c *gin.Context) {
 		})
 	} else {
 		// Like
+		var ownerID *uuid.UUID
+		err := h.DB.QueryRowContext(c.Request.Context(),
+			`SELECT created_by FROM house_announcements WHERE id = $1 AND deleted_at IS NULL`, announcementID).Scan(&ownerID)
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Announcement not found"),
+			})
+			return
+		}
+		if err != nil {
+			internalError(c, "Failed to like announcement", err)
+			return
+		}
+		if !requireCanInteract(c, h.DB, userID, ownerID, false) {
+			return
+		}
+
 		insertQuery := `INSERT INTO announcement_likes (announcement_id, user_id) VALUES ($1, $2)`
 		h.DB.ExecContext(c.Request.Context(), insertQuery, announcementID, userID)
 		c.JSON(http.StatusOK, models.APIResponse{
@@ -441,48 +802,99 @@ func (h *HouseHandler) LikeAnnouncement(c *gin.Context) {
 	}
 }
 
-// GetComments returns comments for an announcement
+// commentColumns selects an announcement comment (ac) with its author (u)
+const commentColumns = `ac.id, ac.announcement_id, ac.user_id, ac.content, ac.created_at, ac.updated_at, ac.edited_at,
+	COALESCE(u.full_name, 'Unknown') as user_name,
+	COALESCE(u.avatar_url, '') as avatar_url`
+
+func scanComment(row interface{ Scan(...interface{}) error }) (models.AnnouncementComment, error) {
+	var cm models.AnnouncementComment
+	err := row.Scan(&cm.ID, &cm.AnnouncementID, &cm.UserID, &cm.Content, &cm.CreatedAt, &cm.UpdatedAt, &cm.EditedAt, &cm.UserName, &cm.AvatarURL)
+	return cm, err
+}
+
+// GetComments returns a page of comments for an announcement, oldest first,
+// leaving out those by users the viewer has blocked and, unless they are the
+// viewer, shadow banned users
+// GET /api/v1/announcements/:id/comments
 func (h *HouseHandler) GetComments(c *gin.Context) {
 	announcementID := c.Param("id")
+	var viewerID *uuid.UUID
+	if id, ok := middleware.UserID(c); ok {
+		viewerID = &id
+	}
 
-	query := `
-		SELECT 
-			ac.id, ac.announcement_id, ac.user_id, ac.content, ac.created_at, ac.updated_at,
-			COALESCE(u.full_name, 'Unknown') as user_name,
-			COALESCE(u.avatar_url, '') as avatar_url
-		FROM announcement_comments ac
-		LEFT JOIN users u ON ac.user_id = u.id
-		WHERE ac.announcement_id = $1 AND ac.deleted_at IS NULL
-		ORDER BY ac.created_at ASC
-	`
-	rows, err := h.DB.QueryContext(c.Request.Context(), query, announcementID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
+	var query models.ListCommentsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
-			Error:   strPtr("Failed to fetch comments"),
+			Error:   strPtr("Invalid query parameters"),
 		})
 		return
 	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 || query.PageSize > 100 {
+		query.PageSize = 20
+	}
+
+	ctx := c.Request.Context()
+	where := `
+		WHERE ac.announcement_id = $1 AND ac.deleted_at IS NULL
+		  AND ` + moderation.NotBlockedBy("$2", "ac.user_id") + `
+		  AND ` + moderation.VisibleTo("$2", "ac.user_id")
+
+	var total int
+	if err := h.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM announcement_comments ac`+where,
+		announcementID, viewerID).Scan(&total); err != nil {
+		internalError(c, "Failed to fetch comments", err)
+		return
+	}
+
+	rows, err := h.DB.QueryContext(ctx, `
+		SELECT `+commentColumns+`
+		FROM announcement_comments ac
+		LEFT JOIN users u ON ac.user_id = u.id`+where+`
+		ORDER BY ac.created_at ASC, ac.id
+		LIMIT $3 OFFSET $4
+	`, announcementID, viewerID, query.PageSize, (query.Page-1)*query.PageSize)
+	if err != nil {
+		internalError(c, "Failed to fetch comments", err)
+		return
+	}
 	defer rows.Close()
 
 	comments := []models.AnnouncementComment{}
 	for rows.Next() {
-		var cm models.AnnouncementComment
-		if err := rows.Scan(&cm.ID, &cm.AnnouncementID, &cm.UserID, &cm.Content, &cm.CreatedAt, &cm.UpdatedAt, &cm.UserName, &cm.AvatarURL); err == nil {
-			comments = append(comments, cm)
+		cm, err := scanComment(rows)
+		if err != nil {
+			internalError(c, "Failed to fetch comments", err)
+			return
 		}
+		comments = append(comments, cm)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch comments", err)
+		return
 	}
 
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
-		Data:    comments,
+		Data: models.PaginatedResponse{
+			Data:       comments,
+			Page:       query.Page,
+			PageSize:   query.PageSize,
+			TotalItems: int64(total),
+			TotalPages: (total + query.PageSize - 1) / query.PageSize,
+		},
 	})
 }
 
 // AddComment adds a comment to an announcement
 func (h *HouseHandler) AddComment(c *gin.Context) {
 	announcementID := c.Param("id")
-	userID, exists := c.Get("user_id")
+	userID, exists := middleware.UserID(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, models.APIResponse{
 			Success: false,
@@ -492,7 +904,7 @@ func (h *HouseHandler) AddComment(c *gin.Context) {
 	}
 
 	var req models.CreateCommentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Error:   strPtr("Invalid request: " + err.Error()),
@@ -500,14 +912,44 @@ func (h *HouseHandler) AddComment(c *gin.Context) {
 		return
 	}
 
+	var ownerID *uuid.UUID
+	err := h.DB.QueryRowContext(c.Request.Context(),
+		`SELECT created_by FROM house_announcements WHERE id = $1 AND deleted_at IS NULL`, announcementID).Scan(&ownerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Announcement not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to add comment", err)
+		return
+	}
+	if !requireCanInteract(c, h.DB, userID, ownerID, true) {
+		return
+	}
+	screened, ok := screenContent(c, h.filter, "comment", req.Content)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to add comment", err)
+		return
+	}
+	defer tx.Rollback()
+
 	var comment models.AnnouncementComment
 	query := `
 		INSERT INTO announcement_comments (announcement_id, user_id, content)
 		VALUES ($1, $2, $3)
 		RETURNING id, announcement_id, user_id, content, created_at, updated_at
 	`
-	err := h.DB.QueryRowContext(
-		c.Request.Context(),
+	err = tx.QueryRowContext(
+		ctx,
 		query,
 		announcementID, userID, req.Content,
 	).Scan(&comment.ID, &comment.AnnouncementID, &comment.UserID, &comment.Content, &comment.CreatedAt, &comment.UpdatedAt)
@@ -520,6 +962,17 @@ func (h *HouseHandler) AddComment(c *gin.Context) {
 		return
 	}
 
+	if screened.Verdict == moderation.Flag {
+		if err := moderation.RecordFlag(ctx, tx, moderation.ContentAnnouncementComment, comment.ID, userID, screened.Reasons); err != nil {
+			internalError(c, "Failed to add comment", err)
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to add comment", err)
+		return
+	}
+
 	// Get user info
 	userQuery := `SELECT full_name, COALESCE(avatar_url, '') FROM users WHERE id = $1`
 	h.DB.QueryRowContext(c.Request.Context(), userQuery, userID).Scan(&comment.UserName, &comment.AvatarURL)
@@ -538,7 +991,7 @@ func (h *HouseHandler) AddComment(c *gin.Context) {
 // GetHouseEvents returns all events for a house
 func (h *HouseHandler) GetHouseEvents(c *gin.Context) {
 	houseID := c.Param("id")
-	userID, _ := c.Get("user_id")
+	userID, authenticated := middleware.UserID(c)
 
 	query := `
 		SELECT 
@@ -552,10 +1005,7 @@ func (h *HouseHandler) GetHouseEvents(c *gin.Context) {
 	`
 	rows, err := h.DB.QueryContext(c.Request.Context(), query, houseID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Error:   strPtr("Failed to fetch events: " + err.Error()),
-		})
+		internalError(c, "Failed to fetch events", err)
 		return
 	}
 	defer rows.Close()
@@ -563,13 +1013,19 @@ func (h *HouseHandler) GetHouseEvents(c *gin.Context) {
 	events := []models.HouseEvent{}
 	for rows.Next() {
 		var e models.HouseEvent
-		if err := rows.Scan(&e.ID, &e.HouseID, &e.Title, &e.Description, &e.EventDate, &e.StartTime, &e.EndTime, &e.Venue, &e.MaxParticipants, &e.RegistrationDeadline, &e.Status, &e.CreatedBy, &e.CreatedAt, &e.UpdatedAt, &e.EnrollmentCount); err == nil {
-			events = append(events, e)
+		if err := rows.Scan(&e.ID, &e.HouseID, &e.Title, &e.Description, &e.EventDate, &e.StartTime, &e.EndTime, &e.Venue, &e.MaxParticipants, &e.RegistrationDeadline, &e.Status, &e.CreatedBy, &e.CreatedAt, &e.UpdatedAt, &e.EnrollmentCount); err != nil {
+			internalError(c, "Failed to fetch events", err)
+			return
 		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch events", err)
+		return
 	}
 
 	// Check if current user is enrolled in each event
-	if userID != nil {
+	if authenticated {
 		for i := range events {
 			var count int
 			enrollQuery := `SELECT COUNT(*) FROM house_event_enrollments WHERE event_id = $1 AND user_id = $2`
@@ -584,13 +1040,21 @@ func (h *HouseHandler) GetHouseEvents(c *gin.Context) {
 	})
 }
 
-// CreateHouseEvent creates a new house event (admin only)
+// CreateHouseEvent creates a new house event, by an admin or a house role
+// with the create_events permission
+// POST /api/v1/houses/:id/events
 func (h *HouseHandler) CreateHouseEvent(c *gin.Context) {
-	houseID := c.Param("id")
-	userID, _ := c.Get("user_id")
+	houseID, ok := parseHouseID(c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.UserID(c)
+	if !requireHousePermission(c, h.DB, houseID, models.HousePermissionCreateEvents) {
+		return
+	}
 
 	var req models.CreateHouseEventRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Error:   strPtr("Invalid request: " + err.Error()),
@@ -623,8 +1087,17 @@ func (h *HouseHandler) CreateHouseEvent(c *gin.Context) {
 		VALUES ($1, $2, $3, $4, $5::time, $6::time, $7, $8, $9, $10)
 		RETURNING id, house_id, title, description, event_date, start_time::text, end_time::text, venue, max_participants, registration_deadline, status, created_by, created_at, updated_at
 	`
-	err = h.DB.QueryRowContext(
-		c.Request.Context(),
+	// Follower notifications are queued in the same transaction as the event
+	ctx := c.Request.Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to create event", err)
+		return
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(
+		ctx,
 		query,
 		houseID, req.Title, req.Description, eventDate, req.StartTime, req.EndTime, req.Venue, req.MaxParticipants, regDeadline, userID,
 	).Scan(&event.ID, &event.HouseID, &event.Title, &event.Description, &event.EventDate, &event.StartTime, &event.EndTime, &event.Venue, &event.MaxParticipants, &event.RegistrationDeadline, &event.Status, &event.CreatedBy, &event.CreatedAt, &event.UpdatedAt)
@@ -637,6 +1110,20 @@ func (h *HouseHandler) CreateHouseEvent(c *gin.Context) {
 		return
 	}
 
+	if err := notifyFollowers(ctx, tx, models.FollowTargetHouse, &event.HouseID, notifications.FollowedContentPublishedPayload{
+		ContentType: notifications.FollowedHouseEvent,
+		ContentID:   event.ID,
+		Title:       event.Title,
+		AuthorID:    event.CreatedBy,
+	}); err != nil {
+		internalError(c, "Failed to create event", err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to create event", err)
+		return
+	}
+
 	c.JSON(http.StatusCreated, models.APIResponse{
 		Success: true,
 		Message: "Event created successfully",
@@ -644,10 +1131,11 @@ func (h *HouseHandler) CreateHouseEvent(c *gin.Context) {
 	})
 }
 
-// EnrollInEvent enrolls user in a house event
+// EnrollInEvent enrolls user in a house event. Alumni, who belong to no
+// house, can't enroll.
 func (h *HouseHandler) EnrollInEvent(c *gin.Context) {
 	eventID := c.Param("event_id")
-	userID, exists := c.Get("user_id")
+	userID, exists := middleware.UserID(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, models.APIResponse{
 			Success: false,
@@ -655,6 +1143,13 @@ func (h *HouseHandler) EnrollInEvent(c *gin.Context) {
 		})
 		return
 	}
+	if role, _ := middleware.Role(c); role == models.RoleAlumni {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Alumni can't enroll in house events"),
+		})
+		return
+	}
 
 	// Check if already enrolled
 	var count int
@@ -669,11 +1164,23 @@ func (h *HouseHandler) EnrollInEvent(c *gin.Context) {
 		return
 	}
 
-	// Check event capacity
+	// Check event capacity and that enrollment is still open: until the
+	// end of its registration deadline's day
 	var maxParticipants sql.NullInt64
 	var enrollmentCount int
-	capacityQuery := `SELECT max_participants, (SELECT COUNT(*) FROM house_event_enrollments WHERE event_id = $1) FROM house_events WHERE id = $1`
-	h.DB.QueryRowContext(c.Request.Context(), capacityQuery, eventID).Scan(&maxParticipants, &enrollmentCount)
+	var closed bool
+	capacityQuery := `SELECT max_participants, (SELECT COUNT(*) FROM house_event_enrollments WHERE event_id = $1),
+		COALESCE(registration_deadline < CURRENT_DATE, false)
+		FROM house_events WHERE id = $1`
+	h.DB.QueryRowContext(c.Request.Context(), capacityQuery, eventID).Scan(&maxParticipants, &enrollmentCount, &closed)
+
+	if closed {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Enrollment for this event has closed"),
+		})
+		return
+	}
 
 	if maxParticipants.Valid && enrollmentCount >= int(maxParticipants.Int64) {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
@@ -703,7 +1210,7 @@ func (h *HouseHandler) EnrollInEvent(c *gin.Context) {
 // UnenrollFromEvent removes user enrollment from a house event
 func (h *HouseHandler) UnenrollFromEvent(c *gin.Context) {
 	eventID := c.Param("event_id")
-	userID, exists := c.Get("user_id")
+	userID, exists := middleware.UserID(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, models.APIResponse{
 			Success: false,
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/auth"
+)
+
+// TestHousePostingRequiresHousePermission verifies only admins and users
+// linked to a house role with the permission, such as the house captain,
+// can post a house's announcements and events.
+func TestHousePostingRequiresHousePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authService := auth.NewService("test-secret", 1, 1)
+	houseID := uuid.New()
+
+	permitted := func(ok bool) fakeResult {
+		return fakeResult{columns: []string{"exists"}, rows: [][]driver.Value{{ok}}}
+	}
+	announcement := `{"title": "House meeting", "content": "Friday at 5 in the quad"}`
+	event := `{"title": "Relay", "event_date": "2026-11-20"}`
+
+	tests := []struct {
+		name    string
+		role    models.UserRole
+		result  fakeResult // Whether the user's house role has the permission
+		path    string
+		body    string
+		allowed bool
+	}{
+		{
+			name: "student without a house role posts an announcement", role: models.RoleStudent, result: permitted(false),
+			path: "/houses/" + houseID.String() + "/announcements", body: announcement,
+		},
+		{
+			name: "captain without create_events creates an event", role: models.RoleStudent, result: permitted(false),
+			path: "/houses/" + houseID.String() + "/events", body: event,
+		},
+		{
+			name: "captain posts an announcement", role: models.RoleStudent, result: permitted(true),
+			path: "/houses/" + houseID.String() + "/announcements", body: announcement,
+			allowed: true,
+		},
+		{
+			name: "admin creates an event", role: models.RoleAdmin, result: permitted(false),
+			path: "/houses/" + houseID.String() + "/events", body: event,
+			allowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &HouseHandler{DB: openFakeDB(t, tt.result)}
+			router := gin.New()
+			router.Use(middleware.AuthMiddleware(authService))
+			router.POST("/houses/:id/announcements", h.CreateAnnouncement)
+			router.POST("/houses/:id/events", h.CreateHouseEvent)
+
+			token, err := authService.GenerateAccessToken(&models.User{
+				ID: uuid.New(), Email: "user@college.edu", Role: tt.role,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// The fake database can't complete the write; getting past the
+			// check is what counts
+			if tt.allowed {
+				if w.Code == http.StatusForbidden {
+					t.Errorf("status = 403, want the post attempted; body: %s", w.Body.String())
+				}
+				return
+			}
+			if w.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want 403; body: %s", w.Code, w.Body.String())
+			}
+			for _, q := range fakeQueriesRun(t) {
+				if strings.Contains(q, "INSERT") {
+					t.Error("the post was attempted")
+				}
+			}
+		})
+	}
+}
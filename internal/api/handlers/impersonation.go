@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/audit"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/auth"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// defaultImpersonationDuration is the session length when none is requested
+const defaultImpersonationDuration = 15 * time.Minute
+
+// ImpersonationHandler lets support staff view the app as another user and
+// exposes the audit log that records them doing so
+type ImpersonationHandler struct {
+	db          *database.DB
+	authService *auth.Service
+}
+
+// NewImpersonationHandler creates a new impersonation handler
+func NewImpersonationHandler(db *database.DB, authService *auth.Service) *ImpersonationHandler {
+	return &ImpersonationHandler{db: db, authService: authService}
+}
+
+// StartImpersonation issues a short-lived token for acting as a user.
+// Sessions are read-only unless allow_writes is set, and can be limited
+// further to scopes. Admins cannot be impersonated, so an impersonation
+// token never reaches admin routes.
+// POST /api/v1/admin/impersonate/:user_id
+func (h *ImpersonationHandler) StartImpersonation(c *gin.Context) {
+	adminID, _ := middleware.UserID(c)
+
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid user ID"),
+		})
+		return
+	}
+	if userID == adminID {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Cannot impersonate yourself"),
+		})
+		return
+	}
+
+	var req models.StartImpersonationRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	duration := defaultImpersonationDuration
+	if req.DurationMinutes != 0 {
+		duration = time.Duration(req.DurationMinutes) * time.Minute
+		if duration < time.Minute || duration > auth.MaxImpersonationDuration {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("duration_minutes must be between 1 and 60"),
+			})
+			return
+		}
+	}
+	var scope string
+	if len(req.Scopes) > 0 {
+		if scope, err = auth.NormalizeScope(req.Scopes); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr(err.Error()),
+			})
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	var user models.User
+	err = h.db.QueryRowContext(ctx, `
+		SELECT id, email, full_name, role, avatar_url, department, year, created_at, updated_at
+		FROM users
+		WHERE id = $1 AND deleted_at IS NULL
+	`, userID).Scan(
+		&user.ID, &user.Email, &user.FullName, &user.Role,
+		&user.AvatarURL, &user.Department, &user.Year, &user.CreatedAt, &user.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("User not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to start impersonation", err)
+		return
+	}
+	if auth.IsAdmin(user.Role) {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Admins cannot be impersonated"),
+		})
+		return
+	}
+
+	imp := auth.Impersonation{
+		SessionID: uuid.New(),
+		AdminID:   adminID,
+		ReadOnly:  !req.AllowWrites,
+	}
+	token, expiresAt, err := h.authService.GenerateImpersonationToken(&user, imp, scope, duration)
+	if err != nil {
+		internalError(c, "Failed to start impersonation", err)
+		return
+	}
+
+	// The session is only usable once it is on record
+	if err := audit.Record(ctx, h.db, audit.Entry{
+		Action:        audit.ActionImpersonationStart,
+		ActorID:       &adminID,
+		SubjectUserID: &user.ID,
+		SessionID:     &imp.SessionID,
+		Method:        c.Request.Method,
+		Path:          c.Request.URL.RequestURI(),
+		Status:        http.StatusCreated,
+		RequestID:     middleware.GetRequestID(c),
+		IPAddress:     c.ClientIP(),
+		Details: map[string]interface{}{
+			"reason":     req.Reason,
+			"read_only":  imp.ReadOnly,
+			"scope":      scope,
+			"expires_at": expiresAt,
+		},
+	}); err != nil {
+		internalError(c, "Failed to start impersonation", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Impersonation session started",
+		Data: models.ImpersonationResponse{
+			AccessToken: token,
+			ExpiresAt:   expiresAt,
+			SessionID:   imp.SessionID,
+			ReadOnly:    imp.ReadOnly,
+			Scope:       scope,
+			User:        user,
+		},
+	})
+}
+
+// ListAuditLog returns audit log entries, newest first, optionally filtered
+// by action, actor, subject user or impersonation session
+// GET /api/v1/admin/audit-log
+func (h *ImpersonationHandler) ListAuditLog(c *gin.Context) {
+	var query models.ListAuditLogQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid query parameters"),
+		})
+		return
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 || query.PageSize > 100 {
+		query.PageSize = 50
+	}
+
+	var filters [3]*uuid.UUID
+	for i, s := range []string{query.ActorID, query.SubjectUserID, query.SessionID} {
+		if s == "" {
+			continue
+		}
+		id, err := uuid.Parse(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Invalid ID filter"),
+			})
+			return
+		}
+		filters[i] = &id
+	}
+
+	ctx := c.Request.Context()
+	db := h.db.Reader()
+	const where = `
+		WHERE ($1 = '' OR action = $1)
+		  AND ($2::uuid IS NULL OR actor_id = $2)
+		  AND ($3::uuid IS NULL OR subject_user_id = $3)
+		  AND ($4::uuid IS NULL OR session_id = $4)`
+	args := []interface{}{query.Action, filters[0], filters[1], filters[2]}
+
+	var total int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM audit_log"+where, args...).Scan(&total); err != nil {
+		internalError(c, "Failed to fetch audit log", err)
+		return
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, action, actor_id, subject_user_id, session_id, method, path, status,
+		       request_id, ip_address, details, created_at
+		FROM audit_log`+where+`
+		ORDER BY created_at DESC
+		LIMIT $5 OFFSET $6
+	`, append(args, query.PageSize, (query.Page-1)*query.PageSize)...)
+	if err != nil {
+		internalError(c, "Failed to fetch audit log", err)
+		return
+	}
+	defer rows.Close()
+
+	entries := []models.AuditLogEntry{}
+	for rows.Next() {
+		var e models.AuditLogEntry
+		var details []byte
+		if err := rows.Scan(&e.ID, &e.Action, &e.ActorID, &e.SubjectUserID, &e.SessionID, &e.Method,
+			&e.Path, &e.Status, &e.RequestID, &e.IPAddress, &details, &e.CreatedAt); err != nil {
+			internalError(c, "Failed to fetch audit log", err)
+			return
+		}
+		e.Details = details
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch audit log", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: models.PaginatedResponse{
+			Data:       entries,
+			Page:       query.Page,
+			PageSize:   query.PageSize,
+			TotalItems: int64(total),
+			TotalPages: (total + query.PageSize - 1) / query.PageSize,
+		},
+	})
+}
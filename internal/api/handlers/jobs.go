@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/jobs"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+// JobHandler exposes the background job queue to admins
+type JobHandler struct {
+	queue *jobs.Queue
+}
+
+// NewJobHandler creates a new job handler
+func NewJobHandler(queue *jobs.Queue) *JobHandler {
+	return &JobHandler{queue: queue}
+}
+
+// ListJobs lists jobs with optional status/type filters
+// GET /api/v1/admin/jobs
+func (h *JobHandler) ListJobs(c *gin.Context) {
+	var query models.ListJobsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid query parameters"),
+		})
+		return
+	}
+	h.respondWithJobs(c, query)
+}
+
+// ListDeadJobs lists jobs that exhausted their retries
+// GET /api/v1/admin/jobs/dead
+func (h *JobHandler) ListDeadJobs(c *gin.Context) {
+	var query models.ListJobsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid query parameters"),
+		})
+		return
+	}
+	dead := string(models.JobStatusDead)
+	query.Status = &dead
+	h.respondWithJobs(c, query)
+}
+
+func (h *JobHandler) respondWithJobs(c *gin.Context, query models.ListJobsQuery) {
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 || query.PageSize > 100 {
+		query.PageSize = 50
+	}
+
+	jobList, total, err := h.queue.List(c.Request.Context(), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Failed to fetch jobs"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: models.PaginatedResponse{
+			Data:       jobList,
+			Page:       query.Page,
+			PageSize:   query.PageSize,
+			TotalItems: int64(total),
+			TotalPages: (total + query.PageSize - 1) / query.PageSize,
+		},
+	})
+}
+
+// GetJobStats returns queue counts by status and type
+// GET /api/v1/admin/jobs/stats
+func (h *JobHandler) GetJobStats(c *gin.Context) {
+	stats, err := h.queue.Stats(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Failed to fetch job stats"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    stats,
+	})
+}
+
+// RetryJob re-queues a dead job
+// POST /api/v1/admin/jobs/:id/retry
+func (h *JobHandler) RetryJob(c *gin.Context) {
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid job ID"),
+		})
+		return
+	}
+
+	ok, err := h.queue.Retry(c.Request.Context(), jobID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Failed to retry job"),
+		})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Dead job not found"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Job re-queued",
+	})
+}
+
+// DiscardJob removes a job from the dead-letter list
+// DELETE /api/v1/admin/jobs/:id
+func (h *JobHandler) DiscardJob(c *gin.Context) {
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid job ID"),
+		})
+		return
+	}
+
+	ok, err := h.queue.Discard(c.Request.Context(), jobID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Failed to discard job"),
+		})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Dead job not found"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Job discarded",
+	})
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// fakeResult is what every query on a fake connection returns
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+	err     error // Returned from Next after the rows instead of io.EOF
+}
+
+// fakeDriver serves canned results, keyed by DSN, and records the queries
+// run
+type fakeDriver struct{}
+
+var (
+	fakeResultsMu sync.Mutex
+	fakeResults   = map[string]fakeResult{}
+	fakeQueries   = map[string][]string{}
+)
+
+func init() {
+	sql.Register("handlers-fake", fakeDriver{})
+}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fakeResultsMu.Lock()
+	defer fakeResultsMu.Unlock()
+	return &fakeConn{name: name, result: fakeResults[name]}, nil
+}
+
+type fakeConn struct {
+	name   string
+	result fakeResult
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("transactions not supported") }
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	fakeResultsMu.Lock()
+	fakeQueries[c.name] = append(fakeQueries[c.name], query)
+	fakeResultsMu.Unlock()
+	return &fakeRows{result: c.result}, nil
+}
+
+// ExecContext records the statement, then fails it: the fake database has
+// nothing to write to
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	fakeResultsMu.Lock()
+	fakeQueries[c.name] = append(fakeQueries[c.name], query)
+	fakeResultsMu.Unlock()
+	return nil, errors.New("exec not supported")
+}
+
+type fakeRows struct {
+	result fakeResult
+	pos    int
+}
+
+func (r *fakeRows) Columns() []string { return r.result.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.result.rows) {
+		if r.result.err != nil {
+			return r.result.err
+		}
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+func openFakeDB(t *testing.T, result fakeResult) *sql.DB {
+	t.Helper()
+	fakeResultsMu.Lock()
+	fakeResults[t.Name()] = result
+	delete(fakeQueries, t.Name())
+	fakeResultsMu.Unlock()
+
+	db, err := sql.Open("handlers-fake", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// fakeQueriesRun returns the queries run on the test's fake database
+func fakeQueriesRun(t *testing.T) []string {
+	fakeResultsMu.Lock()
+	defer fakeResultsMu.Unlock()
+	return append([]string(nil), fakeQueries[t.Name()]...)
+}
+
+var (
+	houseColumns = []string{"id", "name", "color", "description", "logo_url", "points", "created_at", "updated_at"}
+	deptColumns  = []string{"id", "code", "name", "description", "logo_url", "icon_name", "color_hex",
+		"total_members", "total_clubs", "total_events", "created_at", "updated_at"}
+)
+
+func TestListHandlersSurfaceRowErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		path    string
+		result  fakeResult
+		handler func(*sql.DB) gin.HandlerFunc
+	}{
+		{
+			name: "houses scan mismatch",
+			path: "/houses",
+			result: fakeResult{
+				columns: houseColumns,
+				rows: [][]driver.Value{
+					{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "Red", nil, nil, nil, "not-a-number", now, now},
+				},
+			},
+			handler: func(db *sql.DB) gin.HandlerFunc { return NewHouseHandler(db, nil).GetHouses },
+		},
+		{
+			name: "houses iteration error",
+			path: "/houses",
+			result: fakeResult{
+				columns: houseColumns,
+				err:     errors.New("connection reset"),
+			},
+			handler: func(db *sql.DB) gin.HandlerFunc { return NewHouseHandler(db, nil).GetHouses },
+		},
+		{
+			name: "schedules column count mismatch",
+			path: "/schedules?date=2025-01-15",
+			result: fakeResult{
+				columns: []string{"id", "title"},
+				rows:    [][]driver.Value{{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "Orientation"}},
+			},
+			handler: func(db *sql.DB) gin.HandlerFunc {
+				return NewScheduleHandler(&database.DB{DB: db}).ListSchedules
+			},
+		},
+		{
+			name: "departments scan mismatch",
+			path: "/departments",
+			result: fakeResult{
+				columns: deptColumns,
+				rows: [][]driver.Value{
+					{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "CSE", "Computer Science", nil, nil, nil, nil,
+						"many", 0, 0, now, now},
+				},
+			},
+			handler: func(db *sql.DB) gin.HandlerFunc { return (&DepartmentHandler{DB: db}).GetDepartments },
+		},
+		{
+			name: "departments iteration error",
+			path: "/departments",
+			result: fakeResult{
+				columns: deptColumns,
+				err:     errors.New("connection reset"),
+			},
+			handler: func(db *sql.DB) gin.HandlerFunc { return (&DepartmentHandler{DB: db}).GetDepartments },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openFakeDB(t, tt.result)
+
+			router := gin.New()
+			router.Use(middleware.RequestIDMiddleware())
+			route, _, _ := strings.Cut(tt.path, "?")
+			router.GET(route, tt.handler(db))
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set(middleware.RequestIDHeader, "req-123")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusInternalServerError {
+				t.Fatalf("status = %d, want 500; body: %s", w.Code, w.Body.String())
+			}
+
+			var body struct {
+				RequestID string `json:"request_id"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.RequestID != "req-123" {
+				t.Errorf("request_id = %q, want %q", body.RequestID, "req-123")
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository"
+)
+
+// counterStreamPoll is how often live counter streams re-read the counts.
+// Like update streams they poll the database, so they work across API
+// instances and for clients that cannot hold a WebSocket.
+var counterStreamPoll = 2 * time.Second
+
+// StreamPostCounters streams a post's like, comment, share and view counts
+// as server-sent events
+// GET /api/v1/posts/:id/counters/stream
+func (h *PostsHandler) StreamPostCounters(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid post ID"),
+		})
+		return
+	}
+
+	q := repository.New(h.db.Reader())
+	streamCounters(c, "post not found", func(ctx context.Context) (interface{}, error) {
+		return q.GetPostCounters(ctx, postID)
+	})
+}
+
+// StreamStoryCounters streams an active story's like and view counts as
+// server-sent events until it expires
+// GET /api/v1/stories/:id/counters/stream
+func (h *StoriesHandler) StreamStoryCounters(c *gin.Context) {
+	storyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid story ID"),
+		})
+		return
+	}
+
+	q := repository.New(h.db)
+	streamCounters(c, "story not found", func(ctx context.Context) (interface{}, error) {
+		return q.GetStoryCounters(ctx, storyID, time.Now())
+	})
+}
+
+// StreamEnrollment streams an event's registration count and spots left as
+// server-sent events, for showing live availability as it fills up
+// GET /api/v1/events/:id/enrollment/stream
+func (h *EventHandler) StreamEnrollment(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid event ID"),
+		})
+		return
+	}
+
+	q := repository.New(h.db.Reader())
+	streamCounters(c, "event not found", func(ctx context.Context) (interface{}, error) {
+		return q.GetEventEnrollment(ctx, eventID)
+	})
+}
+
+// startEventStream writes the headers of a server-sent event stream
+func startEventStream(c *gin.Context) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+}
+
+// streamCounters sends what fetch returns as a "counters" event, first
+// straight away and then whenever it changes. When fetch reports
+// sql.ErrNoRows the item was deleted or expired, so it sends an "end" event
+// and closes the stream.
+func streamCounters(c *gin.Context, notFound string, fetch func(ctx context.Context) (interface{}, error)) {
+	ctx := c.Request.Context()
+	counters, err := fetch(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr(notFound),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to fetch counters", err)
+		return
+	}
+
+	startEventStream(c)
+
+	var last []byte
+	send := func(counters interface{}) bool {
+		data, err := json.Marshal(counters)
+		if err != nil {
+			logInternalError(c, "failed to encode counters", err)
+			return false
+		}
+		if !bytes.Equal(data, last) {
+			fmt.Fprintf(c.Writer, "event: counters\ndata: %s\n\n", data)
+			c.Writer.Flush()
+			last = data
+		}
+		return true
+	}
+	if !send(counters) {
+		return
+	}
+
+	poll := time.NewTicker(counterStreamPoll)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(updateStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+			c.Writer.Flush()
+		case <-poll.C:
+			counters, err := fetch(ctx)
+			if errors.Is(err, sql.ErrNoRows) {
+				fmt.Fprint(c.Writer, "event: end\ndata: {}\n\n")
+				c.Writer.Flush()
+				return
+			}
+			if err != nil {
+				if ctx.Err() == nil {
+					logInternalError(c, "failed to poll counters", err)
+				}
+				return
+			}
+			if !send(counters) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+func TestStreamCounters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	defer func(poll time.Duration) { counterStreamPoll = poll }(counterStreamPoll)
+	counterStreamPoll = time.Millisecond
+
+	tests := []struct {
+		name     string
+		results  []interface{} // models.StoryCounters or an error, one per fetch
+		wantCode int
+		want     []string
+	}{
+		{
+			name: "sends changes and ends when the item goes away",
+			results: []interface{}{
+				models.StoryCounters{LikeCount: 1, ViewCount: 10},
+				models.StoryCounters{LikeCount: 1, ViewCount: 10},
+				models.StoryCounters{LikeCount: 2, ViewCount: 12},
+				sql.ErrNoRows,
+			},
+			wantCode: http.StatusOK,
+			want: []string{
+				"event: counters\ndata: {\"like_count\":1,\"view_count\":10}\n\n",
+				"event: counters\ndata: {\"like_count\":2,\"view_count\":12}\n\n",
+				"event: end\ndata: {}\n\n",
+			},
+		},
+		{
+			name:     "not found before streaming",
+			results:  []interface{}{sql.ErrNoRows},
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			router := gin.New()
+			router.GET("/stream", func(c *gin.Context) {
+				streamCounters(c, "story not found", func(ctx context.Context) (interface{}, error) {
+					r := tt.results[calls]
+					calls++
+					if err, ok := r.(error); ok {
+						return nil, err
+					}
+					return r, nil
+				})
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stream", nil))
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d; body: %s", w.Code, tt.wantCode, w.Body.String())
+			}
+			if tt.want != nil {
+				body := strings.ReplaceAll(w.Body.String(), ": ping\n\n", "")
+				if got := strings.Join(tt.want, ""); body != got {
+					t.Errorf("body = %q, want %q", body, got)
+				}
+			}
+		})
+	}
+}
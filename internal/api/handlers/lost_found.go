@@ -0,0 +1,515 @@
+package handlers
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/storage"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+const lostFoundItemSelect = `
+	SELECT i.id, i.kind, i.title, i.description, i.location, i.image_url, i.status,
+	       i.posted_by, i.expires_at, i.created_at, i.updated_at,
+	       u.id, u.full_name, u.avatar_url, u.role
+	FROM lost_found_items i
+	JOIN users u ON u.id = i.posted_by`
+
+func scanLostFoundItem(row interface{ Scan(...interface{}) error }) (models.LostFoundItem, error) {
+	var item models.LostFoundItem
+	var poster models.UserSummary
+	err := row.Scan(
+		&item.ID, &item.Kind, &item.Title, &item.Description, &item.Location, &item.ImageURL, &item.Status,
+		&item.PostedBy, &item.ExpiresAt, &item.CreatedAt, &item.UpdatedAt,
+		&poster.ID, &poster.FullName, &poster.AvatarURL, &poster.Role,
+	)
+	item.Poster = &poster
+	return item, err
+}
+
+// LostFoundHandler handles lost and found items and claims
+type LostFoundHandler struct {
+	db      *database.DB
+	storage storage.StorageService
+}
+
+// NewLostFoundHandler creates a new lost and found handler
+func NewLostFoundHandler(db *database.DB, storageService storage.StorageService) *LostFoundHandler {
+	return &LostFoundHandler{db: db, storage: storageService}
+}
+
+// ListItems lists open items, newest first
+// GET /api/v1/lost-found?kind=found
+func (h *LostFoundHandler) ListItems(c *gin.Context) {
+	var query models.ListLostFoundQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid query parameters"),
+		})
+		return
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 || query.PageSize > 100 {
+		query.PageSize = 20
+	}
+
+	rows, err := h.db.Reader().QueryContext(c.Request.Context(), lostFoundItemSelect+`
+		WHERE i.status = 'open' AND i.expires_at > NOW() AND ($1 = '' OR i.kind = $1)
+		ORDER BY i.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, query.Kind, query.PageSize, (query.Page-1)*query.PageSize)
+	if err != nil {
+		internalError(c, "Failed to fetch items", err)
+		return
+	}
+	defer rows.Close()
+
+	items := []models.LostFoundItem{}
+	for rows.Next() {
+		item, err := scanLostFoundItem(rows)
+		if err != nil {
+			internalError(c, "Failed to fetch items", err)
+			return
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch items", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    items,
+	})
+}
+
+// GetItem returns a single item
+// GET /api/v1/lost-found/:id
+func (h *LostFoundHandler) GetItem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid item ID"),
+		})
+		return
+	}
+
+	item, err := scanLostFoundItem(h.db.Reader().QueryRowContext(c.Request.Context(),
+		lostFoundItemSelect+` WHERE i.id = $1`, id))
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Item not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to fetch item", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    item,
+	})
+}
+
+// CreateItem posts a lost or found item with an optional photo
+// POST /api/v1/lost-found (multipart/form-data)
+func (h *LostFoundHandler) CreateItem(c *gin.Context) {
+	var req models.CreateLostFoundItemRequest
+	if err := c.ShouldBind(&req); err != nil {
+		if strings.Contains(err.Error(), "http: request body too large") {
+			c.JSON(http.StatusRequestEntityTooLarge, models.APIResponse{
+				Success: false,
+				Error:   strPtr("File too large"),
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	var imageURL, imagePath *string
+	file, header, err := c.Request.FormFile("image")
+	switch {
+	case err == nil:
+		defer file.Close()
+		if !isValidImageType(header.Header.Get("Content-Type")) {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Invalid file type. Allowed: JPEG, PNG, GIF, WebP"),
+			})
+			return
+		}
+		result, err := h.storage.UploadImage(c.Request.Context(), file, header.Filename, "lost-found", storage.ImageTypeBanner)
+		if err != nil {
+			internalError(c, "Failed to upload image", err)
+			return
+		}
+		imageURL, imagePath = &result.URL, &result.Path
+	case err != http.ErrMissingFile:
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid image upload"),
+		})
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+	ctx := c.Request.Context()
+
+	var id uuid.UUID
+	err = h.db.QueryRowContext(ctx, `
+		INSERT INTO lost_found_items (kind, title, description, location, image_url, image_path, posted_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`, req.Kind, req.Title, req.Description, req.Location, imageURL, imagePath, userID).Scan(&id)
+	if err != nil {
+		if imagePath != nil {
+			h.deleteImage(c, *imagePath)
+		}
+		internalError(c, "Failed to create item", err)
+		return
+	}
+
+	item, err := scanLostFoundItem(h.db.QueryRowContext(ctx, lostFoundItemSelect+` WHERE i.id = $1`, id))
+	if err != nil {
+		internalError(c, "Failed to create item", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Item posted successfully",
+		Data:    item,
+	})
+}
+
+// DeleteItem removes an item and its photo (poster or admin)
+// DELETE /api/v1/lost-found/:id
+func (h *LostFoundHandler) DeleteItem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid item ID"),
+		})
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+	role, _ := middleware.Role(c)
+
+	var imagePath sql.NullString
+	err = h.db.QueryRowContext(c.Request.Context(), `
+		DELETE FROM lost_found_items
+		WHERE id = $1 AND (posted_by = $2 OR $3)
+		RETURNING image_path
+	`, id, userID, role == models.RoleAdmin).Scan(&imagePath)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Item not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to delete item", err)
+		return
+	}
+
+	if imagePath.Valid {
+		h.deleteImage(c, imagePath.String)
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Item deleted successfully",
+	})
+}
+
+// CreateClaim claims an open item posted by someone else
+// POST /api/v1/lost-found/:id/claims
+func (h *LostFoundHandler) CreateClaim(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid item ID"),
+		})
+		return
+	}
+
+	var req models.CreateClaimRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+	ctx := c.Request.Context()
+
+	var postedBy uuid.UUID
+	var status string
+	err = h.db.QueryRowContext(ctx, `
+		SELECT posted_by, CASE WHEN expires_at <= NOW() THEN 'expired' ELSE status END
+		FROM lost_found_items WHERE id = $1
+	`, itemID).Scan(&postedBy, &status)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Item not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to create claim", err)
+		return
+	}
+	if postedBy == userID {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("You can't claim your own item"),
+		})
+		return
+	}
+	if status != "open" {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Item is no longer open"),
+		})
+		return
+	}
+
+	var claim models.LostFoundClaim
+	err = h.db.QueryRowContext(ctx, `
+		INSERT INTO lost_found_claims (item_id, claimant_id, message)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (item_id, claimant_id) DO NOTHING
+		RETURNING id, item_id, claimant_id, message, status, created_at, resolved_at
+	`, itemID, userID, req.Message).Scan(
+		&claim.ID, &claim.ItemID, &claim.ClaimantID, &claim.Message, &claim.Status, &claim.CreatedAt, &claim.ResolvedAt,
+	)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("You have already claimed this item"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to create claim", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Claim submitted",
+		Data:    claim,
+	})
+}
+
+// ListClaims lists the claims on an item (poster only)
+// GET /api/v1/lost-found/:id/claims
+func (h *LostFoundHandler) ListClaims(c *gin.Context) {
+	itemID, ok := h.requirePoster(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.db.QueryContext(c.Request.Context(), `
+		SELECT cl.id, cl.item_id, cl.claimant_id, cl.message, cl.status, cl.created_at, cl.resolved_at,
+		       u.id, u.full_name, u.avatar_url, u.role
+		FROM lost_found_claims cl
+		JOIN users u ON u.id = cl.claimant_id
+		WHERE cl.item_id = $1
+		ORDER BY cl.created_at ASC
+	`, itemID)
+	if err != nil {
+		internalError(c, "Failed to fetch claims", err)
+		return
+	}
+	defer rows.Close()
+
+	claims := []models.LostFoundClaim{}
+	for rows.Next() {
+		var claim models.LostFoundClaim
+		var claimant models.UserSummary
+		if err := rows.Scan(
+			&claim.ID, &claim.ItemID, &claim.ClaimantID, &claim.Message, &claim.Status, &claim.CreatedAt, &claim.ResolvedAt,
+			&claimant.ID, &claimant.FullName, &claimant.AvatarURL, &claimant.Role,
+		); err != nil {
+			internalError(c, "Failed to fetch claims", err)
+			return
+		}
+		claim.Claimant = &claimant
+		claims = append(claims, claim)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch claims", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    claims,
+	})
+}
+
+// ResolveClaim approves or rejects a claim (poster only). Approving a claim
+// closes the item and rejects every other pending claim.
+// PUT /api/v1/lost-found/:id/claims/:claim_id
+func (h *LostFoundHandler) ResolveClaim(c *gin.Context) {
+	itemID, ok := h.requirePoster(c)
+	if !ok {
+		return
+	}
+
+	claimID, err := uuid.Parse(c.Param("claim_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid claim ID"),
+		})
+		return
+	}
+
+	var req models.ResolveClaimRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to resolve claim", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Lock the item so two approvals can't both succeed
+	var status string
+	if err := tx.QueryRowContext(ctx,
+		"SELECT status FROM lost_found_items WHERE id = $1 FOR UPDATE", itemID,
+	).Scan(&status); err != nil {
+		internalError(c, "Failed to resolve claim", err)
+		return
+	}
+	if status != "open" {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Item is no longer open"),
+		})
+		return
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE lost_found_claims SET status = $1, resolved_at = NOW()
+		WHERE id = $2 AND item_id = $3 AND status = 'pending'
+	`, req.Status, claimID, itemID)
+	if err != nil {
+		internalError(c, "Failed to resolve claim", err)
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Pending claim not found"),
+		})
+		return
+	}
+
+	if req.Status == "approved" {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE lost_found_claims SET status = 'rejected', resolved_at = NOW()
+			WHERE item_id = $1 AND status = 'pending'
+		`, itemID); err != nil {
+			internalError(c, "Failed to resolve claim", err)
+			return
+		}
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE lost_found_items SET status = 'claimed' WHERE id = $1", itemID,
+		); err != nil {
+			internalError(c, "Failed to resolve claim", err)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to resolve claim", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Claim " + req.Status,
+	})
+}
+
+// requirePoster parses the item ID and checks the current user posted it.
+// Returns false if the request has been answered.
+func (h *LostFoundHandler) requirePoster(c *gin.Context) (uuid.UUID, bool) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid item ID"),
+		})
+		return uuid.Nil, false
+	}
+
+	userID, _ := middleware.UserID(c)
+
+	var postedBy uuid.UUID
+	err = h.db.QueryRowContext(c.Request.Context(),
+		"SELECT posted_by FROM lost_found_items WHERE id = $1", itemID,
+	).Scan(&postedBy)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Item not found"),
+		})
+		return uuid.Nil, false
+	}
+	if err != nil {
+		internalError(c, "Failed to fetch item", err)
+		return uuid.Nil, false
+	}
+	if postedBy != userID {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Only the poster can manage claims"),
+		})
+		return uuid.Nil, false
+	}
+	return itemID, true
+}
+
+func (h *LostFoundHandler) deleteImage(c *gin.Context, path string) {
+	if err := h.storage.Delete(c.Request.Context(), path); err != nil {
+		log.Printf("[WARN] request_id=%s failed to delete lost and found image %s: %v",
+			middleware.GetRequestID(c), path, err)
+	}
+}
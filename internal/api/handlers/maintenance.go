@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// MaintenanceHandler lets admins toggle maintenance mode
+type MaintenanceHandler struct {
+	db          *database.DB
+	maintenance *middleware.Maintenance
+}
+
+// NewMaintenanceHandler creates a new maintenance handler
+func NewMaintenanceHandler(db *database.DB, maintenance *middleware.Maintenance) *MaintenanceHandler {
+	return &MaintenanceHandler{db: db, maintenance: maintenance}
+}
+
+// GetMaintenance returns the current maintenance status
+// GET /api/v1/admin/maintenance
+func (h *MaintenanceHandler) GetMaintenance(c *gin.Context) {
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    h.maintenance.Status(c.Request.Context()),
+	})
+}
+
+// UpdateMaintenance turns maintenance mode on or off with an optional message
+// and ETA. Other instances pick up the change within a few seconds.
+// PUT /api/v1/admin/maintenance
+func (h *MaintenanceHandler) UpdateMaintenance(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	var req models.UpdateMaintenanceRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	// Turning maintenance off clears the message and ETA for next time
+	if !*req.Enabled {
+		req.Message, req.ETA = nil, nil
+	}
+
+	if _, err := h.db.ExecContext(c.Request.Context(), `
+		INSERT INTO maintenance_mode (id, enabled, message, eta, updated_by)
+		VALUES (TRUE, $1, NULLIF($2, ''), $3, $4)
+		ON CONFLICT (id) DO UPDATE SET
+			enabled = EXCLUDED.enabled, message = EXCLUDED.message,
+			eta = EXCLUDED.eta, updated_by = EXCLUDED.updated_by
+	`, *req.Enabled, req.Message, req.ETA, userID); err != nil {
+		internalError(c, "Failed to update maintenance mode", err)
+		return
+	}
+	h.maintenance.Refresh()
+
+	status := h.maintenance.Status(c.Request.Context())
+	message := "Maintenance mode disabled"
+	if *req.Enabled {
+		message = "Maintenance mode enabled"
+	} else if status.Forced {
+		message = "Maintenance mode disabled, but held on by the MAINTENANCE_MODE config flag"
+	}
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    status,
+	})
+}
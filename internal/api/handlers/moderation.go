@@ -0,0 +1,766 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/audit"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/auth"
+	"github.com/yourusername/college-event-backend/internal/services/moderation"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// ModerationHandler manages user blocks and, for admins, user mutes, shadow
+// bans and content flagged by the content filter
+type ModerationHandler struct {
+	db *database.DB
+}
+
+// NewModerationHandler creates a new moderation handler
+func NewModerationHandler(db *database.DB) *ModerationHandler {
+	return &ModerationHandler{db: db}
+}
+
+// requireCanInteract rejects the user commenting on or liking content owned
+// by ownerID when either has blocked the other, and rejects comments from a
+// muted user. ownerID is nil for content whose author has been deleted.
+// Returns false if the request was aborted.
+func requireCanInteract(c *gin.Context, q database.Querier, userID uuid.UUID, ownerID *uuid.UUID, commenting bool) bool {
+	ctx := c.Request.Context()
+
+	if commenting {
+		until, err := moderation.MutedUntil(ctx, q, userID)
+		if err != nil {
+			internalError(c, "Failed to check moderation status", err)
+			return false
+		}
+		if until != nil {
+			c.JSON(http.StatusForbidden, models.APIResponse{
+				Success: false,
+				Error:   strPtr("You are muted from commenting until " + until.Format(time.RFC3339)),
+				Data:    gin.H{"muted_until": until},
+			})
+			return false
+		}
+	}
+
+	if ownerID == nil || *ownerID == userID {
+		return true
+	}
+	blocked, err := moderation.Blocked(ctx, q, userID, *ownerID)
+	if err != nil {
+		internalError(c, "Failed to check moderation status", err)
+		return false
+	}
+	if blocked {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("You can't interact with this user's content"),
+		})
+		return false
+	}
+	return true
+}
+
+// screenContent runs content through the content filter, rejecting it if
+// it has a banned word. what names the content in the error. Returns false
+// if the request was aborted.
+func screenContent(c *gin.Context, f *moderation.Filter, what string, texts ...string) (moderation.Result, bool) {
+	result := f.Check(texts...)
+	if result.Verdict == moderation.Reject {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Your " + what + " contains language that isn't allowed"),
+		})
+		return result, false
+	}
+	return result, true
+}
+
+// BlockUser blocks a user. Their comments are hidden from the blocker, and
+// neither can comment on or like the other's content.
+// POST /api/v1/users/:id/block
+func (h *ModerationHandler) BlockUser(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	blockedID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid user ID"),
+		})
+		return
+	}
+	if blockedID == userID {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("You can't block yourself"),
+		})
+		return
+	}
+
+	res, err := h.db.ExecContext(c.Request.Context(), `
+		INSERT INTO blocked_users (blocker_id, blocked_id)
+		SELECT $1, id FROM users WHERE id = $2
+		ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+	`, userID, blockedID)
+	if err != nil {
+		internalError(c, "Failed to block user", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		// Either already blocked or no such user
+		var exists bool
+		if err := h.db.QueryRowContext(c.Request.Context(),
+			"SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", blockedID).Scan(&exists); err != nil {
+			internalError(c, "Failed to block user", err)
+			return
+		}
+		if !exists {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   strPtr("User not found"),
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "User blocked",
+	})
+}
+
+// UnblockUser removes a block. Unblocking a user who isn't blocked succeeds.
+// DELETE /api/v1/users/:id/block
+func (h *ModerationHandler) UnblockUser(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	blockedID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid user ID"),
+		})
+		return
+	}
+
+	if _, err := h.db.ExecContext(c.Request.Context(),
+		"DELETE FROM blocked_users WHERE blocker_id = $1 AND blocked_id = $2", userID, blockedID); err != nil {
+		internalError(c, "Failed to unblock user", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "User unblocked",
+	})
+}
+
+// ListBlockedUsers returns the users the current user has blocked, most
+// recently blocked first
+// GET /api/v1/me/blocked-users
+func (h *ModerationHandler) ListBlockedUsers(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	rows, err := h.db.QueryContext(c.Request.Context(), `
+		SELECT b.blocked_id, u.full_name, u.avatar_url, b.created_at
+		FROM blocked_users b
+		JOIN users u ON u.id = b.blocked_id
+		WHERE b.blocker_id = $1
+		ORDER BY b.created_at DESC
+	`, userID)
+	if err != nil {
+		internalError(c, "Failed to fetch blocked users", err)
+		return
+	}
+	defer rows.Close()
+
+	blocked := []models.BlockedUser{}
+	for rows.Next() {
+		var b models.BlockedUser
+		if err := rows.Scan(&b.UserID, &b.FullName, &b.AvatarURL, &b.BlockedAt); err != nil {
+			internalError(c, "Failed to fetch blocked users", err)
+			return
+		}
+		blocked = append(blocked, b)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch blocked users", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    blocked,
+	})
+}
+
+// MuteUser stops a user commenting for a number of hours. Admins cannot be
+// muted.
+// POST /api/v1/admin/users/:id/mute
+func (h *ModerationHandler) MuteUser(c *gin.Context) {
+	adminID, _ := middleware.UserID(c)
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid user ID"),
+		})
+		return
+	}
+
+	var req models.MuteUserRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid request: " + err.Error()),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	var mute models.UserMute
+	var role models.UserRole
+	err = h.db.QueryRowContext(ctx, "SELECT id, full_name, role FROM users WHERE id = $1", userID).
+		Scan(&mute.UserID, &mute.FullName, &role)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("User not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to mute user", err)
+		return
+	}
+	if auth.IsAdmin(role) {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Admins cannot be muted"),
+		})
+		return
+	}
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to mute user", err)
+		return
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO user_mutes (user_id, muted_until, reason, muted_by)
+		VALUES ($1, NOW() + make_interval(hours => $2), $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET muted_until = EXCLUDED.muted_until, reason = EXCLUDED.reason,
+		    muted_by = EXCLUDED.muted_by, created_at = CURRENT_TIMESTAMP
+		RETURNING muted_until, reason, muted_by, created_at
+	`, userID, req.Hours, req.Reason, adminID).Scan(&mute.MutedUntil, &mute.Reason, &mute.MutedBy, &mute.CreatedAt)
+	if err != nil {
+		internalError(c, "Failed to mute user", err)
+		return
+	}
+
+	if err := audit.Record(ctx, tx, audit.Entry{
+		Action:        audit.ActionUserMute,
+		ActorID:       &adminID,
+		SubjectUserID: &userID,
+		Method:        c.Request.Method,
+		Path:          c.Request.URL.RequestURI(),
+		Status:        http.StatusOK,
+		RequestID:     middleware.GetRequestID(c),
+		IPAddress:     c.ClientIP(),
+		Details: map[string]interface{}{
+			"reason":      req.Reason,
+			"muted_until": mute.MutedUntil,
+		},
+	}); err != nil {
+		internalError(c, "Failed to mute user", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to mute user", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "User muted",
+		Data:    mute,
+	})
+}
+
+// UnmuteUser lifts a user's mute before it expires
+// DELETE /api/v1/admin/users/:id/mute
+func (h *ModerationHandler) UnmuteUser(c *gin.Context) {
+	adminID, _ := middleware.UserID(c)
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid user ID"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to unmute user", err)
+		return
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		"DELETE FROM user_mutes WHERE user_id = $1 AND muted_until > NOW()", userID)
+	if err != nil {
+		internalError(c, "Failed to unmute user", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("User is not muted"),
+		})
+		return
+	}
+
+	if err := audit.Record(ctx, tx, audit.Entry{
+		Action:        audit.ActionUserUnmute,
+		ActorID:       &adminID,
+		SubjectUserID: &userID,
+		Method:        c.Request.Method,
+		Path:          c.Request.URL.RequestURI(),
+		Status:        http.StatusOK,
+		RequestID:     middleware.GetRequestID(c),
+		IPAddress:     c.ClientIP(),
+	}); err != nil {
+		internalError(c, "Failed to unmute user", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to unmute user", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "User unmuted",
+	})
+}
+
+// ListMutes returns the users currently muted, soonest to expire first
+// GET /api/v1/admin/mutes
+func (h *ModerationHandler) ListMutes(c *gin.Context) {
+	rows, err := h.db.Reader().QueryContext(c.Request.Context(), `
+		SELECT m.user_id, u.full_name, m.muted_until, m.reason, m.muted_by, m.created_at
+		FROM user_mutes m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.muted_until > NOW()
+		ORDER BY m.muted_until
+	`)
+	if err != nil {
+		internalError(c, "Failed to fetch mutes", err)
+		return
+	}
+	defer rows.Close()
+
+	mutes := []models.UserMute{}
+	for rows.Next() {
+		var m models.UserMute
+		if err := rows.Scan(&m.UserID, &m.FullName, &m.MutedUntil, &m.Reason, &m.MutedBy, &m.CreatedAt); err != nil {
+			internalError(c, "Failed to fetch mutes", err)
+			return
+		}
+		mutes = append(mutes, m)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch mutes", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    mutes,
+	})
+}
+
+// ListContentFlags returns content the filter flagged, and posts and
+// stories held by the image check, oldest first, with the content itself.
+// Pending flags are listed unless status says otherwise.
+// GET /api/v1/admin/moderation/flags
+func (h *ModerationHandler) ListContentFlags(c *gin.Context) {
+	status := c.DefaultQuery("status", "pending")
+	switch status {
+	case "pending", "approved", "removed", "dismissed":
+	default:
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("status must be pending, approved, removed or dismissed"),
+		})
+		return
+	}
+
+	rows, err := h.db.Reader().QueryContext(c.Request.Context(), `
+		SELECT f.id, f.content_type, f.content_id,
+		       COALESCE(pc.content, ac.content, ha.title || E'\n\n' || ha.content,
+		                CASE WHEN p.id IS NOT NULL THEN concat_ws(E'\n\n', p.description, p.image_url, p.thumbnail_url) END,
+		                CASE WHEN st.id IS NOT NULL THEN concat_ws(E'\n\n', st.description, st.image_url, st.thumbnail_url) END,
+		                cm.content,
+		                CASE WHEN cr.id IS NOT NULL THEN cr.rating || E'/5\n\n' || cr.body END),
+		       f.user_id, u.full_name, f.reasons, f.status, f.reviewed_by, f.reviewed_at, f.created_at
+		FROM content_flags f
+		LEFT JOIN users u ON u.id = f.user_id
+		LEFT JOIN post_comments pc
+		       ON f.content_type = 'post_comment' AND pc.id = f.content_id AND pc.deleted_at IS NULL
+		LEFT JOIN announcement_comments ac
+		       ON f.content_type = 'announcement_comment' AND ac.id = f.content_id AND ac.deleted_at IS NULL
+		LEFT JOIN house_announcements ha
+		       ON f.content_type = 'announcement' AND ha.id = f.content_id AND ha.deleted_at IS NULL
+		LEFT JOIN posts p
+		       ON f.content_type = 'post' AND p.id = f.content_id AND p.deleted_at IS NULL
+		LEFT JOIN stories st
+		       ON f.content_type = 'story' AND st.id = f.content_id AND st.expires_at > NOW() AND st.deleted_at IS NULL
+		LEFT JOIN chat_channel_messages cm
+		       ON f.content_type = 'chat_message' AND cm.id = f.content_id AND cm.deleted_at IS NULL
+		LEFT JOIN club_reviews cr
+		       ON f.content_type = 'club_review' AND cr.id = f.content_id AND cr.deleted_at IS NULL
+		WHERE f.status = $1
+		ORDER BY f.created_at
+		LIMIT 200
+	`, status)
+	if err != nil {
+		internalError(c, "Failed to fetch flagged content", err)
+		return
+	}
+	defer rows.Close()
+
+	flags := []models.ContentFlag{}
+	for rows.Next() {
+		var f models.ContentFlag
+		if err := rows.Scan(&f.ID, &f.ContentType, &f.ContentID, &f.Content, &f.UserID, &f.UserName,
+			pq.Array(&f.Reasons), &f.Status, &f.ReviewedBy, &f.ReviewedAt, &f.CreatedAt); err != nil {
+			internalError(c, "Failed to fetch flagged content", err)
+			return
+		}
+		flags = append(flags, f)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch flagged content", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    flags,
+	})
+}
+
+// ReviewContentFlag resolves a pending flag, either keeping the content,
+// which publishes it if it was held, or removing it
+// PUT /api/v1/admin/moderation/flags/:id
+func (h *ModerationHandler) ReviewContentFlag(c *gin.Context) {
+	adminID, _ := middleware.UserID(c)
+
+	flagID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid flag ID"),
+		})
+		return
+	}
+
+	var req models.ReviewContentFlagRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid request: " + err.Error()),
+		})
+		return
+	}
+	status := "approved"
+	if req.Action == "remove" {
+		status = "removed"
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to review flag", err)
+		return
+	}
+	defer tx.Rollback()
+
+	var contentType string
+	var contentID uuid.UUID
+	err = tx.QueryRowContext(ctx, `
+		UPDATE content_flags
+		SET status = $2, reviewed_by = $3, reviewed_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+		RETURNING content_type, content_id
+	`, flagID, status, adminID).Scan(&contentType, &contentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Pending flag not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to review flag", err)
+		return
+	}
+
+	if status == "removed" {
+		if err := moderation.RemoveContent(ctx, tx, contentType, contentID); err != nil {
+			internalError(c, "Failed to review flag", err)
+			return
+		}
+	} else {
+		if err := moderation.ReleaseContent(ctx, tx, contentType, contentID); err != nil {
+			internalError(c, "Failed to review flag", err)
+			return
+		}
+		if contentType == moderation.ContentPost {
+			if err := notifyReleasedPost(ctx, tx, contentID); err != nil {
+				internalError(c, "Failed to review flag", err)
+				return
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to review flag", err)
+		return
+	}
+
+	message := "Content approved"
+	if status == "removed" {
+		message = "Content removed"
+	}
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: message,
+	})
+}
+
+// notifyReleasedPost tells followers about a post held for review once it's
+// approved, as they would have been told when it was created
+func notifyReleasedPost(ctx context.Context, tx *sql.Tx, postID uuid.UUID) error {
+	var published bool
+	err := tx.QueryRowContext(ctx,
+		"SELECT status = 'published' FROM posts WHERE id = $1 AND deleted_at IS NULL", postID,
+	).Scan(&published)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !published {
+		// A draft or scheduled post's followers are told when it's published
+		return nil
+	}
+	return notifyPostPublished(ctx, tx, postID)
+}
+
+// ShadowBanUser hides a user's posts and comments from everyone but
+// themselves. Admins cannot be shadow banned.
+// POST /api/v1/admin/moderation/shadow-bans
+func (h *ModerationHandler) ShadowBanUser(c *gin.Context) {
+	adminID, _ := middleware.UserID(c)
+
+	var req models.ShadowBanRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid request: " + err.Error()),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	var ban models.ShadowBan
+	var role models.UserRole
+	err := h.db.QueryRowContext(ctx, "SELECT id, full_name, role FROM users WHERE id = $1", req.UserID).
+		Scan(&ban.UserID, &ban.FullName, &role)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("User not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to shadow ban user", err)
+		return
+	}
+	if auth.IsAdmin(role) {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Admins cannot be shadow banned"),
+		})
+		return
+	}
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to shadow ban user", err)
+		return
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO shadow_bans (user_id, reason, banned_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO NOTHING
+		RETURNING reason, banned_by, created_at
+	`, req.UserID, req.Reason, adminID).Scan(&ban.Reason, &ban.BannedBy, &ban.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("User is already shadow banned"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to shadow ban user", err)
+		return
+	}
+
+	if err := audit.Record(ctx, tx, audit.Entry{
+		Action:        audit.ActionUserShadowBan,
+		ActorID:       &adminID,
+		SubjectUserID: &req.UserID,
+		Method:        c.Request.Method,
+		Path:          c.Request.URL.RequestURI(),
+		Status:        http.StatusCreated,
+		RequestID:     middleware.GetRequestID(c),
+		IPAddress:     c.ClientIP(),
+		Details:       map[string]interface{}{"reason": req.Reason},
+	}); err != nil {
+		internalError(c, "Failed to shadow ban user", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to shadow ban user", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "User shadow banned",
+		Data:    ban,
+	})
+}
+
+// LiftShadowBan makes a shadow banned user's content visible again
+// DELETE /api/v1/admin/moderation/shadow-bans/:user_id
+func (h *ModerationHandler) LiftShadowBan(c *gin.Context) {
+	adminID, _ := middleware.UserID(c)
+
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid user ID"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to lift shadow ban", err)
+		return
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM shadow_bans WHERE user_id = $1", userID)
+	if err != nil {
+		internalError(c, "Failed to lift shadow ban", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("User is not shadow banned"),
+		})
+		return
+	}
+
+	if err := audit.Record(ctx, tx, audit.Entry{
+		Action:        audit.ActionUserShadowUnban,
+		ActorID:       &adminID,
+		SubjectUserID: &userID,
+		Method:        c.Request.Method,
+		Path:          c.Request.URL.RequestURI(),
+		Status:        http.StatusOK,
+		RequestID:     middleware.GetRequestID(c),
+		IPAddress:     c.ClientIP(),
+	}); err != nil {
+		internalError(c, "Failed to lift shadow ban", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to lift shadow ban", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Shadow ban lifted",
+	})
+}
+
+// ListShadowBans returns shadow banned users, most recently banned first
+// GET /api/v1/admin/moderation/shadow-bans
+func (h *ModerationHandler) ListShadowBans(c *gin.Context) {
+	rows, err := h.db.Reader().QueryContext(c.Request.Context(), `
+		SELECT b.user_id, u.full_name, b.reason, b.banned_by, b.created_at
+		FROM shadow_bans b
+		JOIN users u ON u.id = b.user_id
+		ORDER BY b.created_at DESC
+	`)
+	if err != nil {
+		internalError(c, "Failed to fetch shadow bans", err)
+		return
+	}
+	defer rows.Close()
+
+	bans := []models.ShadowBan{}
+	for rows.Next() {
+		var b models.ShadowBan
+		if err := rows.Scan(&b.UserID, &b.FullName, &b.Reason, &b.BannedBy, &b.CreatedAt); err != nil {
+			internalError(c, "Failed to fetch shadow bans", err)
+			return
+		}
+		bans = append(bans, b)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch shadow bans", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    bans,
+	})
+}
@@ -0,0 +1,406 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
+	"github.com/yourusername/college-event-backend/internal/settings"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// NotificationHandler handles the in-app notification inbox
+type NotificationHandler struct {
+	db       *database.DB
+	settings *settings.Store // Campus-wide default quiet hours
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(db *database.DB, settingsStore *settings.Store) *NotificationHandler {
+	return &NotificationHandler{db: db, settings: settingsStore}
+}
+
+// ListNotifications returns the current user's notifications, newest first
+// GET /api/v1/notifications
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	var query models.ListNotificationsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid query parameters"),
+		})
+		return
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 || query.PageSize > 100 {
+		query.PageSize = 20
+	}
+
+	ctx := c.Request.Context()
+	rows, err := h.db.QueryContext(ctx, `
+		SELECT id, user_id, category, title, body, data, read_at, created_at
+		FROM notifications
+		WHERE user_id = $1 AND ($2 = FALSE OR read_at IS NULL)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`, userID, query.UnreadOnly, query.PageSize, (query.Page-1)*query.PageSize)
+	if err != nil {
+		internalError(c, "Failed to fetch notifications", err)
+		return
+	}
+	defer rows.Close()
+
+	notifications := []models.Notification{}
+	for rows.Next() {
+		var n models.Notification
+		var data []byte
+		if err := rows.Scan(&n.ID, &n.UserID, &n.Category, &n.Title, &n.Body, &data, &n.ReadAt, &n.CreatedAt); err != nil {
+			internalError(c, "Failed to fetch notifications", err)
+			return
+		}
+		n.Data = data
+		notifications = append(notifications, n)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch notifications", err)
+		return
+	}
+
+	var unreadCount int
+	if err := h.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL", userID,
+	).Scan(&unreadCount); err != nil {
+		internalError(c, "Failed to fetch notifications", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: models.NotificationsListResponse{
+			Notifications: notifications,
+			UnreadCount:   unreadCount,
+			Page:          query.Page,
+			PageSize:      query.PageSize,
+		},
+	})
+}
+
+// MarkNotificationRead marks a single notification as read
+// POST /api/v1/notifications/:id/read
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid notification ID"),
+		})
+		return
+	}
+
+	result, err := h.db.ExecContext(c.Request.Context(), `
+		UPDATE notifications SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Failed to update notification"),
+		})
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Notification not found"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Notification marked as read",
+	})
+}
+
+// MarkAllNotificationsRead marks every unread notification as read
+// POST /api/v1/notifications/read-all
+func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	_, err := h.db.ExecContext(c.Request.Context(), `
+		UPDATE notifications SET read_at = NOW()
+		WHERE user_id = $1 AND read_at IS NULL
+	`, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Failed to update notifications"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "All notifications marked as read",
+	})
+}
+
+// GetNotificationPreferences returns the current user's push and email
+// settings for every category
+// GET /api/v1/me/notification-preferences
+func (h *NotificationHandler) GetNotificationPreferences(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	prefs, err := h.loadPreferences(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, "Failed to fetch notification preferences", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    prefs,
+	})
+}
+
+// UpdateNotificationPreferences changes the push and/or email setting of one
+// or more categories and returns the full set
+// PUT /api/v1/me/notification-preferences
+func (h *NotificationHandler) UpdateNotificationPreferences(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	var req models.UpdateNotificationPreferencesRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	for _, p := range req.Preferences {
+		if !notifications.IsCategory(p.Category) {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Unknown notification category: " + p.Category),
+			})
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to update notification preferences", err)
+		return
+	}
+	defer tx.Rollback()
+
+	for _, p := range req.Preferences {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO notification_preferences (user_id, category, push_enabled, email_enabled)
+			VALUES ($1, $2, COALESCE($3, TRUE), COALESCE($4, TRUE))
+			ON CONFLICT (user_id, category) DO UPDATE SET
+				push_enabled = COALESCE($3, notification_preferences.push_enabled),
+				email_enabled = COALESCE($4, notification_preferences.email_enabled)
+		`, userID, p.Category, p.Push, p.Email); err != nil {
+			internalError(c, "Failed to update notification preferences", err)
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to update notification preferences", err)
+		return
+	}
+
+	prefs, err := h.loadPreferences(ctx, userID)
+	if err != nil {
+		internalError(c, "Failed to fetch notification preferences", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Notification preferences updated",
+		Data:    prefs,
+	})
+}
+
+// loadPreferences returns a setting for every category, defaulting to both
+// channels on where the user has not saved one
+func (h *NotificationHandler) loadPreferences(ctx context.Context, userID uuid.UUID) ([]models.NotificationPreference, error) {
+	rows, err := h.db.QueryContext(ctx, `
+		SELECT category, push_enabled, email_enabled
+		FROM notification_preferences
+		WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	saved := make(map[string]models.NotificationPreference)
+	for rows.Next() {
+		var p models.NotificationPreference
+		if err := rows.Scan(&p.Category, &p.Push, &p.Email); err != nil {
+			return nil, err
+		}
+		saved[p.Category] = p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	prefs := make([]models.NotificationPreference, 0, len(notifications.Categories))
+	for _, category := range notifications.Categories {
+		p, ok := saved[category]
+		if !ok {
+			p = models.NotificationPreference{Category: category, Push: true, Email: true}
+		}
+		prefs = append(prefs, p)
+	}
+	return prefs, nil
+}
+
+// GetQuietHours returns the quiet hours that apply to the current user
+// GET /api/v1/me/quiet-hours
+func (h *NotificationHandler) GetQuietHours(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	settings, err := h.loadQuietHours(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, "Failed to fetch quiet hours", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    settings,
+	})
+}
+
+// UpdateQuietHours turns the current user's quiet hours on or off, optionally
+// with their own window
+// PUT /api/v1/me/quiet-hours
+func (h *NotificationHandler) UpdateQuietHours(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	var req models.UpdateQuietHoursRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	if (req.Start == nil) != (req.End == nil) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("start and end must be given together"),
+		})
+		return
+	}
+	if req.Start != nil {
+		q, err := notifications.ParseQuietHours(*req.Start, *req.End)
+		if err != nil || !q.Enabled() {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("start and end must be different times in HH:MM format"),
+			})
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	if _, err := h.db.ExecContext(ctx, `
+		INSERT INTO notification_quiet_hours (user_id, enabled, start_time, end_time)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE SET
+			enabled = EXCLUDED.enabled, start_time = EXCLUDED.start_time, end_time = EXCLUDED.end_time
+	`, userID, *req.Enabled, req.Start, req.End); err != nil {
+		internalError(c, "Failed to update quiet hours", err)
+		return
+	}
+
+	settings, err := h.loadQuietHours(ctx, userID)
+	if err != nil {
+		internalError(c, "Failed to fetch quiet hours", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Quiet hours updated",
+		Data:    settings,
+	})
+}
+
+// ResetQuietHours returns the current user to the campus-wide quiet hours
+// DELETE /api/v1/me/quiet-hours
+func (h *NotificationHandler) ResetQuietHours(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	if _, err := h.db.ExecContext(c.Request.Context(),
+		"DELETE FROM notification_quiet_hours WHERE user_id = $1", userID,
+	); err != nil {
+		internalError(c, "Failed to reset quiet hours", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Quiet hours reset to the campus default",
+		Data:    h.defaultQuietHours(c.Request.Context()),
+	})
+}
+
+func (h *NotificationHandler) defaultQuietHours(ctx context.Context) models.QuietHoursSettings {
+	current := h.settings.Current(ctx)
+	if current.QuietHoursStart == "" {
+		return models.QuietHoursSettings{}
+	}
+	return models.QuietHoursSettings{
+		Enabled: true,
+		Start:   current.QuietHoursStart,
+		End:     current.QuietHoursEnd,
+	}
+}
+
+// loadQuietHours returns the user's own quiet hours, or the campus default
+func (h *NotificationHandler) loadQuietHours(ctx context.Context, userID uuid.UUID) (models.QuietHoursSettings, error) {
+	var enabled bool
+	var start, end *string
+	err := h.db.QueryRowContext(ctx, `
+		SELECT enabled, to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI')
+		FROM notification_quiet_hours
+		WHERE user_id = $1
+	`, userID).Scan(&enabled, &start, &end)
+	if err == sql.ErrNoRows {
+		return h.defaultQuietHours(ctx), nil
+	}
+	if err != nil {
+		return models.QuietHoursSettings{}, err
+	}
+
+	settings := h.defaultQuietHours(ctx)
+	settings.Custom = true
+	if start != nil && end != nil {
+		settings.Start, settings.End = *start, *end
+	}
+	// Enabled without a window of their own while the campus has none is off
+	settings.Enabled = enabled && settings.Start != ""
+	if !settings.Enabled {
+		settings.Start, settings.End = "", ""
+	}
+	return settings, nil
+}
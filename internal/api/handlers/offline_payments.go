@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/audit"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
+	"github.com/yourusername/college-event-backend/internal/webhooks"
+)
+
+// RecordOfflinePayment records a payment for a paid event taken in cash or
+// otherwise outside Razorpay (admin only). The admin recording it is kept
+// as the collector, with the receipt number issued, and the student is
+// registered just as an online payment would register them.
+// POST /api/v1/admin/events/:id/offline-payments
+func (h *PaymentHandler) RecordOfflinePayment(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid event ID"),
+		})
+		return
+	}
+
+	var req models.RecordOfflinePaymentRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	req.ReceiptNumber = strings.TrimSpace(req.ReceiptNumber)
+	if req.ReceiptNumber == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("receipt_number is required"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	var event models.Event
+	err = h.db.QueryRowContext(ctx, `
+		SELECT id, title, status, is_paid_event, event_amount, COALESCE(currency, 'INR')
+		FROM events
+		WHERE id = $1 AND deleted_at IS NULL
+	`, eventID).Scan(&event.ID, &event.Title, &event.Status, &event.IsPaidEvent, &event.EventAmount, &event.Currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("event not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to fetch event", err)
+		return
+	}
+	if event.Status != nil && *event.Status == models.EventStatusCancelled {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("this event has been cancelled"),
+		})
+		return
+	}
+	if !event.IsPaidEvent {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("this event does not require payment"),
+		})
+		return
+	}
+
+	var userExists bool
+	if err := h.db.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM users WHERE id = $1 AND deleted_at IS NULL)", req.UserID,
+	).Scan(&userExists); err != nil {
+		internalError(c, "failed to fetch user", err)
+		return
+	}
+	if !userExists {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("user not found"),
+		})
+		return
+	}
+
+	// Events with ticket tiers are priced by the tier bought. The collector
+	// may have agreed a different amount.
+	var amount float64
+	if event.EventAmount != nil {
+		amount = *event.EventAmount
+	}
+	tiers, err := loadTicketTiers(ctx, h.db.DB, eventID)
+	if err != nil {
+		internalError(c, "failed to fetch ticket tiers", err)
+		return
+	}
+	if len(tiers) > 0 || req.TierID != nil {
+		tier, ok := selectTicketTier(c, tiers, req.TierID)
+		if !ok {
+			return
+		}
+		amount = tier.Price
+	}
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to record payment", err)
+		return
+	}
+	defer tx.Rollback()
+
+	var paid, receiptTaken bool
+	if err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM event_payments WHERE event_id = $1 AND user_id = $2 AND status = 'paid'),
+		       EXISTS(SELECT 1 FROM event_payments WHERE event_id = $1 AND receipt_number = $3)
+	`, eventID, req.UserID, req.ReceiptNumber).Scan(&paid, &receiptTaken); err != nil {
+		internalError(c, "failed to record payment", err)
+		return
+	}
+	if paid {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("this student has already paid for this event"),
+		})
+		return
+	}
+	if receiptTaken {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("that receipt number is already recorded for this event"),
+		})
+		return
+	}
+
+	collectorID, _ := middleware.UserID(c)
+	payment := models.EventPayment{
+		EventID:       eventID,
+		UserID:        req.UserID,
+		Amount:        amount,
+		Currency:      *event.Currency,
+		Status:        "paid",
+		Method:        req.Method,
+		CollectedBy:   &collectorID,
+		ReceiptNumber: &req.ReceiptNumber,
+	}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO event_payments (event_id, user_id, amount, currency, status, method, collected_by, receipt_number, tier_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at, updated_at
+	`, eventID, req.UserID, amount, payment.Currency, payment.Status, payment.Method, collectorID,
+		req.ReceiptNumber, req.TierID).Scan(&payment.ID, &payment.CreatedAt, &payment.UpdatedAt)
+	if err != nil {
+		internalError(c, "failed to record payment", err)
+		return
+	}
+
+	soldOut, err := registerPaidAttendee(ctx, tx, eventID, req.UserID, nil, req.TierID)
+	if err != nil {
+		internalError(c, "failed to register for event", err)
+		return
+	}
+	if soldOut {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("this ticket tier is sold out"),
+		})
+		return
+	}
+
+	if err := outbox.Write(ctx, tx, notifications.TopicPaymentCaptured, notifications.PaymentCapturedPayload{
+		PaymentID:  payment.ID,
+		EventID:    eventID,
+		UserID:     req.UserID,
+		EventTitle: event.Title,
+		Amount:     amount,
+		Currency:   payment.Currency,
+	}); err != nil {
+		internalError(c, "failed to record payment", err)
+		return
+	}
+
+	if err := webhooks.Emit(ctx, tx, webhooks.PaymentCaptured, webhooks.PaymentCapturedData{
+		PaymentID: payment.ID,
+		Kind:      "event_registration",
+		EventID:   &eventID,
+		UserID:    req.UserID,
+		Amount:    amount,
+		Currency:  payment.Currency,
+		Method:    payment.Method,
+	}); err != nil {
+		internalError(c, "failed to record payment", err)
+		return
+	}
+
+	if err := audit.Record(ctx, tx, audit.Entry{
+		Action:        audit.ActionOfflinePayment,
+		ActorID:       &collectorID,
+		SubjectUserID: &req.UserID,
+		Method:        c.Request.Method,
+		Path:          c.Request.URL.RequestURI(),
+		Status:        http.StatusCreated,
+		RequestID:     middleware.GetRequestID(c),
+		IPAddress:     c.ClientIP(),
+		Details: map[string]interface{}{
+			"event_id":       eventID,
+			"payment_id":     payment.ID,
+			"method":         payment.Method,
+			"amount":         amount,
+			"receipt_number": req.ReceiptNumber,
+		},
+	}); err != nil {
+		internalError(c, "failed to record payment", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to record payment", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "payment recorded and registration complete",
+		Data:    payment,
+	})
+}
@@ -2,17 +2,29 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
+	"database/sql"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
 	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+	"github.com/yourusername/college-event-backend/internal/services/gamification"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
+	"github.com/yourusername/college-event-backend/internal/services/payments"
+	"github.com/yourusername/college-event-backend/internal/services/wallet"
+	"github.com/yourusername/college-event-backend/internal/webhooks"
 	"github.com/yourusername/college-event-backend/pkg/database"
 )
 
@@ -30,9 +42,11 @@ func NewPaymentHandler(db *database.DB) *PaymentHandler {
 	}
 }
 
-// CreateOrder creates a Razorpay order for event payment
+// CreateOrder creates a Razorpay order for event payment. If the user's
+// wallet covers the price it pays instead and the user is registered
+// straight away.
 func (h *PaymentHandler) CreateOrder(c *gin.Context) {
-	userID, exists := c.Get("user_id")
+	userID, exists := middleware.UserID(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, models.APIResponse{
 			Success: false,
@@ -42,7 +56,7 @@ func (h *PaymentHandler) CreateOrder(c *gin.Context) {
 	}
 
 	var req models.CreateOrderRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Error:   strPtr("invalid request body"),
@@ -53,18 +67,45 @@ func (h *PaymentHandler) CreateOrder(c *gin.Context) {
 	// Get event details
 	var event models.Event
 	err := h.db.QueryRow(`
-		SELECT id, title, is_paid_event, event_amount, currency
+		SELECT id, title, status, is_paid_event, event_amount, currency, end_date, registration_deadline, alumni_access
 		FROM events
 		WHERE id = $1 AND deleted_at IS NULL
-	`, req.EventID).Scan(&event.ID, &event.Title, &event.IsPaidEvent, &event.EventAmount, &event.Currency)
+	`, req.EventID).Scan(&event.ID, &event.Title, &event.Status, &event.IsPaidEvent, &event.EventAmount, &event.Currency,
+		&event.EndDate, &event.RegistrationDeadline, &event.AlumniAccess)
 
-	if err != nil {
+	role, _ := middleware.Role(c)
+	if err != nil || !event.VisibleTo(role) {
 		c.JSON(http.StatusNotFound, models.APIResponse{
 			Success: false,
 			Error:   strPtr("event not found"),
 		})
 		return
 	}
+	if !event.OpenTo(role) {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("this event isn't open to alumni"),
+		})
+		return
+	}
+
+	if event.Status != nil && *event.Status == models.EventStatusCancelled {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("this event has been cancelled"),
+		})
+		return
+	}
+
+	// An order opened before registrations close is still honoured when
+	// it's paid after
+	if !event.RegistrationOpen(time.Now()) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("registration for this event has closed"),
+		})
+		return
+	}
 
 	if !event.IsPaidEvent {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
@@ -74,6 +115,26 @@ func (h *PaymentHandler) CreateOrder(c *gin.Context) {
 		return
 	}
 
+	// Answers to the registration form are checked before any order exists
+	fields, err := loadRegistrationForm(c.Request.Context(), h.db.DB, req.EventID)
+	if err != nil {
+		internalError(c, "failed to fetch registration form", err)
+		return
+	}
+	responses, err := validateFormResponses(fields, req.FormResponses)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	responsesJSON, err := json.Marshal(responses)
+	if err != nil {
+		internalError(c, "failed to create payment record", err)
+		return
+	}
+
 	// Check if user already has a successful payment
 	var existingPayment string
 	err = h.db.QueryRow(`
@@ -89,13 +150,35 @@ func (h *PaymentHandler) CreateOrder(c *gin.Context) {
 		return
 	}
 
+	// Events with ticket tiers are priced by the tier bought
+	amount := *event.EventAmount
+	tiers, err := loadTicketTiers(c.Request.Context(), h.db.DB, req.EventID)
+	if err != nil {
+		internalError(c, "failed to fetch ticket tiers", err)
+		return
+	}
+	if len(tiers) > 0 || req.TierID != nil {
+		tier, ok := selectTicketTier(c, tiers, req.TierID)
+		if !ok {
+			return
+		}
+		amount = tier.Price
+	}
+
 	// Convert amount to paise (Razorpay expects amount in smallest currency unit)
-	amountInPaise := int(*event.EventAmount * 100)
+	amountInPaise := int(math.Round(amount * 100))
 	currency := "INR"
 	if event.Currency != nil {
 		currency = *event.Currency
 	}
 
+	// The wallet pays if its balance covers the price
+	if !req.SkipWallet && currency == wallet.Currency && amount > 0 {
+		if h.payFromWallet(c, userID, event, amount, currency, responsesJSON, req.TierID) {
+			return
+		}
+	}
+
 	// Create order via Razorpay API
 	orderID, err := h.createRazorpayOrder(amountInPaise, currency)
 	if err != nil {
@@ -110,9 +193,9 @@ func (h *PaymentHandler) CreateOrder(c *gin.Context) {
 	// Store pending payment record
 	paymentID := uuid.New()
 	_, err = h.db.Exec(`
-		INSERT INTO event_payments (id, event_id, user_id, razorpay_order_id, amount, currency, status)
-		VALUES ($1, $2, $3, $4, $5, $6, 'pending')
-	`, paymentID, req.EventID, userID, orderID, *event.EventAmount, currency)
+		INSERT INTO event_payments (id, event_id, user_id, razorpay_order_id, amount, currency, status, form_responses, tier_id)
+		VALUES ($1, $2, $3, $4, $5, $6, 'pending', $7, $8)
+	`, paymentID, req.EventID, userID, orderID, amount, currency, responsesJSON, req.TierID)
 
 	if err != nil {
 		fmt.Printf("Failed to store payment record: %v\n", err)
@@ -135,9 +218,101 @@ func (h *PaymentHandler) CreateOrder(c *gin.Context) {
 	})
 }
 
+// payFromWallet pays for an event from the user's wallet and registers them,
+// responding as CreateOrder. Returns false, having done nothing, if the
+// balance doesn't cover amount.
+func (h *PaymentHandler) payFromWallet(c *gin.Context, userID uuid.UUID, event models.Event, amount float64, currency string, formResponses []byte, tierID *uuid.UUID) bool {
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to pay from wallet", err)
+		return true
+	}
+	defer tx.Rollback()
+
+	paymentID := uuid.New()
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO event_payments (id, event_id, user_id, amount, currency, status, method, form_responses, tier_id)
+		VALUES ($1, $2, $3, $4, $5, 'paid', $6, $7, $8)
+	`, paymentID, event.ID, userID, amount, currency, models.PaymentMethodWallet, formResponses, tierID); err != nil {
+		internalError(c, "failed to pay from wallet", err)
+		return true
+	}
+
+	_, err = wallet.Debit(ctx, tx, wallet.Entry{
+		UserID:      userID,
+		Amount:      amount,
+		Kind:        wallet.KindPayment,
+		Description: event.Title,
+		PaymentID:   &paymentID,
+	})
+	if errors.Is(err, wallet.ErrInsufficientFunds) {
+		return false
+	}
+	if err != nil {
+		internalError(c, "failed to pay from wallet", err)
+		return true
+	}
+
+	soldOut, err := registerPaidAttendee(ctx, tx, event.ID, userID, formResponses, tierID)
+	if err != nil {
+		internalError(c, "failed to register for event", err)
+		return true
+	}
+	if soldOut {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("this ticket tier is sold out"),
+		})
+		return true
+	}
+
+	if err := outbox.Write(ctx, tx, notifications.TopicPaymentCaptured, notifications.PaymentCapturedPayload{
+		PaymentID:  paymentID,
+		EventID:    event.ID,
+		UserID:     userID,
+		EventTitle: event.Title,
+		Amount:     amount,
+		Currency:   currency,
+	}); err != nil {
+		internalError(c, "failed to pay from wallet", err)
+		return true
+	}
+
+	if err := webhooks.Emit(ctx, tx, webhooks.PaymentCaptured, webhooks.PaymentCapturedData{
+		PaymentID: paymentID,
+		Kind:      "event_registration",
+		EventID:   &event.ID,
+		UserID:    userID,
+		Amount:    amount,
+		Currency:  currency,
+		Method:    models.PaymentMethodWallet,
+	}); err != nil {
+		internalError(c, "failed to pay from wallet", err)
+		return true
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to pay from wallet", err)
+		return true
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "paid from wallet and registration complete",
+		Data: models.CreateOrderResponse{
+			Amount:         int(math.Round(amount * 100)),
+			Currency:       currency,
+			EventID:        event.ID.String(),
+			PaidWithWallet: true,
+		},
+	})
+	return true
+}
+
 // VerifyPayment verifies the payment signature and registers user for event
 func (h *PaymentHandler) VerifyPayment(c *gin.Context) {
-	userID, exists := c.Get("user_id")
+	userID, exists := middleware.UserID(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, models.APIResponse{
 			Success: false,
@@ -147,7 +322,7 @@ func (h *PaymentHandler) VerifyPayment(c *gin.Context) {
 	}
 
 	var req models.VerifyPaymentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Error:   strPtr("invalid request body"),
@@ -186,13 +361,38 @@ func (h *PaymentHandler) VerifyPayment(c *gin.Context) {
 		return
 	}
 
+	// Payment update, registration and the receipt notification commit together
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   strPtr("failed to update payment record"),
+		})
+		return
+	}
+	defer tx.Rollback()
+
 	// Update payment record
-	_, err = h.db.Exec(`
+	var captured notifications.PaymentCapturedPayload
+	var formResponses []byte
+	var tierID *uuid.UUID
+	err = tx.QueryRowContext(ctx, `
 		UPDATE event_payments
 		SET razorpay_payment_id = $1, razorpay_signature = $2, status = 'paid', updated_at = CURRENT_TIMESTAMP
 		WHERE razorpay_order_id = $3 AND user_id = $4
-	`, req.RazorpayPaymentID, req.RazorpaySignature, req.RazorpayOrderID, userID)
+		RETURNING id, amount, COALESCE(currency, 'INR'), form_responses, tier_id
+	`, req.RazorpayPaymentID, req.RazorpaySignature, req.RazorpayOrderID, userID).Scan(
+		&captured.PaymentID, &captured.Amount, &captured.Currency, &formResponses, &tierID,
+	)
 
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("payment order not found"),
+		})
+		return
+	}
 	if err != nil {
 		fmt.Printf("Failed to update payment record: %v\n", err)
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
@@ -202,23 +402,62 @@ func (h *PaymentHandler) VerifyPayment(c *gin.Context) {
 		return
 	}
 
-	// Register user for event
-	_, err = h.db.Exec(`
-		INSERT INTO event_registrations (event_id, user_id)
-		VALUES ($1, $2)
-		ON CONFLICT (event_id, user_id) DO NOTHING
-	`, eventID, userID)
+	// A payment completed after the event was cancelled is refunded instead
+	// of registering
+	var eventStatus string
+	if err := tx.QueryRowContext(ctx, "SELECT status FROM events WHERE id = $1", eventID).Scan(&eventStatus); err != nil {
+		internalError(c, "failed to register for event", err)
+		return
+	}
+	if eventStatus == models.EventStatusCancelled {
+		h.refundPayment(c, tx, captured.PaymentID, "this event has been cancelled; your payment will be refunded")
+		return
+	}
 
+	soldOut, err := registerPaidAttendee(ctx, tx, eventID, userID, formResponses, tierID)
 	if err != nil {
-		fmt.Printf("Failed to register user for event: %v\n", err)
+		internalError(c, "failed to register for event", err)
+		return
+	}
+	if soldOut {
+		// A tier that sold out while this order was open is refunded
+		h.refundPayment(c, tx, captured.PaymentID, "these tickets sold out; your payment will be refunded")
+		return
 	}
 
-	// Update event participant count
-	h.db.Exec(`
-		UPDATE events 
-		SET current_participants = current_participants + 1, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $1
-	`, eventID)
+	captured.EventID = eventID
+	captured.UserID = userID
+	if err := tx.QueryRowContext(ctx, "SELECT title FROM events WHERE id = $1", eventID).Scan(&captured.EventTitle); err != nil {
+		internalError(c, "failed to update payment record", err)
+		return
+	}
+
+	if err := outbox.Write(ctx, tx, notifications.TopicPaymentCaptured, captured); err != nil {
+		internalError(c, "failed to update payment record", err)
+		return
+	}
+
+	if err := webhooks.Emit(ctx, tx, webhooks.PaymentCaptured, webhooks.PaymentCapturedData{
+		PaymentID:         captured.PaymentID,
+		Kind:              "event_registration",
+		EventID:           &eventID,
+		UserID:            userID,
+		Amount:            captured.Amount,
+		Currency:          captured.Currency,
+		Method:            models.PaymentMethodRazorpay,
+		RazorpayPaymentID: req.RazorpayPaymentID,
+	}); err != nil {
+		internalError(c, "failed to update payment record", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   strPtr("failed to update payment record"),
+		})
+		return
+	}
 
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
@@ -226,9 +465,70 @@ func (h *PaymentHandler) VerifyPayment(c *gin.Context) {
 	})
 }
 
+// registerPaidAttendee registers a user who has paid for an event. A new
+// registration counts the ticket as sold, updates the participant count and
+// awards attendance points; an existing one is left alone. Returns true,
+// having registered no one, if the ticket tier has sold out.
+func registerPaidAttendee(ctx context.Context, tx *sql.Tx, eventID, userID uuid.UUID, formResponses []byte, tierID *uuid.UUID) (bool, error) {
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO event_registrations (event_id, user_id, form_responses, tier_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_id, user_id) DO NOTHING
+	`, eventID, userID, formResponses, tierID)
+	if err != nil {
+		return false, err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return false, nil
+	}
+
+	// Tickets are counted as sold when paid for
+	if tierID != nil {
+		res, err := tx.ExecContext(ctx, `
+			UPDATE ticket_tiers SET sold = sold + 1
+			WHERE id = $1 AND (quantity IS NULL OR sold < quantity)
+		`, *tierID)
+		if err != nil {
+			return false, err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			_, err := tx.ExecContext(ctx,
+				"DELETE FROM event_registrations WHERE event_id = $1 AND user_id = $2", eventID, userID)
+			return true, err
+		}
+	}
+
+	var participants int
+	if err := tx.QueryRowContext(ctx, `
+		UPDATE events 
+		SET current_participants = current_participants + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING current_participants
+	`, eventID).Scan(&participants); err != nil {
+		return false, fmt.Errorf("failed to update participant count: %w", err)
+	}
+
+	if err := outbox.Write(ctx, tx, notifications.TopicEventRegistrationsAdded, notifications.EventRegistrationsAddedPayload{
+		EventID: eventID,
+		Before:  participants - 1,
+		After:   participants,
+	}); err != nil {
+		return false, fmt.Errorf("failed to queue capacity check: %w", err)
+	}
+
+	if err := gamification.Award(ctx, tx, gamification.Entry{
+		UserID:   userID,
+		Source:   gamification.SourceEventAttendance,
+		SourceID: eventID,
+	}); err != nil {
+		return false, fmt.Errorf("failed to award event points: %w", err)
+	}
+	return false, nil
+}
+
 // GetPaymentStatus checks if user has paid for an event
 func (h *PaymentHandler) GetPaymentStatus(c *gin.Context) {
-	userID, exists := c.Get("user_id")
+	userID, exists := middleware.UserID(c)
 	if !exists {
 		c.JSON(http.StatusUnauthorized, models.APIResponse{
 			Success: false,
@@ -249,12 +549,12 @@ func (h *PaymentHandler) GetPaymentStatus(c *gin.Context) {
 
 	var payment models.EventPayment
 	err = h.db.QueryRow(`
-		SELECT razorpay_payment_id, status
+		SELECT razorpay_payment_id, status, method
 		FROM event_payments
 		WHERE event_id = $1 AND user_id = $2
 		ORDER BY created_at DESC
 		LIMIT 1
-	`, eventID, userID).Scan(&payment.RazorpayPaymentID, &payment.Status)
+	`, eventID, userID).Scan(&payment.RazorpayPaymentID, &payment.Status, &payment.Method)
 
 	if err != nil {
 		c.JSON(http.StatusOK, models.APIResponse{
@@ -272,6 +572,7 @@ func (h *PaymentHandler) GetPaymentStatus(c *gin.Context) {
 			HasPaid:   payment.Status == "paid",
 			PaymentID: payment.RazorpayPaymentID,
 			Status:    &payment.Status,
+			Method:    &payment.Method,
 		},
 	})
 }
@@ -321,3 +622,65 @@ func (h *PaymentHandler) createRazorpayOrder(amount int, currency string) (strin
 
 	return orderID, nil
 }
+
+// refundPayment queues a refund for a payment that can't become a
+// registration, commits and answers the request with message
+func (h *PaymentHandler) refundPayment(c *gin.Context, tx *sql.Tx, paymentID uuid.UUID, message string) {
+	ctx := c.Request.Context()
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE event_payments SET status = 'refund_pending', updated_at = CURRENT_TIMESTAMP WHERE id = $1
+	`, paymentID); err != nil {
+		internalError(c, "failed to queue refund", err)
+		return
+	}
+	if err := outbox.Write(ctx, tx, payments.TopicRefundRequested, payments.RefundRequestedPayload{PaymentID: paymentID}); err != nil {
+		internalError(c, "failed to queue refund", err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to queue refund", err)
+		return
+	}
+
+	c.JSON(http.StatusConflict, models.APIResponse{
+		Success: false,
+		Error:   strPtr(message),
+	})
+}
+
+// selectTicketTier finds the tier an order is for among an event's tiers
+// and checks it is on sale. Responds and returns false if it can't be bought.
+func selectTicketTier(c *gin.Context, tiers []models.TicketTier, tierID *uuid.UUID) (models.TicketTier, bool) {
+	if tierID == nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("tier_id is required: choose a ticket tier"),
+		})
+		return models.TicketTier{}, false
+	}
+	for _, t := range tiers {
+		if t.ID != *tierID {
+			continue
+		}
+		if t.Remaining != nil && *t.Remaining == 0 {
+			c.JSON(http.StatusConflict, models.APIResponse{
+				Success: false,
+				Error:   strPtr("this ticket tier is sold out"),
+			})
+			return t, false
+		}
+		if !t.Available {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("this ticket tier is not on sale"),
+			})
+			return t, false
+		}
+		return t, true
+	}
+	c.JSON(http.StatusBadRequest, models.APIResponse{
+		Success: false,
+		Error:   strPtr("unknown ticket tier for this event"),
+	})
+	return models.TicketTier{}, false
+}
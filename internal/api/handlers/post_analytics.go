@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+// GetPostAnalytics reports how a post is watched: views, average watch time
+// and completion, and, for videos, where viewers drop off
+// GET /api/v1/admin/posts/:id/analytics
+func (h *PostsHandler) GetPostAnalytics(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid post ID"),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+	db := h.db.Reader()
+
+	a := models.PostViewAnalytics{PostID: postID, DropOff: []models.DropOffPoint{}}
+	err = db.QueryRowContext(ctx, `
+		SELECT p.content_type, p.duration_seconds,
+			COUNT(v.id),
+			COUNT(DISTINCT v.user_id),
+			COUNT(v.duration_watched_seconds),
+			AVG(v.duration_watched_seconds)::float8,
+			AVG(v.completion_percent)::float8,
+			COUNT(*) FILTER (WHERE v.completion_percent = 100)
+		FROM posts p
+		LEFT JOIN post_views v ON v.post_id = p.id
+		WHERE p.id = $1
+		GROUP BY p.id
+	`, postID).Scan(&a.ContentType, &a.DurationSecs, &a.Views, &a.UniqueViewers, &a.TimedViews,
+		&a.AvgWatchSecs, &a.AvgCompletionPercent, &a.CompletedViews)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Post not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to fetch post analytics", err)
+		return
+	}
+
+	// How many of the views with a completion got at least this far, at
+	// every tenth
+	rows, err := db.QueryContext(ctx, `
+		SELECT at, COUNT(v.id) FILTER (WHERE v.completion_percent >= at), COUNT(v.id)
+		FROM generate_series(0, 100, 10) AS at
+		LEFT JOIN post_views v ON v.post_id = $1 AND v.completion_percent IS NOT NULL
+		GROUP BY at
+		ORDER BY at
+	`, postID)
+	if err != nil {
+		internalError(c, "Failed to fetch post analytics", err)
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var at, watching, total int
+		if err := rows.Scan(&at, &watching, &total); err != nil {
+			internalError(c, "Failed to fetch post analytics", err)
+			return
+		}
+		if total == 0 {
+			continue // Nothing reported a completion
+		}
+		a.DropOff = append(a.DropOff, models.DropOffPoint{
+			AtPercent:       at,
+			WatchingPercent: float64(watching) * 100 / float64(total),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch post analytics", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    a,
+	})
+}
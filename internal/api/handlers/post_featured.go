@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository"
+)
+
+// ListFeaturedPosts lists the featured carousel, in the order admins gave it
+// GET /api/v1/posts/featured
+func (h *PostsHandler) ListFeaturedPosts(c *gin.Context) {
+	var viewerID *uuid.UUID
+	if userID, exists := middleware.UserID(c); exists {
+		viewerID = &userID
+	}
+
+	ctx := c.Request.Context()
+	posts, err := repository.New(h.db.Reader()).ListFeaturedPosts(ctx, viewerID)
+	if err != nil {
+		internalError(c, "Failed to fetch featured posts", err)
+		return
+	}
+
+	if viewerID != nil {
+		for i := range posts {
+			posts[i].MyReaction, _ = userReaction(ctx, h.db.DB, postReactions, posts[i].ID, *viewerID)
+			posts[i].IsLikedByMe = posts[i].MyReaction != nil
+			posts[i].IsSharedByMe = h.checkUserSharedPost(posts[i].ID, *viewerID)
+		}
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    posts,
+	})
+}
+
+// PinPost pins a post to the top of the feed; pinning it again moves it
+// above the other pinned posts
+// POST /api/v1/admin/posts/:id/pin
+func (h *PostsHandler) PinPost(c *gin.Context) {
+	h.setPinned(c, true)
+}
+
+// UnpinPost returns a pinned post to its place in the feed
+// DELETE /api/v1/admin/posts/:id/pin
+func (h *PostsHandler) UnpinPost(c *gin.Context) {
+	h.setPinned(c, false)
+}
+
+func (h *PostsHandler) setPinned(c *gin.Context, pinned bool) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid post ID"),
+		})
+		return
+	}
+
+	var pinnedAt *time.Time
+	err = h.db.QueryRowContext(c.Request.Context(), `
+		UPDATE posts SET pinned_at = CASE WHEN $2 THEN NOW() END
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING pinned_at
+	`, postID, pinned).Scan(&pinnedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Post not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to update post", err)
+		return
+	}
+
+	message := "Post unpinned"
+	if pinned {
+		message = "Post pinned"
+	}
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    gin.H{"id": postID, "pinned_at": pinnedAt},
+	})
+}
+
+// lockFeatured serializes changes to the carousel's order. Locking the
+// featured rows wouldn't stop two posts being featured at once when
+// there are none.
+func lockFeatured(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('posts:featured'))")
+	return err
+}
+
+// FeaturePost adds a published post to the featured carousel at a position,
+// or last without one. A post already featured is moved there.
+// POST /api/v1/admin/posts/:id/feature
+func (h *PostsHandler) FeaturePost(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid post ID"),
+		})
+		return
+	}
+
+	var req models.FeaturePostRequest
+	if err := bindJSON(c, &req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid request: " + err.Error()),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to feature post", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if err := lockFeatured(ctx, tx); err != nil {
+		internalError(c, "Failed to feature post", err)
+		return
+	}
+
+	var status string
+	err = tx.QueryRowContext(ctx,
+		"SELECT status FROM posts WHERE id = $1 AND deleted_at IS NULL", postID,
+	).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Post not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to feature post", err)
+		return
+	}
+	if status != models.PostStatusPublished {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Only published posts can be featured"),
+		})
+		return
+	}
+
+	// Number the other featured posts 0, 1, 2... in their current order,
+	// leaving a gap at the new position
+	var others int
+	if err := tx.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM posts WHERE featured_position IS NOT NULL AND id <> $1", postID,
+	).Scan(&others); err != nil {
+		internalError(c, "Failed to feature post", err)
+		return
+	}
+	position := others
+	if req.Position != nil && *req.Position < others {
+		position = *req.Position
+	}
+	if _, err := tx.ExecContext(ctx, `
+		WITH ordered AS (
+			SELECT id, ROW_NUMBER() OVER (ORDER BY featured_position) - 1 AS pos
+			FROM posts
+			WHERE featured_position IS NOT NULL AND id <> $1
+		)
+		UPDATE posts p
+		SET featured_position = CASE WHEN o.pos >= $2 THEN o.pos + 1 ELSE o.pos END
+		FROM ordered o
+		WHERE p.id = o.id
+		  AND p.featured_position <> CASE WHEN o.pos >= $2 THEN o.pos + 1 ELSE o.pos END
+	`, postID, position); err != nil {
+		internalError(c, "Failed to feature post", err)
+		return
+	}
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE posts SET featured_position = $2 WHERE id = $1", postID, position,
+	); err != nil {
+		internalError(c, "Failed to feature post", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to feature post", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Post featured",
+		Data:    gin.H{"id": postID, "featured_position": position},
+	})
+}
+
+// UnfeaturePost takes a post out of the featured carousel, closing up the
+// gap it leaves
+// DELETE /api/v1/admin/posts/:id/feature
+func (h *PostsHandler) UnfeaturePost(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid post ID"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to unfeature post", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if err := lockFeatured(ctx, tx); err != nil {
+		internalError(c, "Failed to unfeature post", err)
+		return
+	}
+
+	res, err := tx.ExecContext(ctx,
+		"UPDATE posts SET featured_position = NULL WHERE id = $1 AND featured_position IS NOT NULL", postID,
+	)
+	if err != nil {
+		internalError(c, "Failed to unfeature post", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Post is not featured"),
+		})
+		return
+	}
+	if _, err := tx.ExecContext(ctx, `
+		WITH ordered AS (
+			SELECT id, ROW_NUMBER() OVER (ORDER BY featured_position) - 1 AS pos
+			FROM posts
+			WHERE featured_position IS NOT NULL
+		)
+		UPDATE posts p
+		SET featured_position = o.pos
+		FROM ordered o
+		WHERE p.id = o.id AND p.featured_position <> o.pos
+	`); err != nil {
+		internalError(c, "Failed to unfeature post", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to unfeature post", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Post removed from featured",
+	})
+}
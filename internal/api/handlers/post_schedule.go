@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository"
+)
+
+// SchedulePost publishes a draft or scheduled post now, schedules it for
+// publish_at, or with draft turns it back into a draft. A post already
+// published can't be rescheduled.
+// PUT /api/v1/admin/posts/:id/schedule
+func (h *PostsHandler) SchedulePost(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid post ID"),
+		})
+		return
+	}
+
+	var req models.SchedulePostRequest
+	if err := bindJSON(c, &req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid request: " + err.Error()),
+		})
+		return
+	}
+	if req.Draft && req.PublishAt != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("A post can't be both a draft and scheduled"),
+		})
+		return
+	}
+	if req.PublishAt != nil && !req.PublishAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("publish_at must be in the future"),
+		})
+		return
+	}
+
+	status := models.PostStatusPublished
+	switch {
+	case req.Draft:
+		status = models.PostStatusDraft
+	case req.PublishAt != nil:
+		status = models.PostStatusScheduled
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to schedule post", err)
+		return
+	}
+	defer tx.Rollback()
+
+	var held bool
+	err = tx.QueryRowContext(ctx, `
+		UPDATE posts
+		SET status = $2, publish_at = $3,
+			published_at = CASE WHEN $2 = 'published' THEN NOW() END
+		WHERE id = $1 AND deleted_at IS NULL AND status <> 'published'
+		RETURNING held_for_review
+	`, postID, status, req.PublishAt).Scan(&held)
+	if errors.Is(err, sql.ErrNoRows) {
+		var exists bool
+		if err := tx.QueryRowContext(ctx,
+			"SELECT EXISTS (SELECT 1 FROM posts WHERE id = $1 AND deleted_at IS NULL)", postID,
+		).Scan(&exists); err != nil {
+			internalError(c, "Failed to schedule post", err)
+			return
+		}
+		if exists {
+			c.JSON(http.StatusConflict, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Post is already published"),
+			})
+			return
+		}
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Post not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to schedule post", err)
+		return
+	}
+
+	// A held post's followers are notified once it's approved
+	if status == models.PostStatusPublished && !held {
+		if err := notifyPostPublished(ctx, tx, postID); err != nil {
+			internalError(c, "Failed to schedule post", err)
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to schedule post", err)
+		return
+	}
+
+	message := "Post published"
+	switch status {
+	case models.PostStatusDraft:
+		message = "Post saved as a draft"
+	case models.PostStatusScheduled:
+		message = "Post scheduled"
+	}
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    gin.H{"id": postID, "status": status, "publish_at": req.PublishAt},
+	})
+}
+
+// ListUnpublishedPosts lists drafts and scheduled posts, scheduled posts
+// soonest due first, then drafts, newest first
+// GET /api/v1/admin/posts/unpublished
+func (h *PostsHandler) ListUnpublishedPosts(c *gin.Context) {
+	var query models.ListUnpublishedPostsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid query parameters"),
+		})
+		return
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 {
+		query.PageSize = 20
+	}
+	if query.PageSize > 100 {
+		query.PageSize = 100
+	}
+
+	ctx := c.Request.Context()
+	q := repository.New(h.db.Reader())
+	total, err := q.CountUnpublishedPosts(ctx, query.Status)
+	if err != nil {
+		internalError(c, "Failed to fetch posts", err)
+		return
+	}
+	posts, err := q.ListUnpublishedPosts(ctx, query.Status, query.Page, query.PageSize)
+	if err != nil {
+		internalError(c, "Failed to fetch posts", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: models.PaginatedResponse{
+			Data:       posts,
+			Page:       query.Page,
+			PageSize:   query.PageSize,
+			TotalItems: int64(total),
+			TotalPages: (total + query.PageSize - 1) / query.PageSize,
+		},
+	})
+}
@@ -1,32 +1,51 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
 	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+	"github.com/yourusername/college-event-backend/internal/repository"
+	"github.com/yourusername/college-event-backend/internal/services/gamification"
+	"github.com/yourusername/college-event-backend/internal/services/moderation"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
+	"github.com/yourusername/college-event-backend/internal/services/security"
+	"github.com/yourusername/college-event-backend/pkg/database"
 )
 
 // PostsHandler handles post-related requests
 type PostsHandler struct {
-	db *sql.DB
+	db       *database.DB
+	filter   *moderation.Filter
+	images   moderation.ImageChecker
+	security *security.Detector
 }
 
-// NewPostsHandler creates a new posts handler
-func NewPostsHandler(db *sql.DB) *PostsHandler {
-	return &PostsHandler{db: db}
+// NewPostsHandler creates a new posts handler. Comments are screened by
+// filter and post images by images; either may be nil. Comment spam is
+// watched for by detector.
+func NewPostsHandler(db *database.DB, filter *moderation.Filter, images moderation.ImageChecker, detector *security.Detector) *PostsHandler {
+	return &PostsHandler{db: db, filter: filter, images: images, security: detector}
 }
 
-// CreatePost creates a new post (admin-only)
+// CreatePost creates a new post (admin-only). A post whose image fails the
+// image check is held for review instead of published. With draft it's
+// saved unpublished, and with publish_at it's published then.
 // POST /api/v1/admin/posts
 func (h *PostsHandler) CreatePost(c *gin.Context) {
 	var req models.CreatePostRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Error:   strPtr("Invalid request: " + err.Error()),
@@ -35,8 +54,7 @@ func (h *PostsHandler) CreatePost(c *gin.Context) {
 	}
 
 	// Get user ID from context
-	userID, _ := c.Get("user_id")
-	creatorID := userID.(uuid.UUID)
+	creatorID, _ := middleware.UserID(c)
 
 	// Validate content type matches URLs
 	if req.ContentType == models.ContentTypeImage && req.ImageURL == nil {
@@ -54,14 +72,49 @@ func (h *PostsHandler) CreatePost(c *gin.Context) {
 		return
 	}
 
+	if req.Draft && req.PublishAt != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("A post can't be both a draft and scheduled"),
+		})
+		return
+	}
+	if req.PublishAt != nil && !req.PublishAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("publish_at must be in the future"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if ok, err := audienceHouseExists(ctx, h.db.DB, req.Audience); err != nil {
+		internalError(c, "Failed to create post", err)
+		return
+	} else if !ok {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Audience house not found"),
+		})
+		return
+	}
+
+	imageReasons, err := moderation.CheckImages(ctx, h.images, req.ImageURL, req.ThumbnailURL)
+	if err != nil {
+		logInternalError(c, "Image check failed, holding post for review", err)
+	}
+
 	// Insert post
 	query := `
 		INSERT INTO posts (
 			created_by, club_id, house_id, content_type, 
 			image_url, video_url, thumbnail_url, duration_seconds,
-			description, hashtags
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id, created_at, updated_at, storage_class
+			description, hashtags, held_for_review,
+			status, publish_at, published_at,
+			audience_department, audience_year, audience_house_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13,
+			CASE WHEN $12 = 'published' THEN NOW() END, $14, $15, $16)
+		RETURNING id, created_at, updated_at, storage_class, published_at
 	`
 
 	var post models.Post
@@ -78,13 +131,33 @@ func (h *PostsHandler) CreatePost(c *gin.Context) {
 	if post.Hashtags == nil {
 		post.Hashtags = []string{}
 	}
+	post.HeldForReview = len(imageReasons) > 0
+	post.PublishAt = req.PublishAt
+	post.Audience = req.Audience
+	switch {
+	case req.Draft:
+		post.Status = models.PostStatusDraft
+	case req.PublishAt != nil:
+		post.Status = models.PostStatusScheduled
+	default:
+		post.Status = models.PostStatusPublished
+	}
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to create post", err)
+		return
+	}
+	defer tx.Rollback()
 
-	err := h.db.QueryRow(
-		query,
+	err = tx.QueryRowContext(
+		ctx, query,
 		creatorID, req.ClubID, req.HouseID, req.ContentType,
 		req.ImageURL, req.VideoURL, req.ThumbnailURL, req.DurationSecs,
-		req.Description, pq.Array(post.Hashtags),
-	).Scan(&post.ID, &post.CreatedAt, &post.UpdatedAt, &post.StorageClass)
+		req.Description, pq.Array(post.Hashtags), post.HeldForReview,
+		post.Status, req.PublishAt,
+		req.Audience.Department, req.Audience.Year, req.Audience.HouseID,
+	).Scan(&post.ID, &post.CreatedAt, &post.UpdatedAt, &post.StorageClass, &post.PublishedAt)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
@@ -94,13 +167,67 @@ func (h *PostsHandler) CreatePost(c *gin.Context) {
 		return
 	}
 
+	if err := gamification.Award(ctx, tx, gamification.Entry{
+		UserID:   creatorID,
+		Source:   gamification.SourcePost,
+		SourceID: post.ID,
+	}); err != nil {
+		internalError(c, "Failed to create post", err)
+		return
+	}
+
+	// A held post's followers are notified once it's approved, and a draft
+	// or scheduled post's once it's published
+	if post.HeldForReview {
+		if err := moderation.RecordFlag(ctx, tx, moderation.ContentPost, post.ID, creatorID, imageReasons); err != nil {
+			internalError(c, "Failed to create post", err)
+			return
+		}
+	} else if post.Status == models.PostStatusPublished {
+		if err := notifyPostPublished(ctx, tx, post.ID); err != nil {
+			internalError(c, "Failed to create post", err)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to create post", err)
+		return
+	}
+
+	message := "Post created successfully"
+	switch {
+	case post.HeldForReview:
+		message = "Post created and held for review"
+	case post.Status == models.PostStatusDraft:
+		message = "Post saved as a draft"
+	case post.Status == models.PostStatusScheduled:
+		message = "Post scheduled"
+	}
 	c.JSON(http.StatusCreated, models.APIResponse{
 		Success: true,
-		Message: "Post created successfully",
+		Message: message,
 		Data:    post,
 	})
 }
 
+// notifyPostPublished queues the notifications to a post's followers and
+// audience, in the transaction publishing it
+func notifyPostPublished(ctx context.Context, tx database.Execer, postID uuid.UUID) error {
+	return outbox.Write(ctx, tx, notifications.TopicPostPublished, notifications.PostPublishedPayload{PostID: postID})
+}
+
+// audienceHouseExists reports whether the house a post is targeted at, if
+// any, exists
+func audienceHouseExists(ctx context.Context, db *sql.DB, audience models.PostAudience) (bool, error) {
+	if audience.HouseID == nil {
+		return true, nil
+	}
+	var exists bool
+	err := db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM houses WHERE id = $1)", *audience.HouseID).Scan(&exists)
+	return exists, err
+}
+
 // ListPosts lists posts with pagination
 // GET /api/v1/posts
 func (h *PostsHandler) ListPosts(c *gin.Context) {
@@ -121,104 +248,34 @@ func (h *PostsHandler) ListPosts(c *gin.Context) {
 		query.PageSize = 20
 	}
 
-	offset := (query.Page - 1) * query.PageSize
-
-	// Build query with filters
-	whereClause := "WHERE p.deleted_at IS NULL"
-	args := []interface{}{}
-	argCount := 1
-
-	if query.Hashtag != nil {
-		whereClause += " AND $" + strconv.Itoa(argCount) + " = ANY(p.hashtags)"
-		args = append(args, *query.Hashtag)
-		argCount++
+	if userID, exists := middleware.UserID(c); exists {
+		query.ViewerID = &userID
 	}
 
-	if query.ClubID != nil {
-		whereClause += " AND p.club_id = $" + strconv.Itoa(argCount)
-		args = append(args, *query.ClubID)
-		argCount++
-	}
-
-	if query.HouseID != nil {
-		whereClause += " AND p.house_id = $" + strconv.Itoa(argCount)
-		args = append(args, *query.HouseID)
-		argCount++
-	}
-
-	if query.Search != nil {
-		searchTerm := "%" + strings.ToLower(*query.Search) + "%"
-		whereClause += " AND LOWER(p.description) LIKE $" + strconv.Itoa(argCount)
-		args = append(args, searchTerm)
-		argCount++
-	}
+	ctx := c.Request.Context()
+	q := repository.New(h.db.Reader())
 
 	// Get total count
-	var totalCount int
-	countQuery := "SELECT COUNT(*) FROM posts p " + whereClause
-	err := h.db.QueryRow(countQuery, args...).Scan(&totalCount)
+	totalCount, err := q.CountPosts(ctx, query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Error:   strPtr("Failed to get post count"),
-		})
+		internalError(c, "Failed to get post count", err)
 		return
 	}
 
 	// Get posts
-	postsQuery := `
-		SELECT 
-			p.id, p.created_by, p.club_id, p.house_id,
-			p.content_type, p.image_url, p.video_url, p.thumbnail_url, p.duration_seconds,
-			p.description, p.hashtags, p.created_at, p.updated_at,
-			p.archived_at, p.storage_class,
-			p.like_count, p.comment_count, p.share_count, p.view_count,
-			u.id, u.full_name, u.avatar_url, u.role
-		FROM posts p
-		JOIN users u ON p.created_by = u.id
-		` + whereClause + `
-		ORDER BY p.created_at DESC
-		LIMIT $` + strconv.Itoa(argCount) + ` OFFSET $` + strconv.Itoa(argCount+1)
-
-	args = append(args, query.PageSize, offset)
-
-	rows, err := h.db.Query(postsQuery, args...)
+	posts, err := q.ListPosts(ctx, query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Error:   strPtr("Failed to fetch posts"),
-		})
+		internalError(c, "Failed to fetch posts", err)
 		return
 	}
-	defer rows.Close()
-
-	posts := []models.PostResponse{}
-	for rows.Next() {
-		var pr models.PostResponse
-		var hashtags pq.StringArray
 
-		err := rows.Scan(
-			&pr.ID, &pr.CreatedBy, &pr.ClubID, &pr.HouseID,
-			&pr.ContentType, &pr.ImageURL, &pr.VideoURL, &pr.ThumbnailURL, &pr.DurationSecs,
-			&pr.Description, &hashtags, &pr.CreatedAt, &pr.UpdatedAt,
-			&pr.ArchivedAt, &pr.StorageClass,
-			&pr.LikeCount, &pr.CommentCount, &pr.ShareCount, &pr.ViewCount,
-			&pr.Creator.ID, &pr.Creator.FullName, &pr.Creator.AvatarURL, &pr.Creator.Role,
-		)
-		if err != nil {
-			continue
+	// Check if current user reacted to/shared each post
+	if userID, exists := middleware.UserID(c); exists {
+		for i := range posts {
+			posts[i].MyReaction, _ = userReaction(ctx, h.db.DB, postReactions, posts[i].ID, userID)
+			posts[i].IsLikedByMe = posts[i].MyReaction != nil
+			posts[i].IsSharedByMe = h.checkUserSharedPost(posts[i].ID, userID)
 		}
-
-		pr.Hashtags = hashtags
-
-		// Check if current user liked this post
-		userID, exists := c.Get("user_id")
-		if exists {
-			pr.IsLikedByMe = h.checkUserLikedPost(pr.ID, userID.(uuid.UUID))
-			pr.IsSharedByMe = h.checkUserSharedPost(pr.ID, userID.(uuid.UUID))
-		}
-
-		posts = append(posts, pr)
 	}
 
 	totalPages := (totalCount + query.PageSize - 1) / query.PageSize
@@ -247,31 +304,13 @@ func (h *PostsHandler) GetPost(c *gin.Context) {
 		return
 	}
 
-	// Get post with creator info
-	query := `
-		SELECT 
-			p.id, p.created_by, p.club_id, p.house_id,
-			p.content_type, p.image_url, p.video_url, p.thumbnail_url, p.duration_seconds,
-			p.description, p.hashtags, p.created_at, p.updated_at,
-			p.archived_at, p.storage_class,
-			p.like_count, p.comment_count, p.share_count, p.view_count,
-			u.id, u.full_name, u.avatar_url, u.role
-		FROM posts p
-		JOIN users u ON p.created_by = u.id
-		WHERE p.id = $1 AND p.deleted_at IS NULL
-	`
-
-	var pr models.PostResponse
-	var hashtags pq.StringArray
+	var viewerID *uuid.UUID
+	if userID, exists := middleware.UserID(c); exists {
+		viewerID = &userID
+	}
 
-	err = h.db.QueryRow(query, postID).Scan(
-		&pr.ID, &pr.CreatedBy, &pr.ClubID, &pr.HouseID,
-		&pr.ContentType, &pr.ImageURL, &pr.VideoURL, &pr.ThumbnailURL, &pr.DurationSecs,
-		&pr.Description, &hashtags, &pr.CreatedAt, &pr.UpdatedAt,
-		&pr.ArchivedAt, &pr.StorageClass,
-		&pr.LikeCount, &pr.CommentCount, &pr.ShareCount, &pr.ViewCount,
-		&pr.Creator.ID, &pr.Creator.FullName, &pr.Creator.AvatarURL, &pr.Creator.Role,
-	)
+	// Get post with creator info
+	pr, err := repository.New(h.db.Reader()).GetPost(c.Request.Context(), postID, viewerID)
 
 	if err == sql.ErrNoRows {
 		c.JSON(http.StatusNotFound, models.APIResponse{
@@ -288,13 +327,11 @@ func (h *PostsHandler) GetPost(c *gin.Context) {
 		return
 	}
 
-	pr.Hashtags = hashtags
-
-	// Check if current user liked/shared
-	userID, exists := c.Get("user_id")
-	if exists {
-		pr.IsLikedByMe = h.checkUserLikedPost(pr.ID, userID.(uuid.UUID))
-		pr.IsSharedByMe = h.checkUserSharedPost(pr.ID, userID.(uuid.UUID))
+	// Check if current user reacted to/shared
+	if viewerID != nil {
+		pr.MyReaction, _ = userReaction(c.Request.Context(), h.db.DB, postReactions, pr.ID, *viewerID)
+		pr.IsLikedByMe = pr.MyReaction != nil
+		pr.IsSharedByMe = h.checkUserSharedPost(pr.ID, *viewerID)
 	}
 
 	c.JSON(http.StatusOK, models.APIResponse{
@@ -303,7 +340,8 @@ func (h *PostsHandler) GetPost(c *gin.Context) {
 	})
 }
 
-// UpdatePost updates a post (admin-only)
+// UpdatePost updates a post (admin-only). If the body names the version it
+// was edited from and the post has changed since, it's refused with 409.
 // PUT /api/v1/admin/posts/:id
 func (h *PostsHandler) UpdatePost(c *gin.Context) {
 	postID, err := uuid.Parse(c.Param("id"))
@@ -316,10 +354,10 @@ func (h *PostsHandler) UpdatePost(c *gin.Context) {
 	}
 
 	var req models.UpdatePostRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
-			Error:   strPtr("Invalid request"),
+			Error:   strPtr("Invalid request: " + err.Error()),
 		})
 		return
 	}
@@ -349,24 +387,39 @@ func (h *PostsHandler) UpdatePost(c *gin.Context) {
 		return
 	}
 
+	updates = append(updates, "version = version + 1")
 	query := "UPDATE posts SET " + strings.Join(updates, ", ") +
-		" WHERE id = $" + strconv.Itoa(argCount) + " AND deleted_at IS NULL"
-	args = append(args, postID)
+		" WHERE id = $" + strconv.Itoa(argCount) + " AND deleted_at IS NULL" +
+		" AND ($" + strconv.Itoa(argCount+1) + "::int IS NULL OR version = $" + strconv.Itoa(argCount+1) + ")" +
+		" RETURNING version"
+	args = append(args, postID, req.Version)
 
-	result, err := h.db.Exec(query, args...)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
+	var version int
+	err = h.db.QueryRow(query, args...).Scan(&version)
+	if err == sql.ErrNoRows {
+		current, ok, err := staleVersion(c.Request.Context(), h.db.DB, postVersionQuery, postID)
+		if err != nil {
+			internalError(c, "Failed to update post", err)
+			return
+		}
+		if ok {
+			c.JSON(http.StatusConflict, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Post was edited by someone else since you loaded it; reload it and reapply your changes"),
+				Data:    gin.H{"version": current},
+			})
+			return
+		}
+		c.JSON(http.StatusNotFound, models.APIResponse{
 			Success: false,
-			Error:   strPtr("Failed to update post"),
+			Error:   strPtr("Post not found"),
 		})
 		return
 	}
-
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		c.JSON(http.StatusNotFound, models.APIResponse{
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
-			Error:   strPtr("Post not found"),
+			Error:   strPtr("Failed to update post"),
 		})
 		return
 	}
@@ -374,6 +427,7 @@ func (h *PostsHandler) UpdatePost(c *gin.Context) {
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
 		Message: "Post updated successfully",
+		Data:    gin.H{"id": postID, "version": version},
 	})
 }
 
@@ -443,7 +497,8 @@ func (h *PostsHandler) HardDeletePost(c *gin.Context) {
 	})
 }
 
-// ToggleLike toggles like on a post
+// ToggleLike removes the user's reaction to a post if they have one, and
+// likes the post otherwise
 // POST /api/v1/posts/:id/like
 func (h *PostsHandler) ToggleLike(c *gin.Context) {
 	postID, err := uuid.Parse(c.Param("id"))
@@ -455,8 +510,7 @@ func (h *PostsHandler) ToggleLike(c *gin.Context) {
 		return
 	}
 
-	userID, _ := c.Get("user_id")
-	uid := userID.(uuid.UUID)
+	uid, _ := middleware.UserID(c)
 
 	// Check if already liked
 	var exists bool
@@ -480,6 +534,23 @@ func (h *PostsHandler) ToggleLike(c *gin.Context) {
 		})
 	} else {
 		// Like
+		var ownerID uuid.UUID
+		err = h.db.QueryRow("SELECT created_by FROM posts WHERE id = $1 AND deleted_at IS NULL", postID).Scan(&ownerID)
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Post not found"),
+			})
+			return
+		}
+		if err != nil {
+			internalError(c, "Failed to like post", err)
+			return
+		}
+		if !requireCanInteract(c, h.db, uid, &ownerID, false) {
+			return
+		}
+
 		_, err = h.db.Exec("INSERT INTO post_likes (post_id, user_id) VALUES ($1, $2)", postID, uid)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, models.APIResponse{
@@ -509,7 +580,7 @@ func (h *PostsHandler) AddComment(c *gin.Context) {
 	}
 
 	var req models.CreateCommentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Error:   strPtr("Invalid request"),
@@ -517,8 +588,36 @@ func (h *PostsHandler) AddComment(c *gin.Context) {
 		return
 	}
 
-	userID, _ := c.Get("user_id")
-	uid := userID.(uuid.UUID)
+	uid, _ := middleware.UserID(c)
+
+	var ownerID uuid.UUID
+	err = h.db.QueryRow("SELECT created_by FROM posts WHERE id = $1 AND deleted_at IS NULL", postID).Scan(&ownerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Post not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to add comment", err)
+		return
+	}
+	if !requireCanInteract(c, h.db, uid, &ownerID, true) {
+		return
+	}
+	screened, ok := screenContent(c, h.filter, "comment", req.Content)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to add comment", err)
+		return
+	}
+	defer tx.Rollback()
 
 	query := `
 		INSERT INTO post_comments (post_id, user_id, content)
@@ -527,7 +626,7 @@ func (h *PostsHandler) AddComment(c *gin.Context) {
 	`
 
 	var comment models.PostComment
-	err = h.db.QueryRow(query, postID, uid, req.Content).
+	err = tx.QueryRowContext(ctx, query, postID, uid, req.Content).
 		Scan(&comment.ID, &comment.CreatedAt)
 
 	if err != nil {
@@ -538,6 +637,21 @@ func (h *PostsHandler) AddComment(c *gin.Context) {
 		return
 	}
 
+	if screened.Verdict == moderation.Flag {
+		if err := moderation.RecordFlag(ctx, tx, moderation.ContentPostComment, comment.ID, uid, screened.Reasons); err != nil {
+			internalError(c, "Failed to add comment", err)
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to add comment", err)
+		return
+	}
+
+	if err := h.security.Commented(ctx, uid, c.ClientIP()); err != nil {
+		logInternalError(c, "Failed to check comment pace", err)
+	}
+
 	comment.PostID = postID
 	comment.UserID = uid
 	comment.Content = req.Content
@@ -561,9 +675,8 @@ func (h *PostsHandler) DeleteComment(c *gin.Context) {
 		return
 	}
 
-	userID, _ := c.Get("user_id")
-	uid := userID.(uuid.UUID)
-	userRole, _ := c.Get("user_role")
+	uid, _ := middleware.UserID(c)
+	userRole, _ := middleware.Role(c)
 
 	// Check if user owns the comment or is admin
 	var ownerID uuid.UUID
@@ -578,7 +691,7 @@ func (h *PostsHandler) DeleteComment(c *gin.Context) {
 
 	// Allow deletion if owner or admin
 	isOwner := ownerID == uid
-	isAdmin := userRole.(models.UserRole) == models.RoleAdmin || userRole.(models.UserRole) == models.RoleFaculty
+	isAdmin := userRole == models.RoleAdmin || userRole == models.RoleFaculty
 
 	if !isOwner && !isAdmin {
 		c.JSON(http.StatusForbidden, models.APIResponse{
@@ -617,10 +730,9 @@ func (h *PostsHandler) TrackShare(c *gin.Context) {
 	}
 
 	var req models.CreateShareRequest
-	c.ShouldBindJSON(&req) // Optional binding
+	bindJSON(c, &req) // Optional binding
 
-	userID, _ := c.Get("user_id")
-	uid := userID.(uuid.UUID)
+	uid, _ := middleware.UserID(c)
 
 	query := "INSERT INTO post_shares (post_id, user_id, share_method) VALUES ($1, $2, $3)"
 	_, err = h.db.Exec(query, postID, uid, req.ShareMethod)
@@ -638,7 +750,9 @@ func (h *PostsHandler) TrackShare(c *gin.Context) {
 	})
 }
 
-// TrackView tracks a post view
+// TrackView tracks a post view. The body, optional, reports how long the
+// viewer watched and how far through a video they got; completion is
+// worked out from the watch time and the video's length if not given.
 // POST /api/v1/posts/:id/view
 func (h *PostsHandler) TrackView(c *gin.Context) {
 	postID, err := uuid.Parse(c.Param("id"))
@@ -650,15 +764,29 @@ func (h *PostsHandler) TrackView(c *gin.Context) {
 		return
 	}
 
-	userID, exists := c.Get("user_id")
+	var req models.TrackViewRequest
+	if err := bindJSON(c, &req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid request: " + err.Error()),
+		})
+		return
+	}
+
 	var uid *uuid.UUID
-	if exists {
-		u := userID.(uuid.UUID)
-		uid = &u
+	if userID, exists := middleware.UserID(c); exists {
+		uid = &userID
 	}
 
-	query := "INSERT INTO post_views (post_id, user_id) VALUES ($1, $2)"
-	_, err = h.db.Exec(query, postID, uid)
+	query := `
+		INSERT INTO post_views (post_id, user_id, duration_watched_seconds, completion_percent)
+		SELECT id, $2, $3::int, COALESCE($4::int, CASE
+			WHEN content_type = 'video' AND duration_seconds > 0 THEN LEAST(100, $3::int * 100 / duration_seconds)
+		END)
+		FROM posts
+		WHERE id = $1
+	`
+	_, err = h.db.Exec(query, postID, uid, req.DurationWatchedSecs, req.CompletionPercent)
 	if err != nil {
 		// Silently fail for views (non-critical)
 		c.JSON(http.StatusOK, models.APIResponse{
@@ -674,13 +802,6 @@ func (h *PostsHandler) TrackView(c *gin.Context) {
 }
 
 // Helper functions
-func (h *PostsHandler) checkUserLikedPost(postID, userID uuid.UUID) bool {
-	var exists bool
-	query := "SELECT EXISTS(SELECT 1 FROM post_likes WHERE post_id = $1 AND user_id = $2)"
-	h.db.QueryRow(query, postID, userID).Scan(&exists)
-	return exists
-}
-
 func (h *PostsHandler) checkUserSharedPost(postID, userID uuid.UUID) bool {
 	var exists bool
 	query := "SELECT EXISTS(SELECT 1 FROM post_shares WHERE post_id = $1 AND user_id = $2)"
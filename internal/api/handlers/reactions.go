@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+// reactionTarget is a kind of content users can react to
+type reactionTarget struct {
+	notFound   string // Error when the content doesn't exist
+	table      string // Reactions table, named for the likes it started as
+	column     string // Column of table referencing the content
+	ownerQuery string // Selects the content's creator by ID
+}
+
+var (
+	postReactions = reactionTarget{
+		notFound:   "Post not found",
+		table:      "post_likes",
+		column:     "post_id",
+		ownerQuery: "SELECT created_by FROM posts WHERE id = $1 AND deleted_at IS NULL",
+	}
+	announcementReactions = reactionTarget{
+		notFound:   "Announcement not found",
+		table:      "announcement_likes",
+		column:     "announcement_id",
+		ownerQuery: "SELECT created_by FROM house_announcements WHERE id = $1 AND deleted_at IS NULL",
+	}
+)
+
+// userReaction returns the user's reaction to content, or nil if they
+// haven't reacted
+func userReaction(ctx context.Context, db *sql.DB, t reactionTarget, contentID, userID uuid.UUID) (*string, error) {
+	var reaction string
+	err := db.QueryRowContext(ctx,
+		"SELECT reaction FROM "+t.table+" WHERE "+t.column+" = $1 AND user_id = $2", contentID, userID).Scan(&reaction)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &reaction, nil
+}
+
+// setReaction sets the user's reaction to the content in the :id param,
+// replacing any reaction they had
+func setReaction(c *gin.Context, db *sql.DB, t reactionTarget) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid ID"),
+		})
+		return
+	}
+
+	var req models.ReactRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid request: " + err.Error()),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	userID, _ := middleware.UserID(c)
+
+	var ownerID *uuid.UUID
+	err = db.QueryRowContext(ctx, t.ownerQuery, contentID).Scan(&ownerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr(t.notFound),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to react", err)
+		return
+	}
+	if !requireCanInteract(c, db, userID, ownerID, false) {
+		return
+	}
+
+	// Only a changed reaction touches the row, so the count trigger sees
+	// real changes only
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO `+t.table+` (`+t.column+`, user_id, reaction)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (`+t.column+`, user_id) DO UPDATE SET reaction = EXCLUDED.reaction
+		WHERE `+t.table+`.reaction <> EXCLUDED.reaction
+	`, contentID, userID, req.Reaction)
+	if err != nil {
+		internalError(c, "Failed to react", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Reaction saved",
+		Data:    gin.H{"reaction": req.Reaction},
+	})
+}
+
+// removeReaction removes the user's reaction to the content in the :id
+// param. Removing a reaction that doesn't exist succeeds.
+func removeReaction(c *gin.Context, db *sql.DB, t reactionTarget) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid ID"),
+		})
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+	if _, err := db.ExecContext(c.Request.Context(),
+		"DELETE FROM "+t.table+" WHERE "+t.column+" = $1 AND user_id = $2", contentID, userID); err != nil {
+		internalError(c, "Failed to remove reaction", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Reaction removed",
+		Data:    gin.H{"reaction": nil},
+	})
+}
+
+// React sets the user's reaction to a post: like, love, clap or fire
+// POST /api/v1/posts/:id/reaction
+func (h *PostsHandler) React(c *gin.Context) {
+	setReaction(c, h.db.DB, postReactions)
+}
+
+// RemoveReaction removes the user's reaction to a post
+// DELETE /api/v1/posts/:id/reaction
+func (h *PostsHandler) RemoveReaction(c *gin.Context) {
+	removeReaction(c, h.db.DB, postReactions)
+}
+
+// React sets the user's reaction to an announcement: like, love, clap or
+// fire
+// POST /api/v1/announcements/:id/reaction
+func (h *HouseHandler) React(c *gin.Context) {
+	setReaction(c, h.DB, announcementReactions)
+}
+
+// RemoveReaction removes the user's reaction to an announcement
+// DELETE /api/v1/announcements/:id/reaction
+func (h *HouseHandler) RemoveReaction(c *gin.Context) {
+	removeReaction(c, h.DB, announcementReactions)
+}
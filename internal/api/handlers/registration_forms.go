@@ -0,0 +1,467 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+const (
+	defaultTextLength     = 500
+	defaultTextareaLength = 5000
+)
+
+var formFieldKey = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// validateFormSchema checks a registration form is well formed: keys are
+// unique identifiers and choice fields have options
+func validateFormSchema(fields []models.FormField) error {
+	seen := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		if !formFieldKey.MatchString(f.Key) {
+			return fmt.Errorf("field %q: key must be lowercase letters, digits and underscores", f.Key)
+		}
+		if seen[f.Key] {
+			return fmt.Errorf("field %q: duplicate key", f.Key)
+		}
+		seen[f.Key] = true
+
+		switch f.Type {
+		case models.FormFieldSelect, models.FormFieldMultiSelect:
+			if len(f.Options) == 0 {
+				return fmt.Errorf("field %q: %s fields need options", f.Key, f.Type)
+			}
+		default:
+			if len(f.Options) > 0 {
+				return fmt.Errorf("field %q: only select fields have options", f.Key)
+			}
+		}
+		if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+			return fmt.Errorf("field %q: min is greater than max", f.Key)
+		}
+	}
+	return nil
+}
+
+// validateFormResponses checks responses against a registration form and
+// returns them with empty answers dropped. Unknown keys are rejected.
+func validateFormResponses(fields []models.FormField, responses models.FormResponses) (models.FormResponses, error) {
+	byKey := make(map[string]models.FormField, len(fields))
+	for _, f := range fields {
+		byKey[f.Key] = f
+	}
+	for key := range responses {
+		if _, ok := byKey[key]; !ok {
+			return nil, fmt.Errorf("unknown field %q", key)
+		}
+	}
+
+	clean := make(models.FormResponses, len(responses))
+	for _, f := range fields {
+		v, present := responses[f.Key]
+		if !present || isEmptyAnswer(v) {
+			if f.Required {
+				return nil, fmt.Errorf("%s is required", f.Label)
+			}
+			continue
+		}
+		if err := validateAnswer(f, v); err != nil {
+			return nil, fmt.Errorf("%s %w", f.Label, err)
+		}
+		if f.Type == models.FormFieldCheckbox && f.Required && v != true {
+			return nil, fmt.Errorf("%s must be checked", f.Label)
+		}
+		clean[f.Key] = v
+	}
+	return clean, nil
+}
+
+func isEmptyAnswer(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// validateAnswer checks a non-empty answer against its field. Errors read
+// after the field's label.
+func validateAnswer(f models.FormField, v interface{}) error {
+	switch f.Type {
+	case models.FormFieldText, models.FormFieldTextarea, models.FormFieldURL, models.FormFieldEmail:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("must be text")
+		}
+		maxLength := f.MaxLength
+		if maxLength == 0 {
+			maxLength = defaultTextLength
+			if f.Type == models.FormFieldTextarea {
+				maxLength = defaultTextareaLength
+			}
+		}
+		if len([]rune(s)) > maxLength {
+			return fmt.Errorf("must be at most %d characters", maxLength)
+		}
+		switch f.Type {
+		case models.FormFieldURL:
+			u, err := url.Parse(s)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("must be an http or https link")
+			}
+		case models.FormFieldEmail:
+			if _, err := mail.ParseAddress(s); err != nil {
+				return fmt.Errorf("must be an email address")
+			}
+		}
+
+	case models.FormFieldNumber:
+		n, ok := v.(float64)
+		if !ok {
+			return fmt.Errorf("must be a number")
+		}
+		if f.Min != nil && n < *f.Min {
+			return fmt.Errorf("must be at least %s", formatNumber(*f.Min))
+		}
+		if f.Max != nil && n > *f.Max {
+			return fmt.Errorf("must be at most %s", formatNumber(*f.Max))
+		}
+
+	case models.FormFieldSelect:
+		s, ok := v.(string)
+		if !ok || !containsString(f.Options, s) {
+			return fmt.Errorf("must be one of: %s", strings.Join(f.Options, ", "))
+		}
+
+	case models.FormFieldMultiSelect:
+		list, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("must be a list")
+		}
+		for _, item := range list {
+			s, ok := item.(string)
+			if !ok || !containsString(f.Options, s) {
+				return fmt.Errorf("must only contain: %s", strings.Join(f.Options, ", "))
+			}
+		}
+
+	case models.FormFieldCheckbox:
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("must be true or false")
+		}
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// formatAnswer renders an answer for a spreadsheet cell
+func formatAnswer(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return formatNumber(v)
+	case bool:
+		if v {
+			return "yes"
+		}
+		return "no"
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = formatAnswer(item)
+		}
+		return strings.Join(parts, "; ")
+	}
+	return fmt.Sprint(v)
+}
+
+// loadRegistrationForm returns an event's registration form fields.
+// Returns sql.ErrNoRows if the event doesn't exist.
+func loadRegistrationForm(ctx context.Context, db *sql.DB, eventID uuid.UUID) ([]models.FormField, error) {
+	var data []byte
+	err := db.QueryRowContext(ctx,
+		"SELECT registration_form FROM events WHERE id = $1 AND deleted_at IS NULL", eventID,
+	).Scan(&data)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []models.FormField{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// GetRegistrationForm returns an event's registration form
+// GET /api/v1/events/:id/registration-form
+func (h *EventHandler) GetRegistrationForm(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid event ID"),
+		})
+		return
+	}
+
+	fields, err := loadRegistrationForm(c.Request.Context(), h.db.Reader(), eventID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("event not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to fetch registration form", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    fields,
+	})
+}
+
+// UpdateRegistrationForm replaces an event's registration form (event
+// organizers only). Existing answers are kept; answers to removed fields are
+// left out of exports.
+// PUT /api/v1/events/:id/registration-form
+func (h *EventHandler) UpdateRegistrationForm(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateRegistrationFormRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	if req.Fields == nil {
+		req.Fields = []models.FormField{}
+	}
+	if err := validateFormSchema(req.Fields); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	data, err := json.Marshal(req.Fields)
+	if err != nil {
+		internalError(c, "failed to update registration form", err)
+		return
+	}
+	if _, err := h.db.ExecContext(c.Request.Context(),
+		"UPDATE events SET registration_form = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2", data, event.ID,
+	); err != nil {
+		internalError(c, "failed to update registration form", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "registration form updated",
+		Data:    req.Fields,
+	})
+}
+
+// SubmitFormResponses sets the caller's answers to an event's registration
+// form, for registrations made without them (such as through a fest pass)
+// PUT /api/v1/events/:id/registration/responses
+func (h *EventHandler) SubmitFormResponses(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid event ID"),
+		})
+		return
+	}
+
+	var req models.SubmitFormResponsesRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid request body"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	fields, err := loadRegistrationForm(ctx, h.db.DB, eventID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("event not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to save responses", err)
+		return
+	}
+
+	responses, err := validateFormResponses(fields, req.Responses)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	data, err := json.Marshal(responses)
+	if err != nil {
+		internalError(c, "failed to save responses", err)
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+	result, err := h.db.ExecContext(ctx,
+		"UPDATE event_registrations SET form_responses = $1 WHERE event_id = $2 AND user_id = $3", data, eventID, userID)
+	if err != nil {
+		internalError(c, "failed to save responses", err)
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("you are not registered for this event"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "responses saved",
+		Data:    responses,
+	})
+}
+
+// ListEventRegistrations returns an event's registrants with their answers
+// (event organizers only). With format=csv it downloads a spreadsheet with
+// one column per form field.
+// GET /api/v1/events/:id/registrations
+func (h *EventHandler) ListEventRegistrations(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	fields, err := loadRegistrationForm(ctx, h.db.DB, event.ID)
+	if err != nil {
+		internalError(c, "failed to fetch registrations", err)
+		return
+	}
+
+	rows, err := h.db.QueryContext(ctx, `
+		SELECT u.id, u.full_name, u.email, r.registered_at, t.name, r.form_responses
+		FROM event_registrations r
+		JOIN users u ON u.id = r.user_id
+		LEFT JOIN ticket_tiers t ON t.id = r.tier_id
+		WHERE r.event_id = $1
+		ORDER BY r.registered_at ASC
+	`, event.ID)
+	if err != nil {
+		internalError(c, "failed to fetch registrations", err)
+		return
+	}
+	defer rows.Close()
+
+	registrants := []models.EventRegistrant{}
+	for rows.Next() {
+		var r models.EventRegistrant
+		var data []byte
+		if err := rows.Scan(&r.UserID, &r.FullName, &r.Email, &r.RegisteredAt, &r.TicketTier, &data); err != nil {
+			internalError(c, "failed to fetch registrations", err)
+			return
+		}
+		r.Responses = models.FormResponses{}
+		if data != nil {
+			if err := json.Unmarshal(data, &r.Responses); err != nil {
+				internalError(c, "failed to fetch registrations", err)
+				return
+			}
+		}
+		registrants = append(registrants, r)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "failed to fetch registrations", err)
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		writeRegistrationsCSV(c, fields, registrants)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    registrants,
+	})
+}
+
+func writeRegistrationsCSV(c *gin.Context, fields []models.FormField, registrants []models.EventRegistrant) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="registrations.csv"`)
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	header := []string{"full_name", "email", "registered_at", "ticket_tier"}
+	for _, f := range fields {
+		header = append(header, f.Label)
+	}
+	w.Write(header)
+
+	for _, r := range registrants {
+		tier := ""
+		if r.TicketTier != nil {
+			tier = *r.TicketTier
+		}
+		row := []string{r.FullName, r.Email, r.RegisteredAt.Format("2006-01-02 15:04"), tier}
+		for _, f := range fields {
+			row = append(row, formatAnswer(r.Responses[f.Key]))
+		}
+		w.Write(row)
+	}
+	w.Flush()
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+func TestValidateFormResponses(t *testing.T) {
+	min, max := 1.0, 4.0
+	fields := []models.FormField{
+		{Key: "tshirt", Label: "T-shirt size", Type: models.FormFieldSelect, Required: true, Options: []string{"S", "M", "L"}},
+		{Key: "diet", Label: "Dietary needs", Type: models.FormFieldMultiSelect, Options: []string{"veg", "vegan", "jain"}},
+		{Key: "github", Label: "GitHub URL", Type: models.FormFieldURL},
+		{Key: "team_size", Label: "Team size", Type: models.FormFieldNumber, Min: &min, Max: &max},
+		{Key: "terms", Label: "Terms", Type: models.FormFieldCheckbox, Required: true},
+	}
+
+	tests := []struct {
+		name      string
+		responses models.FormResponses
+		wantErr   bool
+		wantKeys  int
+	}{
+		{
+			name:      "valid",
+			responses: models.FormResponses{"tshirt": "M", "diet": []interface{}{"veg"}, "github": "https://github.com/x", "team_size": 3.0, "terms": true},
+			wantKeys:  5,
+		},
+		{
+			name:      "empty optional answers are dropped",
+			responses: models.FormResponses{"tshirt": "S", "github": " ", "diet": []interface{}{}, "terms": true},
+			wantKeys:  2,
+		},
+		{name: "missing required", responses: models.FormResponses{"terms": true}, wantErr: true},
+		{name: "required checkbox unchecked", responses: models.FormResponses{"tshirt": "S", "terms": false}, wantErr: true},
+		{name: "option not offered", responses: models.FormResponses{"tshirt": "XXL", "terms": true}, wantErr: true},
+		{name: "multiselect option not offered", responses: models.FormResponses{"tshirt": "S", "diet": []interface{}{"keto"}, "terms": true}, wantErr: true},
+		{name: "bad url", responses: models.FormResponses{"tshirt": "S", "github": "github.com/x", "terms": true}, wantErr: true},
+		{name: "number out of range", responses: models.FormResponses{"tshirt": "S", "team_size": 9.0, "terms": true}, wantErr: true},
+		{name: "wrong type", responses: models.FormResponses{"tshirt": "S", "team_size": "3", "terms": true}, wantErr: true},
+		{name: "unknown field", responses: models.FormResponses{"tshirt": "S", "terms": true, "age": 20.0}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateFormResponses(fields, tt.responses)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != tt.wantKeys {
+				t.Errorf("got %d answers, want %d: %v", len(got), tt.wantKeys, got)
+			}
+		})
+	}
+}
+
+func TestValidateFormSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []models.FormField
+		wantErr bool
+	}{
+		{name: "valid", fields: []models.FormField{{Key: "tshirt_size", Type: models.FormFieldSelect, Options: []string{"S"}}}},
+		{name: "bad key", fields: []models.FormField{{Key: "T-shirt", Type: models.FormFieldText}}, wantErr: true},
+		{name: "duplicate key", fields: []models.FormField{{Key: "a", Type: models.FormFieldText}, {Key: "a", Type: models.FormFieldText}}, wantErr: true},
+		{name: "select without options", fields: []models.FormField{{Key: "a", Type: models.FormFieldSelect}}, wantErr: true},
+		{name: "options on text", fields: []models.FormField{{Key: "a", Type: models.FormFieldText, Options: []string{"x"}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateFormSchema(tt.fields); (err != nil) != tt.wantErr {
+				t.Errorf("validateFormSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,366 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/audit"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository"
+	"github.com/yourusername/college-event-backend/internal/services/gamification"
+)
+
+const registrationTransferColumns = `
+	id, event_id, from_user_id, to_user_id, token, status, expires_at, created_at, completed_at`
+
+func scanRegistrationTransfer(row interface{ Scan(...interface{}) error }) (models.RegistrationTransfer, error) {
+	var t models.RegistrationTransfer
+	err := row.Scan(
+		&t.ID, &t.EventID, &t.FromUserID, &t.ToUserID, &t.Token, &t.Status, &t.ExpiresAt, &t.CreatedAt, &t.CompletedAt,
+	)
+	return t, err
+}
+
+// newTransferToken returns a random 64-character hex transfer token
+func newTransferToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// transferDeadline is when an event's registrations can no longer change
+// hands: its registration deadline, or its start without one
+func transferDeadline(e models.Event) time.Time {
+	if e.RegistrationDeadline != nil {
+		return *e.RegistrationDeadline
+	}
+	return e.StartDate
+}
+
+// CreateRegistrationTransfer starts handing the caller's registration for
+// an event to someone else. The returned token is shared with the
+// recipient, who accepts it before the registration deadline. Creating a
+// transfer replaces any open one.
+// POST /api/v1/events/:id/registration/transfer
+func (h *EventHandler) CreateRegistrationTransfer(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid event ID"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	event, err := repository.New(h.db).GetEvent(ctx, eventID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("event not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to create transfer", err)
+		return
+	}
+	if event.Status != nil && *event.Status == models.EventStatusCancelled {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("this event has been cancelled"),
+		})
+		return
+	}
+	deadline := transferDeadline(event)
+	if !time.Now().Before(deadline) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("registrations for this event can no longer be transferred"),
+		})
+		return
+	}
+
+	token, err := newTransferToken()
+	if err != nil {
+		internalError(c, "failed to create transfer", err)
+		return
+	}
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to create transfer", err)
+		return
+	}
+	defer tx.Rollback()
+
+	var registered bool
+	if err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM event_registrations WHERE event_id = $1 AND user_id = $2)
+	`, eventID, userID).Scan(&registered); err != nil {
+		internalError(c, "failed to create transfer", err)
+		return
+	}
+	if !registered {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("you are not registered for this event"),
+		})
+		return
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE registration_transfers SET status = 'cancelled', completed_at = CURRENT_TIMESTAMP
+		WHERE event_id = $1 AND from_user_id = $2 AND status = 'pending'
+	`, eventID, userID); err != nil {
+		internalError(c, "failed to create transfer", err)
+		return
+	}
+	transfer, err := scanRegistrationTransfer(tx.QueryRowContext(ctx, `
+		INSERT INTO registration_transfers (event_id, from_user_id, token, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+registrationTransferColumns,
+		eventID, userID, token, deadline))
+	if err != nil {
+		internalError(c, "failed to create transfer", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to create transfer", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "transfer created; share the token with the recipient",
+		Data:    transfer,
+	})
+}
+
+// CancelRegistrationTransfer withdraws the caller's open transfer for an
+// event
+// DELETE /api/v1/events/:id/registration/transfer
+func (h *EventHandler) CancelRegistrationTransfer(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid event ID"),
+		})
+		return
+	}
+
+	res, err := h.db.ExecContext(c.Request.Context(), `
+		UPDATE registration_transfers SET status = 'cancelled', completed_at = CURRENT_TIMESTAMP
+		WHERE event_id = $1 AND from_user_id = $2 AND status = 'pending'
+	`, eventID, userID)
+	if err != nil {
+		internalError(c, "failed to cancel transfer", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("no open transfer for this event"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "transfer cancelled",
+	})
+}
+
+// AcceptRegistrationTransfer takes over the registration, and any payment
+// for it, that a transfer token hands over. Answers to the registration
+// form and check-ins stay behind; the recipient submits their own answers.
+// The transfer is recorded in the audit log. The recipient must be one who
+// may register for the event (see models.Event.OpenTo).
+// POST /api/v1/registration-transfers/accept
+func (h *EventHandler) AcceptRegistrationTransfer(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	var req models.AcceptTransferRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to accept transfer", err)
+		return
+	}
+	defer tx.Rollback()
+
+	transfer, err := scanRegistrationTransfer(tx.QueryRowContext(ctx, `
+		SELECT `+registrationTransferColumns+`
+		FROM registration_transfers
+		WHERE token = $1 AND status = 'pending'
+		FOR UPDATE
+	`, req.Token))
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("transfer not found or no longer open"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to accept transfer", err)
+		return
+	}
+	if !time.Now().Before(transfer.ExpiresAt) {
+		c.JSON(http.StatusGone, models.APIResponse{
+			Success: false,
+			Error:   strPtr("this transfer has expired"),
+		})
+		return
+	}
+	if transfer.FromUserID == userID {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("you cannot accept your own transfer"),
+		})
+		return
+	}
+
+	var event models.Event
+	err = tx.QueryRowContext(ctx,
+		"SELECT status, alumni_access FROM events WHERE id = $1 AND deleted_at IS NULL", transfer.EventID,
+	).Scan(&event.Status, &event.AlumniAccess)
+	if errors.Is(err, sql.ErrNoRows) || (event.Status != nil && *event.Status == models.EventStatusCancelled) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("this event is no longer taking registrations"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to accept transfer", err)
+		return
+	}
+	if role, _ := middleware.Role(c); !event.OpenTo(role) {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("you can't register for this event"),
+		})
+		return
+	}
+
+	var alreadyRegistered bool
+	if err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM event_registrations WHERE event_id = $1 AND user_id = $2)
+	`, transfer.EventID, userID).Scan(&alreadyRegistered); err != nil {
+		internalError(c, "failed to accept transfer", err)
+		return
+	}
+	if alreadyRegistered {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("you are already registered for this event"),
+		})
+		return
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE event_registrations
+		SET user_id = $1, form_responses = NULL, checked_in_at = NULL, checked_in_by = NULL
+		WHERE event_id = $2 AND user_id = $3
+	`, userID, transfer.EventID, transfer.FromUserID)
+	if err != nil {
+		internalError(c, "failed to accept transfer", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("the registration being transferred no longer exists"),
+		})
+		return
+	}
+
+	res, err = tx.ExecContext(ctx, `
+		UPDATE event_payments SET user_id = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE event_id = $2 AND user_id = $3 AND status = 'paid'
+	`, userID, transfer.EventID, transfer.FromUserID)
+	if err != nil {
+		internalError(c, "failed to accept transfer", err)
+		return
+	}
+	paymentsMoved, _ := res.RowsAffected()
+
+	// Attendance points follow the registration
+	if err := gamification.Revoke(ctx, tx, transfer.FromUserID, gamification.SourceEventAttendance, transfer.EventID); err != nil {
+		internalError(c, "failed to accept transfer", err)
+		return
+	}
+	if err := gamification.Award(ctx, tx, gamification.Entry{
+		UserID:   userID,
+		Source:   gamification.SourceEventAttendance,
+		SourceID: transfer.EventID,
+	}); err != nil {
+		internalError(c, "failed to accept transfer", err)
+		return
+	}
+
+	transfer, err = scanRegistrationTransfer(tx.QueryRowContext(ctx, `
+		UPDATE registration_transfers
+		SET status = 'accepted', to_user_id = $1, completed_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+		RETURNING `+registrationTransferColumns,
+		userID, transfer.ID))
+	if err != nil {
+		internalError(c, "failed to accept transfer", err)
+		return
+	}
+
+	if err := audit.Record(ctx, tx, audit.Entry{
+		Action:        audit.ActionRegistrationTransfer,
+		ActorID:       &userID,
+		SubjectUserID: &transfer.FromUserID,
+		Method:        c.Request.Method,
+		Path:          c.Request.URL.RequestURI(),
+		Status:        http.StatusOK,
+		RequestID:     middleware.GetRequestID(c),
+		IPAddress:     c.ClientIP(),
+		Details: map[string]interface{}{
+			"event_id":       transfer.EventID,
+			"transfer_id":    transfer.ID,
+			"payments_moved": paymentsMoved,
+		},
+	}); err != nil {
+		internalError(c, "failed to accept transfer", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to accept transfer", err)
+		return
+	}
+
+	// The token is spent; only its creator ever sees it
+	transfer.Token = ""
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "registration transferred to you",
+		Data:    transfer,
+	})
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// ResolveHandler maps shared links to the entities they point at, so the
+// app can route them without parsing URLs itself
+type ResolveHandler struct {
+	db        *database.DB
+	appScheme string
+}
+
+// NewResolveHandler creates a new link resolver. appScheme is the URL scheme
+// the app opens, such as "collegeevents".
+func NewResolveHandler(db *database.DB, appScheme string) *ResolveHandler {
+	return &ResolveHandler{db: db, appScheme: appScheme}
+}
+
+// linkKind is a type of entity links can point at
+type linkKind struct {
+	typ        string // models.LinkType*
+	collection string // Path segment naming the collection in links
+	prefix     byte   // First character of short codes
+}
+
+var (
+	eventLinks = linkKind{typ: models.LinkTypeEvent, collection: "events", prefix: 'e'}
+	postLinks  = linkKind{typ: models.LinkTypePost, collection: "posts", prefix: 'p'}
+	clubLinks  = linkKind{typ: models.LinkTypeClub, collection: "clubs", prefix: 'c'}
+	linkKinds  = []linkKind{eventLinks, postLinks, clubLinks}
+)
+
+// shortCode returns the short code of an entity: the kind's prefix followed
+// by the ID in unpadded base64url
+func shortCode(k linkKind, id uuid.UUID) string {
+	return string(k.prefix) + base64.RawURLEncoding.EncodeToString(id[:])
+}
+
+// parseShortCode parses a short code. ok is false if s isn't one.
+func parseShortCode(s string) (kind linkKind, id uuid.UUID, ok bool) {
+	if len(s) != 23 {
+		return linkKind{}, uuid.Nil, false
+	}
+	for _, k := range linkKinds {
+		if k.prefix != s[0] {
+			continue
+		}
+		b, err := base64.RawURLEncoding.DecodeString(s[1:])
+		if err != nil {
+			return linkKind{}, uuid.Nil, false
+		}
+		id, err = uuid.FromBytes(b)
+		if err != nil {
+			return linkKind{}, uuid.Nil, false
+		}
+		return k, id, true
+	}
+	return linkKind{}, uuid.Nil, false
+}
+
+// parseLink parses a shared link into the type and ID of what it points at.
+// It accepts short codes, the app's own links (collegeevents://events/:id),
+// and web links to share pages (/share/events/:id) or the API
+// (/api/v1/events/:id) on any host. ok is false for anything else.
+func parseLink(raw, appScheme string) (kind linkKind, id uuid.UUID, ok bool) {
+	raw = strings.TrimSpace(raw)
+	if kind, id, ok := parseShortCode(raw); ok {
+		return kind, id, true
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return linkKind{}, uuid.Nil, false
+	}
+	var segments []string
+	switch {
+	case u.Scheme == "http" || u.Scheme == "https":
+		segments = strings.Split(strings.Trim(u.Path, "/"), "/")
+		switch {
+		case len(segments) > 0 && segments[0] == "share":
+			segments = segments[1:]
+		case len(segments) > 2 && segments[0] == "api" && segments[1] == "v1":
+			segments = segments[2:]
+		}
+	case appScheme != "" && strings.EqualFold(u.Scheme, appScheme):
+		// The collection is the host: collegeevents://events/:id
+		segments = append([]string{u.Host}, strings.Split(strings.Trim(u.Path, "/"), "/")...)
+	default:
+		return linkKind{}, uuid.Nil, false
+	}
+
+	if len(segments) != 2 {
+		return linkKind{}, uuid.Nil, false
+	}
+	id, err = uuid.Parse(segments[1])
+	if err != nil {
+		return linkKind{}, uuid.Nil, false
+	}
+	for _, k := range linkKinds {
+		if k.collection == segments[0] {
+			return k, id, true
+		}
+	}
+	return linkKind{}, uuid.Nil, false
+}
+
+// eventShortLinkCode returns the code of a web link to an event's short
+// link (/e/:code). ok is false for any other link.
+func eventShortLinkCode(raw string) (code string, ok bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", false
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) != 2 || segments[0] != "e" || segments[1] == "" {
+		return "", false
+	}
+	return segments[1], true
+}
+
+// Resolve maps a shared URL, short code or event short link to the event,
+// post or club it points at. Links to deleted content, and posts hidden from the viewer, are
+// not found.
+// GET /api/v1/resolve?url=...
+func (h *ResolveHandler) Resolve(c *gin.Context) {
+	raw := c.Query("url")
+	if raw == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("url is required"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	kind, id, ok := parseLink(raw, h.appScheme)
+	if code, isShort := eventShortLinkCode(raw); !ok && isShort {
+		// Short links are looked up without counting a click
+		err := h.db.Reader().QueryRowContext(ctx,
+			"SELECT event_id FROM short_links WHERE code = $1", strings.ToUpper(code)).Scan(&id)
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Link points to something that no longer exists"),
+			})
+			return
+		}
+		if err != nil {
+			internalError(c, "Failed to resolve link", err)
+			return
+		}
+		kind, ok = eventLinks, true
+	}
+	if !ok {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Unrecognized link"),
+		})
+		return
+	}
+
+	q := repository.New(h.db.Reader())
+	link := models.ResolvedLink{
+		Type:      kind.typ,
+		ID:        id,
+		Path:      kind.collection + "/" + id.String(),
+		ShortCode: shortCode(kind, id),
+	}
+	var err error
+	switch kind.typ {
+	case models.LinkTypeEvent:
+		var event models.Event
+		event, err = q.GetEvent(ctx, id)
+		if role, _ := middleware.Role(c); err == nil && !event.VisibleTo(role) {
+			err = sql.ErrNoRows
+		}
+		link.Title = event.Title
+	case models.LinkTypePost:
+		var viewerID *uuid.UUID
+		if userID, ok := middleware.UserID(c); ok {
+			viewerID = &userID
+		}
+		var post models.PostResponse
+		post, err = q.GetPost(ctx, id, viewerID)
+		link.Title = shareExcerpt(post.Description, shareDescriptionLength)
+	case models.LinkTypeClub:
+		var club models.Club
+		club, err = q.GetClub(ctx, id)
+		link.Title = club.Name
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Link points to something that no longer exists"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to resolve link", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    link,
+	})
+}
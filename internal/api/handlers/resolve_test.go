@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+func TestParseLink(t *testing.T) {
+	id := uuid.MustParse("6f1c2a9e-3b4d-4e5f-8a7b-9c0d1e2f3a4b")
+
+	for _, tc := range []struct {
+		raw  string
+		want string // Type, or "" if unrecognized
+	}{
+		{"https://api.example.edu/share/events/" + id.String(), models.LinkTypeEvent},
+		{"https://api.example.edu/share/posts/" + id.String() + "?utm_source=whatsapp", models.LinkTypePost},
+		{"http://localhost:8080/api/v1/clubs/" + id.String(), models.LinkTypeClub},
+		{"https://campus.example.edu/events/" + id.String() + "/", models.LinkTypeEvent},
+		{"collegeevents://posts/" + id.String(), models.LinkTypePost},
+		{"  " + shortCode(eventLinks, id) + " ", models.LinkTypeEvent},
+		{"otherapp://events/" + id.String(), ""},
+		{"https://api.example.edu/houses/" + id.String(), ""},
+		{"https://api.example.edu/events/not-an-id", ""},
+		{"https://api.example.edu/events/" + id.String() + "/comments", ""},
+		{"x" + shortCode(eventLinks, id)[1:], ""},
+	} {
+		kind, got, ok := parseLink(tc.raw, "collegeevents")
+		if !ok {
+			if tc.want != "" {
+				t.Errorf("parseLink(%q) not recognized, want %s", tc.raw, tc.want)
+			}
+			continue
+		}
+		if tc.want == "" {
+			t.Errorf("parseLink(%q) = %s %s, want unrecognized", tc.raw, kind.typ, got)
+			continue
+		}
+		if kind.typ != tc.want || got != id {
+			t.Errorf("parseLink(%q) = %s %s, want %s %s", tc.raw, kind.typ, got, tc.want, id)
+		}
+	}
+}
+
+func TestEventShortLinkCode(t *testing.T) {
+	if code, ok := eventShortLinkCode("https://api.example.edu/e/AB12CD?src=bio"); !ok || code != "AB12CD" {
+		t.Errorf("got %q, %v", code, ok)
+	}
+	for _, raw := range []string{"https://api.example.edu/e/", "https://api.example.edu/e/AB/CD", "collegeevents://e/AB12CD"} {
+		if _, ok := eventShortLinkCode(raw); ok {
+			t.Errorf("eventShortLinkCode(%q) recognized", raw)
+		}
+	}
+}
@@ -0,0 +1,391 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/storage"
+	"github.com/yourusername/college-event-backend/internal/uploads"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+const (
+	// maxVideoBytes bounds a resumable video upload
+	maxVideoBytes = 2 << 30
+
+	// uploadSessionTTL is how long an upload session lasts after its last
+	// chunk before it's cleaned up
+	uploadSessionTTL = 24 * time.Hour
+
+	resumableUploadPath = "/api/v1/admin/uploads/resumable/"
+)
+
+// videoTypes are the video formats that can be uploaded; they're stored
+// unchanged
+var videoTypes = map[string]bool{
+	"video/mp4":       true,
+	"video/webm":      true,
+	"video/quicktime": true,
+}
+
+// ResumableUploadHandler handles resumable uploads over the tus protocol, so
+// large files sent over a flaky connection continue from where they
+// stopped instead of restarting
+type ResumableUploadHandler struct {
+	db      *database.DB
+	storage storage.StorageService
+	stage   *uploads.Stage
+}
+
+// NewResumableUploadHandler creates a new resumable upload handler
+func NewResumableUploadHandler(db *database.DB, s storage.StorageService, stage *uploads.Stage) *ResumableUploadHandler {
+	return &ResumableUploadHandler{db: db, storage: s, stage: stage}
+}
+
+const uploadSessionColumns = `id, filename, content_type, folder, image_type, size, received, status, url, path, expires_at, created_at`
+
+func scanUploadSession(row interface{ Scan(...interface{}) error }) (models.UploadSession, string, error) {
+	var s models.UploadSession
+	var imageType string
+	err := row.Scan(&s.ID, &s.Filename, &s.ContentType, &s.Folder, &imageType, &s.Size, &s.Received,
+		&s.Status, &s.URL, &s.Path, &s.ExpiresAt, &s.CreatedAt)
+	if err == nil {
+		s.Progress = float64(s.Received) * 100 / float64(s.Size)
+	}
+	return s, imageType, err
+}
+
+// tusVersionOK checks the client speaks our tus version, answering 412 if
+// not
+func tusVersionOK(c *gin.Context) bool {
+	c.Header("Tus-Resumable", uploads.TusVersion)
+	if v := c.GetHeader("Tus-Resumable"); v != "" && v != uploads.TusVersion {
+		c.Header("Tus-Version", uploads.TusVersion)
+		c.JSON(http.StatusPreconditionFailed, models.APIResponse{
+			Success: false,
+			Error:   strPtr("unsupported tus version"),
+		})
+		return false
+	}
+	return true
+}
+
+// CreateUpload starts a resumable upload. The file's size goes in the
+// Upload-Length header; Upload-Metadata carries filename, filetype, and
+// optionally folder and type (image sizing, as for a single upload).
+// Chunks are then sent to the Location returned.
+// POST /api/v1/admin/uploads/resumable
+func (h *ResumableUploadHandler) CreateUpload(c *gin.Context) {
+	if !tusVersionOK(c) {
+		return
+	}
+
+	size, err := strconv.ParseInt(c.GetHeader("Upload-Length"), 10, 64)
+	if err != nil || size < 1 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Upload-Length must be the file size in bytes"),
+		})
+		return
+	}
+	metadata, err := uploads.ParseMetadata(c.GetHeader("Upload-Metadata"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	contentType := metadata["filetype"]
+	limit := int64(maxImageBytes)
+	switch {
+	case videoTypes[contentType]:
+		limit = maxVideoBytes
+	case !isValidImageType(contentType):
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid file type. Allowed: JPEG, PNG, GIF, WebP, MP4, WebM, MOV"),
+		})
+		return
+	}
+	if size > limit {
+		c.Header("Tus-Max-Size", strconv.FormatInt(limit, 10))
+		c.JSON(http.StatusRequestEntityTooLarge, models.APIResponse{
+			Success: false,
+			Error:   strPtr("File too large. Maximum size is " + strconv.FormatInt(limit>>20, 10) + "MB"),
+		})
+		return
+	}
+
+	filename := metadata["filename"]
+	if filename == "" {
+		filename = "upload"
+	}
+	folder := sanitizeFolderName(metadata["folder"])
+	imageType := parseImageType(metadata["type"])
+
+	userID, _ := middleware.UserID(c)
+	session, _, err := scanUploadSession(h.db.QueryRowContext(c.Request.Context(), `
+		INSERT INTO upload_sessions (user_id, filename, content_type, folder, image_type, size, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+uploadSessionColumns,
+		userID, filename, contentType, folder, string(imageType), size, time.Now().Add(uploadSessionTTL)))
+	if err != nil {
+		internalError(c, "failed to start upload", err)
+		return
+	}
+
+	c.Header("Location", resumableUploadPath+session.ID.String())
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "upload started",
+		Data:    session,
+	})
+}
+
+// loadUploadSession finds the current user's upload session by the :id
+// param, responding 404 if there isn't one
+func (h *ResumableUploadHandler) loadUploadSession(c *gin.Context, q interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}, forUpdate bool) (models.UploadSession, string, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("upload not found"),
+		})
+		return models.UploadSession{}, "", false
+	}
+
+	query := "SELECT " + uploadSessionColumns + " FROM upload_sessions WHERE id = $1 AND user_id = $2"
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+	userID, _ := middleware.UserID(c)
+	session, imageType, err := scanUploadSession(q.QueryRowContext(c.Request.Context(), query, id, userID))
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("upload not found"),
+		})
+		return models.UploadSession{}, "", false
+	}
+	if err != nil {
+		internalError(c, "failed to fetch upload", err)
+		return models.UploadSession{}, "", false
+	}
+	return session, imageType, true
+}
+
+// GetUploadOffset tells a client how much of an upload was received, so it
+// can resume from there
+// HEAD /api/v1/admin/uploads/resumable/:id
+func (h *ResumableUploadHandler) GetUploadOffset(c *gin.Context) {
+	if !tusVersionOK(c) {
+		return
+	}
+	session, _, ok := h.loadUploadSession(c, h.db, false)
+	if !ok {
+		return
+	}
+
+	c.Header("Upload-Offset", strconv.FormatInt(session.Received, 10))
+	c.Header("Upload-Length", strconv.FormatInt(session.Size, 10))
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+}
+
+// UploadChunk appends a chunk to an upload, starting at the Upload-Offset
+// header, which must match what was received so far. Once the last byte
+// arrives the file is stored; a failed store is retried by sending an
+// empty chunk at the end.
+// PATCH /api/v1/admin/uploads/resumable/:id
+func (h *ResumableUploadHandler) UploadChunk(c *gin.Context) {
+	if !tusVersionOK(c) {
+		return
+	}
+	if c.ContentType() != "application/offset+octet-stream" {
+		c.JSON(http.StatusUnsupportedMediaType, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Content-Type must be application/offset+octet-stream"),
+		})
+		return
+	}
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Upload-Offset must be a byte offset"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to upload chunk", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// The row lock keeps concurrent chunks of an upload from interleaving
+	session, imageType, ok := h.loadUploadSession(c, tx, true)
+	if !ok {
+		return
+	}
+	if session.Status == models.UploadSessionCompleted {
+		c.Header("Upload-Offset", strconv.FormatInt(session.Size, 10))
+		c.Status(http.StatusNoContent)
+		return
+	}
+	if time.Now().After(session.ExpiresAt) {
+		c.JSON(http.StatusGone, models.APIResponse{
+			Success: false,
+			Error:   strPtr("upload expired"),
+		})
+		return
+	}
+	if offset != session.Received {
+		c.Header("Upload-Offset", strconv.FormatInt(session.Received, 10))
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Upload-Offset does not match the bytes received"),
+		})
+		return
+	}
+
+	n, appendErr := h.stage.Append(session.ID, offset, c.Request.Body, session.Size-session.Received)
+	session.Received += n
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE upload_sessions SET received = $1, expires_at = $2 WHERE id = $3",
+		session.Received, time.Now().Add(uploadSessionTTL), session.ID,
+	); err != nil {
+		internalError(c, "failed to upload chunk", err)
+		return
+	}
+
+	var result *storage.UploadResult
+	if appendErr == nil && session.Received == session.Size {
+		if result, err = h.store(c, session, imageType); err != nil {
+			// Keep what was received; the client retries the store
+			if err := tx.Commit(); err != nil {
+				logInternalError(c, "failed to record upload progress", err)
+			}
+			c.Header("Upload-Offset", strconv.FormatInt(session.Received, 10))
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Failed to store upload: " + err.Error()),
+			})
+			return
+		}
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE upload_sessions SET status = $1, url = $2, path = $3 WHERE id = $4",
+			models.UploadSessionCompleted, result.URL, result.Path, session.ID,
+		); err != nil {
+			h.deleteStored(c, result.Path)
+			internalError(c, "failed to complete upload", err)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		if result != nil {
+			h.deleteStored(c, result.Path)
+		}
+		internalError(c, "failed to upload chunk", err)
+		return
+	}
+	if result != nil {
+		if err := h.stage.Remove(session.ID); err != nil {
+			logInternalError(c, "failed to remove staged upload", err)
+		}
+	}
+
+	c.Header("Upload-Offset", strconv.FormatInt(session.Received, 10))
+	if appendErr != nil {
+		internalError(c, "failed to receive chunk", appendErr)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// store hands a fully received upload to storage: images are optimized as
+// for a single upload, videos are stored as they are
+func (h *ResumableUploadHandler) store(c *gin.Context, session models.UploadSession, imageType string) (*storage.UploadResult, error) {
+	f, err := h.stage.Open(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ctx := c.Request.Context()
+	if videoTypes[session.ContentType] {
+		return h.storage.UploadFile(ctx, f, session.Filename, session.Folder, session.ContentType)
+	}
+	return h.storage.UploadImage(ctx, f, session.Filename, session.Folder, storage.ImageType(imageType))
+}
+
+func (h *ResumableUploadHandler) deleteStored(c *gin.Context, path string) {
+	if err := h.storage.Delete(c.Request.Context(), path); err != nil {
+		logInternalError(c, "failed to delete stored upload", err)
+	}
+}
+
+// GetUpload returns an upload's progress, and its URL once complete
+// GET /api/v1/admin/uploads/resumable/:id
+func (h *ResumableUploadHandler) GetUpload(c *gin.Context) {
+	session, _, ok := h.loadUploadSession(c, h.db, false)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    session,
+	})
+}
+
+// CancelUpload abandons an unfinished upload and discards what was received
+// DELETE /api/v1/admin/uploads/resumable/:id
+func (h *ResumableUploadHandler) CancelUpload(c *gin.Context) {
+	if !tusVersionOK(c) {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("upload not found"),
+		})
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+	res, err := h.db.ExecContext(c.Request.Context(),
+		"DELETE FROM upload_sessions WHERE id = $1 AND user_id = $2 AND status = $3",
+		id, userID, models.UploadSessionUploading)
+	if err != nil {
+		internalError(c, "failed to cancel upload", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("upload not found"),
+		})
+		return
+	}
+	if err := h.stage.Remove(id); err != nil {
+		logInternalError(c, "failed to remove staged upload", err)
+	}
+
+	c.Status(http.StatusNoContent)
+}
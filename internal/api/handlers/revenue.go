@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/payments"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// takenPaymentStatuses are the statuses of payments whose money was taken,
+// including those refunded since
+const takenPaymentStatuses = `('paid', 'refund_pending', 'refunded')`
+
+// RevenueHandler reports on clubs' payment revenue
+type RevenueHandler struct {
+	db       *database.DB
+	razorpay *payments.Client
+}
+
+// NewRevenueHandler creates a new revenue handler
+func NewRevenueHandler(db *database.DB, razorpay *payments.Client) *RevenueHandler {
+	return &RevenueHandler{db: db, razorpay: razorpay}
+}
+
+// requireClub parses :id and checks the club exists. Returns false if the
+// request has been answered.
+func requireClub(c *gin.Context, db *sql.DB) (uuid.UUID, bool) {
+	clubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid club ID"),
+		})
+		return clubID, false
+	}
+	var exists bool
+	err = db.QueryRowContext(c.Request.Context(),
+		"SELECT EXISTS(SELECT 1 FROM clubs WHERE id = $1)", clubID).Scan(&exists)
+	if err != nil {
+		internalError(c, "failed to fetch club", err)
+		return clubID, false
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("club not found"),
+		})
+		return clubID, false
+	}
+	return clubID, true
+}
+
+// GetClubRevenue reports what each of a club's paid events brought in:
+// payments taken, refunds and net revenue, with totals per currency
+// (admin only). ?term= limits it to an academic term's events.
+// GET /api/v1/admin/clubs/:id/revenue
+func (h *RevenueHandler) GetClubRevenue(c *gin.Context) {
+	db := h.db.Reader()
+	clubID, ok := requireClub(c, db)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+	termID, err := termFilter(ctx, db, c.Query("term"))
+	if err != nil {
+		respondTermFilterError(c, err)
+		return
+	}
+
+	report := models.ClubRevenue{ClubID: clubID, TermID: termID}
+	report.Events, err = clubEventRevenue(ctx, db, clubID, termID)
+	if err != nil {
+		internalError(c, "failed to fetch revenue", err)
+		return
+	}
+
+	report.Totals = []models.RevenueTotal{}
+	totals := map[string]int{} // Index in report.Totals by currency
+	for _, e := range report.Events {
+		i, ok := totals[e.Currency]
+		if !ok {
+			i = len(report.Totals)
+			totals[e.Currency] = i
+			report.Totals = append(report.Totals, models.RevenueTotal{Currency: e.Currency})
+		}
+		t := &report.Totals[i]
+		t.PaidRegistrations += e.PaidRegistrations
+		t.Gross += e.Gross
+		t.Refunded += e.Refunded
+		t.RefundPending += e.RefundPending
+		t.Net += e.Net
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    report,
+	})
+}
+
+// clubEventRevenue returns the revenue of a club's paid events, latest first
+func clubEventRevenue(ctx context.Context, db *sql.DB, clubID uuid.UUID, termID *uuid.UUID) ([]models.EventRevenue, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT e.id, e.title, e.start_date, COALESCE(e.currency, 'INR'),
+		       COUNT(p.id) FILTER (WHERE p.status IN `+takenPaymentStatuses+`),
+		       COALESCE(SUM(p.amount) FILTER (WHERE p.status IN `+takenPaymentStatuses+`), 0),
+		       COALESCE(SUM(p.amount) FILTER (WHERE p.status = 'refunded'), 0),
+		       COALESCE(SUM(p.amount) FILTER (WHERE p.status = 'refund_pending'), 0)
+		FROM events e
+		LEFT JOIN event_payments p ON p.event_id = e.id
+		WHERE e.club_id = $1 AND e.deleted_at IS NULL AND e.is_paid_event
+		  AND ($2::uuid IS NULL OR e.term_id = $2)
+		GROUP BY e.id
+		ORDER BY e.start_date DESC
+	`, clubID, termID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []models.EventRevenue{}
+	for rows.Next() {
+		var e models.EventRevenue
+		if err := rows.Scan(
+			&e.EventID, &e.Title, &e.StartDate, &e.Currency,
+			&e.PaidRegistrations, &e.Gross, &e.Refunded, &e.RefundPending,
+		); err != nil {
+			return nil, err
+		}
+		e.Net = e.Gross - e.Refunded - e.RefundPending
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// GetClubSettlements reconciles a month of online payments for a club's
+// events with Razorpay's settlement report: whether each was paid out, in
+// which settlement and less what fees (admin only). ?month=YYYY-MM defaults
+// to the current month.
+// GET /api/v1/admin/clubs/:id/settlements
+func (h *RevenueHandler) GetClubSettlements(c *gin.Context) {
+	clubID, ok := requireClub(c, h.db.Reader())
+	if !ok {
+		return
+	}
+
+	start := time.Now().UTC()
+	start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if m := c.Query("month"); m != "" {
+		t, err := time.Parse("2006-01", m)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("month must be YYYY-MM"),
+			})
+			return
+		}
+		start = t
+	}
+
+	ctx := c.Request.Context()
+	rows, err := h.db.Reader().QueryContext(ctx, `
+		SELECT p.id, p.event_id, e.title, p.razorpay_payment_id, p.amount, COALESCE(p.currency, 'INR'),
+		       p.status, p.created_at
+		FROM event_payments p
+		JOIN events e ON e.id = p.event_id
+		WHERE e.club_id = $1 AND p.razorpay_payment_id IS NOT NULL
+		  AND p.status IN `+takenPaymentStatuses+`
+		  AND p.created_at >= $2 AND p.created_at < $3
+		ORDER BY p.created_at
+	`, clubID, start, start.AddDate(0, 1, 0))
+	if err != nil {
+		internalError(c, "failed to fetch settlements", err)
+		return
+	}
+	defer rows.Close()
+
+	report := models.ClubSettlements{
+		ClubID:   clubID,
+		Year:     start.Year(),
+		Month:    int(start.Month()),
+		Payments: []models.PaymentSettlement{},
+	}
+	for rows.Next() {
+		var p models.PaymentSettlement
+		if err := rows.Scan(
+			&p.PaymentID, &p.EventID, &p.EventTitle, &p.RazorpayPaymentID, &p.Amount, &p.Currency,
+			&p.Status, &p.CreatedAt,
+		); err != nil {
+			internalError(c, "failed to fetch settlements", err)
+			return
+		}
+		report.Payments = append(report.Payments, p)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "failed to fetch settlements", err)
+		return
+	}
+
+	entries, err := h.razorpay.SettlementRecon(ctx, report.Year, report.Month)
+	if err != nil {
+		internalError(c, "failed to fetch the settlement report from Razorpay", err)
+		return
+	}
+	reconcileSettlements(&report, entries)
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    report,
+	})
+}
+
+// reconcileSettlements fills in each payment's settlement from the
+// report's payment entries
+func reconcileSettlements(report *models.ClubSettlements, entries []payments.SettlementEntry) {
+	byPayment := make(map[string]payments.SettlementEntry)
+	for _, e := range entries {
+		if e.Type == "payment" {
+			byPayment[e.EntityID] = e
+		}
+	}
+
+	report.Settled, report.Pending = 0, 0
+	for i := range report.Payments {
+		p := &report.Payments[i]
+		e, ok := byPayment[p.RazorpayPaymentID]
+		if !ok {
+			p.Settlement = models.SettlementMissing
+			report.Pending++
+			continue
+		}
+		fee, tax := float64(e.Fee)/100, float64(e.Tax)/100
+		p.Fee, p.Tax = &fee, &tax
+		if !e.Settled {
+			p.Settlement = models.SettlementUnsettled
+			report.Pending++
+			continue
+		}
+		p.Settlement = models.SettlementSettled
+		p.SettlementID, p.SettlementUTR = &e.SettlementID, &e.SettlementUTR
+		if e.SettledAt != 0 {
+			settledAt := time.Unix(e.SettledAt, 0).UTC()
+			p.SettledAt = &settledAt
+		}
+		report.Settled++
+	}
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// SavedHandler manages the posts and events students bookmark
+type SavedHandler struct {
+	db *database.DB
+}
+
+// NewSavedHandler creates a new saved content handler
+func NewSavedHandler(db *database.DB) *SavedHandler {
+	return &SavedHandler{db: db}
+}
+
+// savedTarget is a kind of content that can be saved
+type savedTarget struct {
+	name    string // Capitalized, for messages
+	noun    string // Lowercase name
+	table   string // Table of saves
+	column  string // Column of table referencing the content
+	content string // Table of the content, soft deleted through deleted_at
+}
+
+var (
+	savedPosts  = savedTarget{name: "Post", noun: "post", table: "saved_posts", column: "post_id", content: "posts"}
+	savedEvents = savedTarget{name: "Event", noun: "event", table: "saved_events", column: "event_id", content: "events"}
+)
+
+// save bookmarks the content in the :id param. Saving it again succeeds.
+func (h *SavedHandler) save(c *gin.Context, t savedTarget) {
+	userID, _ := middleware.UserID(c)
+
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid " + t.noun + " ID"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	res, err := h.db.ExecContext(ctx, `
+		INSERT INTO `+t.table+` (user_id, `+t.column+`)
+		SELECT $1, id FROM `+t.content+` WHERE id = $2 AND deleted_at IS NULL
+		ON CONFLICT (user_id, `+t.column+`) DO NOTHING
+	`, userID, contentID)
+	if err != nil {
+		internalError(c, "Failed to save "+t.noun, err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		// Either already saved or no such content
+		var exists bool
+		if err := h.db.QueryRowContext(ctx,
+			"SELECT EXISTS(SELECT 1 FROM "+t.content+" WHERE id = $1 AND deleted_at IS NULL)", contentID).Scan(&exists); err != nil {
+			internalError(c, "Failed to save "+t.noun, err)
+			return
+		}
+		if !exists {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   strPtr(t.name + " not found"),
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: t.name + " saved",
+		Data:    gin.H{"saved": true},
+	})
+}
+
+// unsave removes the bookmark on the content in the :id param. Unsaving
+// content that isn't saved succeeds.
+func (h *SavedHandler) unsave(c *gin.Context, t savedTarget) {
+	userID, _ := middleware.UserID(c)
+
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid " + t.noun + " ID"),
+		})
+		return
+	}
+
+	if _, err := h.db.ExecContext(c.Request.Context(),
+		"DELETE FROM "+t.table+" WHERE user_id = $1 AND "+t.column+" = $2", userID, contentID); err != nil {
+		internalError(c, "Failed to unsave "+t.noun, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: t.name + " removed from saved",
+		Data:    gin.H{"saved": false},
+	})
+}
+
+// SavePost bookmarks a post
+// POST /api/v1/posts/:id/save
+func (h *SavedHandler) SavePost(c *gin.Context) {
+	h.save(c, savedPosts)
+}
+
+// UnsavePost removes a post from the user's bookmarks
+// DELETE /api/v1/posts/:id/save
+func (h *SavedHandler) UnsavePost(c *gin.Context) {
+	h.unsave(c, savedPosts)
+}
+
+// SaveEvent bookmarks an event
+// POST /api/v1/events/:id/save
+func (h *SavedHandler) SaveEvent(c *gin.Context) {
+	h.save(c, savedEvents)
+}
+
+// UnsaveEvent removes an event from the user's bookmarks
+// DELETE /api/v1/events/:id/save
+func (h *SavedHandler) UnsaveEvent(c *gin.Context) {
+	h.unsave(c, savedEvents)
+}
+
+// ListSaved returns the user's saved posts and events, most recently saved
+// first. Deleted content drops out.
+// GET /api/v1/me/saved
+func (h *SavedHandler) ListSaved(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	ctx := c.Request.Context()
+	// Read from the primary so something just saved is listed
+	q := repository.New(h.db.DB)
+
+	posts, err := q.ListSavedPosts(ctx, userID)
+	if err != nil {
+		internalError(c, "Failed to fetch saved posts", err)
+		return
+	}
+	events, err := q.ListSavedEvents(ctx, userID)
+	if err != nil {
+		internalError(c, "Failed to fetch saved events", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    models.SavedContent{Posts: posts, Events: events},
+	})
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
 	"github.com/yourusername/college-event-backend/internal/models"
 	"github.com/yourusername/college-event-backend/pkg/database"
 )
@@ -41,7 +42,7 @@ func (h *ScheduleHandler) ListSchedules(c *gin.Context) {
 	}
 
 	// Check if user is authenticated (optional for viewing official schedules)
-	userID, userExists := c.Get("user_id")
+	userID, userExists := middleware.UserID(c)
 
 	var rows *sql.Rows
 	if userExists {
@@ -53,7 +54,7 @@ func (h *ScheduleHandler) ListSchedules(c *gin.Context) {
 			WHERE schedule_date = $1 
 			  AND (schedule_type = 'official' OR (schedule_type = 'personal' AND user_id = $2))
 			ORDER BY start_time ASC
-		`, scheduleDate, userID.(uuid.UUID))
+		`, scheduleDate, userID)
 	} else {
 		// Get only official schedules
 		rows, err = h.db.Query(`
@@ -66,10 +67,7 @@ func (h *ScheduleHandler) ListSchedules(c *gin.Context) {
 	}
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Error:   strPtr("failed to fetch schedules"),
-		})
+		internalError(c, "failed to fetch schedules", err)
 		return
 	}
 	defer rows.Close()
@@ -83,10 +81,15 @@ func (h *ScheduleHandler) ListSchedules(c *gin.Context) {
 			&schedule.CreatedBy, &schedule.UserID, &schedule.CreatedAt, &schedule.UpdatedAt,
 		)
 		if err != nil {
-			continue
+			internalError(c, "failed to fetch schedules", err)
+			return
 		}
 		schedules = append(schedules, schedule)
 	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "failed to fetch schedules", err)
+		return
+	}
 
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
@@ -143,11 +146,11 @@ func (h *ScheduleHandler) GetSchedule(c *gin.Context) {
 // CreateSchedule creates a new schedule item
 // Admin can create 'official' schedules, students can only create 'personal' schedules
 func (h *ScheduleHandler) CreateSchedule(c *gin.Context) {
-	userID, _ := c.Get("user_id")
-	userRole, _ := c.Get("user_role")
+	userID, _ := middleware.UserID(c)
+	userRole, _ := middleware.Role(c)
 
 	var req models.CreateScheduleRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		fmt.Printf("CreateSchedule validation error: %v\n", err.Error())
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
@@ -172,8 +175,7 @@ func (h *ScheduleHandler) CreateSchedule(c *gin.Context) {
 
 	if req.ScheduleType == "official" {
 		// Only admin can create official schedules
-		roleVal, _ := userRole.(models.UserRole)
-		if roleVal != models.RoleAdmin {
+		if userRole != models.RoleAdmin {
 			c.JSON(http.StatusForbidden, models.APIResponse{
 				Success: false,
 				Error:   strPtr("only admin can create official schedules"),
@@ -186,8 +188,7 @@ func (h *ScheduleHandler) CreateSchedule(c *gin.Context) {
 	} else {
 		// Personal schedule - associate with the current user
 		scheduleType = "personal"
-		uid := userID.(uuid.UUID)
-		targetUserID = &uid
+		targetUserID = &userID
 	}
 
 	var schedule models.Schedule
@@ -195,7 +196,7 @@ func (h *ScheduleHandler) CreateSchedule(c *gin.Context) {
 		INSERT INTO schedules (title, description, schedule_date, start_time, end_time, location, schedule_type, created_by, user_id)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id, title, description, schedule_date, start_time, end_time, location, schedule_type, created_by, user_id, created_at, updated_at
-	`, req.Title, req.Description, scheduleDate, req.StartTime, req.EndTime, req.Location, scheduleType, userID.(uuid.UUID), targetUserID).Scan(
+	`, req.Title, req.Description, scheduleDate, req.StartTime, req.EndTime, req.Location, scheduleType, userID, targetUserID).Scan(
 		&schedule.ID, &schedule.Title, &schedule.Description, &schedule.ScheduleDate,
 		&schedule.StartTime, &schedule.EndTime, &schedule.Location, &schedule.ScheduleType,
 		&schedule.CreatedBy, &schedule.UserID, &schedule.CreatedAt, &schedule.UpdatedAt,
@@ -230,8 +231,8 @@ func (h *ScheduleHandler) UpdateSchedule(c *gin.Context) {
 		return
 	}
 
-	userID, _ := c.Get("user_id")
-	userRole, _ := c.Get("user_role")
+	userID, _ := middleware.UserID(c)
+	userRole, _ := middleware.Role(c)
 
 	// First, fetch the existing schedule to check permissions
 	var existingSchedule models.Schedule
@@ -265,8 +266,7 @@ func (h *ScheduleHandler) UpdateSchedule(c *gin.Context) {
 	// Check permissions
 	// Admin can update any schedule
 	// Non-admin can only update their own personal schedules
-	roleVal, _ := userRole.(models.UserRole)
-	if roleVal != models.RoleAdmin {
+	if userRole != models.RoleAdmin {
 		if existingSchedule.ScheduleType == "official" {
 			c.JSON(http.StatusForbidden, models.APIResponse{
 				Success: false,
@@ -274,7 +274,7 @@ func (h *ScheduleHandler) UpdateSchedule(c *gin.Context) {
 			})
 			return
 		}
-		if existingSchedule.UserID == nil || *existingSchedule.UserID != userID.(uuid.UUID) {
+		if existingSchedule.UserID == nil || *existingSchedule.UserID != userID {
 			c.JSON(http.StatusForbidden, models.APIResponse{
 				Success: false,
 				Error:   strPtr("cannot edit other users' schedules"),
@@ -284,7 +284,7 @@ func (h *ScheduleHandler) UpdateSchedule(c *gin.Context) {
 	}
 
 	var req models.UpdateScheduleRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Error:   strPtr(fmt.Sprintf("invalid request body: %s", err.Error())),
@@ -385,8 +385,8 @@ func (h *ScheduleHandler) DeleteSchedule(c *gin.Context) {
 		return
 	}
 
-	userID, _ := c.Get("user_id")
-	userRole, _ := c.Get("user_role")
+	userID, _ := middleware.UserID(c)
+	userRole, _ := middleware.Role(c)
 
 	// First, fetch the existing schedule to check permissions
 	var existingSchedule models.Schedule
@@ -411,8 +411,7 @@ func (h *ScheduleHandler) DeleteSchedule(c *gin.Context) {
 	}
 
 	// Check permissions
-	roleVal, _ := userRole.(models.UserRole)
-	if roleVal != models.RoleAdmin {
+	if userRole != models.RoleAdmin {
 		if existingSchedule.ScheduleType == "official" {
 			c.JSON(http.StatusForbidden, models.APIResponse{
 				Success: false,
@@ -420,7 +419,7 @@ func (h *ScheduleHandler) DeleteSchedule(c *gin.Context) {
 			})
 			return
 		}
-		if existingSchedule.UserID == nil || *existingSchedule.UserID != userID.(uuid.UUID) {
+		if existingSchedule.UserID == nil || *existingSchedule.UserID != userID {
 			c.JSON(http.StatusForbidden, models.APIResponse{
 				Success: false,
 				Error:   strPtr("cannot delete other users' schedules"),
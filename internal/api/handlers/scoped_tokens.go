@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/auth"
+)
+
+// defaultScopedTokenDuration is the token lifetime when none is requested
+const defaultScopedTokenDuration = 24 * time.Hour
+
+// CreateScopedToken issues an access token for the current user limited to
+// the requested scopes, for an integration to act on their behalf with least
+// privilege. The token carries the user's role, and the role still applies.
+// Scoped tokens are not allowed here, so a token can't mint a broader one.
+// POST /api/v1/auth/tokens
+func (h *AuthHandler) CreateScopedToken(c *gin.Context) {
+	if _, ok := middleware.Impersonation(c); ok {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("impersonation sessions cannot create tokens"),
+		})
+		return
+	}
+
+	var req models.CreateScopedTokenRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	scope, err := auth.NormalizeScope(req.Scopes)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	duration := defaultScopedTokenDuration
+	if req.ExpiresInHours != 0 {
+		duration = time.Duration(req.ExpiresInHours) * time.Hour
+		if duration < time.Hour || duration > auth.MaxScopedTokenDuration {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("expires_in_hours must be between 1 and 720"),
+			})
+			return
+		}
+	}
+
+	userID, _ := middleware.UserID(c)
+	var user models.User
+	err = h.db.QueryRowContext(c.Request.Context(), `
+		SELECT id, email, full_name, role
+		FROM users
+		WHERE id = $1 AND deleted_at IS NULL
+	`, userID).Scan(&user.ID, &user.Email, &user.FullName, &user.Role)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Error:   strPtr("user not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to create token", err)
+		return
+	}
+
+	token, expiresAt, err := h.authService.GenerateScopedToken(&user, scope, duration)
+	if err != nil {
+		internalError(c, "Failed to create token", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "token created",
+		Data: models.ScopedTokenResponse{
+			AccessToken: token,
+			ExpiresAt:   expiresAt,
+			Scope:       scope,
+		},
+	})
+}
@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/audit"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// SecurityHandler serves the admin security feed: alerts raised by the
+// suspicious activity rules and the lockouts applied in response
+type SecurityHandler struct {
+	db *database.DB
+}
+
+// NewSecurityHandler creates a new SecurityHandler
+func NewSecurityHandler(db *database.DB) *SecurityHandler {
+	return &SecurityHandler{db: db}
+}
+
+// ListSecurityAlerts returns security alerts, newest first. status is open
+// (the default), acknowledged or all.
+// GET /api/v1/admin/security/alerts
+func (h *SecurityHandler) ListSecurityAlerts(c *gin.Context) {
+	var query models.ListSecurityAlertsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid query parameters"),
+		})
+		return
+	}
+	if query.Status == "" {
+		query.Status = "open"
+	}
+	if query.Status != "open" && query.Status != "acknowledged" && query.Status != "all" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("status must be open, acknowledged or all"),
+		})
+		return
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 || query.PageSize > 100 {
+		query.PageSize = 50
+	}
+
+	ctx := c.Request.Context()
+	db := h.db.Reader()
+	const where = `
+		WHERE ($1 = 'all' OR ($1 = 'open') = (a.acknowledged_at IS NULL))
+		  AND ($2 = '' OR a.rule = $2)`
+	args := []interface{}{query.Status, query.Rule}
+
+	var total int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM security_alerts a"+where, args...).Scan(&total); err != nil {
+		internalError(c, "Failed to fetch security alerts", err)
+		return
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT a.id, a.rule, a.severity, a.user_id, u.full_name, u.email, a.ip_address, a.event_count,
+		       a.response, a.response_until, a.acknowledged_at, a.acknowledged_by, a.created_at
+		FROM security_alerts a
+		LEFT JOIN users u ON u.id = a.user_id`+where+`
+		ORDER BY a.created_at DESC
+		LIMIT $3 OFFSET $4
+	`, append(args, query.PageSize, (query.Page-1)*query.PageSize)...)
+	if err != nil {
+		internalError(c, "Failed to fetch security alerts", err)
+		return
+	}
+	defer rows.Close()
+
+	alerts := []models.SecurityAlert{}
+	for rows.Next() {
+		var a models.SecurityAlert
+		if err := rows.Scan(&a.ID, &a.Rule, &a.Severity, &a.UserID, &a.UserName, &a.UserEmail, &a.IPAddress,
+			&a.EventCount, &a.Response, &a.ResponseUntil, &a.AcknowledgedAt, &a.AcknowledgedBy, &a.CreatedAt); err != nil {
+			internalError(c, "Failed to fetch security alerts", err)
+			return
+		}
+		alerts = append(alerts, a)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch security alerts", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: models.PaginatedResponse{
+			Data:       alerts,
+			Page:       query.Page,
+			PageSize:   query.PageSize,
+			TotalItems: int64(total),
+			TotalPages: (total + query.PageSize - 1) / query.PageSize,
+		},
+	})
+}
+
+// AcknowledgeSecurityAlert marks an alert as reviewed, taking it off the
+// open feed. Its lockout, if any, stays until it ends or is lifted.
+// POST /api/v1/admin/security/alerts/:id/acknowledge
+func (h *SecurityHandler) AcknowledgeSecurityAlert(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid alert ID"),
+		})
+		return
+	}
+
+	adminID, _ := middleware.UserID(c)
+	result, err := h.db.ExecContext(c.Request.Context(), `
+		UPDATE security_alerts
+		SET acknowledged_at = COALESCE(acknowledged_at, NOW()),
+		    acknowledged_by = COALESCE(acknowledged_by, $2)
+		WHERE id = $1
+	`, id, adminID)
+	if err != nil {
+		internalError(c, "Failed to acknowledge alert", err)
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Alert not found"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Alert acknowledged",
+	})
+}
+
+// ListLockouts returns the lockouts in force, ending soonest first
+// GET /api/v1/admin/security/lockouts
+func (h *SecurityHandler) ListLockouts(c *gin.Context) {
+	rows, err := h.db.Reader().QueryContext(c.Request.Context(), `
+		SELECT l.id, l.kind, l.user_id, u.full_name, l.ip_address, l.locked_until, l.alert_id, l.created_at
+		FROM security_lockouts l
+		LEFT JOIN users u ON u.id = l.user_id
+		WHERE l.lifted_at IS NULL AND l.locked_until > NOW()
+		ORDER BY l.locked_until ASC
+	`)
+	if err != nil {
+		internalError(c, "Failed to fetch lockouts", err)
+		return
+	}
+	defer rows.Close()
+
+	lockouts := []models.SecurityLockout{}
+	for rows.Next() {
+		var l models.SecurityLockout
+		if err := rows.Scan(&l.ID, &l.Kind, &l.UserID, &l.UserName, &l.IPAddress, &l.LockedUntil,
+			&l.AlertID, &l.CreatedAt); err != nil {
+			internalError(c, "Failed to fetch lockouts", err)
+			return
+		}
+		lockouts = append(lockouts, l)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch lockouts", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    lockouts,
+	})
+}
+
+// LiftLockout ends a lockout early, e.g. once the account's owner has been
+// verified. Automatic mutes are lifted by unmuting the user.
+// DELETE /api/v1/admin/security/lockouts/:id
+func (h *SecurityHandler) LiftLockout(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid lockout ID"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	adminID, _ := middleware.UserID(c)
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to lift lockout", err)
+		return
+	}
+	defer tx.Rollback()
+
+	var kind string
+	var userID *uuid.UUID
+	var ip *string
+	err = tx.QueryRowContext(ctx, `
+		UPDATE security_lockouts
+		SET lifted_at = NOW(), lifted_by = $2
+		WHERE id = $1 AND lifted_at IS NULL AND locked_until > NOW()
+		RETURNING kind, user_id, ip_address
+	`, id, adminID).Scan(&kind, &userID, &ip)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Lockout not found or already ended"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to lift lockout", err)
+		return
+	}
+
+	details := map[string]interface{}{"lockout_id": id, "kind": kind}
+	if ip != nil {
+		details["ip_address"] = *ip
+	}
+	if err := audit.Record(ctx, tx, audit.Entry{
+		Action:        audit.ActionLockoutLift,
+		ActorID:       &adminID,
+		SubjectUserID: userID,
+		Method:        c.Request.Method,
+		Path:          c.Request.URL.RequestURI(),
+		Status:        http.StatusOK,
+		RequestID:     middleware.GetRequestID(c),
+		IPAddress:     c.ClientIP(),
+		Details:       details,
+	}); err != nil {
+		internalError(c, "Failed to lift lockout", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to lift lockout", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Lockout lifted",
+	})
+}
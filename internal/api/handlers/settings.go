@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/audit"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/settings"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// SettingsHandler lets admins change runtime settings without a restart
+type SettingsHandler struct {
+	db       *database.DB
+	settings *settings.Store
+}
+
+// NewSettingsHandler creates a new settings handler
+func NewSettingsHandler(db *database.DB, settingsStore *settings.Store) *SettingsHandler {
+	return &SettingsHandler{db: db, settings: settingsStore}
+}
+
+// GetSettings returns the runtime settings in force, the overrides and the
+// defaults from config
+// GET /api/v1/admin/settings
+func (h *SettingsHandler) GetSettings(c *gin.Context) {
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    h.runtimeSettings(c),
+	})
+}
+
+// UpdateSettings overrides the settings in the body, keyed by setting. null
+// resets a setting to its default. Features are overridden per feature, so
+// {"features": {"chat": false}} leaves the other features as they are.
+// Each changed setting is audited, and other instances pick up the change
+// within a few seconds.
+// PATCH /api/v1/admin/settings
+func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
+	var req map[string]json.RawMessage
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	adminID, _ := middleware.UserID(c)
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to update settings", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Lock out concurrent updates so each sees the other's overrides
+	if _, err := tx.ExecContext(ctx, "LOCK TABLE runtime_settings IN EXCLUSIVE MODE"); err != nil {
+		internalError(c, "Failed to update settings", err)
+		return
+	}
+	current, err := settings.LoadOverrides(ctx, tx)
+	if err != nil {
+		internalError(c, "Failed to update settings", err)
+		return
+	}
+
+	updated := make(map[string]json.RawMessage, len(current))
+	for key, value := range current {
+		updated[key] = value
+	}
+	for key, value := range req {
+		if key == "features" && !isJSONNull(value) {
+			value, err = mergeFeatures(current[key], value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, models.APIResponse{
+					Success: false,
+					Error:   strPtr(err.Error()),
+				})
+				return
+			}
+		}
+		if isJSONNull(value) {
+			delete(updated, key)
+		} else {
+			updated[key] = value
+		}
+	}
+	if _, err := settings.Apply(h.settings.Defaults(), updated); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	keys := make([]string, 0, len(req))
+	for key := range req {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		old, now := current[key], updated[key]
+		if sameJSON(old, now) {
+			continue
+		}
+		if now == nil {
+			_, err = tx.ExecContext(ctx, "DELETE FROM runtime_settings WHERE key = $1", key)
+		} else {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO runtime_settings (key, value, updated_by)
+				VALUES ($1, $2, $3)
+				ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, updated_by = EXCLUDED.updated_by
+			`, key, []byte(now), adminID)
+		}
+		if err != nil {
+			internalError(c, "Failed to update settings", err)
+			return
+		}
+
+		if err := audit.Record(ctx, tx, audit.Entry{
+			Action:    audit.ActionSettingsUpdate,
+			ActorID:   &adminID,
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.RequestURI(),
+			Status:    http.StatusOK,
+			RequestID: middleware.GetRequestID(c),
+			IPAddress: c.ClientIP(),
+			Details:   map[string]interface{}{"key": key, "old": old, "new": now},
+		}); err != nil {
+			internalError(c, "Failed to update settings", err)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to update settings", err)
+		return
+	}
+	h.settings.Refresh()
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Settings updated",
+		Data:    h.runtimeSettings(c),
+	})
+}
+
+func (h *SettingsHandler) runtimeSettings(c *gin.Context) models.RuntimeSettings {
+	ctx := c.Request.Context()
+	overrides := h.settings.Overrides(ctx)
+	if overrides == nil {
+		overrides = map[string]json.RawMessage{}
+	}
+	return models.RuntimeSettings{
+		Settings:  h.settings.Current(ctx),
+		Overrides: overrides,
+		Defaults:  h.settings.Defaults(),
+	}
+}
+
+// mergeFeatures applies a features update to the overridden features. A
+// feature set to null goes back to its default.
+func mergeFeatures(current, update json.RawMessage) (json.RawMessage, error) {
+	var changes map[string]*bool
+	if err := json.Unmarshal(update, &changes); err != nil {
+		return nil, errFeatures
+	}
+	features := map[string]bool{}
+	if current != nil {
+		if err := json.Unmarshal(current, &features); err != nil {
+			return nil, err
+		}
+	}
+	for feature, on := range changes {
+		if on == nil {
+			delete(features, feature)
+		} else {
+			features[feature] = *on
+		}
+	}
+	if len(features) == 0 {
+		return json.RawMessage("null"), nil
+	}
+	return json.Marshal(features)
+}
+
+var errFeatures = errors.New("features must map feature names to true, false or null")
+
+// sameJSON reports whether two JSON values are equal, however formatted
+func sameJSON(a, b json.RawMessage) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	var x, y interface{}
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func isJSONNull(value json.RawMessage) bool {
+	return value == nil || bytes.Equal(bytes.TrimSpace(value), []byte("null"))
+}
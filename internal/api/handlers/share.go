@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/repository"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// shareDescriptionLength bounds the preview text, which chat apps cut short
+// anyway
+const shareDescriptionLength = 200
+
+// ShareHandler renders the pages behind shared event and post links. Chat
+// apps read their OpenGraph tags for the link preview; people opening them
+// are sent on into the app.
+type ShareHandler struct {
+	db        *database.DB
+	baseURL   string
+	appScheme string
+}
+
+// NewShareHandler creates a new share handler. baseURL is the public URL of
+// the API and appScheme the URL scheme the app opens, such as
+// "collegeevents".
+func NewShareHandler(db *database.DB, baseURL, appScheme string) *ShareHandler {
+	return &ShareHandler{
+		db:        db,
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		appScheme: appScheme,
+	}
+}
+
+// sharePreview is what a share page shows
+type sharePreview struct {
+	Title       string
+	Description string
+	ImageURL    string
+	PageURL     string
+	// A URL in the app's own scheme, which templates otherwise treat as
+	// unsafe
+	DeepLink template.URL
+}
+
+var sharePage = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html><head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<meta property="og:type" content="website">
+<meta property="og:title" content="{{.Title}}">
+<meta property="og:description" content="{{.Description}}">
+<meta property="og:url" content="{{.PageURL}}">
+{{- if .ImageURL}}
+<meta property="og:image" content="{{.ImageURL}}">
+<meta name="twitter:card" content="summary_large_image">
+<meta name="twitter:image" content="{{.ImageURL}}">
+{{- else}}
+<meta name="twitter:card" content="summary">
+{{- end}}
+<meta name="twitter:title" content="{{.Title}}">
+<meta name="twitter:description" content="{{.Description}}">
+{{- if .DeepLink}}
+<script>window.location.replace({{.DeepLink}});</script>
+{{- end}}
+</head>
+<body style="font-family: Arial, sans-serif; text-align: center; padding: 48px;">
+<h2>{{.Title}}</h2><p>{{.Description}}</p>
+{{- if .DeepLink}}
+<p><a href="{{.DeepLink}}">Open in the app</a></p>
+{{- end}}
+</body></html>`))
+
+// renderSharePage writes a share page. Previews are cached briefly, since a
+// link shared to a group is fetched by every member's app.
+func renderSharePage(c *gin.Context, status int, p sharePreview) {
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	if status == http.StatusOK {
+		c.Header("Cache-Control", "public, max-age=300")
+	}
+	c.Status(status)
+	if err := sharePage.Execute(c.Writer, p); err != nil {
+		logInternalError(c, "Failed to render share page", err)
+	}
+}
+
+// shareNotFound renders the page for a link to content that is gone
+func shareNotFound(c *gin.Context, what string) {
+	renderSharePage(c, http.StatusNotFound, sharePreview{
+		Title:       what + " not found",
+		Description: "This link may have expired or the " + strings.ToLower(what) + " was removed.",
+	})
+}
+
+// absoluteURL resolves an upload path served by this API, such as
+// /uploads/..., against the public URL. Chat apps only fetch absolute
+// image URLs.
+func (h *ShareHandler) absoluteURL(u string) string {
+	if strings.HasPrefix(u, "/") && !strings.HasPrefix(u, "//") {
+		return h.baseURL + u
+	}
+	return u
+}
+
+// deepLink returns the app link to a path, or "" with no app scheme set
+func (h *ShareHandler) deepLink(path string) template.URL {
+	if h.appScheme == "" {
+		return ""
+	}
+	return template.URL(h.appScheme + "://" + path)
+}
+
+// shareExcerpt shortens s to at most n runes on a word boundary
+func shareExcerpt(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	cut := string(r[:n])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return cut + "…"
+}
+
+// ShareEvent renders the preview page of a shared event link. It's seen
+// signed out, so alumni events aren't found.
+// GET /share/events/:id
+func (h *ShareHandler) ShareEvent(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		shareNotFound(c, "Event")
+		return
+	}
+
+	event, err := repository.New(h.db.Reader()).GetEvent(c.Request.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		shareNotFound(c, "Event")
+		return
+	}
+	if err == nil && !event.VisibleTo("") {
+		shareNotFound(c, "Event")
+		return
+	}
+	if err != nil {
+		logInternalError(c, "Failed to fetch shared event", err)
+		renderSharePage(c, http.StatusInternalServerError, sharePreview{
+			Title:       "Something went wrong",
+			Description: "We couldn't load this event. Please try again later.",
+		})
+		return
+	}
+
+	description := event.StartDate.Format("Mon, 2 Jan 2006 3:04 PM")
+	if event.Location != nil && *event.Location != "" {
+		description += " · " + *event.Location
+	}
+	if event.Description != nil && *event.Description != "" {
+		description += " — " + *event.Description
+	}
+	p := sharePreview{
+		Title:       event.Title,
+		Description: shareExcerpt(description, shareDescriptionLength),
+		PageURL:     h.baseURL + "/share/events/" + event.ID.String(),
+		DeepLink:    h.deepLink("events/" + event.ID.String()),
+	}
+	if event.BannerURL != nil {
+		p.ImageURL = h.absoluteURL(*event.BannerURL)
+	}
+	renderSharePage(c, http.StatusOK, p)
+}
+
+// SharePost renders the preview page of a shared post link. Posts hidden
+// from signed out users, such as a shadow banned user's, are not found.
+// GET /share/posts/:id
+func (h *ShareHandler) SharePost(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		shareNotFound(c, "Post")
+		return
+	}
+
+	post, err := repository.New(h.db.Reader()).GetPost(c.Request.Context(), id, nil)
+	if errors.Is(err, sql.ErrNoRows) {
+		shareNotFound(c, "Post")
+		return
+	}
+	if err != nil {
+		logInternalError(c, "Failed to fetch shared post", err)
+		renderSharePage(c, http.StatusInternalServerError, sharePreview{
+			Title:       "Something went wrong",
+			Description: "We couldn't load this post. Please try again later.",
+		})
+		return
+	}
+
+	p := sharePreview{
+		Title:       "Post by " + post.Creator.FullName,
+		Description: shareExcerpt(post.Description, shareDescriptionLength),
+		PageURL:     h.baseURL + "/share/posts/" + post.ID.String(),
+		DeepLink:    h.deepLink("posts/" + post.ID.String()),
+	}
+	switch {
+	case post.ImageURL != nil:
+		p.ImageURL = h.absoluteURL(*post.ImageURL)
+	case post.ThumbnailURL != nil:
+		p.ImageURL = h.absoluteURL(*post.ThumbnailURL)
+	}
+	renderSharePage(c, http.StatusOK, p)
+}
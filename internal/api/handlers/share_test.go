@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSharePageEscapesAndKeepsDeepLink(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewShareHandler(nil, "https://api.example.edu/", "collegeevents")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	renderSharePage(c, http.StatusOK, sharePreview{
+		Title:       `Quiz "night" <b>`,
+		Description: "Bring a friend",
+		ImageURL:    h.absoluteURL("/uploads/banner.jpg"),
+		PageURL:     h.baseURL + "/share/events/1",
+		DeepLink:    h.deepLink("events/1"),
+	})
+
+	body := w.Body.String()
+	for _, want := range []string{
+		`<meta property="og:title" content="Quiz &#34;night&#34; &lt;b&gt;">`,
+		`<meta property="og:image" content="https://api.example.edu/uploads/banner.jpg">`,
+		`<meta name="twitter:card" content="summary_large_image">`,
+		`<a href="collegeevents://events/1">`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("page missing %s\n%s", want, body)
+		}
+	}
+	if got := w.Header().Get("Cache-Control"); got == "" {
+		t.Error("preview is not cacheable")
+	}
+}
+
+func TestShareExcerpt(t *testing.T) {
+	if got := shareExcerpt("  short\n text ", 20); got != "short text" {
+		t.Errorf("got %q", got)
+	}
+	if got := shareExcerpt("one two three", 9); got != "one two…" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+const (
+	// shortCodeAlphabet leaves out characters easily misread on a poster:
+	// 0/O and 1/I
+	shortCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	shortCodeLength   = 6
+	// shortCodeAttempts bounds retries when a generated code is taken
+	shortCodeAttempts = 5
+)
+
+const shortLinkColumns = `
+	id, code, event_id, source, click_count, last_clicked_at, created_by, created_at`
+
+func scanShortLink(row interface{ Scan(...interface{}) error }) (models.ShortLink, error) {
+	var l models.ShortLink
+	err := row.Scan(
+		&l.ID, &l.Code, &l.EventID, &l.Source, &l.ClickCount, &l.LastClickedAt, &l.CreatedBy, &l.CreatedAt,
+	)
+	return l, err
+}
+
+// ShortLinkHandler manages short links to events
+type ShortLinkHandler struct {
+	db      *database.DB
+	baseURL string
+}
+
+// NewShortLinkHandler creates a new short link handler. baseURL is the
+// public URL of the API, which serves the short links.
+func NewShortLinkHandler(db *database.DB, baseURL string) *ShortLinkHandler {
+	return &ShortLinkHandler{db: db, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// newShortCode returns a random code from shortCodeAlphabet
+func newShortCode() (string, error) {
+	b := make([]byte, shortCodeLength)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(shortCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		b[i] = shortCodeAlphabet[n.Int64()]
+	}
+	return string(b), nil
+}
+
+// errShortCodeTaken is returned for a chosen short code already in use
+var errShortCodeTaken = errors.New("short code taken")
+
+// createShortLink creates a short link to an event with the given code, or
+// a generated one if code is nil
+func createShortLink(ctx context.Context, db *sql.DB, eventID uuid.UUID, source string, code *string, createdBy uuid.UUID) (models.ShortLink, error) {
+	attempts := shortCodeAttempts
+	if code != nil {
+		attempts = 1
+	}
+	for i := 0; i < attempts; i++ {
+		var c string
+		if code != nil {
+			c = strings.ToUpper(*code)
+		} else {
+			var err error
+			if c, err = newShortCode(); err != nil {
+				return models.ShortLink{}, err
+			}
+		}
+
+		link, err := scanShortLink(db.QueryRowContext(ctx, `
+			INSERT INTO short_links (code, event_id, source, created_by)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (code) DO NOTHING
+			RETURNING `+shortLinkColumns,
+			c, eventID, source, createdBy))
+		if errors.Is(err, sql.ErrNoRows) {
+			// The code is taken
+			continue
+		}
+		return link, err
+	}
+	if code != nil {
+		return models.ShortLink{}, errShortCodeTaken
+	}
+	return models.ShortLink{}, errors.New("no free short code found")
+}
+
+// CreateShortLink creates a short link to an event for one place it is
+// shared, such as "poster" or "instagram" (organizers only). Codes are
+// case-insensitive; organizers may choose one or have it generated.
+// POST /api/v1/events/:id/short-links
+func (h *ShortLinkHandler) CreateShortLink(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+
+	var req models.CreateShortLinkRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	source := strings.ToLower(strings.TrimSpace(req.Source))
+	if source == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("source is required"),
+		})
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+	link, err := createShortLink(c.Request.Context(), h.db.DB, event.ID, source, req.Code, userID)
+	if errors.Is(err, errShortCodeTaken) {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("that code is already taken"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to create short link", err)
+		return
+	}
+
+	link.URL = h.baseURL + "/e/" + link.Code
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "short link created",
+		Data:    link,
+	})
+}
+
+// ListShortLinks returns an event's short links with their click counts,
+// most clicked first (organizers only)
+// GET /api/v1/events/:id/short-links
+func (h *ShortLinkHandler) ListShortLinks(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+
+	rows, err := h.db.QueryContext(c.Request.Context(), `
+		SELECT `+shortLinkColumns+`
+		FROM short_links
+		WHERE event_id = $1
+		ORDER BY click_count DESC, created_at DESC
+	`, event.ID)
+	if err != nil {
+		internalError(c, "failed to fetch short links", err)
+		return
+	}
+	defer rows.Close()
+
+	links := []models.ShortLink{}
+	for rows.Next() {
+		l, err := scanShortLink(rows)
+		if err != nil {
+			internalError(c, "failed to fetch short links", err)
+			return
+		}
+		l.URL = h.baseURL + "/e/" + l.Code
+		links = append(links, l)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "failed to fetch short links", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    links,
+	})
+}
+
+// DeleteShortLink deletes a short link, which stops working (organizers
+// only)
+// DELETE /api/v1/events/:id/short-links/:link_id
+func (h *ShortLinkHandler) DeleteShortLink(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+
+	linkID, err := uuid.Parse(c.Param("link_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid short link ID"),
+		})
+		return
+	}
+
+	res, err := h.db.ExecContext(c.Request.Context(),
+		"DELETE FROM short_links WHERE id = $1 AND event_id = $2", linkID, event.ID)
+	if err != nil {
+		internalError(c, "failed to delete short link", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("short link not found"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "short link deleted",
+	})
+}
+
+// FollowShortLink counts a click on a short link and redirects to the
+// event's share page, which previews the event and opens the app
+// GET /e/:code
+func (h *ShortLinkHandler) FollowShortLink(c *gin.Context) {
+	var eventID uuid.UUID
+	err := h.db.QueryRowContext(c.Request.Context(), `
+		UPDATE short_links
+		SET click_count = click_count + 1, last_clicked_at = CURRENT_TIMESTAMP
+		WHERE code = $1
+		RETURNING event_id
+	`, strings.ToUpper(c.Param("code"))).Scan(&eventID)
+	if errors.Is(err, sql.ErrNoRows) {
+		renderSharePage(c, http.StatusNotFound, sharePreview{
+			Title:       "Link not found",
+			Description: "This link may have been mistyped or removed.",
+		})
+		return
+	}
+	if err != nil {
+		logInternalError(c, "Failed to follow short link", err)
+		renderSharePage(c, http.StatusInternalServerError, sharePreview{
+			Title:       "Something went wrong",
+			Description: "We couldn't open this link. Please try again later.",
+		})
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/share/events/"+eventID.String())
+}
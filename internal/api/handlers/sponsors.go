@@ -0,0 +1,351 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/storage"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+const sponsorColumns = `
+	id, event_id, fest_id, name, tier, logo_url, link_url, sort_order, created_at, updated_at`
+
+func scanSponsor(row interface{ Scan(...interface{}) error }) (models.Sponsor, error) {
+	var s models.Sponsor
+	err := row.Scan(
+		&s.ID, &s.EventID, &s.FestID, &s.Name, &s.Tier, &s.LogoURL, &s.LinkURL, &s.SortOrder, &s.CreatedAt, &s.UpdatedAt,
+	)
+	return s, err
+}
+
+// loadSponsors returns the sponsors of an event and of a fest, either of
+// which may be nil, title sponsors first and then in the organizers' order
+func loadSponsors(ctx context.Context, db *sql.DB, eventID, festID *uuid.UUID) ([]models.Sponsor, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+sponsorColumns+`
+		FROM sponsors
+		WHERE event_id = $1 OR fest_id = $2
+		ORDER BY CASE tier WHEN 'title' THEN 0 WHEN 'gold' THEN 1 ELSE 2 END, sort_order, name
+	`, eventID, festID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sponsors := []models.Sponsor{}
+	for rows.Next() {
+		s, err := scanSponsor(rows)
+		if err != nil {
+			return nil, err
+		}
+		sponsors = append(sponsors, s)
+	}
+	return sponsors, rows.Err()
+}
+
+// sponsorOwner is the event or fest a sponsor is attached to
+type sponsorOwner struct {
+	column string // event_id or fest_id
+	id     uuid.UUID
+}
+
+// SponsorHandler manages event and fest sponsors
+type SponsorHandler struct {
+	db      *database.DB
+	storage storage.StorageService
+}
+
+// NewSponsorHandler creates a new sponsor handler
+func NewSponsorHandler(db *database.DB, storage storage.StorageService) *SponsorHandler {
+	return &SponsorHandler{db: db, storage: storage}
+}
+
+// CreateEventSponsor adds a sponsor to an event (event organizers only)
+// POST /api/v1/events/:id/sponsors
+func (h *SponsorHandler) CreateEventSponsor(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+	h.create(c, sponsorOwner{"event_id", event.ID})
+}
+
+// UpdateEventSponsor edits an event's sponsor (event organizers only)
+// PUT /api/v1/events/:id/sponsors/:sponsor_id
+func (h *SponsorHandler) UpdateEventSponsor(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+	h.update(c, sponsorOwner{"event_id", event.ID})
+}
+
+// DeleteEventSponsor removes an event's sponsor (event organizers only)
+// DELETE /api/v1/events/:id/sponsors/:sponsor_id
+func (h *SponsorHandler) DeleteEventSponsor(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+	h.delete(c, sponsorOwner{"event_id", event.ID})
+}
+
+// CreateFestSponsor adds a sponsor to a fest (admin only)
+// POST /api/v1/admin/fests/:id/sponsors
+func (h *SponsorHandler) CreateFestSponsor(c *gin.Context) {
+	festID, ok := h.requireFest(c)
+	if !ok {
+		return
+	}
+	h.create(c, sponsorOwner{"fest_id", festID})
+}
+
+// UpdateFestSponsor edits a fest's sponsor (admin only)
+// PUT /api/v1/admin/fests/:id/sponsors/:sponsor_id
+func (h *SponsorHandler) UpdateFestSponsor(c *gin.Context) {
+	festID, ok := h.requireFest(c)
+	if !ok {
+		return
+	}
+	h.update(c, sponsorOwner{"fest_id", festID})
+}
+
+// DeleteFestSponsor removes a fest's sponsor (admin only)
+// DELETE /api/v1/admin/fests/:id/sponsors/:sponsor_id
+func (h *SponsorHandler) DeleteFestSponsor(c *gin.Context) {
+	festID, ok := h.requireFest(c)
+	if !ok {
+		return
+	}
+	h.delete(c, sponsorOwner{"fest_id", festID})
+}
+
+func (h *SponsorHandler) create(c *gin.Context, owner sponsorOwner) {
+	var req models.CreateSponsorRequest
+	if !bindSponsorForm(c, &req) {
+		return
+	}
+
+	logoURL, logoPath, ok := h.uploadLogo(c)
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+	s, err := scanSponsor(h.db.QueryRowContext(c.Request.Context(), `
+		INSERT INTO sponsors (`+owner.column+`, name, tier, logo_url, logo_path, link_url, sort_order, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING `+sponsorColumns,
+		owner.id, req.Name, req.Tier, logoURL, logoPath, req.LinkURL, req.SortOrder, userID))
+	if err != nil {
+		if logoPath != nil {
+			h.deleteLogo(c, *logoPath)
+		}
+		internalError(c, "Failed to add sponsor", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Sponsor added successfully",
+		Data:    s,
+	})
+}
+
+func (h *SponsorHandler) update(c *gin.Context, owner sponsorOwner) {
+	sponsorID, err := uuid.Parse(c.Param("sponsor_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid sponsor ID"),
+		})
+		return
+	}
+
+	var req models.UpdateSponsorRequest
+	if !bindSponsorForm(c, &req) {
+		return
+	}
+
+	logoURL, logoPath, ok := h.uploadLogo(c)
+	if !ok {
+		return
+	}
+
+	// The old logo path is read in the same statement that replaces it
+	var s models.Sponsor
+	var oldLogoPath sql.NullString
+	err = h.db.QueryRowContext(c.Request.Context(), `
+		WITH old AS (
+			SELECT id, logo_path FROM sponsors WHERE id = $7 AND `+owner.column+` = $8
+		)
+		UPDATE sponsors s
+		SET name = COALESCE($1, s.name),
+		    tier = COALESCE($2, s.tier),
+		    link_url = COALESCE($3, s.link_url),
+		    sort_order = COALESCE($4, s.sort_order),
+		    logo_url = COALESCE($5, s.logo_url),
+		    logo_path = COALESCE($6, s.logo_path)
+		FROM old
+		WHERE s.id = old.id
+		RETURNING s.id, s.event_id, s.fest_id, s.name, s.tier, s.logo_url, s.link_url, s.sort_order,
+		          s.created_at, s.updated_at, old.logo_path
+	`, req.Name, req.Tier, req.LinkURL, req.SortOrder, logoURL, logoPath, sponsorID, owner.id).Scan(
+		&s.ID, &s.EventID, &s.FestID, &s.Name, &s.Tier, &s.LogoURL, &s.LinkURL, &s.SortOrder,
+		&s.CreatedAt, &s.UpdatedAt, &oldLogoPath,
+	)
+	if err != nil {
+		if logoPath != nil {
+			h.deleteLogo(c, *logoPath)
+		}
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Sponsor not found"),
+			})
+			return
+		}
+		internalError(c, "Failed to update sponsor", err)
+		return
+	}
+	if logoPath != nil && oldLogoPath.Valid {
+		h.deleteLogo(c, oldLogoPath.String)
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Sponsor updated successfully",
+		Data:    s,
+	})
+}
+
+func (h *SponsorHandler) delete(c *gin.Context, owner sponsorOwner) {
+	sponsorID, err := uuid.Parse(c.Param("sponsor_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid sponsor ID"),
+		})
+		return
+	}
+
+	var logoPath sql.NullString
+	err = h.db.QueryRowContext(c.Request.Context(),
+		"DELETE FROM sponsors WHERE id = $1 AND "+owner.column+" = $2 RETURNING logo_path", sponsorID, owner.id,
+	).Scan(&logoPath)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Sponsor not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to remove sponsor", err)
+		return
+	}
+	if logoPath.Valid {
+		h.deleteLogo(c, logoPath.String)
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Sponsor removed successfully",
+	})
+}
+
+// requireFest parses :id and checks the fest exists. Returns false if the
+// request has been answered.
+func (h *SponsorHandler) requireFest(c *gin.Context) (uuid.UUID, bool) {
+	festID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid fest ID"),
+		})
+		return festID, false
+	}
+
+	var exists bool
+	if err := h.db.QueryRowContext(c.Request.Context(),
+		"SELECT EXISTS(SELECT 1 FROM fests WHERE id = $1 AND deleted_at IS NULL)", festID,
+	).Scan(&exists); err != nil {
+		internalError(c, "Failed to fetch fest", err)
+		return festID, false
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Fest not found"),
+		})
+		return festID, false
+	}
+	return festID, true
+}
+
+// bindSponsorForm binds the multipart form. Returns false if the request has
+// been answered.
+func bindSponsorForm(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBind(req); err != nil {
+		if strings.Contains(err.Error(), "http: request body too large") {
+			c.JSON(http.StatusRequestEntityTooLarge, models.APIResponse{
+				Success: false,
+				Error:   strPtr("File too large"),
+			})
+			return false
+		}
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return false
+	}
+	return true
+}
+
+// uploadLogo stores the optional "logo" file. Returns false if the request
+// has been answered.
+func (h *SponsorHandler) uploadLogo(c *gin.Context) (url, path *string, ok bool) {
+	file, header, err := c.Request.FormFile("logo")
+	if err == http.ErrMissingFile {
+		return nil, nil, true
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid logo upload"),
+		})
+		return nil, nil, false
+	}
+	defer file.Close()
+
+	if !isValidImageType(header.Header.Get("Content-Type")) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid file type. Allowed: JPEG, PNG, GIF, WebP"),
+		})
+		return nil, nil, false
+	}
+
+	result, err := h.storage.UploadImage(c.Request.Context(), file, header.Filename, "sponsors", storage.ImageTypeThumbnail)
+	if err != nil {
+		internalError(c, "Failed to upload logo", err)
+		return nil, nil, false
+	}
+	return &result.URL, &result.Path, true
+}
+
+func (h *SponsorHandler) deleteLogo(c *gin.Context, path string) {
+	if err := h.storage.Delete(c.Request.Context(), path); err != nil {
+		log.Printf("[WARN] request_id=%s failed to delete sponsor logo %s: %v",
+			middleware.GetRequestID(c), path, err)
+	}
+}
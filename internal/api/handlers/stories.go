@@ -2,30 +2,36 @@ package handlers
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
 	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/moderation"
 )
 
 // StoriesHandler handles story-related requests
 type StoriesHandler struct {
-	db *sql.DB
+	db     *sql.DB
+	images moderation.ImageChecker
 }
 
-// NewStoriesHandler creates a new stories handler
-func NewStoriesHandler(db *sql.DB) *StoriesHandler {
-	return &StoriesHandler{db: db}
+// NewStoriesHandler creates a new stories handler. Story images are
+// screened by images, which may be nil.
+func NewStoriesHandler(db *sql.DB, images moderation.ImageChecker) *StoriesHandler {
+	return &StoriesHandler{db: db, images: images}
 }
 
-// CreateStory creates a new 24-hour story (admin-only)
+// CreateStory creates a new 24-hour story (admin-only). A story whose image
+// fails the image check is held for review instead of published.
 // POST /api/v1/admin/stories
 func (h *StoriesHandler) CreateStory(c *gin.Context) {
 	var req models.CreateStoryRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Error:   strPtr("Invalid request: " + err.Error()),
@@ -34,8 +40,7 @@ func (h *StoriesHandler) CreateStory(c *gin.Context) {
 	}
 
 	// Get user ID from context
-	userID, _ := c.Get("user_id")
-	creatorID := userID.(uuid.UUID)
+	creatorID, _ := middleware.UserID(c)
 
 	// Validate content type matches URLs
 	if req.ContentType == models.ContentTypeImage && req.ImageURL == nil {
@@ -53,13 +58,20 @@ func (h *StoriesHandler) CreateStory(c *gin.Context) {
 		return
 	}
 
+	ctx := c.Request.Context()
+	imageReasons, err := moderation.CheckImages(ctx, h.images, req.ImageURL, &req.ThumbnailURL)
+	if err != nil {
+		logInternalError(c, "Image check failed, holding story for review", err)
+	}
+	held := len(imageReasons) > 0
+
 	// Insert story
 	query := `
 		INSERT INTO stories (
 			created_by, club_id, house_id, content_type,
 			image_url, video_url, thumbnail_url, duration_seconds,
-			description, hashtags
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			description, hashtags, held_for_review
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id, created_at, expires_at
 	`
 
@@ -68,12 +80,19 @@ func (h *StoriesHandler) CreateStory(c *gin.Context) {
 		hashtags = []string{}
 	}
 
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to create story", err)
+		return
+	}
+	defer tx.Rollback()
+
 	var story models.Story
-	err := h.db.QueryRow(
-		query,
+	err = tx.QueryRowContext(
+		ctx, query,
 		creatorID, req.ClubID, req.HouseID, req.ContentType,
 		req.ImageURL, req.VideoURL, req.ThumbnailURL, req.DurationSecs,
-		req.Description, pq.Array(hashtags),
+		req.Description, pq.Array(hashtags), held,
 	).Scan(&story.ID, &story.CreatedAt, &story.ExpiresAt)
 
 	if err != nil {
@@ -84,6 +103,17 @@ func (h *StoriesHandler) CreateStory(c *gin.Context) {
 		return
 	}
 
+	if held {
+		if err := moderation.RecordFlag(ctx, tx, moderation.ContentStory, story.ID, creatorID, imageReasons); err != nil {
+			internalError(c, "Failed to create story", err)
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to create story", err)
+		return
+	}
+
 	// Populate response
 	story.CreatedBy = creatorID
 	story.ClubID = req.ClubID
@@ -95,36 +125,45 @@ func (h *StoriesHandler) CreateStory(c *gin.Context) {
 	story.DurationSecs = req.DurationSecs
 	story.Description = req.Description
 	story.Hashtags = hashtags
+	story.HeldForReview = held
 
+	message := "Story created successfully (expires in 24 hours)"
+	if held {
+		message = "Story created and held for review (expires in 24 hours)"
+	}
 	c.JSON(http.StatusCreated, models.APIResponse{
 		Success: true,
-		Message: "Story created successfully (expires in 24 hours)",
+		Message: message,
 		Data:    story,
 	})
 }
 
-// ListStories lists active (non-expired) stories
+// ListStories lists active (non-expired) stories. Stories held for review
+// are listed only for their creators.
 // GET /api/v1/stories
 func (h *StoriesHandler) ListStories(c *gin.Context) {
+	var viewerID *uuid.UUID
+	if uid, ok := middleware.UserID(c); ok {
+		viewerID = &uid
+	}
+
 	query := `
 		SELECT 
 			s.id, s.created_by, s.club_id, s.house_id,
 			s.content_type, s.image_url, s.video_url, s.thumbnail_url, s.duration_seconds,
-			s.description, s.hashtags, s.created_at, s.expires_at,
+			s.description, s.hashtags, s.created_at, s.expires_at, s.held_for_review,
 			s.view_count, s.like_count,
 			u.id, u.full_name, u.avatar_url, u.role
 		FROM stories s
 		JOIN users u ON s.created_by = u.id
-		WHERE s.expires_at > CURRENT_TIMESTAMP
+		WHERE s.expires_at > CURRENT_TIMESTAMP AND s.deleted_at IS NULL
+		  AND ` + moderation.Published("$1", "s") + `
 		ORDER BY s.created_at DESC
 	`
 
-	rows, err := h.db.Query(query)
+	rows, err := h.db.Query(query, viewerID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Error:   strPtr("Failed to fetch stories"),
-		})
+		internalError(c, "Failed to fetch stories", err)
 		return
 	}
 	defer rows.Close()
@@ -139,27 +178,30 @@ func (h *StoriesHandler) ListStories(c *gin.Context) {
 		err := rows.Scan(
 			&sr.ID, &sr.CreatedBy, &sr.ClubID, &sr.HouseID,
 			&sr.ContentType, &sr.ImageURL, &sr.VideoURL, &sr.ThumbnailURL, &sr.DurationSecs,
-			&sr.Description, &hashtags, &sr.CreatedAt, &sr.ExpiresAt,
+			&sr.Description, &hashtags, &sr.CreatedAt, &sr.ExpiresAt, &sr.HeldForReview,
 			&sr.ViewCount, &sr.LikeCount,
 			&sr.Creator.ID, &sr.Creator.FullName, &sr.Creator.AvatarURL, &sr.Creator.Role,
 		)
 		if err != nil {
-			continue
+			internalError(c, "Failed to fetch stories", err)
+			return
 		}
 
 		sr.Hashtags = hashtags
 		sr.TimeRemaining = int(sr.ExpiresAt.Sub(now).Seconds())
 
 		// Check if current user liked/viewed
-		userID, exists := c.Get("user_id")
-		if exists {
-			uid := userID.(uuid.UUID)
+		if uid, exists := middleware.UserID(c); exists {
 			sr.IsLikedByMe = h.checkUserLikedStory(sr.ID, uid)
 			sr.IsViewedByMe = h.checkUserViewedStory(sr.ID, uid)
 		}
 
 		stories = append(stories, sr)
 	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch stories", err)
+		return
+	}
 
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
@@ -179,8 +221,7 @@ func (h *StoriesHandler) ToggleLike(c *gin.Context) {
 		return
 	}
 
-	userID, _ := c.Get("user_id")
-	uid := userID.(uuid.UUID)
+	uid, _ := middleware.UserID(c)
 
 	// Check if already liked
 	var exists bool
@@ -204,6 +245,23 @@ func (h *StoriesHandler) ToggleLike(c *gin.Context) {
 		})
 	} else {
 		// Like
+		var ownerID uuid.UUID
+		err = h.db.QueryRow("SELECT created_by FROM stories WHERE id = $1", storyID).Scan(&ownerID)
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Story not found"),
+			})
+			return
+		}
+		if err != nil {
+			internalError(c, "Failed to like story", err)
+			return
+		}
+		if !requireCanInteract(c, h.db, uid, &ownerID, false) {
+			return
+		}
+
 		_, err = h.db.Exec("INSERT INTO story_likes (story_id, user_id) VALUES ($1, $2)", storyID, uid)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, models.APIResponse{
@@ -232,8 +290,7 @@ func (h *StoriesHandler) TrackView(c *gin.Context) {
 		return
 	}
 
-	userID, _ := c.Get("user_id")
-	uid := userID.(uuid.UUID)
+	uid, _ := middleware.UserID(c)
 
 	// Insert view (unique constraint prevents duplicates)
 	query := "INSERT INTO story_views (story_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING"
@@ -252,6 +309,41 @@ func (h *StoriesHandler) TrackView(c *gin.Context) {
 	})
 }
 
+// DeleteStory soft deletes a story (admin-only). It stays in the trash until
+// restored or purged.
+// DELETE /api/v1/admin/stories/:id
+func (h *StoriesHandler) DeleteStory(c *gin.Context) {
+	storyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid story ID"),
+		})
+		return
+	}
+
+	query := "UPDATE stories SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL"
+	result, err := h.db.Exec(query, storyID)
+	if err != nil {
+		internalError(c, "Failed to delete story", err)
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Story not found"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Story deleted successfully",
+	})
+}
+
 // HardDeleteStory permanently deletes a story (admin-only)
 // DELETE /api/v1/admin/stories/:id/hard
 func (h *StoriesHandler) HardDeleteStory(c *gin.Context) {
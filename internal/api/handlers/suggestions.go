@@ -0,0 +1,328 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/auth"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+const (
+	suggestionPseudonymScope = "suggestions"
+	suggestionRateLimit      = 5
+	suggestionRateWindow     = 24 * time.Hour
+)
+
+const suggestionColumns = `
+	id, club_id, subject, body, status, response, response_public, responded_at, created_at, updated_at`
+
+func scanSuggestion(row interface{ Scan(...interface{}) error }) (models.Suggestion, error) {
+	var s models.Suggestion
+	err := row.Scan(
+		&s.ID, &s.ClubID, &s.Subject, &s.Body, &s.Status, &s.Response, &s.ResponsePublic,
+		&s.RespondedAt, &s.CreatedAt, &s.UpdatedAt,
+	)
+	return s, err
+}
+
+// SuggestionHandler handles the anonymous suggestion box
+type SuggestionHandler struct {
+	db   *database.DB
+	auth *auth.Service
+}
+
+// NewSuggestionHandler creates a new suggestion handler
+func NewSuggestionHandler(db *database.DB, authService *auth.Service) *SuggestionHandler {
+	return &SuggestionHandler{db: db, auth: authService}
+}
+
+// CreateSuggestion submits anonymous feedback to the admins or to a club.
+// Submitters must be signed in so they can be rate limited, but only a keyed
+// hash of their ID is stored.
+// POST /api/v1/suggestions
+func (h *SuggestionHandler) CreateSuggestion(c *gin.Context) {
+	var req models.CreateSuggestionRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	userID, _ := middleware.UserID(c)
+	submitter := h.auth.Pseudonym(suggestionPseudonymScope, userID)
+	ctx := c.Request.Context()
+
+	if req.ClubID != nil {
+		var exists bool
+		if err := h.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM clubs WHERE id = $1)", *req.ClubID).Scan(&exists); err != nil {
+			internalError(c, "Failed to submit suggestion", err)
+			return
+		}
+		if !exists {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Club not found"),
+			})
+			return
+		}
+	}
+
+	var recent int
+	var oldest sql.NullTime
+	if err := h.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), MIN(created_at) FROM suggestions
+		WHERE submitter_hash = $1 AND created_at > $2
+	`, submitter, time.Now().Add(-suggestionRateWindow)).Scan(&recent, &oldest); err != nil {
+		internalError(c, "Failed to submit suggestion", err)
+		return
+	}
+	if recent >= suggestionRateLimit {
+		if oldest.Valid {
+			retryAfter := time.Until(oldest.Time.Add(suggestionRateWindow))
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+		}
+		c.JSON(http.StatusTooManyRequests, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Too many suggestions, please try again later"),
+		})
+		return
+	}
+
+	s, err := scanSuggestion(h.db.QueryRowContext(ctx, `
+		INSERT INTO suggestions (club_id, subject, body, submitter_hash)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+suggestionColumns,
+		req.ClubID, req.Subject, req.Body, submitter))
+	if err != nil {
+		internalError(c, "Failed to submit suggestion", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Suggestion submitted anonymously",
+		Data:    s,
+	})
+}
+
+// ListMySuggestions returns the suggestions the current user submitted, so
+// they can follow their status
+// GET /api/v1/me/suggestions
+func (h *SuggestionHandler) ListMySuggestions(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+	h.list(c, "Failed to fetch suggestions", `
+		SELECT `+suggestionColumns+` FROM suggestions
+		WHERE submitter_hash = $1
+		ORDER BY created_at DESC
+		LIMIT 100
+	`, h.auth.Pseudonym(suggestionPseudonymScope, userID))
+}
+
+// ListPublicSuggestions returns suggestions whose response has been made
+// public, optionally for a single club
+// GET /api/v1/suggestions?club_id=
+func (h *SuggestionHandler) ListPublicSuggestions(c *gin.Context) {
+	var clubID *uuid.UUID
+	if s := c.Query("club_id"); s != "" {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Invalid club ID"),
+			})
+			return
+		}
+		clubID = &id
+	}
+
+	h.list(c, "Failed to fetch suggestions", `
+		SELECT `+suggestionColumns+` FROM suggestions
+		WHERE response_public AND ($1::uuid IS NULL OR club_id = $1)
+		ORDER BY responded_at DESC
+		LIMIT 100
+	`, clubID)
+}
+
+// ListAdminSuggestions returns suggestions addressed to the admins (admin only)
+// GET /api/v1/admin/suggestions?status=new
+func (h *SuggestionHandler) ListAdminSuggestions(c *gin.Context) {
+	h.listForTriage(c, nil)
+}
+
+// TriageAdminSuggestion updates a suggestion addressed to the admins (admin only)
+// PUT /api/v1/admin/suggestions/:id
+func (h *SuggestionHandler) TriageAdminSuggestion(c *gin.Context) {
+	h.triage(c, nil, c.Param("id"))
+}
+
+// ListClubSuggestions returns suggestions addressed to a club (club leads only)
+// GET /api/v1/clubs/:id/suggestions?status=new
+func (h *SuggestionHandler) ListClubSuggestions(c *gin.Context) {
+	clubID, ok := h.requireClubLead(c)
+	if !ok {
+		return
+	}
+	h.listForTriage(c, &clubID)
+}
+
+// TriageClubSuggestion updates a suggestion addressed to a club (club leads only)
+// PUT /api/v1/clubs/:id/suggestions/:suggestion_id
+func (h *SuggestionHandler) TriageClubSuggestion(c *gin.Context) {
+	clubID, ok := h.requireClubLead(c)
+	if !ok {
+		return
+	}
+	h.triage(c, &clubID, c.Param("suggestion_id"))
+}
+
+// requireClubLead parses the club ID and checks the user can manage the
+// club. Returns false if the request has been answered.
+func (h *SuggestionHandler) requireClubLead(c *gin.Context) (uuid.UUID, bool) {
+	clubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid club ID"),
+		})
+		return uuid.Nil, false
+	}
+
+	ok, err := canManageClub(c, h.db.DB, clubID)
+	if err != nil {
+		internalError(c, "Failed to check club role", err)
+		return uuid.Nil, false
+	}
+	if !ok {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Only club leads can view club suggestions"),
+		})
+		return uuid.Nil, false
+	}
+	return clubID, true
+}
+
+// listForTriage lists suggestions for the admins (clubID nil) or a club
+func (h *SuggestionHandler) listForTriage(c *gin.Context, clubID *uuid.UUID) {
+	var query models.ListSuggestionsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid query parameters"),
+		})
+		return
+	}
+	if query.Status != "" && !models.SuggestionStatus(query.Status).Valid() {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("status must be one of new, reviewing, resolved"),
+		})
+		return
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 || query.PageSize > 100 {
+		query.PageSize = 20
+	}
+
+	h.list(c, "Failed to fetch suggestions", `
+		SELECT `+suggestionColumns+` FROM suggestions
+		WHERE club_id IS NOT DISTINCT FROM $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`, clubID, query.Status, query.PageSize, (query.Page-1)*query.PageSize)
+}
+
+func (h *SuggestionHandler) list(c *gin.Context, failure, query string, args ...interface{}) {
+	rows, err := h.db.QueryContext(c.Request.Context(), query, args...)
+	if err != nil {
+		internalError(c, failure, err)
+		return
+	}
+	defer rows.Close()
+
+	suggestions := []models.Suggestion{}
+	for rows.Next() {
+		s, err := scanSuggestion(rows)
+		if err != nil {
+			internalError(c, failure, err)
+			return
+		}
+		suggestions = append(suggestions, s)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, failure, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    suggestions,
+	})
+}
+
+// triage applies a status change and/or response to a suggestion addressed
+// to the admins (clubID nil) or a club
+func (h *SuggestionHandler) triage(c *gin.Context, clubID *uuid.UUID, idParam string) {
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid suggestion ID"),
+		})
+		return
+	}
+
+	var req models.TriageSuggestionRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	if req.Status != nil && !req.Status.Valid() {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("status must be one of new, reviewing, resolved"),
+		})
+		return
+	}
+
+	s, err := scanSuggestion(h.db.QueryRowContext(c.Request.Context(), `
+		UPDATE suggestions
+		SET status = COALESCE($1, status),
+		    response = COALESCE($2, response),
+		    response_public = COALESCE($3, response_public),
+		    responded_at = CASE WHEN $2::text IS NULL THEN responded_at ELSE NOW() END
+		WHERE id = $4 AND club_id IS NOT DISTINCT FROM $5
+		RETURNING `+suggestionColumns,
+		req.Status, req.Response, req.ResponsePublic, id, clubID))
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Suggestion not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to update suggestion", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Suggestion updated",
+		Data:    s,
+	})
+}
@@ -0,0 +1,502 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/terms"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+const termColumns = `id, name, starts_on, ends_on, starts_on <= CURRENT_DATE AND ends_on >= CURRENT_DATE,
+	ends_academic_year, archived_at, created_at, updated_at`
+
+var errInvalidTerm = errors.New("invalid term")
+
+func scanTerm(row interface{ Scan(...interface{}) error }) (models.AcademicTerm, error) {
+	var t models.AcademicTerm
+	var startsOn, endsOn time.Time
+	err := row.Scan(&t.ID, &t.Name, &startsOn, &endsOn, &t.IsCurrent, &t.EndsAcademicYear, &t.ArchivedAt, &t.CreatedAt, &t.UpdatedAt)
+	t.StartsOn = startsOn.Format(dateLayout)
+	t.EndsOn = endsOn.Format(dateLayout)
+	return t, err
+}
+
+// termFilter resolves a ?term= filter: a term ID, "current", or "all" or
+// empty for no filter. "current" between terms also means no filter.
+func termFilter(ctx context.Context, db *sql.DB, value string) (*uuid.UUID, error) {
+	switch value {
+	case "", "all":
+		return nil, nil
+	case "current":
+		var id uuid.UUID
+		err := db.QueryRowContext(ctx, "SELECT id FROM academic_terms WHERE CURRENT_DATE BETWEEN starts_on AND ends_on").Scan(&id)
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &id, nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, errInvalidTerm
+	}
+	return &id, nil
+}
+
+// respondTermFilterError answers a failed termFilter
+func respondTermFilterError(c *gin.Context, err error) {
+	if errors.Is(err, errInvalidTerm) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid term"),
+		})
+		return
+	}
+	internalError(c, "Failed to resolve term", err)
+}
+
+// TermHandler manages academic terms
+type TermHandler struct {
+	db *database.DB
+}
+
+// NewTermHandler creates a new academic term handler
+func NewTermHandler(db *database.DB) *TermHandler {
+	return &TermHandler{db: db}
+}
+
+// ListTerms returns every academic term, newest first
+// GET /api/v1/terms
+func (h *TermHandler) ListTerms(c *gin.Context) {
+	rows, err := h.db.Reader().QueryContext(c.Request.Context(),
+		"SELECT "+termColumns+" FROM academic_terms ORDER BY starts_on DESC")
+	if err != nil {
+		internalError(c, "Failed to fetch terms", err)
+		return
+	}
+	defer rows.Close()
+
+	list := []models.AcademicTerm{}
+	for rows.Next() {
+		t, err := scanTerm(rows)
+		if err != nil {
+			internalError(c, "Failed to fetch terms", err)
+			return
+		}
+		list = append(list, t)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch terms", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    list,
+	})
+}
+
+// GetTermStandings returns a term's house and user leaderboards: the final
+// standings once the term is archived, live standings until then
+// GET /api/v1/terms/:id/standings
+func (h *TermHandler) GetTermStandings(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid term ID"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	db := h.db.Reader()
+	term, err := scanTerm(db.QueryRowContext(ctx, "SELECT "+termColumns+" FROM academic_terms WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Term not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to fetch standings", err)
+		return
+	}
+
+	resp := models.TermStandingsResponse{Term: term, Final: term.ArchivedAt != nil}
+	if resp.Final {
+		resp.Houses, err = queryStandings(ctx, db, `
+			SELECT rank, subject_id, name, points FROM academic_term_standings
+			WHERE term_id = $1 AND kind = 'house' ORDER BY rank, name
+		`, id)
+		if err == nil {
+			resp.Users, err = queryStandings(ctx, db, `
+				SELECT rank, subject_id, name, points FROM academic_term_standings
+				WHERE term_id = $1 AND kind = 'user' ORDER BY rank, name
+			`, id)
+		}
+	} else {
+		resp.Houses, err = queryStandings(ctx, db, `
+			SELECT RANK() OVER (ORDER BY COALESCE(SUM(l.points), 0) DESC), h.id, h.name, COALESCE(SUM(l.points), 0)
+			FROM houses h
+			LEFT JOIN house_point_ledger l ON l.house_id = h.id AND l.term_id = $1
+			WHERE h.deleted_at IS NULL
+			GROUP BY h.id, h.name
+			ORDER BY 4 DESC, h.name
+		`, id)
+		if err == nil {
+			resp.Users, err = queryStandings(ctx, db, `
+				SELECT RANK() OVER (ORDER BY SUM(ap.points) DESC), u.id, u.full_name, SUM(ap.points)
+				FROM activity_points ap
+				JOIN users u ON u.id = ap.user_id AND u.deleted_at IS NULL
+				WHERE ap.term_id = $1
+				GROUP BY u.id, u.full_name
+				ORDER BY 4 DESC, u.full_name
+				LIMIT $2
+			`, id, terms.StandingsUserLimit)
+		}
+	}
+	if err != nil {
+		internalError(c, "Failed to fetch standings", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    resp,
+	})
+}
+
+func queryStandings(ctx context.Context, db *sql.DB, query string, args ...interface{}) ([]models.TermStanding, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	standings := []models.TermStanding{}
+	for rows.Next() {
+		var s models.TermStanding
+		if err := rows.Scan(&s.Rank, &s.SubjectID, &s.Name, &s.Points); err != nil {
+			return nil, err
+		}
+		standings = append(standings, s)
+	}
+	return standings, rows.Err()
+}
+
+// CreateTerm defines an academic term (admin only). Existing records in its
+// date range are tagged with it.
+// POST /api/v1/admin/terms
+func (h *TermHandler) CreateTerm(c *gin.Context) {
+	var req models.CreateTermRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	startsOn, endsOn, ok := parseTermDates(c, req.StartsOn, req.EndsOn)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to create term", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if !h.checkTermConflicts(c, tx, uuid.Nil, req.Name, startsOn, endsOn) {
+		return
+	}
+
+	term, err := scanTerm(tx.QueryRowContext(ctx, `
+		INSERT INTO academic_terms (name, starts_on, ends_on, ends_academic_year)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+termColumns,
+		req.Name, startsOn, endsOn, req.EndsAcademicYear))
+	if err != nil {
+		internalError(c, "Failed to create term", err)
+		return
+	}
+	if err := terms.Retag(ctx, tx, term.ID, startsOn, endsOn); err != nil {
+		internalError(c, "Failed to create term", err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to create term", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Term created successfully",
+		Data:    term,
+	})
+}
+
+// UpdateTerm renames or moves an academic term, or marks whether it ends an
+// academic year (admin only). Records are retagged to match the new dates.
+// PUT /api/v1/admin/terms/:id
+func (h *TermHandler) UpdateTerm(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid term ID"),
+		})
+		return
+	}
+
+	var req models.UpdateTermRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "Failed to update term", err)
+		return
+	}
+	defer tx.Rollback()
+
+	current, err := scanTerm(tx.QueryRowContext(ctx,
+		"SELECT "+termColumns+" FROM academic_terms WHERE id = $1 FOR UPDATE", id))
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Term not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to update term", err)
+		return
+	}
+
+	name, starts, ends, endsYear := current.Name, current.StartsOn, current.EndsOn, current.EndsAcademicYear
+	if req.Name != nil {
+		name = *req.Name
+	}
+	if req.StartsOn != nil {
+		starts = *req.StartsOn
+	}
+	if req.EndsOn != nil {
+		ends = *req.EndsOn
+	}
+	if req.EndsAcademicYear != nil {
+		endsYear = *req.EndsAcademicYear
+	}
+	moved := starts != current.StartsOn || ends != current.EndsOn
+	if moved && current.ArchivedAt != nil {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Archived terms cannot be moved"),
+		})
+		return
+	}
+	startsOn, endsOn, ok := parseTermDates(c, starts, ends)
+	if !ok {
+		return
+	}
+	if !h.checkTermConflicts(c, tx, id, name, startsOn, endsOn) {
+		return
+	}
+
+	term, err := scanTerm(tx.QueryRowContext(ctx, `
+		UPDATE academic_terms SET name = $2, starts_on = $3, ends_on = $4, ends_academic_year = $5
+		WHERE id = $1
+		RETURNING `+termColumns,
+		id, name, startsOn, endsOn, endsYear))
+	if err != nil {
+		internalError(c, "Failed to update term", err)
+		return
+	}
+	if moved {
+		if err := terms.Retag(ctx, tx, id, startsOn, endsOn); err != nil {
+			internalError(c, "Failed to update term", err)
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "Failed to update term", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Term updated successfully",
+		Data:    term,
+	})
+}
+
+// DeleteTerm removes an academic term that has not been archived (admin
+// only). Its records become untagged.
+// DELETE /api/v1/admin/terms/:id
+func (h *TermHandler) DeleteTerm(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid term ID"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	var archived bool
+	err = h.db.QueryRowContext(ctx, "SELECT archived_at IS NOT NULL FROM academic_terms WHERE id = $1", id).Scan(&archived)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Term not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to delete term", err)
+		return
+	}
+	if archived {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Archived terms cannot be deleted"),
+		})
+		return
+	}
+
+	if _, err := h.db.ExecContext(ctx, "DELETE FROM academic_terms WHERE id = $1 AND archived_at IS NULL", id); err != nil {
+		internalError(c, "Failed to delete term", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Term deleted successfully",
+	})
+}
+
+// ArchiveTerm archives an ended term now rather than waiting for the nightly
+// job (admin only)
+// POST /api/v1/admin/terms/:id/archive
+func (h *TermHandler) ArchiveTerm(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid term ID"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	var ended bool
+	err = h.db.QueryRowContext(ctx, "SELECT ends_on < CURRENT_DATE FROM academic_terms WHERE id = $1", id).Scan(&ended)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Term not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to archive term", err)
+		return
+	}
+	if !ended {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Term has not ended yet"),
+		})
+		return
+	}
+
+	err = terms.Archive(ctx, h.db.DB, id)
+	if errors.Is(err, terms.ErrArchived) {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Term is already archived"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to archive term", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Term archived",
+	})
+}
+
+// parseTermDates parses and checks a term's dates, answering 400 if they
+// are invalid
+func parseTermDates(c *gin.Context, starts, ends string) (time.Time, time.Time, bool) {
+	startsOn, err1 := time.Parse(dateLayout, starts)
+	endsOn, err2 := time.Parse(dateLayout, ends)
+	if err1 != nil || err2 != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("starts_on and ends_on must be YYYY-MM-DD"),
+		})
+		return startsOn, endsOn, false
+	}
+	if endsOn.Before(startsOn) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("ends_on must not be before starts_on"),
+		})
+		return startsOn, endsOn, false
+	}
+	return startsOn, endsOn, true
+}
+
+// checkTermConflicts answers 409 if another term has the same name or
+// overlapping dates
+func (h *TermHandler) checkTermConflicts(c *gin.Context, tx *sql.Tx, id uuid.UUID, name string, startsOn, endsOn time.Time) bool {
+	var nameTaken, overlaps bool
+	err := tx.QueryRowContext(c.Request.Context(), `
+		SELECT
+			EXISTS(SELECT 1 FROM academic_terms WHERE id <> $1 AND name = $2),
+			EXISTS(SELECT 1 FROM academic_terms WHERE id <> $1 AND starts_on <= $4 AND ends_on >= $3)
+	`, id, name, startsOn, endsOn).Scan(&nameTaken, &overlaps)
+	if err != nil {
+		internalError(c, "Failed to save term", err)
+		return false
+	}
+	if nameTaken {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("A term with this name already exists"),
+		})
+		return false
+	}
+	if overlaps {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Term overlaps an existing term"),
+		})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+const ticketTierColumns = `
+	id, event_id, name, price, quantity, sold, sales_start, sales_end, sort_order, created_at, updated_at`
+
+func scanTicketTier(row interface{ Scan(...interface{}) error }) (models.TicketTier, error) {
+	var t models.TicketTier
+	err := row.Scan(
+		&t.ID, &t.EventID, &t.Name, &t.Price, &t.Quantity, &t.Sold, &t.SalesStart, &t.SalesEnd, &t.SortOrder,
+		&t.CreatedAt, &t.UpdatedAt,
+	)
+	t.Compute(time.Now())
+	return t, err
+}
+
+// loadTicketTiers returns an event's ticket tiers in the organizers' order
+func loadTicketTiers(ctx context.Context, db *sql.DB, eventID uuid.UUID) ([]models.TicketTier, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+ticketTierColumns+`
+		FROM ticket_tiers
+		WHERE event_id = $1
+		ORDER BY sort_order, price, name
+	`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tiers := []models.TicketTier{}
+	for rows.Next() {
+		t, err := scanTicketTier(rows)
+		if err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, t)
+	}
+	return tiers, rows.Err()
+}
+
+// validSalesWindow responds with 400 and returns false if a sale window
+// ends before it starts
+func validSalesWindow(c *gin.Context, start, end *time.Time) bool {
+	if start != nil && end != nil && !end.After(*start) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("sales_end must be after sales_start"),
+		})
+		return false
+	}
+	return true
+}
+
+// ListTicketTiers returns an event's ticket tiers with what is left of each
+// GET /api/v1/events/:id/ticket-tiers
+func (h *EventHandler) ListTicketTiers(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid event ID"),
+		})
+		return
+	}
+
+	tiers, err := loadTicketTiers(c.Request.Context(), h.db.Reader(), eventID)
+	if err != nil {
+		internalError(c, "failed to fetch ticket tiers", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    tiers,
+	})
+}
+
+// CreateTicketTier adds a ticket tier to a paid event (event organizers
+// only). Once an event has tiers, every order picks one.
+// POST /api/v1/events/:id/ticket-tiers
+func (h *EventHandler) CreateTicketTier(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+	if !event.IsPaidEvent {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("ticket tiers are only for paid events"),
+		})
+		return
+	}
+
+	var req models.CreateTicketTierRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	if !validSalesWindow(c, req.SalesStart, req.SalesEnd) {
+		return
+	}
+
+	tier, err := scanTicketTier(h.db.QueryRowContext(c.Request.Context(), `
+		INSERT INTO ticket_tiers (event_id, name, price, quantity, sales_start, sales_end, sort_order)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+ticketTierColumns,
+		event.ID, req.Name, *req.Price, req.Quantity, req.SalesStart, req.SalesEnd, req.SortOrder))
+	if err != nil {
+		internalError(c, "failed to create ticket tier", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "ticket tier created",
+		Data:    tier,
+	})
+}
+
+// UpdateTicketTier edits a ticket tier (event organizers only). Orders
+// already placed keep the price they were made at; cutting the quantity
+// below what is sold just ends sales.
+// PUT /api/v1/events/:id/ticket-tiers/:tier_id
+func (h *EventHandler) UpdateTicketTier(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+	tierID, err := uuid.Parse(c.Param("tier_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid ticket tier ID"),
+		})
+		return
+	}
+
+	var req models.UpdateTicketTierRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+
+	// The sale window is checked as it stands after the edit, which is
+	// rolled back if it is invalid
+	ctx := c.Request.Context()
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to update ticket tier", err)
+		return
+	}
+	defer tx.Rollback()
+
+	tier, err := scanTicketTier(tx.QueryRowContext(ctx, `
+		UPDATE ticket_tiers
+		SET name = COALESCE($1, name),
+		    price = COALESCE($2, price),
+		    quantity = COALESCE($3, quantity),
+		    sales_start = COALESCE($4, sales_start),
+		    sales_end = COALESCE($5, sales_end),
+		    sort_order = COALESCE($6, sort_order)
+		WHERE id = $7 AND event_id = $8
+		RETURNING `+ticketTierColumns,
+		req.Name, req.Price, req.Quantity, req.SalesStart, req.SalesEnd, req.SortOrder, tierID, event.ID))
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("ticket tier not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to update ticket tier", err)
+		return
+	}
+	if !validSalesWindow(c, tier.SalesStart, tier.SalesEnd) {
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to update ticket tier", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "ticket tier updated",
+		Data:    tier,
+	})
+}
+
+// DeleteTicketTier removes a ticket tier nobody has bought (event organizers
+// only). Tiers with sales are ended by closing their sale window instead.
+// DELETE /api/v1/events/:id/ticket-tiers/:tier_id
+func (h *EventHandler) DeleteTicketTier(c *gin.Context) {
+	event, ok := requireEventOrganizer(c, h.db)
+	if !ok {
+		return
+	}
+	tierID, err := uuid.Parse(c.Param("tier_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid ticket tier ID"),
+		})
+		return
+	}
+
+	var sold int
+	err = h.db.QueryRowContext(c.Request.Context(), `
+		WITH tier AS (
+			SELECT id, sold FROM ticket_tiers WHERE id = $1 AND event_id = $2
+		), deleted AS (
+			DELETE FROM ticket_tiers t USING tier
+			WHERE t.id = tier.id AND tier.sold = 0
+		)
+		SELECT sold FROM tier
+	`, tierID, event.ID).Scan(&sold)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("ticket tier not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "failed to delete ticket tier", err)
+		return
+	}
+	if sold > 0 {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   strPtr("tickets of this tier have been sold; end its sales instead"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "ticket tier deleted",
+	})
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/jobs"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/settings"
+	"github.com/yourusername/college-event-backend/internal/storage"
+)
+
+// TrashHandler lets admins see deleted events, posts and stories, and
+// restore or purge them before the cleanup job deletes them for good
+type TrashHandler struct {
+	db       *sql.DB
+	storage  storage.StorageService
+	settings *settings.Store
+}
+
+// NewTrashHandler creates a new trash handler. Deleted content is purged
+// once it has been in the trash for the retention in the runtime settings.
+func NewTrashHandler(db *sql.DB, storageService storage.StorageService, settingsStore *settings.Store) *TrashHandler {
+	return &TrashHandler{db: db, storage: storageService, settings: settingsStore}
+}
+
+// ListTrash lists deleted content, most recently deleted first, optionally
+// of one type
+// GET /api/v1/admin/trash
+func (h *TrashHandler) ListTrash(c *gin.Context) {
+	var query models.ListTrashQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid query parameters: " + err.Error()),
+		})
+		return
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 || query.PageSize > 100 {
+		query.PageSize = 50
+	}
+
+	rows, err := h.db.QueryContext(c.Request.Context(), `
+		SELECT type, id, title, created_by, deleted_at, COUNT(*) OVER ()
+		FROM (
+			SELECT 'event' AS type, id, title, created_by, deleted_at
+			FROM events WHERE deleted_at IS NOT NULL
+			UNION ALL
+			SELECT 'post', id, LEFT(description, 100), created_by, deleted_at
+			FROM posts WHERE deleted_at IS NOT NULL
+			UNION ALL
+			SELECT 'story', id, LEFT(COALESCE(description, ''), 100), created_by, deleted_at
+			FROM stories WHERE deleted_at IS NOT NULL
+		) t
+		WHERE $1::text IS NULL OR type = $1
+		ORDER BY deleted_at DESC
+		LIMIT $2 OFFSET $3
+	`, query.Type, query.PageSize, (query.Page-1)*query.PageSize)
+	if err != nil {
+		internalError(c, "Failed to fetch trash", err)
+		return
+	}
+	defer rows.Close()
+
+	retention := h.settings.Current(c.Request.Context()).TrashRetention()
+	items := []models.TrashItem{}
+	total := 0
+	for rows.Next() {
+		var item models.TrashItem
+		if err := rows.Scan(&item.Type, &item.ID, &item.Title, &item.CreatedBy, &item.DeletedAt, &total); err != nil {
+			internalError(c, "Failed to fetch trash", err)
+			return
+		}
+		item.PurgeAt = item.DeletedAt.Add(retention)
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch trash", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: models.PaginatedResponse{
+			Data:       items,
+			Page:       query.Page,
+			PageSize:   query.PageSize,
+			TotalItems: int64(total),
+			TotalPages: (total + query.PageSize - 1) / query.PageSize,
+		},
+	})
+}
+
+// RestoreTrashItem undeletes an event, post or story
+// POST /api/v1/admin/trash/:type/:id/restore
+func (h *TrashHandler) RestoreTrashItem(c *gin.Context) {
+	kind, id, ok := trashItemParams(c)
+	if !ok {
+		return
+	}
+
+	err := jobs.RestoreTrashed(c.Request.Context(), h.db, kind, id)
+	if errors.Is(err, jobs.ErrNotInTrash) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("No such " + kind + " in the trash"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to restore "+kind, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Restored " + kind,
+	})
+}
+
+// PurgeTrashItem deletes an event, post or story in the trash for good,
+// with its media, without waiting for the retention window
+// DELETE /api/v1/admin/trash/:type/:id
+func (h *TrashHandler) PurgeTrashItem(c *gin.Context) {
+	kind, id, ok := trashItemParams(c)
+	if !ok {
+		return
+	}
+
+	err := jobs.PurgeTrashed(c.Request.Context(), h.db, h.storage, kind, id)
+	if errors.Is(err, jobs.ErrNotInTrash) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("No such " + kind + " in the trash"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to purge "+kind, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Permanently deleted " + kind,
+	})
+}
+
+// trashItemParams parses the :type and :id route params. Returns false if
+// the request was aborted.
+func trashItemParams(c *gin.Context) (string, uuid.UUID, bool) {
+	kind := c.Param("type")
+	switch kind {
+	case jobs.TrashEvent, jobs.TrashPost, jobs.TrashStory:
+	default:
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("type must be event, post or story"),
+		})
+		return "", uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid " + kind + " ID"),
+		})
+		return "", uuid.Nil, false
+	}
+	return kind, id, true
+}
@@ -22,20 +22,17 @@ func NewUploadHandler(s storage.StorageService) *UploadHandler {
 // UploadImage handles image upload with optimization
 // POST /api/v1/admin/upload
 // Form fields:
-//   - file: the image file (required, max 10MB)
+//   - file: the image file (required; the request is capped by the upload body limit)
 //   - folder: storage folder - "events", "clubs", "profiles" (optional, default: "misc")
 //   - type: image type - "thumbnail", "banner", "original" (optional, default: "banner")
 func (h *UploadHandler) UploadImage(c *gin.Context) {
-	// 1. Limit request body size to 10MB
-	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, 10<<20)
-
-	// 2. Parse the multipart form
+	// 1-2. Parse the multipart form (the body limit is set by middleware)
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
 		if strings.Contains(err.Error(), "http: request body too large") {
 			c.JSON(http.StatusRequestEntityTooLarge, models.APIResponse{
 				Success: false,
-				Error:   strPtr("File too large. Maximum size is 10MB"),
+				Error:   strPtr("File too large"),
 			})
 			return
 		}
@@ -57,25 +54,8 @@ func (h *UploadHandler) UploadImage(c *gin.Context) {
 		return
 	}
 
-	// 4. Get folder (default to "misc")
-	folder := c.PostForm("folder")
-	if folder == "" {
-		folder = "misc"
-	}
-	// Sanitize folder name
-	folder = sanitizeFolderName(folder)
-
-	// 5. Get image type for sizing
-	imageTypeStr := c.PostForm("type")
-	imageType := storage.ImageTypeBanner // Default to banner size
-	switch imageTypeStr {
-	case "thumbnail":
-		imageType = storage.ImageTypeThumbnail
-	case "original":
-		imageType = storage.ImageTypeOriginal
-	case "banner", "":
-		imageType = storage.ImageTypeBanner
-	}
+	// 4-5. Get folder and image type for sizing
+	folder, imageType := uploadOptions(c)
 
 	// 6. Upload via storage service
 	result, err := h.storage.UploadImage(c.Request.Context(), file, header.Filename, folder, imageType)
@@ -101,6 +81,29 @@ func (h *UploadHandler) UploadImage(c *gin.Context) {
 	})
 }
 
+// uploadOptions reads the storage folder (default "misc", sanitized) and the
+// image type for sizing (default banner) from an upload form
+func uploadOptions(c *gin.Context) (string, storage.ImageType) {
+	folder := c.PostForm("folder")
+	if folder == "" {
+		folder = "misc"
+	}
+	folder = sanitizeFolderName(folder)
+
+	return folder, parseImageType(c.PostForm("type"))
+}
+
+// parseImageType reads an image type for sizing, defaulting to banner
+func parseImageType(t string) storage.ImageType {
+	switch t {
+	case "thumbnail":
+		return storage.ImageTypeThumbnail
+	case "original":
+		return storage.ImageTypeOriginal
+	}
+	return storage.ImageTypeBanner
+}
+
 // isValidImageType checks if the content type is an allowed image format
 func isValidImageType(contentType string) bool {
 	allowed := []string{
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/audit"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+// userActivitySources select a user's ($1) activity of each type as
+// (type, at, summary, ref_id, details)
+var userActivitySources = map[string]string{
+	models.ActivityRegistration: `
+		SELECT 'registration', r.registered_at::timestamptz, 'Registered for ' || e.title, r.event_id,
+		       json_build_object('checked_in_at', r.checked_in_at)
+		FROM event_registrations r
+		JOIN events e ON e.id = r.event_id
+		WHERE r.user_id = $1`,
+	models.ActivityPayment: `
+		SELECT 'payment', p.created_at, 'Paid ' || p.amount || ' ' || p.currency || ' for ' || e.title, p.id,
+		       json_build_object('event_id', p.event_id, 'amount', p.amount, 'currency', p.currency,
+		                         'status', p.status, 'method', p.method)
+		FROM event_payments p
+		JOIN events e ON e.id = p.event_id
+		WHERE p.user_id = $1`,
+	models.ActivityPost: `
+		SELECT 'post', p.created_at::timestamptz, 'Posted: ' || LEFT(p.description, 100), p.id,
+		       json_build_object('deleted_at', p.deleted_at)
+		FROM posts p
+		WHERE p.created_by = $1`,
+	models.ActivityComment: `
+		SELECT 'comment', pc.created_at::timestamptz, 'Commented: ' || LEFT(pc.content, 100), pc.id,
+		       json_build_object('post_id', pc.post_id, 'deleted_at', pc.deleted_at)
+		FROM post_comments pc
+		WHERE pc.user_id = $1`,
+	models.ActivityLogin: `
+		SELECT 'login', a.created_at, 'Signed in with ' || COALESCE(a.details->>'method', 'password'), NULL::uuid,
+		       json_build_object('ip_address', a.ip_address)
+		FROM audit_log a
+		WHERE a.subject_user_id = $1 AND a.action = '` + audit.ActionUserLogin + `'`,
+	models.ActivityAudit: `
+		SELECT 'audit', a.created_at, a.action, a.id,
+		       json_build_object('actor_id', a.actor_id, 'details', a.details)
+		FROM audit_log a
+		WHERE a.subject_user_id = $1
+		  AND a.action NOT IN ('` + audit.ActionUserLogin + `', '` + audit.ActionImpersonationRequest + `')`,
+}
+
+// userActivityTypes orders the sources, so the query is the same every time
+var userActivityTypes = []string{
+	models.ActivityRegistration,
+	models.ActivityPayment,
+	models.ActivityPost,
+	models.ActivityComment,
+	models.ActivityLogin,
+	models.ActivityAudit,
+}
+
+// GetUserActivity returns a chronological trail of a user's registrations,
+// payments, posts, comments and sign-ins, and of actions taken on them such
+// as mutes, newest first, for support and disciplinary reviews. types
+// limits it to some kinds of activity, and from and to to a period.
+// GET /api/v1/admin/users/:id/activity
+func (h *ImpersonationHandler) GetUserActivity(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid user ID"),
+		})
+		return
+	}
+
+	var query models.ListUserActivityQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid query parameters"),
+		})
+		return
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 || query.PageSize > 100 {
+		query.PageSize = 50
+	}
+
+	wanted := userActivityTypes
+	if query.Types != "" {
+		wanted = nil
+		for _, t := range strings.Split(query.Types, ",") {
+			t = strings.TrimSpace(t)
+			if _, ok := userActivitySources[t]; !ok {
+				c.JSON(http.StatusBadRequest, models.APIResponse{
+					Success: false,
+					Error:   strPtr("Unknown activity type: " + t),
+				})
+				return
+			}
+			wanted = append(wanted, t)
+		}
+	}
+
+	ctx := c.Request.Context()
+	db := h.db.Reader()
+
+	var exists bool
+	err = db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", userID).Scan(&exists)
+	if err != nil {
+		internalError(c, "Failed to fetch activity", err)
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("User not found"),
+		})
+		return
+	}
+
+	sources := make([]string, 0, len(userActivityTypes))
+	for _, t := range userActivityTypes {
+		if containsString(wanted, t) {
+			sources = append(sources, userActivitySources[t])
+		}
+	}
+	timeline := `
+		WITH activity (type, at, summary, ref_id, details) AS (` + strings.Join(sources, "\n\t\tUNION ALL") + `
+		)
+		SELECT type, at, summary, ref_id, details FROM activity
+		WHERE ($2::timestamptz IS NULL OR at >= $2) AND ($3::timestamptz IS NULL OR at < $3)`
+	args := []interface{}{userID, query.From, query.To}
+
+	var total int
+	err = db.QueryRowContext(ctx, "SELECT COUNT(*) FROM ("+timeline+") t", args...).Scan(&total)
+	if err != nil {
+		internalError(c, "Failed to fetch activity", err)
+		return
+	}
+
+	rows, err := db.QueryContext(ctx, timeline+`
+		ORDER BY at DESC
+		LIMIT $4 OFFSET $5
+	`, append(args, query.PageSize, (query.Page-1)*query.PageSize)...)
+	if err != nil {
+		internalError(c, "Failed to fetch activity", err)
+		return
+	}
+	defer rows.Close()
+
+	activity := []models.UserActivity{}
+	for rows.Next() {
+		var a models.UserActivity
+		var details []byte
+		if err := rows.Scan(&a.Type, &a.At, &a.Summary, &a.RefID, &details); err != nil {
+			internalError(c, "Failed to fetch activity", err)
+			return
+		}
+		a.Details = details
+		activity = append(activity, a)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch activity", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: models.PaginatedResponse{
+			Data:       activity,
+			Page:       query.Page,
+			PageSize:   query.PageSize,
+			TotalItems: int64(total),
+			TotalPages: (total + query.PageSize - 1) / query.PageSize,
+		},
+	})
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/audit"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/wallet"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// recentWalletTransactions is how many transactions GetWallet includes
+const recentWalletTransactions = 10
+
+// WalletHandler handles campus credit wallets
+type WalletHandler struct {
+	db *database.DB
+}
+
+// NewWalletHandler creates a new wallet handler
+func NewWalletHandler(db *database.DB) *WalletHandler {
+	return &WalletHandler{db: db}
+}
+
+// listWalletTransactions returns a page of the user's ledger, newest first
+func listWalletTransactions(ctx context.Context, db *sql.DB, userID uuid.UUID, limit, offset int) ([]models.WalletTransaction, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, amount, balance_after, kind, description, payment_id, created_by, created_at
+		FROM wallet_transactions
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transactions := []models.WalletTransaction{}
+	for rows.Next() {
+		var t models.WalletTransaction
+		if err := rows.Scan(&t.ID, &t.Amount, &t.BalanceAfter, &t.Kind, &t.Description,
+			&t.PaymentID, &t.CreatedBy, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, t)
+	}
+	return transactions, rows.Err()
+}
+
+// GetWallet returns the current user's balance and latest transactions
+// GET /api/v1/wallet
+func (h *WalletHandler) GetWallet(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+	ctx := c.Request.Context()
+	db := h.db.Reader()
+
+	w := models.Wallet{UserID: userID, Currency: wallet.Currency}
+	var err error
+	if w.Balance, err = wallet.Balance(ctx, db, userID); err != nil {
+		internalError(c, "failed to fetch wallet", err)
+		return
+	}
+	if w.Transactions, err = listWalletTransactions(ctx, db, userID, recentWalletTransactions, 0); err != nil {
+		internalError(c, "failed to fetch wallet", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    w,
+	})
+}
+
+// ListWalletTransactions returns the current user's wallet ledger, newest
+// first
+// GET /api/v1/wallet/transactions
+func (h *WalletHandler) ListWalletTransactions(c *gin.Context) {
+	var query models.ListWalletTransactionsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("invalid query parameters"),
+		})
+		return
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 || query.PageSize > 100 {
+		query.PageSize = 50
+	}
+
+	userID, _ := middleware.UserID(c)
+	ctx := c.Request.Context()
+	db := h.db.Reader()
+
+	var total int
+	if err := db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM wallet_transactions WHERE user_id = $1", userID,
+	).Scan(&total); err != nil {
+		internalError(c, "failed to fetch wallet transactions", err)
+		return
+	}
+	transactions, err := listWalletTransactions(ctx, db, userID, query.PageSize, (query.Page-1)*query.PageSize)
+	if err != nil {
+		internalError(c, "failed to fetch wallet transactions", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: models.PaginatedResponse{
+			Data:       transactions,
+			Page:       query.Page,
+			PageSize:   query.PageSize,
+			TotalItems: int64(total),
+			TotalPages: (total + query.PageSize - 1) / query.PageSize,
+		},
+	})
+}
+
+// CreditWallet grants a user campus credits, such as a fest coupon or a
+// refund given as credit (admin only)
+// POST /api/v1/admin/wallet/credits
+func (h *WalletHandler) CreditWallet(c *gin.Context) {
+	var req models.CreditWalletRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	req.Description = strings.TrimSpace(req.Description)
+	if req.Description == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("description is required"),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	var userExists bool
+	if err := h.db.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM users WHERE id = $1 AND deleted_at IS NULL)", req.UserID,
+	).Scan(&userExists); err != nil {
+		internalError(c, "failed to fetch user", err)
+		return
+	}
+	if !userExists {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("user not found"),
+		})
+		return
+	}
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		internalError(c, "failed to credit wallet", err)
+		return
+	}
+	defer tx.Rollback()
+
+	adminID, _ := middleware.UserID(c)
+	balance, err := wallet.Credit(ctx, tx, wallet.Entry{
+		UserID:      req.UserID,
+		Amount:      req.Amount,
+		Kind:        wallet.KindCredit,
+		Description: req.Description,
+		CreatedBy:   &adminID,
+	})
+	if err != nil {
+		internalError(c, "failed to credit wallet", err)
+		return
+	}
+
+	if err := audit.Record(ctx, tx, audit.Entry{
+		Action:        audit.ActionWalletCredit,
+		ActorID:       &adminID,
+		SubjectUserID: &req.UserID,
+		Method:        c.Request.Method,
+		Path:          c.Request.URL.RequestURI(),
+		Status:        http.StatusCreated,
+		RequestID:     middleware.GetRequestID(c),
+		IPAddress:     c.ClientIP(),
+		Details: map[string]interface{}{
+			"amount":      req.Amount,
+			"description": req.Description,
+		},
+	}); err != nil {
+		internalError(c, "failed to credit wallet", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		internalError(c, "failed to credit wallet", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "wallet credited",
+		Data: models.Wallet{
+			UserID:       req.UserID,
+			Balance:      balance,
+			Currency:     wallet.Currency,
+			Transactions: []models.WalletTransaction{},
+		},
+	})
+}
@@ -0,0 +1,417 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/webhooks"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// WebhookHandler manages outbound webhook subscriptions and their delivery
+// log. Delivery itself is done by webhooks.Dispatcher.
+type WebhookHandler struct {
+	db *database.DB
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(db *database.DB) *WebhookHandler {
+	return &WebhookHandler{db: db}
+}
+
+const webhookColumns = `id, url, description, event_types, active, created_by, created_at, updated_at`
+
+func scanWebhook(row interface{ Scan(...interface{}) error }) (models.WebhookSubscription, error) {
+	var w models.WebhookSubscription
+	err := row.Scan(&w.ID, &w.URL, &w.Description, pq.Array(&w.EventTypes), &w.Active,
+		&w.CreatedBy, &w.CreatedAt, &w.UpdatedAt)
+	return w, err
+}
+
+const webhookDeliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts,
+	next_attempt_at, last_attempt_at, last_status_code, last_error, last_response, delivered_at, created_at`
+
+func scanWebhookDelivery(row interface{ Scan(...interface{}) error }) (models.WebhookDelivery, error) {
+	var d models.WebhookDelivery
+	var payload []byte
+	err := row.Scan(&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &payload, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &d.LastAttemptAt, &d.LastStatusCode, &d.LastError, &d.LastResponse,
+		&d.DeliveredAt, &d.CreatedAt)
+	d.Payload = payload
+	if d.Status != "pending" {
+		d.NextAttemptAt = nil
+	}
+	return d, err
+}
+
+// newWebhookSecret returns a random 64-character hex signing secret
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// validEventTypes responds with 400 and returns false if any type is unknown
+func validEventTypes(c *gin.Context, types []string) bool {
+	for _, t := range types {
+		if !webhooks.ValidEventType(t) {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   strPtr("Unknown event type: " + t),
+			})
+			return false
+		}
+	}
+	return true
+}
+
+// parseWebhookID reads :id; returns false if the request has been answered
+func parseWebhookID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid webhook ID"),
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func webhookNotFound(c *gin.Context) {
+	c.JSON(http.StatusNotFound, models.APIResponse{
+		Success: false,
+		Error:   strPtr("Webhook not found"),
+	})
+}
+
+// ListWebhooks returns every subscription, without secrets
+// GET /api/v1/admin/webhooks
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	rows, err := h.db.QueryContext(c.Request.Context(),
+		"SELECT "+webhookColumns+" FROM webhook_subscriptions ORDER BY created_at DESC")
+	if err != nil {
+		internalError(c, "Failed to fetch webhooks", err)
+		return
+	}
+	defer rows.Close()
+
+	subscriptions := []models.WebhookSubscription{}
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			internalError(c, "Failed to fetch webhooks", err)
+			return
+		}
+		subscriptions = append(subscriptions, w)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch webhooks", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    subscriptions,
+	})
+}
+
+// CreateWebhook subscribes an endpoint to event types. The signing secret is
+// returned only in this response.
+// POST /api/v1/admin/webhooks
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	userID, _ := middleware.UserID(c)
+
+	var req models.CreateWebhookRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	if !validEventTypes(c, req.EventTypes) {
+		return
+	}
+
+	var secret string
+	if req.Secret != nil {
+		secret = *req.Secret
+	} else {
+		var err error
+		if secret, err = newWebhookSecret(); err != nil {
+			internalError(c, "Failed to create webhook", err)
+			return
+		}
+	}
+
+	w, err := scanWebhook(h.db.QueryRowContext(c.Request.Context(), `
+		INSERT INTO webhook_subscriptions (url, description, secret, event_types, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+webhookColumns,
+		req.URL, req.Description, secret, pq.Array(req.EventTypes), userID))
+	if err != nil {
+		internalError(c, "Failed to create webhook", err)
+		return
+	}
+	w.Secret = secret
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Webhook created. Store the secret now; it will not be shown again.",
+		Data:    w,
+	})
+}
+
+// UpdateWebhook changes a subscription's URL, description, event types or
+// active flag. Deliveries to an inactive subscription wait until it is
+// reactivated.
+// PUT /api/v1/admin/webhooks/:id
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	id, ok := parseWebhookID(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateWebhookRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr(err.Error()),
+		})
+		return
+	}
+	if !validEventTypes(c, req.EventTypes) {
+		return
+	}
+
+	var eventTypes interface{}
+	if req.EventTypes != nil {
+		eventTypes = pq.Array(req.EventTypes)
+	}
+
+	w, err := scanWebhook(h.db.QueryRowContext(c.Request.Context(), `
+		UPDATE webhook_subscriptions SET
+			url = COALESCE($2, url),
+			description = COALESCE($3, description),
+			event_types = COALESCE($4, event_types),
+			active = COALESCE($5, active)
+		WHERE id = $1
+		RETURNING `+webhookColumns,
+		id, req.URL, req.Description, eventTypes, req.Active))
+	if err == sql.ErrNoRows {
+		webhookNotFound(c)
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to update webhook", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Webhook updated",
+		Data:    w,
+	})
+}
+
+// RotateWebhookSecret replaces a subscription's signing secret and returns
+// the new one. Deliveries sent from now on use it.
+// POST /api/v1/admin/webhooks/:id/rotate-secret
+func (h *WebhookHandler) RotateWebhookSecret(c *gin.Context) {
+	id, ok := parseWebhookID(c)
+	if !ok {
+		return
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		internalError(c, "Failed to rotate webhook secret", err)
+		return
+	}
+
+	w, err := scanWebhook(h.db.QueryRowContext(c.Request.Context(), `
+		UPDATE webhook_subscriptions SET secret = $2
+		WHERE id = $1
+		RETURNING `+webhookColumns,
+		id, secret))
+	if err == sql.ErrNoRows {
+		webhookNotFound(c)
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to rotate webhook secret", err)
+		return
+	}
+	w.Secret = secret
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Webhook secret rotated. Store the secret now; it will not be shown again.",
+		Data:    w,
+	})
+}
+
+// DeleteWebhook removes a subscription along with its delivery log
+// DELETE /api/v1/admin/webhooks/:id
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id, ok := parseWebhookID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.db.ExecContext(c.Request.Context(), "DELETE FROM webhook_subscriptions WHERE id = $1", id)
+	if err != nil {
+		internalError(c, "Failed to delete webhook", err)
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		webhookNotFound(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Webhook deleted",
+	})
+}
+
+// ListWebhookDeliveries returns a subscription's delivery log, newest first
+// GET /api/v1/admin/webhooks/:id/deliveries?status=failed
+func (h *WebhookHandler) ListWebhookDeliveries(c *gin.Context) {
+	id, ok := parseWebhookID(c)
+	if !ok {
+		return
+	}
+
+	var query models.ListWebhookDeliveriesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid query parameters"),
+		})
+		return
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 || query.PageSize > 100 {
+		query.PageSize = 50
+	}
+
+	ctx := c.Request.Context()
+	db := h.db.Reader()
+
+	var exists bool
+	if err := db.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM webhook_subscriptions WHERE id = $1)", id,
+	).Scan(&exists); err != nil {
+		internalError(c, "Failed to fetch webhook deliveries", err)
+		return
+	}
+	if !exists {
+		webhookNotFound(c)
+		return
+	}
+
+	const where = `
+		WHERE subscription_id = $1
+		  AND ($2 = '' OR status = $2)
+		  AND ($3 = '' OR event_type = $3)`
+
+	var total int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM webhook_deliveries"+where,
+		id, query.Status, query.EventType,
+	).Scan(&total); err != nil {
+		internalError(c, "Failed to fetch webhook deliveries", err)
+		return
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries`+where+`
+		ORDER BY created_at DESC
+		LIMIT $4 OFFSET $5
+	`, id, query.Status, query.EventType, query.PageSize, (query.Page-1)*query.PageSize)
+	if err != nil {
+		internalError(c, "Failed to fetch webhook deliveries", err)
+		return
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			internalError(c, "Failed to fetch webhook deliveries", err)
+			return
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		internalError(c, "Failed to fetch webhook deliveries", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: models.PaginatedResponse{
+			Data:       deliveries,
+			Page:       query.Page,
+			PageSize:   query.PageSize,
+			TotalItems: int64(total),
+			TotalPages: (total + query.PageSize - 1) / query.PageSize,
+		},
+	})
+}
+
+// RedeliverWebhook queues a delivery to be sent again with a fresh set of
+// attempts, e.g. after the subscriber has fixed an outage
+// POST /api/v1/admin/webhooks/:id/deliveries/:delivery_id/redeliver
+func (h *WebhookHandler) RedeliverWebhook(c *gin.Context) {
+	id, ok := parseWebhookID(c)
+	if !ok {
+		return
+	}
+	deliveryID, err := uuid.Parse(c.Param("delivery_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Invalid delivery ID"),
+		})
+		return
+	}
+
+	d, err := scanWebhookDelivery(h.db.QueryRowContext(c.Request.Context(), `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW()
+		WHERE id = $1 AND subscription_id = $2
+		RETURNING `+webhookDeliveryColumns,
+		deliveryID, id))
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Delivery not found"),
+		})
+		return
+	}
+	if err != nil {
+		internalError(c, "Failed to queue redelivery", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Delivery queued",
+		Data:    d,
+	})
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/audit"
+)
+
+// ImpersonationAuditMiddleware records every request made with an
+// impersonation token, including writes rejected in read-only sessions. It
+// must run before the auth middleware, which identifies the session.
+func ImpersonationAuditMiddleware(db *sql.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		imp, ok := Impersonation(c)
+		if !ok {
+			return
+		}
+		userID, _ := UserID(c)
+
+		// Recorded even if the client has gone away
+		ctx := context.WithoutCancel(c.Request.Context())
+		err := audit.Record(ctx, db, audit.Entry{
+			Action:        audit.ActionImpersonationRequest,
+			ActorID:       &imp.AdminID,
+			SubjectUserID: &userID,
+			SessionID:     &imp.SessionID,
+			Method:        c.Request.Method,
+			Path:          c.Request.URL.RequestURI(),
+			Status:        c.Writer.Status(),
+			RequestID:     GetRequestID(c),
+			IPAddress:     c.ClientIP(),
+		})
+		if err != nil {
+			log.Printf("[AUDIT] request_id=%s: %v", GetRequestID(c), err)
+		}
+	}
+}
@@ -13,6 +13,14 @@ import (
 func AuthMiddleware(authService *auth.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" && csrfRejected(c) {
+			c.JSON(http.StatusForbidden, models.APIResponse{
+				Success: false,
+				Error:   strPtr("missing or invalid CSRF token"),
+			})
+			c.Abort()
+			return
+		}
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, models.APIResponse{
 				Success: false,
@@ -45,9 +53,10 @@ func AuthMiddleware(authService *auth.Service) gin.HandlerFunc {
 		}
 
 		// Set user info in context
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
-		c.Set("user_role", claims.Role)
+		setUser(c, claims)
+		if !allowImpersonated(c, claims) || !allowScoped(c, claims) {
+			return
+		}
 
 		c.Next()
 	}
@@ -81,9 +90,10 @@ func OptionalAuthMiddleware(authService *auth.Service) gin.HandlerFunc {
 		}
 
 		// Set user info in context
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
-		c.Set("user_role", claims.Role)
+		setUser(c, claims)
+		if !allowImpersonated(c, claims) || !allowScoped(c, claims) {
+			return
+		}
 
 		c.Next()
 	}
@@ -92,7 +102,7 @@ func OptionalAuthMiddleware(authService *auth.Service) gin.HandlerFunc {
 // AdminMiddleware checks if user has admin role
 func AdminMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role, exists := c.Get("user_role")
+		userRole, exists := Role(c)
 		if !exists {
 			c.JSON(http.StatusUnauthorized, models.APIResponse{
 				Success: false,
@@ -102,8 +112,7 @@ func AdminMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		userRole, ok := role.(models.UserRole)
-		if !ok || !auth.IsAdmin(userRole) {
+		if !auth.IsAdmin(userRole) {
 			c.JSON(http.StatusForbidden, models.APIResponse{
 				Success: false,
 				Error:   strPtr("admin access required"),
@@ -119,7 +128,7 @@ func AdminMiddleware() gin.HandlerFunc {
 // AdminOrFacultyMiddleware checks if user has admin or faculty role
 func AdminOrFacultyMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role, exists := c.Get("user_role")
+		userRole, exists := Role(c)
 		if !exists {
 			c.JSON(http.StatusUnauthorized, models.APIResponse{
 				Success: false,
@@ -129,8 +138,7 @@ func AdminOrFacultyMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		userRole, ok := role.(models.UserRole)
-		if !ok || !auth.IsAdminOrFaculty(userRole) {
+		if !auth.IsAdminOrFaculty(userRole) {
 			c.JSON(http.StatusForbidden, models.APIResponse{
 				Success: false,
 				Error:   strPtr("admin or faculty access required"),
@@ -143,6 +151,30 @@ func AdminOrFacultyMiddleware() gin.HandlerFunc {
 	}
 }
 
+// allowImpersonated flags responses to impersonation tokens and rejects
+// writes in read-only sessions. Returns false if the request was aborted.
+func allowImpersonated(c *gin.Context, claims *auth.Claims) bool {
+	imp := claims.Impersonation
+	if imp == nil {
+		return true
+	}
+	c.Header("X-Impersonated-By", imp.AdminID.String())
+
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	if imp.ReadOnly {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("impersonation session is read-only"),
+		})
+		c.Abort()
+		return false
+	}
+	return true
+}
+
 func strPtr(s string) *string {
 	return &s
 }
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+// BodyLimits are the largest request bodies accepted, in bytes, by class of
+// route. Zero or less means no limit.
+type BodyLimits struct {
+	JSON       int64 // Every route not in a larger class
+	Upload     int64 // Single file uploads
+	BulkUpload int64 // Uploads of many files at once
+	Video      int64 // Chunks of resumable uploads, which may be videos
+}
+
+// DefaultBodyLimits keeps JSON small to blunt abuse, with room for uploads
+var DefaultBodyLimits = BodyLimits{
+	JSON:       1 << 20,
+	Upload:     10 << 20,
+	BulkUpload: 250 << 20,
+	Video:      2 << 30,
+}
+
+// BodyLimit caps request bodies. Routes, keyed by their full path pattern
+// (e.g. "/api/v1/admin/upload"), get their own limit; every other route
+// gets defaultLimit. A declared Content-Length over the limit is refused
+// with 413 up front; a body that turns out longer fails when read, with
+// "http: request body too large".
+func BodyLimit(defaultLimit int64, routes map[string]int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, ok := routes[c.FullPath()]
+		if !ok {
+			limit = defaultLimit
+		}
+		if limit <= 0 || c.Request.Body == nil {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > limit {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, models.APIResponse{
+				Success: false,
+				Error:   strPtr(fmt.Sprintf("request body too large: the limit is %s", formatBytes(limit))),
+			})
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
+
+// formatBytes renders a limit in the largest whole unit
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30 && n%(1<<30) == 0:
+		return fmt.Sprintf("%dGB", n>>30)
+	case n >= 1<<20 && n%(1<<20) == 0:
+		return fmt.Sprintf("%dMB", n>>20)
+	case n >= 1<<10 && n%(1<<10) == 0:
+		return fmt.Sprintf("%dKB", n>>10)
+	}
+	return fmt.Sprintf("%d bytes", n)
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/auth"
+)
+
+// Context keys for the authenticated user. Handlers should read them through
+// UserID, Email and Role rather than c.Get so a mistyped key can't compile.
+const (
+	userIDKey    = "user_id"
+	userEmailKey = "user_email"
+	userRoleKey  = "user_role"
+	// Set only when an admin is impersonating the user
+	impersonationKey = "impersonation"
+)
+
+// setUser stores the token's claims on the request context
+func setUser(c *gin.Context, claims *auth.Claims) {
+	c.Set(userIDKey, claims.UserID)
+	c.Set(userEmailKey, claims.Email)
+	c.Set(userRoleKey, claims.Role)
+	if claims.Impersonation != nil {
+		c.Set(impersonationKey, *claims.Impersonation)
+	}
+}
+
+// UserID returns the authenticated user's ID; ok is false for anonymous requests
+func UserID(c *gin.Context) (id uuid.UUID, ok bool) {
+	v, exists := c.Get(userIDKey)
+	if !exists {
+		return uuid.Nil, false
+	}
+	id, ok = v.(uuid.UUID)
+	return id, ok
+}
+
+// Email returns the authenticated user's email; ok is false for anonymous requests
+func Email(c *gin.Context) (email string, ok bool) {
+	v, exists := c.Get(userEmailKey)
+	if !exists {
+		return "", false
+	}
+	email, ok = v.(string)
+	return email, ok
+}
+
+// Role returns the authenticated user's role; ok is false for anonymous requests
+func Role(c *gin.Context) (role models.UserRole, ok bool) {
+	v, exists := c.Get(userRoleKey)
+	if !exists {
+		return "", false
+	}
+	role, ok = v.(models.UserRole)
+	return role, ok
+}
+
+// Impersonation returns the support session the request is made under; ok is
+// false unless an admin is impersonating the user
+func Impersonation(c *gin.Context) (imp auth.Impersonation, ok bool) {
+	v, exists := c.Get(impersonationKey)
+	if !exists {
+		return auth.Impersonation{}, false
+	}
+	imp, ok = v.(auth.Impersonation)
+	return imp, ok
+}
@@ -19,8 +19,11 @@ func CORSMiddleware(allowedOrigins string) gin.HandlerFunc {
 		config.AllowOrigins = origins
 	}
 	
-	config.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Type", "Authorization", "Accept"}
+	config.AllowMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	config.AllowHeaders = []string{"Origin", "Content-Type", "Authorization", "Accept", RequestIDHeader, CSRFHeader,
+		"Tus-Resumable", "Upload-Length", "Upload-Metadata", "Upload-Offset"}
+	config.ExposeHeaders = []string{RequestIDHeader,
+		"Location", "Tus-Resumable", "Tus-Version", "Tus-Max-Size", "Upload-Length", "Upload-Offset"}
 	config.AllowCredentials = true
 	
 	return cors.New(config)
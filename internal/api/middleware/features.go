@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/settings"
+)
+
+// featureRoutes maps each feature that can be switched off to the route
+// prefixes it serves. Admin routes stay up so admins can still moderate a
+// feature that is off.
+var featureRoutes = map[string][]string{
+	settings.FeatureChat:        {"/api/v1/chat"},
+	settings.FeatureGraphQL:     {"/api/v1/graphql"},
+	settings.FeatureLostFound:   {"/api/v1/lost-found"},
+	settings.FeatureStories:     {"/api/v1/stories"},
+	settings.FeatureSuggestions: {"/api/v1/suggestions", "/api/v1/me/suggestions", "/api/v1/clubs/:id/suggestions"},
+}
+
+// FeatureFlags answers requests to features switched off in the runtime
+// settings with 404, as if the routes weren't there
+func FeatureFlags(store *settings.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		feature := routeFeature(c.FullPath())
+		if feature == "" || store.Current(c.Request.Context()).Enabled(feature) {
+			c.Next()
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   strPtr("This feature is turned off"),
+		})
+	}
+}
+
+// routeFeature returns the feature a route belongs to, if it can be
+// switched off
+func routeFeature(route string) string {
+	for feature, prefixes := range featureRoutes {
+		for _, prefix := range prefixes {
+			if route == prefix || strings.HasPrefix(route, prefix+"/") {
+				return feature
+			}
+		}
+	}
+	return ""
+}
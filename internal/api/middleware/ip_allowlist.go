@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+// adminPathPrefixes are where AdminAllowlist applies: the admin API and the
+// profiler
+var adminPathPrefixes = []string{"/api/v1/admin", "/debug"}
+
+// ParseCIDRs parses a comma-separated list of networks in CIDR notation. A
+// bare IP address stands for just that address.
+func ParseCIDRs(list string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, s := range strings.Split(list, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if !strings.Contains(s, "/") {
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address %q", s)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", s, err)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+// AdminAllowlist rejects requests to the admin API (/api/v1/admin/...) and
+// the profiler (/debug/...) from outside the allowed networks, e.g. to keep
+// them to the campus network and VPN in production. With no networks, all
+// are allowed. The client's address is gin's ClientIP, which believes
+// X-Forwarded-For only from the router's trusted proxies.
+func AdminAllowlist(allowed []*net.IPNet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(allowed) == 0 || !isAdminPath(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
+		ip := net.ParseIP(c.ClientIP())
+		for _, n := range allowed {
+			if ip != nil && n.Contains(ip) {
+				c.Next()
+				return
+			}
+		}
+
+		log.Printf("[ADMIN ALLOWLIST] request_id=%s: rejected %s %s from %s",
+			GetRequestID(c), c.Request.Method, c.Request.URL.Path, c.ClientIP())
+		c.AbortWithStatusJSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   strPtr("Admin access is not allowed from this network"),
+		})
+	}
+}
+
+func isAdminPath(path string) bool {
+	for _, prefix := range adminPathPrefixes {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
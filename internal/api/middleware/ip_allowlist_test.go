@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseCIDRs(t *testing.T) {
+	nets, err := ParseCIDRs(" 10.0.0.0/8, 192.168.1.7 ,2001:db8::/32,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(nets) != 3 {
+		t.Fatalf("got %d networks, want 3", len(nets))
+	}
+	if got := nets[1].String(); got != "192.168.1.7/32" {
+		t.Errorf("bare address parsed as %s, want 192.168.1.7/32", got)
+	}
+
+	for _, list := range []string{"10.0.0.0/33", "campus", "10.0.0"} {
+		if _, err := ParseCIDRs(list); err == nil {
+			t.Errorf("ParseCIDRs(%q) succeeded, want an error", list)
+		}
+	}
+}
+
+func TestAdminAllowlist(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	allowed, _ := ParseCIDRs("10.0.0.0/8")
+
+	tests := []struct {
+		name       string
+		path       string
+		remoteAddr string
+		want       int
+	}{
+		{"admin from allowed network", "/api/v1/admin/users", "10.1.2.3:5000", http.StatusOK},
+		{"admin from outside", "/api/v1/admin/users", "203.0.113.9:5000", http.StatusForbidden},
+		{"admin root from outside", "/api/v1/admin", "203.0.113.9:5000", http.StatusForbidden},
+		{"profiler from outside", "/debug/pprof/heap", "203.0.113.9:5000", http.StatusForbidden},
+		{"profiler symbol lookup from outside", "/debug/pprof/symbol", "203.0.113.9:5000", http.StatusForbidden},
+		{"profiler from allowed network", "/debug/pprof/heap", "10.1.2.3:5000", http.StatusOK},
+		{"lookalike profiler path from outside", "/debugger", "203.0.113.9:5000", http.StatusOK},
+		{"non-admin from outside", "/api/v1/events", "203.0.113.9:5000", http.StatusOK},
+		{"lookalike path from outside", "/api/v1/administrators", "203.0.113.9:5000", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.Use(AdminAllowlist(allowed))
+			engine.NoRoute(func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.RemoteAddr = tt.remoteAddr
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/auth"
+)
+
+// maintenanceCacheTTL is how long an instance trusts its copy of the
+// maintenance flag, and so how long a toggle takes to reach every instance
+const maintenanceCacheTTL = 5 * time.Second
+
+// Maintenance tracks maintenance mode, stored in the database so that every
+// instance follows the same admin toggle
+type Maintenance struct {
+	db     *sql.DB
+	forced bool
+
+	mu        sync.Mutex
+	status    models.MaintenanceStatus
+	fetchedAt time.Time
+}
+
+// NewMaintenance creates the maintenance mode tracker. forced holds the API in
+// maintenance regardless of the admin toggle.
+func NewMaintenance(db *sql.DB, forced bool) *Maintenance {
+	return &Maintenance{db: db, forced: forced}
+}
+
+// Status returns the current maintenance status. If the database cannot be
+// read, the last known status is used.
+func (m *Maintenance) Status(ctx context.Context) models.MaintenanceStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if time.Since(m.fetchedAt) >= maintenanceCacheTTL {
+		status, err := m.load(ctx)
+		if err != nil {
+			log.Printf("[MAINTENANCE] Failed to read maintenance mode: %v", err)
+		} else {
+			m.status = status
+		}
+		// Failures are also cached so an unreachable database isn't hit on every request
+		m.fetchedAt = time.Now()
+	}
+
+	status := m.status
+	if m.forced {
+		status.Enabled = true
+		status.Forced = true
+	}
+	if status.Enabled && status.Message == "" {
+		status.Message = models.DefaultMaintenanceMessage
+	}
+	return status
+}
+
+// Refresh drops the cached status so the next request rereads it
+func (m *Maintenance) Refresh() {
+	m.mu.Lock()
+	m.fetchedAt = time.Time{}
+	m.mu.Unlock()
+}
+
+func (m *Maintenance) load(ctx context.Context) (models.MaintenanceStatus, error) {
+	var status models.MaintenanceStatus
+	var message *string
+	err := m.db.QueryRowContext(ctx,
+		"SELECT enabled, message, eta, updated_at FROM maintenance_mode WHERE id",
+	).Scan(&status.Enabled, &message, &status.ETA, &status.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return models.MaintenanceStatus{}, nil
+	}
+	if message != nil {
+		status.Message = *message
+	}
+	return status, err
+}
+
+// maintenanceExempt lists paths served during maintenance so admins can sign
+// in and turn it off, and load balancers keep seeing the instance
+func maintenanceExempt(path string) bool {
+	switch path {
+	case "/health", "/metrics", "/api/v1/auth/login", "/api/v1/auth/ldap", "/api/v1/auth/oidc":
+		return true
+	}
+	return path == "/api/v1/admin" || strings.HasPrefix(path, "/api/v1/admin/")
+}
+
+// MaintenanceMiddleware answers non-admin requests with 503 while maintenance
+// mode is on. Requests bearing an admin token are let through anywhere.
+func MaintenanceMiddleware(m *Maintenance, authService *auth.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodOptions || maintenanceExempt(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
+		status := m.Status(c.Request.Context())
+		if !status.Enabled {
+			c.Next()
+			return
+		}
+
+		if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+			if claims, err := authService.ValidateToken(token); err == nil && auth.IsAdmin(claims.Role) {
+				c.Next()
+				return
+			}
+		}
+
+		if status.ETA != nil {
+			if wait := time.Until(*status.ETA); wait > 0 {
+				c.Header("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			}
+		}
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, models.APIResponse{
+			Success:   false,
+			Message:   status.Message,
+			Error:     strPtr("service under maintenance"),
+			Data:      status,
+			RequestID: GetRequestID(c),
+		})
+	}
+}
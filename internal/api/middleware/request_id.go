@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the request ID in both directions
+const RequestIDHeader = "X-Request-ID"
+
+const requestIDKey = "request_id"
+
+// RequestIDMiddleware tags each request with an ID, reusing the caller's
+// X-Request-ID when present so logs can be correlated across services
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > 128 {
+			id = uuid.New().String()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// GetRequestID returns the current request's ID, or "" outside RequestIDMiddleware
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
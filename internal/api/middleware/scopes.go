@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/auth"
+)
+
+// scopeResources maps the first path segment under /api/v1 to the scope
+// resource guarding it. Routes missing here are closed to scoped tokens.
+var scopeResources = map[string]string{
+	"events":        "events",
+	"fests":         "events",
+	"calendar":      "events",
+	"categories":    "events",
+	"posts":         "posts",
+	"stories":       "posts",
+	"clubs":         "clubs",
+	"departments":   "clubs",
+	"resources":     "clubs",
+	"houses":        "houses",
+	"announcements": "houses",
+	"house-events":  "houses",
+	"leaderboard":   "houses",
+	"terms":         "houses",
+	"profile":       "profile",
+	"me":            "profile",
+	"users":         "profile",
+	"notifications": "profile",
+	"schedules":     "profile",
+	"payments":      "payments",
+	"suggestions":   "community",
+	"lost-found":    "community",
+	"admin":         "admin",
+}
+
+// requiredScope returns the scope a request needs: read for safe methods,
+// write otherwise, on the resource its route belongs to. ok is false for
+// routes no scope grants.
+func requiredScope(c *gin.Context) (scope string, ok bool) {
+	path := strings.TrimPrefix(c.FullPath(), "/api/v1/")
+	segment, _, _ := strings.Cut(path, "/")
+	resource, ok := scopeResources[segment]
+	if !ok {
+		return "", false
+	}
+
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return "read:" + resource, true
+	}
+	return "write:" + resource, true
+}
+
+// allowScoped rejects requests outside a scoped token's grants. Returns
+// false if the request was aborted.
+func allowScoped(c *gin.Context, claims *auth.Claims) bool {
+	if !claims.Scoped() {
+		return true
+	}
+	scope, ok := requiredScope(c)
+	if ok && claims.HasScope(scope) {
+		return true
+	}
+
+	msg := "token scope does not allow this request"
+	if ok {
+		msg = "token is missing scope " + scope
+	}
+	c.JSON(http.StatusForbidden, models.APIResponse{
+		Success: false,
+		Error:   strPtr(msg),
+	})
+	c.Abort()
+	return false
+}
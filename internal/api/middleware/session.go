@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/services/auth"
+)
+
+// Cookie sessions, for the web admin panel, carry the access token in an
+// httpOnly cookie rather than the Authorization header. Requests that change
+// anything must echo the session's CSRF token in CSRFHeader.
+const (
+	SessionCookie = "session"
+	// CSRFCookie holds the CSRF token where the panel's scripts can read it
+	CSRFCookie = "csrf_token"
+	CSRFHeader = "X-CSRF-Token"
+)
+
+// csrfRejectedKey marks requests whose session cookie was ignored for lack
+// of a valid CSRF token
+const csrfRejectedKey = "csrf_rejected"
+
+// SessionCookies configures the cookies of cookie sessions
+type SessionCookies struct {
+	Domain   string // Empty for the API's own host
+	Secure   bool   // Sent over HTTPS only
+	SameSite http.SameSite
+}
+
+// ParseSameSite parses a SameSite setting: lax, strict or none
+func ParseSameSite(s string) (http.SameSite, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "lax", "":
+		return http.SameSiteLaxMode, nil
+	case "strict":
+		return http.SameSiteStrictMode, nil
+	case "none":
+		return http.SameSiteNoneMode, nil
+	}
+	return 0, fmt.Errorf("unknown SameSite mode %q: use lax, strict or none", s)
+}
+
+// Set starts a cookie session with an access token, returning its CSRF
+// token
+func (sc SessionCookies) Set(c *gin.Context, authService *auth.Service, accessToken string) string {
+	csrfToken := authService.CSRFToken(accessToken)
+	maxAge := int(authService.AccessTokenTTL() / time.Second)
+	sc.set(c, SessionCookie, accessToken, maxAge, true)
+	sc.set(c, CSRFCookie, csrfToken, maxAge, false)
+	return csrfToken
+}
+
+// Clear ends a cookie session
+func (sc SessionCookies) Clear(c *gin.Context) {
+	sc.set(c, SessionCookie, "", -1, true)
+	sc.set(c, CSRFCookie, "", -1, false)
+}
+
+func (sc SessionCookies) set(c *gin.Context, name, value string, maxAge int, httpOnly bool) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Domain:   sc.Domain,
+		MaxAge:   maxAge,
+		Secure:   sc.Secure,
+		HttpOnly: httpOnly,
+		SameSite: sc.SameSite,
+	})
+}
+
+// CookieSessionMiddleware authenticates requests without an Authorization
+// header by their session cookie, handing its token on as a bearer token so
+// the auth middlewares treat both alike. Unsafe methods need the session's
+// CSRF token; without it the cookie is ignored and the request goes on
+// unauthenticated, so public endpoints such as login still work.
+func CookieSessionMiddleware(authService *auth.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "" {
+			c.Next()
+			return
+		}
+		token, err := c.Cookie(SessionCookie)
+		if err != nil || token == "" {
+			c.Next()
+			return
+		}
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			if !authService.VerifyCSRFToken(token, c.GetHeader(CSRFHeader)) {
+				c.Set(csrfRejectedKey, true)
+				c.Next()
+				return
+			}
+		}
+
+		c.Request.Header.Set("Authorization", "Bearer "+token)
+		c.Next()
+	}
+}
+
+// csrfRejected reports whether the request's session cookie was ignored for
+// lack of a valid CSRF token
+func csrfRejected(c *gin.Context) bool {
+	return c.GetBool(csrfRejectedKey)
+}
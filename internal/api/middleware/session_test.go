@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/college-event-backend/internal/services/auth"
+)
+
+func TestCookieSessionMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authService := auth.NewService("test-secret", 1, 1)
+	csrfToken := authService.CSRFToken("token-1")
+
+	tests := []struct {
+		name       string
+		method     string
+		cookie     string
+		csrf       string
+		bearer     string
+		wantAuth   string
+		wantReject bool
+	}{
+		{"read with cookie", http.MethodGet, "token-1", "", "", "Bearer token-1", false},
+		{"write with CSRF token", http.MethodPost, "token-1", csrfToken, "", "Bearer token-1", false},
+		{"write without CSRF token", http.MethodPost, "token-1", "", "", "", true},
+		{"write with another session's CSRF token", http.MethodDelete, "token-1", authService.CSRFToken("token-2"), "", "", true},
+		{"bearer token wins", http.MethodPost, "token-1", "", "Bearer mobile", "Bearer mobile", false},
+		{"no session", http.MethodPost, "", "", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotAuth string
+			var gotReject bool
+			engine := gin.New()
+			engine.Use(CookieSessionMiddleware(authService))
+			engine.NoRoute(func(c *gin.Context) {
+				gotAuth = c.GetHeader("Authorization")
+				gotReject = csrfRejected(c)
+			})
+
+			req := httptest.NewRequest(tt.method, "/api/v1/profile", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: SessionCookie, Value: tt.cookie})
+			}
+			if tt.csrf != "" {
+				req.Header.Set(CSRFHeader, tt.csrf)
+			}
+			if tt.bearer != "" {
+				req.Header.Set("Authorization", tt.bearer)
+			}
+			engine.ServeHTTP(httptest.NewRecorder(), req)
+
+			if gotAuth != tt.wantAuth || gotReject != tt.wantReject {
+				t.Errorf("Authorization = %q, CSRF rejected = %v; want %q, %v", gotAuth, gotReject, tt.wantAuth, tt.wantReject)
+			}
+		})
+	}
+}
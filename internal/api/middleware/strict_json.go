@@ -0,0 +1,29 @@
+package middleware
+
+import "github.com/gin-gonic/gin"
+
+// strictJSONKey marks requests whose JSON bodies may not have unknown fields
+const strictJSONKey = "strict_json"
+
+// Strict JSON modes, for where StrictJSON is applied
+const (
+	StrictJSONAdmin = "admin" // Admin endpoints only
+	StrictJSONAll   = "all"
+	StrictJSONOff   = "off"
+)
+
+// StrictJSON makes JSON bodies with fields the endpoint doesn't know
+// rejected, rather than the fields silently dropped, so that client typos
+// surface. Handlers honour it through their JSON binding.
+func StrictJSON() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(strictJSONKey, true)
+		c.Next()
+	}
+}
+
+// IsStrictJSON reports whether the request's JSON body may not have unknown
+// fields
+func IsStrictJSON(c *gin.Context) bool {
+	return c.GetBool(strictJSONKey)
+}
@@ -1,12 +1,26 @@
 package api
 
 import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/yourusername/college-event-backend/internal/api/handlers"
 	"github.com/yourusername/college-event-backend/internal/api/middleware"
+	"github.com/yourusername/college-event-backend/internal/jobs"
+	"github.com/yourusername/college-event-backend/internal/realtime"
 	"github.com/yourusername/college-event-backend/internal/services/auth"
+	"github.com/yourusername/college-event-backend/internal/services/moderation"
+	"github.com/yourusername/college-event-backend/internal/services/payments"
+	"github.com/yourusername/college-event-backend/internal/services/security"
+	"github.com/yourusername/college-event-backend/internal/settings"
 	"github.com/yourusername/college-event-backend/internal/storage"
+	"github.com/yourusername/college-event-backend/internal/uploads"
 	"github.com/yourusername/college-event-backend/pkg/database"
+	"github.com/yourusername/college-event-backend/pkg/metrics"
+	"github.com/yourusername/college-event-backend/pkg/version"
 )
 
 type Router struct {
@@ -14,45 +28,295 @@ type Router struct {
 	db          *database.DB
 	authService *auth.Service
 	storage     storage.StorageService
+	jobQueue    *jobs.Queue
 	corsOrigins string
+	settings    *settings.Store
+	// maintenanceForced holds the API in maintenance mode from config
+	maintenanceForced bool
+	authProviders     auth.Providers
+	graphQLEnabled    bool
+	contentFilter     *moderation.Filter
+	imageChecker      moderation.ImageChecker
+	bodyLimits        middleware.BodyLimits
+	strictJSON        string
+	publicBaseURL     string
+	appLinkScheme     string
+	realtimeHub       *realtime.Hub
+	securityRules     security.Rules
+	adminAllowlist    []*net.IPNet
+	sessionCookies    *middleware.SessionCookies
+	uploadsHandler    http.Handler
+	debugEndpoints    bool
 }
 
-func NewRouter(db *database.DB, authService *auth.Service, storageService storage.StorageService, corsOrigins string) *Router {
+func NewRouter(db *database.DB, authService *auth.Service, storageService storage.StorageService, jobQueue *jobs.Queue, corsOrigins string) *Router {
+	engine := gin.Default()
+	// Forwarded client addresses are believed only from configured proxies
+	_ = engine.SetTrustedProxies(nil)
 	return &Router{
-		engine:      gin.Default(),
-		db:          db,
-		authService: authService,
-		storage:     storageService,
-		corsOrigins: corsOrigins,
+		engine:        engine,
+		db:            db,
+		authService:   authService,
+		storage:       storageService,
+		jobQueue:      jobQueue,
+		corsOrigins:   corsOrigins,
+		authProviders: auth.Providers{Enabled: []string{"password", "google"}},
+		bodyLimits:    middleware.DefaultBodyLimits,
+		strictJSON:    middleware.StrictJSONAdmin,
+		securityRules: security.DefaultRules(),
+	}
+}
+
+// SetSettings sets the runtime settings admins can change without a
+// restart: campus quiet hours, trash retention, suspicious activity limits
+// and feature flags
+func (r *Router) SetSettings(store *settings.Store) {
+	r.settings = store
+}
+
+// SetMaintenanceForced holds the API in maintenance mode regardless of the
+// admin toggle
+func (r *Router) SetMaintenanceForced(forced bool) {
+	r.maintenanceForced = forced
+}
+
+// SetAuthProviders sets the enabled login methods
+func (r *Router) SetAuthProviders(providers auth.Providers) {
+	r.authProviders = providers
+}
+
+// SetGraphQLEnabled serves the GraphQL API
+func (r *Router) SetGraphQLEnabled(enabled bool) {
+	r.graphQLEnabled = enabled
+}
+
+// SetContentFilter screens comments and announcements
+func (r *Router) SetContentFilter(f *moderation.Filter) {
+	r.contentFilter = f
+}
+
+// SetBodyLimits sets the largest request bodies accepted by each class of
+// route
+func (r *Router) SetBodyLimits(limits middleware.BodyLimits) {
+	r.bodyLimits = limits
+}
+
+// SetStrictJSON sets where JSON bodies with unknown fields are rejected:
+// admin endpoints, all endpoints, or nowhere (see the middleware.StrictJSON
+// modes)
+func (r *Router) SetStrictJSON(mode string) {
+	r.strictJSON = mode
+}
+
+// SetImageChecker screens post and story images
+func (r *Router) SetImageChecker(c moderation.ImageChecker) {
+	r.imageChecker = c
+}
+
+// SetShareLinks sets the public URL of the API and the app's URL scheme,
+// used by the pages behind shared links
+func (r *Router) SetShareLinks(publicBaseURL, appLinkScheme string) {
+	r.publicBaseURL = publicBaseURL
+	r.appLinkScheme = appLinkScheme
+}
+
+// SetRealtimeHub sets the hub that carries chat events to connected
+// clients. Without one, events reach this instance's clients only.
+func (r *Router) SetRealtimeHub(h *realtime.Hub) {
+	r.realtimeHub = h
+}
+
+// SetSecurityRules sets when suspicious activity raises alerts, and whether
+// lockouts and mutes are applied automatically
+func (r *Router) SetSecurityRules(rules security.Rules) {
+	r.securityRules = rules
+}
+
+// SetDebugEndpoints serves Go's profiler at /debug/pprof to admins
+func (r *Router) SetDebugEndpoints(enabled bool) {
+	r.debugEndpoints = enabled
+}
+
+// SetAdminAllowlist keeps the admin API and the profiler to the given
+// networks. With none, they're reachable from anywhere.
+func (r *Router) SetAdminAllowlist(nets []*net.IPNet) {
+	r.adminAllowlist = nets
+}
+
+// SetSessionCookies offers cookie sessions, with CSRF tokens, to web clients
+// alongside bearer tokens
+func (r *Router) SetSessionCookies(cookies middleware.SessionCookies) {
+	r.sessionCookies = &cookies
+}
+
+// SetUploadsHandler serves locally stored files under /uploads
+func (r *Router) SetUploadsHandler(h http.Handler) {
+	r.uploadsHandler = h
+}
+
+// SetTrustedProxies sets the proxies (IPs or CIDRs) whose X-Forwarded-For
+// is believed for the client's address, as used by audit logs, lockouts and
+// the admin allowlist. Unless set, no proxy is, and the client is the peer.
+func (r *Router) SetTrustedProxies(proxies []string) error {
+	return r.engine.SetTrustedProxies(proxies)
+}
+
+// trustedPlatforms maps platforms to the header carrying the client's
+// address they set
+var trustedPlatforms = map[string]string{
+	"appengine":  gin.PlatformGoogleAppEngine,
+	"cloudflare": gin.PlatformCloudflare,
+	"flyio":      gin.PlatformFlyIO,
+}
+
+// SetTrustedPlatform takes the client's address from the header set by the
+// platform in front of the API (appengine, cloudflare or flyio) rather than
+// from X-Forwarded-For
+func (r *Router) SetTrustedPlatform(platform string) error {
+	header, ok := trustedPlatforms[platform]
+	if !ok {
+		return fmt.Errorf("unknown platform %q: use appengine, cloudflare or flyio", platform)
 	}
+	r.engine.TrustedPlatform = header
+	return nil
 }
 
 func (r *Router) Setup() *gin.Engine {
 	// Apply CORS middleware
 	r.engine.Use(middleware.CORSMiddleware(r.corsOrigins))
+	r.engine.Use(middleware.RequestIDMiddleware())
+	r.engine.Use(middleware.AdminAllowlist(r.adminAllowlist))
+	if r.sessionCookies != nil {
+		r.engine.Use(middleware.CookieSessionMiddleware(r.authService))
+	}
+	r.engine.Use(middleware.BodyLimit(r.bodyLimits.JSON, map[string]int64{
+		// Uploads get more room than the JSON APIs
+		"/api/v1/admin/upload":                         r.bodyLimits.Upload,
+		"/api/v1/lost-found":                           r.bodyLimits.Upload,
+		"/api/v1/events/:id/sponsors":                  r.bodyLimits.Upload,
+		"/api/v1/events/:id/sponsors/:sponsor_id":      r.bodyLimits.Upload,
+		"/api/v1/admin/fests/:id/sponsors":             r.bodyLimits.Upload,
+		"/api/v1/admin/fests/:id/sponsors/:sponsor_id": r.bodyLimits.Upload,
+		"/api/v1/admin/upload/bulk":                    r.bodyLimits.BulkUpload,
+		"/api/v1/events/:id/photos":                    r.bodyLimits.BulkUpload,
+		"/api/v1/chat/channels/:id/messages":           r.bodyLimits.BulkUpload,
+		"/api/v1/admin/uploads/resumable/:id":          r.bodyLimits.Video,
+	}))
+	if r.strictJSON == middleware.StrictJSONAll {
+		r.engine.Use(middleware.StrictJSON())
+	}
+	r.engine.Use(middleware.ImpersonationAuditMiddleware(r.db.DB))
+	maintenance := middleware.NewMaintenance(r.db.DB, r.maintenanceForced)
+	r.engine.Use(middleware.MaintenanceMiddleware(maintenance, r.authService))
+	r.engine.Use(middleware.FeatureFlags(r.settings))
 
 	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(r.db, r.authService)
+	securityDetector := security.NewDetector(r.db.DB, r.securityRules)
+	securityDetector.SetSettings(r.settings)
+	authHandler := handlers.NewAuthHandler(r.db, r.authService, r.authProviders, securityDetector, r.sessionCookies)
 	eventHandler := handlers.NewEventHandler(r.db)
 	deptHandler := &handlers.DepartmentHandler{DB: r.db.DB}
 	clubHandler := &handlers.ClubHandler{DB: r.db.DB}
 	scheduleHandler := handlers.NewScheduleHandler(r.db)
 	uploadHandler := handlers.NewUploadHandler(r.storage)
-	houseHandler := handlers.NewHouseHandler(r.db.DB)
-	postsHandler := handlers.NewPostsHandler(r.db.DB)
-	storiesHandler := handlers.NewStoriesHandler(r.db.DB)
+	houseHandler := handlers.NewHouseHandler(r.db.DB, r.contentFilter)
+	competitionHandler := handlers.NewCompetitionHandler(r.db.DB)
+	if r.realtimeHub == nil {
+		r.realtimeHub = realtime.NewHub(nil)
+	}
+	chatHandler := handlers.NewChatHandler(r.db.DB, r.contentFilter, r.realtimeHub, r.storage)
+	broadcastHandler := handlers.NewBroadcastHandler(r.db.DB, chatHandler)
+	postsHandler := handlers.NewPostsHandler(r.db, r.contentFilter, r.imageChecker, securityDetector)
+	storiesHandler := handlers.NewStoriesHandler(r.db.DB, r.imageChecker)
+	trashHandler := handlers.NewTrashHandler(r.db.DB, r.storage, r.settings)
 	paymentHandler := handlers.NewPaymentHandler(r.db)
+	jobHandler := handlers.NewJobHandler(r.jobQueue)
+	notificationHandler := handlers.NewNotificationHandler(r.db, r.settings)
+	calendarHandler := handlers.NewCalendarHandler(r.db)
+	dashboardHandler := handlers.NewDashboardHandler(r.db)
+	achievementHandler := handlers.NewAchievementHandler(r.db)
+	suggestionHandler := handlers.NewSuggestionHandler(r.db, r.authService)
+	clubReviewHandler := handlers.NewClubReviewHandler(r.db.DB, r.contentFilter)
+	lostFoundHandler := handlers.NewLostFoundHandler(r.db, r.storage)
+	bookingHandler := handlers.NewBookingHandler(r.db)
+	festHandler := handlers.NewFestHandler(r.db)
+	sponsorHandler := handlers.NewSponsorHandler(r.db, r.storage)
+	digestHandler := handlers.NewDigestHandler(r.db)
+	impersonationHandler := handlers.NewImpersonationHandler(r.db, r.authService)
+	termHandler := handlers.NewTermHandler(r.db)
+	categoryHandler := handlers.NewCategoryHandler(r.db)
+	maintenanceHandler := handlers.NewMaintenanceHandler(r.db, maintenance)
+	webhookHandler := handlers.NewWebhookHandler(r.db)
+	moderationHandler := handlers.NewModerationHandler(r.db)
+	securityHandler := handlers.NewSecurityHandler(r.db)
+	backupHandler := handlers.NewBackupHandler(r.db)
+	settingsHandler := handlers.NewSettingsHandler(r.db, r.settings)
+	debugHandler := handlers.NewDebugHandler(r.db, r.realtimeHub)
+	savedHandler := handlers.NewSavedHandler(r.db)
+	followHandler := handlers.NewFollowHandler(r.db)
+	shareHandler := handlers.NewShareHandler(r.db, r.publicBaseURL, r.appLinkScheme)
+	resolveHandler := handlers.NewResolveHandler(r.db, r.appLinkScheme)
+	shortLinkHandler := handlers.NewShortLinkHandler(r.db, r.publicBaseURL)
+	posterHandler := handlers.NewPosterHandler(r.db, r.storage, r.publicBaseURL)
+	qrHandler := handlers.NewQRHandler(r.authService)
+	revenueHandler := handlers.NewRevenueHandler(r.db, payments.NewClient())
+	walletHandler := handlers.NewWalletHandler(r.db)
+	campaignHandler := handlers.NewCampaignHandler(r.db)
+	photoHandler := handlers.NewPhotoHandler(r.db, r.storage)
+	resumableUploadHandler := handlers.NewResumableUploadHandler(r.db, r.storage, uploads.NewStage(uploads.StagingDir()))
 
 	// Health check
 	r.engine.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "ok",
+		status, code := "ok", 200
+		dbStatus := "ok"
+		if err := r.db.HealthCheck(); err != nil {
+			// The driver's error can name hosts and users, so it's only logged
+			log.Printf("[HEALTH] database check failed: %v", err)
+			status, code = "degraded", 503
+			dbStatus = "unavailable"
+		}
+		c.JSON(code, gin.H{
+			"status":  status,
 			"service": "college-events-api",
+			"database": gin.H{
+				"status": dbStatus,
+				"pool":   r.db.PoolStats(),
+			},
 		})
 	})
 
-	// Serve static files for local storage (development)
-	r.engine.Static("/uploads", "./uploads")
+	// Build the API is running, for support and bug reports
+	r.engine.GET("/version", func(c *gin.Context) {
+		c.JSON(http.StatusOK, version.Get())
+	})
+
+	// Prometheus metrics
+	r.engine.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	// Link previews for events and posts shared to chat apps, and short
+	// links to events
+	r.engine.GET("/share/events/:id", shareHandler.ShareEvent)
+	r.engine.GET("/share/posts/:id", shareHandler.SharePost)
+	r.engine.GET("/e/:code", shortLinkHandler.FollowShortLink)
+
+	// Profiler for performance investigations, for admins only. It sits
+	// outside the admin group, so AdminAllowlist matches /debug itself.
+	if r.debugEndpoints {
+		debug := r.engine.Group("/debug")
+		debug.Use(middleware.AuthMiddleware(r.authService))
+		debug.Use(middleware.AdminMiddleware())
+		{
+			debug.GET("/pprof/*name", debugHandler.Pprof)
+			debug.POST("/pprof/*name", debugHandler.Pprof) // symbol lookups
+		}
+	}
+
+	// Serve files from local storage (development)
+	if r.uploadsHandler != nil {
+		uploadsHandler := gin.WrapH(http.StripPrefix("/uploads", r.uploadsHandler))
+		r.engine.GET("/uploads/*path", uploadsHandler)
+		r.engine.HEAD("/uploads/*path", uploadsHandler)
+	}
 
 	// API v1 routes
 	v1 := r.engine.Group("/api/v1")
@@ -60,9 +324,21 @@ func (r *Router) Setup() *gin.Engine {
 		// Public auth routes
 		auth := v1.Group("/auth")
 		{
-			auth.POST("/register", authHandler.Register)
-			auth.POST("/login", authHandler.Login)
-			auth.POST("/google", authHandler.GoogleAuth)
+			auth.GET("/providers", authHandler.ListAuthProviders)
+			auth.POST("/logout", authHandler.Logout)
+			if r.authProviders.IsEnabled("password") {
+				auth.POST("/register", authHandler.Register)
+				auth.POST("/login", authHandler.Login)
+			}
+			if r.authProviders.IsEnabled("google") {
+				auth.POST("/google", authHandler.GoogleAuth)
+			}
+			if r.authProviders.IsEnabled("ldap") && r.authProviders.LDAP != nil {
+				auth.POST("/ldap", authHandler.LDAPLogin)
+			}
+			if r.authProviders.IsEnabled("oidc") && r.authProviders.OIDC != nil {
+				auth.POST("/oidc", authHandler.OIDCLogin)
+			}
 		}
 
 		// ====================================================================
@@ -73,18 +349,74 @@ func (r *Router) Setup() *gin.Engine {
 		v1.GET("/departments", deptHandler.GetDepartments)
 		v1.GET("/departments/:id", deptHandler.GetDepartment)
 		v1.GET("/departments/:id/clubs", deptHandler.GetDepartmentClubs)
+		v1.GET("/departments/:id/events", middleware.OptionalAuthMiddleware(r.authService), deptHandler.GetDepartmentEvents)
 
 		// Clubs
 		v1.GET("/clubs", clubHandler.GetClubs)
-		v1.GET("/clubs/:id", clubHandler.GetClub)
+		v1.GET("/clubs/:id", middleware.OptionalAuthMiddleware(r.authService), clubHandler.GetClub)
 		v1.GET("/clubs/:id/members", clubHandler.GetClubMembers)
-		v1.GET("/clubs/:id/events", clubHandler.GetClubEvents)
-		v1.GET("/clubs/:id/announcements", clubHandler.GetClubAnnouncements)
+		v1.GET("/clubs/:id/events", middleware.OptionalAuthMiddleware(r.authService), clubHandler.GetClubEvents)
+		v1.GET("/clubs/:id/announcements", middleware.OptionalAuthMiddleware(r.authService), clubHandler.GetClubAnnouncements)
 		v1.GET("/clubs/:id/awards", clubHandler.GetClubAwards)
+		v1.GET("/clubs/:id/reviews", middleware.OptionalAuthMiddleware(r.authService), clubReviewHandler.ListClubReviews)
+		v1.GET("/clubs/:id/calendar", middleware.OptionalAuthMiddleware(r.authService), clubHandler.GetClubCalendar)
 
 		// Events
-		v1.GET("/events", eventHandler.ListEvents)
-		v1.GET("/events/:id", eventHandler.GetEvent)
+		v1.GET("/events", middleware.OptionalAuthMiddleware(r.authService), eventHandler.ListEvents)
+		v1.GET("/events/featured", middleware.OptionalAuthMiddleware(r.authService), eventHandler.ListFeaturedEvents) // Home screen carousel
+		v1.GET("/events/:id", middleware.OptionalAuthMiddleware(r.authService), eventHandler.GetEvent)
+		v1.GET("/events/:id/results", eventHandler.ListEventResults)
+		v1.GET("/events/:id/registration-form", eventHandler.GetRegistrationForm)
+		v1.GET("/events/:id/ticket-tiers", eventHandler.ListTicketTiers)
+
+		// Fests (event groups with a combined schedule, results and leaderboard)
+		v1.GET("/fests", festHandler.ListFests)
+		v1.GET("/fests/:id", middleware.OptionalAuthMiddleware(r.authService), festHandler.GetFest)
+
+		// Fundraising campaigns and their donor walls
+		v1.GET("/campaigns", campaignHandler.ListCampaigns)
+		v1.GET("/campaigns/:id", campaignHandler.GetCampaign)
+		v1.GET("/campaigns/:id/donations", campaignHandler.ListCampaignDonations)
+		v1.GET("/events/:id/updates", eventHandler.ListEventUpdates)
+		v1.GET("/events/:id/updates/stream", eventHandler.StreamEventUpdates)
+		v1.GET("/events/:id/enrollment/stream", eventHandler.StreamEnrollment)
+
+		// Activity points leaderboard (campus-wide, or per department/house)
+		v1.GET("/leaderboard", achievementHandler.GetLeaderboard)
+
+		// Academic terms and their standings
+		v1.GET("/terms", termHandler.ListTerms)
+		v1.GET("/terms/:id/standings", termHandler.GetTermStandings)
+
+		// Event categories
+		v1.GET("/categories", categoryHandler.ListCategories)
+
+		// Read-only GraphQL API over events, clubs, posts and houses
+		if r.graphQLEnabled {
+			graphQLHandler := handlers.NewGraphQLHandler(r.db)
+			v1.POST("/graphql", middleware.OptionalAuthMiddleware(r.authService), graphQLHandler.Query)
+			v1.GET("/graphql/schema", graphQLHandler.Schema)
+		}
+
+		// Suggestions with public responses
+		v1.GET("/suggestions", suggestionHandler.ListPublicSuggestions)
+
+		// Lost and found (public browsing)
+		v1.GET("/lost-found", lostFoundHandler.ListItems)
+		v1.GET("/lost-found/:id", lostFoundHandler.GetItem)
+
+		// Bookable resources and their availability
+		v1.GET("/resources", bookingHandler.ListResources)
+		v1.GET("/resources/:id/availability", bookingHandler.GetResourceAvailability)
+
+		// Campus calendar (events, house events, official schedules, deadlines)
+		v1.GET("/calendar", middleware.OptionalAuthMiddleware(r.authService), calendarHandler.GetCalendar)
+
+		// One-click unsubscribe link from the weekly digest email
+		v1.GET("/digest/unsubscribe", digestHandler.Unsubscribe)
+
+		// Maps shared links and short codes to what they point at
+		v1.GET("/resolve", middleware.OptionalAuthMiddleware(r.authService), resolveHandler.Resolve)
 
 		// Schedules (public GET - returns official schedules, personal schedules if authenticated)
 		v1.GET("/schedules", middleware.OptionalAuthMiddleware(r.authService), scheduleHandler.ListSchedules)
@@ -95,15 +427,23 @@ func (r *Router) Setup() *gin.Engine {
 		v1.GET("/houses/:id", houseHandler.GetHouse)
 		v1.GET("/houses/:id/announcements", middleware.OptionalAuthMiddleware(r.authService), houseHandler.GetAnnouncements)
 		v1.GET("/houses/:id/events", middleware.OptionalAuthMiddleware(r.authService), houseHandler.GetHouseEvents)
-		v1.GET("/announcements/:id/comments", houseHandler.GetComments)
+		v1.GET("/announcements/:id/comments", middleware.OptionalAuthMiddleware(r.authService), houseHandler.GetComments)
+
+		// Inter-house competitions (public)
+		v1.GET("/house-competitions", competitionHandler.ListCompetitions)
+		v1.GET("/house-competitions/standings", competitionHandler.GetCompetitionStandings)
+		v1.GET("/house-competitions/:id", competitionHandler.GetCompetition)
 
 		// Posts (public read, authenticated for interactions)
 		v1.GET("/posts", middleware.OptionalAuthMiddleware(r.authService), postsHandler.ListPosts)
+		v1.GET("/posts/featured", middleware.OptionalAuthMiddleware(r.authService), postsHandler.ListFeaturedPosts)
 		v1.GET("/posts/:id", middleware.OptionalAuthMiddleware(r.authService), postsHandler.GetPost)
 		v1.POST("/posts/:id/view", postsHandler.TrackView) // Can be anonymous
+		v1.GET("/posts/:id/counters/stream", postsHandler.StreamPostCounters)
 
 		// Stories (public read, authenticated for interactions)
 		v1.GET("/stories", middleware.OptionalAuthMiddleware(r.authService), storiesHandler.ListStories)
+		v1.GET("/stories/:id/counters/stream", storiesHandler.StreamStoryCounters)
 
 		// ====================================================================
 		// PROTECTED ROUTES (Authenticated Users)
@@ -116,6 +456,9 @@ func (r *Router) Setup() *gin.Engine {
 			protected.GET("/profile", authHandler.GetProfile)
 			protected.PUT("/profile", authHandler.UpdateProfile)
 
+			// Scoped access tokens for integrations
+			protected.POST("/auth/tokens", authHandler.CreateScopedToken)
+
 			// ================================================================
 			// PAYMENT ROUTES - Razorpay Integration
 			// ================================================================
@@ -126,43 +469,210 @@ func (r *Router) Setup() *gin.Engine {
 				payments.GET("/status/:event_id", paymentHandler.GetPaymentStatus)
 			}
 
+			// Campus credit wallet
+			protected.GET("/wallet", walletHandler.GetWallet)
+			protected.GET("/wallet/transactions", walletHandler.ListWalletTransactions)
+
+			// Event registration forms, answers and the registrations export
+			protected.PUT("/events/:id/registration-form", eventHandler.UpdateRegistrationForm)
+			protected.PUT("/events/:id/registration/responses", eventHandler.SubmitFormResponses)
+			protected.GET("/events/:id/registrations", eventHandler.ListEventRegistrations)
+
+			// Handing a registration to another student
+			protected.POST("/events/:id/registration/transfer", eventHandler.CreateRegistrationTransfer)
+			protected.DELETE("/events/:id/registration/transfer", eventHandler.CancelRegistrationTransfer)
+			protected.POST("/registration-transfers/accept", eventHandler.AcceptRegistrationTransfer)
+
+			// Attendee check-in and certificate eligibility
+			protected.POST("/events/:id/registrations/:user_id/check-in", eventHandler.CheckInAttendee)
+			protected.DELETE("/events/:id/registrations/:user_id/check-in", eventHandler.UndoCheckIn)
+
+			// Event photo galleries (uploaded by organizers, for attendees)
+			protected.POST("/events/:id/photos", photoHandler.UploadEventPhotos)
+			protected.GET("/events/:id/photos", photoHandler.ListEventPhotos)
+			protected.GET("/events/:id/photos/:photo_id/download", photoHandler.DownloadEventPhoto)
+			protected.DELETE("/events/:id/photos/:photo_id", photoHandler.DeleteEventPhoto)
+			protected.POST("/events/:id/photos/:photo_id/takedown", photoHandler.RequestPhotoTakedown)
+			protected.GET("/events/:id/photo-takedowns", photoHandler.ListPhotoTakedowns)
+			protected.POST("/events/:id/photo-takedowns/:request_id/resolve", photoHandler.ResolvePhotoTakedown)
+			protected.GET("/events/:id/certificate-rules", eventHandler.GetCertificateRules)
+			protected.PUT("/events/:id/certificate-rules", eventHandler.UpdateCertificateRules)
+			protected.GET("/events/:id/certificate-eligibility", eventHandler.GetCertificateEligibility)
+
+			// Ticket tiers of paid events (managed by the event's organizers)
+			protected.POST("/events/:id/ticket-tiers", eventHandler.CreateTicketTier)
+			protected.PUT("/events/:id/ticket-tiers/:tier_id", eventHandler.UpdateTicketTier)
+			protected.DELETE("/events/:id/ticket-tiers/:tier_id", eventHandler.DeleteTicketTier)
+
+			// Event sponsors (managed by the event's organizers)
+			protected.POST("/events/:id/sponsors", sponsorHandler.CreateEventSponsor)
+			protected.PUT("/events/:id/sponsors/:sponsor_id", sponsorHandler.UpdateEventSponsor)
+			protected.DELETE("/events/:id/sponsors/:sponsor_id", sponsorHandler.DeleteEventSponsor)
+
+			// Fest passes (free, or paid through Razorpay)
+			protected.POST("/fests/:id/pass", paymentHandler.CreateFestPassOrder)
+			protected.POST("/fests/:id/pass/verify", paymentHandler.VerifyFestPass)
+
+			// Fundraising campaigns (run by club leads, donations through Razorpay)
+			protected.POST("/clubs/:id/campaigns", campaignHandler.CreateCampaign)
+			protected.PUT("/campaigns/:id", campaignHandler.UpdateCampaign)
+			protected.GET("/campaigns/:id/donors", campaignHandler.GetCampaignDonors)
+			protected.POST("/campaigns/:id/donations", paymentHandler.CreateDonationOrder)
+			protected.POST("/campaigns/:id/donations/verify", paymentHandler.VerifyDonation)
+
+			// Event live updates (posted by the event's organizers)
+			protected.POST("/events/:id/updates", eventHandler.PostEventUpdate)
+
+			// Attendee broadcasts and posters. These live with the admin tools
+			// but are open to every organizer of the event, not only admins.
+			protected.POST("/admin/events/:id/broadcast", eventHandler.CreateEventBroadcast)
+			protected.GET("/admin/events/:id/broadcasts", eventHandler.ListEventBroadcasts)
+			protected.POST("/admin/events/:id/poster", posterHandler.CreateEventPoster)
+
+			// Short links to events (managed by the event's organizers)
+			protected.GET("/events/:id/short-links", shortLinkHandler.ListShortLinks)
+			protected.POST("/events/:id/short-links", shortLinkHandler.CreateShortLink)
+			protected.DELETE("/events/:id/short-links/:link_id", shortLinkHandler.DeleteShortLink)
+
 			// Club announcements (create/update/delete by club admins)
 			protected.POST("/clubs/:id/announcements", clubHandler.CreateClubAnnouncement)
 			protected.PUT("/clubs/:id/announcements/:announcement_id", clubHandler.UpdateClubAnnouncement)
 			protected.DELETE("/clubs/:id/announcements/:announcement_id", clubHandler.DeleteClubAnnouncement)
+			protected.POST("/clubs/:id/announcements/:announcement_id/read", clubHandler.AcknowledgeClubAnnouncement)
+			protected.GET("/clubs/:id/announcements/:announcement_id/reads", clubHandler.GetAnnouncementReads)
 
-			// Club members (add/update/remove by club admins)
+			// Club members (add/update/remove by club leads, the faculty advisor and admins)
 			protected.POST("/clubs/:id/members", clubHandler.AddClubMember)
 			protected.PUT("/clubs/:id/members/:user_id", clubHandler.UpdateClubMember)
 			protected.DELETE("/clubs/:id/members/:user_id", clubHandler.RemoveClubMember)
 
-			// Club awards (add by club admins)
+			// Club awards (add by club leads, the faculty advisor and admins)
 			protected.POST("/clubs/:id/awards", clubHandler.CreateClubAward)
 
+			// Club meetings and attendance (managed by club leads, check-in by members)
+			protected.GET("/clubs/:id/meetings", clubHandler.GetClubMeetings)
+			protected.POST("/clubs/:id/meetings", clubHandler.CreateClubMeeting)
+			protected.POST("/clubs/:id/meetings/:meeting_id/code", clubHandler.OpenMeetingCheckIn)
+			protected.POST("/clubs/:id/meetings/:meeting_id/check-in", clubHandler.CheckInToMeeting)
+			protected.GET("/clubs/:id/attendance", clubHandler.GetClubAttendance)
+
 			// Schedule management (users can create/edit/delete their own personal schedules)
 			protected.POST("/schedules", scheduleHandler.CreateSchedule)
 			protected.PUT("/schedules/:id", scheduleHandler.UpdateSchedule)
 			protected.DELETE("/schedules/:id", scheduleHandler.DeleteSchedule)
 
 			// House interactions (authenticated users)
-			protected.POST("/houses/:id/roles", houseHandler.AddHouseRole)
-			protected.DELETE("/houses/:id/roles/:role_id", houseHandler.RemoveHouseRole)
+			// Announcements and events by admins and house roles with the
+			// permission, such as the house captain
+			protected.POST("/houses/:id/announcements", houseHandler.CreateAnnouncement)
+			protected.POST("/houses/:id/events", houseHandler.CreateHouseEvent)
 			protected.POST("/announcements/:id/like", houseHandler.LikeAnnouncement)
+			protected.POST("/announcements/:id/reaction", houseHandler.React)
+			protected.DELETE("/announcements/:id/reaction", houseHandler.RemoveReaction)
 			protected.POST("/announcements/:id/comments", houseHandler.AddComment)
+			protected.PUT("/announcements/:id/comments/:comment_id", houseHandler.EditComment)
+			protected.GET("/announcements/:id/comments/:comment_id/edits", houseHandler.ListCommentEdits)
 			protected.POST("/house-events/:event_id/enroll", houseHandler.EnrollInEvent)
 			protected.DELETE("/house-events/:event_id/enroll", houseHandler.UnenrollFromEvent)
 
 			// Post interactions (authenticated users)
 			protected.POST("/posts/:id/like", postsHandler.ToggleLike)
+			protected.POST("/posts/:id/reaction", postsHandler.React)
+			protected.DELETE("/posts/:id/reaction", postsHandler.RemoveReaction)
 			protected.POST("/posts/:id/comment", postsHandler.AddComment)
+			protected.PUT("/posts/:id/comments/:comment_id", postsHandler.EditComment)
 			protected.DELETE("/posts/:id/comments/:comment_id", postsHandler.DeleteComment)
+			protected.GET("/posts/:id/comments/:comment_id/edits", postsHandler.ListCommentEdits)
 			protected.POST("/posts/:id/share", postsHandler.TrackShare)
 
 			// Story interactions (authenticated users)
 			protected.POST("/stories/:id/like", storiesHandler.ToggleLike)
 			protected.POST("/stories/:id/view", storiesHandler.TrackView)
+
+			// Notification inbox
+			protected.GET("/notifications", notificationHandler.ListNotifications)
+			protected.POST("/notifications/:id/read", notificationHandler.MarkNotificationRead)
+			protected.POST("/notifications/read-all", notificationHandler.MarkAllNotificationsRead)
+			protected.GET("/me/notification-preferences", notificationHandler.GetNotificationPreferences)
+			protected.PUT("/me/notification-preferences", notificationHandler.UpdateNotificationPreferences)
+			protected.GET("/me/quiet-hours", notificationHandler.GetQuietHours)
+			protected.PUT("/me/quiet-hours", notificationHandler.UpdateQuietHours)
+			protected.DELETE("/me/quiet-hours", notificationHandler.ResetQuietHours)
+
+			// Home screen summary
+			protected.GET("/me/dashboard", dashboardHandler.GetDashboard)
+
+			// Weekly digest email opt-in
+			protected.GET("/me/digest", digestHandler.GetDigestSubscription)
+			protected.PUT("/me/digest", digestHandler.UpdateDigestSubscription)
+
+			// Activity points and badges
+			protected.GET("/me/achievements", achievementHandler.GetMyAchievements)
+
+			// Anonymous suggestion box
+			protected.POST("/suggestions", suggestionHandler.CreateSuggestion)
+			protected.GET("/me/suggestions", suggestionHandler.ListMySuggestions)
+			protected.GET("/clubs/:id/suggestions", suggestionHandler.ListClubSuggestions)
+			protected.PUT("/clubs/:id/suggestions/:suggestion_id", suggestionHandler.TriageClubSuggestion)
+
+			// Lost and found posting and claims
+			protected.POST("/lost-found", lostFoundHandler.CreateItem)
+			protected.DELETE("/lost-found/:id", lostFoundHandler.DeleteItem)
+			protected.POST("/lost-found/:id/claims", lostFoundHandler.CreateClaim)
+			protected.GET("/lost-found/:id/claims", lostFoundHandler.ListClaims)
+			protected.PUT("/lost-found/:id/claims/:claim_id", lostFoundHandler.ResolveClaim)
+
+			// Resource bookings (requested by club leads)
+			protected.GET("/clubs/:id/bookings", bookingHandler.ListClubBookings)
+			protected.POST("/clubs/:id/bookings", bookingHandler.CreateClubBooking)
+			protected.DELETE("/clubs/:id/bookings/:booking_id", bookingHandler.CancelClubBooking)
+
+			// Own club memberships
+			protected.GET("/me/clubs", clubHandler.GetMyClubs)
+			protected.DELETE("/me/clubs/:id", clubHandler.LeaveClub)
+
+			// Blocking other users
+			protected.POST("/users/:id/block", moderationHandler.BlockUser)
+			protected.DELETE("/users/:id/block", moderationHandler.UnblockUser)
+			protected.GET("/me/blocked-users", moderationHandler.ListBlockedUsers)
+
+			// Saved posts and events
+			protected.POST("/posts/:id/save", savedHandler.SavePost)
+			protected.DELETE("/posts/:id/save", savedHandler.UnsavePost)
+			protected.POST("/events/:id/save", savedHandler.SaveEvent)
+			protected.DELETE("/events/:id/save", savedHandler.UnsaveEvent)
+			protected.GET("/me/saved", savedHandler.ListSaved)
+
+			// Reviewing clubs
+			protected.PUT("/clubs/:id/review", clubReviewHandler.ReviewClub) // Members and attendees, one each
+			protected.DELETE("/clubs/:id/review", clubReviewHandler.DeleteClubReview)
+
+			// Following clubs and houses
+			protected.POST("/clubs/:id/follow", followHandler.FollowClub)
+			protected.DELETE("/clubs/:id/follow", followHandler.UnfollowClub)
+			protected.POST("/houses/:id/follow", followHandler.FollowHouse)
+			protected.DELETE("/houses/:id/follow", followHandler.UnfollowHouse)
+			protected.GET("/me/follows", followHandler.ListFollows)
+
+			// House and club chat channels
+			protected.GET("/chat/ws", chatHandler.ServeChatSocket)
+			protected.GET("/chat/channels", chatHandler.ListChatChannels)
+			protected.GET("/chat/channels/:id/messages", chatHandler.GetChatMessages)
+			protected.POST("/chat/channels/:id/messages", chatHandler.PostChatMessage)
+			protected.DELETE("/chat/channels/:id/messages/:message_id", chatHandler.DeleteChatMessage)
+			protected.GET("/chat/channels/:id/messages/:message_id/receipts", chatHandler.GetChatMessageReceipts)
+			protected.POST("/chat/channels/:id/messages/:message_id/pin", chatHandler.PinChatMessage)
+			protected.DELETE("/chat/channels/:id/messages/:message_id/pin", chatHandler.UnpinChatMessage)
+			protected.GET("/chat/channels/:id/pins", chatHandler.GetPinnedChatMessages)
+			protected.POST("/chat/channels/:id/read", chatHandler.MarkChatChannelRead)
+
+			// Checking signed QR codes (tickets, attendance) when scanned
+			protected.POST("/qr/verify", qrHandler.VerifyQR)
 		}
 
+		// QR code generation (admins only)
+		v1.GET("/qr", middleware.AuthMiddleware(r.authService), middleware.AdminMiddleware(), qrHandler.GenerateQR)
+
 		// ====================================================================
 		// ADMIN ROUTES (System Administrators)
 		// ====================================================================
@@ -170,6 +680,9 @@ func (r *Router) Setup() *gin.Engine {
 		admin := v1.Group("/admin")
 		admin.Use(middleware.AuthMiddleware(r.authService))
 		admin.Use(middleware.AdminMiddleware())
+		if r.strictJSON == middleware.StrictJSONAdmin {
+			admin.Use(middleware.StrictJSON())
+		}
 		{
 			// Department management
 			admin.POST("/departments", deptHandler.CreateDepartment)
@@ -180,14 +693,40 @@ func (r *Router) Setup() *gin.Engine {
 			admin.POST("/clubs", clubHandler.CreateClub)
 			admin.PUT("/clubs/:id", clubHandler.UpdateClub)
 			admin.DELETE("/clubs/:id", clubHandler.DeleteClub)
+			admin.GET("/clubs/:id/revenue", revenueHandler.GetClubRevenue)
+			admin.GET("/clubs/:id/settlements", revenueHandler.GetClubSettlements)
 
 			// Event management
 			admin.POST("/events", eventHandler.CreateEvent)
 			admin.PUT("/events/:id", eventHandler.UpdateEvent)
 			admin.DELETE("/events/:id", eventHandler.DeleteEvent)
+			admin.POST("/events/:id/cancel", eventHandler.CancelEvent)
+			admin.POST("/events/:id/feature", eventHandler.FeatureEvent) // Add to or move in the carousel
+			admin.DELETE("/events/:id/feature", eventHandler.UnfeatureEvent)
+			admin.POST("/events/:id/offline-payments", paymentHandler.RecordOfflinePayment)
+			admin.POST("/wallet/credits", walletHandler.CreditWallet)
+			admin.POST("/events/:id/results", eventHandler.RecordEventResults)
+
+			// Fest management
+			admin.POST("/fests", festHandler.CreateFest)
+			admin.PUT("/fests/:id", festHandler.UpdateFest)
+			admin.DELETE("/fests/:id", festHandler.DeleteFest)
+			admin.POST("/fests/:id/events", festHandler.AttachFestEvents)
+			admin.DELETE("/fests/:id/events/:event_id", festHandler.DetachFestEvent)
+			admin.POST("/fests/:id/sponsors", sponsorHandler.CreateFestSponsor)
+			admin.PUT("/fests/:id/sponsors/:sponsor_id", sponsorHandler.UpdateFestSponsor)
+			admin.DELETE("/fests/:id/sponsors/:sponsor_id", sponsorHandler.DeleteFestSponsor)
 
 			// Image upload (optimized & stored to GCS/local)
 			admin.POST("/upload", uploadHandler.UploadImage)
+			admin.POST("/upload/bulk", uploadHandler.UploadImages)
+
+			// Resumable uploads (tus protocol) for videos and large files
+			admin.POST("/uploads/resumable", resumableUploadHandler.CreateUpload)
+			admin.HEAD("/uploads/resumable/:id", resumableUploadHandler.GetUploadOffset)
+			admin.PATCH("/uploads/resumable/:id", resumableUploadHandler.UploadChunk)
+			admin.GET("/uploads/resumable/:id", resumableUploadHandler.GetUpload)
+			admin.DELETE("/uploads/resumable/:id", resumableUploadHandler.CancelUpload)
 
 			// House management
 			admin.POST("/houses", houseHandler.CreateHouse)
@@ -195,16 +734,118 @@ func (r *Router) Setup() *gin.Engine {
 			admin.DELETE("/houses/:id", houseHandler.DeleteHouse)
 			admin.POST("/houses/:id/announcements", houseHandler.CreateAnnouncement)
 			admin.POST("/houses/:id/events", houseHandler.CreateHouseEvent)
+			admin.POST("/houses/:id/roles", houseHandler.AddHouseRole)
+			admin.PUT("/houses/:id/roles/:role_id", houseHandler.UpdateHouseRole)
+			admin.DELETE("/houses/:id/roles/:role_id", houseHandler.RemoveHouseRole)
+			admin.POST("/house-competitions", competitionHandler.CreateCompetition)
+			admin.POST("/house-competitions/:id/results", competitionHandler.RecordCompetitionResults)
 
 			// Posts management (admin/faculty only)
 			admin.POST("/posts", postsHandler.CreatePost)
+			admin.GET("/posts/unpublished", postsHandler.ListUnpublishedPosts) // Drafts and scheduled posts
 			admin.PUT("/posts/:id", postsHandler.UpdatePost)
-			admin.DELETE("/posts/:id", postsHandler.DeletePost)          // Soft delete
-			admin.DELETE("/posts/:id/hard", postsHandler.HardDeletePost) // Permanent delete
+			admin.PUT("/posts/:id/schedule", postsHandler.SchedulePost)
+			admin.POST("/posts/:id/pin", postsHandler.PinPost)
+			admin.DELETE("/posts/:id/pin", postsHandler.UnpinPost)
+			admin.POST("/posts/:id/feature", postsHandler.FeaturePost) // Add to or move in the carousel
+			admin.DELETE("/posts/:id/feature", postsHandler.UnfeaturePost)
+			admin.DELETE("/posts/:id", postsHandler.DeletePost)              // Soft delete
+			admin.DELETE("/posts/:id/hard", postsHandler.HardDeletePost)     // Permanent delete
+			admin.GET("/posts/:id/analytics", postsHandler.GetPostAnalytics) // Watch time and drop-off
 
 			// Stories management (admin/faculty only)
 			admin.POST("/stories", storiesHandler.CreateStory)
+			admin.DELETE("/stories/:id", storiesHandler.DeleteStory)          // Soft delete
 			admin.DELETE("/stories/:id/hard", storiesHandler.HardDeleteStory) // Permanent delete
+
+			// Trash of deleted events, posts and stories
+			admin.GET("/trash", trashHandler.ListTrash)
+			admin.POST("/trash/:type/:id/restore", trashHandler.RestoreTrashItem)
+			admin.DELETE("/trash/:type/:id", trashHandler.PurgeTrashItem)
+
+			// Badge rules and competition awards
+			admin.GET("/badges", achievementHandler.ListBadges)
+			admin.POST("/badges", achievementHandler.CreateBadge)
+			admin.PUT("/badges/:id", achievementHandler.UpdateBadge)
+			admin.POST("/achievements/competition-wins", achievementHandler.AwardCompetitionWin)
+
+			// Broadcasts to cohorts
+			admin.POST("/broadcasts", broadcastHandler.CreateBroadcast)
+			admin.GET("/broadcasts", broadcastHandler.ListBroadcasts)
+			admin.GET("/broadcasts/:id", broadcastHandler.GetBroadcast)
+
+			// Suggestion box triage
+			admin.GET("/suggestions", suggestionHandler.ListAdminSuggestions)
+			admin.PUT("/suggestions/:id", suggestionHandler.TriageAdminSuggestion)
+
+			// Resources and booking approval
+			admin.POST("/resources", bookingHandler.CreateResource)
+			admin.PUT("/resources/:id", bookingHandler.UpdateResource)
+			admin.GET("/bookings", bookingHandler.ListBookings)
+			admin.PUT("/bookings/:id", bookingHandler.ReviewBooking)
+
+			// Background jobs dashboard
+			admin.GET("/jobs", jobHandler.ListJobs)
+			admin.GET("/jobs/stats", jobHandler.GetJobStats)
+			admin.GET("/jobs/dead", jobHandler.ListDeadJobs)
+			admin.POST("/jobs/:id/retry", jobHandler.RetryJob)
+			admin.DELETE("/jobs/:id", jobHandler.DiscardJob) // Dead-letter only
+
+			// Weekly digest delivery
+			admin.GET("/digest/stats", digestHandler.GetDigestStats)
+
+			// Support impersonation and its audit trail
+			admin.POST("/impersonate/:user_id", impersonationHandler.StartImpersonation)
+			admin.GET("/audit-log", impersonationHandler.ListAuditLog)
+			admin.GET("/users/:id/activity", impersonationHandler.GetUserActivity)
+
+			// Comment mutes
+			admin.POST("/users/:id/mute", moderationHandler.MuteUser)
+			admin.DELETE("/users/:id/mute", moderationHandler.UnmuteUser)
+			admin.GET("/mutes", moderationHandler.ListMutes)
+
+			// Suspicious activity alerts and the lockouts applied for them
+			admin.GET("/security/alerts", securityHandler.ListSecurityAlerts)
+			admin.POST("/security/alerts/:id/acknowledge", securityHandler.AcknowledgeSecurityAlert)
+			admin.GET("/security/lockouts", securityHandler.ListLockouts)
+			admin.DELETE("/security/lockouts/:id", securityHandler.LiftLockout)
+
+			// Content the filter flagged for review, and shadow bans
+			admin.GET("/moderation/flags", moderationHandler.ListContentFlags)
+			admin.PUT("/moderation/flags/:id", moderationHandler.ReviewContentFlag)
+			admin.GET("/moderation/shadow-bans", moderationHandler.ListShadowBans)
+			admin.POST("/moderation/shadow-bans", moderationHandler.ShadowBanUser)
+			admin.DELETE("/moderation/shadow-bans/:user_id", moderationHandler.LiftShadowBan)
+
+			// Maintenance mode (admin routes stay available while it is on)
+			admin.GET("/maintenance", maintenanceHandler.GetMaintenance)
+			admin.PUT("/maintenance", maintenanceHandler.UpdateMaintenance)
+
+			// Runtime settings
+			admin.GET("/settings", settingsHandler.GetSettings)
+			admin.PATCH("/settings", settingsHandler.UpdateSettings)
+
+			// Database backups, taken by cmd/backup
+			admin.GET("/backups", backupHandler.ListBackups)
+			admin.POST("/backups", backupHandler.RequestBackup)
+
+			// Runtime diagnostics (profiles are at /debug/pprof)
+			admin.GET("/debug/vars", debugHandler.Vars)
+
+			// Outbound webhooks for external systems
+			admin.GET("/webhooks", webhookHandler.ListWebhooks)
+			admin.POST("/webhooks", webhookHandler.CreateWebhook)
+			admin.PUT("/webhooks/:id", webhookHandler.UpdateWebhook)
+			admin.DELETE("/webhooks/:id", webhookHandler.DeleteWebhook)
+			admin.POST("/webhooks/:id/rotate-secret", webhookHandler.RotateWebhookSecret)
+			admin.GET("/webhooks/:id/deliveries", webhookHandler.ListWebhookDeliveries)
+			admin.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", webhookHandler.RedeliverWebhook)
+
+			// Academic terms (ended terms are archived nightly)
+			admin.POST("/terms", termHandler.CreateTerm)
+			admin.PUT("/terms/:id", termHandler.UpdateTerm)
+			admin.DELETE("/terms/:id", termHandler.DeleteTerm)
+			admin.POST("/terms/:id/archive", termHandler.ArchiveTerm)
 		}
 	}
 
@@ -214,3 +855,9 @@ func (r *Router) Setup() *gin.Engine {
 func (r *Router) Run(addr string) error {
 	return r.engine.Run(addr)
 }
+
+// Handler returns the router as an http.Handler, for serving it with a
+// configured http.Server
+func (r *Router) Handler() http.Handler {
+	return r.engine
+}
@@ -0,0 +1,69 @@
+// Package audit records security-relevant actions in the audit log. Entries
+// can be written in the caller's transaction, alongside the action itself.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// Actions
+const (
+	ActionImpersonationStart   = "impersonation.start"
+	ActionImpersonationRequest = "impersonation.request"
+	ActionUserMute             = "user.mute"
+	ActionUserUnmute           = "user.unmute"
+	ActionUserShadowBan        = "user.shadow_ban"
+	ActionUserShadowUnban      = "user.shadow_unban"
+	ActionRegistrationTransfer = "registration.transfer"
+	ActionOfflinePayment       = "payment.offline"
+	ActionWalletCredit         = "wallet.credit"
+	ActionUserLogin            = "user.login"
+	ActionUserLoginFailed      = "user.login_failed"
+	ActionUserSignup           = "user.signup"
+	ActionLockoutLift          = "security.lockout_lift"
+	ActionSettingsUpdate       = "settings.update"
+	ActionBackupRequest        = "backup.request"
+)
+
+// Entry is a single audit log record. ActorID is who acted; SubjectUserID is
+// the user acted upon or as.
+type Entry struct {
+	Action        string
+	ActorID       *uuid.UUID
+	SubjectUserID *uuid.UUID
+	SessionID     *uuid.UUID // Groups the requests of one impersonation session
+	Method        string
+	Path          string
+	Status        int
+	RequestID     string
+	IPAddress     string
+	Details       map[string]interface{}
+}
+
+// Record writes an entry to the audit log
+func Record(ctx context.Context, tx database.Execer, e Entry) error {
+	var details []byte
+	if e.Details != nil {
+		var err error
+		if details, err = json.Marshal(e.Details); err != nil {
+			return fmt.Errorf("failed to marshal audit details: %w", err)
+		}
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO audit_log (action, actor_id, subject_user_id, session_id, method, path,
+		                       status, request_id, ip_address, details)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, 0), NULLIF($8, ''),
+		        NULLIF($9, ''), $10)
+	`, e.Action, e.ActorID, e.SubjectUserID, e.SessionID, e.Method, e.Path,
+		e.Status, e.RequestID, e.IPAddress, details)
+	if err != nil {
+		return fmt.Errorf("failed to record %s audit entry: %w", e.Action, err)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+// Package backup dumps the database to the storage provider and keeps the
+// newest dumps. cmd/backup runs it on a schedule and for the backups admins
+// request through the API, which are queued in the backups table.
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/storage"
+)
+
+// What started a backup
+const (
+	SourceSchedule = "schedule"
+	SourceManual   = "manual" // cmd/backup run by hand
+	SourceAdmin    = "admin"
+)
+
+const (
+	// Storage folder the dumps are written to
+	folder = "backups"
+
+	// Upper bound on a single backup. A backup still running after this
+	// belonged to a process that died, and is marked failed.
+	backupTimeout = 2 * time.Hour
+)
+
+// DumpFunc writes a dump of the database to w
+type DumpFunc func(ctx context.Context, w io.Writer) error
+
+// Service takes backups and applies the retention
+type Service struct {
+	db        *sql.DB
+	storage   storage.StorageService
+	provider  string // Recorded with each backup, for finding it to restore
+	retention int
+	dump      DumpFunc
+
+	// One backup at a time, whatever started it
+	mu sync.Mutex
+}
+
+// NewService creates a backup service that keeps the newest retention
+// backups
+func NewService(db *sql.DB, storageService storage.StorageService, provider string, retention int, dump DumpFunc) *Service {
+	return &Service{
+		db:        db,
+		storage:   storageService,
+		provider:  provider,
+		retention: retention,
+		dump:      dump,
+	}
+}
+
+// Run takes a backup now
+func (s *Service) Run(ctx context.Context, source string) error {
+	var id uuid.UUID
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO backups (status, source, started_at)
+		VALUES ('running', $1, NOW())
+		RETURNING id
+	`, source).Scan(&id)
+	if err != nil {
+		return fmt.Errorf("failed to record backup: %w", err)
+	}
+	return s.take(ctx, id)
+}
+
+// RunRequested takes the backups admins have requested, oldest first
+func (s *Service) RunRequested(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `
+		UPDATE backups
+		SET status = 'failed', error = 'interrupted', completed_at = NOW()
+		WHERE status = 'running' AND started_at < $1
+	`, time.Now().Add(-backupTimeout)); err != nil {
+		return fmt.Errorf("failed to expire interrupted backups: %w", err)
+	}
+
+	for {
+		// SKIP LOCKED lets several cmd/backup processes share the queue
+		var id uuid.UUID
+		err := s.db.QueryRowContext(ctx, `
+			UPDATE backups
+			SET status = 'running', started_at = NOW()
+			WHERE id = (
+				SELECT id FROM backups
+				WHERE status = 'requested'
+				ORDER BY created_at ASC
+				FOR UPDATE SKIP LOCKED
+				LIMIT 1
+			)
+			RETURNING id
+		`).Scan(&id)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to claim requested backup: %w", err)
+		}
+		if err := s.take(ctx, id); err != nil {
+			return err
+		}
+	}
+}
+
+// take dumps the database into a backup recorded as running, then applies
+// the retention. The dump goes to a temporary file first, so a failed
+// pg_dump never leaves a partial backup in storage.
+func (s *Service) take(ctx context.Context, id uuid.UUID) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, backupTimeout)
+	defer cancel()
+
+	defer func() {
+		if err != nil {
+			// ctx may be why it failed
+			if _, dbErr := s.db.Exec(`
+				UPDATE backups SET status = 'failed', error = $2, completed_at = NOW()
+				WHERE id = $1
+			`, id, err.Error()); dbErr != nil {
+				log.Printf("[BACKUP] Failed to mark backup %s failed: %v", id, dbErr)
+			}
+		}
+	}()
+
+	startTime := time.Now()
+	f, err := os.CreateTemp("", "backup-*.dump")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err := s.dump(ctx, f); err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind dump: %w", err)
+	}
+
+	name := fmt.Sprintf("campus-%s.dump", startTime.UTC().Format("20060102-150405"))
+	result, err := s.storage.UploadFile(ctx, f, name, folder, "application/octet-stream")
+	if err != nil {
+		return fmt.Errorf("failed to upload dump: %w", err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, `
+		UPDATE backups
+		SET status = 'completed', storage_provider = $2, path = $3, size_bytes = $4, completed_at = NOW()
+		WHERE id = $1
+	`, id, s.provider, result.Path, result.SizeBytes); err != nil {
+		// The dump is stored but unrecorded, so the retention won't delete it
+		return fmt.Errorf("failed to record backup at %s: %w", result.Path, err)
+	}
+	log.Printf("[BACKUP] Backup %s completed in %.1fs: %s (%d bytes)",
+		id, time.Since(startTime).Seconds(), result.Path, result.SizeBytes)
+
+	s.prune(ctx)
+	return nil
+}
+
+// prune deletes the completed backups beyond the newest s.retention. It only
+// runs after a backup succeeds, so failing backups never eat into the ones
+// kept.
+func (s *Service) prune(ctx context.Context) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, path FROM backups
+		WHERE status = 'completed'
+		ORDER BY completed_at DESC
+		OFFSET $1
+	`, s.retention)
+	if err != nil {
+		log.Printf("[BACKUP] Failed to find expired backups: %v", err)
+		return
+	}
+	type expired struct {
+		id   uuid.UUID
+		path string
+	}
+	var backups []expired
+	for rows.Next() {
+		var b expired
+		if err := rows.Scan(&b.id, &b.path); err != nil {
+			log.Printf("[BACKUP] Failed to read expired backup: %v", err)
+			continue
+		}
+		backups = append(backups, b)
+	}
+	rows.Close()
+
+	for _, b := range backups {
+		if err := s.storage.Delete(ctx, b.path); err != nil {
+			log.Printf("[BACKUP] Failed to delete expired backup %s: %v", b.path, err)
+			continue
+		}
+		if _, err := s.db.ExecContext(ctx, `UPDATE backups SET status = 'deleted' WHERE id = $1`, b.id); err != nil {
+			log.Printf("[BACKUP] Failed to mark backup %s deleted: %v", b.id, err)
+			continue
+		}
+		log.Printf("[BACKUP] Deleted expired backup %s", b.path)
+	}
+}
@@ -0,0 +1,208 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository"
+)
+
+// batchWait is how long a loader collects keys before fetching them. gqlgen
+// resolves the items of a list concurrently, so siblings asking for the same
+// field land in one batch.
+const batchWait = 2 * time.Millisecond
+
+// Loader batches and caches lookups by key for the length of one request
+type Loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	cache   map[K]*result[V]
+	pending map[K]*result[V]
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// NewLoader returns a Loader that looks up keys with fetch. Keys missing from
+// fetch's result load as the zero value.
+func NewLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{fetch: fetch, cache: map[K]*result[V]{}}
+}
+
+// Load returns the value for key, fetched along with every other key asked
+// for within batchWait
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	res, ok := l.cache[key]
+	if !ok {
+		res = &result[V]{done: make(chan struct{})}
+		l.cache[key] = res
+		if l.pending == nil {
+			l.pending = map[K]*result[V]{}
+			time.AfterFunc(batchWait, func() { l.dispatch(ctx) })
+		}
+		l.pending[key] = res
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-res.done:
+		return res.value, res.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (l *Loader[K, V]) dispatch(ctx context.Context) {
+	l.mu.Lock()
+	pending := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	keys := make([]K, 0, len(pending))
+	for k := range pending {
+		keys = append(keys, k)
+	}
+	values, err := l.fetch(ctx, keys)
+	for k, res := range pending {
+		res.value, res.err = values[k], err
+		close(res.done)
+	}
+}
+
+// clubEventsKey and clubPostsKey identify a club's list field along with its
+// arguments; keys with the same arguments are fetched in one query
+type clubEventsKey struct {
+	clubID   uuid.UUID
+	upcoming bool
+	limit    int
+}
+
+type clubPostsKey struct {
+	clubID uuid.UUID
+	limit  int
+}
+
+// loaders are the per-request loaders behind the batched fields
+type loaders struct {
+	clubs         *Loader[uuid.UUID, *models.Club]
+	registrations *Loader[uuid.UUID, []*repository.EventRegistrant]
+	clubEvents    *Loader[clubEventsKey, []*models.Event]
+	clubPosts     *Loader[clubPostsKey, []*models.PostResponse]
+	// ledClubs lists the clubs the viewer leads, looked up at most once
+	ledClubs func() (map[uuid.UUID]bool, error)
+}
+
+type loadersKey struct{}
+
+// withLoaders returns ctx carrying a fresh set of loaders over q
+func withLoaders(ctx context.Context, q *repository.Queries) context.Context {
+	l := &loaders{
+		clubs: NewLoader(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*models.Club, error) {
+			clubs, err := q.GetClubsByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[uuid.UUID]*models.Club, len(clubs))
+			for i := range clubs {
+				byID[clubs[i].ID] = &clubs[i]
+			}
+			return byID, nil
+		}),
+		registrations: NewLoader(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]*repository.EventRegistrant, error) {
+			registrants, err := q.ListEventsRegistrants(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byEvent := make(map[uuid.UUID][]*repository.EventRegistrant, len(ids))
+			for _, id := range ids {
+				byEvent[id] = []*repository.EventRegistrant{}
+			}
+			for i := range registrants {
+				byEvent[registrants[i].EventID] = append(byEvent[registrants[i].EventID], &registrants[i])
+			}
+			return byEvent, nil
+		}),
+		clubEvents: NewLoader(func(ctx context.Context, keys []clubEventsKey) (map[clubEventsKey][]*models.Event, error) {
+			out := make(map[clubEventsKey][]*models.Event, len(keys))
+			for args, ids := range groupClubIDs(keys, func(k clubEventsKey) (clubEventsKey, uuid.UUID) {
+				return clubEventsKey{upcoming: k.upcoming, limit: k.limit}, k.clubID
+			}) {
+				var from *time.Time
+				if args.upcoming {
+					now := time.Now()
+					from = &now
+				}
+				events, err := q.ListEventsOfClubs(ctx, ids, from, alumniEvents(ctx), args.limit)
+				if err != nil {
+					return nil, err
+				}
+				for i := range events {
+					if events[i].ClubID == nil {
+						continue
+					}
+					k := clubEventsKey{*events[i].ClubID, args.upcoming, args.limit}
+					out[k] = append(out[k], &events[i])
+				}
+			}
+			return out, nil
+		}),
+		clubPosts: NewLoader(func(ctx context.Context, keys []clubPostsKey) (map[clubPostsKey][]*models.PostResponse, error) {
+			out := make(map[clubPostsKey][]*models.PostResponse, len(keys))
+			for args, ids := range groupClubIDs(keys, func(k clubPostsKey) (clubPostsKey, uuid.UUID) {
+				return clubPostsKey{limit: k.limit}, k.clubID
+			}) {
+				posts, err := q.ListPostsOfClubs(ctx, ids, args.limit, viewerID(ctx))
+				if err != nil {
+					return nil, err
+				}
+				for i := range posts {
+					if posts[i].ClubID == nil {
+						continue
+					}
+					k := clubPostsKey{*posts[i].ClubID, args.limit}
+					out[k] = append(out[k], &posts[i])
+				}
+			}
+			return out, nil
+		}),
+	}
+	l.ledClubs = sync.OnceValues(func() (map[uuid.UUID]bool, error) {
+		led := map[uuid.UUID]bool{}
+		v, ok := viewerFrom(ctx)
+		if !ok {
+			return led, nil
+		}
+		ids, err := q.ListLedClubIDs(ctx, v.UserID)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			led[id] = true
+		}
+		return led, nil
+	})
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// groupClubIDs groups keys by their arguments, so each group is one query
+func groupClubIDs[K comparable](keys []K, split func(K) (K, uuid.UUID)) map[K][]uuid.UUID {
+	groups := map[K][]uuid.UUID{}
+	for _, k := range keys {
+		args, id := split(k)
+		groups[args] = append(groups[args], id)
+	}
+	return groups
+}
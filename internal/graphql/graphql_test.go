@@ -0,0 +1,300 @@
+package graphql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// fakeResult is what a query on a fake connection returns
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+// fakeDriver answers each query with the result its test's respond func
+// picks for it, keyed by DSN, and records the queries run
+type fakeDriver struct{}
+
+var (
+	fakeMu       sync.Mutex
+	fakeRespond  = map[string]func(query string) fakeResult{}
+	fakeQueryLog = map[string][]string{}
+)
+
+func init() {
+	sql.Register("graphql-fake", fakeDriver{})
+}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{name: name}, nil
+}
+
+type fakeConn struct {
+	name string
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("transactions not supported") }
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	fakeMu.Lock()
+	defer fakeMu.Unlock()
+	fakeQueryLog[c.name] = append(fakeQueryLog[c.name], query)
+	return &fakeRows{result: fakeRespond[c.name](query)}, nil
+}
+
+type fakeRows struct {
+	result fakeResult
+	pos    int
+}
+
+func (r *fakeRows) Columns() []string { return r.result.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.result.rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+// queriesRun returns the queries run on the test's fake database whose text
+// contains substr
+func queriesRun(t *testing.T, substr string) []string {
+	fakeMu.Lock()
+	defer fakeMu.Unlock()
+	var out []string
+	for _, q := range fakeQueryLog[t.Name()] {
+		if strings.Contains(q, substr) {
+			out = append(out, q)
+		}
+	}
+	return out
+}
+
+// execute runs query against a server over a fake database answering with
+// respond, as viewer when given, and returns the decoded response
+func execute(t *testing.T, respond func(query string) fakeResult, viewer *Viewer, query string) (data map[string]interface{}, errs []string) {
+	t.Helper()
+	fakeMu.Lock()
+	fakeRespond[t.Name()] = respond
+	delete(fakeQueryLog, t.Name())
+	fakeMu.Unlock()
+
+	db, err := sql.Open("graphql-fake", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	body, _ := json.Marshal(map[string]string{"query": query})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	if viewer != nil {
+		req = req.WithContext(WithViewer(req.Context(), *viewer))
+	}
+	w := httptest.NewRecorder()
+	NewServer(&database.DB{DB: db}).ServeHTTP(w, req)
+
+	var resp struct {
+		Data   map[string]interface{} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decoding %q: %v", w.Body.String(), err)
+	}
+	for _, e := range resp.Errors {
+		errs = append(errs, e.Message)
+	}
+	return resp.Data, errs
+}
+
+var (
+	eventColumns = []string{"id", "title", "description", "banner_url", "start_date", "end_date", "location", "category",
+		"status", "max_participants", "current_participants", "registration_deadline", "is_featured", "club_id",
+		"created_by", "created_at", "updated_at", "deleted_at", "is_paid_event", "event_amount", "currency", "fest_id",
+		"registration_form", "term_id", "certificate_requires_check_in", "cancelled_at", "cancellation_reason",
+		"version", "department_id", "featured_position", "featured_from", "featured_until", "alumni_access"}
+	clubColumns = []string{"id", "department_id", "name", "tagline", "description", "logo_url", "image_url",
+		"department", "primary_color", "secondary_color", "member_count", "event_count", "awards_count", "rating",
+		"email", "phone", "website", "social_links", "created_at", "updated_at", "deleted_at", "version"}
+)
+
+// row lays out values by columns, leaving the rest NULL
+func row(columns []string, values map[string]driver.Value) []driver.Value {
+	out := make([]driver.Value, len(columns))
+	for i, c := range columns {
+		out[i] = values[c]
+	}
+	return out
+}
+
+func eventRow(id uuid.UUID, title string, clubID, createdBy *uuid.UUID) []driver.Value {
+	now := time.Now()
+	values := map[string]driver.Value{
+		"id": id.String(), "title": title, "start_date": now, "end_date": now.Add(time.Hour),
+		"status": models.EventStatusUpcoming, "created_by": uuid.NewString(), "registration_form": []byte("[]"),
+		"certificate_requires_check_in": false, "version": int64(1), "alumni_access": models.AlumniAccessNone,
+	}
+	if clubID != nil {
+		values["club_id"] = clubID.String()
+	}
+	if createdBy != nil {
+		values["created_by"] = createdBy.String()
+	}
+	return row(eventColumns, values)
+}
+
+func clubRow(id uuid.UUID, name string) []driver.Value {
+	return row(clubColumns, map[string]driver.Value{"id": id.String(), "name": name, "version": int64(1)})
+}
+
+func TestFieldsAreBatched(t *testing.T) {
+	chess, drama := uuid.New(), uuid.New()
+	respond := func(query string) fakeResult {
+		if strings.Contains(query, "FROM clubs") {
+			return fakeResult{columns: clubColumns, rows: [][]driver.Value{clubRow(chess, "Chess"), clubRow(drama, "Drama")}}
+		}
+		return fakeResult{columns: eventColumns, rows: [][]driver.Value{
+			eventRow(uuid.New(), "Blitz", &chess, nil),
+			eventRow(uuid.New(), "Hamlet", &drama, nil),
+			eventRow(uuid.New(), "Simul", &chess, nil),
+			eventRow(uuid.New(), "Open day", nil, nil),
+		}}
+	}
+
+	data, errs := execute(t, respond, nil, `{ events { title club { name } } }`)
+	if len(errs) > 0 {
+		t.Fatalf("errors = %v", errs)
+	}
+	got, _ := json.Marshal(data)
+	want := `{"events":[{"club":{"name":"Chess"},"title":"Blitz"},{"club":{"name":"Drama"},"title":"Hamlet"},` +
+		`{"club":{"name":"Chess"},"title":"Simul"},{"club":null,"title":"Open day"}]}`
+	if string(got) != want {
+		t.Errorf("data = %s, want %s", got, want)
+	}
+	// One lookup for every event's club, not one per event
+	if n := len(queriesRun(t, "FROM clubs")); n != 1 {
+		t.Errorf("clubs looked up %d times, want 1", n)
+	}
+
+	data, errs = execute(t, respond, nil, `{ clubs { name events(limit: 2) { title } } }`)
+	if len(errs) > 0 {
+		t.Fatalf("errors = %v", errs)
+	}
+	got, _ = json.Marshal(data)
+	want = `{"clubs":[{"events":[{"title":"Blitz"},{"title":"Simul"}],"name":"Chess"},` +
+		`{"events":[{"title":"Hamlet"}],"name":"Drama"}]}`
+	if string(got) != want {
+		t.Errorf("data = %s, want %s", got, want)
+	}
+	if n := len(queriesRun(t, "FROM events")); n != 1 {
+		t.Errorf("club events listed %d times, want 1", n)
+	}
+}
+
+func TestRegistrationsOnlyForOrganizers(t *testing.T) {
+	viewer := Viewer{UserID: uuid.New(), Role: models.RoleStudent}
+	mine, theirs := uuid.New(), uuid.New()
+	respond := func(query string) fakeResult {
+		switch {
+		case strings.Contains(query, "FROM event_registrations"):
+			return fakeResult{
+				columns: []string{"event_id", "id", "full_name", "email", "registered_at"},
+				rows:    [][]driver.Value{{mine.String(), uuid.NewString(), "Asha Rao", "asha@college.edu", time.Now()}},
+			}
+		case strings.Contains(query, "FROM club_members"):
+			return fakeResult{columns: []string{"club_id"}}
+		}
+		return fakeResult{columns: eventColumns, rows: [][]driver.Value{
+			eventRow(mine, "Mine", nil, &viewer.UserID),
+			eventRow(theirs, "Theirs", nil, ptr(uuid.New())),
+		}}
+	}
+
+	data, errs := execute(t, respond, &viewer, `{ events { title registrations { email user { fullName } } } }`)
+	if len(errs) > 0 {
+		t.Fatalf("errors = %v", errs)
+	}
+	got, _ := json.Marshal(data)
+	want := `{"events":[{"registrations":[{"email":"asha@college.edu","user":{"fullName":"Asha Rao"}}],"title":"Mine"},` +
+		`{"registrations":null,"title":"Theirs"}]}`
+	if string(got) != want {
+		t.Errorf("data = %s, want %s", got, want)
+	}
+
+	_, errs = execute(t, respond, nil, `{ events { registrations { email } } }`)
+	if len(errs) > 0 {
+		t.Fatalf("errors = %v", errs)
+	}
+	if q := queriesRun(t, "FROM event_registrations"); len(q) > 0 {
+		t.Errorf("registrations were read for a signed-out viewer")
+	}
+}
+
+func TestQueriesAreLimited(t *testing.T) {
+	respond := func(string) fakeResult {
+		return fakeResult{columns: clubColumns, rows: [][]driver.Value{clubRow(uuid.New(), "Chess")}}
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"page too large", `{ events(pageSize: 101) { title } }`, "pageSize must be between 1 and 100"},
+		{"limit too small", `{ clubs { posts(limit: 0) { description } } }`, "limit must be between 1 and 100"},
+		{"invalid ID", `{ event(id: "not-a-uuid") { title } }`, "invalid UUID"},
+		{
+			"too complex",
+			`{ clubs { events(limit: 100) { registrations { email } } } }`,
+			"exceeds the limit of 5000",
+		},
+		{
+			"too deep",
+			`{ clubs { events(limit: 1) { club { events(limit: 1) { club { events(limit: 1) { club { events(limit: 1) { title } } } } } } } } }`,
+			"exceeds the limit of 8",
+		},
+		{
+			"too deep through fragments",
+			`{ clubs { ...c } } fragment c on Club { events(limit: 1) { club { events(limit: 1) { club { events(limit: 1) { club { events(limit: 1) { title } } } } } } } }`,
+			"exceeds the limit of 8",
+		},
+		{"introspection", `{ __schema { types { name } } }`, "introspection disabled"},
+		{"mutation", `mutation { clubs { name } }`, "mutation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := execute(t, respond, nil, tt.query)
+			if len(errs) == 0 || !strings.Contains(strings.Join(errs, "; "), tt.want) {
+				t.Errorf("errors = %v, want one containing %q", errs, tt.want)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
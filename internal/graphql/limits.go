@@ -0,0 +1,86 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	// maxPageSize bounds every list argument
+	maxPageSize = 100
+	// maxComplexity bounds a query's cost, each field costing one times the
+	// number of items its list may hold
+	maxComplexity = 5000
+	// maxDepth bounds how deeply fields may nest, since types refer back to
+	// each other (a club's events' club's posts' club...)
+	maxDepth = 8
+	// listEstimate is the cost multiplier of lists without a size argument
+	listEstimate = 20
+)
+
+// complexity prices list fields by how many items they may return, so a
+// query's complexity approximates the rows it reads
+func complexity() ComplexityRoot {
+	var c ComplexityRoot
+	sized := func(child int, n *int) int {
+		if n == nil || *n < 1 {
+			return child
+		}
+		return child * *n
+	}
+	unsized := func(child int) int { return child * listEstimate }
+
+	c.Query.Events = func(child int, _ *uuid.UUID, _ *bool, _ *int, pageSize *int) int { return sized(child, pageSize) }
+	c.Query.Posts = func(child int, _, _ *uuid.UUID, _ *string, _ *int, pageSize *int) int { return sized(child, pageSize) }
+	c.Query.Clubs = unsized
+	c.Query.Houses = unsized
+	c.Club.Events = func(child int, _ *bool, limit *int) int { return sized(child, limit) }
+	c.Club.Posts = func(child int, limit *int) int { return sized(child, limit) }
+	c.Event.Registrations = unsized
+	c.User.Clubs = unsized
+	c.User.RegisteredEvents = func(child int, limit *int) int { return sized(child, limit) }
+	return c
+}
+
+// depthLimit rejects operations nesting fields deeper than its limit
+type depthLimit int
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = depthLimit(0)
+
+func (depthLimit) ExtensionName() string { return "DepthLimit" }
+
+func (depthLimit) Validate(graphql.ExecutableSchema) error { return nil }
+
+func (d depthLimit) MutateOperationContext(_ context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	op := opCtx.Doc.Operations.ForName(opCtx.OperationName)
+	if depth := selectionDepth(op.SelectionSet); depth > int(d) {
+		return gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d)
+	}
+	return nil
+}
+
+// selectionDepth is the deepest nesting of fields in set; fragments add no
+// depth of their own
+func selectionDepth(set ast.SelectionSet) int {
+	deepest := 0
+	for _, sel := range set {
+		var depth int
+		switch sel := sel.(type) {
+		case *ast.Field:
+			depth = 1 + selectionDepth(sel.SelectionSet)
+		case *ast.InlineFragment:
+			depth = selectionDepth(sel.SelectionSet)
+		case *ast.FragmentSpread:
+			depth = selectionDepth(sel.Definition.SelectionSet)
+		}
+		deepest = max(deepest, depth)
+	}
+	return deepest
+}
@@ -0,0 +1,110 @@
+// Package graphql serves the read-only GraphQL API. The executable schema in
+// generated.go is built by gqlgen from schema.graphqls; the resolvers in
+// schema.resolvers.go read through the repository, batching per-parent
+// lookups with the loaders in dataloader.go.
+package graphql
+
+//go:generate go run github.com/99designs/gqlgen@v0.17.85 generate --config gqlgen.yml
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/google/uuid"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// SDL is the schema in GraphQL SDL, for client code generation
+//
+//go:embed schema.graphqls
+var SDL string
+
+// Resolver is the root resolver. Per-request state lives in the loaders
+// attached to each operation's context.
+type Resolver struct {
+	q *repository.Queries
+}
+
+// NewServer returns the API's HTTP handler over db. It accepts queries by
+// POST only and has no introspection; every query reads from the replica.
+func NewServer(db *database.DB) *handler.Server {
+	r := &Resolver{q: repository.New(db.Reader())}
+	srv := handler.New(NewExecutableSchema(Config{Resolvers: r, Complexity: complexity()}))
+	srv.AddTransport(transport.POST{})
+	srv.Use(depthLimit(maxDepth))
+	srv.Use(extension.FixedComplexityLimit(maxComplexity))
+	srv.AroundOperations(func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		return next(withLoaders(ctx, r.q))
+	})
+	return srv
+}
+
+// Viewer is the signed-in user a query runs as
+type Viewer struct {
+	UserID uuid.UUID
+	Role   models.UserRole
+}
+
+type viewerKey struct{}
+
+// WithViewer returns ctx carrying the signed-in user
+func WithViewer(ctx context.Context, v Viewer) context.Context {
+	return context.WithValue(ctx, viewerKey{}, v)
+}
+
+func viewerFrom(ctx context.Context) (Viewer, bool) {
+	v, ok := ctx.Value(viewerKey{}).(Viewer)
+	return v, ok
+}
+
+// viewerID returns the signed-in user's ID, or nil when signed out
+func viewerID(ctx context.Context) *uuid.UUID {
+	if v, ok := viewerFrom(ctx); ok {
+		return &v.UserID
+	}
+	return nil
+}
+
+// alumniEvents reports whether the viewer sees alumni events; signed out,
+// they don't
+func alumniEvents(ctx context.Context) bool {
+	v, _ := viewerFrom(ctx)
+	return models.SeesAlumniEvents(v.Role)
+}
+
+// intArg returns an Int argument, checking it is within 1..max
+func intArg(name string, n *int, max int) (int, error) {
+	if n == nil || *n < 1 || *n > max {
+		return 0, gqlerror.Errorf("%s must be between 1 and %d", name, max)
+	}
+	return *n, nil
+}
+
+// pointers returns pointers to the items of a list
+func pointers[T any](items []T) []*T {
+	out := make([]*T, len(items))
+	for i := range items {
+		out[i] = &items[i]
+	}
+	return out
+}
+
+// found turns a single-row lookup into a nullable result: nil when there is
+// no such row
+func found[T any](item T, err error) (*T, error) {
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
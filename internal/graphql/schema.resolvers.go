@@ -0,0 +1,236 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository"
+)
+
+// Events is the resolver for the events field.
+func (r *clubResolver) Events(ctx context.Context, obj *models.Club, upcoming *bool, limit *int) ([]*models.Event, error) {
+	n, err := intArg("limit", limit, maxPageSize)
+	if err != nil {
+		return nil, err
+	}
+	events, err := loadersFrom(ctx).clubEvents.Load(ctx, clubEventsKey{obj.ID, upcoming != nil && *upcoming, n})
+	if events == nil && err == nil {
+		events = []*models.Event{}
+	}
+	return events, err
+}
+
+// Posts is the resolver for the posts field.
+func (r *clubResolver) Posts(ctx context.Context, obj *models.Club, limit *int) ([]*models.PostResponse, error) {
+	n, err := intArg("limit", limit, maxPageSize)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := loadersFrom(ctx).clubPosts.Load(ctx, clubPostsKey{obj.ID, n})
+	if posts == nil && err == nil {
+		posts = []*models.PostResponse{}
+	}
+	return posts, err
+}
+
+// Club is the resolver for the club field.
+func (r *eventResolver) Club(ctx context.Context, obj *models.Event) (*models.Club, error) {
+	if obj.ClubID == nil {
+		return nil, nil
+	}
+	return loadersFrom(ctx).clubs.Load(ctx, *obj.ClubID)
+}
+
+// Registrations is the resolver for the registrations field.
+func (r *eventResolver) Registrations(ctx context.Context, obj *models.Event) ([]*repository.EventRegistrant, error) {
+	viewer, ok := viewerFrom(ctx)
+	if !ok {
+		return nil, nil
+	}
+	organizer := viewer.Role == models.RoleAdmin || (obj.CreatedBy != nil && *obj.CreatedBy == viewer.UserID)
+	if !organizer && obj.ClubID != nil {
+		led, err := loadersFrom(ctx).ledClubs()
+		if err != nil {
+			return nil, err
+		}
+		organizer = led[*obj.ClubID]
+	}
+	if !organizer {
+		return nil, nil
+	}
+	return loadersFrom(ctx).registrations.Load(ctx, obj.ID)
+}
+
+// ContentType is the resolver for the contentType field.
+func (r *postResolver) ContentType(ctx context.Context, obj *models.PostResponse) (string, error) {
+	return string(obj.ContentType), nil
+}
+
+// Club is the resolver for the club field.
+func (r *postResolver) Club(ctx context.Context, obj *models.PostResponse) (*models.Club, error) {
+	if obj.ClubID == nil {
+		return nil, nil
+	}
+	return loadersFrom(ctx).clubs.Load(ctx, *obj.ClubID)
+}
+
+// Viewer is the resolver for the viewer field.
+func (r *queryResolver) Viewer(ctx context.Context) (*models.User, error) {
+	v, ok := viewerFrom(ctx)
+	if !ok {
+		return nil, nil
+	}
+	return found(r.q.GetUser(ctx, v.UserID))
+}
+
+// Event is the resolver for the event field.
+func (r *queryResolver) Event(ctx context.Context, id uuid.UUID) (*models.Event, error) {
+	event, err := found(r.q.GetEvent(ctx, id))
+	if event == nil {
+		return nil, err
+	}
+	if v, _ := viewerFrom(ctx); !event.VisibleTo(v.Role) {
+		return nil, nil
+	}
+	return event, nil
+}
+
+// Events is the resolver for the events field.
+func (r *queryResolver) Events(ctx context.Context, clubID *uuid.UUID, upcoming *bool, page *int, pageSize *int) ([]*models.Event, error) {
+	p, err := intArg("page", page, 1<<20)
+	if err != nil {
+		return nil, err
+	}
+	size, err := intArg("pageSize", pageSize, maxPageSize)
+	if err != nil {
+		return nil, err
+	}
+	var from *time.Time
+	if upcoming != nil && *upcoming {
+		now := time.Now()
+		from = &now
+	}
+	events, err := r.q.ListEventsPage(ctx, clubID, from, alumniEvents(ctx), size, (p-1)*size)
+	if err != nil {
+		return nil, err
+	}
+	return pointers(events), nil
+}
+
+// Club is the resolver for the club field.
+func (r *queryResolver) Club(ctx context.Context, id uuid.UUID) (*models.Club, error) {
+	return found(r.q.GetClub(ctx, id))
+}
+
+// Clubs is the resolver for the clubs field.
+func (r *queryResolver) Clubs(ctx context.Context) ([]*models.Club, error) {
+	clubs, err := r.q.ListClubs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pointers(clubs), nil
+}
+
+// Posts is the resolver for the posts field.
+func (r *queryResolver) Posts(ctx context.Context, clubID *uuid.UUID, houseID *uuid.UUID, hashtag *string, page *int, pageSize *int) ([]*models.PostResponse, error) {
+	f := models.ListPostsQuery{ViewerID: viewerID(ctx), ClubID: clubID, HouseID: houseID, Hashtag: hashtag}
+	var err error
+	if f.Page, err = intArg("page", page, 1<<20); err != nil {
+		return nil, err
+	}
+	if f.PageSize, err = intArg("pageSize", pageSize, maxPageSize); err != nil {
+		return nil, err
+	}
+	posts, err := r.q.ListPosts(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	return pointers(posts), nil
+}
+
+// House is the resolver for the house field.
+func (r *queryResolver) House(ctx context.Context, id uuid.UUID) (*models.House, error) {
+	return found(r.q.GetHouse(ctx, id))
+}
+
+// Houses is the resolver for the houses field.
+func (r *queryResolver) Houses(ctx context.Context) ([]*models.House, error) {
+	houses, err := r.q.ListHouses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pointers(houses), nil
+}
+
+// User is the resolver for the user field.
+func (r *registrationResolver) User(ctx context.Context, obj *repository.EventRegistrant) (*models.UserSummary, error) {
+	return &models.UserSummary{ID: obj.UserID, FullName: obj.FullName}, nil
+}
+
+// Role is the resolver for the role field.
+func (r *userResolver) Role(ctx context.Context, obj *models.User) (string, error) {
+	return string(obj.Role), nil
+}
+
+// Clubs is the resolver for the clubs field.
+func (r *userResolver) Clubs(ctx context.Context, obj *models.User) ([]*models.Club, error) {
+	clubs, err := r.q.ListMemberClubs(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	return pointers(clubs), nil
+}
+
+// RegisteredEvents is the resolver for the registeredEvents field.
+func (r *userResolver) RegisteredEvents(ctx context.Context, obj *models.User, limit *int) ([]*models.Event, error) {
+	n, err := intArg("limit", limit, maxPageSize)
+	if err != nil {
+		return nil, err
+	}
+	events, err := r.q.ListRegisteredUpcomingEvents(ctx, obj.ID, time.Now(), n)
+	if err != nil {
+		return nil, err
+	}
+	return pointers(events), nil
+}
+
+// Role is the resolver for the role field.
+func (r *userSummaryResolver) Role(ctx context.Context, obj *models.UserSummary) (string, error) {
+	return string(obj.Role), nil
+}
+
+// Club returns ClubResolver implementation.
+func (r *Resolver) Club() ClubResolver { return &clubResolver{r} }
+
+// Event returns EventResolver implementation.
+func (r *Resolver) Event() EventResolver { return &eventResolver{r} }
+
+// Post returns PostResolver implementation.
+func (r *Resolver) Post() PostResolver { return &postResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Registration returns RegistrationResolver implementation.
+func (r *Resolver) Registration() RegistrationResolver { return &registrationResolver{r} }
+
+// User returns UserResolver implementation.
+func (r *Resolver) User() UserResolver { return &userResolver{r} }
+
+// UserSummary returns UserSummaryResolver implementation.
+func (r *Resolver) UserSummary() UserSummaryResolver { return &userSummaryResolver{r} }
+
+type clubResolver struct{ *Resolver }
+type eventResolver struct{ *Resolver }
+type postResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type registrationResolver struct{ *Resolver }
+type userResolver struct{ *Resolver }
+type userSummaryResolver struct{ *Resolver }
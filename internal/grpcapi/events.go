@@ -0,0 +1,148 @@
+package grpcapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository"
+	campusv1 "github.com/yourusername/college-event-backend/pkg/pb/campus/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListEvents page sizes
+const (
+	defaultEventPageSize = 50
+	maxEventPageSize     = 200
+)
+
+type eventService struct {
+	campusv1.UnimplementedEventServiceServer
+	q *repository.Queries
+	// primary answers CheckRegistration, which is often called right after
+	// a user registers and cannot tolerate replica lag
+	primary *repository.Queries
+}
+
+func (s *eventService) GetEvent(ctx context.Context, req *campusv1.GetEventRequest) (*campusv1.Event, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.q.GetEvent(ctx, id)
+	if err != nil {
+		return nil, queryError("event", err)
+	}
+	return eventMessage(event), nil
+}
+
+func (s *eventService) ListEvents(ctx context.Context, req *campusv1.ListEventsRequest) (*campusv1.ListEventsResponse, error) {
+	var clubID *uuid.UUID
+	if req.ClubId != nil {
+		id, err := parseID("club_id", req.GetClubId())
+		if err != nil {
+			return nil, err
+		}
+		clubID = &id
+	}
+	var from *time.Time
+	if req.GetUpcomingOnly() {
+		now := time.Now()
+		from = &now
+	}
+	limit := int(req.GetPageSize())
+	if limit <= 0 {
+		limit = defaultEventPageSize
+	} else if limit > maxEventPageSize {
+		limit = maxEventPageSize
+	}
+	offset := max(int(req.GetOffset()), 0)
+
+	// Callers share a service token, so they see alumni events too
+	events, err := s.q.ListEventsPage(ctx, clubID, from, true, limit, offset)
+	if err != nil {
+		return nil, queryError("events", err)
+	}
+	resp := &campusv1.ListEventsResponse{Events: make([]*campusv1.Event, len(events))}
+	for i, e := range events {
+		resp.Events[i] = eventMessage(e)
+	}
+	return resp, nil
+}
+
+func (s *eventService) ListRegistrations(ctx context.Context, req *campusv1.ListRegistrationsRequest) (*campusv1.ListRegistrationsResponse, error) {
+	eventID, err := parseID("event_id", req.GetEventId())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.q.GetEvent(ctx, eventID); err != nil {
+		return nil, queryError("event", err)
+	}
+
+	registrants, err := s.q.ListEventsRegistrants(ctx, []uuid.UUID{eventID})
+	if err != nil {
+		return nil, queryError("registrations", err)
+	}
+	resp := &campusv1.ListRegistrationsResponse{Registrations: make([]*campusv1.Registration, len(registrants))}
+	for i, r := range registrants {
+		resp.Registrations[i] = registrationMessage(r)
+	}
+	return resp, nil
+}
+
+func (s *eventService) CheckRegistration(ctx context.Context, req *campusv1.CheckRegistrationRequest) (*campusv1.CheckRegistrationResponse, error) {
+	eventID, err := parseID("event_id", req.GetEventId())
+	if err != nil {
+		return nil, err
+	}
+	userID, err := parseID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := s.primary.GetEventRegistrant(ctx, eventID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &campusv1.CheckRegistrationResponse{}, nil
+	}
+	if err != nil {
+		return nil, queryError("registration", err)
+	}
+	return &campusv1.CheckRegistrationResponse{
+		Registered:   true,
+		RegisteredAt: timestamppb.New(r.RegisteredAt),
+	}, nil
+}
+
+func eventMessage(e models.Event) *campusv1.Event {
+	return &campusv1.Event{
+		Id:                   e.ID.String(),
+		Title:                e.Title,
+		Description:          e.Description,
+		StartTime:            timestamppb.New(e.StartDate),
+		EndTime:              timestamppb.New(e.EndDate),
+		Location:             e.Location,
+		Category:             e.Category,
+		Status:               e.Status,
+		MaxParticipants:      int32Ptr(e.MaxParticipants),
+		CurrentParticipants:  int32(e.CurrentParticipants),
+		RegistrationDeadline: timestamp(e.RegistrationDeadline),
+		IsPaid:               e.IsPaidEvent,
+		Amount:               e.EventAmount,
+		Currency:             e.Currency,
+		ClubId:               idPtr(e.ClubID),
+		FestId:               idPtr(e.FestID),
+	}
+}
+
+func registrationMessage(r repository.EventRegistrant) *campusv1.Registration {
+	return &campusv1.Registration{
+		EventId:      r.EventID.String(),
+		UserId:       r.UserID.String(),
+		FullName:     r.FullName,
+		Email:        r.Email,
+		RegisteredAt: timestamppb.New(r.RegisteredAt),
+	}
+}
@@ -0,0 +1,71 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository"
+	campusv1 "github.com/yourusername/college-event-backend/pkg/pb/campus/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type paymentService struct {
+	campusv1.UnimplementedPaymentServiceServer
+	q *repository.Queries
+}
+
+func (s *paymentService) GetPayment(ctx context.Context, req *campusv1.GetPaymentRequest) (*campusv1.Payment, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	payment, err := s.q.GetPayment(ctx, id)
+	if err != nil {
+		return nil, queryError("payment", err)
+	}
+	return paymentMessage(payment), nil
+}
+
+func (s *paymentService) ListUserPayments(ctx context.Context, req *campusv1.ListUserPaymentsRequest) (*campusv1.ListUserPaymentsResponse, error) {
+	userID, err := parseID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if req.Status != nil {
+		switch req.GetStatus() {
+		case "pending", "paid", "failed", "refunded":
+		default:
+			return nil, status.Error(codes.InvalidArgument, "status must be one of pending, paid, failed or refunded")
+		}
+	}
+
+	payments, err := s.q.ListUserPayments(ctx, userID, req.Status)
+	if err != nil {
+		return nil, queryError("payments", err)
+	}
+	resp := &campusv1.ListUserPaymentsResponse{Payments: make([]*campusv1.Payment, len(payments))}
+	for i, p := range payments {
+		resp.Payments[i] = paymentMessage(p)
+	}
+	return resp, nil
+}
+
+// paymentMessage leaves out the gateway signature, which only the payment
+// verification endpoint needs
+func paymentMessage(p models.EventPayment) *campusv1.Payment {
+	return &campusv1.Payment{
+		Id:            p.ID.String(),
+		EventId:       p.EventID.String(),
+		UserId:        p.UserID.String(),
+		OrderId:       p.RazorpayOrderID,
+		PaymentId:     p.RazorpayPaymentID,
+		Amount:        p.Amount,
+		Currency:      p.Currency,
+		Status:        p.Status,
+		FailureReason: p.FailureReason,
+		CreatedAt:     timestamppb.New(p.CreatedAt),
+		UpdatedAt:     timestamppb.New(p.UpdatedAt),
+	}
+}
@@ -0,0 +1,103 @@
+// Package grpcapi serves the internal gRPC API that other campus services,
+// such as attendance and mess management, use to read users, events and
+// payments. It shares the repository layer with the REST API. The protobuf
+// definitions are in proto/campus/v1 and the generated code in
+// pkg/pb/campus/v1.
+package grpcapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/repository"
+	"github.com/yourusername/college-event-backend/pkg/database"
+	campusv1 "github.com/yourusername/college-event-backend/pkg/pb/campus/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NewServer creates a gRPC server with every campus service registered.
+// Each call must carry token as a bearer token in its authorization metadata.
+func NewServer(db *database.DB, token string) *grpc.Server {
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(logCalls, authorize(token)))
+
+	reader := repository.New(db.Reader())
+	campusv1.RegisterUserServiceServer(s, &userService{q: reader})
+	campusv1.RegisterEventServiceServer(s, &eventService{q: reader, primary: repository.New(db.DB)})
+	campusv1.RegisterPaymentServiceServer(s, &paymentService{q: reader})
+	return s
+}
+
+// authorize rejects calls without the shared token
+func authorize(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "authorization metadata is required")
+		}
+		given, ok := strings.CutPrefix(values[0], "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// logCalls logs each call with its status and duration
+func logCalls(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	log.Printf("[GRPC] %s %s %v", info.FullMethod, status.Code(err), time.Since(start).Round(time.Microsecond))
+	return resp, err
+}
+
+// parseID parses a UUID argument
+func parseID(field, s string) (uuid.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "%s must be a UUID", field)
+	}
+	return id, nil
+}
+
+// queryError maps sql.ErrNoRows to NotFound and logs anything else
+func queryError(what string, err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Errorf(codes.NotFound, "%s not found", what)
+	}
+	log.Printf("[GRPC] Failed to fetch %s: %v", what, err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func int32Ptr(n *int) *int32 {
+	if n == nil {
+		return nil
+	}
+	v := int32(*n)
+	return &v
+}
+
+func idPtr(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
@@ -0,0 +1,92 @@
+package grpcapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"testing"
+
+	campusv1 "github.com/yourusername/college-event-backend/pkg/pb/campus/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testToken = "test-token-0123456789abcdef0123456789"
+
+// dialTestServer serves a user service with no database over an in-memory
+// listener. Calls that get past argument validation would panic, so tests
+// only make calls that are rejected before reaching the database.
+func dialTestServer(t *testing.T) campusv1.UserServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(authorize(testToken)))
+	campusv1.RegisterUserServiceServer(s, &userService{})
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return campusv1.NewUserServiceClient(conn)
+}
+
+func TestAuthorize(t *testing.T) {
+	client := dialTestServer(t)
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          codes.Code
+	}{
+		{"missing token", "", codes.Unauthenticated},
+		{"wrong token", "Bearer nope", codes.Unauthenticated},
+		{"not a bearer token", testToken, codes.Unauthenticated},
+		// Passes authorization and fails on the malformed ID
+		{"valid token", "Bearer " + testToken, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", tt.authorization)
+			}
+			_, err := client.GetUser(ctx, &campusv1.GetUserRequest{Id: "not-a-uuid"})
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("GetUser() code = %v, want %v (%v)", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestBatchGetUsersLimit(t *testing.T) {
+	client := dialTestServer(t)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+testToken)
+
+	ids := make([]string, maxBatchUsers+1)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("00000000-0000-0000-0000-%012d", i)
+	}
+	_, err := client.BatchGetUsers(ctx, &campusv1.BatchGetUsersRequest{Ids: ids})
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Errorf("BatchGetUsers() code = %v, want %v", got, codes.InvalidArgument)
+	}
+}
+
+func TestQueryError(t *testing.T) {
+	if got := status.Code(queryError("user", sql.ErrNoRows)); got != codes.NotFound {
+		t.Errorf("queryError(ErrNoRows) code = %v, want NotFound", got)
+	}
+	if got := status.Code(queryError("user", fmt.Errorf("connection reset"))); got != codes.Internal {
+		t.Errorf("queryError(other) code = %v, want Internal", got)
+	}
+}
@@ -0,0 +1,81 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository"
+	campusv1 "github.com/yourusername/college-event-backend/pkg/pb/campus/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxBatchUsers bounds BatchGetUsers
+const maxBatchUsers = 500
+
+type userService struct {
+	campusv1.UnimplementedUserServiceServer
+	q *repository.Queries
+}
+
+func (s *userService) GetUser(ctx context.Context, req *campusv1.GetUserRequest) (*campusv1.User, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.q.GetUser(ctx, id)
+	if err != nil {
+		return nil, queryError("user", err)
+	}
+	return userMessage(user), nil
+}
+
+func (s *userService) GetUserByEmail(ctx context.Context, req *campusv1.GetUserByEmailRequest) (*campusv1.User, error) {
+	if req.GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+	user, err := s.q.GetUserByEmail(ctx, req.GetEmail())
+	if err != nil {
+		return nil, queryError("user", err)
+	}
+	return userMessage(user), nil
+}
+
+func (s *userService) BatchGetUsers(ctx context.Context, req *campusv1.BatchGetUsersRequest) (*campusv1.BatchGetUsersResponse, error) {
+	if len(req.GetIds()) > maxBatchUsers {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d ids can be requested at once", maxBatchUsers)
+	}
+	ids := make([]uuid.UUID, len(req.GetIds()))
+	for i, s := range req.GetIds() {
+		id, err := parseID("ids", s)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+
+	users, err := s.q.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, queryError("users", err)
+	}
+	resp := &campusv1.BatchGetUsersResponse{Users: make([]*campusv1.User, len(users))}
+	for i, u := range users {
+		resp.Users[i] = userMessage(u)
+	}
+	return resp, nil
+}
+
+func userMessage(u models.User) *campusv1.User {
+	return &campusv1.User{
+		Id:         u.ID.String(),
+		Email:      u.Email,
+		FullName:   u.FullName,
+		Role:       string(u.Role),
+		Department: u.Department,
+		Year:       int32Ptr(u.Year),
+		AvatarUrl:  u.AvatarURL,
+		CreatedAt:  timestamppb.New(u.CreatedAt),
+	}
+}
@@ -9,7 +9,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/robfig/cron/v3"
+	"github.com/yourusername/college-event-backend/internal/settings"
 	"github.com/yourusername/college-event-backend/internal/storage"
+	"github.com/yourusername/college-event-backend/internal/uploads"
 )
 
 // CleanupService handles automated cleanup and archiving jobs
@@ -17,14 +19,18 @@ type CleanupService struct {
 	db      *sql.DB
 	storage storage.StorageService
 	cron    *cron.Cron
+
+	// Runtime settings, for how long deleted content stays in the trash
+	settings *settings.Store
 }
 
 // NewCleanupService creates a new cleanup service
-func NewCleanupService(db *sql.DB, storageService storage.StorageService) *CleanupService {
+func NewCleanupService(db *sql.DB, storageService storage.StorageService, settingsStore *settings.Store) *CleanupService {
 	return &CleanupService{
-		db:      db,
-		storage: storageService,
-		cron:    cron.New(),
+		db:       db,
+		storage:  storageService,
+		cron:     cron.New(),
+		settings: settingsStore,
 	}
 }
 
@@ -48,6 +54,59 @@ func (s *CleanupService) Start() {
 		}
 	})
 
+	// Lost and found expiry - daily at 3 AM
+	s.cron.AddFunc("0 3 * * *", func() {
+		if err := s.ExpireLostAndFound(); err != nil {
+			log.Printf("[CRON] Lost and found expiry failed: %v", err)
+		} else {
+			log.Println("[CRON] Lost and found expiry completed successfully")
+		}
+	})
+
+	// Abandoned resumable uploads - every hour at minute 30
+	s.cron.AddFunc("30 * * * *", func() {
+		if err := s.ExpireUploadSessions(); err != nil {
+			log.Printf("[CRON] Upload session expiry failed: %v", err)
+		} else {
+			log.Println("[CRON] Upload session expiry completed successfully")
+		}
+	})
+
+	// Attachments of removed chat messages and conversations - every hour
+	// at minute 45
+	s.cron.AddFunc("45 * * * *", func() {
+		if err := s.CleanupChatAttachments(); err != nil {
+			log.Printf("[CRON] Chat attachment cleanup failed: %v", err)
+		} else {
+			log.Println("[CRON] Chat attachment cleanup completed successfully")
+		}
+	})
+
+	// Scheduled posts - every minute; only failures are logged, as it
+	// usually has nothing to do
+	s.cron.AddFunc("* * * * *", func() {
+		if err := s.PublishScheduledPosts(); err != nil {
+			log.Printf("[CRON] Scheduled post publishing failed: %v", err)
+		}
+	})
+
+	// Event status - every minute, logging only failures like the post
+	// publisher
+	s.cron.AddFunc("* * * * *", func() {
+		if err := s.UpdateEventStatuses(); err != nil {
+			log.Printf("[CRON] Event status update failed: %v", err)
+		}
+	})
+
+	// Trash purge - daily at 4 AM
+	s.cron.AddFunc("0 4 * * *", func() {
+		if err := s.PurgeTrash(); err != nil {
+			log.Printf("[CRON] Trash purge failed: %v", err)
+		} else {
+			log.Println("[CRON] Trash purge completed successfully")
+		}
+	})
+
 	s.cron.Start()
 	log.Println("[CRON] Cleanup service started")
 }
@@ -58,7 +117,8 @@ func (s *CleanupService) Stop() {
 	log.Println("[CRON] Cleanup service stopped")
 }
 
-// CleanupExpiredStories deletes expired stories and their media files
+// CleanupExpiredStories deletes expired stories and their media files.
+// Stories in the trash are left to PurgeTrash.
 func (s *CleanupService) CleanupExpiredStories() error {
 	ctx := context.Background()
 	startTime := time.Now()
@@ -88,6 +148,12 @@ func (s *CleanupService) CleanupExpiredStories() error {
 			continue
 		}
 
+		// A deleted story waits out the trash retention instead
+		var trashed bool
+		if err := s.db.QueryRow(`SELECT deleted_at IS NOT NULL FROM stories WHERE id = $1`, storyID).Scan(&trashed); err == nil && trashed {
+			continue
+		}
+
 		// Delete media files from storage
 		if imageURL.Valid && imageURL.String != "" {
 			path := extractPathFromURL(imageURL.String)
@@ -224,6 +290,162 @@ func (s *CleanupService) ArchiveOldPosts() error {
 	return nil
 }
 
+// ExpireLostAndFound closes open lost and found items past their expiry and
+// deletes their photos. The rows are kept so posters can see what expired.
+func (s *CleanupService) ExpireLostAndFound() error {
+	ctx := context.Background()
+	startTime := time.Now()
+
+	log.Println("[CLEANUP] Starting lost and found expiry...")
+
+	rows, err := s.db.QueryContext(ctx, `
+		WITH due AS (
+			SELECT id, image_path FROM lost_found_items
+			WHERE status = 'open' AND expires_at <= NOW()
+			FOR UPDATE
+		)
+		UPDATE lost_found_items i
+		SET status = 'expired', image_url = NULL, image_path = NULL
+		FROM due
+		WHERE i.id = due.id
+		RETURNING i.id, due.image_path
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	expiredCount := 0
+	failedCount := 0
+
+	for rows.Next() {
+		var itemID uuid.UUID
+		var imagePath sql.NullString
+		if err := rows.Scan(&itemID, &imagePath); err != nil {
+			return err
+		}
+		expiredCount++
+
+		if imagePath.Valid && imagePath.String != "" {
+			if err := s.storage.Delete(ctx, imagePath.String); err != nil {
+				log.Printf("[CLEANUP] Failed to delete image %s of item %s: %v", imagePath.String, itemID, err)
+				failedCount++
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	duration := time.Since(startTime)
+	log.Printf("[CLEANUP] Lost and found expiry complete: %d expired, %d image deletions failed in %.2fs",
+		expiredCount, failedCount, duration.Seconds())
+
+	return nil
+}
+
+// ExpireUploadSessions deletes resumable uploads that stopped before
+// finishing and were not resumed in time, along with their staged chunks
+func (s *CleanupService) ExpireUploadSessions() error {
+	ctx := context.Background()
+	startTime := time.Now()
+
+	log.Println("[CLEANUP] Starting upload session expiry...")
+
+	rows, err := s.db.QueryContext(ctx, `
+		DELETE FROM upload_sessions
+		WHERE status = 'uploading' AND expires_at <= NOW()
+		RETURNING id
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	stage := uploads.NewStage(uploads.StagingDir())
+	expiredCount := 0
+	failedCount := 0
+
+	for rows.Next() {
+		var sessionID uuid.UUID
+		if err := rows.Scan(&sessionID); err != nil {
+			return err
+		}
+		expiredCount++
+
+		if err := stage.Remove(sessionID); err != nil {
+			log.Printf("[CLEANUP] Failed to remove staged upload %s: %v", sessionID, err)
+			failedCount++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	duration := time.Since(startTime)
+	log.Printf("[CLEANUP] Upload session expiry complete: %d expired, %d removals failed in %.2fs",
+		expiredCount, failedCount, duration.Seconds())
+
+	return nil
+}
+
+// CleanupChatAttachments deletes the attachments of chat messages that
+// were removed, and of conversations whose house or club was deleted,
+// along with their files
+func (s *CleanupService) CleanupChatAttachments() error {
+	ctx := context.Background()
+	startTime := time.Now()
+
+	log.Println("[CLEANUP] Starting chat attachment cleanup...")
+
+	rows, err := s.db.QueryContext(ctx, `
+		DELETE FROM chat_message_attachments a
+		WHERE a.message_id IS NULL OR a.channel_id IS NULL
+		   OR EXISTS (
+			SELECT 1 FROM chat_channel_messages m
+			WHERE m.id = a.message_id AND m.deleted_at IS NOT NULL
+		   )
+		RETURNING a.id, a.path, a.thumbnail_path
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	deletedCount := 0
+	failedCount := 0
+
+	for rows.Next() {
+		var attachmentID uuid.UUID
+		var path string
+		var thumbnailPath sql.NullString
+		if err := rows.Scan(&attachmentID, &path, &thumbnailPath); err != nil {
+			return err
+		}
+		deletedCount++
+
+		paths := []string{path}
+		if thumbnailPath.Valid && thumbnailPath.String != "" {
+			paths = append(paths, thumbnailPath.String)
+		}
+		for _, p := range paths {
+			if err := s.storage.Delete(ctx, p); err != nil {
+				log.Printf("[CLEANUP] Failed to delete file %s of chat attachment %s: %v", p, attachmentID, err)
+				failedCount++
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	duration := time.Since(startTime)
+	log.Printf("[CLEANUP] Chat attachment cleanup complete: %d deleted, %d file deletions failed in %.2fs",
+		deletedCount, failedCount, duration.Seconds())
+
+	return nil
+}
+
 // extractPathFromURL extracts the GCS object path from a full URL
 // Example: https://storage.googleapis.com/bucket/posts/abc.jpg -> posts/abc.jpg
 func extractPathFromURL(url string) string {
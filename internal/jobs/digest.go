@@ -0,0 +1,349 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"html/template"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
+)
+
+const (
+	// digestBatchSize is how many digests are claimed and sent at a time
+	digestBatchSize = 50
+	// digestMaxAttempts bounds retries of a failed send within the same week
+	digestMaxAttempts = 3
+	// digestStaleAfter is how long a send may sit in 'sending' before it is
+	// assumed lost to a crash and retried
+	digestStaleAfter = time.Hour
+)
+
+// DigestService emails the weekly campus roundup to subscribed users
+type DigestService struct {
+	db      *sql.DB
+	email   notifications.EmailSender
+	baseURL string
+	cron    *cron.Cron
+}
+
+// NewDigestService creates a new digest service. baseURL is the public URL
+// of the API, used for unsubscribe links.
+func NewDigestService(db *sql.DB, email notifications.EmailSender, baseURL string) *DigestService {
+	return &DigestService{
+		db:      db,
+		email:   email,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		cron:    cron.New(),
+	}
+}
+
+// Start starts the cron job
+func (s *DigestService) Start() {
+	// Weekly digest - Mondays at 8 AM
+	s.cron.AddFunc("0 8 * * 1", func() {
+		if err := s.SendWeeklyDigest(context.Background()); err != nil {
+			log.Printf("[CRON] Weekly digest failed: %v", err)
+		} else {
+			log.Println("[CRON] Weekly digest completed successfully")
+		}
+	})
+
+	s.cron.Start()
+	log.Println("[CRON] Digest service started")
+}
+
+// Stop stops the cron job
+func (s *DigestService) Stop() {
+	s.cron.Stop()
+	log.Println("[CRON] Digest service stopped")
+}
+
+type digestEvent struct {
+	Title    string
+	StartsAt time.Time
+	Location *string
+}
+
+type digestPost struct {
+	Excerpt      string
+	LikeCount    int
+	CommentCount int
+}
+
+type digestHouse struct {
+	Name   string
+	Points int
+}
+
+// digestContent is shared by every recipient of a week's digest
+type digestContent struct {
+	WeekStart time.Time
+	Events    []digestEvent
+	Posts     []digestPost
+	Houses    []digestHouse
+}
+
+type digestData struct {
+	digestContent
+	Name           string
+	UnsubscribeURL string
+}
+
+var digestTemplate = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #222; max-width: 600px;">
+<h2>Your week on campus</h2>
+<p>Hi {{.Name}}, here's what's happening for the week of {{.WeekStart.Format "2 January"}}.</p>
+
+<h3>Upcoming events</h3>
+{{if .Events}}<ul>
+{{range .Events}}<li><strong>{{.Title}}</strong> &middot; {{.StartsAt.Format "Mon 2 Jan, 3:04 PM"}}{{with .Location}} &middot; {{.}}{{end}}</li>
+{{end}}</ul>{{else}}<p>No events scheduled this week.</p>{{end}}
+
+<h3>Top posts</h3>
+{{if .Posts}}<ul>
+{{range .Posts}}<li>{{.Excerpt}} <em>({{.LikeCount}} likes, {{.CommentCount}} comments)</em></li>
+{{end}}</ul>{{else}}<p>No new posts last week.</p>{{end}}
+
+{{if .Houses}}<h3>House standings</h3>
+<ol>
+{{range .Houses}}<li>{{.Name}} &middot; {{.Points}} points</li>
+{{end}}</ol>{{end}}
+
+<p style="font-size: 12px; color: #888;">You're receiving this because you subscribed to the weekly digest.
+<a href="{{.UnsubscribeURL}}">Unsubscribe</a></p>
+</body>
+</html>
+`))
+
+// weekStart returns local midnight on the Monday of t's week
+func weekStart(t time.Time) time.Time {
+	t = t.In(time.Local)
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.Local)
+}
+
+// excerpt shortens s to at most n runes on a word boundary
+func excerpt(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	cut := string(r[:n])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return cut + "…"
+}
+
+// SendWeeklyDigest emails this week's digest to every subscriber who has not
+// yet received it. Each send is recorded in digest_sends, so running it again
+// in the same week only retries failed and interrupted sends.
+func (s *DigestService) SendWeeklyDigest(ctx context.Context) error {
+	startTime := time.Now()
+	week := weekStart(startTime)
+	weekDate := week.Format("2006-01-02")
+
+	log.Println("[DIGEST] Starting weekly digest...")
+
+	// Queue a send for each current subscriber
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO digest_sends (user_id, week_start)
+		SELECT s.user_id, $1::date
+		FROM digest_subscriptions s
+		JOIN users u ON u.id = s.user_id AND u.deleted_at IS NULL
+		ON CONFLICT (user_id, week_start) DO NOTHING
+	`, weekDate); err != nil {
+		return err
+	}
+
+	// Requeue failed sends with attempts left, and sends lost to a crash
+	if _, err := s.db.ExecContext(ctx, `
+		UPDATE digest_sends SET status = 'pending'
+		WHERE week_start = $1::date
+		  AND ((status = 'failed' AND attempts < $2)
+		       OR (status = 'sending' AND updated_at < $3))
+	`, weekDate, digestMaxAttempts, startTime.Add(-digestStaleAfter)); err != nil {
+		return err
+	}
+
+	content, err := s.compileDigest(ctx, startTime)
+	if err != nil {
+		return err
+	}
+	content.WeekStart = week
+
+	var sent, failed int
+	for {
+		n, f, err := s.sendBatch(ctx, weekDate, content)
+		if err != nil {
+			return err
+		}
+		sent += n - f
+		failed += f
+		if n < digestBatchSize {
+			break
+		}
+	}
+
+	log.Printf("[DIGEST] Completed in %v: %d sent, %d failed", time.Since(startTime), sent, failed)
+	return nil
+}
+
+// compileDigest gathers the events, posts and house standings for the week
+// starting at now
+func (s *DigestService) compileDigest(ctx context.Context, now time.Time) (digestContent, error) {
+	var content digestContent
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT title, start_date, location
+		FROM events
+		WHERE deleted_at IS NULL AND start_date >= $1 AND start_date < $2
+		  AND alumni_access <> 'only'
+		ORDER BY start_date ASC
+		LIMIT 10
+	`, now, now.AddDate(0, 0, 7))
+	if err != nil {
+		return content, err
+	}
+	for rows.Next() {
+		var e digestEvent
+		if err := rows.Scan(&e.Title, &e.StartsAt, &e.Location); err != nil {
+			rows.Close()
+			return content, err
+		}
+		content.Events = append(content.Events, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return content, err
+	}
+
+	rows, err = s.db.QueryContext(ctx, `
+		SELECT description, COALESCE(like_count, 0), COALESCE(comment_count, 0)
+		FROM posts
+		WHERE deleted_at IS NULL AND NOT held_for_review
+		  AND status = 'published' AND published_at >= $1
+		  AND audience_department IS NULL AND audience_year IS NULL AND audience_house_id IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM shadow_bans WHERE user_id = posts.created_by)
+		ORDER BY COALESCE(like_count, 0) + COALESCE(comment_count, 0) DESC, published_at DESC
+		LIMIT 5
+	`, now.AddDate(0, 0, -7))
+	if err != nil {
+		return content, err
+	}
+	for rows.Next() {
+		var p digestPost
+		if err := rows.Scan(&p.Excerpt, &p.LikeCount, &p.CommentCount); err != nil {
+			rows.Close()
+			return content, err
+		}
+		p.Excerpt = excerpt(p.Excerpt, 140)
+		content.Posts = append(content.Posts, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return content, err
+	}
+
+	rows, err = s.db.QueryContext(ctx, `
+		SELECT name, COALESCE(points, 0)
+		FROM houses
+		WHERE deleted_at IS NULL
+		ORDER BY points DESC NULLS LAST, name ASC
+	`)
+	if err != nil {
+		return content, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var h digestHouse
+		if err := rows.Scan(&h.Name, &h.Points); err != nil {
+			return content, err
+		}
+		content.Houses = append(content.Houses, h)
+	}
+	return content, rows.Err()
+}
+
+// sendBatch claims up to digestBatchSize pending sends and delivers them.
+// Returns how many were claimed and how many of those failed.
+func (s *DigestService) sendBatch(ctx context.Context, weekDate string, content digestContent) (claimed, failed int, err error) {
+	// Sends for users who unsubscribed after being queued are never claimed
+	rows, err := s.db.QueryContext(ctx, `
+		UPDATE digest_sends d
+		SET status = 'sending', attempts = d.attempts + 1, error = NULL
+		FROM (
+			SELECT ds.id FROM digest_sends ds
+			JOIN digest_subscriptions sub ON sub.user_id = ds.user_id
+			WHERE ds.week_start = $1::date AND ds.status = 'pending'
+			ORDER BY ds.created_at
+			LIMIT $2
+			FOR UPDATE OF ds SKIP LOCKED
+		) p, digest_subscriptions s, users u
+		WHERE d.id = p.id AND s.user_id = d.user_id AND u.id = d.user_id
+		RETURNING d.id, u.email, u.full_name, s.unsubscribe_token
+	`, weekDate, digestBatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	type recipient struct {
+		sendID uuid.UUID
+		email  string
+		name   string
+		token  string
+	}
+	var recipients []recipient
+	for rows.Next() {
+		var r recipient
+		if err := rows.Scan(&r.sendID, &r.email, &r.name, &r.token); err != nil {
+			rows.Close()
+			return 0, 0, err
+		}
+		recipients = append(recipients, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	subject := "Your week on campus: " + content.WeekStart.Format("2 January")
+	for _, r := range recipients {
+		sendErr := s.sendOne(ctx, r.email, subject, digestData{
+			digestContent:  content,
+			Name:           r.name,
+			UnsubscribeURL: s.baseURL + "/api/v1/digest/unsubscribe?token=" + url.QueryEscape(r.token),
+		})
+
+		if sendErr != nil {
+			failed++
+			log.Printf("[DIGEST] Send %s failed: %v", r.sendID, sendErr)
+			_, err = s.db.ExecContext(ctx,
+				"UPDATE digest_sends SET status = 'failed', error = $2 WHERE id = $1", r.sendID, sendErr.Error())
+		} else {
+			_, err = s.db.ExecContext(ctx,
+				"UPDATE digest_sends SET status = 'sent', sent_at = NOW() WHERE id = $1", r.sendID)
+		}
+		if err != nil {
+			return len(recipients), failed, err
+		}
+	}
+	return len(recipients), failed, nil
+}
+
+func (s *DigestService) sendOne(ctx context.Context, to, subject string, data digestData) error {
+	var body bytes.Buffer
+	if err := digestTemplate.Execute(&body, data); err != nil {
+		return err
+	}
+	return s.email.SendEmail(ctx, to, subject, body.String())
+}
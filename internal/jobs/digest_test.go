@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+)
+
+// TestWeekStart verifies every day of a week maps to its Monday
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local)
+	for i := 0; i < 7; i++ {
+		day := monday.AddDate(0, 0, i).Add(15 * time.Hour)
+		if got := weekStart(day); !got.Equal(monday) {
+			t.Errorf("weekStart(%s) = %s, want %s", day.Format("Mon 2006-01-02"), got, monday)
+		}
+	}
+	if got := weekStart(monday.Add(-time.Minute)); !got.Equal(monday.AddDate(0, 0, -7)) {
+		t.Errorf("weekStart(Sunday 23:59) = %s, want previous Monday", got)
+	}
+}
+
+// TestExcerpt verifies long text is cut on a word boundary
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{in: "short post", n: 20, want: "short post"},
+		{in: "spaced   out\n\ntext", n: 20, want: "spaced out text"},
+		{in: "the quick brown fox jumps", n: 12, want: "the quick…"},
+		{in: "abcdefghij", n: 5, want: "abcde…"},
+	}
+
+	for _, tt := range tests {
+		if got := excerpt(tt.in, tt.n); got != tt.want {
+			t.Errorf("excerpt(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+		}
+	}
+}
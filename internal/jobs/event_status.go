@@ -0,0 +1,74 @@
+package jobs
+
+import (
+	"context"
+	"log"
+
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
+)
+
+// UpdateEventStatuses moves events between upcoming, ongoing and completed
+// by their dates, and queues notifications to the registrants of those that
+// just started or ended. An event moved into the future goes back to
+// upcoming quietly, and one whose start or end is over an hour past, after
+// downtime, changes without a notification.
+func (s *CleanupService) UpdateEventStatuses() error {
+	ctx := context.Background()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		UPDATE events
+		SET status = CASE WHEN end_date <= NOW() THEN 'completed'
+		                  WHEN start_date <= NOW() THEN 'ongoing'
+		                  ELSE 'upcoming' END
+		WHERE deleted_at IS NULL AND status IN ('upcoming', 'ongoing', 'completed')
+		  AND status <> CASE WHEN end_date <= NOW() THEN 'completed'
+		                     WHEN start_date <= NOW() THEN 'ongoing'
+		                     ELSE 'upcoming' END
+		RETURNING id, title, status,
+			COALESCE(CASE status WHEN 'ongoing' THEN start_date WHEN 'completed' THEN end_date END
+				>= NOW() - INTERVAL '1 hour', false)
+	`)
+	if err != nil {
+		return err
+	}
+	var changed []notifications.EventStatusChangedPayload
+	updated := 0
+	for rows.Next() {
+		var p notifications.EventStatusChangedPayload
+		var fresh bool
+		if err := rows.Scan(&p.EventID, &p.EventTitle, &p.Status, &fresh); err != nil {
+			rows.Close()
+			return err
+		}
+		updated++
+		if fresh && p.Status != models.EventStatusUpcoming {
+			changed = append(changed, p)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, p := range changed {
+		if err := outbox.Write(ctx, tx, notifications.TopicEventStatusChanged, p); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if updated > 0 {
+		log.Printf("[CLEANUP] Updated the status of %d events", updated)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
+)
+
+// PublishScheduledPosts publishes the scheduled posts that are due and
+// queues the notifications to their followers and audience. A post held
+// for review is published but its notifications wait for its approval.
+func (s *CleanupService) PublishScheduledPosts() error {
+	ctx := context.Background()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		UPDATE posts
+		SET status = 'published', published_at = NOW()
+		WHERE status = 'scheduled' AND publish_at <= NOW() AND deleted_at IS NULL
+		RETURNING id, held_for_review
+	`)
+	if err != nil {
+		return err
+	}
+	var notify []uuid.UUID
+	published := 0
+	for rows.Next() {
+		var id uuid.UUID
+		var held bool
+		if err := rows.Scan(&id, &held); err != nil {
+			rows.Close()
+			return err
+		}
+		published++
+		if !held {
+			notify = append(notify, id)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range notify {
+		if err := outbox.Write(ctx, tx, notifications.TopicPostPublished, notifications.PostPublishedPayload{PostID: id}); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if published > 0 {
+		log.Printf("[CLEANUP] Published %d scheduled posts", published)
+	}
+	return nil
+}
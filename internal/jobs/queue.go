@@ -0,0 +1,406 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+const (
+	// Retry backoff grows exponentially from baseBackoff up to maxBackoff
+	baseBackoff = 10 * time.Second
+	maxBackoff  = time.Hour
+
+	// A running job whose lock is older than this is assumed to belong to a
+	// crashed worker and is handed back to the queue
+	staleLockTimeout = 15 * time.Minute
+
+	// Upper bound on a single handler invocation
+	jobTimeout = 10 * time.Minute
+
+	// Completed jobs are pruned after this long
+	completedRetention = 7 * 24 * time.Hour
+)
+
+// HandlerFunc processes a single job payload. Returning an error schedules a
+// retry with backoff until the job's max_attempts is exhausted.
+type HandlerFunc func(ctx context.Context, payload json.RawMessage) error
+
+// Queue is a persistent Postgres-backed job queue with a worker pool
+type Queue struct {
+	db           *sql.DB
+	workers      int
+	pollInterval time.Duration
+
+	mu       sync.RWMutex
+	handlers map[string]HandlerFunc
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewQueue creates a new job queue
+func NewQueue(db *sql.DB, workers int, pollInterval time.Duration) *Queue {
+	if workers < 1 {
+		workers = 1
+	}
+	if pollInterval <= 0 {
+		pollInterval = 2 * time.Second
+	}
+	return &Queue{
+		db:           db,
+		workers:      workers,
+		pollInterval: pollInterval,
+		handlers:     make(map[string]HandlerFunc),
+		stop:         make(chan struct{}),
+	}
+}
+
+// Register associates a handler with a job type. Must be called before Start.
+func (q *Queue) Register(jobType string, fn HandlerFunc) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[jobType] = fn
+}
+
+// Enqueue schedules a job to run as soon as a worker is free
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload interface{}) (uuid.UUID, error) {
+	return q.EnqueueAt(ctx, jobType, payload, time.Now())
+}
+
+// EnqueueAt schedules a job to run no earlier than runAt
+func (q *Queue) EnqueueAt(ctx context.Context, jobType string, payload interface{}, runAt time.Time) (uuid.UUID, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to marshal job payload: %w", err)
+	}
+
+	var id uuid.UUID
+	err = q.db.QueryRowContext(ctx, `
+		INSERT INTO jobs (job_type, payload, run_at)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`, jobType, data, runAt).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to enqueue %s job: %w", jobType, err)
+	}
+	return id, nil
+}
+
+// Start launches the worker pool and the maintenance loop
+func (q *Queue) Start() {
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go q.worker(i)
+	}
+
+	q.wg.Add(1)
+	go q.maintenance()
+
+	log.Printf("[JOBS] Queue started with %d workers", q.workers)
+}
+
+// Stop signals workers to finish their current job and waits for them
+func (q *Queue) Stop() {
+	close(q.stop)
+	q.wg.Wait()
+	log.Println("[JOBS] Queue stopped")
+}
+
+func (q *Queue) worker(n int) {
+	defer q.wg.Done()
+
+	for {
+		select {
+		case <-q.stop:
+			return
+		default:
+		}
+
+		job, err := q.claim()
+		if err != nil {
+			log.Printf("[JOBS] Worker %d failed to claim job: %v", n, err)
+		}
+		if job == nil {
+			// Nothing runnable (or the claim failed); wait before polling again
+			select {
+			case <-q.stop:
+				return
+			case <-time.After(q.pollInterval):
+			}
+			continue
+		}
+
+		q.run(job)
+	}
+}
+
+// claim atomically locks the oldest runnable job. SKIP LOCKED lets multiple
+// workers (and multiple API instances) poll the same table without contention.
+func (q *Queue) claim() (*models.Job, error) {
+	var job models.Job
+	err := q.db.QueryRow(`
+		UPDATE jobs
+		SET status = 'running', attempts = attempts + 1, locked_at = NOW()
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE status = 'pending' AND run_at <= NOW()
+			ORDER BY run_at ASC
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING id, job_type, payload, attempts, max_attempts
+	`).Scan(&job.ID, &job.JobType, &job.Payload, &job.Attempts, &job.MaxAttempts)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (q *Queue) run(job *models.Job) {
+	q.mu.RLock()
+	handler, ok := q.handlers[job.JobType]
+	q.mu.RUnlock()
+
+	if !ok {
+		q.markDead(job, "no handler registered for job type "+job.JobType)
+		return
+	}
+
+	startTime := time.Now()
+	err := q.invoke(handler, job)
+	if err == nil {
+		_, dbErr := q.db.Exec(`
+			UPDATE jobs
+			SET status = 'completed', completed_at = NOW(), locked_at = NULL, last_error = NULL
+			WHERE id = $1
+		`, job.ID)
+		if dbErr != nil {
+			log.Printf("[JOBS] Failed to mark job %s completed: %v", job.ID, dbErr)
+		}
+		log.Printf("[JOBS] %s job %s completed in %.2fs", job.JobType, job.ID, time.Since(startTime).Seconds())
+		return
+	}
+
+	if job.Attempts >= job.MaxAttempts {
+		q.markDead(job, err.Error())
+		return
+	}
+
+	delay := backoff(job.Attempts)
+	_, dbErr := q.db.Exec(`
+		UPDATE jobs
+		SET status = 'pending', locked_at = NULL, last_error = $2, run_at = $3
+		WHERE id = $1
+	`, job.ID, err.Error(), time.Now().Add(delay))
+	if dbErr != nil {
+		log.Printf("[JOBS] Failed to reschedule job %s: %v", job.ID, dbErr)
+	}
+	log.Printf("[JOBS] %s job %s failed (attempt %d/%d), retrying in %s: %v",
+		job.JobType, job.ID, job.Attempts, job.MaxAttempts, delay, err)
+}
+
+// invoke runs the handler with a timeout, converting panics into errors so a
+// single bad job cannot take down a worker
+func (q *Queue) invoke(handler HandlerFunc, job *models.Job) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), jobTimeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return handler(ctx, job.Payload)
+}
+
+func (q *Queue) markDead(job *models.Job, reason string) {
+	_, err := q.db.Exec(`
+		UPDATE jobs SET status = 'dead', locked_at = NULL, last_error = $2
+		WHERE id = $1
+	`, job.ID, reason)
+	if err != nil {
+		log.Printf("[JOBS] Failed to dead-letter job %s: %v", job.ID, err)
+	}
+	log.Printf("[JOBS] %s job %s moved to dead-letter after %d attempts: %s",
+		job.JobType, job.ID, job.Attempts, reason)
+}
+
+// maintenance periodically releases stale locks and prunes completed jobs
+func (q *Queue) maintenance() {
+	defer q.wg.Done()
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.stop:
+			return
+		case <-ticker.C:
+		}
+
+		result, err := q.db.Exec(`
+			UPDATE jobs SET status = 'pending', locked_at = NULL
+			WHERE status = 'running' AND locked_at < $1
+		`, time.Now().Add(-staleLockTimeout))
+		if err != nil {
+			log.Printf("[JOBS] Failed to release stale jobs: %v", err)
+		} else if n, _ := result.RowsAffected(); n > 0 {
+			log.Printf("[JOBS] Released %d stale jobs back to the queue", n)
+		}
+
+		if _, err := q.db.Exec(`
+			DELETE FROM jobs WHERE status = 'completed' AND completed_at < $1
+		`, time.Now().Add(-completedRetention)); err != nil {
+			log.Printf("[JOBS] Failed to prune completed jobs: %v", err)
+		}
+	}
+}
+
+// backoff returns the delay before the next attempt, doubling per attempt
+func backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := baseBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
+
+// ============================================================================
+// ADMIN OPERATIONS
+// ============================================================================
+
+// List returns jobs matching the given filters, newest first
+func (q *Queue) List(ctx context.Context, query models.ListJobsQuery) ([]models.Job, int, error) {
+	where := "WHERE 1=1"
+	args := []interface{}{}
+
+	if query.Status != nil {
+		args = append(args, *query.Status)
+		where += " AND status = $" + strconv.Itoa(len(args))
+	}
+	if query.JobType != nil {
+		args = append(args, *query.JobType)
+		where += " AND job_type = $" + strconv.Itoa(len(args))
+	}
+
+	var total int
+	if err := q.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM jobs "+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, query.PageSize, (query.Page-1)*query.PageSize)
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT id, job_type, payload, status, attempts, max_attempts, last_error,
+		       run_at, locked_at, completed_at, created_at, updated_at
+		FROM jobs `+where+`
+		ORDER BY created_at DESC
+		LIMIT $`+strconv.Itoa(len(args)-1)+` OFFSET $`+strconv.Itoa(len(args)), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	jobs := []models.Job{}
+	for rows.Next() {
+		var j models.Job
+		if err := rows.Scan(
+			&j.ID, &j.JobType, &j.Payload, &j.Status, &j.Attempts, &j.MaxAttempts, &j.LastError,
+			&j.RunAt, &j.LockedAt, &j.CompletedAt, &j.CreatedAt, &j.UpdatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, total, rows.Err()
+}
+
+// Stats returns job counts grouped by status and type
+func (q *Queue) Stats(ctx context.Context) (*models.JobStats, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT job_type, status, COUNT(*) FROM jobs GROUP BY job_type, status
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := &models.JobStats{
+		ByStatus: map[string]int{},
+		ByType:   map[string]map[string]int{},
+	}
+	for rows.Next() {
+		var jobType, status string
+		var count int
+		if err := rows.Scan(&jobType, &status, &count); err != nil {
+			return nil, err
+		}
+		stats.ByStatus[status] += count
+		if stats.ByType[jobType] == nil {
+			stats.ByType[jobType] = map[string]int{}
+		}
+		stats.ByType[jobType][status] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var oldest sql.NullFloat64
+	err = q.db.QueryRowContext(ctx, `
+		SELECT EXTRACT(EPOCH FROM NOW() - MIN(run_at)) FROM jobs
+		WHERE status = 'pending' AND run_at <= NOW()
+	`).Scan(&oldest)
+	if err != nil {
+		return nil, err
+	}
+	if oldest.Valid {
+		secs := int(oldest.Float64)
+		stats.OldestPendingSecs = &secs
+	}
+
+	return stats, nil
+}
+
+// Retry moves a dead job back to the queue with a fresh attempt budget
+func (q *Queue) Retry(ctx context.Context, id uuid.UUID) (bool, error) {
+	result, err := q.db.ExecContext(ctx, `
+		UPDATE jobs
+		SET status = 'pending', attempts = 0, run_at = NOW(), locked_at = NULL
+		WHERE id = $1 AND status = 'dead'
+	`, id)
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, nil
+}
+
+// Discard permanently removes a dead job from the dead-letter list
+func (q *Queue) Discard(ctx context.Context, id uuid.UUID) (bool, error) {
+	result, err := q.db.ExecContext(ctx, `DELETE FROM jobs WHERE id = $1 AND status = 'dead'`, id)
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, nil
+}
@@ -0,0 +1,28 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+)
+
+// TestBackoff verifies retry delays double per attempt and are capped
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 0, want: 10 * time.Second},
+		{attempt: 1, want: 10 * time.Second},
+		{attempt: 2, want: 20 * time.Second},
+		{attempt: 3, want: 40 * time.Second},
+		{attempt: 6, want: 320 * time.Second},
+		{attempt: 10, want: time.Hour},
+		{attempt: 50, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := backoff(tt.attempt); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
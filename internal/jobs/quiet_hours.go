@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"log"
+
+	"github.com/robfig/cron/v3"
+	"github.com/yourusername/college-event-backend/internal/services/notifications"
+)
+
+// QuietHoursService delivers pushes held back during users' quiet hours
+type QuietHoursService struct {
+	notifier *notifications.Service
+	cron     *cron.Cron
+}
+
+// NewQuietHoursService creates a new quiet hours delivery service
+func NewQuietHoursService(notifier *notifications.Service) *QuietHoursService {
+	return &QuietHoursService{
+		notifier: notifier,
+		cron:     cron.New(),
+	}
+}
+
+// Start starts the cron job
+func (s *QuietHoursService) Start() {
+	// Morning summaries - every 5 minutes, so each user's summary follows
+	// shortly after their own quiet hours end
+	s.cron.AddFunc("*/5 * * * *", func() {
+		n, err := s.notifier.DeliverDeferred(context.Background())
+		if err != nil {
+			log.Printf("[CRON] Quiet hours delivery failed: %v", err)
+		} else if n > 0 {
+			log.Printf("[CRON] Quiet hours delivery sent %d summaries", n)
+		}
+	})
+
+	s.cron.Start()
+	log.Println("[CRON] Quiet hours service started")
+}
+
+// Stop stops the cron job
+func (s *QuietHoursService) Stop() {
+	s.cron.Stop()
+	log.Println("[CRON] Quiet hours service stopped")
+}
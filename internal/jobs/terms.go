@@ -0,0 +1,59 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/robfig/cron/v3"
+	"github.com/yourusername/college-event-backend/internal/services/terms"
+)
+
+// TermArchiveService archives academic terms once they end and graduates
+// the students whose academic year is over
+type TermArchiveService struct {
+	db   *sql.DB
+	cron *cron.Cron
+}
+
+// NewTermArchiveService creates a new term archival service
+func NewTermArchiveService(db *sql.DB) *TermArchiveService {
+	return &TermArchiveService{
+		db:   db,
+		cron: cron.New(),
+	}
+}
+
+// Start starts the cron job
+func (s *TermArchiveService) Start() {
+	// Term archival - daily at 00:30, the night after a term's last day
+	s.cron.AddFunc("30 0 * * *", func() {
+		n, err := terms.ArchiveEnded(context.Background(), s.db)
+		if err != nil {
+			log.Printf("[CRON] Term archival failed: %v", err)
+		}
+		if n > 0 {
+			log.Printf("[CRON] Archived %d academic terms", n)
+		}
+	})
+
+	// Graduation - daily at 00:45, after archival
+	s.cron.AddFunc("45 0 * * *", func() {
+		n, err := terms.Graduate(context.Background(), s.db)
+		if err != nil {
+			log.Printf("[CRON] Graduation failed: %v", err)
+		}
+		if n > 0 {
+			log.Printf("[CRON] Graduated %d students to alumni", n)
+		}
+	})
+
+	s.cron.Start()
+	log.Println("[CRON] Term archive service started")
+}
+
+// Stop stops the cron job
+func (s *TermArchiveService) Stop() {
+	s.cron.Stop()
+	log.Println("[CRON] Term archive service stopped")
+}
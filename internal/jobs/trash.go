@@ -0,0 +1,199 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/storage"
+)
+
+// Kinds of content that go to the trash when deleted
+const (
+	TrashEvent = "event"
+	TrashPost  = "post"
+	TrashStory = "story"
+)
+
+// trashTables maps each kind of trashed content to its table
+var trashTables = map[string]string{
+	TrashEvent: "events",
+	TrashPost:  "posts",
+	TrashStory: "stories",
+}
+
+// ErrNotInTrash is returned for content that doesn't exist or isn't deleted
+var ErrNotInTrash = errors.New("not in trash")
+
+// RestoreTrashed undeletes content in the trash. A restored story that has
+// expired meanwhile isn't shown again; the story cleanup deletes it.
+func RestoreTrashed(ctx context.Context, db *sql.DB, kind string, id uuid.UUID) error {
+	table, ok := trashTables[kind]
+	if !ok {
+		return fmt.Errorf("unknown trash kind %q", kind)
+	}
+	result, err := db.ExecContext(ctx,
+		"UPDATE "+table+" SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNotInTrash
+	}
+	return nil
+}
+
+// PurgeTrashed deletes content in the trash for good, along with its media.
+// Everything hanging off it (registrations, comments, likes...) goes with it.
+// Media that fails to delete is logged and left behind.
+func PurgeTrashed(ctx context.Context, db *sql.DB, store storage.StorageService, kind string, id uuid.UUID) error {
+	paths, err := purgeRow(ctx, db, kind, id)
+	if err != nil {
+		return err
+	}
+	for _, p := range paths {
+		if err := store.Delete(ctx, p); err != nil {
+			log.Printf("[TRASH] Failed to delete %s of %s %s: %v", p, kind, id, err)
+		}
+	}
+	return nil
+}
+
+// purgeRow deletes a trashed row and returns the storage paths of its media
+func purgeRow(ctx context.Context, db *sql.DB, kind string, id uuid.UUID) ([]string, error) {
+	table, ok := trashTables[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown trash kind %q", kind)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var paths []string
+	var urls []sql.NullString
+	if kind == TrashEvent {
+		// The photo rows go with the event, so find their files first
+		rows, err := tx.QueryContext(ctx, `
+			SELECT path, thumbnail_path FROM event_photos
+			WHERE event_id = $1 AND removed_at IS NULL
+		`, id)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var path, thumbnailPath string
+			if err := rows.Scan(&path, &thumbnailPath); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			paths = append(paths, path, thumbnailPath)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+
+		var bannerURL sql.NullString
+		err = tx.QueryRowContext(ctx,
+			"DELETE FROM events WHERE id = $1 AND deleted_at IS NOT NULL RETURNING banner_url", id,
+		).Scan(&bannerURL)
+		if err != nil {
+			return nil, notInTrash(err)
+		}
+		urls = append(urls, bannerURL)
+	} else {
+		var imageURL, videoURL, thumbnailURL sql.NullString
+		err = tx.QueryRowContext(ctx,
+			"DELETE FROM "+table+" WHERE id = $1 AND deleted_at IS NOT NULL RETURNING image_url, video_url, thumbnail_url", id,
+		).Scan(&imageURL, &videoURL, &thumbnailURL)
+		if err != nil {
+			return nil, notInTrash(err)
+		}
+		urls = append(urls, imageURL, videoURL, thumbnailURL)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	for _, u := range urls {
+		if u.Valid && u.String != "" {
+			paths = append(paths, extractPathFromURL(u.String))
+		}
+	}
+	return paths, nil
+}
+
+func notInTrash(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotInTrash
+	}
+	return err
+}
+
+// PurgeTrash deletes for good the content that has been in the trash longer
+// than the retention window
+func (s *CleanupService) PurgeTrash() error {
+	ctx := context.Background()
+	startTime := time.Now()
+
+	log.Println("[CLEANUP] Starting trash purge...")
+
+	cutoff := time.Now().Add(-s.settings.Current(ctx).TrashRetention())
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT 'event', id FROM events WHERE deleted_at <= $1
+		UNION ALL
+		SELECT 'post', id FROM posts WHERE deleted_at <= $1
+		UNION ALL
+		SELECT 'story', id FROM stories WHERE deleted_at <= $1
+	`, cutoff)
+	if err != nil {
+		return err
+	}
+
+	type due struct {
+		kind string
+		id   uuid.UUID
+	}
+	var items []due
+	for rows.Next() {
+		var d due
+		if err := rows.Scan(&d.kind, &d.id); err != nil {
+			rows.Close()
+			return err
+		}
+		items = append(items, d)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	purgedCount := 0
+	failedCount := 0
+
+	for _, d := range items {
+		err := PurgeTrashed(ctx, s.db, s.storage, d.kind, d.id)
+		if errors.Is(err, ErrNotInTrash) {
+			continue // Restored or purged since
+		}
+		if err != nil {
+			log.Printf("[CLEANUP] Failed to purge %s %s: %v", d.kind, d.id, err)
+			failedCount++
+			continue
+		}
+		purgedCount++
+	}
+
+	duration := time.Since(startTime)
+	log.Printf("[CLEANUP] Trash purge complete: %d purged, %d failed in %.2fs",
+		purgedCount, failedCount, duration.Seconds())
+
+	return nil
+}
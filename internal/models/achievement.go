@@ -0,0 +1,95 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ActivityPoint is a single entry in a user's points ledger
+type ActivityPoint struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	Source    string    `json:"source" db:"source"`
+	SourceID  uuid.UUID `json:"source_id" db:"source_id"`
+	Points    int       `json:"points" db:"points"`
+	Reason    *string   `json:"reason,omitempty" db:"reason"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Badge is an achievement granted when a user meets its rule. With a
+// RuleSource the user needs Threshold awards from that source; without one,
+// Threshold total points.
+type Badge struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	Code        string    `json:"code" db:"code"`
+	Name        string    `json:"name" db:"name"`
+	Description *string   `json:"description,omitempty" db:"description"`
+	IconURL     *string   `json:"icon_url,omitempty" db:"icon_url"`
+	RuleSource  *string   `json:"rule_source,omitempty" db:"rule_source"`
+	Threshold   int       `json:"threshold" db:"threshold"`
+	IsActive    bool      `json:"is_active" db:"is_active"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateBadgeRequest represents badge creation data
+type CreateBadgeRequest struct {
+	Code        string  `json:"code" binding:"required,max=50"`
+	Name        string  `json:"name" binding:"required,max=100"`
+	Description *string `json:"description"`
+	IconURL     *string `json:"icon_url"`
+	RuleSource  *string `json:"rule_source"`
+	Threshold   int     `json:"threshold" binding:"required,min=1"`
+}
+
+// UpdateBadgeRequest represents badge update data. Rules can't be changed
+// once created; deactivate the badge and create a new one instead.
+type UpdateBadgeRequest struct {
+	Name        *string `json:"name" binding:"omitempty,max=100"`
+	Description *string `json:"description"`
+	IconURL     *string `json:"icon_url"`
+	IsActive    *bool   `json:"is_active"`
+}
+
+// EarnedBadge is a badge the user holds
+type EarnedBadge struct {
+	Badge
+	AwardedAt time.Time `json:"awarded_at"`
+}
+
+// AchievementsResponse is the user's points and badges
+type AchievementsResponse struct {
+	TotalPoints    int             `json:"total_points"`
+	PointsBySource map[string]int  `json:"points_by_source"`
+	Badges         []EarnedBadge   `json:"badges"`
+	Recent         []ActivityPoint `json:"recent"`
+}
+
+// CompetitionWinRequest awards a competition win to a user and, optionally,
+// points to their house
+type CompetitionWinRequest struct {
+	UserID      uuid.UUID  `json:"user_id" binding:"required"`
+	Title       string     `json:"title" binding:"required,max=255"`
+	EventID     *uuid.UUID `json:"event_id"` // The competition; omit for one-off awards
+	Points      int        `json:"points" binding:"min=0"`
+	HousePoints int        `json:"house_points" binding:"min=0"`
+}
+
+// LeaderboardQuery scopes a leaderboard to a department or house, and to a
+// term: a term ID, "current" (the default) or "all"
+type LeaderboardQuery struct {
+	Department string `form:"department"`
+	HouseID    string `form:"house_id"`
+	Term       string `form:"term"`
+	Limit      int    `form:"limit"`
+}
+
+// LeaderboardEntry is a user's position on a leaderboard
+type LeaderboardEntry struct {
+	Rank       int       `json:"rank"`
+	UserID     uuid.UUID `json:"user_id"`
+	FullName   string    `json:"full_name"`
+	AvatarURL  *string   `json:"avatar_url,omitempty"`
+	Department *string   `json:"department,omitempty"`
+	Points     int       `json:"points"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Admin broadcast cohorts: who receives a broadcast
+const (
+	BroadcastCohortAllStudents      = "all_students"
+	BroadcastCohortDepartment       = "department"
+	BroadcastCohortYear             = "year"
+	BroadcastCohortHouse            = "house"
+	BroadcastCohortEventRegistrants = "event_registrants"
+)
+
+// AdminBroadcast is a message from admins to a cohort of users, and the log
+// of its delivery
+type AdminBroadcast struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	SentBy         *uuid.UUID `json:"sent_by,omitempty" db:"sent_by"`
+	Cohort         string     `json:"cohort" db:"cohort"`
+	Department     *string    `json:"department,omitempty" db:"department"`
+	Year           *int       `json:"year,omitempty" db:"year"`
+	HouseID        *uuid.UUID `json:"house_id,omitempty" db:"house_id"`
+	EventID        *uuid.UUID `json:"event_id,omitempty" db:"event_id"`
+	Title          string     `json:"title" db:"title"`
+	Body           string     `json:"body" db:"body"`
+	SendEmail      bool       `json:"send_email" db:"send_email"`
+	ChatMessageID  *uuid.UUID `json:"chat_message_id,omitempty" db:"chat_message_id"`
+	RecipientCount *int       `json:"recipient_count" db:"recipient_count"` // Null until delivered
+	DeliveredCount *int       `json:"delivered_count" db:"delivered_count"`
+	FailedCount    *int       `json:"failed_count" db:"failed_count"`
+	ReadCount      int        `json:"read_count" db:"read_count"`
+	DeliveredAt    *time.Time `json:"delivered_at" db:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
+
+// CreateAdminBroadcastRequest represents a broadcast to a cohort. Department,
+// Year, HouseID or EventID names the cohort's department, year, house or
+// event. PostToChat also posts the broadcast to a house's chat channel.
+type CreateAdminBroadcastRequest struct {
+	Cohort     string     `json:"cohort" binding:"required,oneof=all_students department year house event_registrants"`
+	Department *string    `json:"department" binding:"omitempty,min=1,max=100"`
+	Year       *int       `json:"year" binding:"omitempty,min=1,max=10"`
+	HouseID    *uuid.UUID `json:"house_id"`
+	EventID    *uuid.UUID `json:"event_id"`
+	Title      string     `json:"title" binding:"required,max=200"`
+	Body       string     `json:"body" binding:"required,min=1,max=2000"`
+	SendEmail  bool       `json:"send_email"`
+	PostToChat bool       `json:"post_to_chat"`
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditLogEntry is a recorded security-relevant action
+type AuditLogEntry struct {
+	ID            uuid.UUID       `json:"id" db:"id"`
+	Action        string          `json:"action" db:"action"`
+	ActorID       *uuid.UUID      `json:"actor_id,omitempty" db:"actor_id"`
+	SubjectUserID *uuid.UUID      `json:"subject_user_id,omitempty" db:"subject_user_id"`
+	SessionID     *uuid.UUID      `json:"session_id,omitempty" db:"session_id"`
+	Method        *string         `json:"method,omitempty" db:"method"`
+	Path          *string         `json:"path,omitempty" db:"path"`
+	Status        *int            `json:"status,omitempty" db:"status"`
+	RequestID     *string         `json:"request_id,omitempty" db:"request_id"`
+	IPAddress     *string         `json:"ip_address,omitempty" db:"ip_address"`
+	Details       json.RawMessage `json:"details,omitempty" db:"details"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+}
+
+// ListAuditLogQuery filters the audit log
+type ListAuditLogQuery struct {
+	Action        string `form:"action"`
+	ActorID       string `form:"actor_id"`
+	SubjectUserID string `form:"subject_user_id"`
+	SessionID     string `form:"session_id"`
+	Page          int    `form:"page"`
+	PageSize      int    `form:"page_size"`
+}
+
+// StartImpersonationRequest opens a support session as another user
+type StartImpersonationRequest struct {
+	Reason          string `json:"reason" binding:"required,max=500"`
+	AllowWrites     bool   `json:"allow_writes"`     // Sessions are read-only unless set
+	DurationMinutes int    `json:"duration_minutes"` // Default 15, at most 60
+	// Scopes optionally limit the session further, e.g. ["read:events"]
+	Scopes []string `json:"scopes"`
+}
+
+// ImpersonationResponse carries the impersonation token. It has no refresh
+// token and must not replace the admin's own session in the client.
+type ImpersonationResponse struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	SessionID   uuid.UUID `json:"session_id"`
+	ReadOnly    bool      `json:"read_only"`
+	Scope       string    `json:"scope,omitempty"`
+	User        User      `json:"user"`
+}
+
+// User activity types, for a user's activity timeline
+const (
+	ActivityRegistration = "registration"
+	ActivityPayment      = "payment"
+	ActivityPost         = "post"
+	ActivityComment      = "comment"
+	ActivityLogin        = "login"
+	ActivityAudit        = "audit" // Actions taken on the user, e.g. mutes
+)
+
+// UserActivity is an entry in a user's activity timeline. RefID is the
+// registration's event, or the payment, post, comment or audit entry.
+type UserActivity struct {
+	Type    string          `json:"type"`
+	At      time.Time       `json:"at"`
+	Summary string          `json:"summary"`
+	RefID   *uuid.UUID      `json:"ref_id,omitempty"`
+	Details json.RawMessage `json:"details,omitempty"`
+}
+
+// ListUserActivityQuery filters a user's activity timeline. Types is a
+// comma-separated list of activity types.
+type ListUserActivityQuery struct {
+	Types    string     `form:"types"`
+	From     *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To       *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Page     int        `form:"page"`
+	PageSize int        `form:"page_size"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Backup is a pg_dump of the database taken by cmd/backup
+type Backup struct {
+	ID uuid.UUID `json:"id" db:"id"`
+	// Status is requested, running, completed, failed, or deleted once it
+	// fell out of the retention
+	Status          string     `json:"status" db:"status"`
+	Source          string     `json:"source" db:"source"` // schedule, manual or admin
+	RequestedBy     *uuid.UUID `json:"requested_by,omitempty" db:"requested_by"`
+	StorageProvider *string    `json:"storage_provider,omitempty" db:"storage_provider"`
+	Path            *string    `json:"path,omitempty" db:"path"`
+	SizeBytes       *int64     `json:"size_bytes,omitempty" db:"size_bytes"`
+	Error           *string    `json:"error,omitempty" db:"error"`
+	StartedAt       *time.Time `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ListBackupsQuery pages through backups, newest first
+type ListBackupsQuery struct {
+	Status   string `form:"status"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Resource is a bookable projector, auditorium, sound system, ...
+type Resource struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Kind        string    `json:"kind" db:"kind"` // projector, auditorium, sound_system, other
+	Description *string   `json:"description,omitempty" db:"description"`
+	Location    *string   `json:"location,omitempty" db:"location"`
+	Capacity    *int      `json:"capacity,omitempty" db:"capacity"`
+	IsActive    bool      `json:"is_active" db:"is_active"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateResourceRequest represents resource creation data
+type CreateResourceRequest struct {
+	Name        string  `json:"name" binding:"required,max=255"`
+	Kind        string  `json:"kind" binding:"required,oneof=projector auditorium sound_system other"`
+	Description *string `json:"description"`
+	Location    *string `json:"location" binding:"omitempty,max=255"`
+	Capacity    *int    `json:"capacity" binding:"omitempty,min=1"`
+}
+
+// UpdateResourceRequest represents resource update data
+type UpdateResourceRequest struct {
+	Name        *string `json:"name" binding:"omitempty,max=255"`
+	Description *string `json:"description"`
+	Location    *string `json:"location" binding:"omitempty,max=255"`
+	Capacity    *int    `json:"capacity" binding:"omitempty,min=1"`
+	IsActive    *bool   `json:"is_active"`
+}
+
+// Booking statuses
+const (
+	BookingPending   = "pending"
+	BookingApproved  = "approved"
+	BookingRejected  = "rejected"
+	BookingCancelled = "cancelled"
+)
+
+// ResourceBooking is a club's request to use a resource for a time slot
+type ResourceBooking struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	ResourceID  uuid.UUID  `json:"resource_id" db:"resource_id"`
+	ClubID      uuid.UUID  `json:"club_id" db:"club_id"`
+	EventID     *uuid.UUID `json:"event_id,omitempty" db:"event_id"`
+	RequestedBy *uuid.UUID `json:"requested_by,omitempty" db:"requested_by"`
+	StartsAt    time.Time  `json:"starts_at" db:"starts_at"`
+	EndsAt      time.Time  `json:"ends_at" db:"ends_at"`
+	Purpose     *string    `json:"purpose,omitempty" db:"purpose"`
+	Status      string     `json:"status" db:"status"`
+	ReviewedBy  *uuid.UUID `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	ReviewNote  *string    `json:"review_note,omitempty" db:"review_note"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// CreateBookingRequest represents a booking request by a club lead
+type CreateBookingRequest struct {
+	ResourceID uuid.UUID  `json:"resource_id" binding:"required"`
+	EventID    *uuid.UUID `json:"event_id"`
+	StartsAt   time.Time  `json:"starts_at" binding:"required"`
+	EndsAt     time.Time  `json:"ends_at" binding:"required"`
+	Purpose    *string    `json:"purpose"`
+}
+
+// ReviewBookingRequest approves or rejects a pending booking
+type ReviewBookingRequest struct {
+	Status string  `json:"status" binding:"required,oneof=approved rejected"`
+	Note   *string `json:"note"`
+}
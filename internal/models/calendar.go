@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalendarItemType discriminates entries in a calendar view
+type CalendarItemType string
+
+const (
+	CalendarItemEvent                CalendarItemType = "event"
+	CalendarItemClubAnnouncement     CalendarItemType = "club_announcement"
+	CalendarItemHouseEvent           CalendarItemType = "house_event"
+	CalendarItemSchedule             CalendarItemType = "schedule"
+	CalendarItemRegistrationDeadline CalendarItemType = "registration_deadline"
+	CalendarItemBooking              CalendarItemType = "booking"         // Approved resource booking
+	CalendarItemBookingRequest       CalendarItemType = "booking_request" // Pending resource booking
+)
+
+// CalendarItem is a single entry on a calendar day. All-day items (dates
+// without a time) have AllDay set and no StartsAt.
+type CalendarItem struct {
+	Type     CalendarItemType `json:"type"`
+	ID       uuid.UUID        `json:"id"`
+	Title    string           `json:"title"`
+	StartsAt *time.Time       `json:"starts_at,omitempty"`
+	EndsAt   *time.Time       `json:"ends_at,omitempty"`
+	AllDay   bool             `json:"all_day"`
+	Location *string          `json:"location,omitempty"`
+	ClubID   *uuid.UUID       `json:"club_id,omitempty"`
+	HouseID  *uuid.UUID       `json:"house_id,omitempty"`
+}
+
+// ClubCalendarResponse is a month of a club's activity keyed by date (YYYY-MM-DD)
+type ClubCalendarResponse struct {
+	ClubID uuid.UUID                 `json:"club_id"`
+	Month  string                    `json:"month"`
+	Days   map[string][]CalendarItem `json:"days"`
+}
+
+// CampusCalendarResponse is every campus activity between two inclusive
+// dates keyed by date (YYYY-MM-DD)
+type CampusCalendarResponse struct {
+	From string                    `json:"from"`
+	To   string                    `json:"to"`
+	Days map[string][]CalendarItem `json:"days"`
+}
+
+// ResourceAvailabilityResponse is a resource's bookings between two inclusive
+// dates keyed by date (YYYY-MM-DD)
+type ResourceAvailabilityResponse struct {
+	ResourceID uuid.UUID                 `json:"resource_id"`
+	From       string                    `json:"from"`
+	To         string                    `json:"to"`
+	Days       map[string][]CalendarItem `json:"days"`
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Campaign statuses
+const (
+	CampaignActive = "active"
+	CampaignClosed = "closed"
+)
+
+// Campaign is a club's fundraising drive
+type Campaign struct {
+	ID           uuid.UUID        `json:"id"`
+	ClubID       uuid.UUID        `json:"club_id"`
+	ClubName     string           `json:"club_name"`
+	Title        string           `json:"title"`
+	Description  *string          `json:"description,omitempty"`
+	TargetAmount float64          `json:"target_amount"`
+	Currency     string           `json:"currency"`
+	EndsAt       *time.Time       `json:"ends_at,omitempty"`
+	Status       string           `json:"status"` // active, closed
+	CreatedBy    *uuid.UUID       `json:"created_by,omitempty"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+	Progress     CampaignProgress `json:"progress"`
+}
+
+// CampaignProgress is what a campaign has raised, for its progress bar
+type CampaignProgress struct {
+	Raised    float64 `json:"raised"`
+	Donations int     `json:"donations"`
+	// Percent of the target raised; over 100 once the target is passed
+	Percent float64 `json:"percent"`
+}
+
+// Open reports whether the campaign takes donations at now
+func (c Campaign) Open(now time.Time) bool {
+	return c.Status == CampaignActive && (c.EndsAt == nil || now.Before(*c.EndsAt))
+}
+
+// CreateCampaignRequest starts a fundraising campaign for a club
+type CreateCampaignRequest struct {
+	Title        string     `json:"title" binding:"required,max=200"`
+	Description  *string    `json:"description"`
+	TargetAmount float64    `json:"target_amount" binding:"required,gt=0"`
+	Currency     string     `json:"currency" binding:"omitempty,len=3"` // Defaults to INR
+	EndsAt       *time.Time `json:"ends_at"`
+}
+
+// UpdateCampaignRequest changes a campaign; omitted fields are unchanged
+type UpdateCampaignRequest struct {
+	Title        *string    `json:"title" binding:"omitempty,max=200"`
+	Description  *string    `json:"description"`
+	TargetAmount *float64   `json:"target_amount" binding:"omitempty,gt=0"`
+	EndsAt       *time.Time `json:"ends_at"`
+	Status       *string    `json:"status" binding:"omitempty,oneof=active closed"`
+}
+
+// Donation is a paid donation as shown publicly. Anonymous donors go
+// unnamed.
+type Donation struct {
+	ID        uuid.UUID `json:"id"`
+	DonorName *string   `json:"donor_name,omitempty"`
+	Amount    float64   `json:"amount"`
+	Currency  string    `json:"currency"`
+	Anonymous bool      `json:"anonymous"`
+	Message   *string   `json:"message,omitempty"`
+	PaidAt    time.Time `json:"paid_at"`
+}
+
+// Donor is a row of a campaign's donor report. Anonymous donors go unnamed
+// here too.
+type Donor struct {
+	DonationID        uuid.UUID `json:"donation_id"`
+	FullName          *string   `json:"full_name,omitempty"`
+	Email             *string   `json:"email,omitempty"`
+	Amount            float64   `json:"amount"`
+	Currency          string    `json:"currency"`
+	Anonymous         bool      `json:"anonymous"`
+	Message           *string   `json:"message,omitempty"`
+	RazorpayPaymentID string    `json:"razorpay_payment_id"`
+	PaidAt            time.Time `json:"paid_at"`
+}
+
+// DonateRequest starts a donation to a campaign
+type DonateRequest struct {
+	Amount    float64 `json:"amount" binding:"required,gte=1"`
+	Anonymous bool    `json:"anonymous"`
+	Message   *string `json:"message" binding:"omitempty,max=500"`
+}
+
+// DonationOrderResponse is the Razorpay order that completes a donation
+type DonationOrderResponse struct {
+	DonationID uuid.UUID `json:"donation_id"`
+	OrderID    string    `json:"order_id"`
+	Amount     int       `json:"amount"` // Amount in paise
+	Currency   string    `json:"currency"`
+	KeyID      string    `json:"key_id"`
+	CampaignID string    `json:"campaign_id"`
+}
+
+// VerifyDonationRequest completes a donation
+type VerifyDonationRequest struct {
+	RazorpayOrderID   string `json:"razorpay_order_id" binding:"required"`
+	RazorpayPaymentID string `json:"razorpay_payment_id" binding:"required"`
+	RazorpaySignature string `json:"razorpay_signature" binding:"required"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCampaignOpen(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	before, after := now.Add(-time.Hour), now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		campaign Campaign
+		open     bool
+	}{
+		{"active, no end", Campaign{Status: CampaignActive}, true},
+		{"active, ends later", Campaign{Status: CampaignActive, EndsAt: &after}, true},
+		{"active, ended", Campaign{Status: CampaignActive, EndsAt: &before}, false},
+		{"ends now", Campaign{Status: CampaignActive, EndsAt: &now}, false},
+		{"closed", Campaign{Status: CampaignClosed, EndsAt: &after}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.campaign.Open(now); got != tt.open {
+				t.Errorf("Open = %v, want %v", got, tt.open)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reasons a registrant is not eligible for a certificate
+const (
+	IneligibleNotCheckedIn = "not_checked_in"
+)
+
+// CertificateRules decide which of an event's registrants are eligible for
+// a participation certificate. Every registrant is by default.
+type CertificateRules struct {
+	// RequireCheckIn limits certificates to registrants checked in at the event
+	RequireCheckIn bool `json:"require_check_in"`
+}
+
+// EventCheckIn records a registrant checked in at an event
+type EventCheckIn struct {
+	EventID     uuid.UUID `json:"event_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	CheckedInAt time.Time `json:"checked_in_at"`
+}
+
+// IneligibleRegistrant is a registrant not eligible for a certificate, and why
+type IneligibleRegistrant struct {
+	UserID       uuid.UUID `json:"user_id"`
+	FullName     string    `json:"full_name"`
+	Email        string    `json:"email"`
+	RegisteredAt time.Time `json:"registered_at"`
+	Reason       string    `json:"reason"`
+}
+
+// CertificateEligibility reports which of an event's registrants are
+// eligible for a certificate under its rules
+type CertificateEligibility struct {
+	Rules      CertificateRules       `json:"rules"`
+	Registered int                    `json:"registered"`
+	CheckedIn  int                    `json:"checked_in"`
+	Eligible   int                    `json:"eligible"`
+	Ineligible []IneligibleRegistrant `json:"ineligible"`
+}
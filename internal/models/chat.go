@@ -0,0 +1,110 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChatChannel is the group chat of a house or a club, as seen by one user
+type ChatChannel struct {
+	ID      uuid.UUID  `json:"id"`
+	HouseID *uuid.UUID `json:"house_id,omitempty"`
+	ClubID  *uuid.UUID `json:"club_id,omitempty"`
+	Name    string     `json:"name"` // The house or club's name
+	// UnreadCount counts others' messages since the user last read the
+	// channel
+	UnreadCount int                 `json:"unread_count"`
+	LastMessage *ChatChannelMessage `json:"last_message,omitempty"`
+	LastReadAt  *time.Time          `json:"last_read_at,omitempty"`
+	CanModerate bool                `json:"can_moderate"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+// ChatChannelMessage is a message posted in a house or club channel
+type ChatChannelMessage struct {
+	ID        uuid.UUID  `json:"id"`
+	ChannelID uuid.UUID  `json:"channel_id"`
+	UserID    *uuid.UUID `json:"user_id,omitempty"`
+	UserName  string     `json:"user_name"`
+	AvatarURL string     `json:"avatar_url"`
+	Content   string     `json:"content"`
+	PinnedAt  *time.Time `json:"pinned_at,omitempty"`
+	PinnedBy  *uuid.UUID `json:"pinned_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	Attachments []ChatAttachment `json:"attachments,omitempty"`
+}
+
+// ChatAttachment is an image or file sent with a channel message
+type ChatAttachment struct {
+	ID           uuid.UUID `json:"id"`
+	Kind         string    `json:"kind"` // image or file
+	FileName     string    `json:"file_name"`
+	ContentType  string    `json:"content_type"`
+	URL          string    `json:"url"`
+	ThumbnailURL *string   `json:"thumbnail_url,omitempty"`
+	Width        *int      `json:"width,omitempty"`
+	Height       *int      `json:"height,omitempty"`
+	SizeBytes    int64     `json:"size_bytes"`
+}
+
+// PostChatMessageRequest posts a message to a channel. Messages with
+// attachments are sent as multipart/form-data, where content may be empty.
+type PostChatMessageRequest struct {
+	Content string `json:"content" form:"content" binding:"max=2000"`
+}
+
+// ListChatMessagesQuery pages back through a channel's messages, newest
+// first
+type ListChatMessagesQuery struct {
+	Before *time.Time `form:"before" time_format:"2006-01-02T15:04:05Z07:00"` // Messages older than this
+	Limit  int        `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// Chat event types sent over the chat WebSocket
+const (
+	ChatEventMessage   = "message"
+	ChatEventTyping    = "typing"
+	ChatEventDelivered = "delivered"
+	ChatEventRead      = "read"
+)
+
+// ChatEvent is sent to the members connected to a channel. Typing events
+// are never stored: clients show them for ExpiresIn seconds unless
+// repeated. Delivered and read events move a member's receipt up to UpTo.
+type ChatEvent struct {
+	Type      string              `json:"type"`
+	ChannelID uuid.UUID           `json:"channel_id"`
+	UserID    *uuid.UUID          `json:"user_id,omitempty"`
+	UserName  string              `json:"user_name,omitempty"`
+	Message   *ChatChannelMessage `json:"message,omitempty"`
+	UpTo      *time.Time          `json:"up_to,omitempty"`
+	ExpiresIn int                 `json:"expires_in,omitempty"`
+}
+
+// ChatClientFrame is sent by a client over the chat WebSocket: typing in a
+// channel, delivered up to a message, or read a channel
+type ChatClientFrame struct {
+	Type      string     `json:"type"`
+	ChannelID uuid.UUID  `json:"channel_id"`
+	MessageID *uuid.UUID `json:"message_id,omitempty"` // For delivered
+}
+
+// ChatMessageReceipts is who among a channel's members has received and
+// read a message
+type ChatMessageReceipts struct {
+	MessageID      uuid.UUID         `json:"message_id"`
+	MemberCount    int               `json:"member_count"` // Excluding the author
+	DeliveredCount int               `json:"delivered_count"`
+	ReadCount      int               `json:"read_count"`
+	Read           []ChatReceiptUser `json:"read"`
+	Delivered      []ChatReceiptUser `json:"delivered"` // Received but not read yet
+}
+
+// ChatReceiptUser is a member in a message's receipts
+type ChatReceiptUser struct {
+	UserID   uuid.UUID `json:"user_id"`
+	FullName string    `json:"full_name"`
+	At       time.Time `json:"at"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClubMeeting is a club meeting members can check in to
+type ClubMeeting struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	ClubID        uuid.UUID  `json:"club_id" db:"club_id"`
+	Title         string     `json:"title" db:"title"`
+	Description   *string    `json:"description,omitempty" db:"description"`
+	Location      *string    `json:"location,omitempty" db:"location"`
+	StartsAt      time.Time  `json:"starts_at" db:"starts_at"`
+	CreatedBy     *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+	AttendeeCount int        `json:"attendee_count" db:"attendee_count"`
+}
+
+// CreateClubMeetingRequest represents meeting creation data
+type CreateClubMeetingRequest struct {
+	Title       string    `json:"title" binding:"required,max=255"`
+	Description *string   `json:"description"`
+	Location    *string   `json:"location"`
+	StartsAt    time.Time `json:"starts_at" binding:"required"`
+}
+
+// OpenCheckInRequest opens a meeting's check-in window
+type OpenCheckInRequest struct {
+	ValidMinutes int `json:"valid_minutes"` // Defaults to 10, at most 60
+}
+
+// CheckInCode is a meeting's current check-in code. QRPayload encodes the
+// same code for the app to render as a QR code.
+type CheckInCode struct {
+	MeetingID uuid.UUID `json:"meeting_id"`
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+	QRPayload string    `json:"qr_payload"`
+}
+
+// CheckInRequest is a member checking in to a meeting
+type CheckInRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// MemberAttendance is one member's attendance across a club's meetings
+type MemberAttendance struct {
+	UserID        uuid.UUID   `json:"user_id"`
+	FullName      string      `json:"full_name"`
+	Email         string      `json:"email"`
+	Attended      int         `json:"attended"`
+	TotalMeetings int         `json:"total_meetings"`
+	MeetingIDs    []uuid.UUID `json:"meeting_ids"` // Meetings the member checked in to
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClubReview is a member's or attendee's review of a club. A review the
+// content filter flagged is held, and seen only by its author, until a
+// moderator approves it.
+type ClubReview struct {
+	ID            uuid.UUID   `json:"id"`
+	ClubID        uuid.UUID   `json:"club_id"`
+	Rating        int         `json:"rating"` // 1 to 5 stars
+	Body          string      `json:"body"`
+	HeldForReview bool        `json:"held_for_review"`
+	Author        UserSummary `json:"author"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// ClubReviewRequest writes the caller's review of a club, replacing any
+// they wrote before
+type ClubReviewRequest struct {
+	Rating int    `json:"rating" binding:"required,min=1,max=5"`
+	Body   string `json:"body" binding:"max=2000"`
+}
+
+// ListClubReviewsQuery pages through a club's reviews, newest first
+type ListClubReviewsQuery struct {
+	Page     int `form:"page"`
+	PageSize int `form:"page_size"`
+}
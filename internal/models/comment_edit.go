@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CommentEdit is one edit of a comment: the content it replaced
+type CommentEdit struct {
+	ID              uuid.UUID  `json:"id" db:"id"`
+	PreviousContent string     `json:"previous_content" db:"previous_content"`
+	EditedBy        *uuid.UUID `json:"edited_by,omitempty" db:"edited_by"`
+	EditedAt        time.Time  `json:"edited_at" db:"edited_at"`
+}
+
+// EditedComment is a comment after an edit
+type EditedComment struct {
+	ID       uuid.UUID `json:"id"`
+	Content  string    `json:"content"`
+	EditedAt time.Time `json:"edited_at"`
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HouseCompetition is a competition contested by every house, each through
+// its own linked house event
+type HouseCompetition struct {
+	ID                uuid.UUID  `json:"id" db:"id"`
+	Title             string     `json:"title" db:"title"`
+	Description       *string    `json:"description,omitempty" db:"description"`
+	EventDate         time.Time  `json:"event_date" db:"event_date"`
+	Venue             *string    `json:"venue,omitempty" db:"venue"`
+	TermID            *uuid.UUID `json:"term_id,omitempty" db:"term_id"`
+	ResultsRecordedAt *time.Time `json:"results_recorded_at,omitempty" db:"results_recorded_at"`
+	CreatedBy         *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// HouseCompetitionDetail is a competition with each house's event and, once
+// recorded, score
+type HouseCompetitionDetail struct {
+	HouseCompetition
+	Houses []CompetitionHouse `json:"houses"`
+}
+
+// CompetitionHouse is one house's part in a competition
+type CompetitionHouse struct {
+	HouseID         uuid.UUID  `json:"house_id"`
+	Name            string     `json:"name"`
+	Color           *string    `json:"color,omitempty"`
+	HouseEventID    *uuid.UUID `json:"house_event_id,omitempty"`
+	EnrollmentCount int        `json:"enrollment_count"`
+	Position        *int       `json:"position,omitempty"`
+	Score           *float64   `json:"score,omitempty"`
+	Points          *int       `json:"points,omitempty"`
+}
+
+// CreateHouseCompetitionRequest creates a competition and an event for it in
+// every house
+type CreateHouseCompetitionRequest struct {
+	CreateHouseEventRequest
+}
+
+// HouseScoreRequest is one house's result in a competition
+type HouseScoreRequest struct {
+	HouseID  uuid.UUID `json:"house_id" binding:"required"`
+	Position int       `json:"position" binding:"required,min=1"`
+	Score    *float64  `json:"score"`
+	Points   *int      `json:"points" binding:"omitempty,min=0"` // Omit for the position default
+}
+
+// RecordHouseScoresRequest records every house's result in a competition at
+// once
+type RecordHouseScoresRequest struct {
+	Scores []HouseScoreRequest `json:"scores" binding:"required,min=1,max=20,dive"`
+}
+
+// HouseSeasonStanding is a house's place in a season of competitions
+type HouseSeasonStanding struct {
+	Rank         int       `json:"rank"`
+	HouseID      uuid.UUID `json:"house_id"`
+	Name         string    `json:"name"`
+	Color        *string   `json:"color,omitempty"`
+	Points       int       `json:"points"`
+	Wins         int       `json:"wins"`
+	Competitions int       `json:"competitions"`
+}
+
+// HouseSeasonStandings ranks the houses by competition points in a term, or
+// across all time when TermID is nil
+type HouseSeasonStandings struct {
+	TermID *uuid.UUID            `json:"term_id,omitempty"`
+	Houses []HouseSeasonStanding `json:"houses"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "github.com/google/uuid"
+
+// DashboardHouse is the user's house and its position on the leaderboard
+type DashboardHouse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Color       *string   `json:"color,omitempty"`
+	Points      int       `json:"points"`
+	Rank        int       `json:"rank"`
+	TotalHouses int       `json:"total_houses"`
+}
+
+// DashboardResponse is everything the app's home screen needs on launch
+type DashboardResponse struct {
+	UpcomingEvents      []Event            `json:"upcoming_events"`
+	TodaySchedules      []Schedule         `json:"today_schedules"`
+	UnpaidOrders        []EventPayment     `json:"unpaid_orders"`
+	UnreadNotifications int                `json:"unread_notifications"`
+	House               *DashboardHouse    `json:"house"` // null if the user has no house
+	ClubAnnouncements   []ClubAnnouncement `json:"club_announcements"`
+}
@@ -0,0 +1,26 @@
+package models
+
+// DigestSubscription is the current user's weekly digest opt-in
+type DigestSubscription struct {
+	Subscribed bool `json:"subscribed"`
+}
+
+// UpdateDigestSubscriptionRequest opts in to or out of the weekly digest
+type UpdateDigestSubscriptionRequest struct {
+	Subscribed *bool `json:"subscribed" binding:"required"`
+}
+
+// DigestWeekStats counts a week's digest sends by status
+type DigestWeekStats struct {
+	WeekStart string `json:"week_start"`
+	Pending   int    `json:"pending"`
+	Sending   int    `json:"sending"`
+	Sent      int    `json:"sent"`
+	Failed    int    `json:"failed"`
+}
+
+// DigestStatsResponse is the admin view of digest delivery
+type DigestStatsResponse struct {
+	Subscribers int               `json:"subscribers"`
+	Weeks       []DigestWeekStats `json:"weeks"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Broadcast segments: which of an event's registered attendees receive it
+const (
+	BroadcastSegmentAll  = "all"
+	BroadcastSegmentPaid = "paid" // Registrants with a captured payment for the event
+)
+
+// EventBroadcast is a message from an event's organizers to its attendees,
+// and the log of its delivery
+type EventBroadcast struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	EventID        uuid.UUID  `json:"event_id" db:"event_id"`
+	SentBy         *uuid.UUID `json:"sent_by,omitempty" db:"sent_by"`
+	Segment        string     `json:"segment" db:"segment"`
+	Title          string     `json:"title" db:"title"`
+	Body           string     `json:"body" db:"body"`
+	SendEmail      bool       `json:"send_email" db:"send_email"`
+	RecipientCount *int       `json:"recipient_count" db:"recipient_count"` // Null until delivered
+	DeliveredAt    *time.Time `json:"delivered_at" db:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
+
+// CreateEventBroadcastRequest represents a broadcast to an event's attendees.
+// Title defaults to the event's title, Segment to all and SendEmail to true.
+type CreateEventBroadcastRequest struct {
+	Title     string `json:"title" binding:"max=200"`
+	Body      string `json:"body" binding:"required,min=1,max=2000"`
+	Segment   string `json:"segment" binding:"omitempty,oneof=all paid"`
+	SendEmail *bool  `json:"send_email"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventPhoto is a photo in an event's gallery. URL is the full-size photo
+// for download; ThumbnailURL is for browsing.
+type EventPhoto struct {
+	ID           uuid.UUID  `json:"id"`
+	EventID      uuid.UUID  `json:"event_id"`
+	URL          string     `json:"url"`
+	ThumbnailURL string     `json:"thumbnail_url"`
+	Width        int        `json:"width"`
+	Height       int        `json:"height"`
+	SizeBytes    int64      `json:"size_bytes"`
+	UploadedBy   *uuid.UUID `json:"uploaded_by,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// PhotoUploadFailure is a file from a bulk upload that wasn't added
+type PhotoUploadFailure struct {
+	Filename string `json:"filename"`
+	Error    string `json:"error"`
+}
+
+// PhotoUploadResult reports a bulk upload file by file
+type PhotoUploadResult struct {
+	Uploaded []EventPhoto         `json:"uploaded"`
+	Failed   []PhotoUploadFailure `json:"failed"`
+}
+
+// Photo takedown request statuses
+const (
+	TakedownPending  = "pending"
+	TakedownApproved = "approved"
+	TakedownRejected = "rejected"
+)
+
+// PhotoTakedownRequest is a student's request to have a photo removed
+type PhotoTakedownRequest struct {
+	ID            uuid.UUID  `json:"id"`
+	PhotoID       uuid.UUID  `json:"photo_id"`
+	ThumbnailURL  string     `json:"thumbnail_url"`
+	RequestedBy   uuid.UUID  `json:"requested_by"`
+	RequesterName string     `json:"requester_name"`
+	Reason        *string    `json:"reason,omitempty"`
+	Status        string     `json:"status"`
+	ReviewedBy    *uuid.UUID `json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// CreatePhotoTakedownRequest asks for a photo to be removed
+type CreatePhotoTakedownRequest struct {
+	Reason *string `json:"reason" binding:"omitempty,max=500"`
+}
+
+// ResolvePhotoTakedownRequest approves a takedown, removing the photo, or
+// rejects it
+type ResolvePhotoTakedownRequest struct {
+	Approve *bool `json:"approve" binding:"required"`
+}
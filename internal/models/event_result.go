@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventResult is a placement in a competition, won by an individual or a team
+type EventResult struct {
+	ID          uuid.UUID           `json:"id" db:"id"`
+	EventID     uuid.UUID           `json:"event_id" db:"event_id"`
+	Position    int                 `json:"position" db:"position"`
+	TeamName    *string             `json:"team_name,omitempty" db:"team_name"`
+	ClubID      *uuid.UUID          `json:"club_id,omitempty" db:"club_id"`
+	ClubName    *string             `json:"club_name,omitempty"`
+	ClubAwardID *uuid.UUID          `json:"club_award_id,omitempty" db:"club_award_id"`
+	HousePoints int                 `json:"house_points" db:"house_points"`
+	PrizeAmount *float64            `json:"prize_amount,omitempty" db:"prize_amount"`
+	Members     []EventResultMember `json:"members"`
+	CreatedAt   time.Time           `json:"created_at" db:"created_at"`
+}
+
+// EventResultMember is the winner of an individual placement or a member of
+// a winning team
+type EventResultMember struct {
+	UserID    uuid.UUID  `json:"user_id"`
+	FullName  string     `json:"full_name"`
+	AvatarURL *string    `json:"avatar_url,omitempty"`
+	HouseID   *uuid.UUID `json:"house_id,omitempty"`
+}
+
+// PlacementRequest is a single placement. A team is any placement with a
+// team_name; individual placements have exactly one user.
+type PlacementRequest struct {
+	Position    int         `json:"position" binding:"required,min=1"`
+	UserIDs     []uuid.UUID `json:"user_ids" binding:"required,min=1,max=50"`
+	TeamName    *string     `json:"team_name" binding:"omitempty,max=255"`
+	ClubID      *uuid.UUID  `json:"club_id"`
+	Points      int         `json:"points" binding:"min=0"`                 // Activity points per member; zero uses the default
+	HousePoints *int        `json:"house_points" binding:"omitempty,min=0"` // Per house; omit for the position default
+	PrizeAmount *float64    `json:"prize_amount" binding:"omitempty,min=0"`
+}
+
+// RecordResultsRequest records every placement of an event at once
+type RecordResultsRequest struct {
+	Results []PlacementRequest `json:"results" binding:"required,min=1,max=50,dive"`
+}
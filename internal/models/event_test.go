@@ -0,0 +1,62 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventRegistrationOpen(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	before, after, later := now.Add(-time.Hour), now.Add(time.Hour), now.Add(48*time.Hour)
+	upcoming, completed, cancelled := EventStatusUpcoming, EventStatusCompleted, EventStatusCancelled
+
+	tests := []struct {
+		name  string
+		event Event
+		open  bool
+	}{
+		{"no deadline", Event{Status: &upcoming, EndDate: later}, true},
+		{"before deadline", Event{Status: &upcoming, EndDate: later, RegistrationDeadline: &after}, true},
+		{"deadline passed", Event{Status: &upcoming, EndDate: later, RegistrationDeadline: &before}, false},
+		{"deadline now", Event{Status: &upcoming, EndDate: later, RegistrationDeadline: &now}, false},
+		{"ended", Event{Status: &upcoming, EndDate: before}, false},
+		{"completed", Event{Status: &completed, EndDate: later}, false},
+		{"cancelled", Event{Status: &cancelled, EndDate: later, RegistrationDeadline: &after}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.event.RegistrationOpen(now); got != tt.open {
+				t.Errorf("RegistrationOpen = %v, want %v", got, tt.open)
+			}
+		})
+	}
+}
+
+func TestEventAlumniAccess(t *testing.T) {
+	tests := []struct {
+		access  string
+		role    UserRole
+		visible bool
+		open    bool
+	}{
+		{AlumniAccessNone, RoleStudent, true, true},
+		{AlumniAccessNone, RoleAlumni, true, false},
+		{AlumniAccessNone, "", true, true},
+		{AlumniAccessOpen, RoleStudent, true, true},
+		{AlumniAccessOpen, RoleAlumni, true, true},
+		{AlumniAccessOnly, RoleStudent, false, false},
+		{AlumniAccessOnly, RoleAlumni, true, true},
+		{AlumniAccessOnly, RoleFaculty, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.access+"/"+string(tt.role), func(t *testing.T) {
+			e := Event{AlumniAccess: tt.access}
+			if got := e.VisibleTo(tt.role); got != tt.visible {
+				t.Errorf("VisibleTo = %v, want %v", got, tt.visible)
+			}
+			if got := e.OpenTo(tt.role); got != tt.open {
+				t.Errorf("OpenTo = %v, want %v", got, tt.open)
+			}
+		})
+	}
+}
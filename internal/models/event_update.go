@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventUpdate is a live update posted by an event's organizers
+type EventUpdate struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	EventID   uuid.UUID  `json:"event_id" db:"event_id"`
+	PostedBy  *uuid.UUID `json:"posted_by,omitempty" db:"posted_by"`
+	Body      string     `json:"body" db:"body"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// CreateEventUpdateRequest represents a live update post
+type CreateEventUpdateRequest struct {
+	Body string `json:"body" binding:"required,min=1,max=1000"`
+}
+
+// EventDetail is an event with its most recent live updates, its sponsors,
+// including those of the fest it belongs to, and its registration form
+type EventDetail struct {
+	Event
+	Updates          []EventUpdate `json:"updates"`
+	Sponsors         []Sponsor     `json:"sponsors"`
+	RegistrationForm []FormField   `json:"registration_form"`
+	TicketTiers      []TicketTier  `json:"ticket_tiers"`
+}
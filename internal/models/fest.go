@@ -0,0 +1,133 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Fest groups many sub-events under one brand and date range
+type Fest struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	Name        string     `json:"name" db:"name"`
+	Tagline     *string    `json:"tagline,omitempty" db:"tagline"`
+	Description *string    `json:"description,omitempty" db:"description"`
+	LogoURL     *string    `json:"logo_url,omitempty" db:"logo_url"`
+	BannerURL   *string    `json:"banner_url,omitempty" db:"banner_url"`
+	ColorHex    *string    `json:"color_hex,omitempty" db:"color_hex"`
+	StartDate   time.Time  `json:"start_date" db:"start_date"`
+	EndDate     time.Time  `json:"end_date" db:"end_date"`
+	PassAmount  *float64   `json:"pass_amount,omitempty" db:"pass_amount"` // nil when no combined pass is offered
+	Currency    string     `json:"currency" db:"currency"`
+	CreatedBy   *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// CreateFestRequest represents fest creation data. Dates are YYYY-MM-DD.
+type CreateFestRequest struct {
+	Name        string   `json:"name" binding:"required,max=255"`
+	Tagline     *string  `json:"tagline" binding:"omitempty,max=255"`
+	Description *string  `json:"description"`
+	LogoURL     *string  `json:"logo_url" binding:"omitempty,max=500"`
+	BannerURL   *string  `json:"banner_url" binding:"omitempty,max=500"`
+	ColorHex    *string  `json:"color_hex" binding:"omitempty,hexcolor"`
+	StartDate   string   `json:"start_date" binding:"required"`
+	EndDate     string   `json:"end_date" binding:"required"`
+	PassAmount  *float64 `json:"pass_amount" binding:"omitempty,min=0"`
+	Currency    *string  `json:"currency" binding:"omitempty,len=3"`
+}
+
+// UpdateFestRequest represents fest update data
+type UpdateFestRequest struct {
+	Name        *string  `json:"name" binding:"omitempty,max=255"`
+	Tagline     *string  `json:"tagline" binding:"omitempty,max=255"`
+	Description *string  `json:"description"`
+	LogoURL     *string  `json:"logo_url" binding:"omitempty,max=500"`
+	BannerURL   *string  `json:"banner_url" binding:"omitempty,max=500"`
+	ColorHex    *string  `json:"color_hex" binding:"omitempty,hexcolor"`
+	StartDate   *string  `json:"start_date"`
+	EndDate     *string  `json:"end_date"`
+	PassAmount  *float64 `json:"pass_amount" binding:"omitempty,min=0"`
+	DisablePass bool     `json:"disable_pass"` // Stop offering the combined pass
+}
+
+// AttachFestEventsRequest adds existing events to a fest
+type AttachFestEventsRequest struct {
+	EventIDs []uuid.UUID `json:"event_ids" binding:"required,min=1,max=200"`
+}
+
+// FestEvent is a sub-event in a fest's schedule with its results
+type FestEvent struct {
+	Event
+	Results []EventResult `json:"results"`
+}
+
+// FestHouseStanding is a house's competition points across a fest
+type FestHouseStanding struct {
+	HouseID uuid.UUID `json:"house_id"`
+	Name    string    `json:"name"`
+	Color   *string   `json:"color,omitempty"`
+	Points  int       `json:"points"`
+}
+
+// FestClubStanding is a club's medal count across a fest
+type FestClubStanding struct {
+	ClubID     uuid.UUID `json:"club_id"`
+	Name       string    `json:"name"`
+	First      int       `json:"first"`
+	Second     int       `json:"second"`
+	Third      int       `json:"third"`
+	Placements int       `json:"placements"`
+}
+
+// FestLeaderboard aggregates the results of a fest's sub-events
+type FestLeaderboard struct {
+	Houses []FestHouseStanding `json:"houses"`
+	Clubs  []FestClubStanding  `json:"clubs"`
+}
+
+// FestDetail is a fest with its full schedule, results and leaderboard
+type FestDetail struct {
+	Fest
+	Events      []FestEvent     `json:"events"`
+	Leaderboard FestLeaderboard `json:"leaderboard"`
+	Sponsors    []Sponsor       `json:"sponsors"`
+	Pass        *FestPass       `json:"pass,omitempty"` // The caller's pass, when signed in
+}
+
+// Fest pass statuses
+const (
+	FestPassPending = "pending"
+	FestPassPaid    = "paid"
+	FestPassFailed  = "failed"
+)
+
+// FestPass is a user's combined registration for every sub-event of a fest
+type FestPass struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	FestID    uuid.UUID `json:"fest_id" db:"fest_id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	Amount    float64   `json:"amount" db:"amount"`
+	Currency  string    `json:"currency" db:"currency"`
+	Status    string    `json:"status" db:"status"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// FestPassOrderResponse is returned when a pass is requested. Free passes
+// are active immediately; paid passes return a Razorpay order to complete.
+type FestPassOrderResponse struct {
+	Pass     *FestPass `json:"pass,omitempty"`
+	OrderID  string    `json:"order_id,omitempty"`
+	Amount   int       `json:"amount,omitempty"` // Amount in paise
+	Currency string    `json:"currency,omitempty"`
+	KeyID    string    `json:"key_id,omitempty"`
+	FestID   string    `json:"fest_id"`
+}
+
+// VerifyFestPassRequest completes a paid pass
+type VerifyFestPassRequest struct {
+	RazorpayOrderID   string `json:"razorpay_order_id" binding:"required"`
+	RazorpayPaymentID string `json:"razorpay_payment_id" binding:"required"`
+	RazorpaySignature string `json:"razorpay_signature" binding:"required"`
+}
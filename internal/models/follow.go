@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// What users can follow
+const (
+	FollowTargetClub  = "club"
+	FollowTargetHouse = "house"
+)
+
+// Follow is a club or house a user follows
+type Follow struct {
+	TargetType string    `json:"target_type"`
+	TargetID   uuid.UUID `json:"target_id"`
+	Name       string    `json:"name"`
+	LogoURL    *string   `json:"logo_url,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
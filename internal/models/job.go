@@ -0,0 +1,50 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobStatus defines the lifecycle state of a background job
+type JobStatus string
+
+const (
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusCompleted JobStatus = "completed"
+	JobStatusDead      JobStatus = "dead" // Exhausted all retries (dead-letter)
+)
+
+// Job represents a unit of async work in the persistent job queue
+type Job struct {
+	ID          uuid.UUID       `json:"id" db:"id"`
+	JobType     string          `json:"job_type" db:"job_type"`
+	Payload     json.RawMessage `json:"payload" db:"payload"`
+	Status      JobStatus       `json:"status" db:"status"`
+	Attempts    int             `json:"attempts" db:"attempts"`
+	MaxAttempts int             `json:"max_attempts" db:"max_attempts"`
+	LastError   *string         `json:"last_error,omitempty" db:"last_error"`
+	RunAt       time.Time       `json:"run_at" db:"run_at"`
+	LockedAt    *time.Time      `json:"locked_at,omitempty" db:"locked_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// ListJobsQuery represents filters for the admin jobs dashboard
+type ListJobsQuery struct {
+	Status   *string `form:"status"`
+	JobType  *string `form:"type"`
+	Page     int     `form:"page"`
+	PageSize int     `form:"page_size"`
+}
+
+// JobStats summarizes queue health for the admin dashboard
+type JobStats struct {
+	ByStatus map[string]int            `json:"by_status"`
+	ByType   map[string]map[string]int `json:"by_type"`
+	// Oldest runnable job age, useful for spotting a stalled worker pool
+	OldestPendingSecs *int `json:"oldest_pending_seconds,omitempty"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "github.com/google/uuid"
+
+// Types of entity shared links resolve to
+const (
+	LinkTypeEvent = "event"
+	LinkTypePost  = "post"
+	LinkTypeClub  = "club"
+)
+
+// ResolvedLink is the entity a shared link points at
+type ResolvedLink struct {
+	Type string    `json:"type"`
+	ID   uuid.UUID `json:"id"`
+	// Route in the app, such as events/:id
+	Path      string `json:"path"`
+	ShortCode string `json:"short_code"`
+	Title     string `json:"title"`
+}
@@ -0,0 +1,25 @@
+package models
+
+// PostCounters are a post's engagement counts, streamed live to clients
+type PostCounters struct {
+	LikeCount    int `json:"like_count"`
+	CommentCount int `json:"comment_count"`
+	ShareCount   int `json:"share_count"`
+	ViewCount    int `json:"view_count"`
+}
+
+// StoryCounters are a story's engagement counts, streamed live to clients
+type StoryCounters struct {
+	LikeCount int `json:"like_count"`
+	ViewCount int `json:"view_count"`
+}
+
+// EventEnrollment is an event's registration count against its capacity,
+// streamed live to clients. MaxParticipants and SpotsLeft are null for
+// events without a limit.
+type EventEnrollment struct {
+	CurrentParticipants int  `json:"current_participants"`
+	MaxParticipants     *int `json:"max_participants"`
+	SpotsLeft           *int `json:"spots_left"`
+	IsFull              bool `json:"is_full"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LostFoundItem is a lost or found item posted by a user
+type LostFoundItem struct {
+	ID          uuid.UUID    `json:"id" db:"id"`
+	Kind        string       `json:"kind" db:"kind"` // lost, found
+	Title       string       `json:"title" db:"title"`
+	Description *string      `json:"description,omitempty" db:"description"`
+	Location    *string      `json:"location,omitempty" db:"location"`
+	ImageURL    *string      `json:"image_url,omitempty" db:"image_url"`
+	Status      string       `json:"status" db:"status"` // open, claimed, expired
+	PostedBy    uuid.UUID    `json:"posted_by" db:"posted_by"`
+	ExpiresAt   time.Time    `json:"expires_at" db:"expires_at"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at" db:"updated_at"`
+	Poster      *UserSummary `json:"poster,omitempty"`
+}
+
+// CreateLostFoundItemRequest is the multipart form for posting an item.
+// The optional photo is sent as the "image" file field.
+type CreateLostFoundItemRequest struct {
+	Kind        string  `form:"kind" binding:"required,oneof=lost found"`
+	Title       string  `form:"title" binding:"required,max=255"`
+	Description *string `form:"description"`
+	Location    *string `form:"location" binding:"omitempty,max=255"`
+}
+
+// ListLostFoundQuery represents lost and found filters
+type ListLostFoundQuery struct {
+	Kind     string `form:"kind" binding:"omitempty,oneof=lost found"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+}
+
+// LostFoundClaim is a user's claim on an item
+type LostFoundClaim struct {
+	ID         uuid.UUID    `json:"id" db:"id"`
+	ItemID     uuid.UUID    `json:"item_id" db:"item_id"`
+	ClaimantID uuid.UUID    `json:"claimant_id" db:"claimant_id"`
+	Message    string       `json:"message" db:"message"`
+	Status     string       `json:"status" db:"status"` // pending, approved, rejected
+	CreatedAt  time.Time    `json:"created_at" db:"created_at"`
+	ResolvedAt *time.Time   `json:"resolved_at,omitempty" db:"resolved_at"`
+	Claimant   *UserSummary `json:"claimant,omitempty"`
+}
+
+// CreateClaimRequest represents claim submission data
+type CreateClaimRequest struct {
+	Message string `json:"message" binding:"required,max=2000"`
+}
+
+// ResolveClaimRequest approves or rejects a claim
+type ResolveClaimRequest struct {
+	Status string `json:"status" binding:"required,oneof=approved rejected"`
+}
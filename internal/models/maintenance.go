@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// DefaultMaintenanceMessage is shown when maintenance is enabled without one
+const DefaultMaintenanceMessage = "We're down for scheduled maintenance and will be back shortly."
+
+// MaintenanceStatus describes whether the API is in maintenance mode
+type MaintenanceStatus struct {
+	Enabled bool       `json:"enabled"`
+	Message string     `json:"message,omitempty"`
+	ETA     *time.Time `json:"eta,omitempty"`
+	// Forced is set when the MAINTENANCE_MODE config flag holds the API in
+	// maintenance regardless of the admin toggle
+	Forced    bool       `json:"forced,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdateMaintenanceRequest turns maintenance mode on or off
+type UpdateMaintenanceRequest struct {
+	Enabled *bool      `json:"enabled" binding:"required"`
+	Message *string    `json:"message" binding:"omitempty,max=500"`
+	ETA     *time.Time `json:"eta"`
+}
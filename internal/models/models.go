@@ -15,6 +15,9 @@ const (
 	RoleAdmin   UserRole = "admin"
 	RoleStudent UserRole = "student"
 	RoleFaculty UserRole = "faculty"
+	// Graduated students. They can follow events and register for those
+	// open to alumni, but belong to no house or club.
+	RoleAlumni UserRole = "alumni"
 )
 
 // User represents a user in the system
@@ -30,6 +33,10 @@ type User struct {
 	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
 	DeletedAt    *time.Time `json:"-" db:"deleted_at"`
+
+	// GraduationYear is when a student becomes alumni, at the end of that
+	// year's academic year
+	GraduationYear *int `json:"graduation_year,omitempty" db:"graduation_year"`
 }
 
 // RegisterRequest represents user registration data
@@ -53,6 +60,51 @@ type LoginResponse struct {
 	User         User   `json:"user"`
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
+	// CSRFToken is set for cookie sessions, whose tokens are left out
+	CSRFToken string `json:"csrf_token,omitempty"`
+}
+
+// LDAPLoginRequest represents directory (LDAP / Active Directory) login data
+type LDAPLoginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// OIDCLoginRequest represents an SSO sign-in: either the ID token the
+// client obtained or an authorization code for the server to redeem
+type OIDCLoginRequest struct {
+	IDToken      string `json:"id_token"`
+	Code         string `json:"code"`
+	RedirectURI  string `json:"redirect_uri"`
+	CodeVerifier string `json:"code_verifier"` // PKCE
+	Nonce        string `json:"nonce"`
+}
+
+// AuthProvidersResponse lists the enabled login methods
+type AuthProvidersResponse struct {
+	Providers []string          `json:"providers"`
+	OIDC      *OIDCProviderInfo `json:"oidc,omitempty"`
+}
+
+// OIDCProviderInfo is what clients need to start an SSO sign-in
+type OIDCProviderInfo struct {
+	Issuer                string `json:"issuer"`
+	ClientID              string `json:"client_id"`
+	AuthorizationEndpoint string `json:"authorization_endpoint,omitempty"`
+}
+
+// CreateScopedTokenRequest asks for an access token limited to scopes such
+// as "read:events" or "write:posts", for an integration
+type CreateScopedTokenRequest struct {
+	Scopes         []string `json:"scopes" binding:"required,min=1"`
+	ExpiresInHours int      `json:"expires_in_hours"` // Default 24, at most 720
+}
+
+// ScopedTokenResponse carries a scoped access token. It has no refresh token.
+type ScopedTokenResponse struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Scope       string    `json:"scope"`
 }
 
 // ============================================================================
@@ -117,6 +169,7 @@ type Club struct {
 	Phone          *string         `json:"phone,omitempty" db:"phone"`
 	Website        *string         `json:"website,omitempty" db:"website"`
 	SocialLinks    json.RawMessage `json:"social_links,omitempty" db:"social_links"`
+	Version        int             `json:"version" db:"version"`
 	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time       `json:"updated_at" db:"updated_at"`
 }
@@ -149,12 +202,34 @@ type UpdateClubRequest struct {
 	Phone          *string         `json:"phone"`
 	Website        *string         `json:"website"`
 	SocialLinks    json.RawMessage `json:"social_links"`
+	// Version is the version of the club the edit was made against. If the
+	// club has been edited since, the update is refused.
+	Version *int `json:"version"`
 }
 
 // ============================================================================
 // CLUB MEMBERS
 // ============================================================================
 
+// Roles in a club. Its leads (president, vice president and secretary) and
+// its faculty advisor manage it; members don't.
+const (
+	ClubRoleMember        = "member"
+	ClubRolePresident     = "president"
+	ClubRoleVicePresident = "vice_president"
+	ClubRoleSecretary     = "secretary"
+	ClubRoleAdvisor       = "advisor"
+)
+
+// ClubRoleManages reports whether a club role may manage the club
+func ClubRoleManages(role string) bool {
+	switch role {
+	case ClubRolePresident, ClubRoleVicePresident, ClubRoleSecretary, ClubRoleAdvisor:
+		return true
+	}
+	return false
+}
+
 // ClubMember represents a member of a club
 type ClubMember struct {
 	ID        uuid.UUID `json:"id" db:"id"`
@@ -172,16 +247,30 @@ type ClubMemberWithUser struct {
 	User User `json:"user"`
 }
 
+// ClubMembership represents one of the current user's club memberships
+type ClubMembership struct {
+	ClubMember
+	Club Club `json:"club"`
+}
+
+// ClubDetail is a club with the caller's membership, if any, so clients
+// can decide whether to show member or admin controls
+type ClubDetail struct {
+	Club
+	FollowerCount int         `json:"follower_count"`
+	Membership    *ClubMember `json:"membership"`
+}
+
 // AddClubMemberRequest represents add member data
 type AddClubMemberRequest struct {
 	UserID   uuid.UUID `json:"user_id" binding:"required"`
-	Role     *string   `json:"role"`
+	Role     *string   `json:"role" binding:"omitempty,oneof=member president vice_president secretary advisor"`
 	Position *string   `json:"position"`
 }
 
 // UpdateClubMemberRequest represents update member data
 type UpdateClubMemberRequest struct {
-	Role     *string `json:"role"`
+	Role     *string `json:"role" binding:"omitempty,oneof=member president vice_president secretary advisor"`
 	Position *string `json:"position"`
 }
 
@@ -218,6 +307,24 @@ type UpdateAnnouncementRequest struct {
 	IsPinned *bool   `json:"is_pinned"`
 }
 
+// AnnouncementReads is how many of a club's current members have read one of
+// its announcements
+type AnnouncementReads struct {
+	AnnouncementID uuid.UUID `json:"announcement_id"`
+	MemberCount    int       `json:"member_count"`
+	ReadCount      int       `json:"read_count"`
+	ReadPercentage float64   `json:"read_percentage"`
+	// Unread lists the members who haven't read it yet
+	Unread []AnnouncementReader `json:"unread"`
+}
+
+// AnnouncementReader is a club member in an announcement's read receipts
+type AnnouncementReader struct {
+	UserID   uuid.UUID `json:"user_id"`
+	FullName string    `json:"full_name"`
+	Email    string    `json:"email"`
+}
+
 // ============================================================================
 // CLUB AWARDS
 // ============================================================================
@@ -233,6 +340,7 @@ type ClubAward struct {
 	EventName      *string    `json:"event_name,omitempty" db:"event_name"`
 	AwardedDate    *time.Time `json:"awarded_date,omitempty" db:"awarded_date"`
 	CertificateURL *string    `json:"certificate_url,omitempty" db:"certificate_url"`
+	TermID         *uuid.UUID `json:"term_id,omitempty" db:"term_id"`
 	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
 }
 
@@ -251,6 +359,24 @@ type CreateAwardRequest struct {
 // EVENTS
 // ============================================================================
 
+// Event statuses. Events move from upcoming to ongoing at their start and to
+// completed at their end; cancelled events stay cancelled.
+const (
+	EventStatusUpcoming  = "upcoming"
+	EventStatusOngoing   = "ongoing"
+	EventStatusCompleted = "completed"
+	EventStatusCancelled = "cancelled"
+)
+
+// Who of the alumni an event is for. Alumni may view students' events
+// (none), register for those open to them too (open), and alone see and
+// register for alumni events (only), which staff also see.
+const (
+	AlumniAccessNone = "none"
+	AlumniAccessOpen = "open"
+	AlumniAccessOnly = "only"
+)
+
 // Event represents an event in the system
 type Event struct {
 	ID                   uuid.UUID  `json:"id" db:"id"`
@@ -266,15 +392,92 @@ type Event struct {
 	CurrentParticipants  int        `json:"current_participants" db:"current_participants"`
 	RegistrationDeadline *time.Time `json:"registration_deadline,omitempty" db:"registration_deadline"`
 	IsFeatured           bool       `json:"is_featured" db:"is_featured"`
+	AlumniAccess         string     `json:"alumni_access" db:"alumni_access"`
+	// Place in the featured carousel, and when it's shown there; set only
+	// where events are read through the repository
+	FeaturedPosition *int       `json:"featured_position,omitempty" db:"featured_position"`
+	FeaturedFrom     *time.Time `json:"featured_from,omitempty" db:"featured_from"`
+	FeaturedUntil    *time.Time `json:"featured_until,omitempty" db:"featured_until"`
 	// Payment fields
 	IsPaidEvent bool       `json:"is_paid_event" db:"is_paid_event"`
 	EventAmount *float64   `json:"event_amount,omitempty" db:"event_amount"`
 	Currency    *string    `json:"currency,omitempty" db:"currency"`
 	ClubID      *uuid.UUID `json:"club_id,omitempty" db:"club_id"`
+	FestID      *uuid.UUID `json:"fest_id,omitempty" db:"fest_id"`
 	CreatedBy   *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	TermID      *uuid.UUID `json:"term_id,omitempty" db:"term_id"`
+	Version     int        `json:"version" db:"version"`
 	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
 	DeletedAt   *time.Time `json:"-" db:"deleted_at"`
+
+	// DepartmentID is set for events a department hosts itself rather than
+	// through one of its clubs
+	DepartmentID *uuid.UUID `json:"department_id,omitempty" db:"department_id"`
+}
+
+// FeatureEventRequest adds an event to the featured carousel, or moves it,
+// at Position; past the end, or without one, it goes last. It's shown only
+// from StartsAt and until EndsAt, when given.
+type FeatureEventRequest struct {
+	Position *int       `json:"position,omitempty" binding:"omitempty,min=0"`
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+}
+
+// RegistrationOpen reports whether the event takes registrations at now:
+// it's neither cancelled nor over and its registration deadline, if any,
+// hasn't passed
+func (e Event) RegistrationOpen(now time.Time) bool {
+	if e.Status != nil && (*e.Status == EventStatusCancelled || *e.Status == EventStatusCompleted) {
+		return false
+	}
+	if e.RegistrationDeadline != nil && !now.Before(*e.RegistrationDeadline) {
+		return false
+	}
+	return now.Before(e.EndDate)
+}
+
+// VisibleTo reports whether a user with role sees the event. Alumni events
+// are hidden from students and signed out visitors, whose role is empty.
+func (e Event) VisibleTo(role UserRole) bool {
+	return e.AlumniAccess != AlumniAccessOnly || SeesAlumniEvents(role)
+}
+
+// OpenTo reports whether a user with role may register for the event:
+// alumni only for events open to them, and students for any but alumni
+// events
+func (e Event) OpenTo(role UserRole) bool {
+	switch role {
+	case RoleAlumni:
+		return e.AlumniAccess == AlumniAccessOpen || e.AlumniAccess == AlumniAccessOnly
+	case RoleStudent:
+		return e.AlumniAccess != AlumniAccessOnly
+	}
+	return true
+}
+
+// SeesAlumniEvents reports whether a user with role sees alumni events:
+// alumni, and the staff who run them
+func SeesAlumniEvents(role UserRole) bool {
+	return role == RoleAlumni || role == RoleAdmin || role == RoleFaculty
+}
+
+// CancelEventRequest is the reason given for cancelling an event, shown to
+// its attendees
+type CancelEventRequest struct {
+	Reason *string `json:"reason" binding:"omitempty,max=500"`
+}
+
+// EventCancellation is the outcome of cancelling an event
+type EventCancellation struct {
+	EventID     uuid.UUID `json:"event_id"`
+	CancelledAt time.Time `json:"cancelled_at"`
+	// RefundsQueued is how many paid registrations are being refunded
+	RefundsQueued int `json:"refunds_queued"`
+	// OfflineRefunds is how many were paid offline, to be refunded by hand.
+	// They are left refund_pending.
+	OfflineRefunds int `json:"offline_refunds"`
 }
 
 // CreateEventRequest represents event creation data
@@ -289,10 +492,40 @@ type CreateEventRequest struct {
 	Category    *string    `json:"category"`
 	MaxCapacity *int       `json:"max_capacity"`
 	ClubID      *uuid.UUID `json:"club_id"`
+	// Registrations close at RegistrationDeadline, or at the end without one
+	RegistrationDeadline *JSONTime `json:"registration_deadline"`
+	// AlumniAccess is none, the default, open or only (see AlumniAccessNone)
+	AlumniAccess *string `json:"alumni_access" binding:"omitempty,oneof=none open only"`
 	// Payment fields
 	IsPaidEvent bool     `json:"is_paid_event"`
 	EventAmount *float64 `json:"event_amount"`
 	Currency    *string  `json:"currency"`
+
+	// DepartmentID is for events a department hosts itself
+	DepartmentID *uuid.UUID `json:"department_id"`
+}
+
+// DuplicateEvent is an existing event a new one looks like a copy of: one
+// around the same dates with a similar title, hosted by the same club or at
+// the same venue, or with a near identical title
+type DuplicateEvent struct {
+	ID              uuid.UUID  `json:"id"`
+	Title           string     `json:"title"`
+	StartDate       time.Time  `json:"start_date"`
+	EndDate         time.Time  `json:"end_date"`
+	Location        *string    `json:"location,omitempty"`
+	ClubID          *uuid.UUID `json:"club_id,omitempty"`
+	TitleSimilarity float64    `json:"title_similarity"` // 0 to 1
+	SameClub        bool       `json:"same_club"`
+	SameVenue       bool       `json:"same_venue"`
+}
+
+// UpdateEventRequest represents event update data
+type UpdateEventRequest struct {
+	CreateEventRequest
+	// Version is the version of the event the edit was made against. If the
+	// event has been edited since, the update is refused.
+	Version *int `json:"version"`
 }
 
 // JSONTime is a custom time type that handles multiple datetime formats
@@ -353,32 +586,61 @@ type EventRegistration struct {
 
 // EventPayment represents a payment transaction for event registration
 type EventPayment struct {
-	ID                uuid.UUID `json:"id" db:"id"`
-	EventID           uuid.UUID `json:"event_id" db:"event_id"`
-	UserID            uuid.UUID `json:"user_id" db:"user_id"`
-	RazorpayOrderID   string    `json:"razorpay_order_id" db:"razorpay_order_id"`
-	RazorpayPaymentID *string   `json:"razorpay_payment_id,omitempty" db:"razorpay_payment_id"`
-	RazorpaySignature *string   `json:"razorpay_signature,omitempty" db:"razorpay_signature"`
-	Amount            float64   `json:"amount" db:"amount"`
-	Currency          string    `json:"currency" db:"currency"`
-	Status            string    `json:"status" db:"status"` // pending, paid, failed, refunded
-	FailureReason     *string   `json:"failure_reason,omitempty" db:"failure_reason"`
-	CreatedAt         time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+	ID                uuid.UUID  `json:"id" db:"id"`
+	EventID           uuid.UUID  `json:"event_id" db:"event_id"`
+	UserID            uuid.UUID  `json:"user_id" db:"user_id"`
+	RazorpayOrderID   string     `json:"razorpay_order_id,omitempty" db:"razorpay_order_id"` // Empty for offline payments
+	RazorpayPaymentID *string    `json:"razorpay_payment_id,omitempty" db:"razorpay_payment_id"`
+	RazorpaySignature *string    `json:"razorpay_signature,omitempty" db:"razorpay_signature"`
+	Amount            float64    `json:"amount" db:"amount"`
+	Currency          string     `json:"currency" db:"currency"`
+	Status            string     `json:"status" db:"status"` // pending, paid, failed, refund_pending, refunded
+	FailureReason     *string    `json:"failure_reason,omitempty" db:"failure_reason"`
+	Method            string     `json:"method" db:"method"`
+	CollectedBy       *uuid.UUID `json:"collected_by,omitempty" db:"collected_by"` // Who took an offline payment
+	ReceiptNumber     *string    `json:"receipt_number,omitempty" db:"receipt_number"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Payment methods
+const (
+	PaymentMethodRazorpay = "razorpay"
+	PaymentMethodCash     = "cash"
+	PaymentMethodOffline  = "offline" // Bank transfer, UPI to the club, cheque and the like
+	PaymentMethodWallet   = "wallet"  // Campus credits
+)
+
+// RecordOfflinePaymentRequest records a payment taken outside Razorpay
+type RecordOfflinePaymentRequest struct {
+	UserID        uuid.UUID `json:"user_id" binding:"required"`
+	Method        string    `json:"method" binding:"required,oneof=cash offline"`
+	ReceiptNumber string    `json:"receipt_number" binding:"required,max=50"`
+	// Amount defaults to the price of the event or of the ticket tier
+	Amount *float64   `json:"amount" binding:"omitempty,gte=0"`
+	TierID *uuid.UUID `json:"tier_id"` // Required for events with tiers
 }
 
 // CreateOrderRequest represents request to create a Razorpay order
 type CreateOrderRequest struct {
-	EventID uuid.UUID `json:"event_id" binding:"required"`
+	EventID       uuid.UUID     `json:"event_id" binding:"required"`
+	FormResponses FormResponses `json:"form_responses"` // Answers to the event's registration form
+	// TierID is the ticket tier bought, required for events with tiers
+	TierID *uuid.UUID `json:"tier_id"`
+	// SkipWallet pays through Razorpay even if the wallet balance covers
+	// the price
+	SkipWallet bool `json:"skip_wallet"`
 }
 
-// CreateOrderResponse represents response after creating a Razorpay order
+// CreateOrderResponse represents response after creating a Razorpay order.
+// An order paid from the wallet is complete, with no Razorpay order.
 type CreateOrderResponse struct {
-	OrderID  string `json:"order_id"`
-	Amount   int    `json:"amount"` // Amount in paise
-	Currency string `json:"currency"`
-	KeyID    string `json:"key_id"`
-	EventID  string `json:"event_id"`
+	OrderID        string `json:"order_id,omitempty"`
+	Amount         int    `json:"amount"` // Amount in paise
+	Currency       string `json:"currency"`
+	KeyID          string `json:"key_id,omitempty"`
+	EventID        string `json:"event_id"`
+	PaidWithWallet bool   `json:"paid_with_wallet"`
 }
 
 // VerifyPaymentRequest represents request to verify a payment
@@ -394,6 +656,7 @@ type PaymentStatusResponse struct {
 	HasPaid   bool    `json:"has_paid"`
 	PaymentID *string `json:"payment_id,omitempty"`
 	Status    *string `json:"status,omitempty"`
+	Method    *string `json:"method,omitempty"`
 }
 
 // ============================================================================
@@ -424,10 +687,13 @@ type ChatMessage struct {
 
 // APIResponse represents a standard API response
 type APIResponse struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message,omitempty"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   *string     `json:"error,omitempty"`
+	Success   bool        `json:"success"`
+	Message   string      `json:"message,omitempty"`
+	Data      interface{} `json:"data,omitempty"`
+	Error     *string     `json:"error,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+	// Version of the API that failed, on server errors, for bug reports
+	Version string `json:"version,omitempty"`
 }
 
 // PaginationParams represents pagination parameters
@@ -537,7 +803,8 @@ type House struct {
 	UpdatedAt   *time.Time `json:"updated_at,omitempty" db:"updated_at"`
 	DeletedAt   *time.Time `json:"-" db:"deleted_at"`
 	// Computed fields (not in DB)
-	Roles []HouseRole `json:"roles,omitempty"`
+	Roles         []HouseRole `json:"roles,omitempty"`
+	FollowerCount *int        `json:"follower_count,omitempty"`
 }
 
 // CreateHouseRequest represents house creation data
@@ -561,21 +828,47 @@ type UpdateHouseRequest struct {
 // HOUSE ROLES
 // ============================================================================
 
-// HouseRole represents a role/position in a house (admin-defined)
+// House role permissions, held by the user a role is linked to
+const (
+	HousePermissionPostAnnouncements = "post_announcements"
+	HousePermissionCreateEvents      = "create_events"
+)
+
+// HouseRole represents a role/position in a house (admin-defined). A role
+// linked to a user gives them its permissions in the house.
 type HouseRole struct {
-	ID           uuid.UUID `json:"id" db:"id"`
-	HouseID      uuid.UUID `json:"house_id" db:"house_id"`
-	MemberName   string    `json:"member_name" db:"member_name"`
-	RoleTitle    string    `json:"role_title" db:"role_title"`
-	DisplayOrder int       `json:"display_order" db:"display_order"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	ID           uuid.UUID  `json:"id" db:"id"`
+	HouseID      uuid.UUID  `json:"house_id" db:"house_id"`
+	UserID       *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
+	MemberName   string     `json:"member_name" db:"member_name"`
+	RoleTitle    string     `json:"role_title" db:"role_title"`
+	DisplayOrder int        `json:"display_order" db:"display_order"`
+	Permissions  []string   `json:"permissions" db:"permissions"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty" db:"updated_at"`
 }
 
-// CreateHouseRoleRequest represents house role creation data
+// CreateHouseRoleRequest represents house role creation data. MemberName
+// defaults to the linked user's name.
 type CreateHouseRoleRequest struct {
-	MemberName   string `json:"member_name" binding:"required,max=255"`
-	RoleTitle    string `json:"role_title" binding:"required,max=255"`
-	DisplayOrder *int   `json:"display_order"`
+	UserID       *uuid.UUID `json:"user_id"`
+	MemberName   string     `json:"member_name" binding:"required_without=UserID,max=255"`
+	RoleTitle    string     `json:"role_title" binding:"required,max=255"`
+	DisplayOrder *int       `json:"display_order"`
+	Permissions  []string   `json:"permissions" binding:"omitempty,dive,oneof=post_announcements create_events"`
+}
+
+// UpdateHouseRoleRequest represents house role update data. DisplayOrder
+// moves the role to that position among the house's roles, shifting the
+// others. UnlinkUser removes the linked user, and with them the role's
+// permissions.
+type UpdateHouseRoleRequest struct {
+	UserID       *uuid.UUID `json:"user_id"`
+	UnlinkUser   bool       `json:"unlink_user"`
+	MemberName   *string    `json:"member_name" binding:"omitempty,min=1,max=255"`
+	RoleTitle    *string    `json:"role_title" binding:"omitempty,min=1,max=255"`
+	DisplayOrder *int       `json:"display_order" binding:"omitempty,min=0"`
+	Permissions  *[]string  `json:"permissions" binding:"omitempty,dive,oneof=post_announcements create_events"`
 }
 
 // ============================================================================
@@ -593,10 +886,15 @@ type HouseAnnouncement struct {
 	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
 	DeletedAt *time.Time `json:"-" db:"deleted_at"`
 	// Computed fields
-	AuthorName   string `json:"author_name,omitempty"`
-	LikeCount    int    `json:"like_count"`
-	CommentCount int    `json:"comment_count"`
-	IsLikedByMe  bool   `json:"is_liked_by_me"`
+	AuthorName     string         `json:"author_name,omitempty"`
+	LikeCount      int            `json:"like_count"` // Reactions of every type
+	ReactionCounts ReactionCounts `json:"reaction_counts"`
+	CommentCount   int            `json:"comment_count"`
+	IsLikedByMe    bool           `json:"is_liked_by_me"` // Reacted in any way
+	MyReaction     *string        `json:"my_reaction"`
+	// The first comments, oldest first, in announcement lists; the rest are
+	// paged through GET /announcements/:id/comments
+	Comments []AnnouncementComment `json:"comments,omitempty"`
 }
 
 // CreateHouseAnnouncementRequest represents announcement creation data
@@ -631,12 +929,19 @@ type AnnouncementComment struct {
 	Content        string     `json:"content" db:"content"`
 	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+	EditedAt       *time.Time `json:"edited_at" db:"edited_at"` // Null unless edited
 	DeletedAt      *time.Time `json:"-" db:"deleted_at"`
 	// Computed fields
 	UserName  string `json:"user_name,omitempty"`
 	AvatarURL string `json:"avatar_url,omitempty"`
 }
 
+// ListCommentsQuery pages through an announcement's comments, oldest first
+type ListCommentsQuery struct {
+	Page     int `form:"page"`
+	PageSize int `form:"page_size"`
+}
+
 // CreateCommentRequest represents comment creation data
 type CreateCommentRequest struct {
 	Content string `json:"content" binding:"required,max=1000"`
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BlockedUser is a user the viewer has blocked
+type BlockedUser struct {
+	UserID    uuid.UUID `json:"user_id" db:"blocked_id"`
+	FullName  string    `json:"full_name" db:"full_name"`
+	AvatarURL *string   `json:"avatar_url,omitempty" db:"avatar_url"`
+	BlockedAt time.Time `json:"blocked_at" db:"created_at"`
+}
+
+// UserMute stops a user commenting until MutedUntil
+type UserMute struct {
+	UserID     uuid.UUID  `json:"user_id" db:"user_id"`
+	FullName   string     `json:"full_name" db:"full_name"`
+	MutedUntil time.Time  `json:"muted_until" db:"muted_until"`
+	Reason     *string    `json:"reason,omitempty" db:"reason"`
+	MutedBy    *uuid.UUID `json:"muted_by,omitempty" db:"muted_by"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// MuteUserRequest mutes a user for a number of hours, up to a year.
+// Muting a muted user replaces their mute.
+type MuteUserRequest struct {
+	Hours  int     `json:"hours" binding:"required,min=1,max=8760"`
+	Reason *string `json:"reason" binding:"omitempty,max=500"`
+}
+
+// ContentFlag is a comment or announcement the content filter flagged for
+// review
+type ContentFlag struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	ContentType string     `json:"content_type" db:"content_type"`
+	ContentID   uuid.UUID  `json:"content_id" db:"content_id"`
+	Content     *string    `json:"content"` // Null once the content is deleted
+	UserID      *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
+	UserName    *string    `json:"user_name,omitempty"`
+	Reasons     []string   `json:"reasons" db:"reasons"`
+	Status      string     `json:"status" db:"status"`
+	ReviewedBy  *uuid.UUID `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ReviewContentFlagRequest resolves a flag, keeping or removing the content
+type ReviewContentFlagRequest struct {
+	Action string `json:"action" binding:"required,oneof=approve remove"`
+}
+
+// ShadowBan hides a user's posts and comments from everyone but themselves
+type ShadowBan struct {
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	FullName  string     `json:"full_name" db:"full_name"`
+	Reason    *string    `json:"reason,omitempty" db:"reason"`
+	BannedBy  *uuid.UUID `json:"banned_by,omitempty" db:"banned_by"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ShadowBanRequest shadow bans a user
+type ShadowBanRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
+	Reason *string   `json:"reason" binding:"omitempty,max=500"`
+}
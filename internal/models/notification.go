@@ -0,0 +1,72 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification represents an entry in a user's in-app notification inbox
+type Notification struct {
+	ID        uuid.UUID       `json:"id" db:"id"`
+	UserID    uuid.UUID       `json:"user_id" db:"user_id"`
+	Category  string          `json:"category" db:"category"`
+	Title     string          `json:"title" db:"title"`
+	Body      string          `json:"body" db:"body"`
+	Data      json.RawMessage `json:"data,omitempty" db:"data"`
+	ReadAt    *time.Time      `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+// ListNotificationsQuery represents inbox filters
+type ListNotificationsQuery struct {
+	UnreadOnly bool `form:"unread_only"`
+	Page       int  `form:"page"`
+	PageSize   int  `form:"page_size"`
+}
+
+// NotificationsListResponse is the paginated inbox with the unread badge count
+type NotificationsListResponse struct {
+	Notifications []Notification `json:"notifications"`
+	UnreadCount   int            `json:"unread_count"`
+	Page          int            `json:"page"`
+	PageSize      int            `json:"page_size"`
+}
+
+// NotificationPreference is a user's channel settings for one category
+type NotificationPreference struct {
+	Category string `json:"category"`
+	Push     bool   `json:"push"`
+	Email    bool   `json:"email"`
+}
+
+// NotificationPreferenceUpdate changes one category. Omitted channels keep
+// their current setting.
+type NotificationPreferenceUpdate struct {
+	Category string `json:"category" binding:"required"`
+	Push     *bool  `json:"push"`
+	Email    *bool  `json:"email"`
+}
+
+// UpdateNotificationPreferencesRequest changes one or more categories
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreferenceUpdate `json:"preferences" binding:"required,min=1,dive"`
+}
+
+// QuietHoursSettings are the quiet hours that apply to the current user.
+// Custom is false while the campus-wide window is in use.
+type QuietHoursSettings struct {
+	Enabled bool   `json:"enabled"`
+	Start   string `json:"start,omitempty"` // "HH:MM", campus time
+	End     string `json:"end,omitempty"`
+	Custom  bool   `json:"custom"`
+}
+
+// UpdateQuietHoursRequest sets the current user's quiet hours. Start and end
+// are given together; omitting both keeps the campus-wide window.
+type UpdateQuietHoursRequest struct {
+	Enabled *bool   `json:"enabled" binding:"required"`
+	Start   *string `json:"start"`
+	End     *string `json:"end"`
+}
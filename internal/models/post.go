@@ -25,6 +25,22 @@ const (
 	StorageClassArchive  StorageClass = "ARCHIVE"
 )
 
+// Post statuses. Drafts are held until an admin publishes or schedules
+// them; scheduled posts are published at PublishAt.
+const (
+	PostStatusDraft     = "draft"
+	PostStatusScheduled = "scheduled"
+	PostStatusPublished = "published"
+)
+
+// PostAudience narrows who sees a post to a department, a year and a house;
+// users must match every one given. An empty audience is everyone.
+type PostAudience struct {
+	Department *string    `json:"department,omitempty" binding:"omitempty,min=1,max=100"`
+	Year       *int       `json:"year,omitempty" binding:"omitempty,min=1,max=10"`
+	HouseID    *uuid.UUID `json:"house_id,omitempty"`
+}
+
 // Post represents an announcement post
 type Post struct {
 	ID        uuid.UUID  `json:"id" db:"id"`
@@ -42,6 +58,7 @@ type Post struct {
 	Hashtags     []string    `json:"hashtags" db:"hashtags"`
 
 	// Metadata
+	Version   int        `json:"version" db:"version"`
 	CreatedAt time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
@@ -50,18 +67,35 @@ type Post struct {
 	ArchivedAt   *time.Time   `json:"archived_at,omitempty" db:"archived_at"`
 	StorageClass StorageClass `json:"storage_class" db:"storage_class"`
 
+	// Set while an image that failed the image check awaits review; only
+	// the creator sees the post meanwhile
+	HeldForReview bool `json:"held_for_review" db:"held_for_review"`
+
+	// Publication: drafts and scheduled posts are only seen by their creator
+	Status      string       `json:"status" db:"status"`
+	PublishAt   *time.Time   `json:"publish_at,omitempty" db:"publish_at"`
+	PublishedAt *time.Time   `json:"published_at,omitempty" db:"published_at"`
+	Audience    PostAudience `json:"audience"`
+
+	// Curation: a pinned post stays at the top of the feed, and a featured
+	// one is in the carousel at FeaturedPosition
+	PinnedAt         *time.Time `json:"pinned_at,omitempty" db:"pinned_at"`
+	FeaturedPosition *int       `json:"featured_position,omitempty" db:"featured_position"`
+
 	// Metrics
-	LikeCount    int `json:"like_count" db:"like_count"`
-	CommentCount int `json:"comment_count" db:"comment_count"`
-	ShareCount   int `json:"share_count" db:"share_count"`
-	ViewCount    int `json:"view_count" db:"view_count"`
+	LikeCount      int            `json:"like_count" db:"like_count"` // Reactions of every type
+	ReactionCounts ReactionCounts `json:"reaction_counts" db:"reaction_counts"`
+	CommentCount   int            `json:"comment_count" db:"comment_count"`
+	ShareCount     int            `json:"share_count" db:"share_count"`
+	ViewCount      int            `json:"view_count" db:"view_count"`
 }
 
 // PostResponse is the response DTO with additional user data
 type PostResponse struct {
 	Post
 	Creator      UserSummary `json:"creator"`
-	IsLikedByMe  bool        `json:"is_liked_by_me"`
+	IsLikedByMe  bool        `json:"is_liked_by_me"` // Reacted in any way
+	MyReaction   *string     `json:"my_reaction"`
 	IsSharedByMe bool        `json:"is_shared_by_me"`
 }
 
@@ -76,12 +110,41 @@ type CreatePostRequest struct {
 	DurationSecs *int        `json:"duration_seconds,omitempty"`
 	Description  string      `json:"description" binding:"required,min=1,max=2000"`
 	Hashtags     []string    `json:"hashtags,omitempty"`
+	// Draft holds the post until it is published or scheduled; PublishAt, in
+	// the future, schedules it. Without either it is published at once.
+	Draft     bool         `json:"draft,omitempty"`
+	PublishAt *time.Time   `json:"publish_at,omitempty"`
+	Audience  PostAudience `json:"audience"`
+}
+
+// SchedulePostRequest publishes a draft or scheduled post now, schedules it
+// for PublishAt, or with Draft turns it back into a draft
+type SchedulePostRequest struct {
+	Draft     bool       `json:"draft,omitempty"`
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+}
+
+// FeaturePostRequest adds a post to the featured carousel, or moves it, at
+// Position; past the end, or without one, it goes last
+type FeaturePostRequest struct {
+	Position *int `json:"position,omitempty" binding:"omitempty,min=0"`
+}
+
+// ListUnpublishedPostsQuery pages through drafts and scheduled posts, those
+// due soonest first
+type ListUnpublishedPostsQuery struct {
+	Status   string `form:"status" binding:"omitempty,oneof=draft scheduled"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
 }
 
 // UpdatePostRequest is the request to update an existing post
 type UpdatePostRequest struct {
 	Description *string   `json:"description,omitempty" binding:"omitempty,min=1,max=2000"`
 	Hashtags    *[]string `json:"hashtags,omitempty"`
+	// Version is the version of the post the edit was made against. If the
+	// post has been edited since, the update is refused.
+	Version *int `json:"version,omitempty"`
 }
 
 // PostLike represents a user's like on a post
@@ -101,6 +164,7 @@ type PostComment struct {
 	ParentCommentID *uuid.UUID `json:"parent_comment_id,omitempty" db:"parent_comment_id"`
 	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+	EditedAt        *time.Time `json:"edited_at" db:"edited_at"` // Null unless edited
 	DeletedAt       *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
 }
 
@@ -132,6 +196,39 @@ type PostView struct {
 	UserID              *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
 	ViewedAt            time.Time  `json:"viewed_at" db:"viewed_at"`
 	DurationWatchedSecs *int       `json:"duration_watched_seconds,omitempty" db:"duration_watched_seconds"`
+	CompletionPercent   *int       `json:"completion_percent,omitempty" db:"completion_percent"`
+}
+
+// TrackViewRequest is the optional body of a post view: how long the viewer
+// watched, and how far through a video they got
+type TrackViewRequest struct {
+	DurationWatchedSecs *int `json:"duration_watched_seconds" binding:"omitempty,min=0,max=86400"`
+	CompletionPercent   *int `json:"completion_percent" binding:"omitempty,min=0,max=100"`
+}
+
+// PostViewAnalytics summarizes how a post is watched
+type PostViewAnalytics struct {
+	PostID        uuid.UUID   `json:"post_id"`
+	ContentType   ContentType `json:"content_type"`
+	DurationSecs  *int        `json:"duration_seconds,omitempty"` // The video's length
+	Views         int         `json:"views"`
+	UniqueViewers int         `json:"unique_viewers"` // Signed-in viewers
+	TimedViews    int         `json:"timed_views"`    // Views that reported a watch time
+	// Averages over the views that reported them; null without any
+	AvgWatchSecs         *float64 `json:"avg_watch_seconds"`
+	AvgCompletionPercent *float64 `json:"avg_completion_percent"`
+	CompletedViews       int      `json:"completed_views"` // Watched to the end
+	// The share of views with a completion still watching at each tenth of
+	// the video: the first point is 100, and the drops show where viewers
+	// leave
+	DropOff []DropOffPoint `json:"drop_off"`
+}
+
+// DropOffPoint is the percentage of viewers still watching at a point in a
+// video
+type DropOffPoint struct {
+	AtPercent       int     `json:"at_percent"`
+	WatchingPercent float64 `json:"watching_percent"`
 }
 
 // Story represents a 24-hour ephemeral story
@@ -154,6 +251,10 @@ type Story struct {
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
 
+	// Set while an image that failed the image check awaits review; only
+	// the creator sees the story meanwhile
+	HeldForReview bool `json:"held_for_review" db:"held_for_review"`
+
 	// Metrics
 	ViewCount int `json:"view_count" db:"view_count"`
 	LikeCount int `json:"like_count" db:"like_count"`
@@ -213,6 +314,9 @@ type ListPostsQuery struct {
 	ClubID   *uuid.UUID `form:"club_id" binding:"omitempty"`
 	HouseID  *uuid.UUID `form:"house_id" binding:"omitempty"`
 	Search   *string    `form:"q" binding:"omitempty,min=1"`
+	// ViewerID is the signed-in user, who sees their own posts even when
+	// shadow banned
+	ViewerID *uuid.UUID `form:"-"`
 }
 
 // PostsListResponse is the paginated response for posts
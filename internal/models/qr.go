@@ -0,0 +1,13 @@
+package models
+
+// VerifyQRRequest is the contents of a scanned signed QR code
+type VerifyQRRequest struct {
+	Payload string `json:"payload" binding:"required"`
+}
+
+// VerifiedQR is the result of checking a signed QR code. Data is what a
+// genuine code was generated for.
+type VerifiedQR struct {
+	Valid bool   `json:"valid"`
+	Data  string `json:"data,omitempty"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Reaction types. A user has at most one reaction on a post or
+// announcement; the like endpoints toggle a like reaction.
+const (
+	ReactionLike = "like"
+	ReactionLove = "love"
+	ReactionClap = "clap"
+	ReactionFire = "fire"
+)
+
+// ReactionCounts counts reactions by type. Types nobody used are omitted.
+type ReactionCounts map[string]int
+
+// Scan reads counts stored as a JSON object
+func (rc *ReactionCounts) Scan(value interface{}) error {
+	*rc = ReactionCounts{}
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, rc)
+	case string:
+		return json.Unmarshal([]byte(v), rc)
+	}
+	return fmt.Errorf("cannot scan %T into ReactionCounts", value)
+}
+
+// ReactRequest sets the user's reaction, replacing any they had
+type ReactRequest struct {
+	Reaction string `json:"reaction" binding:"required,oneof=like love clap fire"`
+}
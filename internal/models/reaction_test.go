@@ -0,0 +1,34 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReactionCountsScan(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  ReactionCounts
+	}{
+		{"json", []byte(`{"like": 3, "fire": 1}`), ReactionCounts{"like": 3, "fire": 1}},
+		{"empty", []byte(`{}`), ReactionCounts{}},
+		{"null", nil, ReactionCounts{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ReactionCounts
+			if err := got.Scan(tt.value); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Scan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var rc ReactionCounts
+	if err := rc.Scan(42); err == nil {
+		t.Error("Scan(int) succeeded, want an error")
+	}
+}
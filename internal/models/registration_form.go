@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Registration form field types
+const (
+	FormFieldText        = "text"
+	FormFieldTextarea    = "textarea"
+	FormFieldNumber      = "number"
+	FormFieldSelect      = "select"
+	FormFieldMultiSelect = "multiselect"
+	FormFieldCheckbox    = "checkbox"
+	FormFieldURL         = "url"
+	FormFieldEmail       = "email"
+)
+
+// FormField is one extra field of an event's registration form
+type FormField struct {
+	Key       string   `json:"key" binding:"required,max=50"` // Response key: lowercase letters, digits and underscores
+	Label     string   `json:"label" binding:"required,max=255"`
+	Type      string   `json:"type" binding:"required,oneof=text textarea number select multiselect checkbox url email"`
+	Required  bool     `json:"required"`
+	Options   []string `json:"options,omitempty"`    // select and multiselect
+	MaxLength int      `json:"max_length,omitempty"` // text and textarea; zero uses the default
+	Min       *float64 `json:"min,omitempty"`        // number
+	Max       *float64 `json:"max,omitempty"`        // number
+	HelpText  *string  `json:"help_text,omitempty"`
+}
+
+// UpdateRegistrationFormRequest replaces an event's registration form
+type UpdateRegistrationFormRequest struct {
+	Fields []FormField `json:"fields" binding:"max=30,dive"`
+}
+
+// FormResponses are a registrant's answers keyed by field key
+type FormResponses map[string]interface{}
+
+// SubmitFormResponsesRequest updates a registrant's answers
+type SubmitFormResponsesRequest struct {
+	Responses FormResponses `json:"responses"`
+}
+
+// EventRegistrant is a registration with the registrant's answers
+type EventRegistrant struct {
+	UserID       uuid.UUID     `json:"user_id"`
+	FullName     string        `json:"full_name"`
+	Email        string        `json:"email"`
+	RegisteredAt time.Time     `json:"registered_at"`
+	TicketTier   *string       `json:"ticket_tier,omitempty"` // Name of the tier bought
+	Responses    FormResponses `json:"responses"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Registration transfer statuses
+const (
+	TransferPending   = "pending"
+	TransferAccepted  = "accepted"
+	TransferCancelled = "cancelled"
+)
+
+// RegistrationTransfer hands a registration to another user. Token is only
+// shown to the registrant who created it.
+type RegistrationTransfer struct {
+	ID          uuid.UUID  `json:"id"`
+	EventID     uuid.UUID  `json:"event_id"`
+	FromUserID  uuid.UUID  `json:"from_user_id"`
+	ToUserID    *uuid.UUID `json:"to_user_id,omitempty"`
+	Token       string     `json:"token,omitempty"`
+	Status      string     `json:"status"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// AcceptTransferRequest is a transfer token shared by a registrant
+type AcceptTransferRequest struct {
+	Token string `json:"token" binding:"required"`
+}
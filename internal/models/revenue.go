@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventRevenue is what an event's registrations brought in
+type EventRevenue struct {
+	EventID   uuid.UUID `json:"event_id"`
+	Title     string    `json:"title"`
+	StartDate time.Time `json:"start_date"`
+	Currency  string    `json:"currency"`
+	// PaidRegistrations counts payments taken, including those since
+	// refunded
+	PaidRegistrations int     `json:"paid_registrations"`
+	Gross             float64 `json:"gross"`
+	Refunded          float64 `json:"refunded"`
+	RefundPending     float64 `json:"refund_pending"`
+	// Net is gross less refunds, made and pending
+	Net float64 `json:"net"`
+}
+
+// RevenueTotal sums event revenue in one currency
+type RevenueTotal struct {
+	Currency          string  `json:"currency"`
+	PaidRegistrations int     `json:"paid_registrations"`
+	Gross             float64 `json:"gross"`
+	Refunded          float64 `json:"refunded"`
+	RefundPending     float64 `json:"refund_pending"`
+	Net               float64 `json:"net"`
+}
+
+// ClubRevenue reports a club's revenue per event, for a term or overall
+type ClubRevenue struct {
+	ClubID uuid.UUID      `json:"club_id"`
+	TermID *uuid.UUID     `json:"term_id,omitempty"`
+	Events []EventRevenue `json:"events"`
+	Totals []RevenueTotal `json:"totals"`
+}
+
+// Settlement statuses of a payment
+const (
+	SettlementSettled   = "settled"
+	SettlementUnsettled = "unsettled" // In the report, not yet paid out
+	SettlementMissing   = "missing"   // Not in the month's report
+)
+
+// PaymentSettlement matches a payment taken for a club's event with its line
+// in Razorpay's settlement report
+type PaymentSettlement struct {
+	PaymentID         uuid.UUID  `json:"payment_id"`
+	EventID           uuid.UUID  `json:"event_id"`
+	EventTitle        string     `json:"event_title"`
+	RazorpayPaymentID string     `json:"razorpay_payment_id"`
+	Amount            float64    `json:"amount"`
+	Currency          string     `json:"currency"`
+	Status            string     `json:"status"` // The payment's own status
+	CreatedAt         time.Time  `json:"created_at"`
+	Settlement        string     `json:"settlement"`
+	Fee               *float64   `json:"fee,omitempty"`
+	Tax               *float64   `json:"tax,omitempty"`
+	SettlementID      *string    `json:"settlement_id,omitempty"`
+	SettlementUTR     *string    `json:"settlement_utr,omitempty"`
+	SettledAt         *time.Time `json:"settled_at,omitempty"`
+}
+
+// ClubSettlements reconciles a month of a club's payments with Razorpay's
+// settlement report
+type ClubSettlements struct {
+	ClubID   uuid.UUID           `json:"club_id"`
+	Year     int                 `json:"year"`
+	Month    int                 `json:"month"`
+	Payments []PaymentSettlement `json:"payments"`
+	Settled  int                 `json:"settled"`
+	Pending  int                 `json:"pending"` // Unsettled or missing
+}
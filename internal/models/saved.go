@@ -0,0 +1,7 @@
+package models
+
+// SavedContent is what a user has bookmarked, most recently saved first
+type SavedContent struct {
+	Posts  []PostResponse `json:"posts"`
+	Events []Event        `json:"events"`
+}
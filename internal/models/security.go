@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SecurityAlert is raised when a suspicious activity rule trips
+type SecurityAlert struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	Rule       string     `json:"rule" db:"rule"`         // failed_logins, signup_burst or comment_spam
+	Severity   string     `json:"severity" db:"severity"` // medium or high
+	UserID     *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
+	UserName   *string    `json:"user_name,omitempty" db:"-"`
+	UserEmail  *string    `json:"user_email,omitempty" db:"-"`
+	IPAddress  *string    `json:"ip_address,omitempty" db:"ip_address"`
+	EventCount int        `json:"event_count" db:"event_count"`
+	// Response is what was done automatically: none, lockout or mute
+	Response       string     `json:"response" db:"response"`
+	ResponseUntil  *time.Time `json:"response_until,omitempty" db:"response_until"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty" db:"acknowledged_at"`
+	AcknowledgedBy *uuid.UUID `json:"acknowledged_by,omitempty" db:"acknowledged_by"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ListSecurityAlertsQuery filters the security alert feed
+type ListSecurityAlertsQuery struct {
+	Status   string `form:"status"` // open (default), acknowledged or all
+	Rule     string `form:"rule"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+}
+
+// SecurityLockout temporarily locks an account's password login, or blocks
+// sign-ups from an IP address
+type SecurityLockout struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	Kind        string     `json:"kind" db:"kind"` // login or signup
+	UserID      *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
+	UserName    *string    `json:"user_name,omitempty" db:"-"`
+	IPAddress   *string    `json:"ip_address,omitempty" db:"ip_address"`
+	LockedUntil time.Time  `json:"locked_until" db:"locked_until"`
+	AlertID     *uuid.UUID `json:"alert_id,omitempty" db:"alert_id"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/yourusername/college-event-backend/internal/settings"
+)
+
+// RuntimeSettings are the runtime settings in force, with what admins have
+// overridden and the defaults from config
+type RuntimeSettings struct {
+	Settings  settings.Settings          `json:"settings"`
+	Overrides map[string]json.RawMessage `json:"overrides"`
+	Defaults  settings.Settings          `json:"defaults"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShortLink is a short URL to an event, tagged with where it is shared
+type ShortLink struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	Code          string     `json:"code" db:"code"`
+	EventID       uuid.UUID  `json:"event_id" db:"event_id"`
+	Source        string     `json:"source" db:"source"`
+	ClickCount    int        `json:"click_count" db:"click_count"`
+	LastClickedAt *time.Time `json:"last_clicked_at,omitempty" db:"last_clicked_at"`
+	CreatedBy     *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	// Computed fields
+	URL string `json:"url" db:"-"`
+}
+
+// CreateShortLinkRequest creates a short link to an event. Without a code,
+// one is generated.
+type CreateShortLinkRequest struct {
+	Source string  `json:"source" binding:"required,max=50"`
+	Code   *string `json:"code" binding:"omitempty,min=4,max=16,alphanum"`
+}
+
+// EventPoster is a generated event poster
+type EventPoster struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	// ShortLink is the link the poster's QR code opens
+	ShortLink ShortLink `json:"short_link"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sponsor tiers, highest first
+const (
+	SponsorTierTitle  = "title"
+	SponsorTierGold   = "gold"
+	SponsorTierSilver = "silver"
+)
+
+// Sponsor is shown on an event or a fest
+type Sponsor struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	EventID   *uuid.UUID `json:"event_id,omitempty" db:"event_id"`
+	FestID    *uuid.UUID `json:"fest_id,omitempty" db:"fest_id"`
+	Name      string     `json:"name" db:"name"`
+	Tier      string     `json:"tier" db:"tier"`
+	LogoURL   *string    `json:"logo_url,omitempty" db:"logo_url"`
+	LinkURL   *string    `json:"link_url,omitempty" db:"link_url"`
+	SortOrder int        `json:"sort_order" db:"sort_order"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// CreateSponsorRequest is the multipart form for adding a sponsor. The logo
+// is sent as the "logo" file field.
+type CreateSponsorRequest struct {
+	Name      string  `form:"name" binding:"required,max=255"`
+	Tier      string  `form:"tier" binding:"required,oneof=title gold silver"`
+	LinkURL   *string `form:"link_url" binding:"omitempty,url,max=500"`
+	SortOrder int     `form:"sort_order"`
+}
+
+// UpdateSponsorRequest is the multipart form for editing a sponsor. A new
+// "logo" file replaces the current one.
+type UpdateSponsorRequest struct {
+	Name      *string `form:"name" binding:"omitempty,max=255"`
+	Tier      *string `form:"tier" binding:"omitempty,oneof=title gold silver"`
+	LinkURL   *string `form:"link_url" binding:"omitempty,url,max=500"`
+	SortOrder *int    `form:"sort_order"`
+}
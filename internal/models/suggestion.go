@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SuggestionStatus is a suggestion's triage state
+type SuggestionStatus string
+
+const (
+	SuggestionNew       SuggestionStatus = "new"
+	SuggestionReviewing SuggestionStatus = "reviewing"
+	SuggestionResolved  SuggestionStatus = "resolved"
+)
+
+// Valid reports whether s is a known status
+func (s SuggestionStatus) Valid() bool {
+	switch s {
+	case SuggestionNew, SuggestionReviewing, SuggestionResolved:
+		return true
+	}
+	return false
+}
+
+// Suggestion is an anonymous piece of feedback for the admins (ClubID nil)
+// or a club. It deliberately has no submitter field.
+type Suggestion struct {
+	ID             uuid.UUID        `json:"id" db:"id"`
+	ClubID         *uuid.UUID       `json:"club_id,omitempty" db:"club_id"`
+	Subject        string           `json:"subject" db:"subject"`
+	Body           string           `json:"body" db:"body"`
+	Status         SuggestionStatus `json:"status" db:"status"`
+	Response       *string          `json:"response,omitempty" db:"response"`
+	ResponsePublic bool             `json:"response_public" db:"response_public"`
+	RespondedAt    *time.Time       `json:"responded_at,omitempty" db:"responded_at"`
+	CreatedAt      time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// CreateSuggestionRequest represents suggestion submission data
+type CreateSuggestionRequest struct {
+	ClubID  *uuid.UUID `json:"club_id"`
+	Subject string     `json:"subject" binding:"required,max=255"`
+	Body    string     `json:"body" binding:"required,max=5000"`
+}
+
+// TriageSuggestionRequest moves a suggestion through triage and optionally
+// responds to it
+type TriageSuggestionRequest struct {
+	Status         *SuggestionStatus `json:"status"`
+	Response       *string           `json:"response" binding:"omitempty,max=5000"`
+	ResponsePublic *bool             `json:"response_public"`
+}
+
+// ListSuggestionsQuery represents triage list filters
+type ListSuggestionsQuery struct {
+	Status   string `form:"status"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AcademicTerm is a semester or academic year. Dates are YYYY-MM-DD and
+// inclusive.
+type AcademicTerm struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	Name       string     `json:"name" db:"name"`
+	StartsOn   string     `json:"starts_on" db:"starts_on"`
+	EndsOn     string     `json:"ends_on" db:"ends_on"`
+	IsCurrent  bool       `json:"is_current"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty" db:"archived_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+
+	// EndsAcademicYear marks an academic year's last term. Students
+	// graduating that year become alumni once it ends.
+	EndsAcademicYear bool `json:"ends_academic_year" db:"ends_academic_year"`
+}
+
+// CreateTermRequest represents academic term creation data
+type CreateTermRequest struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	StartsOn string `json:"starts_on" binding:"required"`
+	EndsOn   string `json:"ends_on" binding:"required"`
+	// EndsAcademicYear marks the last term of an academic year
+	EndsAcademicYear bool `json:"ends_academic_year"`
+}
+
+// UpdateTermRequest represents academic term update data. Archived terms
+// can be renamed but not moved.
+type UpdateTermRequest struct {
+	Name     *string `json:"name" binding:"omitempty,max=100"`
+	StartsOn *string `json:"starts_on"`
+	EndsOn   *string `json:"ends_on"`
+	// EndsAcademicYear marks the last term of an academic year
+	EndsAcademicYear *bool `json:"ends_academic_year"`
+}
+
+// TermStanding is a house's or user's final position in a term
+type TermStanding struct {
+	Rank      int       `json:"rank"`
+	SubjectID uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Points    int       `json:"points"`
+}
+
+// TermStandingsResponse is a term's house and user leaderboards: the final
+// snapshot once archived, live until then
+type TermStandingsResponse struct {
+	Term   AcademicTerm   `json:"term"`
+	Final  bool           `json:"final"`
+	Houses []TermStanding `json:"houses"`
+	Users  []TermStanding `json:"users"`
+}
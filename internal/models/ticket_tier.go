@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TicketTier is a kind of ticket sold for a paid event, such as early bird
+// or VIP
+type TicketTier struct {
+	ID      uuid.UUID `json:"id"`
+	EventID uuid.UUID `json:"event_id"`
+	Name    string    `json:"name"`
+	Price   float64   `json:"price"`
+	// Quantity is how many are for sale; nil is unlimited
+	Quantity   *int       `json:"quantity,omitempty"`
+	Sold       int        `json:"sold"`
+	SalesStart *time.Time `json:"sales_start,omitempty"`
+	SalesEnd   *time.Time `json:"sales_end,omitempty"`
+	SortOrder  int        `json:"sort_order"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// Computed when loaded
+	Remaining *int `json:"remaining,omitempty"` // nil is unlimited
+	Available bool `json:"available"`           // On sale and not sold out
+}
+
+// Compute fills in Remaining and Available as of now
+func (t *TicketTier) Compute(now time.Time) {
+	t.Remaining = nil
+	if t.Quantity != nil {
+		n := *t.Quantity - t.Sold
+		if n < 0 {
+			n = 0
+		}
+		t.Remaining = &n
+	}
+	onSale := (t.SalesStart == nil || !now.Before(*t.SalesStart)) &&
+		(t.SalesEnd == nil || now.Before(*t.SalesEnd))
+	t.Available = onSale && (t.Remaining == nil || *t.Remaining > 0)
+}
+
+// CreateTicketTierRequest adds a ticket tier to an event
+type CreateTicketTierRequest struct {
+	Name       string     `json:"name" binding:"required,max=50"`
+	Price      *float64   `json:"price" binding:"required,min=0"`
+	Quantity   *int       `json:"quantity" binding:"omitempty,min=1"`
+	SalesStart *time.Time `json:"sales_start"`
+	SalesEnd   *time.Time `json:"sales_end"`
+	SortOrder  int        `json:"sort_order"`
+}
+
+// UpdateTicketTierRequest edits a ticket tier; omitted fields are kept
+type UpdateTicketTierRequest struct {
+	Name       *string    `json:"name" binding:"omitempty,max=50"`
+	Price      *float64   `json:"price" binding:"omitempty,min=0"`
+	Quantity   *int       `json:"quantity" binding:"omitempty,min=1"`
+	SalesStart *time.Time `json:"sales_start"`
+	SalesEnd   *time.Time `json:"sales_end"`
+	SortOrder  *int       `json:"sort_order"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTicketTierCompute(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	before, after := now.Add(-time.Hour), now.Add(time.Hour)
+	intPtr := func(n int) *int { return &n }
+
+	tests := []struct {
+		name      string
+		tier      TicketTier
+		remaining *int
+		available bool
+	}{
+		{"unlimited", TicketTier{}, nil, true},
+		{"some left", TicketTier{Quantity: intPtr(100), Sold: 40}, intPtr(60), true},
+		{"sold out", TicketTier{Quantity: intPtr(100), Sold: 100}, intPtr(0), false},
+		{"quantity cut below sold", TicketTier{Quantity: intPtr(50), Sold: 60}, intPtr(0), false},
+		{"in window", TicketTier{SalesStart: &before, SalesEnd: &after}, nil, true},
+		{"not started", TicketTier{SalesStart: &after}, nil, false},
+		{"ended", TicketTier{SalesEnd: &before}, nil, false},
+		{"ends now", TicketTier{SalesEnd: &now}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.tier.Compute(now)
+			if (tt.tier.Remaining == nil) != (tt.remaining == nil) ||
+				(tt.remaining != nil && *tt.tier.Remaining != *tt.remaining) {
+				t.Errorf("Remaining = %v, want %v", tt.tier.Remaining, tt.remaining)
+			}
+			if tt.tier.Available != tt.available {
+				t.Errorf("Available = %v, want %v", tt.tier.Available, tt.available)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrashItem is a deleted event, post or story that can still be restored
+type TrashItem struct {
+	Type string    `json:"type"` // event, post or story
+	ID   uuid.UUID `json:"id"`
+	// Title is an event's title, or the start of a post or story's
+	// description
+	Title     string     `json:"title"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"`
+	DeletedAt time.Time  `json:"deleted_at"`
+	// PurgeAt is when the cleanup job deletes it for good
+	PurgeAt time.Time `json:"purge_at"`
+}
+
+// ListTrashQuery filters and pages the trash
+type ListTrashQuery struct {
+	Type     *string `form:"type" binding:"omitempty,oneof=event post story"`
+	Page     int     `form:"page"`
+	PageSize int     `form:"page_size"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UploadedImage is the result of one file of a bulk upload. On failure
+// only Filename and Error are set.
+type UploadedImage struct {
+	Filename  string `json:"filename"`
+	Success   bool   `json:"success"`
+	Error     string `json:"error,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Path      string `json:"path,omitempty"`
+	SizeBytes int64  `json:"size_bytes,omitempty"`
+	Width     int    `json:"width,omitempty"`
+	Height    int    `json:"height,omitempty"`
+}
+
+// BulkUploadResult reports a bulk upload file by file, in the order sent
+type BulkUploadResult struct {
+	Uploaded int             `json:"uploaded"`
+	Failed   int             `json:"failed"`
+	Results  []UploadedImage `json:"results"`
+}
+
+// Upload session statuses
+const (
+	UploadSessionUploading = "uploading"
+	UploadSessionCompleted = "completed"
+)
+
+// UploadSession is a resumable upload and how far it has got. URL and Path
+// are set once it completes.
+type UploadSession struct {
+	ID          uuid.UUID `json:"id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Folder      string    `json:"folder"`
+	Size        int64     `json:"size"`
+	Received    int64     `json:"received"`
+	Progress    float64   `json:"progress"` // Percent received
+	Status      string    `json:"status"`   // uploading, completed
+	URL         *string   `json:"url,omitempty"`
+	Path        *string   `json:"path,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
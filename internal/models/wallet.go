@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Wallet is a user's campus credit balance with their latest transactions
+type Wallet struct {
+	UserID       uuid.UUID           `json:"user_id"`
+	Balance      float64             `json:"balance"`
+	Currency     string              `json:"currency"`
+	Transactions []WalletTransaction `json:"transactions"`
+}
+
+// WalletTransaction is an entry in a wallet's ledger
+type WalletTransaction struct {
+	ID           uuid.UUID  `json:"id"`
+	Amount       float64    `json:"amount"` // Negative for debits
+	BalanceAfter float64    `json:"balance_after"`
+	Kind         string     `json:"kind"` // credit, payment, refund
+	Description  *string    `json:"description,omitempty"`
+	PaymentID    *uuid.UUID `json:"payment_id,omitempty"`
+	CreatedBy    *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// CreditWalletRequest grants a user campus credits
+type CreditWalletRequest struct {
+	UserID      uuid.UUID `json:"user_id" binding:"required"`
+	Amount      float64   `json:"amount" binding:"required,gt=0,lte=100000"`
+	Description string    `json:"description" binding:"required,max=200"` // e.g. "Fest coupon"
+}
+
+// ListWalletTransactionsQuery pages through a wallet's ledger
+type ListWalletTransactionsQuery struct {
+	Page     int `form:"page"`
+	PageSize int `form:"page_size"`
+}
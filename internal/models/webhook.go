@@ -0,0 +1,65 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookSubscription is an external endpoint that receives events. The
+// secret is only returned when the subscription is created or rotated.
+type WebhookSubscription struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	URL         string     `json:"url" db:"url"`
+	Description *string    `json:"description,omitempty" db:"description"`
+	Secret      string     `json:"secret,omitempty" db:"secret"`
+	EventTypes  []string   `json:"event_types" db:"event_types"`
+	Active      bool       `json:"active" db:"active"`
+	CreatedBy   *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// CreateWebhookRequest subscribes an endpoint. A secret is generated when
+// none is given.
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" binding:"required,url,max=500"`
+	Description *string  `json:"description" binding:"omitempty,max=255"`
+	Secret      *string  `json:"secret" binding:"omitempty,min=16,max=128"`
+	EventTypes  []string `json:"event_types" binding:"required,min=1"`
+}
+
+// UpdateWebhookRequest changes a subscription; omitted fields are unchanged
+type UpdateWebhookRequest struct {
+	URL         *string  `json:"url" binding:"omitempty,url,max=500"`
+	Description *string  `json:"description" binding:"omitempty,max=255"`
+	EventTypes  []string `json:"event_types" binding:"omitempty,min=1"`
+	Active      *bool    `json:"active"`
+}
+
+// WebhookDelivery is one event sent, or to be sent, to a subscription
+type WebhookDelivery struct {
+	ID             uuid.UUID       `json:"id" db:"id"`
+	SubscriptionID uuid.UUID       `json:"subscription_id" db:"subscription_id"`
+	EventID        uuid.UUID       `json:"event_id" db:"event_id"`
+	EventType      string          `json:"event_type" db:"event_type"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	Status         string          `json:"status" db:"status"` // pending, delivered, failed
+	Attempts       int             `json:"attempts" db:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty" db:"last_attempt_at"`
+	LastStatusCode *int            `json:"last_status_code,omitempty" db:"last_status_code"`
+	LastError      *string         `json:"last_error,omitempty" db:"last_error"`
+	LastResponse   *string         `json:"last_response,omitempty" db:"last_response"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+}
+
+// ListWebhookDeliveriesQuery filters a subscription's delivery log
+type ListWebhookDeliveriesQuery struct {
+	Status    string `form:"status" binding:"omitempty,oneof=pending delivered failed"`
+	EventType string `form:"event_type"`
+	Page      int    `form:"page"`
+	PageSize  int    `form:"page_size"`
+}
@@ -0,0 +1,208 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+const (
+	batchSize    = 50
+	maxAttempts  = 10
+	pollInterval = 2 * time.Second
+	maxBackoff   = 30 * time.Minute
+)
+
+// Event is a single outbox record handed to a topic handler
+type Event struct {
+	ID      uuid.UUID
+	Topic   string
+	Payload json.RawMessage
+}
+
+// Handler delivers an event's side effects. Delivery is at-least-once, so
+// handlers must tolerate seeing the same event more than once.
+type Handler func(ctx context.Context, event Event) error
+
+// Write records an event in the outbox as part of the caller's transaction
+func Write(ctx context.Context, tx database.Execer, topic string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox payload: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO outbox_events (topic, payload) VALUES ($1, $2)
+	`, topic, data)
+	if err != nil {
+		return fmt.Errorf("failed to write %s outbox event: %w", topic, err)
+	}
+	return nil
+}
+
+// Relay drains the outbox and dispatches events to registered handlers
+type Relay struct {
+	db *sql.DB
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewRelay creates a new outbox relay
+func NewRelay(db *sql.DB) *Relay {
+	return &Relay{
+		db:       db,
+		handlers: make(map[string]Handler),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Register associates a handler with a topic. Must be called before Start.
+func (r *Relay) Register(topic string, h Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[topic] = h
+}
+
+// Start begins polling the outbox in the background
+func (r *Relay) Start() {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		for {
+			n, err := r.drain()
+			if err != nil {
+				log.Printf("[OUTBOX] Drain failed: %v", err)
+			}
+			if n == batchSize {
+				// Backlog remains; keep going without waiting
+				continue
+			}
+			select {
+			case <-r.stop:
+				return
+			case <-time.After(pollInterval):
+			}
+		}
+	}()
+	log.Println("[OUTBOX] Relay started")
+}
+
+// Stop waits for the in-flight batch to finish
+func (r *Relay) Stop() {
+	close(r.stop)
+	r.wg.Wait()
+	log.Println("[OUTBOX] Relay stopped")
+}
+
+// drain delivers one batch of pending events. Rows stay locked for the
+// duration of the batch so concurrent relays never deliver the same event at
+// the same time; an event is only marked processed after its handler succeeds.
+func (r *Relay) drain() (int, error) {
+	ctx := context.Background()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, topic, payload, attempts
+		FROM outbox_events
+		WHERE processed_at IS NULL AND failed_at IS NULL AND available_at <= NOW()
+		ORDER BY created_at ASC
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	type pending struct {
+		Event
+		attempts int
+	}
+	var batch []pending
+	for rows.Next() {
+		var p pending
+		if err := rows.Scan(&p.ID, &p.Topic, &p.Payload, &p.attempts); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		batch = append(batch, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, p := range batch {
+		if err := r.deliver(ctx, p.Event); err != nil {
+			attempts := p.attempts + 1
+			if attempts >= maxAttempts {
+				log.Printf("[OUTBOX] Giving up on %s event %s after %d attempts: %v", p.Topic, p.ID, attempts, err)
+				_, err = tx.ExecContext(ctx, `
+					UPDATE outbox_events SET attempts = $2, last_error = $3, failed_at = NOW()
+					WHERE id = $1
+				`, p.ID, attempts, err.Error())
+			} else {
+				log.Printf("[OUTBOX] Delivery of %s event %s failed (attempt %d): %v", p.Topic, p.ID, attempts, err)
+				_, err = tx.ExecContext(ctx, `
+					UPDATE outbox_events SET attempts = $2, last_error = $3, available_at = $4
+					WHERE id = $1
+				`, p.ID, attempts, err.Error(), time.Now().Add(retryDelay(attempts)))
+			}
+			if err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE outbox_events SET processed_at = NOW(), attempts = attempts + 1 WHERE id = $1
+		`, p.ID); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(batch), tx.Commit()
+}
+
+func (r *Relay) deliver(ctx context.Context, event Event) (err error) {
+	r.mu.RLock()
+	handler, ok := r.handlers[event.Topic]
+	r.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("no handler registered for topic %s", event.Topic)
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("panic: %v", rec)
+		}
+	}()
+	return handler(ctx, event)
+}
+
+// retryDelay doubles from 5s per attempt, capped at maxBackoff
+func retryDelay(attempts int) time.Duration {
+	delay := 5 * time.Second
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
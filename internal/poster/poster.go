@@ -0,0 +1,96 @@
+// Package poster composes shareable event posters: the event's banner,
+// title, date and venue, and a QR code to register.
+package poster
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/disintegration/imaging"
+	"github.com/yourusername/college-event-backend/pkg/qrcode"
+)
+
+// Posters are portrait 4:5, the shape Instagram shows uncropped
+const (
+	Width  = 1080
+	Height = 1350
+)
+
+const (
+	margin       = 72
+	bannerHeight = 608 // 16:9 at full width
+	qrSide       = 300
+)
+
+var (
+	background = color.RGBA{R: 0x1E, G: 0x1B, B: 0x4B, A: 0xFF} // Deep indigo
+	accent     = color.RGBA{R: 0x4F, G: 0x46, B: 0xE5, A: 0xFF} // Default club color
+	muted      = color.RGBA{R: 0xC7, G: 0xD2, B: 0xFE, A: 0xFF}
+)
+
+// Content is what a poster shows
+type Content struct {
+	// Banner is cropped to fill the top of the poster. Without one the top
+	// is a block of color.
+	Banner image.Image
+	Title  string
+	When   string
+	Where  string
+	// Caption is shown beside the QR code, such as the link it encodes
+	Caption string
+	QR      *qrcode.Code
+}
+
+// Render draws a poster
+func Render(c Content) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	bannerRect := image.Rect(0, 0, Width, bannerHeight)
+	if c.Banner != nil {
+		banner := imaging.Fill(c.Banner, Width, bannerHeight, imaging.Center, imaging.Lanczos)
+		draw.Draw(img, bannerRect, banner, image.Point{}, draw.Src)
+	} else {
+		draw.Draw(img, bannerRect, image.NewUniform(accent), image.Point{}, draw.Src)
+	}
+
+	textWidth := Width - 2*margin
+	columnWidth := Width - 3*margin - qrSide
+	title := face{font: boldFont, size: 64}
+	body := face{font: regularFont, size: 36}
+
+	y := bannerHeight + margin + title.size
+	for _, line := range title.wrap(c.Title, textWidth, 3) {
+		title.draw(img, margin, y, line, color.White)
+		y += title.size * 5 / 4
+	}
+	// The date and venue sit beside the QR code, one line each
+	y += body.size / 2
+	for _, text := range []string{c.When, c.Where} {
+		if text == "" {
+			continue
+		}
+		for _, line := range body.wrap(text, columnWidth, 1) {
+			body.draw(img, margin, y, line, muted)
+			y += body.size * 3 / 2
+		}
+	}
+
+	if c.QR != nil {
+		// The largest whole number of pixels per module that fits, so
+		// modules stay crisp
+		code := c.QR.Image(qrSide / (c.QR.Size + 8))
+		side := code.Bounds().Dx()
+		at := image.Pt(Width-margin-side, Height-margin-side)
+		draw.Draw(img, image.Rectangle{Min: at, Max: at.Add(image.Pt(side, side))}, code, image.Point{}, draw.Src)
+
+		label := face{font: boldFont, size: 40}
+		small := face{font: regularFont, size: 28}
+		label.draw(img, margin, Height-margin-side/2, "Scan to register", color.White)
+		for i, line := range small.wrap(c.Caption, columnWidth, 2) {
+			small.draw(img, margin, Height-margin-side/2+label.size+i*small.size*3/2, line, muted)
+		}
+	}
+	return img
+}
@@ -0,0 +1,74 @@
+package poster
+
+import (
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/college-event-backend/pkg/qrcode"
+)
+
+func TestWrap(t *testing.T) {
+	f := face{font: regularFont, size: 36}
+
+	lines := f.wrap("Annual Tech Fest", 2000, 3)
+	if len(lines) != 1 || lines[0] != "Annual Tech Fest" {
+		t.Errorf("short text wrapped: %q", lines)
+	}
+
+	long := strings.Repeat("hackathon ", 40)
+	lines = f.wrap(long, 400, 3)
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3: %q", len(lines), lines)
+	}
+	for _, line := range lines {
+		if w := f.width(line); w > 400 {
+			t.Errorf("line %q is %d px wide", line, w)
+		}
+	}
+	if !strings.HasSuffix(lines[2], "…") {
+		t.Errorf("truncated text doesn't end in an ellipsis: %q", lines[2])
+	}
+
+	lines = f.wrap(strings.Repeat("x", 100), 300, 5)
+	if len(lines) < 2 {
+		t.Errorf("long word not cut: %q", lines)
+	}
+}
+
+func TestRender(t *testing.T) {
+	qr, err := qrcode.Encode("https://api.example.edu/e/AB12CD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := Render(Content{
+		Banner:  image.NewRGBA(image.Rect(0, 0, 1920, 1080)),
+		Title:   "Robotics Workshop",
+		When:    "Sat, 14 Mar 2026 10:00 AM",
+		Where:   "Main Auditorium",
+		Caption: "api.example.edu/e/AB12CD",
+		QR:      qr,
+	})
+	if img.Bounds() != image.Rect(0, 0, Width, Height) {
+		t.Fatalf("poster is %v", img.Bounds())
+	}
+
+	// Some title text is drawn below the banner
+	var lit bool
+	for x := margin; x < Width-margin && !lit; x++ {
+		for y := bannerHeight + margin; y < bannerHeight+margin+64; y++ {
+			if r, _, _, _ := img.At(x, y).RGBA(); r > 0xF000 {
+				lit = true
+				break
+			}
+		}
+	}
+	if !lit {
+		t.Error("no title text drawn")
+	}
+
+	// The QR code's quiet zone is white in the bottom right
+	if r, g, b, _ := img.At(Width-margin-2, Height-margin-2).RGBA(); r != 0xFFFF || g != 0xFFFF || b != 0xFFFF {
+		t.Error("QR code not drawn in the bottom right")
+	}
+}
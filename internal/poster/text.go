@@ -0,0 +1,172 @@
+package poster
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+var (
+	regularFont = mustParse(goregular.TTF)
+	boldFont    = mustParse(gobold.TTF)
+)
+
+func mustParse(ttf []byte) *sfnt.Font {
+	f, err := sfnt.Parse(ttf)
+	if err != nil {
+		panic("poster: parsing font: " + err.Error())
+	}
+	return f
+}
+
+// face is a font at a size in pixels
+type face struct {
+	font *sfnt.Font
+	size int
+}
+
+// glyphs returns the glyphs of s and the position of each along the
+// baseline. Characters the font lacks are left out.
+func (f face) glyphs(s string) (indexes []sfnt.GlyphIndex, xs []fixed.Int26_6, width fixed.Int26_6) {
+	var buf sfnt.Buffer
+	ppem := fixed.I(f.size)
+	prev := sfnt.GlyphIndex(0)
+	for _, r := range s {
+		i, err := f.font.GlyphIndex(&buf, r)
+		if err != nil || i == 0 {
+			continue
+		}
+		if prev != 0 {
+			if k, err := f.font.Kern(&buf, prev, i, ppem, font.HintingNone); err == nil {
+				width += k
+			}
+		}
+		advance, err := f.font.GlyphAdvance(&buf, i, ppem, font.HintingNone)
+		if err != nil {
+			continue
+		}
+		indexes = append(indexes, i)
+		xs = append(xs, width)
+		width += advance
+		prev = i
+	}
+	return indexes, xs, width
+}
+
+// width returns the width of s in pixels
+func (f face) width(s string) int {
+	_, _, w := f.glyphs(s)
+	return w.Ceil()
+}
+
+// draw draws s in a color with its baseline starting at (x, y)
+func (f face) draw(dst draw.Image, x, y int, s string, c color.Color) {
+	indexes, xs, width := f.glyphs(s)
+	if len(indexes) == 0 {
+		return
+	}
+
+	// Rasterize into the line's box, with room for ascenders, descenders
+	// and glyphs overhanging their advance
+	pad := f.size / 2
+	box := image.Rect(x-pad, y-f.size-pad, x+width.Ceil()+pad, y+pad)
+	r := vector.NewRasterizer(box.Dx(), box.Dy())
+	originX, originY := float32(pad), float32(f.size+pad)
+
+	var buf sfnt.Buffer
+	ppem := fixed.I(f.size)
+	for n, i := range indexes {
+		segments, err := f.font.LoadGlyph(&buf, i, ppem, nil)
+		if err != nil {
+			continue
+		}
+		dx := originX + float32(xs[n])/64
+		pt := func(p fixed.Point26_6) (float32, float32) {
+			return dx + float32(p.X)/64, originY + float32(p.Y)/64
+		}
+		for _, seg := range segments {
+			switch seg.Op {
+			case sfnt.SegmentOpMoveTo:
+				r.MoveTo(pt(seg.Args[0]))
+			case sfnt.SegmentOpLineTo:
+				r.LineTo(pt(seg.Args[0]))
+			case sfnt.SegmentOpQuadTo:
+				x1, y1 := pt(seg.Args[0])
+				x2, y2 := pt(seg.Args[1])
+				r.QuadTo(x1, y1, x2, y2)
+			case sfnt.SegmentOpCubeTo:
+				x1, y1 := pt(seg.Args[0])
+				x2, y2 := pt(seg.Args[1])
+				x3, y3 := pt(seg.Args[2])
+				r.CubeTo(x1, y1, x2, y2, x3, y3)
+			}
+		}
+	}
+	r.Draw(dst, box, image.NewUniform(c), image.Point{})
+}
+
+// wrap breaks s into lines at most maxWidth pixels wide, at most maxLines
+// of them. Text that doesn't fit ends in an ellipsis; a word too long for a
+// line is cut.
+func (f face) wrap(s string, maxWidth, maxLines int) []string {
+	var lines []string
+	line := ""
+	words := strings.Fields(s)
+	for i := 0; i < len(words); i++ {
+		candidate := words[i]
+		if line != "" {
+			candidate = line + " " + words[i]
+		}
+		if f.width(candidate) <= maxWidth {
+			line = candidate
+			continue
+		}
+		if line == "" {
+			// A single word wider than the line
+			line = f.fit(words[i], maxWidth)
+			if rest := strings.TrimPrefix(words[i], line); rest != "" {
+				words[i] = rest
+				i--
+			}
+		} else {
+			i--
+		}
+		lines = append(lines, line)
+		line = ""
+		if len(lines) == maxLines {
+			lines[maxLines-1] = f.ellipsize(lines[maxLines-1], maxWidth)
+			return lines
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// fit returns the longest prefix of s at most maxWidth pixels wide
+func (f face) fit(s string, maxWidth int) string {
+	runes := []rune(s)
+	n := len(runes)
+	for n > 1 && f.width(string(runes[:n])) > maxWidth {
+		n--
+	}
+	return string(runes[:n])
+}
+
+// ellipsize ends s in an ellipsis, shortening it to stay within maxWidth
+func (f face) ellipsize(s string, maxWidth int) string {
+	runes := []rune(strings.TrimRight(s, " "))
+	for len(runes) > 0 && f.width(string(runes)+"…") > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimRight(string(runes), " ") + "…"
+}
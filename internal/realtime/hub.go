@@ -0,0 +1,180 @@
+// Package realtime fans out short-lived events, such as chat messages and
+// typing indicators, to the WebSocket connections subscribed to a topic.
+// With Redis configured, events go through Redis pub/sub so connections on
+// every API instance receive them; without it, or while Redis is down,
+// they reach this instance's connections only.
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+)
+
+// redisChannel carries every topic's events between instances
+const redisChannel = "campus:realtime"
+
+// subscriptionBuffer is how many events a slow connection can fall behind
+// before events are dropped for it
+const subscriptionBuffer = 64
+
+// Hub delivers events published to a topic to its subscribers
+type Hub struct {
+	redis *RedisClient // nil for a single instance
+
+	mu   sync.RWMutex
+	subs map[string]map[*Subscription]struct{}
+
+	// redisDown is set while publishing to Redis fails, so the outage is
+	// logged once rather than per event
+	redisDown bool
+}
+
+// NewHub creates a hub. redis may be nil when a single API instance runs.
+func NewHub(redis *RedisClient) *Hub {
+	return &Hub{
+		redis: redis,
+		subs:  make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+// Subscription receives the events published to its topics
+type Subscription struct {
+	C      <-chan []byte
+	c      chan []byte
+	hub    *Hub
+	topics []string
+}
+
+// Subscribe receives the events published to topics from now on. Close
+// the subscription when done.
+func (h *Hub) Subscribe(topics ...string) *Subscription {
+	c := make(chan []byte, subscriptionBuffer)
+	s := &Subscription{C: c, c: c, hub: h, topics: topics}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, t := range topics {
+		if h.subs[t] == nil {
+			h.subs[t] = make(map[*Subscription]struct{})
+		}
+		h.subs[t][s] = struct{}{}
+	}
+	return s
+}
+
+// Close stops the subscription
+func (s *Subscription) Close() {
+	h := s.hub
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, t := range s.topics {
+		delete(h.subs[t], s)
+		if len(h.subs[t]) == 0 {
+			delete(h.subs, t)
+		}
+	}
+}
+
+// envelope is an event on its way between instances
+type envelope struct {
+	Topic   string          `json:"topic"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Publish sends payload, a JSON document, to the topic's subscribers on
+// every instance
+func (h *Hub) Publish(ctx context.Context, topic string, payload []byte) {
+	if h.redis != nil {
+		msg, err := json.Marshal(envelope{Topic: topic, Payload: payload})
+		if err != nil {
+			log.Printf("[REALTIME] Failed to encode event for %s: %v", topic, err)
+			return
+		}
+		err = h.redis.Publish(ctx, redisChannel, msg)
+		h.setRedisDown(err)
+		if err == nil {
+			return // Delivered here too, through Run
+		}
+	}
+	h.deliver(topic, payload)
+}
+
+// HubStats describes a hub's subscribers and its link to other instances
+type HubStats struct {
+	Topics        int  `json:"topics"`
+	Subscriptions int  `json:"subscriptions"` // One per subscribed topic
+	Redis         bool `json:"redis"`
+	RedisDown     bool `json:"redis_down"`
+}
+
+// Stats returns the hub's current statistics, e.g. to watch subscriptions
+// from clients that never disconnect cleanly
+func (h *Hub) Stats() HubStats {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	stats := HubStats{Topics: len(h.subs), Redis: h.redis != nil, RedisDown: h.redisDown}
+	for _, subs := range h.subs {
+		stats.Subscriptions += len(subs)
+	}
+	return stats
+}
+
+func (h *Hub) setRedisDown(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err != nil && !h.redisDown {
+		log.Printf("[REALTIME] Redis unavailable, delivering events to this instance only: %v", err)
+	} else if err == nil && h.redisDown {
+		log.Println("[REALTIME] Redis available again")
+	}
+	h.redisDown = err != nil
+}
+
+// deliver hands payload to this instance's subscribers of topic. A
+// subscriber too far behind misses it.
+func (h *Hub) deliver(topic string, payload []byte) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for s := range h.subs[topic] {
+		select {
+		case s.c <- payload:
+		default:
+		}
+	}
+}
+
+// Run relays events published by every instance to this one's subscribers
+// until ctx is done, reconnecting to Redis as needed. It returns at once
+// without Redis.
+func (h *Hub) Run(ctx context.Context) {
+	if h.redis == nil {
+		return
+	}
+	backoff := time.Second
+	for {
+		err := h.redis.Subscribe(ctx, redisChannel, func(msg []byte) {
+			backoff = time.Second
+			var e envelope
+			if err := json.Unmarshal(msg, &e); err != nil {
+				log.Printf("[REALTIME] Dropping malformed event: %v", err)
+				return
+			}
+			h.deliver(e.Topic, e.Payload)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("[REALTIME] Redis subscription lost, retrying in %s: %v", backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package realtime
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis is a Redis server just capable enough for the hub: it checks
+// AUTH and relays PUBLISH to SUBSCRIBE connections
+func fakeRedis(t *testing.T, password string) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	var mu sync.Mutex
+	subscribers := map[string][]net.Conn{}
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				authed := password == ""
+				for {
+					reply, err := readReply(r)
+					if err != nil {
+						return
+					}
+					args, _ := reply.([]interface{})
+					if len(args) == 0 {
+						return
+					}
+					cmd, _ := args[0].(string)
+					switch strings.ToUpper(cmd) {
+					case "AUTH":
+						if args[1] != password {
+							conn.Write([]byte("-WRONGPASS invalid password\r\n"))
+							continue
+						}
+						authed = true
+						conn.Write([]byte("+OK\r\n"))
+					case "PING":
+						if !authed {
+							conn.Write([]byte("-NOAUTH Authentication required.\r\n"))
+							continue
+						}
+						conn.Write([]byte("+PONG\r\n"))
+					case "PUBLISH":
+						if !authed {
+							conn.Write([]byte("-NOAUTH Authentication required.\r\n"))
+							continue
+						}
+						channel, msg := args[1].(string), args[2].(string)
+						mu.Lock()
+						subs := subscribers[channel]
+						for _, s := range subs {
+							writeCommand(s, "message", channel, msg)
+						}
+						mu.Unlock()
+						conn.Write([]byte(":" + strconv.Itoa(len(subs)) + "\r\n"))
+					case "SUBSCRIBE":
+						channel := args[1].(string)
+						mu.Lock()
+						writeCommand(conn, "subscribe", channel)
+						subscribers[channel] = append(subscribers[channel], conn)
+						mu.Unlock()
+					}
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func receive(t *testing.T, s *Subscription) string {
+	t.Helper()
+	select {
+	case payload := <-s.C:
+		return string(payload)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event received")
+		return ""
+	}
+}
+
+func TestReadReply(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("*3\r\n$7\r\nmessage\r\n$2\r\nch\r\n$5\r\nhi\r\nx\r\n-ERR nope\r\n:42\r\n$-1\r\n"))
+
+	reply, err := readReply(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := reply.([]interface{})
+	if len(parts) != 3 || parts[0] != "message" || parts[2] != "hi\r\nx" {
+		t.Errorf("array = %q", parts)
+	}
+
+	if _, err := readReply(r); err == nil || err.Error() != "redis: ERR nope" {
+		t.Errorf("error reply = %v", err)
+	}
+	if n, err := readReply(r); err != nil || n != int64(42) {
+		t.Errorf("integer = %v, %v", n, err)
+	}
+	if v, err := readReply(r); err != nil || v != nil {
+		t.Errorf("null = %v, %v", v, err)
+	}
+}
+
+func TestHubLocal(t *testing.T) {
+	hub := NewHub(nil)
+	a := hub.Subscribe("chat:a")
+	both := hub.Subscribe("chat:a", "chat:b")
+	defer both.Close()
+	if got := hub.Stats(); got.Topics != 2 || got.Subscriptions != 3 || got.Redis {
+		t.Errorf("Stats() = %+v, want 2 topics, 3 subscriptions and no Redis", got)
+	}
+
+	hub.Publish(context.Background(), "chat:b", []byte(`{"n":1}`))
+	if got := receive(t, both); got != `{"n":1}` {
+		t.Errorf("got %s", got)
+	}
+	select {
+	case p := <-a.C:
+		t.Errorf("chat:a subscriber got %s", p)
+	default:
+	}
+
+	a.Close()
+	if got := hub.Stats().Subscriptions; got != 2 {
+		t.Errorf("%d subscriptions after closing one, want 2", got)
+	}
+	hub.Publish(context.Background(), "chat:a", []byte(`{"n":2}`))
+	if got := receive(t, both); got != `{"n":2}` {
+		t.Errorf("got %s", got)
+	}
+	select {
+	case p := <-a.C:
+		t.Errorf("closed subscription got %s", p)
+	default:
+	}
+}
+
+func TestHubThroughRedis(t *testing.T) {
+	addr := fakeRedis(t, "secret")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Two instances sharing one Redis
+	hubA := NewHub(NewRedisClient(addr, "secret"))
+	hubB := NewHub(NewRedisClient(addr, "secret"))
+	go hubA.Run(ctx)
+	go hubB.Run(ctx)
+
+	subA := hubA.Subscribe("chat:x")
+	defer subA.Close()
+	subB := hubB.Subscribe("chat:x")
+	defer subB.Close()
+
+	// Wait for both to subscribe to Redis
+	for _, sub := range []*Subscription{subA, subB} {
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			hubA.Publish(ctx, "chat:x", []byte(`"ping"`))
+			select {
+			case <-sub.C:
+			case <-time.After(50 * time.Millisecond):
+				if time.Now().After(deadline) {
+					t.Fatal("an instance never received events")
+				}
+				continue
+			}
+			break
+		}
+	}
+	time.Sleep(50 * time.Millisecond)
+	for _, sub := range []*Subscription{subA, subB} {
+		for len(sub.C) > 0 {
+			<-sub.C
+		}
+	}
+
+	hubB.Publish(ctx, "chat:x", []byte(`{"type":"typing"}`))
+	if got := receive(t, subA); got != `{"type":"typing"}` {
+		t.Errorf("instance A got %s", got)
+	}
+	if got := receive(t, subB); got != `{"type":"typing"}` {
+		t.Errorf("instance B got %s", got)
+	}
+}
+
+func TestRedisPing(t *testing.T) {
+	addr := fakeRedis(t, "secret")
+	ctx := context.Background()
+	if err := NewRedisClient(addr, "secret").Ping(ctx); err != nil {
+		t.Errorf("Ping: %v", err)
+	}
+	if err := NewRedisClient(addr, "wrong").Ping(ctx); err == nil {
+		t.Error("Ping succeeded with the wrong password")
+	}
+}
+
+func TestHubFallsBackWithoutRedis(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close() // Nothing listens here
+
+	hub := NewHub(NewRedisClient(addr, ""))
+	sub := hub.Subscribe("chat:x")
+	defer sub.Close()
+
+	hub.Publish(context.Background(), "chat:x", []byte(`"local"`))
+	if got := receive(t, sub); got != `"local"` {
+		t.Errorf("got %s", got)
+	}
+}
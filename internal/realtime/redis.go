@@ -0,0 +1,228 @@
+package realtime
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// A minimal Redis client (RESP2): just AUTH, PING, PUBLISH and SUBSCRIBE,
+// which is all the hub needs to reach the other API instances.
+
+const redisDialTimeout = 5 * time.Second
+
+// RedisClient publishes to and subscribes to Redis channels
+type RedisClient struct {
+	addr     string
+	password string
+
+	mu   sync.Mutex // Guards the publishing connection
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// NewRedisClient creates a client for the Redis server at addr (host:port).
+// Connections are made as needed.
+func NewRedisClient(addr, password string) *RedisClient {
+	return &RedisClient{addr: addr, password: password}
+}
+
+// dial connects and authenticates
+func (c *RedisClient) dial(ctx context.Context) (net.Conn, *bufio.Reader, error) {
+	d := net.Dialer{Timeout: redisDialTimeout}
+	conn, err := d.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := bufio.NewReader(conn)
+	if c.password != "" {
+		conn.SetDeadline(time.Now().Add(redisDialTimeout))
+		if err := writeCommand(conn, "AUTH", c.password); err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+		if _, err := readReply(r); err != nil {
+			conn.Close()
+			return nil, nil, fmt.Errorf("redis auth: %w", err)
+		}
+		conn.SetDeadline(time.Time{})
+	}
+	return conn, r, nil
+}
+
+// Ping checks that the server can be reached, on a connection of its own
+func (c *RedisClient) Ping(ctx context.Context) error {
+	conn, r, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(redisDialTimeout))
+	if err := writeCommand(conn, "PING"); err != nil {
+		return err
+	}
+	_, err = readReply(r)
+	return err
+}
+
+// Publish sends msg to every subscriber of channel
+func (c *RedisClient) Publish(ctx context.Context, channel string, msg []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		conn, r, err := c.dial(ctx)
+		if err != nil {
+			return err
+		}
+		c.conn, c.r = conn, r
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(redisDialTimeout)
+	}
+	c.conn.SetDeadline(deadline)
+
+	err := writeCommand(c.conn, "PUBLISH", channel, string(msg))
+	if err == nil {
+		_, err = readReply(c.r)
+	}
+	var redisErr redisError
+	if err != nil && !errors.As(err, &redisErr) {
+		// The connection is in an unknown state; start over next time
+		c.conn.Close()
+		c.conn, c.r = nil, nil
+	}
+	return err
+}
+
+// Subscribe calls handle with each message published to channel until ctx
+// is done or the connection fails, which it returns
+func (c *RedisClient) Subscribe(ctx context.Context, channel string, handle func([]byte)) error {
+	conn, r, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if err := writeCommand(conn, "SUBSCRIBE", channel); err != nil {
+		return err
+	}
+	for {
+		reply, err := readReply(r)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		// Pushes are ["subscribe", channel, count] then
+		// ["message", channel, payload]
+		parts, ok := reply.([]interface{})
+		if !ok || len(parts) != 3 {
+			continue
+		}
+		if kind, _ := parts[0].(string); kind != "message" {
+			continue
+		}
+		if payload, ok := parts[2].(string); ok {
+			handle([]byte(payload))
+		}
+	}
+}
+
+// Close closes the publishing connection
+func (c *RedisClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn, c.r = nil, nil
+	return err
+}
+
+// redisError is an error reply from the server
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+// writeCommand sends a command as an array of bulk strings
+func writeCommand(w io.Writer, args ...string) error {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+	for _, arg := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(arg)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, arg...)
+		buf = append(buf, '\r', '\n')
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// readReply reads one reply: a string for simple and bulk strings, an
+// int64 for integers, nil for null and []interface{} for arrays. An error
+// reply is returned as a redisError.
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+	kind, body := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, redisError(body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed bulk length %q", body)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return string(data[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed array length %q", body)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			if items[i], err = readReply(r); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("redis: unknown reply type %q", kind)
+}
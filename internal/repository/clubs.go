@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository/gen"
+)
+
+func clubFromRow(c gen.Club) models.Club {
+	return models.Club{
+		ID:             c.ID,
+		DepartmentID:   c.DepartmentID,
+		Name:           c.Name,
+		Tagline:        c.Tagline,
+		Description:    c.Description,
+		LogoURL:        c.LogoURL,
+		PrimaryColor:   deref(c.PrimaryColor),
+		SecondaryColor: deref(c.SecondaryColor),
+		MemberCount:    deref(c.MemberCount),
+		EventCount:     deref(c.EventCount),
+		AwardsCount:    deref(c.AwardsCount),
+		Rating:         deref(c.Rating),
+		Email:          c.Email,
+		Phone:          c.Phone,
+		Website:        c.Website,
+		SocialLinks:    deref(c.SocialLinks),
+		Version:        c.Version,
+		CreatedAt:      deref(c.CreatedAt),
+		UpdatedAt:      deref(c.UpdatedAt),
+	}
+}
+
+// ListClubs returns all clubs ordered by name
+func (q *Queries) ListClubs(ctx context.Context) ([]models.Club, error) {
+	clubs, err := q.sqlc.ListClubs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return convert(clubs, clubFromRow), nil
+}
+
+// GetClub returns a single club. Returns sql.ErrNoRows if it doesn't exist.
+func (q *Queries) GetClub(ctx context.Context, id uuid.UUID) (models.Club, error) {
+	c, err := q.sqlc.GetClub(ctx, id)
+	if err != nil {
+		return models.Club{}, err
+	}
+	return clubFromRow(c), nil
+}
+
+func clubAnnouncementFromRow(a gen.ClubAnnouncement) models.ClubAnnouncement {
+	return models.ClubAnnouncement{
+		ID:        a.ID,
+		ClubID:    deref(a.ClubID),
+		Title:     a.Title,
+		Content:   a.Content,
+		Priority:  deref(a.Priority),
+		IsPinned:  deref(a.IsPinned),
+		CreatedBy: a.CreatedBy,
+		CreatedAt: deref(a.CreatedAt),
+		UpdatedAt: deref(a.UpdatedAt),
+	}
+}
+
+// ListMemberClubAnnouncements returns the latest announcement of each club
+// the user belongs to, newest first
+func (q *Queries) ListMemberClubAnnouncements(ctx context.Context, userID uuid.UUID, limit int) ([]models.ClubAnnouncement, error) {
+	announcements, err := q.sqlc.ListMemberClubAnnouncements(ctx, gen.ListMemberClubAnnouncementsParams{
+		UserID: &userID, Lim: limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convert(announcements, clubAnnouncementFromRow), nil
+}
+
+// GetClubsByIDs returns the clubs with the given IDs that exist
+func (q *Queries) GetClubsByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Club, error) {
+	clubs, err := q.sqlc.GetClubsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return convert(clubs, clubFromRow), nil
+}
+
+// ListMemberClubs returns the clubs the user belongs to, ordered by name
+func (q *Queries) ListMemberClubs(ctx context.Context, userID uuid.UUID) ([]models.Club, error) {
+	clubs, err := q.sqlc.ListMemberClubs(ctx, &userID)
+	if err != nil {
+		return nil, err
+	}
+	return convert(clubs, clubFromRow), nil
+}
+
+// ListLedClubIDs returns the clubs in which the user holds a role other than
+// plain member
+func (q *Queries) ListLedClubIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	ids, err := q.sqlc.ListLedClubIDs(ctx, &userID)
+	if err != nil {
+		return nil, err
+	}
+	return convert(ids, deref[uuid.UUID]), nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+const eventUpdateColumns = `
+	id, event_id, posted_by, body, created_at`
+
+func scanEventUpdate(row scanner) (models.EventUpdate, error) {
+	var u models.EventUpdate
+	err := row.Scan(&u.ID, &u.EventID, &u.PostedBy, &u.Body, &u.CreatedAt)
+	return u, err
+}
+
+// ListEventUpdates returns up to limit of an event's live updates, newest first
+func (q *Queries) ListEventUpdates(ctx context.Context, eventID uuid.UUID, limit int) ([]models.EventUpdate, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT `+eventUpdateColumns+`
+		FROM event_updates
+		WHERE event_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, eventID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return collect(rows, scanEventUpdate)
+}
+
+// ListEventUpdatesSince returns an event's live updates posted after since,
+// oldest first
+func (q *Queries) ListEventUpdatesSince(ctx context.Context, eventID uuid.UUID, since time.Time) ([]models.EventUpdate, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT `+eventUpdateColumns+`
+		FROM event_updates
+		WHERE event_id = $1 AND created_at > $2
+		ORDER BY created_at ASC
+	`, eventID, since)
+	if err != nil {
+		return nil, err
+	}
+	return collect(rows, scanEventUpdate)
+}
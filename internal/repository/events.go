@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository/gen"
+)
+
+// Event queries leave out alumni events unless alumniEvents is set (see
+// models.SeesAlumniEvents). A departmentID, when given, matches the events a
+// department hosts itself or through its clubs.
+
+func eventFromRow(e gen.Event) models.Event {
+	return models.Event{
+		ID:                   e.ID,
+		Title:                e.Title,
+		Description:          e.Description,
+		StartDate:            e.StartDate,
+		EndDate:              e.EndDate,
+		Location:             e.Location,
+		BannerURL:            e.BannerURL,
+		Category:             e.Category,
+		Status:               &e.Status,
+		MaxParticipants:      e.MaxParticipants,
+		CurrentParticipants:  deref(e.CurrentParticipants),
+		RegistrationDeadline: e.RegistrationDeadline,
+		IsFeatured:           deref(e.IsFeatured),
+		AlumniAccess:         e.AlumniAccess,
+		FeaturedPosition:     e.FeaturedPosition,
+		FeaturedFrom:         e.FeaturedFrom,
+		FeaturedUntil:        e.FeaturedUntil,
+		IsPaidEvent:          deref(e.IsPaidEvent),
+		EventAmount:          e.EventAmount,
+		Currency:             e.Currency,
+		ClubID:               e.ClubID,
+		FestID:               e.FestID,
+		CreatedBy:            &e.CreatedBy,
+		TermID:               e.TermID,
+		Version:              e.Version,
+		CreatedAt:            deref(e.CreatedAt),
+		UpdatedAt:            deref(e.UpdatedAt),
+		DepartmentID:         e.DepartmentID,
+	}
+}
+
+// events converts the result of an events query
+func events(rows []gen.Event, err error) ([]models.Event, error) {
+	if err != nil {
+		return nil, err
+	}
+	return convert(rows, eventFromRow), nil
+}
+
+// ListUpcomingEvents returns events that have not ended, soonest first,
+// optionally only one department's
+func (q *Queries) ListUpcomingEvents(ctx context.Context, now time.Time, departmentID *uuid.UUID, alumniEvents bool) ([]models.Event, error) {
+	return events(q.sqlc.ListUpcomingEvents(ctx, gen.ListUpcomingEventsParams{
+		Now: now, DepartmentID: departmentID, AlumniEvents: alumniEvents,
+	}))
+}
+
+// ListFeaturedEvents returns the featured events shown at now, in carousel
+// order: those within their featuring window that are neither cancelled
+// nor over
+func (q *Queries) ListFeaturedEvents(ctx context.Context, now time.Time, alumniEvents bool) ([]models.Event, error) {
+	return events(q.sqlc.ListFeaturedEvents(ctx, gen.ListFeaturedEventsParams{
+		Now: &now, AlumniEvents: alumniEvents,
+	}))
+}
+
+// ListTermEvents returns every event in an academic term, in date order,
+// optionally only one department's
+func (q *Queries) ListTermEvents(ctx context.Context, termID uuid.UUID, departmentID *uuid.UUID, alumniEvents bool) ([]models.Event, error) {
+	return events(q.sqlc.ListTermEvents(ctx, gen.ListTermEventsParams{
+		TermID: &termID, DepartmentID: departmentID, AlumniEvents: alumniEvents,
+	}))
+}
+
+// GetEvent returns a single event. Returns sql.ErrNoRows if it doesn't exist.
+func (q *Queries) GetEvent(ctx context.Context, id uuid.UUID) (models.Event, error) {
+	e, err := q.sqlc.GetEvent(ctx, id)
+	if err != nil {
+		return models.Event{}, err
+	}
+	return eventFromRow(e), nil
+}
+
+// ListRegisteredUpcomingEvents returns up to limit events the user has
+// registered for that have not ended, soonest first
+func (q *Queries) ListRegisteredUpcomingEvents(ctx context.Context, userID uuid.UUID, now time.Time, limit int) ([]models.Event, error) {
+	return events(q.sqlc.ListRegisteredUpcomingEvents(ctx, gen.ListRegisteredUpcomingEventsParams{
+		Now: now, UserID: userID, Lim: limit,
+	}))
+}
+
+// ListFestEvents returns a fest's sub-events in schedule order
+func (q *Queries) ListFestEvents(ctx context.Context, festID uuid.UUID, alumniEvents bool) ([]models.Event, error) {
+	return events(q.sqlc.ListFestEvents(ctx, gen.ListFestEventsParams{
+		FestID: &festID, AlumniEvents: alumniEvents,
+	}))
+}
+
+// ListEventsPage returns a page of events soonest first, optionally only one
+// club's and only those not ended at from
+func (q *Queries) ListEventsPage(ctx context.Context, clubID *uuid.UUID, from *time.Time, alumniEvents bool, limit, offset int) ([]models.Event, error) {
+	return events(q.sqlc.ListEventsPage(ctx, gen.ListEventsPageParams{
+		ClubID: clubID, EndsAfter: from, AlumniEvents: alumniEvents, Lim: limit, Off: offset,
+	}))
+}
+
+// ListEventsOfClubs returns up to limit events of each club, soonest first,
+// optionally only those not ended at from
+func (q *Queries) ListEventsOfClubs(ctx context.Context, clubIDs []uuid.UUID, from *time.Time, alumniEvents bool, limit int) ([]models.Event, error) {
+	return events(q.sqlc.ListEventsOfClubs(ctx, gen.ListEventsOfClubsParams{
+		ClubIds: clubIDs, EndsAfter: from, AlumniEvents: alumniEvents, Lim: limit,
+	}))
+}
+
+// EventRegistrant is a registrant of one of several events
+type EventRegistrant struct {
+	EventID uuid.UUID
+	models.EventRegistrant
+}
+
+func eventRegistrantFromRow(r gen.ListEventsRegistrantsRow) EventRegistrant {
+	return EventRegistrant{
+		EventID: r.EventID,
+		EventRegistrant: models.EventRegistrant{
+			UserID: r.UserID, FullName: r.FullName, Email: r.Email, RegisteredAt: deref(r.RegisteredAt),
+		},
+	}
+}
+
+// ListEventsRegistrants returns the registrants of each event in
+// registration order, without their form responses
+func (q *Queries) ListEventsRegistrants(ctx context.Context, eventIDs []uuid.UUID) ([]EventRegistrant, error) {
+	registrants, err := q.sqlc.ListEventsRegistrants(ctx, eventIDs)
+	if err != nil {
+		return nil, err
+	}
+	return convert(registrants, eventRegistrantFromRow), nil
+}
+
+// GetEventRegistrant returns a user's registration for an event. Returns
+// sql.ErrNoRows if they are not registered.
+func (q *Queries) GetEventRegistrant(ctx context.Context, eventID, userID uuid.UUID) (EventRegistrant, error) {
+	r, err := q.sqlc.GetEventRegistrant(ctx, gen.GetEventRegistrantParams{EventID: eventID, UserID: userID})
+	if err != nil {
+		return EventRegistrant{}, err
+	}
+	return eventRegistrantFromRow(gen.ListEventsRegistrantsRow(r)), nil
+}
+
+// GetEventEnrollment returns an event's registration count and capacity.
+// Returns sql.ErrNoRows if it doesn't exist or was deleted.
+func (q *Queries) GetEventEnrollment(ctx context.Context, id uuid.UUID) (models.EventEnrollment, error) {
+	row, err := q.sqlc.GetEventEnrollment(ctx, id)
+	if err != nil {
+		return models.EventEnrollment{}, err
+	}
+	e := models.EventEnrollment{CurrentParticipants: deref(row.CurrentParticipants), MaxParticipants: row.MaxParticipants}
+	if e.MaxParticipants != nil {
+		left := max(*e.MaxParticipants-e.CurrentParticipants, 0)
+		e.SpotsLeft = &left
+		e.IsFull = left == 0
+	}
+	return e, nil
+}
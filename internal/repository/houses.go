@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+const houseColumns = `id, name, color, description, logo_url, points, created_at, updated_at`
+
+func scanHouse(row scanner) (models.House, error) {
+	var h models.House
+	err := row.Scan(&h.ID, &h.Name, &h.Color, &h.Description, &h.LogoURL, &h.Points, &h.CreatedAt, &h.UpdatedAt)
+	return h, err
+}
+
+// ListHouses returns all houses, most points first
+func (q *Queries) ListHouses(ctx context.Context) ([]models.House, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT `+houseColumns+`
+		FROM houses
+		WHERE deleted_at IS NULL
+		ORDER BY points DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	return collect(rows, scanHouse)
+}
+
+// GetHouse returns a single house. Returns sql.ErrNoRows if it doesn't exist.
+func (q *Queries) GetHouse(ctx context.Context, id uuid.UUID) (models.House, error) {
+	return scanHouse(q.db.QueryRowContext(ctx, `
+		SELECT `+houseColumns+`
+		FROM houses
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id))
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+const paymentColumns = `
+	id, event_id, user_id, razorpay_order_id, razorpay_payment_id, razorpay_signature,
+	amount, currency, status, failure_reason, method, collected_by, receipt_number, created_at, updated_at`
+
+func scanPayment(row scanner) (models.EventPayment, error) {
+	var p models.EventPayment
+	var orderID sql.NullString // Offline payments have no order
+	err := row.Scan(
+		&p.ID, &p.EventID, &p.UserID, &orderID, &p.RazorpayPaymentID, &p.RazorpaySignature,
+		&p.Amount, &p.Currency, &p.Status, &p.FailureReason, &p.Method, &p.CollectedBy, &p.ReceiptNumber,
+		&p.CreatedAt, &p.UpdatedAt,
+	)
+	p.RazorpayOrderID = orderID.String
+	return p, err
+}
+
+// ListPendingPayments returns the user's orders that were created but never
+// paid, for events that have not been deleted, newest first
+func (q *Queries) ListPendingPayments(ctx context.Context, userID uuid.UUID) ([]models.EventPayment, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT `+paymentColumns+`
+		FROM event_payments
+		WHERE user_id = $1 AND status = 'pending'
+		  AND event_id IN (SELECT id FROM events WHERE deleted_at IS NULL)
+		ORDER BY created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return collect(rows, scanPayment)
+}
+
+// GetPayment returns a single payment. Returns sql.ErrNoRows if it doesn't
+// exist.
+func (q *Queries) GetPayment(ctx context.Context, id uuid.UUID) (models.EventPayment, error) {
+	return scanPayment(q.db.QueryRowContext(ctx, `
+		SELECT `+paymentColumns+`
+		FROM event_payments
+		WHERE id = $1
+	`, id))
+}
+
+// ListUserPayments returns the user's payments newest first, optionally only
+// those with status
+func (q *Queries) ListUserPayments(ctx context.Context, userID uuid.UUID, status *string) ([]models.EventPayment, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT `+paymentColumns+`
+		FROM event_payments
+		WHERE user_id = $1 AND ($2::text IS NULL OR status = $2)
+		ORDER BY created_at DESC
+	`, userID, status)
+	if err != nil {
+		return nil, err
+	}
+	return collect(rows, scanPayment)
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository/gen"
+)
+
+// Post queries read posts as seen by a viewer (nil when signed out): those
+// whose creator is shadow banned (see moderation.VisibleTo), that are held
+// for review (see moderation.Published) or whose audience leaves the viewer
+// out are hidden, except from their creator.
+
+// postRow is a post with its creator; every posts query's row has this shape
+type postRow = gen.GetPostRow
+
+func postFromRow(r postRow) models.PostResponse {
+	p := r.Post
+	return models.PostResponse{
+		Post: models.Post{
+			ID:            p.ID,
+			CreatedBy:     p.CreatedBy,
+			ClubID:        p.ClubID,
+			HouseID:       p.HouseID,
+			ContentType:   p.ContentType,
+			ImageURL:      p.ImageURL,
+			VideoURL:      p.VideoURL,
+			ThumbnailURL:  p.ThumbnailURL,
+			DurationSecs:  p.DurationSeconds,
+			Description:   p.Description,
+			Hashtags:      p.Hashtags,
+			Version:       p.Version,
+			CreatedAt:     deref(p.CreatedAt),
+			UpdatedAt:     deref(p.UpdatedAt),
+			ArchivedAt:    p.ArchivedAt,
+			StorageClass:  deref(p.StorageClass),
+			HeldForReview: p.HeldForReview,
+			Status:        p.Status,
+			PublishAt:     p.PublishAt,
+			PublishedAt:   p.PublishedAt,
+			Audience: models.PostAudience{
+				Department: p.AudienceDepartment,
+				Year:       p.AudienceYear,
+				HouseID:    p.AudienceHouseID,
+			},
+			PinnedAt:         p.PinnedAt,
+			FeaturedPosition: p.FeaturedPosition,
+			LikeCount:        deref(p.LikeCount),
+			ReactionCounts:   p.ReactionCounts,
+			CommentCount:     deref(p.CommentCount),
+			ShareCount:       deref(p.ShareCount),
+			ViewCount:        deref(p.ViewCount),
+		},
+		Creator: models.UserSummary{
+			ID:        r.CreatorID,
+			FullName:  r.CreatorFullName,
+			AvatarURL: r.CreatorAvatarURL,
+			Role:      r.CreatorRole,
+		},
+	}
+}
+
+// searchPattern turns a search term into a LIKE pattern over lowercased text
+func searchPattern(search *string) *string {
+	if search == nil {
+		return nil
+	}
+	pattern := "%" + strings.ToLower(*search) + "%"
+	return &pattern
+}
+
+// CountPosts counts posts matching the filter
+func (q *Queries) CountPosts(ctx context.Context, f models.ListPostsQuery) (int, error) {
+	n, err := q.sqlc.CountPosts(ctx, gen.CountPostsParams{
+		ViewerID: f.ViewerID, Hashtag: f.Hashtag, ClubID: f.ClubID, HouseID: f.HouseID, Search: searchPattern(f.Search),
+	})
+	return int(n), err
+}
+
+// ListPosts returns one page of published posts matching the filter, pinned
+// posts first, most recently pinned first, then the rest most recently
+// published first
+func (q *Queries) ListPosts(ctx context.Context, f models.ListPostsQuery) ([]models.PostResponse, error) {
+	rows, err := q.sqlc.ListPosts(ctx, gen.ListPostsParams{
+		ViewerID: f.ViewerID, Hashtag: f.Hashtag, ClubID: f.ClubID, HouseID: f.HouseID, Search: searchPattern(f.Search),
+		Lim: f.PageSize, Off: (f.Page - 1) * f.PageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convert(rows, func(r gen.ListPostsRow) models.PostResponse { return postFromRow(postRow(r)) }), nil
+}
+
+// ListFeaturedPosts returns the featured posts visible to viewerID (nil
+// when signed out), in carousel order
+func (q *Queries) ListFeaturedPosts(ctx context.Context, viewerID *uuid.UUID) ([]models.PostResponse, error) {
+	rows, err := q.sqlc.ListFeaturedPosts(ctx, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return convert(rows, func(r gen.ListFeaturedPostsRow) models.PostResponse { return postFromRow(postRow(r)) }), nil
+}
+
+// GetPost returns a single post with its creator, as seen by viewerID (nil
+// when signed out). Returns sql.ErrNoRows if it doesn't exist, or, unless
+// the viewer is its creator, its creator is shadow banned, it's held for
+// review or not yet published, or the viewer isn't in its audience.
+func (q *Queries) GetPost(ctx context.Context, id uuid.UUID, viewerID *uuid.UUID) (models.PostResponse, error) {
+	r, err := q.sqlc.GetPost(ctx, gen.GetPostParams{ID: id, ViewerID: viewerID})
+	if err != nil {
+		return models.PostResponse{}, err
+	}
+	return postFromRow(r), nil
+}
+
+// ListPostsOfClubs returns up to limit of each club's published posts
+// visible to viewerID, most recently published first
+func (q *Queries) ListPostsOfClubs(ctx context.Context, clubIDs []uuid.UUID, limit int, viewerID *uuid.UUID) ([]models.PostResponse, error) {
+	rows, err := q.sqlc.ListPostsOfClubs(ctx, gen.ListPostsOfClubsParams{ClubIds: clubIDs, ViewerID: viewerID, Lim: limit})
+	if err != nil {
+		return nil, err
+	}
+	return convert(rows, func(r gen.ListPostsOfClubsRow) models.PostResponse { return postFromRow(postRow(r)) }), nil
+}
+
+// CountUnpublishedPosts counts drafts and scheduled posts, or those of one
+// status
+func (q *Queries) CountUnpublishedPosts(ctx context.Context, status string) (int, error) {
+	n, err := q.sqlc.CountUnpublishedPosts(ctx, status)
+	return int(n), err
+}
+
+// ListUnpublishedPosts returns one page of drafts and scheduled posts, or
+// those of one status: scheduled posts soonest due first, then drafts,
+// newest first
+func (q *Queries) ListUnpublishedPosts(ctx context.Context, status string, page, pageSize int) ([]models.PostResponse, error) {
+	rows, err := q.sqlc.ListUnpublishedPosts(ctx, gen.ListUnpublishedPostsParams{
+		Status: status, Lim: pageSize, Off: (page - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convert(rows, func(r gen.ListUnpublishedPostsRow) models.PostResponse { return postFromRow(postRow(r)) }), nil
+}
+
+// GetPostCounters returns a post's engagement counts. Returns sql.ErrNoRows
+// if it doesn't exist.
+func (q *Queries) GetPostCounters(ctx context.Context, id uuid.UUID) (models.PostCounters, error) {
+	c, err := q.sqlc.GetPostCounters(ctx, id)
+	if err != nil {
+		return models.PostCounters{}, err
+	}
+	return models.PostCounters{
+		LikeCount:    deref(c.LikeCount),
+		CommentCount: deref(c.CommentCount),
+		ShareCount:   deref(c.ShareCount),
+		ViewCount:    deref(c.ViewCount),
+	}, nil
+}
@@ -0,0 +1,39 @@
+-- name: ListClubs :many
+-- ListClubs returns all clubs ordered by name
+SELECT * FROM clubs
+ORDER BY name ASC;
+
+-- name: GetClub :one
+-- GetClub returns a single club
+SELECT * FROM clubs
+WHERE id = $1;
+
+-- name: GetClubsByIDs :many
+-- GetClubsByIDs returns the clubs with the given IDs that exist
+SELECT * FROM clubs
+WHERE id = ANY(sqlc.arg(ids)::uuid[]);
+
+-- name: ListMemberClubs :many
+-- ListMemberClubs returns the clubs the user belongs to, ordered by name
+SELECT * FROM clubs
+WHERE id IN (SELECT club_id FROM club_members WHERE user_id = sqlc.arg(user_id))
+ORDER BY name ASC;
+
+-- name: ListLedClubIDs :many
+-- ListLedClubIDs returns the clubs in which the user holds a role other than
+-- plain member
+SELECT club_id FROM club_members
+WHERE user_id = sqlc.arg(user_id) AND COALESCE(role, 'member') <> 'member';
+
+-- name: ListMemberClubAnnouncements :many
+-- ListMemberClubAnnouncements returns the latest announcement of each club
+-- the user belongs to, newest first
+SELECT a.* FROM club_announcements a
+WHERE a.id IN (
+    SELECT DISTINCT ON (club_id) id
+    FROM club_announcements
+    WHERE club_id IN (SELECT club_id FROM club_members WHERE user_id = sqlc.arg(user_id))
+    ORDER BY club_id, created_at DESC
+)
+ORDER BY a.created_at DESC
+LIMIT sqlc.arg(lim)::int;
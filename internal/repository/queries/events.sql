@@ -0,0 +1,106 @@
+-- name: ListUpcomingEvents :many
+-- ListUpcomingEvents returns events that have not ended, soonest first
+SELECT * FROM events
+WHERE deleted_at IS NULL AND end_date >= sqlc.arg(now)
+  AND (sqlc.narg(department_id)::uuid IS NULL OR department_id = sqlc.narg(department_id)
+    OR club_id IN (SELECT c.id FROM clubs c WHERE c.department_id = sqlc.narg(department_id)))
+  AND (alumni_access <> 'only' OR sqlc.arg(alumni_events)::boolean)
+ORDER BY start_date ASC;
+
+-- name: ListFeaturedEvents :many
+-- ListFeaturedEvents returns the featured events shown at now, in carousel
+-- order: those within their featuring window that are neither cancelled nor
+-- over
+SELECT * FROM events
+WHERE deleted_at IS NULL AND featured_position IS NOT NULL
+  AND (featured_from IS NULL OR featured_from <= sqlc.arg(now))
+  AND (featured_until IS NULL OR featured_until > sqlc.arg(now))
+  AND status <> 'cancelled' AND end_date >= sqlc.arg(now)
+  AND (alumni_access <> 'only' OR sqlc.arg(alumni_events)::boolean)
+ORDER BY featured_position;
+
+-- name: ListTermEvents :many
+-- ListTermEvents returns every event in an academic term, in date order
+SELECT * FROM events
+WHERE deleted_at IS NULL AND term_id = sqlc.arg(term_id)
+  AND (sqlc.narg(department_id)::uuid IS NULL OR department_id = sqlc.narg(department_id)
+    OR club_id IN (SELECT c.id FROM clubs c WHERE c.department_id = sqlc.narg(department_id)))
+  AND (alumni_access <> 'only' OR sqlc.arg(alumni_events)::boolean)
+ORDER BY start_date ASC;
+
+-- name: GetEvent :one
+-- GetEvent returns a single event
+SELECT * FROM events
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: ListRegisteredUpcomingEvents :many
+-- ListRegisteredUpcomingEvents returns up to lim events the user has
+-- registered for that have not ended, soonest first
+SELECT * FROM events
+WHERE deleted_at IS NULL AND end_date >= sqlc.arg(now)
+  AND id IN (SELECT event_id FROM event_registrations WHERE user_id = sqlc.arg(user_id))
+ORDER BY start_date ASC
+LIMIT sqlc.arg(lim)::int;
+
+-- name: ListFestEvents :many
+-- ListFestEvents returns a fest's sub-events in schedule order
+SELECT * FROM events
+WHERE fest_id = sqlc.arg(fest_id) AND deleted_at IS NULL
+  AND (alumni_access <> 'only' OR sqlc.arg(alumni_events)::boolean)
+ORDER BY start_date ASC;
+
+-- name: ListEventsPage :many
+-- ListEventsPage returns a page of events soonest first, optionally only one
+-- club's and only those not ended at ends_after
+SELECT * FROM events
+WHERE deleted_at IS NULL
+  AND (sqlc.narg(club_id)::uuid IS NULL OR club_id = sqlc.narg(club_id))
+  AND (sqlc.narg(ends_after)::timestamp IS NULL OR end_date >= sqlc.narg(ends_after))
+  AND (alumni_access <> 'only' OR sqlc.arg(alumni_events)::boolean)
+ORDER BY start_date ASC
+LIMIT sqlc.arg(lim)::int OFFSET sqlc.arg(off)::int;
+
+-- name: ListEventsOfClubs :many
+-- ListEventsOfClubs returns up to lim events of each club, soonest first,
+-- optionally only those not ended at ends_after
+SELECT e.* FROM events e
+JOIN (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY club_id ORDER BY start_date ASC) AS n
+    FROM events
+    WHERE deleted_at IS NULL
+      AND club_id = ANY(sqlc.arg(club_ids)::uuid[])
+      AND (sqlc.narg(ends_after)::timestamp IS NULL OR end_date >= sqlc.narg(ends_after))
+      AND (alumni_access <> 'only' OR sqlc.arg(alumni_events)::boolean)
+) ranked ON ranked.id = e.id
+WHERE ranked.n <= sqlc.arg(lim)::int
+ORDER BY e.start_date ASC;
+
+-- name: ListSavedEvents :many
+-- ListSavedEvents returns the events a user has saved, most recently saved
+-- first
+SELECT e.* FROM events e
+JOIN saved_events s ON s.event_id = e.id
+WHERE s.user_id = sqlc.arg(user_id) AND e.deleted_at IS NULL
+ORDER BY s.saved_at DESC;
+
+-- name: ListEventsRegistrants :many
+-- ListEventsRegistrants returns the registrants of each event in
+-- registration order, without their form responses
+SELECT r.event_id, u.id AS user_id, u.full_name, u.email, r.registered_at
+FROM event_registrations r
+JOIN users u ON u.id = r.user_id
+WHERE r.event_id = ANY(sqlc.arg(event_ids)::uuid[])
+ORDER BY r.registered_at ASC;
+
+-- name: GetEventRegistrant :one
+-- GetEventRegistrant returns a user's registration for an event
+SELECT r.event_id, u.id AS user_id, u.full_name, u.email, r.registered_at
+FROM event_registrations r
+JOIN users u ON u.id = r.user_id
+WHERE r.event_id = sqlc.arg(event_id) AND r.user_id = sqlc.arg(user_id);
+
+-- name: GetEventEnrollment :one
+-- GetEventEnrollment returns an event's registration count and capacity
+SELECT current_participants, max_participants
+FROM events
+WHERE id = $1 AND deleted_at IS NULL;
@@ -0,0 +1,179 @@
+-- name: CountPosts :one
+-- CountPosts counts the published posts matching the filters
+SELECT COUNT(*) FROM posts p
+WHERE p.deleted_at IS NULL AND p.status = 'published'
+  AND (NOT EXISTS (SELECT 1 FROM shadow_bans sb WHERE sb.user_id = p.created_by) OR p.created_by = sqlc.narg(viewer_id)::uuid)
+  AND (NOT p.held_for_review OR p.created_by = sqlc.narg(viewer_id)::uuid)
+  AND (
+    (p.audience_department IS NULL AND p.audience_year IS NULL AND p.audience_house_id IS NULL)
+    OR p.created_by = sqlc.narg(viewer_id)::uuid
+    OR EXISTS (
+      SELECT 1 FROM users au
+      WHERE au.id = sqlc.narg(viewer_id)::uuid
+        AND (p.audience_department IS NULL OR au.department = p.audience_department)
+        AND (p.audience_year IS NULL OR au.year = p.audience_year)
+        AND (p.audience_house_id IS NULL OR EXISTS (
+          SELECT 1 FROM house_members hm WHERE hm.user_id = au.id AND hm.house_id = p.audience_house_id))
+    )
+  )
+  AND (sqlc.narg(hashtag)::text IS NULL OR sqlc.narg(hashtag) = ANY(p.hashtags))
+  AND (sqlc.narg(club_id)::uuid IS NULL OR p.club_id = sqlc.narg(club_id))
+  AND (sqlc.narg(house_id)::uuid IS NULL OR p.house_id = sqlc.narg(house_id))
+  AND (sqlc.narg(search)::text IS NULL OR LOWER(p.description) LIKE sqlc.narg(search));
+
+-- name: ListPosts :many
+-- ListPosts returns one page of published posts matching the filters, pinned
+-- posts first, most recently pinned first, then the rest most recently
+-- published first. search is a LIKE pattern over the lowercased description.
+SELECT sqlc.embed(p), u.id AS creator_id, u.full_name AS creator_full_name,
+  u.avatar_url AS creator_avatar_url, u.role AS creator_role
+FROM posts p
+JOIN users u ON p.created_by = u.id
+WHERE p.deleted_at IS NULL AND p.status = 'published'
+  AND (NOT EXISTS (SELECT 1 FROM shadow_bans sb WHERE sb.user_id = p.created_by) OR p.created_by = sqlc.narg(viewer_id)::uuid)
+  AND (NOT p.held_for_review OR p.created_by = sqlc.narg(viewer_id)::uuid)
+  AND (
+    (p.audience_department IS NULL AND p.audience_year IS NULL AND p.audience_house_id IS NULL)
+    OR p.created_by = sqlc.narg(viewer_id)::uuid
+    OR EXISTS (
+      SELECT 1 FROM users au
+      WHERE au.id = sqlc.narg(viewer_id)::uuid
+        AND (p.audience_department IS NULL OR au.department = p.audience_department)
+        AND (p.audience_year IS NULL OR au.year = p.audience_year)
+        AND (p.audience_house_id IS NULL OR EXISTS (
+          SELECT 1 FROM house_members hm WHERE hm.user_id = au.id AND hm.house_id = p.audience_house_id))
+    )
+  )
+  AND (sqlc.narg(hashtag)::text IS NULL OR sqlc.narg(hashtag) = ANY(p.hashtags))
+  AND (sqlc.narg(club_id)::uuid IS NULL OR p.club_id = sqlc.narg(club_id))
+  AND (sqlc.narg(house_id)::uuid IS NULL OR p.house_id = sqlc.narg(house_id))
+  AND (sqlc.narg(search)::text IS NULL OR LOWER(p.description) LIKE sqlc.narg(search))
+ORDER BY p.pinned_at DESC NULLS LAST, p.published_at DESC
+LIMIT sqlc.arg(lim)::int OFFSET sqlc.arg(off)::int;
+
+-- name: ListFeaturedPosts :many
+-- ListFeaturedPosts returns the featured posts, in carousel order
+SELECT sqlc.embed(p), u.id AS creator_id, u.full_name AS creator_full_name,
+  u.avatar_url AS creator_avatar_url, u.role AS creator_role
+FROM posts p
+JOIN users u ON p.created_by = u.id
+WHERE p.deleted_at IS NULL AND p.status = 'published' AND p.featured_position IS NOT NULL
+  AND (NOT EXISTS (SELECT 1 FROM shadow_bans sb WHERE sb.user_id = p.created_by) OR p.created_by = sqlc.narg(viewer_id)::uuid)
+  AND (NOT p.held_for_review OR p.created_by = sqlc.narg(viewer_id)::uuid)
+  AND (
+    (p.audience_department IS NULL AND p.audience_year IS NULL AND p.audience_house_id IS NULL)
+    OR p.created_by = sqlc.narg(viewer_id)::uuid
+    OR EXISTS (
+      SELECT 1 FROM users au
+      WHERE au.id = sqlc.narg(viewer_id)::uuid
+        AND (p.audience_department IS NULL OR au.department = p.audience_department)
+        AND (p.audience_year IS NULL OR au.year = p.audience_year)
+        AND (p.audience_house_id IS NULL OR EXISTS (
+          SELECT 1 FROM house_members hm WHERE hm.user_id = au.id AND hm.house_id = p.audience_house_id))
+    )
+  )
+ORDER BY p.featured_position;
+
+-- name: GetPost :one
+-- GetPost returns a single post; drafts and scheduled posts only to their
+-- creator
+SELECT sqlc.embed(p), u.id AS creator_id, u.full_name AS creator_full_name,
+  u.avatar_url AS creator_avatar_url, u.role AS creator_role
+FROM posts p
+JOIN users u ON p.created_by = u.id
+WHERE p.id = sqlc.arg(id) AND p.deleted_at IS NULL
+  AND (p.status = 'published' OR p.created_by = sqlc.narg(viewer_id)::uuid)
+  AND (NOT EXISTS (SELECT 1 FROM shadow_bans sb WHERE sb.user_id = p.created_by) OR p.created_by = sqlc.narg(viewer_id)::uuid)
+  AND (NOT p.held_for_review OR p.created_by = sqlc.narg(viewer_id)::uuid)
+  AND (
+    (p.audience_department IS NULL AND p.audience_year IS NULL AND p.audience_house_id IS NULL)
+    OR p.created_by = sqlc.narg(viewer_id)::uuid
+    OR EXISTS (
+      SELECT 1 FROM users au
+      WHERE au.id = sqlc.narg(viewer_id)::uuid
+        AND (p.audience_department IS NULL OR au.department = p.audience_department)
+        AND (p.audience_year IS NULL OR au.year = p.audience_year)
+        AND (p.audience_house_id IS NULL OR EXISTS (
+          SELECT 1 FROM house_members hm WHERE hm.user_id = au.id AND hm.house_id = p.audience_house_id))
+    )
+  );
+
+-- name: ListPostsOfClubs :many
+-- ListPostsOfClubs returns up to lim of each club's published posts, most
+-- recently published first
+SELECT sqlc.embed(p), u.id AS creator_id, u.full_name AS creator_full_name,
+  u.avatar_url AS creator_avatar_url, u.role AS creator_role
+FROM posts p
+JOIN users u ON p.created_by = u.id
+JOIN (
+  SELECT p.id, ROW_NUMBER() OVER (PARTITION BY p.club_id ORDER BY p.published_at DESC) AS n
+  FROM posts p
+  WHERE p.deleted_at IS NULL AND p.club_id = ANY(sqlc.arg(club_ids)::uuid[]) AND p.status = 'published'
+  AND (NOT EXISTS (SELECT 1 FROM shadow_bans sb WHERE sb.user_id = p.created_by) OR p.created_by = sqlc.narg(viewer_id)::uuid)
+  AND (NOT p.held_for_review OR p.created_by = sqlc.narg(viewer_id)::uuid)
+  AND (
+    (p.audience_department IS NULL AND p.audience_year IS NULL AND p.audience_house_id IS NULL)
+    OR p.created_by = sqlc.narg(viewer_id)::uuid
+    OR EXISTS (
+      SELECT 1 FROM users au
+      WHERE au.id = sqlc.narg(viewer_id)::uuid
+        AND (p.audience_department IS NULL OR au.department = p.audience_department)
+        AND (p.audience_year IS NULL OR au.year = p.audience_year)
+        AND (p.audience_house_id IS NULL OR EXISTS (
+          SELECT 1 FROM house_members hm WHERE hm.user_id = au.id AND hm.house_id = p.audience_house_id))
+    )
+  )
+) ranked ON ranked.id = p.id
+WHERE ranked.n <= sqlc.arg(lim)::int
+ORDER BY p.published_at DESC;
+
+-- name: ListSavedPosts :many
+-- ListSavedPosts returns the posts a user has saved, most recently saved
+-- first
+SELECT sqlc.embed(p), u.id AS creator_id, u.full_name AS creator_full_name,
+  u.avatar_url AS creator_avatar_url, u.role AS creator_role
+FROM saved_posts s
+JOIN posts p ON p.id = s.post_id
+JOIN users u ON p.created_by = u.id
+WHERE s.user_id = sqlc.arg(user_id) AND p.deleted_at IS NULL AND p.status = 'published'
+  AND (NOT EXISTS (SELECT 1 FROM shadow_bans sb WHERE sb.user_id = p.created_by) OR p.created_by = sqlc.arg(user_id))
+  AND (NOT p.held_for_review OR p.created_by = sqlc.arg(user_id))
+  AND (
+    (p.audience_department IS NULL AND p.audience_year IS NULL AND p.audience_house_id IS NULL)
+    OR p.created_by = sqlc.arg(user_id)
+    OR EXISTS (
+      SELECT 1 FROM users au
+      WHERE au.id = sqlc.arg(user_id)
+        AND (p.audience_department IS NULL OR au.department = p.audience_department)
+        AND (p.audience_year IS NULL OR au.year = p.audience_year)
+        AND (p.audience_house_id IS NULL OR EXISTS (
+          SELECT 1 FROM house_members hm WHERE hm.user_id = au.id AND hm.house_id = p.audience_house_id))
+    )
+  )
+ORDER BY s.saved_at DESC;
+
+-- name: CountUnpublishedPosts :one
+-- CountUnpublishedPosts counts drafts and scheduled posts, or those of one
+-- status
+SELECT COUNT(*) FROM posts
+WHERE deleted_at IS NULL AND status <> 'published'
+  AND (sqlc.arg(status)::text = '' OR status = sqlc.arg(status));
+
+-- name: ListUnpublishedPosts :many
+-- ListUnpublishedPosts returns one page of drafts and scheduled posts, or
+-- those of one status: scheduled posts soonest due first, then drafts,
+-- newest first
+SELECT sqlc.embed(p), u.id AS creator_id, u.full_name AS creator_full_name,
+  u.avatar_url AS creator_avatar_url, u.role AS creator_role
+FROM posts p
+JOIN users u ON p.created_by = u.id
+WHERE p.deleted_at IS NULL AND p.status <> 'published'
+  AND (sqlc.arg(status)::text = '' OR p.status = sqlc.arg(status))
+ORDER BY p.publish_at ASC NULLS LAST, p.created_at DESC
+LIMIT sqlc.arg(lim)::int OFFSET sqlc.arg(off)::int;
+
+-- name: GetPostCounters :one
+-- GetPostCounters returns a post's engagement counts
+SELECT like_count, comment_count, share_count, view_count
+FROM posts
+WHERE id = $1 AND deleted_at IS NULL;
@@ -0,0 +1,69 @@
+// Package repository holds typed read queries. The event, post and club
+// queries are generated by sqlc from the SQL in queries/ into gen/ (see
+// sqlc.yaml; regenerate with make sqlc) and converted to models here. The
+// rest have a single column list and a single scan function per row type,
+// so a query's SELECT list and its Scan targets cannot drift apart. Either
+// way, scan errors are always returned.
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/yourusername/college-event-backend/internal/repository/gen"
+)
+
+// DBTX is satisfied by *sql.DB and *sql.Tx
+type DBTX = gen.DBTX
+
+// Queries runs typed queries against a connection or transaction
+type Queries struct {
+	db   DBTX
+	sqlc *gen.Queries
+}
+
+// New creates a Queries for db. Pass database.DB.Reader() for read paths
+// that can tolerate replica lag.
+func New(db DBTX) *Queries {
+	return &Queries{db: db, sqlc: gen.New(db)}
+}
+
+// scanner is satisfied by *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// collect scans every row with scan, returning the first scan or iteration error
+func collect[T any](rows *sql.Rows, scan func(scanner) (T, error)) ([]T, error) {
+	defer rows.Close()
+
+	items := []T{}
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// convert converts generated rows to models
+func convert[R, T any](rows []R, fn func(R) T) []T {
+	items := make([]T, len(rows))
+	for i, row := range rows {
+		items[i] = fn(row)
+	}
+	return items
+}
+
+// deref returns *p, or the zero value for a NULL column
+func deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/services/moderation"
+)
+
+// countingScanner records how many destinations Scan was given
+type countingScanner struct {
+	n int
+}
+
+func (s *countingScanner) Scan(dest ...interface{}) error {
+	s.n = len(dest)
+	return nil
+}
+
+func TestColumnsMatchScanTargets(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns string
+		scan    func(scanner) error
+	}{
+		{"schedule", scheduleColumns, func(s scanner) error { _, err := scanSchedule(s); return err }},
+		{"payment", paymentColumns, func(s scanner) error { _, err := scanPayment(s); return err }},
+		{"eventUpdate", eventUpdateColumns, func(s scanner) error { _, err := scanEventUpdate(s); return err }},
+		{"house", houseColumns, func(s scanner) error { _, err := scanHouse(s); return err }},
+		{"user", userColumns, func(s scanner) error { _, err := scanUser(s); return err }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s countingScanner
+			if err := tt.scan(&s); err != nil {
+				t.Fatal(err)
+			}
+			if want := len(strings.Split(tt.columns, ",")); s.n != want {
+				t.Errorf("scan%s has %d targets, %sColumns has %d columns", tt.name, s.n, tt.name, want)
+			}
+		})
+	}
+}
+
+// recordingDB records the queries run on it and fails them
+type recordingDB struct {
+	queries []string
+}
+
+var errRecorded = errors.New("recorded")
+
+func (db *recordingDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	db.queries = append(db.queries, query)
+	return nil, errRecorded
+}
+
+func (db *recordingDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	db.queries = append(db.queries, query)
+	return nil, errRecorded
+}
+
+func (db *recordingDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	db.queries = append(db.queries, query)
+	return nil, errRecorded
+}
+
+func (db *recordingDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	panic("QueryRowContext not supported")
+}
+
+// TestPostQueriesApplyModeration checks the posts queries in queries/, which
+// can't call the moderation package, spell out its conditions as it does
+func TestPostQueriesApplyModeration(t *testing.T) {
+	ctx := context.Background()
+	viewerID := uuid.New()
+
+	tests := []struct {
+		name   string
+		viewer string
+		run    func(*Queries) error
+	}{
+		{"ListPosts", "$1::uuid", func(q *Queries) error {
+			_, err := q.ListPosts(ctx, models.ListPostsQuery{ViewerID: &viewerID, Page: 1, PageSize: 20})
+			return err
+		}},
+		{"ListFeaturedPosts", "$1::uuid", func(q *Queries) error {
+			_, err := q.ListFeaturedPosts(ctx, &viewerID)
+			return err
+		}},
+		{"ListPostsOfClubs", "$2::uuid", func(q *Queries) error {
+			_, err := q.ListPostsOfClubs(ctx, []uuid.UUID{uuid.New()}, 10, &viewerID)
+			return err
+		}},
+		{"ListSavedPosts", "$1", func(q *Queries) error {
+			_, err := q.ListSavedPosts(ctx, viewerID)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &recordingDB{}
+			if err := tt.run(New(db)); !errors.Is(err, errRecorded) || len(db.queries) != 1 {
+				t.Fatalf("err = %v after %d queries, want one query run", err, len(db.queries))
+			}
+			for _, cond := range []string{
+				moderation.VisibleTo(tt.viewer, "p.created_by"),
+				moderation.Published(tt.viewer, "p"),
+			} {
+				if !strings.Contains(db.queries[0], cond) {
+					t.Errorf("query lacks %s:\n%s", cond, db.queries[0])
+				}
+			}
+		})
+	}
+}
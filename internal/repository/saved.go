@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/repository/gen"
+)
+
+// ListSavedPosts returns the posts a user has saved, most recently saved
+// first
+func (q *Queries) ListSavedPosts(ctx context.Context, userID uuid.UUID) ([]models.PostResponse, error) {
+	rows, err := q.sqlc.ListSavedPosts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return convert(rows, func(r gen.ListSavedPostsRow) models.PostResponse { return postFromRow(postRow(r)) }), nil
+}
+
+// ListSavedEvents returns the events a user has saved, most recently saved
+// first
+func (q *Queries) ListSavedEvents(ctx context.Context, userID uuid.UUID) ([]models.Event, error) {
+	return events(q.sqlc.ListSavedEvents(ctx, userID))
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+const scheduleColumns = `
+	id, title, description, schedule_date, start_time, end_time, location,
+	schedule_type, created_by, user_id, created_at, updated_at`
+
+func scanSchedule(row scanner) (models.Schedule, error) {
+	var s models.Schedule
+	err := row.Scan(
+		&s.ID, &s.Title, &s.Description, &s.ScheduleDate, &s.StartTime, &s.EndTime, &s.Location,
+		&s.ScheduleType, &s.CreatedBy, &s.UserID, &s.CreatedAt, &s.UpdatedAt,
+	)
+	return s, err
+}
+
+// ListSchedulesForDay returns the official schedules and the user's personal
+// schedules on a date, earliest first
+func (q *Queries) ListSchedulesForDay(ctx context.Context, userID uuid.UUID, day time.Time) ([]models.Schedule, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT `+scheduleColumns+`
+		FROM schedules
+		WHERE schedule_date = $1::date
+		  AND (schedule_type = 'official' OR (schedule_type = 'personal' AND user_id = $2))
+		ORDER BY start_time ASC
+	`, day.Format("2006-01-02"), userID)
+	if err != nil {
+		return nil, err
+	}
+	return collect(rows, scanSchedule)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+// GetStoryCounters returns an unexpired story's engagement counts. Returns
+// sql.ErrNoRows if it doesn't exist, has expired or was deleted.
+func (q *Queries) GetStoryCounters(ctx context.Context, id uuid.UUID, now time.Time) (models.StoryCounters, error) {
+	var c models.StoryCounters
+	err := q.db.QueryRowContext(ctx, `
+		SELECT COALESCE(like_count, 0), COALESCE(view_count, 0)
+		FROM stories
+		WHERE id = $1 AND expires_at > $2 AND deleted_at IS NULL
+	`, id, now).Scan(&c.LikeCount, &c.ViewCount)
+	return c, err
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+const userColumns = `id, email, full_name, role, avatar_url, department, year, created_at, updated_at`
+
+func scanUser(row scanner) (models.User, error) {
+	var u models.User
+	err := row.Scan(
+		&u.ID, &u.Email, &u.FullName, &u.Role,
+		&u.AvatarURL, &u.Department, &u.Year, &u.CreatedAt, &u.UpdatedAt,
+	)
+	return u, err
+}
+
+// GetUser returns a single user. Returns sql.ErrNoRows if they don't exist
+// or were deleted.
+func (q *Queries) GetUser(ctx context.Context, id uuid.UUID) (models.User, error) {
+	return scanUser(q.db.QueryRowContext(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id))
+}
+
+// GetUserByEmail returns the user with an email address, ignoring case.
+// Returns sql.ErrNoRows if there is none.
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
+	return scanUser(q.db.QueryRowContext(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE LOWER(email) = LOWER($1) AND deleted_at IS NULL
+	`, email))
+}
+
+// GetUsersByIDs returns the users that exist among ids, in no particular order
+func (q *Queries) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]models.User, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE id = ANY($1::uuid[]) AND deleted_at IS NULL
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	return collect(rows, scanUser)
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+// MaxImpersonationDuration bounds the lifetime of an impersonation token
+const MaxImpersonationDuration = time.Hour
+
+// Impersonation identifies a support session in which an admin acts as a user
+type Impersonation struct {
+	SessionID uuid.UUID `json:"session_id"`
+	AdminID   uuid.UUID `json:"admin_id"`
+	ReadOnly  bool      `json:"read_only"`
+}
+
+// GenerateImpersonationToken issues a short-lived access token for user that
+// carries the impersonation claim, optionally limited to scope. There is no
+// refresh token; the admin starts a new session when it expires.
+func (s *Service) GenerateImpersonationToken(user *models.User, imp Impersonation, scope string, ttl time.Duration) (string, time.Time, error) {
+	if ttl <= 0 || ttl > MaxImpersonationDuration {
+		ttl = MaxImpersonationDuration
+	}
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	claims := &Claims{
+		UserID:        user.ID,
+		Email:         user.Email,
+		Role:          user.Role,
+		Impersonation: &imp,
+		Scope:         scope,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Subject:   user.ID.String(),
+			ID:        imp.SessionID.String(),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(s.jwtSecret)
+	return signed, expiresAt, err
+}
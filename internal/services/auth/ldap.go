@@ -0,0 +1,264 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+// ErrLDAPNoEmail is returned when a directory account has no email address
+// and no LDAP email domain is configured to derive one
+var ErrLDAPNoEmail = errors.New("directory account has no email address")
+
+// ldapUsernamePattern restricts usernames to characters that need no escaping
+// in a DN or userPrincipalName
+var ldapUsernamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// rolePriority orders roles so a user in several mapped groups gets the
+// most privileged one
+var rolePriority = map[models.UserRole]int{
+	models.RoleAlumni:  1,
+	models.RoleStudent: 2,
+	models.RoleFaculty: 3,
+	models.RoleAdmin:   4,
+}
+
+// LDAPConfig configures the LDAP / Active Directory provider
+type LDAPConfig struct {
+	// URL of the directory: ldaps://host[:636], or ldap://host[:389] with
+	// StartTLS
+	URL string
+	// StartTLS upgrades an ldap:// connection to TLS before binding
+	StartTLS bool
+	// AllowInsecure permits binding over a plain ldap:// connection, which
+	// sends passwords in the clear. Only for local test directories.
+	AllowInsecure bool
+	// BindDNPatterns are tried in order with %s replaced by the username,
+	// e.g. "uid=%s,ou=people,dc=college,dc=edu" or, for AD, "%s@college.edu"
+	BindDNPatterns []string
+	// BaseDN is searched for the user's entry by UserAttr. When empty the
+	// entry is read directly at the DN that was bound, which only works for
+	// DN-shaped patterns.
+	BaseDN   string
+	UserAttr string // uid, or sAMAccountName for AD
+	// EmailDomain builds username@EmailDomain for entries without a mail
+	// attribute
+	EmailDomain string
+	// GroupRoles maps groups, by full DN or CN, lowercased, to roles
+	GroupRoles map[string]models.UserRole
+	Timeout    time.Duration
+}
+
+// LDAPIdentity is a directory account that authenticated successfully
+type LDAPIdentity struct {
+	DN       string
+	Username string
+	Email    string
+	FullName string
+	Groups   []string
+	// Role is mapped from Groups, or empty if none of them is mapped
+	Role models.UserRole
+}
+
+// LDAPProvider authenticates users against an LDAP directory by binding as
+// them, then reads their entry for profile details and group membership
+type LDAPProvider struct {
+	cfg       LDAPConfig
+	tlsConfig *tls.Config
+}
+
+// NewLDAPProvider creates an LDAP provider. Passwords must not cross the
+// network in the clear: the URL must be ldaps://, or ldap:// with StartTLS,
+// unless AllowInsecure is set.
+func NewLDAPProvider(cfg LDAPConfig) (*LDAPProvider, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LDAP URL: %w", err)
+	}
+	switch u.Scheme {
+	case "ldap":
+		if !cfg.StartTLS && !cfg.AllowInsecure {
+			return nil, fmt.Errorf("ldap:// sends passwords in the clear: use ldaps://, enable StartTLS, or allow insecure binds explicitly")
+		}
+	case "ldaps":
+		if cfg.StartTLS {
+			return nil, fmt.Errorf("StartTLS is for ldap:// URLs; ldaps:// is already encrypted")
+		}
+	default:
+		return nil, fmt.Errorf("LDAP URL must start with ldap:// or ldaps://")
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("LDAP URL has no host")
+	}
+
+	if len(cfg.BindDNPatterns) == 0 {
+		return nil, fmt.Errorf("at least one LDAP bind DN pattern is required")
+	}
+	for _, pattern := range cfg.BindDNPatterns {
+		if strings.Count(pattern, "%s") != 1 {
+			return nil, fmt.Errorf("LDAP bind DN pattern %q must contain %%s exactly once", pattern)
+		}
+	}
+	if cfg.UserAttr == "" {
+		cfg.UserAttr = "uid"
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	return &LDAPProvider{
+		cfg:       cfg,
+		tlsConfig: &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12},
+	}, nil
+}
+
+// Authenticate checks username and password against the directory. Wrong
+// credentials return ErrInvalidCredentials.
+func (p *LDAPProvider) Authenticate(ctx context.Context, username, password string) (*LDAPIdentity, error) {
+	// An empty password would be an unauthenticated bind, which servers
+	// accept without checking anything
+	if !ldapUsernamePattern.MatchString(username) || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := p.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	// Give up on the directory if the caller does
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	boundDN := ""
+	for _, pattern := range p.cfg.BindDNPatterns {
+		dn := strings.Replace(pattern, "%s", username, 1)
+		err := conn.Bind(dn, password)
+		if err == nil {
+			boundDN = dn
+			break
+		}
+		if !ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, fmt.Errorf("ldap bind: %w", err)
+		}
+	}
+	if boundDN == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	attributes := []string{"mail", "displayName", "cn", "memberOf"}
+	search := ldap.NewSearchRequest(boundDN, ldap.ScopeBaseObject, ldap.NeverDerefAliases, 1, 0, false,
+		"(objectClass=*)", attributes, nil)
+	if p.cfg.BaseDN != "" {
+		search = ldap.NewSearchRequest(p.cfg.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 1, 0, false,
+			"("+ldap.EscapeFilter(p.cfg.UserAttr)+"="+ldap.EscapeFilter(username)+")", attributes, nil)
+	}
+	result, err := conn.Search(search)
+	if err != nil {
+		return nil, fmt.Errorf("ldap search: %w", err)
+	}
+	if len(result.Entries) == 0 {
+		return nil, fmt.Errorf("ldap search: no entry found for %q", username)
+	}
+	entry := result.Entries[0]
+
+	identity := &LDAPIdentity{
+		DN:       entry.DN,
+		Username: username,
+		Email:    strings.ToLower(entry.GetAttributeValue("mail")),
+		FullName: entry.GetAttributeValue("displayName"),
+		Groups:   entry.GetAttributeValues("memberOf"),
+	}
+	if identity.Email == "" {
+		if p.cfg.EmailDomain == "" {
+			return nil, ErrLDAPNoEmail
+		}
+		identity.Email = strings.ToLower(username + "@" + p.cfg.EmailDomain)
+	}
+	if identity.FullName == "" {
+		identity.FullName = entry.GetAttributeValue("cn")
+	}
+	if identity.FullName == "" {
+		identity.FullName = username
+	}
+	identity.Role = mapRole(p.cfg.GroupRoles, identity.Groups)
+	return identity, nil
+}
+
+// mapRole returns the most privileged role that roles maps any of groups to,
+// matching each group by its full DN or its CN
+func mapRole(roles map[string]models.UserRole, groups []string) models.UserRole {
+	var role models.UserRole
+	for _, g := range groups {
+		g = strings.ToLower(g)
+		mapped, ok := roles[g]
+		if !ok {
+			mapped, ok = roles[groupCN(g)]
+		}
+		if ok && rolePriority[mapped] > rolePriority[role] {
+			role = mapped
+		}
+	}
+	return role
+}
+
+// dial connects to the directory, upgrading the connection with StartTLS if
+// configured. Each request on the connection must finish within the
+// timeout.
+func (p *LDAPProvider) dial() (*ldap.Conn, error) {
+	conn, err := ldap.DialURL(p.cfg.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: p.cfg.Timeout}),
+		ldap.DialWithTLSConfig(p.tlsConfig))
+	if err != nil {
+		return nil, fmt.Errorf("ldap connect: %w", err)
+	}
+	conn.SetTimeout(p.cfg.Timeout)
+
+	if p.cfg.StartTLS {
+		if err := conn.StartTLS(p.tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("ldap starttls: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+// groupCN returns the value of a DN's leading CN, e.g. "faculty" for
+// "cn=faculty,ou=groups,dc=college,dc=edu"
+func groupCN(dn string) string {
+	first, _, _ := strings.Cut(dn, ",")
+	if name, value, ok := strings.Cut(first, "="); ok && strings.EqualFold(strings.TrimSpace(name), "cn") {
+		return strings.TrimSpace(value)
+	}
+	return ""
+}
+
+// ParseGroupRoles parses a group role mapping (LDAP_GROUP_ROLES,
+// OIDC_ROLE_MAP): semicolon-separated role:group pairs, where group is a full
+// DN or just a name, e.g.
+// "admin:cn=it-admins,ou=groups,dc=college,dc=edu;faculty:Faculty"
+func ParseGroupRoles(s string) (map[string]models.UserRole, error) {
+	roles := map[string]models.UserRole{}
+	for _, pair := range strings.Split(s, ";") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		role, group, ok := strings.Cut(pair, ":")
+		r := models.UserRole(strings.TrimSpace(role))
+		group = strings.ToLower(strings.TrimSpace(group))
+		if !ok || group == "" || rolePriority[r] == 0 {
+			return nil, fmt.Errorf("invalid group role mapping %q", pair)
+		}
+		roles[group] = r
+	}
+	return roles, nil
+}
@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+// fakeDirectory is a plaintext LDAP server with one account, just capable
+// enough to answer the provider's bind and search
+func fakeDirectory(t *testing.T, dn, password string, attrs map[string][]string) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeDirectory(conn, dn, password, attrs)
+		}
+	}()
+	return "ldap://" + ln.Addr().String()
+}
+
+func serveFakeDirectory(conn net.Conn, dn, password string, attrs map[string][]string) {
+	defer conn.Close()
+	reply := func(id interface{}, op *ber.Packet) {
+		msg := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+		msg.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+		msg.AppendChild(op)
+		conn.Write(msg.Bytes())
+	}
+	result := func(tag ber.Tag, code int) *ber.Packet {
+		op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+		op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""))
+		op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+		op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+		return op
+	}
+
+	for {
+		msg, err := ber.ReadPacket(conn)
+		if err != nil || len(msg.Children) < 2 {
+			return
+		}
+		id, op := msg.Children[0].Value, msg.Children[1]
+
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			code := ldap.LDAPResultInvalidCredentials
+			if op.Children[1].Value == dn && op.Children[2].Data.String() == password {
+				code = ldap.LDAPResultSuccess
+			}
+			reply(id, result(ldap.ApplicationBindResponse, int(code)))
+		case ldap.ApplicationSearchRequest:
+			entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+			entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, ""))
+			list := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+			for name, values := range attrs {
+				attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+				attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+				vals := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+				for _, v := range values {
+					vals.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, ""))
+				}
+				attr.AppendChild(vals)
+				list.AppendChild(attr)
+			}
+			entry.AppendChild(list)
+			reply(id, entry)
+			reply(id, result(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+		default:
+			return
+		}
+	}
+}
+
+func TestLDAPAuthenticate(t *testing.T) {
+	const dn = "uid=asha,ou=people,dc=college,dc=edu"
+	url := fakeDirectory(t, dn, "s3cret", map[string][]string{
+		"mail":        {"Asha@College.edu"},
+		"displayName": {"Asha Rao"},
+		"memberOf": {
+			"cn=students,ou=groups,dc=college,dc=edu",
+			"cn=Faculty,ou=groups,dc=college,dc=edu",
+		},
+	})
+
+	p, err := NewLDAPProvider(LDAPConfig{
+		URL:           url,
+		AllowInsecure: true, // The fake directory doesn't speak TLS
+		// The first pattern never matches, so the provider must fall through
+		BindDNPatterns: []string{"uid=%s,ou=staff,dc=college,dc=edu", "uid=%s,ou=people,dc=college,dc=edu"},
+		GroupRoles:     map[string]models.UserRole{"faculty": models.RoleFaculty},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := p.Authenticate(context.Background(), "asha", "s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.DN != dn || identity.Email != "asha@college.edu" || identity.FullName != "Asha Rao" {
+		t.Errorf("identity = %+v", identity)
+	}
+	if identity.Role != models.RoleFaculty {
+		t.Errorf("role = %q, want faculty", identity.Role)
+	}
+
+	for _, tc := range []struct{ username, password string }{
+		{"asha", "wrong"},
+		{"asha", ""}, // unauthenticated bind
+		{"asha,ou=people", "s3cret"},
+	} {
+		if _, err := p.Authenticate(context.Background(), tc.username, tc.password); !errors.Is(err, ErrInvalidCredentials) {
+			t.Errorf("Authenticate(%q, %q) error = %v, want ErrInvalidCredentials", tc.username, tc.password, err)
+		}
+	}
+}
+
+// TestLDAPRequiresEncryption verifies passwords are only sent over ldaps://
+// or StartTLS unless plaintext binds are explicitly allowed
+func TestLDAPRequiresEncryption(t *testing.T) {
+	patterns := []string{"uid=%s,ou=people,dc=college,dc=edu"}
+	tests := []struct {
+		cfg     LDAPConfig
+		wantErr string
+	}{
+		{LDAPConfig{URL: "ldap://ldap.college.edu"}, "in the clear"},
+		{LDAPConfig{URL: "ldaps://ldap.college.edu", StartTLS: true}, "already encrypted"},
+		{LDAPConfig{URL: "ldaps://ldap.college.edu"}, ""},
+		{LDAPConfig{URL: "ldap://ldap.college.edu", StartTLS: true}, ""},
+		{LDAPConfig{URL: "ldap://localhost:3389", AllowInsecure: true}, ""},
+	}
+	for _, tt := range tests {
+		tt.cfg.BindDNPatterns = patterns
+		_, err := NewLDAPProvider(tt.cfg)
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("NewLDAPProvider(%+v) error = %v", tt.cfg, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("NewLDAPProvider(%+v) error = %v, want one mentioning %q", tt.cfg, err, tt.wantErr)
+		}
+	}
+}
+
+func TestLDAPMapRole(t *testing.T) {
+	roles, err := ParseGroupRoles("faculty:Faculty; admin:cn=IT-Admins,ou=groups,dc=college,dc=edu")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		groups []string
+		want   models.UserRole
+	}{
+		{nil, ""},
+		{[]string{"cn=clubs,ou=groups,dc=college,dc=edu"}, ""},
+		{[]string{"CN=Faculty,OU=Groups,DC=college,DC=edu"}, models.RoleFaculty},
+		{[]string{"cn=it-admins,ou=groups,dc=college,dc=edu", "cn=faculty,dc=college,dc=edu"}, models.RoleAdmin},
+	}
+	for _, tt := range tests {
+		if got := mapRole(roles, tt.groups); got != tt.want {
+			t.Errorf("mapRole(%v) = %q, want %q", tt.groups, got, tt.want)
+		}
+	}
+
+	if _, err := ParseGroupRoles("superuser:admins"); err == nil {
+		t.Error("ParseGroupRoles accepted an unknown role")
+	}
+}
@@ -0,0 +1,414 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+// ErrOIDCEmailUnverified is returned when the identity provider does not
+// vouch for the user's email, which accounts are provisioned and linked by
+var ErrOIDCEmailUnverified = errors.New("identity provider has not verified the email address")
+
+const (
+	// oidcMetadataTTL is how long discovery metadata and signing keys are
+	// cached. Keys are also refetched early when a token names an unknown
+	// one, as happens right after the provider rotates them.
+	oidcMetadataTTL = time.Hour
+	// oidcMinRefresh stops tokens with made-up key IDs from hammering the
+	// provider's JWKS endpoint
+	oidcMinRefresh = time.Minute
+)
+
+// OIDCConfig configures institutional single sign-on through an OpenID
+// Connect provider (Azure AD / Entra ID, Google Workspace, Okta, Keycloak...)
+type OIDCConfig struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string // only needed to exchange authorization codes
+	// RoleClaim names the ID token claim holding the user's groups or roles
+	RoleClaim string
+	// RoleMap maps RoleClaim values, lowercased, to roles
+	RoleMap map[string]models.UserRole
+	// TrustEmail accepts emails from a provider that does not send
+	// email_verified (Azure AD), for providers that only issue emails they own
+	TrustEmail bool
+}
+
+// OIDCIdentity is a user the identity provider signed in
+type OIDCIdentity struct {
+	Issuer   string
+	Subject  string
+	Email    string
+	FullName string
+	// Role is mapped from the role claim, or empty if none of its values is
+	// mapped
+	Role models.UserRole
+}
+
+// OIDCProvider verifies ID tokens issued by an OpenID Connect provider
+type OIDCProvider struct {
+	cfg    OIDCConfig
+	client *http.Client
+
+	mu          sync.Mutex
+	metadata    *oidcMetadata
+	metadataAt  time.Time
+	keys        map[string]interface{}
+	keysAt      time.Time
+	keysChecked time.Time
+}
+
+// oidcMetadata is the part of the provider's discovery document used here
+type oidcMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// NewOIDCProvider creates an OIDC provider. Discovery happens on first use,
+// so a provider outage does not stop the API from starting.
+func NewOIDCProvider(cfg OIDCConfig) (*OIDCProvider, error) {
+	cfg.IssuerURL = strings.TrimSuffix(cfg.IssuerURL, "/")
+	u, err := url.Parse(cfg.IssuerURL)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Hostname() != "localhost") {
+		return nil, fmt.Errorf("OIDC issuer must be an https URL")
+	}
+	if cfg.ClientID == "" {
+		return nil, fmt.Errorf("OIDC client ID is required")
+	}
+	if cfg.RoleClaim == "" {
+		cfg.RoleClaim = "groups"
+	}
+	return &OIDCProvider{cfg: cfg, client: &http.Client{Timeout: 10 * time.Second}}, nil
+}
+
+// Issuer returns the issuer URL
+func (p *OIDCProvider) Issuer() string {
+	return p.cfg.IssuerURL
+}
+
+// ClientID returns the client ID tokens must be issued to
+func (p *OIDCProvider) ClientID() string {
+	return p.cfg.ClientID
+}
+
+// AuthorizationEndpoint returns where clients send users to sign in
+func (p *OIDCProvider) AuthorizationEndpoint(ctx context.Context) (string, error) {
+	m, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return m.AuthorizationEndpoint, nil
+}
+
+// Exchange redeems an authorization code, sent to redirectURI, for the
+// user's verified identity. codeVerifier is the PKCE verifier, if the client
+// used one.
+func (p *OIDCProvider) Exchange(ctx context.Context, code, redirectURI, codeVerifier, nonce string) (*OIDCIdentity, error) {
+	m, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {redirectURI},
+		"client_id":    {p.cfg.ClientID},
+	}
+	if p.cfg.ClientSecret != "" {
+		form.Set("client_secret", p.cfg.ClientSecret)
+	}
+	if codeVerifier != "" {
+		form.Set("code_verifier", codeVerifier)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oidc token exchange: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		IDToken string `json:"id_token"`
+		Error   string `json:"error"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("oidc token exchange: %w", err)
+	}
+	// A rejected code (expired, reused, wrong redirect) is the user's
+	// problem, not the provider's
+	if body.Error == "invalid_grant" {
+		return nil, ErrInvalidToken
+	}
+	if resp.StatusCode != http.StatusOK || body.IDToken == "" {
+		return nil, fmt.Errorf("oidc token exchange: %s %s", resp.Status, body.Error)
+	}
+	return p.VerifyIDToken(ctx, body.IDToken, nonce)
+}
+
+// VerifyIDToken checks an ID token's signature, issuer, audience and expiry
+// and returns the identity it asserts. If nonce is set the token must carry
+// it. Invalid tokens return ErrInvalidToken.
+func (p *OIDCProvider) VerifyIDToken(ctx context.Context, raw, nonce string) (*OIDCIdentity, error) {
+	if _, err := p.discover(ctx); err != nil {
+		return nil, err
+	}
+
+	var keyErr error
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, err := p.key(ctx, kid)
+		if err != nil {
+			keyErr = err
+		}
+		return key, err
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(p.cfg.IssuerURL),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if keyErr != nil && !errors.Is(keyErr, ErrInvalidToken) {
+		// The provider could not be reached; the token may be fine
+		return nil, keyErr
+	}
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	// With several audiences the token must have been issued to us
+	if aud, _ := claims.GetAudience(); len(aud) > 1 {
+		if azp, _ := claims["azp"].(string); azp != p.cfg.ClientID {
+			return nil, ErrInvalidToken
+		}
+	}
+	if nonce != "" {
+		if got, _ := claims["nonce"].(string); got != nonce {
+			return nil, ErrInvalidToken
+		}
+	}
+
+	identity := &OIDCIdentity{Issuer: p.cfg.IssuerURL}
+	identity.Subject, _ = claims.GetSubject()
+	if identity.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+	identity.Email = strings.ToLower(claimString(claims, "email"))
+	if identity.Email == "" {
+		// Azure AD puts the sign-in address here when email is unset
+		identity.Email = strings.ToLower(claimString(claims, "preferred_username"))
+	}
+	if !strings.Contains(identity.Email, "@") {
+		return nil, ErrOIDCEmailUnverified
+	}
+	if !p.cfg.TrustEmail && !claimBool(claims, "email_verified") {
+		return nil, ErrOIDCEmailUnverified
+	}
+	identity.FullName = claimString(claims, "name")
+	if identity.FullName == "" {
+		identity.FullName = strings.TrimSpace(claimString(claims, "given_name") + " " + claimString(claims, "family_name"))
+	}
+	if identity.FullName == "" {
+		identity.FullName, _, _ = strings.Cut(identity.Email, "@")
+	}
+	identity.Role = mapRole(p.cfg.RoleMap, claimStrings(claims, p.cfg.RoleClaim))
+	return identity, nil
+}
+
+// discover returns the provider's metadata, fetching it when stale
+func (p *OIDCProvider) discover(ctx context.Context) (*oidcMetadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil && time.Since(p.metadataAt) < oidcMetadataTTL {
+		return p.metadata, nil
+	}
+	var m oidcMetadata
+	if err := p.getJSON(ctx, p.cfg.IssuerURL+"/.well-known/openid-configuration", &m); err != nil {
+		if p.metadata != nil {
+			// Keep using what we had; it rarely changes
+			return p.metadata, nil
+		}
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	if strings.TrimSuffix(m.Issuer, "/") != p.cfg.IssuerURL {
+		return nil, fmt.Errorf("oidc discovery: issuer %q does not match %q", m.Issuer, p.cfg.IssuerURL)
+	}
+	if m.JWKSURI == "" || m.TokenEndpoint == "" {
+		return nil, fmt.Errorf("oidc discovery: metadata is missing jwks_uri or token_endpoint")
+	}
+	p.metadata, p.metadataAt = &m, time.Now()
+	return p.metadata, nil
+}
+
+// key returns the signing key with ID kid. Unknown IDs return
+// ErrInvalidToken.
+func (p *OIDCProvider) key(ctx context.Context, kid string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stale := time.Since(p.keysAt) >= oidcMetadataTTL
+	if key, ok := p.keys[kid]; ok && !stale {
+		return key, nil
+	}
+	if stale || time.Since(p.keysChecked) >= oidcMinRefresh {
+		p.keysChecked = time.Now()
+		var set struct {
+			Keys []jsonWebKey `json:"keys"`
+		}
+		err := p.getJSON(ctx, p.metadata.JWKSURI, &set)
+		if err != nil && p.keys == nil {
+			return nil, fmt.Errorf("oidc keys: %w", err)
+		}
+		if err == nil {
+			keys := map[string]interface{}{}
+			for _, k := range set.Keys {
+				if pub, err := k.publicKey(); err == nil && (k.Use == "" || k.Use == "sig") {
+					keys[k.Kid] = pub
+				}
+			}
+			p.keys, p.keysAt = keys, time.Now()
+		}
+	}
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, ErrInvalidToken
+}
+
+func (p *OIDCProvider) getJSON(ctx context.Context, endpoint string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", endpoint, resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
+
+// jsonWebKey is an RSA or EC public key from a JWKS (RFC 7517)
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	decode := func(s string) (*big.Int, error) {
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil || len(b) == 0 {
+			return nil, fmt.Errorf("invalid key component")
+		}
+		return new(big.Int).SetBytes(b), nil
+	}
+
+	switch k.Kty {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil || !e.IsInt64() {
+			return nil, fmt.Errorf("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("EC key is not on its curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+func claimString(claims jwt.MapClaims, name string) string {
+	s, _ := claims[name].(string)
+	return s
+}
+
+// claimBool reads a boolean claim, which some providers send as a string
+func claimBool(claims jwt.MapClaims, name string) bool {
+	switch v := claims[name].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
+
+// claimStrings reads a claim that may be a single string or a list
+func claimStrings(claims jwt.MapClaims, name string) []string {
+	switch v := claims[name].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var out []string
+		for _, x := range v {
+			if s, ok := x.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+// testIssuer is an OIDC provider serving discovery and one RSA signing key
+func testIssuer(t *testing.T) (*httptest.Server, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(oidcMetadata{
+			Issuer:                srv.URL,
+			AuthorizationEndpoint: srv.URL + "/authorize",
+			TokenEndpoint:         srv.URL + "/token",
+			JWKSURI:               srv.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []jsonWebKey{{
+			Kid: "k1",
+			Kty: "RSA",
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	srv = httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, key
+}
+
+func TestOIDCVerifyIDToken(t *testing.T) {
+	srv, key := testIssuer(t)
+	p, err := NewOIDCProvider(OIDCConfig{
+		IssuerURL: srv.URL,
+		ClientID:  "campus-app",
+		RoleClaim: "groups",
+		RoleMap:   map[string]models.UserRole{"faculty": models.RoleFaculty},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.client = srv.Client()
+
+	sign := func(kid string, change func(jwt.MapClaims)) string {
+		claims := jwt.MapClaims{
+			"iss":            srv.URL,
+			"aud":            "campus-app",
+			"sub":            "0001",
+			"exp":            time.Now().Add(time.Hour).Unix(),
+			"email":          "Meera@College.edu",
+			"email_verified": true,
+			"name":           "Meera Iyer",
+			"nonce":          "n-1",
+			"groups":         []string{"Faculty", "library"},
+		}
+		if change != nil {
+			change(claims)
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = kid
+		raw, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return raw
+	}
+	ctx := context.Background()
+
+	identity, err := p.VerifyIDToken(ctx, sign("k1", nil), "n-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := OIDCIdentity{
+		Issuer:   srv.URL,
+		Subject:  "0001",
+		Email:    "meera@college.edu",
+		FullName: "Meera Iyer",
+		Role:     models.RoleFaculty,
+	}
+	if *identity != want {
+		t.Errorf("identity = %+v, want %+v", *identity, want)
+	}
+
+	tests := []struct {
+		name   string
+		kid    string
+		change func(jwt.MapClaims)
+		nonce  string
+		want   error
+	}{
+		{"unknown key", "k2", nil, "", ErrInvalidToken},
+		{"wrong audience", "k1", func(c jwt.MapClaims) { c["aud"] = "other-app" }, "", ErrInvalidToken},
+		{"wrong issuer", "k1", func(c jwt.MapClaims) { c["iss"] = "https://evil.example" }, "", ErrInvalidToken},
+		{"expired", "k1", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }, "", ErrInvalidToken},
+		{"wrong nonce", "k1", nil, "n-2", ErrInvalidToken},
+		{"unverified email", "k1", func(c jwt.MapClaims) { c["email_verified"] = false }, "", ErrOIDCEmailUnverified},
+	}
+	for _, tt := range tests {
+		if _, err := p.VerifyIDToken(ctx, sign(tt.kid, tt.change), tt.nonce); !errors.Is(err, tt.want) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package auth
+
+// Providers are the enabled login methods (password, google, ldap, oidc)
+// and the clients for those that need one
+type Providers struct {
+	Enabled []string
+	LDAP    *LDAPProvider // set when ldap is enabled
+	OIDC    *OIDCProvider // set when oidc is enabled
+}
+
+// IsEnabled reports whether a login method is enabled
+func (p Providers) IsEnabled(name string) bool {
+	for _, e := range p.Enabled {
+		if e == name {
+			return true
+		}
+	}
+	return false
+}
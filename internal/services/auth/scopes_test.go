@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+)
+
+func TestScopedToken(t *testing.T) {
+	s := NewService("test-secret", 1, 1)
+
+	scope, err := NormalizeScope([]string{"write:posts", "read:events", "write:posts"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scope != "read:events write:posts" {
+		t.Errorf("scope = %q", scope)
+	}
+	for _, bad := range []string{"events", "delete:events", "read:everything"} {
+		if _, err := NormalizeScope([]string{bad}); err == nil {
+			t.Errorf("NormalizeScope accepted %q", bad)
+		}
+	}
+
+	user := &models.User{ID: uuid.New(), Email: "a@college.edu", Role: models.RoleStudent}
+	token, _, err := s.GenerateScopedToken(user, scope, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := s.ValidateToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		scope string
+		want  bool
+	}{
+		{"read:events", true},
+		{"write:events", false},
+		{"read:posts", true}, // Implied by write
+		{"write:posts", true},
+		{"read:clubs", false},
+	}
+	for _, tt := range tests {
+		if got := claims.HasScope(tt.scope); got != tt.want {
+			t.Errorf("HasScope(%q) = %v, want %v", tt.scope, got, tt.want)
+		}
+	}
+
+	if unscoped := (&Claims{}); !unscoped.HasScope("write:admin") {
+		t.Error("unscoped token should grant every scope")
+	}
+}
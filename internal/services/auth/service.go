@@ -1,6 +1,9 @@
 package auth
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"time"
 
@@ -21,6 +24,11 @@ type Claims struct {
 	UserID uuid.UUID       `json:"user_id"`
 	Email  string          `json:"email"`
 	Role   models.UserRole `json:"role"`
+	// Impersonation is set on tokens issued to support staff acting as the user
+	Impersonation *Impersonation `json:"impersonation,omitempty"`
+	// Scope limits the token to space-separated "read:x"/"write:x" grants.
+	// Empty means unrestricted.
+	Scope string `json:"scope,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -114,3 +122,12 @@ func IsFaculty(role models.UserRole) bool {
 func IsAdminOrFaculty(role models.UserRole) bool {
 	return IsAdmin(role) || IsFaculty(role)
 }
+
+// Pseudonym returns a stable, non-reversible identifier for a user within
+// scope. Features that must not store who did something (anonymous feedback)
+// use it to recognise repeat actions by the same user.
+func (s *Service) Pseudonym(scope string, userID uuid.UUID) string {
+	mac := hmac.New(sha256.New, s.jwtSecret)
+	mac.Write([]byte(scope + ":" + userID.String()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
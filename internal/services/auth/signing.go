@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"time"
+)
+
+// payloadSignatureSeparator joins a payload and its signature. It is not in
+// the base64url alphabet, so the signature never contains it.
+const payloadSignatureSeparator = "~"
+
+// payloadSignature returns a payload's signature: the first 128 bits of its
+// HMAC, short enough to keep QR codes easy to scan
+func (s *Service) payloadSignature(payload string) string {
+	mac := hmac.New(sha256.New, s.jwtSecret)
+	mac.Write([]byte("payload:" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// SignPayload appends a signature to a payload, such as the contents of a
+// ticket's QR code, so it cannot be forged or altered
+func (s *Service) SignPayload(payload string) string {
+	return payload + payloadSignatureSeparator + s.payloadSignature(payload)
+}
+
+// VerifyPayload checks a payload signed with SignPayload and returns it
+// without its signature
+func (s *Service) VerifyPayload(signed string) (string, bool) {
+	i := strings.LastIndex(signed, payloadSignatureSeparator)
+	if i < 0 {
+		return "", false
+	}
+	payload, sig := signed[:i], signed[i+len(payloadSignatureSeparator):]
+	if !hmac.Equal([]byte(sig), []byte(s.payloadSignature(payload))) {
+		return "", false
+	}
+	return payload, true
+}
+
+// CSRFToken returns the CSRF token of a cookie session: a signature of its
+// access token, so that a token planted in the browser by another site is
+// worthless and no server-side state is needed
+func (s *Service) CSRFToken(sessionToken string) string {
+	mac := hmac.New(sha256.New, s.jwtSecret)
+	mac.Write([]byte("csrf:" + sessionToken))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyCSRFToken checks a CSRF token sent with a cookie session's request
+func (s *Service) VerifyCSRFToken(sessionToken, csrfToken string) bool {
+	return csrfToken != "" && hmac.Equal([]byte(csrfToken), []byte(s.CSRFToken(sessionToken)))
+}
+
+// AccessTokenTTL returns how long access tokens are valid
+func (s *Service) AccessTokenTTL() time.Duration {
+	return time.Duration(s.jwtExpiryHours) * time.Hour
+}
@@ -0,0 +1,45 @@
+package auth
+
+import "testing"
+
+func TestSignPayload(t *testing.T) {
+	s := NewService("test-secret", 1, 1)
+
+	signed := s.SignPayload("ticket:1234~5")
+	payload, ok := s.VerifyPayload(signed)
+	if !ok || payload != "ticket:1234~5" {
+		t.Fatalf("VerifyPayload(%q) = %q, %v", signed, payload, ok)
+	}
+
+	forged := []string{
+		"ticket:1234",
+		"ticket:1235" + signed[len("ticket:1234~5"):],
+		signed + "x",
+		NewService("other-secret", 1, 1).SignPayload("ticket:1234~5"),
+	}
+	for _, f := range forged {
+		if _, ok := s.VerifyPayload(f); ok {
+			t.Errorf("VerifyPayload accepted %q", f)
+		}
+	}
+}
+
+func TestCSRFToken(t *testing.T) {
+	s := NewService("test-secret", 1, 1)
+
+	token := s.CSRFToken("session-a")
+	if !s.VerifyCSRFToken("session-a", token) {
+		t.Fatalf("VerifyCSRFToken rejected the session's own token")
+	}
+
+	for _, bad := range []string{
+		"",
+		token + "x",
+		s.CSRFToken("session-b"),
+		NewService("other-secret", 1, 1).CSRFToken("session-a"),
+	} {
+		if s.VerifyCSRFToken("session-a", bad) {
+			t.Errorf("VerifyCSRFToken accepted %q", bad)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// Package bootstrap prepares a fresh deployment: it creates the initial admin
+// account and seeds default houses, departments and event categories from a
+// YAML file. Each kind is seeded only while its table is empty, so running it
+// on every start is safe and never brings back entries an admin removed.
+package bootstrap
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/yourusername/college-event-backend/internal/services/auth"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultDepartmentColor matches the department handler's default
+const defaultDepartmentColor = "#4F46E5"
+
+var colorHexPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// Seed is the contents of a bootstrap file
+type Seed struct {
+	Houses      []House      `yaml:"houses"`
+	Departments []Department `yaml:"departments"`
+	Categories  []Category   `yaml:"categories"`
+}
+
+// House is a default house
+type House struct {
+	Name        string `yaml:"name"`
+	Color       string `yaml:"color"`
+	Description string `yaml:"description"`
+}
+
+// Department is a default department
+type Department struct {
+	Code        string `yaml:"code"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	IconName    string `yaml:"icon_name"`
+	ColorHex    string `yaml:"color_hex"`
+}
+
+// Category is a default event category. Categories are listed in the order
+// they appear in the file.
+type Category struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	ColorHex    string `yaml:"color_hex"`
+}
+
+// LoadSeed reads and validates a bootstrap file. Unknown keys are rejected
+// so that typos don't silently drop data.
+func LoadSeed(path string) (*Seed, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var seed Seed
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&seed); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := seed.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &seed, nil
+}
+
+// Validate checks required fields, lengths and duplicates against the
+// schema's constraints
+func (s *Seed) Validate() error {
+	names := map[string]bool{}
+	for i, h := range s.Houses {
+		if h.Name == "" || utf8.RuneCountInString(h.Name) > 100 {
+			return fmt.Errorf("houses[%d]: name is required and at most 100 characters", i)
+		}
+		if utf8.RuneCountInString(h.Color) > 50 {
+			return fmt.Errorf("houses[%d]: color is at most 50 characters", i)
+		}
+		if names[h.Name] {
+			return fmt.Errorf("houses[%d]: duplicate name %q", i, h.Name)
+		}
+		names[h.Name] = true
+	}
+
+	codes := map[string]bool{}
+	for i, d := range s.Departments {
+		if d.Code == "" || utf8.RuneCountInString(d.Code) > 10 {
+			return fmt.Errorf("departments[%d]: code is required and at most 10 characters", i)
+		}
+		if d.Name == "" || utf8.RuneCountInString(d.Name) > 255 {
+			return fmt.Errorf("departments[%d]: name is required and at most 255 characters", i)
+		}
+		if d.ColorHex != "" && !colorHexPattern.MatchString(d.ColorHex) {
+			return fmt.Errorf("departments[%d]: color_hex must look like #4F46E5", i)
+		}
+		if utf8.RuneCountInString(d.IconName) > 50 {
+			return fmt.Errorf("departments[%d]: icon_name is at most 50 characters", i)
+		}
+		if codes[d.Code] {
+			return fmt.Errorf("departments[%d]: duplicate code %q", i, d.Code)
+		}
+		codes[d.Code] = true
+	}
+
+	names = map[string]bool{}
+	for i, c := range s.Categories {
+		if c.Name == "" || utf8.RuneCountInString(c.Name) > 100 {
+			return fmt.Errorf("categories[%d]: name is required and at most 100 characters", i)
+		}
+		if c.ColorHex != "" && !colorHexPattern.MatchString(c.ColorHex) {
+			return fmt.Errorf("categories[%d]: color_hex must look like #4F46E5", i)
+		}
+		if names[c.Name] {
+			return fmt.Errorf("categories[%d]: duplicate name %q", i, c.Name)
+		}
+		names[c.Name] = true
+	}
+	return nil
+}
+
+// Config says what to bootstrap. Empty fields are skipped.
+type Config struct {
+	AdminEmail    string
+	AdminPassword string
+	Seed          *Seed
+}
+
+// Service runs the bootstrap
+type Service struct {
+	db          *sql.DB
+	authService *auth.Service
+}
+
+func NewService(db *sql.DB, authService *auth.Service) *Service {
+	return &Service{db: db, authService: authService}
+}
+
+// Run creates the initial admin and seeds whatever is still empty
+func (s *Service) Run(ctx context.Context, cfg Config) error {
+	if cfg.AdminEmail != "" && cfg.AdminPassword != "" {
+		if err := s.createAdmin(ctx, cfg.AdminEmail, cfg.AdminPassword); err != nil {
+			return fmt.Errorf("initial admin: %w", err)
+		}
+	}
+	if cfg.Seed == nil {
+		return nil
+	}
+
+	if err := s.seedHouses(ctx, cfg.Seed.Houses); err != nil {
+		return fmt.Errorf("houses: %w", err)
+	}
+	if err := s.seedDepartments(ctx, cfg.Seed.Departments); err != nil {
+		return fmt.Errorf("departments: %w", err)
+	}
+	if err := s.seedCategories(ctx, cfg.Seed.Categories); err != nil {
+		return fmt.Errorf("categories: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) createAdmin(ctx context.Context, email, password string) error {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)", email).Scan(&exists)
+	if err != nil || exists {
+		return err
+	}
+
+	passwordHash, err := s.authService.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO users (email, password_hash, full_name, role)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (email) DO NOTHING
+	`, email, passwordHash, "Initial Admin", "admin")
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		log.Printf("✓ Created initial admin user: %s", email)
+	}
+	return nil
+}
+
+// seed inserts rows into table if it has never had any. Soft-deleted rows
+// count, so deleting every default doesn't bring them back. The unique
+// constraint behind ON CONFLICT keeps concurrent starts from duplicating.
+func (s *Service) seed(ctx context.Context, table string, n int, insert func(tx *sql.Tx, i int) (sql.Result, error)) error {
+	if n == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s)", table)).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	created := 0
+	for i := 0; i < n; i++ {
+		res, err := insert(tx, i)
+		if err != nil {
+			return err
+		}
+		if affected, _ := res.RowsAffected(); affected > 0 {
+			created++
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	if created > 0 {
+		log.Printf("✓ Seeded %d %s", created, table)
+	}
+	return nil
+}
+
+func (s *Service) seedHouses(ctx context.Context, houses []House) error {
+	return s.seed(ctx, "houses", len(houses), func(tx *sql.Tx, i int) (sql.Result, error) {
+		h := houses[i]
+		return tx.ExecContext(ctx, `
+			INSERT INTO houses (name, color, description)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (name) DO NOTHING
+		`, h.Name, nullIfEmpty(h.Color), nullIfEmpty(h.Description))
+	})
+}
+
+func (s *Service) seedDepartments(ctx context.Context, departments []Department) error {
+	return s.seed(ctx, "departments", len(departments), func(tx *sql.Tx, i int) (sql.Result, error) {
+		d := departments[i]
+		colorHex := d.ColorHex
+		if colorHex == "" {
+			colorHex = defaultDepartmentColor
+		}
+		return tx.ExecContext(ctx, `
+			INSERT INTO departments (code, name, description, icon_name, color_hex)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (code) DO NOTHING
+		`, d.Code, d.Name, nullIfEmpty(d.Description), nullIfEmpty(d.IconName), colorHex)
+	})
+}
+
+func (s *Service) seedCategories(ctx context.Context, categories []Category) error {
+	return s.seed(ctx, "event_categories", len(categories), func(tx *sql.Tx, i int) (sql.Result, error) {
+		c := categories[i]
+		return tx.ExecContext(ctx, `
+			INSERT INTO event_categories (name, description, color_hex, sort_order)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (name) DO NOTHING
+		`, c.Name, nullIfEmpty(c.Description), nullIfEmpty(c.ColorHex), i)
+	})
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,40 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadSeed(t *testing.T) {
+	seed, err := LoadSeed("../../../bootstrap.example.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seed.Houses) == 0 || len(seed.Departments) == 0 || len(seed.Categories) == 0 {
+		t.Errorf("example seed has an empty section: %+v", seed)
+	}
+
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"unknown key", "houses:\n  - name: Agni\n    colour: red\n", "colour"},
+		{"missing name", "houses:\n  - color: red\n", "houses[0]: name is required"},
+		{"duplicate code", "departments:\n  - {code: CSE, name: A}\n  - {code: CSE, name: B}\n", `duplicate code "CSE"`},
+		{"long code", "departments:\n  - {code: COMPUTERSCI, name: A}\n", "at most 10 characters"},
+		{"bad color", "categories:\n  - {name: Sports, color_hex: green}\n", "color_hex must look like"},
+	}
+	dir := t.TempDir()
+	for _, tt := range tests {
+		path := filepath.Join(dir, "seed.yaml")
+		if err := os.WriteFile(path, []byte(tt.yaml), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadSeed(path); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want it to mention %q", tt.name, err, tt.want)
+		}
+	}
+}
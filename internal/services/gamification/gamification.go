@@ -0,0 +1,166 @@
+// Package gamification awards activity points and badges. Points are written
+// in the caller's transaction, alongside the action that earned them.
+package gamification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// Point sources
+const (
+	SourceEventAttendance   = "event_attendance"
+	SourceMeetingAttendance = "meeting_attendance"
+	SourcePost              = "post"
+	SourceCompetitionWin    = "competition_win"
+)
+
+// SourceHouseCompetition is the house point ledger source of inter-house
+// competition results, which earn points for a house rather than a member
+const SourceHouseCompetition = "house_competition"
+
+// DefaultPoints is what each source is worth when an Entry doesn't say
+var DefaultPoints = map[string]int{
+	SourceEventAttendance:   10,
+	SourceMeetingAttendance: 5,
+	SourcePost:              5,
+	SourceCompetitionWin:    50,
+}
+
+// ValidSource reports whether s is a known point source
+func ValidSource(s string) bool {
+	_, ok := DefaultPoints[s]
+	return ok
+}
+
+// Entry is a single award of points
+type Entry struct {
+	UserID   uuid.UUID
+	Source   string
+	SourceID uuid.UUID // What the points were earned for; one award per user, source and id
+	Points   int       // Zero uses DefaultPoints
+	Reason   string
+}
+
+// Award records an entry and grants any badges the user now qualifies for.
+// Awarding the same user, source and source id twice is a no-op.
+func Award(ctx context.Context, tx database.Execer, e Entry) error {
+	if e.Points == 0 {
+		e.Points = DefaultPoints[e.Source]
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO activity_points (user_id, source, source_id, points, reason)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		ON CONFLICT (user_id, source, source_id) DO NOTHING
+	`, e.UserID, e.Source, e.SourceID, e.Points, e.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to award %s points: %w", e.Source, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return nil
+	}
+
+	return grantBadges(ctx, tx, e.UserID)
+}
+
+// Revoke removes a user's award for a source and source id, such as event
+// attendance points for a registration they gave away. Badges already
+// granted are kept.
+func Revoke(ctx context.Context, tx database.Execer, userID uuid.UUID, source string, sourceID uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `
+		DELETE FROM activity_points WHERE user_id = $1 AND source = $2 AND source_id = $3
+	`, userID, source, sourceID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke %s points: %w", source, err)
+	}
+	return nil
+}
+
+// grantBadges awards every active badge whose rule the user now meets
+func grantBadges(ctx context.Context, tx database.Execer, userID uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO user_badges (user_id, badge_id)
+		SELECT $1, b.id
+		FROM badges b
+		WHERE b.is_active AND b.threshold <= CASE
+			WHEN b.rule_source IS NULL THEN
+				(SELECT COALESCE(SUM(points), 0) FROM activity_points WHERE user_id = $1)
+			ELSE
+				(SELECT COUNT(*) FROM activity_points WHERE user_id = $1 AND source = b.rule_source)
+			END
+		ON CONFLICT (user_id, badge_id) DO NOTHING
+	`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to grant badges: %w", err)
+	}
+	return nil
+}
+
+// CreditHouse adds points to the house of userID and records them in the
+// house point ledger. It does nothing if the user has no house.
+func CreditHouse(ctx context.Context, tx database.Execer, userID uuid.UUID, points int, reason, source string, sourceID uuid.UUID, awardedBy uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `
+		WITH credited AS (
+			INSERT INTO house_point_ledger (house_id, points, reason, source, source_id, user_id, awarded_by)
+			SELECT house_id, $2, $3, $4, $5, $1, $6
+			FROM house_members
+			WHERE user_id = $1
+			RETURNING house_id
+		)
+		UPDATE houses SET points = points + $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (SELECT house_id FROM credited)
+	`, userID, points, reason, source, sourceID, awardedBy)
+	if err != nil {
+		return fmt.Errorf("failed to credit house points: %w", err)
+	}
+	return nil
+}
+
+// CreditHouseDirectly adds points to a house and records them in the house
+// point ledger, for points the house earned as a whole
+func CreditHouseDirectly(ctx context.Context, tx database.Execer, houseID uuid.UUID, points int, reason, source string, sourceID uuid.UUID, awardedBy uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `
+		WITH credited AS (
+			INSERT INTO house_point_ledger (house_id, points, reason, source, source_id, awarded_by)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING house_id
+		)
+		UPDATE houses SET points = points + $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (SELECT house_id FROM credited)
+	`, houseID, points, reason, source, sourceID, awardedBy)
+	if err != nil {
+		return fmt.Errorf("failed to credit house points: %w", err)
+	}
+	return nil
+}
+
+// CreditHousesOf adds points once to each distinct house among userIDs, so a
+// team whose members share a house earns it the points once. Recorded without
+// a user, as the points belong to the team.
+func CreditHousesOf(ctx context.Context, tx database.Execer, userIDs []uuid.UUID, points int, reason, source string, sourceID uuid.UUID, awardedBy uuid.UUID) error {
+	ids := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = id.String()
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		WITH credited AS (
+			INSERT INTO house_point_ledger (house_id, points, reason, source, source_id, awarded_by)
+			SELECT DISTINCT house_id, $2::integer, $3, $4, $5::uuid, $6::uuid
+			FROM house_members
+			WHERE user_id = ANY($1::uuid[])
+			RETURNING house_id
+		)
+		UPDATE houses SET points = points + $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (SELECT house_id FROM credited)
+	`, pq.Array(ids), points, reason, source, sourceID, awardedBy)
+	if err != nil {
+		return fmt.Errorf("failed to credit house points: %w", err)
+	}
+	return nil
+}
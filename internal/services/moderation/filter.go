@@ -0,0 +1,117 @@
+package moderation
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Verdict is what the content filter decides to do with a piece of content
+type Verdict int
+
+const (
+	// Allow publishes the content
+	Allow Verdict = iota
+	// Flag publishes the content and queues it for a moderator to review
+	Flag
+	// Reject refuses the content
+	Reject
+)
+
+// Flag reasons
+const (
+	ReasonBannedWord   = "banned_word"
+	ReasonFlaggedWord  = "flagged_word"
+	ReasonTooManyLinks = "too_many_links"
+	ReasonLinksOnly    = "links_only"
+)
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+// Result is the filter's verdict on some content and why
+type Result struct {
+	Verdict Verdict
+	Reasons []string
+}
+
+// Filter screens user content for banned words and link spam. Content with
+// a banned word is rejected. Borderline content, with a flagged word or
+// more links than allowed, is published but flagged for moderation.
+// A nil *Filter allows everything.
+type Filter struct {
+	banned   map[string]bool
+	flagged  map[string]bool
+	maxLinks int
+}
+
+// NewFilter creates a content filter. Words are matched whole and without
+// regard to case. maxLinks is how many links content may have before it is
+// flagged; a negative maxLinks disables the link check.
+func NewFilter(banned, flagged []string, maxLinks int) *Filter {
+	f := &Filter{
+		banned:   make(map[string]bool, len(banned)),
+		flagged:  make(map[string]bool, len(flagged)),
+		maxLinks: maxLinks,
+	}
+	for _, w := range banned {
+		f.banned[strings.ToLower(w)] = true
+	}
+	for _, w := range flagged {
+		f.flagged[strings.ToLower(w)] = true
+	}
+	return f
+}
+
+// ParseWordList parses a comma-separated word list, skipping blanks
+func ParseWordList(s string) []string {
+	var words []string
+	for _, w := range strings.Split(s, ",") {
+		if w = strings.TrimSpace(w); w != "" {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// Check screens the texts making up one piece of content, such as an
+// announcement's title and body
+func (f *Filter) Check(texts ...string) Result {
+	var r Result
+	if f == nil {
+		return r
+	}
+	flag := func(reason string) {
+		r.Reasons = append(r.Reasons, reason)
+		if r.Verdict < Flag {
+			r.Verdict = Flag
+		}
+	}
+
+	text := strings.Join(texts, "\n")
+	links := linkPattern.FindAllString(text, -1)
+	if f.maxLinks >= 0 {
+		if len(links) > f.maxLinks {
+			flag(ReasonTooManyLinks)
+		} else if len(links) > 0 && strings.TrimSpace(linkPattern.ReplaceAllString(text, "")) == "" {
+			flag(ReasonLinksOnly)
+		}
+	}
+
+	var flaggedWord bool
+	words := strings.FieldsFunc(linkPattern.ReplaceAllString(text, " "), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '\''
+	})
+	for _, w := range words {
+		w = strings.ToLower(strings.Trim(w, "'"))
+		if f.banned[w] {
+			r.Verdict = Reject
+			r.Reasons = append(r.Reasons, ReasonBannedWord)
+			return r
+		}
+		if f.flagged[w] && !flaggedWord {
+			flaggedWord = true
+			flag(ReasonFlaggedWord)
+		}
+	}
+	return r
+}
@@ -0,0 +1,58 @@
+package moderation
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilterCheck(t *testing.T) {
+	f := NewFilter([]string{"Scam"}, []string{"idiot"}, 2)
+
+	tests := []struct {
+		name    string
+		texts   []string
+		verdict Verdict
+		reasons []string
+	}{
+		{"clean", []string{"See you at the fest!"}, Allow, nil},
+		{"banned word ignores case", []string{"This is a SCAM."}, Reject, []string{ReasonBannedWord}},
+		{"banned word in any text", []string{"Title", "total scam"}, Reject, []string{ReasonBannedWord}},
+		{"banned word inside another word", []string{"scampering squirrels"}, Allow, nil},
+		{"flagged word", []string{"what an idiot"}, Flag, []string{ReasonFlaggedWord}},
+		{"flagged word repeated", []string{"idiot idiot"}, Flag, []string{ReasonFlaggedWord}},
+		{"links within limit", []string{"slides at https://a.edu/x and www.b.edu"}, Allow, nil},
+		{"too many links", []string{"https://a.io https://b.io https://c.io buy now"}, Flag, []string{ReasonTooManyLinks}},
+		{"links only", []string{"  https://a.io/deal  "}, Flag, []string{ReasonLinksOnly}},
+		{"words in links ignored", []string{"see https://idiot.example.com/page"}, Allow, nil},
+		{"flagged and links", []string{"idiot https://a.io https://b.io https://c.io"}, Flag, []string{ReasonTooManyLinks, ReasonFlaggedWord}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := f.Check(tt.texts...)
+			if got.Verdict != tt.verdict || !reflect.DeepEqual(got.Reasons, tt.reasons) {
+				t.Errorf("Check(%q) = %v %v, want %v %v", tt.texts, got.Verdict, got.Reasons, tt.verdict, tt.reasons)
+			}
+		})
+	}
+}
+
+func TestFilterCheckNil(t *testing.T) {
+	var f *Filter
+	if got := f.Check("scam"); got.Verdict != Allow {
+		t.Errorf("nil filter verdict = %v, want Allow", got.Verdict)
+	}
+}
+
+func TestFilterLinkCheckDisabled(t *testing.T) {
+	f := NewFilter(nil, nil, -1)
+	if got := f.Check("https://a.io https://b.io https://c.io"); got.Verdict != Allow {
+		t.Errorf("verdict = %v, want Allow with the link check disabled", got.Verdict)
+	}
+}
+
+func TestParseWordList(t *testing.T) {
+	got := ParseWordList(" spam, ,scam ,")
+	if want := []string{"spam", "scam"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseWordList() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,95 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// Content types that can be flagged
+const (
+	ContentPostComment         = "post_comment"
+	ContentAnnouncementComment = "announcement_comment"
+	ContentAnnouncement        = "announcement"
+	ContentPost                = "post"
+	ContentStory               = "story"
+	ContentChatMessage         = "chat_message"
+	ContentClubReview          = "club_review"
+)
+
+// contentTables maps each content type to its table. Every table is soft
+// deleted through deleted_at.
+var contentTables = map[string]string{
+	ContentPostComment:         "post_comments",
+	ContentAnnouncementComment: "announcement_comments",
+	ContentAnnouncement:        "house_announcements",
+	ContentPost:                "posts",
+	ContentStory:               "stories",
+	ContentChatMessage:         "chat_channel_messages",
+	ContentClubReview:          "club_reviews",
+}
+
+// heldTables maps the content types that can be held back from publishing
+// until reviewed to their tables, which have a held_for_review column
+var heldTables = map[string]string{
+	ContentPost:       "posts",
+	ContentStory:      "stories",
+	ContentClubReview: "club_reviews",
+}
+
+// RecordFlag queues content the filter flagged for a moderator to review
+func RecordFlag(ctx context.Context, tx database.Execer, contentType string, contentID, userID uuid.UUID, reasons []string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO content_flags (content_type, content_id, user_id, reasons)
+		VALUES ($1, $2, $3, $4)
+	`, contentType, contentID, userID, pq.Array(reasons))
+	if err != nil {
+		return fmt.Errorf("failed to flag %s: %w", contentType, err)
+	}
+	return nil
+}
+
+// DismissFlags dismisses the pending flags on content its author has
+// rewritten, since what they flagged is gone
+func DismissFlags(ctx context.Context, tx database.Execer, contentType string, contentID uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE content_flags SET status = 'dismissed', reviewed_at = NOW()
+		WHERE content_type = $1 AND content_id = $2 AND status = 'pending'
+	`, contentType, contentID)
+	if err != nil {
+		return fmt.Errorf("failed to dismiss flags on %s: %w", contentType, err)
+	}
+	return nil
+}
+
+// ReleaseContent publishes content held for review. Content that isn't
+// held is left alone.
+func ReleaseContent(ctx context.Context, tx database.Execer, contentType string, contentID uuid.UUID) error {
+	table, ok := heldTables[contentType]
+	if !ok {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx,
+		"UPDATE "+table+" SET held_for_review = FALSE WHERE id = $1", contentID)
+	if err != nil {
+		return fmt.Errorf("failed to release %s: %w", contentType, err)
+	}
+	return nil
+}
+
+// RemoveContent soft deletes flagged content
+func RemoveContent(ctx context.Context, tx database.Execer, contentType string, contentID uuid.UUID) error {
+	table, ok := contentTables[contentType]
+	if !ok {
+		return fmt.Errorf("unknown content type %q", contentType)
+	}
+	_, err := tx.ExecContext(ctx,
+		"UPDATE "+table+" SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL", contentID)
+	if err != nil {
+		return fmt.Errorf("failed to remove %s: %w", contentType, err)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Image flag reasons
+const (
+	ReasonImageAdult    = "image_adult"
+	ReasonImageViolence = "image_violence"
+	ReasonImageRacy     = "image_racy"
+	// ReasonImageUnchecked is given when the check itself failed, so the
+	// image is held rather than published unchecked
+	ReasonImageUnchecked = "image_unchecked"
+)
+
+// ImageChecker screens a publicly reachable image for unsafe content,
+// returning why it should be flagged, or nothing if it's safe
+type ImageChecker interface {
+	CheckImage(ctx context.Context, imageURL string) ([]string, error)
+}
+
+// CheckImages screens the images of one piece of content, such as a post's
+// image and thumbnail, skipping nil and empty URLs. A nil checker passes
+// everything. If a check fails, ReasonImageUnchecked is among the reasons
+// and the first error is returned with them.
+func CheckImages(ctx context.Context, c ImageChecker, imageURLs ...*string) ([]string, error) {
+	if c == nil {
+		return nil, nil
+	}
+	var reasons []string
+	var firstErr error
+	seen := map[string]bool{}
+	add := func(reason string) {
+		if !seen[reason] {
+			seen[reason] = true
+			reasons = append(reasons, reason)
+		}
+	}
+
+	for _, u := range imageURLs {
+		if u == nil || *u == "" {
+			continue
+		}
+		found, err := c.CheckImage(ctx, *u)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			add(ReasonImageUnchecked)
+			continue
+		}
+		for _, r := range found {
+			add(r)
+		}
+	}
+	return reasons, firstErr
+}
+
+// VisionChecker screens images with Google Cloud Vision SafeSearch,
+// flagging images likely to be adult, violent or racy
+type VisionChecker struct {
+	apiKey  string
+	baseURL string
+	http    *http.Client
+}
+
+// NewVisionChecker creates a Cloud Vision checker using an API key
+func NewVisionChecker(apiKey string) *VisionChecker {
+	return &VisionChecker{
+		apiKey:  apiKey,
+		baseURL: "https://vision.googleapis.com/v1",
+		http:    &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// visionFlagged are the SafeSearch likelihoods that flag an image
+var visionFlagged = map[string]bool{"LIKELY": true, "VERY_LIKELY": true}
+
+// CheckImage asks SafeSearch about the image at imageURL
+func (v *VisionChecker) CheckImage(ctx context.Context, imageURL string) ([]string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"requests": []map[string]interface{}{{
+			"image":    map[string]interface{}{"source": map[string]string{"imageUri": imageURL}},
+			"features": []map[string]string{{"type": "SAFE_SEARCH_DETECTION"}},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.baseURL+"/images:annotate?key="+v.apiKey, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := v.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vision request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vision returned status %d", resp.StatusCode)
+	}
+
+	var out struct {
+		Responses []struct {
+			SafeSearch struct {
+				Adult    string `json:"adult"`
+				Violence string `json:"violence"`
+				Racy     string `json:"racy"`
+			} `json:"safeSearchAnnotation"`
+			Error *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		} `json:"responses"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode vision response: %w", err)
+	}
+	if len(out.Responses) != 1 {
+		return nil, fmt.Errorf("vision returned %d responses", len(out.Responses))
+	}
+	r := out.Responses[0]
+	if r.Error != nil {
+		return nil, fmt.Errorf("vision could not check image: %s", r.Error.Message)
+	}
+
+	var reasons []string
+	if visionFlagged[r.SafeSearch.Adult] {
+		reasons = append(reasons, ReasonImageAdult)
+	}
+	if visionFlagged[r.SafeSearch.Violence] {
+		reasons = append(reasons, ReasonImageViolence)
+	}
+	if visionFlagged[r.SafeSearch.Racy] {
+		reasons = append(reasons, ReasonImageRacy)
+	}
+	return reasons, nil
+}
+
+// HookChecker screens images with a self-hosted model. Each image is POSTed
+// to the hook as {"image_url": "..."}, which answers
+// {"safe": bool, "reasons": [...]}.
+type HookChecker struct {
+	url  string
+	http *http.Client
+}
+
+// NewHookChecker creates a checker calling the hook at url
+func NewHookChecker(url string) *HookChecker {
+	return &HookChecker{url: url, http: &http.Client{Timeout: 15 * time.Second}}
+}
+
+// CheckImage asks the hook about the image at imageURL. An unsafe image
+// the hook gives no reasons for is flagged as adult.
+func (h *HookChecker) CheckImage(ctx context.Context, imageURL string) ([]string, error) {
+	body, err := json.Marshal(map[string]string{"image_url": imageURL})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("image hook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("image hook returned status %d", resp.StatusCode)
+	}
+
+	var out struct {
+		Safe    bool     `json:"safe"`
+		Reasons []string `json:"reasons"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode image hook response: %w", err)
+	}
+	if out.Safe {
+		return nil, nil
+	}
+	if len(out.Reasons) == 0 {
+		return []string{ReasonImageAdult}, nil
+	}
+	return out.Reasons, nil
+}
@@ -0,0 +1,122 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestVisionCheckImage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/images:annotate" || r.URL.Query().Get("key") != "secret" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var req struct {
+			Requests []struct {
+				Image struct {
+					Source struct {
+						ImageURI string `json:"imageUri"`
+					} `json:"source"`
+				} `json:"image"`
+			} `json:"requests"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		annotation := map[string]string{"adult": "VERY_UNLIKELY", "violence": "UNLIKELY", "racy": "POSSIBLE"}
+		if req.Requests[0].Image.Source.ImageURI == "https://cdn.example.com/bad.jpg" {
+			annotation = map[string]string{"adult": "VERY_LIKELY", "violence": "UNLIKELY", "racy": "LIKELY"}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"responses": []interface{}{map[string]interface{}{"safeSearchAnnotation": annotation}},
+		})
+	}))
+	defer srv.Close()
+
+	v := &VisionChecker{apiKey: "secret", baseURL: srv.URL, http: srv.Client()}
+
+	reasons, err := v.CheckImage(context.Background(), "https://cdn.example.com/ok.jpg")
+	if err != nil || reasons != nil {
+		t.Errorf("safe image = %v, %v", reasons, err)
+	}
+	reasons, err = v.CheckImage(context.Background(), "https://cdn.example.com/bad.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{ReasonImageAdult, ReasonImageRacy}; !reflect.DeepEqual(reasons, want) {
+		t.Errorf("unsafe image = %v, want %v", reasons, want)
+	}
+}
+
+func TestHookCheckImage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ImageURL string `json:"image_url"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		switch req.ImageURL {
+		case "ok":
+			w.Write([]byte(`{"safe": true}`))
+		case "gore":
+			w.Write([]byte(`{"safe": false, "reasons": ["image_violence"]}`))
+		case "unsafe":
+			w.Write([]byte(`{"safe": false}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	h := NewHookChecker(srv.URL)
+	tests := []struct {
+		url     string
+		reasons []string
+		wantErr bool
+	}{
+		{"ok", nil, false},
+		{"gore", []string{ReasonImageViolence}, false},
+		{"unsafe", []string{ReasonImageAdult}, false},
+		{"broken", nil, true},
+	}
+	for _, tt := range tests {
+		reasons, err := h.CheckImage(context.Background(), tt.url)
+		if (err != nil) != tt.wantErr || !reflect.DeepEqual(reasons, tt.reasons) {
+			t.Errorf("CheckImage(%q) = %v, %v", tt.url, reasons, err)
+		}
+	}
+}
+
+type stubChecker map[string][]string
+
+func (s stubChecker) CheckImage(ctx context.Context, imageURL string) ([]string, error) {
+	if imageURL == "down" {
+		return nil, errors.New("unavailable")
+	}
+	return s[imageURL], nil
+}
+
+func TestCheckImages(t *testing.T) {
+	c := stubChecker{"a": {ReasonImageRacy}, "b": {ReasonImageRacy, ReasonImageAdult}}
+	str := func(s string) *string { return &s }
+
+	if reasons, err := CheckImages(context.Background(), nil, str("a")); reasons != nil || err != nil {
+		t.Errorf("nil checker = %v, %v", reasons, err)
+	}
+
+	reasons, err := CheckImages(context.Background(), c, str("a"), nil, str(""), str("b"), str("clean"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{ReasonImageRacy, ReasonImageAdult}; !reflect.DeepEqual(reasons, want) {
+		t.Errorf("reasons = %v, want %v", reasons, want)
+	}
+
+	reasons, err = CheckImages(context.Background(), c, str("down"), str("clean"))
+	if err == nil || !reflect.DeepEqual(reasons, []string{ReasonImageUnchecked}) {
+		t.Errorf("failed check = %v, %v", reasons, err)
+	}
+}
@@ -0,0 +1,87 @@
+// Package moderation decides whether users may interact with one another's
+// content. Users can block each other, which hides the blocked user's
+// comments from the blocker and stops either commenting on or liking the
+// other's content. Admins can mute a user from commenting for a period, and
+// shadow ban a user so their posts and comments are seen only by themselves.
+// Posts and stories whose images fail the image check are held back, seen
+// only by their authors, until a moderator reviews them.
+package moderation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// MutedUntil returns when the user's mute expires, or nil if they are not
+// muted
+func MutedUntil(ctx context.Context, q database.Querier, userID uuid.UUID) (*time.Time, error) {
+	var until time.Time
+	err := q.QueryRowContext(ctx, `
+		SELECT muted_until FROM user_mutes WHERE user_id = $1 AND muted_until > NOW()
+	`, userID).Scan(&until)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check mute: %w", err)
+	}
+	return &until, nil
+}
+
+// Blocked reports whether either user has blocked the other
+func Blocked(ctx context.Context, q database.Querier, a, b uuid.UUID) (bool, error) {
+	var blocked bool
+	err := q.QueryRowContext(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM blocked_users
+			WHERE (blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1)
+		)
+	`, a, b).Scan(&blocked)
+	if err != nil {
+		return false, fmt.Errorf("failed to check block: %w", err)
+	}
+	return blocked, nil
+}
+
+// ShadowBanned reports whether the user is shadow banned
+func ShadowBanned(ctx context.Context, q database.Querier, userID uuid.UUID) (bool, error) {
+	var banned bool
+	err := q.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM shadow_bans WHERE user_id = $1)", userID,
+	).Scan(&banned)
+	if err != nil {
+		return false, fmt.Errorf("failed to check shadow ban: %w", err)
+	}
+	return banned, nil
+}
+
+// NotBlockedBy returns a SQL condition excluding rows whose author,
+// authorColumn, the viewer bound to viewerParam has blocked. A NULL viewer
+// excludes nothing.
+func NotBlockedBy(viewerParam, authorColumn string) string {
+	return fmt.Sprintf(
+		"NOT EXISTS (SELECT 1 FROM blocked_users bu WHERE bu.blocker_id = %s AND bu.blocked_id = %s)",
+		viewerParam, authorColumn)
+}
+
+// VisibleTo returns a SQL condition excluding rows whose author,
+// authorColumn, is shadow banned, unless the author is the viewer bound to
+// viewerParam. A NULL viewer sees nothing by shadow banned users.
+func VisibleTo(viewerParam, authorColumn string) string {
+	return fmt.Sprintf(
+		"(NOT EXISTS (SELECT 1 FROM shadow_bans sb WHERE sb.user_id = %[2]s) OR %[2]s = %[1]s)",
+		viewerParam, authorColumn)
+}
+
+// Published returns a SQL condition excluding posts or stories, table,
+// that are held for review, unless their author is the viewer bound to
+// viewerParam
+func Published(viewerParam, table string) string {
+	return fmt.Sprintf("(NOT %[2]s.held_for_review OR %[2]s.created_by = %[1]s)", viewerParam, table)
+}
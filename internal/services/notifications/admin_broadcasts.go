@@ -0,0 +1,149 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+)
+
+// TopicAdminBroadcastCreated is written when admins broadcast to a cohort
+const TopicAdminBroadcastCreated = "admin_broadcast.created"
+
+// AdminBroadcastCreatedPayload names the broadcast to deliver
+type AdminBroadcastCreatedPayload struct {
+	BroadcastID uuid.UUID `json:"broadcast_id"`
+}
+
+// cohortRecipients selects the user IDs of a cohort. All but all_students
+// take the cohort's department, year, house or event as $1.
+var cohortRecipients = map[string]string{
+	models.BroadcastCohortAllStudents: `
+		SELECT id FROM users WHERE role = 'student' AND deleted_at IS NULL`,
+	models.BroadcastCohortDepartment: `
+		SELECT id FROM users WHERE role = 'student' AND deleted_at IS NULL AND department = $1`,
+	models.BroadcastCohortYear: `
+		SELECT id FROM users WHERE role = 'student' AND deleted_at IS NULL AND year = $1`,
+	models.BroadcastCohortHouse: `
+		SELECT hm.user_id FROM house_members hm
+		JOIN users u ON u.id = hm.user_id AND u.deleted_at IS NULL
+		WHERE hm.house_id = $1`,
+	models.BroadcastCohortEventRegistrants: `
+		SELECT r.user_id FROM event_registrations r
+		JOIN users u ON u.id = r.user_id AND u.deleted_at IS NULL
+		WHERE r.event_id = $1`,
+}
+
+// cohortCategories are the notification categories of broadcasts to a
+// house or an event's registrants; the rest are announcements
+var cohortCategories = map[string]string{
+	models.BroadcastCohortHouse:            CategoryHouseUpdates,
+	models.BroadcastCohortEventRegistrants: CategoryEvents,
+}
+
+// handleAdminBroadcastCreated delivers a broadcast to each member of its
+// cohort and records how many it reached. A recipient it fails to reach is
+// counted rather than retried, so one bad address doesn't hold up the rest
+// of a large cohort; if it reaches nobody, the delivery is retried.
+func (s *Service) handleAdminBroadcastCreated(ctx context.Context, event outbox.Event) error {
+	var p AdminBroadcastCreatedPayload
+	if err := json.Unmarshal(event.Payload, &p); err != nil {
+		return err
+	}
+
+	var b models.AdminBroadcast
+	err := s.db.QueryRowContext(ctx, `
+		SELECT cohort, department, year, house_id, event_id, title, body, send_email, delivered_at
+		FROM admin_broadcasts WHERE id = $1
+	`, p.BroadcastID).Scan(&b.Cohort, &b.Department, &b.Year, &b.HouseID, &b.EventID,
+		&b.Title, &b.Body, &b.SendEmail, &b.DeliveredAt)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if b.DeliveredAt != nil {
+		return nil
+	}
+
+	query, ok := cohortRecipients[b.Cohort]
+	if !ok {
+		return fmt.Errorf("unknown broadcast cohort %q", b.Cohort)
+	}
+	var args []interface{}
+	switch b.Cohort {
+	case models.BroadcastCohortDepartment:
+		args = append(args, b.Department)
+	case models.BroadcastCohortYear:
+		args = append(args, b.Year)
+	case models.BroadcastCohortHouse:
+		args = append(args, b.HouseID)
+	case models.BroadcastCohortEventRegistrants:
+		args = append(args, b.EventID)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	var recipients []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		recipients = append(recipients, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	category, ok := cohortCategories[b.Cohort]
+	if !ok {
+		category = CategoryAnnouncements
+	}
+	data := map[string]string{"admin_broadcast_id": p.BroadcastID.String()}
+	if b.HouseID != nil {
+		data["house_id"] = b.HouseID.String()
+	}
+	if b.EventID != nil {
+		data["event_id"] = b.EventID.String()
+	}
+
+	failed := 0
+	var lastErr error
+	for _, userID := range recipients {
+		err := s.Send(ctx, Message{
+			UserID:    userID,
+			Category:  category,
+			Title:     b.Title,
+			Body:      b.Body,
+			Data:      data,
+			SendEmail: b.SendEmail,
+			DedupeKey: "admin_broadcast:" + p.BroadcastID.String() + ":" + userID.String(),
+		})
+		if err != nil {
+			log.Printf("[BROADCAST] Failed to deliver broadcast %s to %s: %v", p.BroadcastID, userID, err)
+			failed++
+			lastErr = err
+		}
+	}
+	if len(recipients) > 0 && failed == len(recipients) {
+		return fmt.Errorf("broadcast reached no recipients: %w", lastErr)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		UPDATE admin_broadcasts
+		SET recipient_count = $2, delivered_count = $3, failed_count = $4, delivered_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`, p.BroadcastID, len(recipients), len(recipients)-failed, failed)
+	return err
+}
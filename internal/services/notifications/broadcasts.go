@@ -0,0 +1,91 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+)
+
+// TopicEventBroadcastCreated is written when organizers broadcast to an
+// event's attendees
+const TopicEventBroadcastCreated = "event_broadcast.created"
+
+// EventBroadcastCreatedPayload names the broadcast to deliver
+type EventBroadcastCreatedPayload struct {
+	BroadcastID uuid.UUID `json:"broadcast_id"`
+}
+
+// broadcastRecipients selects the user IDs of a segment of an event's
+// registrants
+var broadcastRecipients = map[string]string{
+	models.BroadcastSegmentAll: `
+		SELECT user_id FROM event_registrations WHERE event_id = $1`,
+	models.BroadcastSegmentPaid: `
+		SELECT r.user_id FROM event_registrations r
+		WHERE r.event_id = $1 AND EXISTS (
+			SELECT 1 FROM event_payments p
+			WHERE p.event_id = r.event_id AND p.user_id = r.user_id AND p.status = 'paid'
+		)`,
+}
+
+func (s *Service) handleEventBroadcastCreated(ctx context.Context, event outbox.Event) error {
+	var p EventBroadcastCreatedPayload
+	if err := json.Unmarshal(event.Payload, &p); err != nil {
+		return err
+	}
+
+	var b models.EventBroadcast
+	err := s.db.QueryRowContext(ctx, `
+		SELECT event_id, segment, title, body, send_email FROM event_broadcasts WHERE id = $1
+	`, p.BroadcastID).Scan(&b.EventID, &b.Segment, &b.Title, &b.Body, &b.SendEmail)
+	if err == sql.ErrNoRows {
+		// The event, and its broadcasts with it, was deleted
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	rows, err := s.db.QueryContext(ctx, broadcastRecipients[b.Segment], b.EventID)
+	if err != nil {
+		return err
+	}
+	var recipients []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		recipients = append(recipients, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, userID := range recipients {
+		err := s.Send(ctx, Message{
+			UserID:    userID,
+			Category:  CategoryEvents,
+			Title:     b.Title,
+			Body:      b.Body,
+			Data:      map[string]string{"event_id": b.EventID.String(), "broadcast_id": p.BroadcastID.String()},
+			SendEmail: b.SendEmail,
+			DedupeKey: "broadcast:" + p.BroadcastID.String() + ":" + userID.String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		UPDATE event_broadcasts SET recipient_count = $2, delivered_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`, p.BroadcastID, len(recipients))
+	return err
+}
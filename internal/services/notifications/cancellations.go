@@ -0,0 +1,82 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+)
+
+// TopicEventCancelled is written when an event is cancelled
+const TopicEventCancelled = "event.cancelled"
+
+// EventCancelledPayload describes a cancelled event
+type EventCancelledPayload struct {
+	EventID    uuid.UUID `json:"event_id"`
+	EventTitle string    `json:"event_title"`
+	Reason     string    `json:"reason,omitempty"`
+}
+
+func (s *Service) handleEventCancelled(ctx context.Context, event outbox.Event) error {
+	var p EventCancelledPayload
+	if err := json.Unmarshal(event.Payload, &p); err != nil {
+		return err
+	}
+
+	// Paid registrants learn their payment is being refunded
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT r.user_id, EXISTS (
+			SELECT 1 FROM event_payments p
+			WHERE p.event_id = r.event_id AND p.user_id = r.user_id
+			  AND p.status IN ('refund_pending', 'refunded')
+		)
+		FROM event_registrations r
+		WHERE r.event_id = $1
+	`, p.EventID)
+	if err != nil {
+		return err
+	}
+	type recipient struct {
+		userID   uuid.UUID
+		refunded bool
+	}
+	var recipients []recipient
+	for rows.Next() {
+		var r recipient
+		if err := rows.Scan(&r.userID, &r.refunded); err != nil {
+			rows.Close()
+			return err
+		}
+		recipients = append(recipients, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	body := p.EventTitle + " has been cancelled."
+	if p.Reason != "" {
+		body += " " + p.Reason
+	}
+	for _, r := range recipients {
+		msgBody := body
+		if r.refunded {
+			msgBody += " Your payment will be refunded to your original payment method."
+		}
+		err := s.Send(ctx, Message{
+			UserID:    r.userID,
+			Category:  CategoryEvents,
+			Title:     "Event cancelled",
+			Body:      msgBody,
+			Data:      map[string]string{"event_id": p.EventID.String()},
+			SendEmail: true,
+			DedupeKey: event.ID.String() + ":" + r.userID.String(),
+			Urgent:    true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+)
+
+// TopicEventRegistrationsAdded is written whenever registrations raise an
+// event's participant count. The capacity rules run when it is relayed.
+const TopicEventRegistrationsAdded = "event.registrations_added"
+
+// EventRegistrationsAddedPayload carries an event's participant count before
+// and after the registrations, as committed with them
+type EventRegistrationsAddedPayload struct {
+	EventID uuid.UUID `json:"event_id"`
+	Before  int       `json:"before"`
+	After   int       `json:"after"`
+}
+
+// ParseCapacityThresholds parses a comma-separated list of percentages of
+// max_participants, such as "50,90,100". An empty string disables capacity
+// alerts.
+func ParseCapacityThresholds(s string) ([]int, error) {
+	var thresholds []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		t, err := strconv.Atoi(strings.TrimSuffix(part, "%"))
+		if err != nil || t < 1 || t > 100 {
+			return nil, fmt.Errorf("invalid capacity threshold %q, use a percentage from 1 to 100", part)
+		}
+		thresholds = append(thresholds, t)
+	}
+	sort.Ints(thresholds)
+	return thresholds, nil
+}
+
+// SetCapacityThresholds sets the percentages of capacity at which event
+// creators are notified
+func (s *Service) SetCapacityThresholds(thresholds []int) {
+	s.capacityThresholds = thresholds
+}
+
+// CapacityThresholdsCrossed returns the thresholds that a rise in
+// participants from before to after reached, in ascending order
+func CapacityThresholdsCrossed(thresholds []int, before, after, max int) []int {
+	var crossed []int
+	for _, t := range thresholds {
+		// Compare in whole participants times 100 to avoid rounding
+		if before*100 < t*max && after*100 >= t*max {
+			crossed = append(crossed, t)
+		}
+	}
+	return crossed
+}
+
+func (s *Service) handleEventRegistrationsAdded(ctx context.Context, event outbox.Event) error {
+	var p EventRegistrationsAddedPayload
+	if err := json.Unmarshal(event.Payload, &p); err != nil {
+		return err
+	}
+	if len(s.capacityThresholds) == 0 {
+		return nil
+	}
+
+	var title string
+	var createdBy *uuid.UUID
+	var maxParticipants *int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT title, created_by, max_participants FROM events
+		WHERE id = $1 AND deleted_at IS NULL
+	`, p.EventID).Scan(&title, &createdBy, &maxParticipants)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if createdBy == nil || maxParticipants == nil || *maxParticipants <= 0 {
+		return nil
+	}
+
+	for _, t := range CapacityThresholdsCrossed(s.capacityThresholds, p.Before, p.After, *maxParticipants) {
+		body := fmt.Sprintf("%s is %d%% full: %d of %d spots taken.", title, t, p.After, *maxParticipants)
+		if t == 100 {
+			body = fmt.Sprintf("%s is full: all %d spots are taken.", title, *maxParticipants)
+		}
+		// Each threshold is announced once per event, even if cancellations
+		// let registrations cross it again
+		err := s.Send(ctx, Message{
+			UserID:    *createdBy,
+			Category:  CategoryEvents,
+			Title:     "Registrations for " + title,
+			Body:      body,
+			Data:      map[string]string{"event_id": p.EventID.String(), "threshold": strconv.Itoa(t)},
+			SendEmail: t == 100,
+			DedupeKey: fmt.Sprintf("capacity:%s:%d", p.EventID, t),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package notifications
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCapacityThresholds(t *testing.T) {
+	got, err := ParseCapacityThresholds(" 90, 50%,100 ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{50, 90, 100}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseCapacityThresholds() = %v, want %v", got, want)
+	}
+
+	if got, err := ParseCapacityThresholds(""); err != nil || got != nil {
+		t.Errorf("ParseCapacityThresholds(\"\") = %v, %v, want nil, nil", got, err)
+	}
+	for _, bad := range []string{"0", "101", "half"} {
+		if _, err := ParseCapacityThresholds(bad); err == nil {
+			t.Errorf("ParseCapacityThresholds(%q) should fail", bad)
+		}
+	}
+}
+
+func TestCapacityThresholdsCrossed(t *testing.T) {
+	thresholds := []int{50, 90, 100}
+
+	tests := []struct {
+		name          string
+		before, after int
+		max           int
+		want          []int
+	}{
+		{"below every threshold", 3, 4, 10, nil},
+		{"reaches half", 4, 5, 10, []int{50}},
+		{"already past half", 5, 6, 10, nil},
+		{"rounds up, not down", 44, 45, 99, nil},
+		{"just over half of an odd capacity", 49, 50, 99, []int{50}},
+		{"fills up", 9, 10, 10, []int{100}},
+		{"bulk registration crosses several", 4, 10, 10, []int{50, 90, 100}},
+		{"pass holders over capacity", 10, 12, 10, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CapacityThresholdsCrossed(thresholds, tt.before, tt.after, tt.max)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CapacityThresholdsCrossed(%d, %d, %d) = %v, want %v", tt.before, tt.after, tt.max, got, tt.want)
+			}
+		})
+	}
+}
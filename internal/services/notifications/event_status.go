@@ -0,0 +1,62 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+)
+
+// TopicEventStatusChanged is written when an event starts or ends
+const TopicEventStatusChanged = "event.status_changed"
+
+// EventStatusChangedPayload names an event and the status it moved to,
+// ongoing or completed
+type EventStatusChangedPayload struct {
+	EventID    uuid.UUID `json:"event_id"`
+	EventTitle string    `json:"event_title"`
+	Status     string    `json:"status"`
+}
+
+// handleEventStatusChanged tells an event's registrants it has started, or
+// thanks them once it's over
+func (s *Service) handleEventStatusChanged(ctx context.Context, event outbox.Event) error {
+	var p EventStatusChangedPayload
+	if err := json.Unmarshal(event.Payload, &p); err != nil {
+		return err
+	}
+
+	var body string
+	switch p.Status {
+	case models.EventStatusOngoing:
+		body = "Starting now"
+	case models.EventStatusCompleted:
+		body = "That's a wrap. Thanks for taking part!"
+	default:
+		return nil
+	}
+
+	registered, err := s.userIDs(ctx, "SELECT user_id FROM event_registrations WHERE event_id = $1", p.EventID)
+	if err != nil {
+		return err
+	}
+	for _, userID := range registered {
+		err := s.Send(ctx, Message{
+			UserID:    userID,
+			Category:  CategoryEvents,
+			Title:     p.EventTitle,
+			Body:      body,
+			Data:      map[string]string{"event_id": p.EventID.String(), "status": p.Status},
+			DedupeKey: "event:" + p.EventID.String() + ":" + p.Status + ":" + userID.String(),
+			// Only worth hearing about when the event starts, not after
+			// quiet hours end
+			Urgent: p.Status == models.EventStatusOngoing,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
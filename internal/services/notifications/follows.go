@@ -0,0 +1,110 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+)
+
+// TopicFollowedContentPublished is written when a club or house publishes
+// an event or post, to notify its followers
+const TopicFollowedContentPublished = "followed_content.published"
+
+// Kinds of content followers are notified of
+const (
+	FollowedEvent      = "event"
+	FollowedHouseEvent = "house_event"
+	FollowedPost       = "post"
+)
+
+// FollowedContentPublishedPayload names the content and the club or house
+// that published it
+type FollowedContentPublishedPayload struct {
+	TargetType  string     `json:"target_type"`
+	TargetID    uuid.UUID  `json:"target_id"`
+	ContentType string     `json:"content_type"`
+	ContentID   uuid.UUID  `json:"content_id"`
+	Title       string     `json:"title,omitempty"`
+	AuthorID    *uuid.UUID `json:"author_id,omitempty"`
+}
+
+// followTargetNames selects the name of a club or house by ID
+var followTargetNames = map[string]string{
+	models.FollowTargetClub:  "SELECT name FROM clubs WHERE id = $1 AND deleted_at IS NULL",
+	models.FollowTargetHouse: "SELECT name FROM houses WHERE id = $1 AND deleted_at IS NULL",
+}
+
+func (s *Service) handleFollowedContentPublished(ctx context.Context, event outbox.Event) error {
+	var p FollowedContentPublishedPayload
+	if err := json.Unmarshal(event.Payload, &p); err != nil {
+		return err
+	}
+
+	query, ok := followTargetNames[p.TargetType]
+	if !ok {
+		return nil
+	}
+	var name string
+	err := s.db.QueryRowContext(ctx, query, p.TargetID).Scan(&name)
+	if err == sql.ErrNoRows {
+		// Deleted since; nothing to tell its followers
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT user_id FROM user_follows
+		WHERE target_type = $1 AND target_id = $2 AND user_id IS DISTINCT FROM $3
+	`, p.TargetType, p.TargetID, p.AuthorID)
+	if err != nil {
+		return err
+	}
+	var followers []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		followers = append(followers, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	category, body := CategoryEvents, "New event: "+p.Title
+	if p.ContentType == FollowedPost {
+		category, body = CategoryClubAnnouncements, "Shared a new post"
+		if p.TargetType == models.FollowTargetHouse {
+			category = CategoryHouseUpdates
+		}
+	}
+	data := map[string]string{
+		p.TargetType + "_id":  p.TargetID.String(),
+		p.ContentType + "_id": p.ContentID.String(),
+	}
+
+	// A post can be both a club's and a house's; keying on the content
+	// notifies someone following both only once
+	for _, userID := range followers {
+		err := s.Send(ctx, Message{
+			UserID:    userID,
+			Category:  category,
+			Title:     name,
+			Body:      body,
+			Data:      data,
+			DedupeKey: "follow:" + p.ContentID.String() + ":" + userID.String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package notifications
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/pkg/ics"
+)
+
+// eventInvite returns a calendar invite for an event, to attach to emails
+// confirming a registration
+func (s *Service) eventInvite(ctx context.Context, eventID uuid.UUID) (Attachment, error) {
+	e := ics.Event{UID: eventID.String() + "@college-events"}
+	var description, location *string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT title, description, location, start_date, end_date, updated_at
+		FROM events WHERE id = $1
+	`, eventID).Scan(&e.Title, &description, &location, &e.Start, &e.End, &e.Updated)
+	if err != nil {
+		return Attachment{}, err
+	}
+	if description != nil {
+		e.Description = *description
+	}
+	if location != nil {
+		e.Location = *location
+	}
+	if s.publicBaseURL != "" {
+		e.URL = s.eventURL(eventID)
+	}
+
+	return Attachment{
+		Filename:    "invite.ics",
+		ContentType: "text/calendar; charset=UTF-8; method=PUBLISH",
+		Data:        ics.Invite(e),
+	}, nil
+}
+
+// eventURL returns the event's share page, which opens the event in the app
+func (s *Service) eventURL(eventID uuid.UUID) string {
+	return s.publicBaseURL + "/share/events/" + eventID.String()
+}
+
+// registrationLinks returns the links in a registration confirmation: the
+// event in the app, where attendees cancel or transfer their registration
+func (s *Service) registrationLinks(eventID uuid.UUID) []EmailLink {
+	if s.publicBaseURL == "" {
+		return nil
+	}
+	return []EmailLink{{Label: "Cancel or transfer your registration", URL: s.eventURL(eventID)}}
+}
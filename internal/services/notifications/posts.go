@@ -0,0 +1,153 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+)
+
+// TopicPostPublished is written when a post goes out, when it is created or
+// when its scheduled time comes, to notify its club's and house's followers
+// and, for a targeted post, its audience
+const TopicPostPublished = "post.published"
+
+// PostPublishedPayload names the post published
+type PostPublishedPayload struct {
+	PostID uuid.UUID `json:"post_id"`
+}
+
+// audienceMatch returns a SQL condition keeping users, u, in the audience
+// bound to three parameters from $first: department, year and house
+func audienceMatch(first int) string {
+	return fmt.Sprintf(`($%[1]d::text IS NULL OR u.department = $%[1]d)
+	AND ($%[2]d::int IS NULL OR u.year = $%[2]d)
+	AND ($%[3]d::uuid IS NULL OR EXISTS (SELECT 1 FROM house_members hm WHERE hm.user_id = u.id AND hm.house_id = $%[3]d))`,
+		first, first+1, first+2)
+}
+
+// handlePostPublished notifies the followers of a post's club and house
+// who are in its audience, then the rest of its audience, if it has one.
+// Each user is told once.
+func (s *Service) handlePostPublished(ctx context.Context, event outbox.Event) error {
+	var p PostPublishedPayload
+	if err := json.Unmarshal(event.Payload, &p); err != nil {
+		return err
+	}
+
+	var post models.Post
+	err := s.db.QueryRowContext(ctx, `
+		SELECT created_by, club_id, house_id, description, audience_department, audience_year, audience_house_id
+		FROM posts
+		WHERE id = $1 AND deleted_at IS NULL AND status = 'published' AND NOT held_for_review
+	`, p.PostID).Scan(&post.CreatedBy, &post.ClubID, &post.HouseID, &post.Description,
+		&post.Audience.Department, &post.Audience.Year, &post.Audience.HouseID)
+	if err == sql.ErrNoRows {
+		// Deleted, unpublished or held since; approving a held post queues
+		// this again
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	audience := []interface{}{post.Audience.Department, post.Audience.Year, post.Audience.HouseID}
+	dedupeKey := func(userID uuid.UUID) string { return "post:" + p.PostID.String() + ":" + userID.String() }
+
+	targets := []struct {
+		kind     string
+		id       *uuid.UUID
+		category string
+	}{
+		{models.FollowTargetClub, post.ClubID, CategoryClubAnnouncements},
+		{models.FollowTargetHouse, post.HouseID, CategoryHouseUpdates},
+	}
+	for _, t := range targets {
+		if t.id == nil {
+			continue
+		}
+		var name string
+		err := s.db.QueryRowContext(ctx, followTargetNames[t.kind], *t.id).Scan(&name)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		followers, err := s.userIDs(ctx, `
+			SELECT f.user_id FROM user_follows f
+			JOIN users u ON u.id = f.user_id AND u.deleted_at IS NULL
+			WHERE f.target_type = $1 AND f.target_id = $2 AND f.user_id IS DISTINCT FROM $3
+			  AND `+audienceMatch(4),
+			append([]interface{}{t.kind, *t.id, post.CreatedBy}, audience...)...)
+		if err != nil {
+			return err
+		}
+		data := map[string]string{t.kind + "_id": t.id.String(), "post_id": p.PostID.String()}
+		for _, userID := range followers {
+			err := s.Send(ctx, Message{
+				UserID:    userID,
+				Category:  t.category,
+				Title:     name,
+				Body:      "Shared a new post",
+				Data:      data,
+				DedupeKey: dedupeKey(userID),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if post.Audience == (models.PostAudience{}) {
+		return nil
+	}
+	members, err := s.userIDs(ctx, `
+		SELECT u.id FROM users u
+		WHERE u.deleted_at IS NULL AND u.id <> $1
+		  AND `+audienceMatch(2),
+		append([]interface{}{post.CreatedBy}, audience...)...)
+	if err != nil {
+		return err
+	}
+	body := []rune(post.Description)
+	if len(body) > 100 {
+		body = append(body[:99], '…')
+	}
+	for _, userID := range members {
+		err := s.Send(ctx, Message{
+			UserID:    userID,
+			Category:  CategoryAnnouncements,
+			Title:     "New post for you",
+			Body:      string(body),
+			Data:      map[string]string{"post_id": p.PostID.String()},
+			DedupeKey: dedupeKey(userID),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// userIDs runs a query selecting user IDs
+func (s *Service) userIDs(ctx context.Context, query string, args ...interface{}) ([]uuid.UUID, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,230 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/settings"
+)
+
+// QuietHours is a daily window, in campus (server local) time, during which
+// non-urgent push notifications are held back and later delivered as a
+// single summary. The window may wrap midnight; an empty window is off.
+type QuietHours struct {
+	Start int // Minutes after midnight
+	End   int
+}
+
+// ParseQuietHours parses a "HH:MM" start and end. Both empty means no quiet hours.
+func ParseQuietHours(start, end string) (QuietHours, error) {
+	if start == "" && end == "" {
+		return QuietHours{}, nil
+	}
+	s, err := parseClock(start)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("invalid quiet hours start %q: %w", start, err)
+	}
+	e, err := parseClock(end)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("invalid quiet hours end %q: %w", end, err)
+	}
+	return QuietHours{Start: s, End: e}, nil
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Enabled reports whether the window is non-empty
+func (q QuietHours) Enabled() bool {
+	return q.Start != q.End
+}
+
+// Contains reports whether t falls inside the window
+func (q QuietHours) Contains(t time.Time) bool {
+	if !q.Enabled() {
+		return false
+	}
+	t = t.In(time.Local)
+	m := t.Hour()*60 + t.Minute()
+	if q.Start < q.End {
+		return m >= q.Start && m < q.End
+	}
+	return m >= q.Start || m < q.End
+}
+
+// StartClock returns the start as "HH:MM"
+func (q QuietHours) StartClock() string {
+	return fmt.Sprintf("%02d:%02d", q.Start/60, q.Start%60)
+}
+
+// EndClock returns the end as "HH:MM"
+func (q QuietHours) EndClock() string {
+	return fmt.Sprintf("%02d:%02d", q.End/60, q.End%60)
+}
+
+// SetDefaultQuietHours sets the campus-wide quiet hours used for users who
+// have not chosen their own
+func (s *Service) SetDefaultQuietHours(q QuietHours) {
+	s.quietHours = q
+}
+
+// SetSettings makes the campus-wide quiet hours follow the runtime
+// settings, so admins can change them without a restart
+func (s *Service) SetSettings(store *settings.Store) {
+	s.settings = store
+}
+
+// defaultQuietHours returns the campus-wide quiet hours in force
+func (s *Service) defaultQuietHours(ctx context.Context) QuietHours {
+	if s.settings == nil {
+		return s.quietHours
+	}
+	current := s.settings.Current(ctx)
+	// The settings are validated, so this only fails on a bad default
+	q, err := ParseQuietHours(current.QuietHoursStart, current.QuietHoursEnd)
+	if err != nil {
+		return s.quietHours
+	}
+	return q
+}
+
+// userQuietHours returns the quiet hours that apply to a user
+func (s *Service) userQuietHours(ctx context.Context, userID uuid.UUID) (QuietHours, error) {
+	var enabled bool
+	var start, end *string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT enabled, to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI')
+		FROM notification_quiet_hours
+		WHERE user_id = $1
+	`, userID).Scan(&enabled, &start, &end)
+	if err == sql.ErrNoRows {
+		return s.defaultQuietHours(ctx), nil
+	}
+	if err != nil {
+		return QuietHours{}, err
+	}
+	if !enabled {
+		return QuietHours{}, nil
+	}
+	if start == nil || end == nil {
+		return s.defaultQuietHours(ctx), nil
+	}
+	return ParseQuietHours(*start, *end)
+}
+
+// deferPush holds a push notification back until the user's quiet hours end
+func (s *Service) deferPush(ctx context.Context, msg Message) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO deferred_pushes (user_id, title, body)
+		VALUES ($1, $2, $3)
+	`, msg.UserID, msg.Title, msg.Body)
+	return err
+}
+
+// DeliverDeferred sends each user whose quiet hours have ended a single push
+// summarising the notifications held back for them. Users still in quiet
+// hours are left for a later run.
+func (s *Service) DeliverDeferred(ctx context.Context) (delivered int, err error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT DISTINCT user_id FROM deferred_pushes")
+	if err != nil {
+		return 0, err
+	}
+	var users []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		users = append(users, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	for _, userID := range users {
+		quiet, err := s.userQuietHours(ctx, userID)
+		if err != nil {
+			return delivered, err
+		}
+		if quiet.Contains(now) {
+			continue
+		}
+		if err := s.deliverDeferredTo(ctx, userID); err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+	return delivered, nil
+}
+
+// deliverDeferredTo claims a user's held pushes and sends them as one. The
+// rows are only removed if the push succeeds.
+func (s *Service) deliverDeferredTo(ctx context.Context, userID uuid.UUID) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		DELETE FROM deferred_pushes WHERE user_id = $1
+		RETURNING title, body, created_at
+	`, userID)
+	if err != nil {
+		return err
+	}
+	type held struct {
+		title, body string
+		at          time.Time
+	}
+	var pushes []held
+	for rows.Next() {
+		var h held
+		if err := rows.Scan(&h.title, &h.body, &h.at); err != nil {
+			rows.Close()
+			return err
+		}
+		pushes = append(pushes, h)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(pushes) == 0 {
+		return nil
+	}
+
+	title, body := pushes[0].title, pushes[0].body
+	if len(pushes) > 1 {
+		titles := make([]string, 0, 3)
+		for i, h := range pushes {
+			if i == 3 {
+				break
+			}
+			titles = append(titles, h.title)
+		}
+		title = fmt.Sprintf("%d new notifications", len(pushes))
+		body = strings.Join(titles, ", ")
+		if len(pushes) > 3 {
+			body += fmt.Sprintf(" and %d more", len(pushes)-3)
+		}
+	}
+
+	data := map[string]string{"type": "quiet_hours_summary", "count": fmt.Sprint(len(pushes))}
+	if err := s.push.SendPush(ctx, userID, title, body, data); err != nil {
+		return fmt.Errorf("push delivery failed: %w", err)
+	}
+	return tx.Commit()
+}
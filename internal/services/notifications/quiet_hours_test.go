@@ -0,0 +1,62 @@
+package notifications
+
+import (
+	"testing"
+	"time"
+)
+
+// TestQuietHoursContains verifies windows within a day and across midnight
+func TestQuietHoursContains(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2025, 3, 3, h, m, 0, 0, time.Local) }
+
+	overnight, err := ParseQuietHours("23:00", "07:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	afternoon, err := ParseQuietHours("13:30", "15:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		quiet QuietHours
+		at    time.Time
+		want  bool
+	}{
+		{"overnight before start", overnight, at(22, 59), false},
+		{"overnight at start", overnight, at(23, 0), true},
+		{"overnight after midnight", overnight, at(3, 15), true},
+		{"overnight at end", overnight, at(7, 0), false},
+		{"afternoon inside", afternoon, at(14, 0), true},
+		{"afternoon before", afternoon, at(13, 29), false},
+		{"afternoon at end", afternoon, at(15, 0), false},
+		{"disabled", QuietHours{}, at(0, 0), false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.quiet.Contains(tt.at); got != tt.want {
+			t.Errorf("%s: Contains(%s) = %v, want %v", tt.name, tt.at.Format("15:04"), got, tt.want)
+		}
+	}
+}
+
+// TestParseQuietHours verifies the HH:MM format and the disabled case
+func TestParseQuietHours(t *testing.T) {
+	q, err := ParseQuietHours("", "")
+	if err != nil || q.Enabled() {
+		t.Errorf(`ParseQuietHours("", "") = %+v, %v; want disabled`, q, err)
+	}
+	q, err = ParseQuietHours("22:30", "06:45")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.StartClock() != "22:30" || q.EndClock() != "06:45" {
+		t.Errorf("clocks = %s-%s, want 22:30-06:45", q.StartClock(), q.EndClock())
+	}
+	for _, bad := range [][2]string{{"23:00", ""}, {"25:00", "07:00"}, {"11pm", "7am"}} {
+		if _, err := ParseQuietHours(bad[0], bad[1]); err == nil {
+			t.Errorf("ParseQuietHours(%q, %q) succeeded, want error", bad[0], bad[1])
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+
+	"github.com/google/uuid"
+)
+
+// EmailSender delivers HTML email
+type EmailSender interface {
+	SendEmail(ctx context.Context, to, subject, htmlBody string, attachments ...Attachment) error
+}
+
+// Attachment is a file attached to an email
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// PushSender delivers a push notification to all of a user's devices
+type PushSender interface {
+	SendPush(ctx context.Context, userID uuid.UUID, title, body string, data map[string]string) error
+}
+
+// SMTPEmailSender sends email through a plain SMTP relay
+type SMTPEmailSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPEmailSender creates an SMTP sender. Auth is skipped when no username is set.
+func NewSMTPEmailSender(host, port, username, password, from string) *SMTPEmailSender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPEmailSender{
+		addr: host + ":" + port,
+		auth: auth,
+		from: from,
+	}
+}
+
+// SendEmail sends a single HTML email
+func (s *SMTPEmailSender) SendEmail(ctx context.Context, to, subject, htmlBody string, attachments ...Attachment) error {
+	var msg bytes.Buffer
+	msg.WriteString("From: " + s.from + "\r\n")
+	msg.WriteString("To: " + to + "\r\n")
+	msg.WriteString("Subject: " + subject + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	if len(attachments) == 0 {
+		msg.WriteString("Content-Type: text/html; charset=\"UTF-8\"\r\n\r\n")
+		msg.WriteString(htmlBody)
+	} else if err := writeMultipart(&msg, htmlBody, attachments); err != nil {
+		return err
+	}
+
+	if err := smtp.SendMail(s.addr, s.auth, s.from, []string{to}, msg.Bytes()); err != nil {
+		return fmt.Errorf("smtp send to %s failed: %w", to, err)
+	}
+	return nil
+}
+
+// writeMultipart writes a multipart/mixed body: the HTML followed by the
+// attachments, base64-encoded
+func writeMultipart(msg *bytes.Buffer, htmlBody string, attachments []Attachment) error {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"text/html; charset=\"UTF-8\""},
+	})
+	if err != nil {
+		return err
+	}
+	part.Write([]byte(htmlBody))
+
+	for _, a := range attachments {
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {a.ContentType + "; name=\"" + a.Filename + "\""},
+			"Content-Disposition":       {"attachment; filename=\"" + a.Filename + "\""},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return err
+		}
+		// Base64 lines are limited to 76 characters
+		encoded := base64.StdEncoding.EncodeToString(a.Data)
+		for len(encoded) > 76 {
+			part.Write([]byte(encoded[:76] + "\r\n"))
+			encoded = encoded[76:]
+		}
+		part.Write([]byte(encoded + "\r\n"))
+	}
+	if err := mw.Close(); err != nil {
+		return err
+	}
+
+	msg.WriteString("Content-Type: multipart/mixed; boundary=\"" + mw.Boundary() + "\"\r\n\r\n")
+	msg.Write(body.Bytes())
+	return nil
+}
+
+// LogEmailSender logs emails instead of sending them (development)
+type LogEmailSender struct{}
+
+// SendEmail logs the email
+func (LogEmailSender) SendEmail(ctx context.Context, to, subject, htmlBody string, attachments ...Attachment) error {
+	log.Printf("[EMAIL] To: %s | Subject: %s | Attachments: %d", to, subject, len(attachments))
+	return nil
+}
+
+// LogPushSender logs push notifications instead of sending them (development)
+type LogPushSender struct{}
+
+// SendPush logs the push notification
+func (LogPushSender) SendPush(ctx context.Context, userID uuid.UUID, title, body string, data map[string]string) error {
+	log.Printf("[PUSH] User: %s | %s: %s", userID, title, body)
+	return nil
+}
@@ -0,0 +1,56 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"testing"
+)
+
+func TestWriteMultipart(t *testing.T) {
+	var msg bytes.Buffer
+	msg.WriteString("Subject: Payment received\r\n")
+	invite := bytes.Repeat([]byte("BEGIN:VCALENDAR\r\n"), 10)
+	err := writeMultipart(&msg, "<p>You're registered!</p>", []Attachment{
+		{Filename: "invite.ics", ContentType: "text/calendar", Data: invite},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := mail.ReadMessage(&msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mediaType, params, err := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("Content-Type = %q", m.Header.Get("Content-Type"))
+	}
+	r := multipart.NewReader(m.Body, params["boundary"])
+
+	part, err := r.NextPart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body, _ := io.ReadAll(part); string(body) != "<p>You're registered!</p>" {
+		t.Errorf("HTML part = %q", body)
+	}
+
+	part, err = r.NextPart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if part.FileName() != "invite.ics" {
+		t.Errorf("attachment filename = %q", part.FileName())
+	}
+	data, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, part))
+	if err != nil || !bytes.Equal(data, invite) {
+		t.Errorf("attachment = %q, %v", data, err)
+	}
+	if _, err := r.NextPart(); err != io.EOF {
+		t.Errorf("got a third part: %v", err)
+	}
+}
@@ -0,0 +1,192 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/settings"
+)
+
+// Notification categories, used for inbox grouping and user preferences
+const (
+	CategoryEvents            = "events"
+	CategoryClubAnnouncements = "club_announcements"
+	CategoryChat              = "chat"
+	CategoryHouseUpdates      = "house_updates"
+	CategoryPaymentReceipts   = "payment_receipts"
+	CategoryAnnouncements     = "announcements" // Campus-wide, from admins
+)
+
+// Categories lists every category, in the order shown in user preferences
+var Categories = []string{
+	CategoryEvents,
+	CategoryClubAnnouncements,
+	CategoryChat,
+	CategoryHouseUpdates,
+	CategoryPaymentReceipts,
+	CategoryAnnouncements,
+}
+
+// IsCategory reports whether category is a known notification category
+func IsCategory(category string) bool {
+	for _, c := range Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Message is a notification addressed to a single user
+type Message struct {
+	UserID   uuid.UUID
+	Category string
+	Title    string
+	Body     string
+	Data     map[string]string
+	// SendEmail also delivers the message to the user's email address
+	SendEmail bool
+	// EmailLinks are shown below the body in the email, in order
+	EmailLinks []EmailLink
+	// EmailAttachments are attached to the email
+	EmailAttachments []Attachment
+	// DedupeKey makes repeated delivery of the same message a no-op
+	DedupeKey string
+	// Urgent messages are pushed even during the user's quiet hours
+	Urgent bool
+}
+
+// EmailLink is a link shown in a message's email
+type EmailLink struct {
+	Label string
+	URL   string
+}
+
+// Service records in-app notifications and fans them out to push and email
+type Service struct {
+	db         *sql.DB
+	email      EmailSender
+	push       PushSender
+	quietHours QuietHours // Campus-wide default
+	settings   *settings.Store
+	// capacityThresholds are percentages of capacity announced to event
+	// creators, ascending
+	capacityThresholds []int
+	// publicBaseURL is the public URL of the API, which serves the links in
+	// emails
+	publicBaseURL string
+}
+
+// NewService creates a new notification service
+func NewService(db *sql.DB, email EmailSender, push PushSender) *Service {
+	return &Service{
+		db:    db,
+		email: email,
+		push:  push,
+	}
+}
+
+// SetPublicBaseURL sets the public URL of the API, used for links in emails
+func (s *Service) SetPublicBaseURL(url string) {
+	s.publicBaseURL = strings.TrimRight(url, "/")
+}
+
+// Send delivers a message. Push and email are skipped for channels the user
+// has turned off for the message's category, and non-urgent pushes are held
+// back during quiet hours; the inbox row is always written.
+// External channels are attempted before the inbox row is written, so a
+// failure is retried in full by the caller; a message whose DedupeKey is
+// already in the inbox is skipped entirely.
+func (s *Service) Send(ctx context.Context, msg Message) error {
+	var dedupeKey *string
+	if msg.DedupeKey != "" {
+		dedupeKey = &msg.DedupeKey
+
+		var exists bool
+		err := s.db.QueryRowContext(ctx,
+			"SELECT EXISTS(SELECT 1 FROM notifications WHERE dedupe_key = $1)", msg.DedupeKey,
+		).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return nil
+		}
+	}
+
+	pushEnabled, emailEnabled, err := s.channelsEnabled(ctx, msg.UserID, msg.Category)
+	if err != nil {
+		return err
+	}
+
+	if pushEnabled {
+		quiet := false
+		if !msg.Urgent {
+			q, err := s.userQuietHours(ctx, msg.UserID)
+			if err != nil {
+				return err
+			}
+			quiet = q.Contains(time.Now())
+		}
+
+		if quiet {
+			if err := s.deferPush(ctx, msg); err != nil {
+				return err
+			}
+		} else if err := s.push.SendPush(ctx, msg.UserID, msg.Title, msg.Body, msg.Data); err != nil {
+			return fmt.Errorf("push delivery failed: %w", err)
+		}
+	}
+
+	if msg.SendEmail && emailEnabled {
+		var email string
+		err := s.db.QueryRowContext(ctx,
+			"SELECT email FROM users WHERE id = $1 AND deleted_at IS NULL", msg.UserID,
+		).Scan(&email)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if email != "" {
+			body := "<p>" + html.EscapeString(msg.Body) + "</p>"
+			for _, l := range msg.EmailLinks {
+				body += `<p><a href="` + html.EscapeString(l.URL) + `">` + html.EscapeString(l.Label) + "</a></p>"
+			}
+			if err := s.email.SendEmail(ctx, email, msg.Title, body, msg.EmailAttachments...); err != nil {
+				return fmt.Errorf("email delivery failed: %w", err)
+			}
+		}
+	}
+
+	var data []byte
+	if msg.Data != nil {
+		if data, err = json.Marshal(msg.Data); err != nil {
+			return err
+		}
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO notifications (user_id, category, title, body, data, dedupe_key)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (dedupe_key) DO NOTHING
+	`, msg.UserID, msg.Category, msg.Title, msg.Body, data, dedupeKey)
+	return err
+}
+
+// channelsEnabled returns the user's push and email settings for a category.
+// Both are on unless the user has turned them off.
+func (s *Service) channelsEnabled(ctx context.Context, userID uuid.UUID, category string) (push, email bool, err error) {
+	err = s.db.QueryRowContext(ctx, `
+		SELECT push_enabled, email_enabled FROM notification_preferences
+		WHERE user_id = $1 AND category = $2
+	`, userID, category).Scan(&push, &email)
+	if err == sql.ErrNoRows {
+		return true, true, nil
+	}
+	return push, email, err
+}
@@ -0,0 +1,169 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+)
+
+// Outbox topics whose side effects are notifications
+const (
+	TopicPaymentCaptured         = "payment.captured"
+	TopicClubAnnouncementCreated = "club_announcement.created"
+	TopicEventUpdatePosted       = "event_update.posted"
+)
+
+// PaymentCapturedPayload is written to the outbox when a payment is verified
+type PaymentCapturedPayload struct {
+	PaymentID  uuid.UUID `json:"payment_id"`
+	EventID    uuid.UUID `json:"event_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	EventTitle string    `json:"event_title"`
+	Amount     float64   `json:"amount"`
+	Currency   string    `json:"currency"`
+}
+
+// ClubAnnouncementCreatedPayload is written to the outbox when a club posts an announcement
+type ClubAnnouncementCreatedPayload struct {
+	AnnouncementID uuid.UUID `json:"announcement_id"`
+	ClubID         uuid.UUID `json:"club_id"`
+	Title          string    `json:"title"`
+}
+
+// EventUpdatePostedPayload is written to the outbox when organizers post a live update
+type EventUpdatePostedPayload struct {
+	UpdateID   uuid.UUID `json:"update_id"`
+	EventID    uuid.UUID `json:"event_id"`
+	EventTitle string    `json:"event_title"`
+	Body       string    `json:"body"`
+}
+
+// RegisterOutboxHandlers wires notification delivery into the outbox relay
+func (s *Service) RegisterOutboxHandlers(relay *outbox.Relay) {
+	relay.Register(TopicPaymentCaptured, s.handlePaymentCaptured)
+	relay.Register(TopicClubAnnouncementCreated, s.handleClubAnnouncementCreated)
+	relay.Register(TopicEventUpdatePosted, s.handleEventUpdatePosted)
+	relay.Register(TopicEventRegistrationsAdded, s.handleEventRegistrationsAdded)
+	relay.Register(TopicEventBroadcastCreated, s.handleEventBroadcastCreated)
+	relay.Register(TopicFollowedContentPublished, s.handleFollowedContentPublished)
+	relay.Register(TopicEventCancelled, s.handleEventCancelled)
+	relay.Register(TopicAdminBroadcastCreated, s.handleAdminBroadcastCreated)
+	relay.Register(TopicPostPublished, s.handlePostPublished)
+	relay.Register(TopicEventStatusChanged, s.handleEventStatusChanged)
+}
+
+func (s *Service) handlePaymentCaptured(ctx context.Context, event outbox.Event) error {
+	var p PaymentCapturedPayload
+	if err := json.Unmarshal(event.Payload, &p); err != nil {
+		return err
+	}
+
+	// The receipt confirms the registration, so it carries a calendar invite
+	invite, err := s.eventInvite(ctx, p.EventID)
+	if err != nil {
+		return err
+	}
+
+	return s.Send(ctx, Message{
+		UserID:           p.UserID,
+		Category:         CategoryPaymentReceipts,
+		Title:            "Payment received",
+		Body:             fmt.Sprintf("Your payment of %.2f %s for %s was successful. You're registered!", p.Amount, p.Currency, p.EventTitle),
+		Data:             map[string]string{"event_id": p.EventID.String(), "payment_id": p.PaymentID.String()},
+		SendEmail:        true,
+		EmailLinks:       s.registrationLinks(p.EventID),
+		EmailAttachments: []Attachment{invite},
+		DedupeKey:        event.ID.String(),
+	})
+}
+
+func (s *Service) handleClubAnnouncementCreated(ctx context.Context, event outbox.Event) error {
+	var p ClubAnnouncementCreatedPayload
+	if err := json.Unmarshal(event.Payload, &p); err != nil {
+		return err
+	}
+
+	var clubName string
+	if err := s.db.QueryRowContext(ctx, "SELECT name FROM clubs WHERE id = $1", p.ClubID).Scan(&clubName); err != nil {
+		return err
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT user_id FROM club_members WHERE club_id = $1", p.ClubID)
+	if err != nil {
+		return err
+	}
+	var members []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		members = append(members, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Per-recipient dedupe keys let a retried fan-out skip members already notified
+	for _, userID := range members {
+		err := s.Send(ctx, Message{
+			UserID:    userID,
+			Category:  CategoryClubAnnouncements,
+			Title:     clubName,
+			Body:      p.Title,
+			Data:      map[string]string{"club_id": p.ClubID.String(), "announcement_id": p.AnnouncementID.String()},
+			DedupeKey: event.ID.String() + ":" + userID.String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) handleEventUpdatePosted(ctx context.Context, event outbox.Event) error {
+	var p EventUpdatePostedPayload
+	if err := json.Unmarshal(event.Payload, &p); err != nil {
+		return err
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT user_id FROM event_registrations WHERE event_id = $1", p.EventID)
+	if err != nil {
+		return err
+	}
+	var registered []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		registered = append(registered, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, userID := range registered {
+		err := s.Send(ctx, Message{
+			UserID:    userID,
+			Category:  CategoryEvents,
+			Title:     p.EventTitle,
+			Body:      p.Body,
+			Data:      map[string]string{"event_id": p.EventID.String(), "update_id": p.UpdateID.String()},
+			DedupeKey: event.ID.String() + ":" + userID.String(),
+			// Live updates (venue moved, start delayed) are time-sensitive
+			Urgent: true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package payments
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// razorpayAPI is the base URL of the Razorpay API
+const razorpayAPI = "https://api.razorpay.com/v1"
+
+// Client calls the Razorpay API
+type Client struct {
+	http      *http.Client
+	baseURL   string
+	keyID     string
+	keySecret string
+}
+
+// NewClient creates a client using the Razorpay keys in the environment, as
+// payments are taken with
+func NewClient() *Client {
+	return &Client{
+		http:      &http.Client{Timeout: 30 * time.Second},
+		baseURL:   razorpayAPI,
+		keyID:     os.Getenv("RAZORPAY_KEY_ID"),
+		keySecret: os.Getenv("RAZORPAY_KEY_SECRET"),
+	}
+}
+
+// call makes a Razorpay API request and decodes its response into out
+func (c *Client) call(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, &reqBody)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.keyID, c.keySecret)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("razorpay API returned status %d for %s %s", resp.StatusCode, method, path)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
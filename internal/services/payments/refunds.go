@@ -0,0 +1,162 @@
+// Package payments works with Razorpay after checkout: refunds queued in
+// the outbox and settlement reports. Payments made from a wallet are
+// refunded to it.
+package payments
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/models"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+	"github.com/yourusername/college-event-backend/internal/services/wallet"
+)
+
+// TopicRefundRequested is written when a paid registration is to be
+// refunded, with the payment already marked refund_pending
+const TopicRefundRequested = "payment.refund_requested"
+
+// RefundRequestedPayload names the payment to refund
+type RefundRequestedPayload struct {
+	PaymentID uuid.UUID `json:"payment_id"`
+}
+
+// Refunder refunds payments through Razorpay, or to the wallet they were
+// paid from
+type Refunder struct {
+	db     *sql.DB
+	client *Client
+}
+
+// NewRefunder creates a refunder
+func NewRefunder(db *sql.DB, client *Client) *Refunder {
+	return &Refunder{db: db, client: client}
+}
+
+// RegisterOutboxHandlers wires refunds into the outbox relay
+func (r *Refunder) RegisterOutboxHandlers(relay *outbox.Relay) {
+	relay.Register(TopicRefundRequested, r.handleRefundRequested)
+}
+
+func (r *Refunder) handleRefundRequested(ctx context.Context, event outbox.Event) error {
+	var p RefundRequestedPayload
+	if err := json.Unmarshal(event.Payload, &p); err != nil {
+		return err
+	}
+
+	var status, method string
+	var razorpayPaymentID *string
+	var amount float64
+	err := r.db.QueryRowContext(ctx, `
+		SELECT status, method, razorpay_payment_id, amount FROM event_payments WHERE id = $1
+	`, p.PaymentID).Scan(&status, &method, &razorpayPaymentID, &amount)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && status != "refund_pending") {
+		// Deleted with its event, or refunded by an earlier delivery
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if method == models.PaymentMethodWallet {
+		return r.refundToWallet(ctx, p.PaymentID)
+	}
+	if razorpayPaymentID == nil {
+		return fmt.Errorf("payment %s has no Razorpay payment to refund", p.PaymentID)
+	}
+
+	// Delivery is at-least-once: a refund made by an earlier delivery that
+	// failed to record it is found rather than made again
+	refundID, err := r.existingRefund(ctx, *razorpayPaymentID)
+	if err != nil {
+		return err
+	}
+	if refundID == "" {
+		if refundID, err = r.refund(ctx, *razorpayPaymentID, int(math.Round(amount*100))); err != nil {
+			return err
+		}
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		UPDATE event_payments
+		SET status = 'refunded', razorpay_refund_id = $1, refunded_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`, refundID, p.PaymentID)
+	return err
+}
+
+// refundToWallet pays a wallet payment back into the wallet
+func (r *Refunder) refundToWallet(ctx context.Context, paymentID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Locked so that a concurrent delivery can't credit it twice
+	var userID uuid.UUID
+	var amount float64
+	var title string
+	err = tx.QueryRowContext(ctx, `
+		UPDATE event_payments p
+		SET status = 'refunded', refunded_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		FROM events e
+		WHERE p.id = $1 AND p.status = 'refund_pending' AND e.id = p.event_id
+		RETURNING p.user_id, p.amount, e.title
+	`, paymentID).Scan(&userID, &amount, &title)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := wallet.Credit(ctx, tx, wallet.Entry{
+		UserID:      userID,
+		Amount:      amount,
+		Kind:        wallet.KindRefund,
+		Description: "Refund: " + title,
+		PaymentID:   &paymentID,
+	}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// existingRefund returns the ID of a refund already made for a Razorpay
+// payment, or "" if there is none
+func (r *Refunder) existingRefund(ctx context.Context, paymentID string) (string, error) {
+	var result struct {
+		Items []struct {
+			ID string `json:"id"`
+		} `json:"items"`
+	}
+	if err := r.client.call(ctx, http.MethodGet, "/payments/"+paymentID+"/refunds", nil, &result); err != nil {
+		return "", err
+	}
+	if len(result.Items) == 0 {
+		return "", nil
+	}
+	return result.Items[0].ID, nil
+}
+
+// refund refunds amount, in the currency's smallest unit, of a Razorpay
+// payment and returns the refund's ID
+func (r *Refunder) refund(ctx context.Context, paymentID string, amount int) (string, error) {
+	var result struct {
+		ID string `json:"id"`
+	}
+	body := map[string]interface{}{"amount": amount}
+	if err := r.client.call(ctx, http.MethodPost, "/payments/"+paymentID+"/refund", body, &result); err != nil {
+		return "", err
+	}
+	if result.ID == "" {
+		return "", fmt.Errorf("invalid refund response from Razorpay")
+	}
+	return result.ID, nil
+}
@@ -0,0 +1,55 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRefund(t *testing.T) {
+	refunded := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, _, _ := r.BasicAuth(); user != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /payments/pay_1/refunds":
+			if refunded {
+				w.Write([]byte(`{"count":1,"items":[{"id":"rfnd_1"}]}`))
+			} else {
+				w.Write([]byte(`{"count":0,"items":[]}`))
+			}
+		case "POST /payments/pay_1/refund":
+			var body struct{ Amount int }
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.Amount != 49900 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			refunded = true
+			w.Write([]byte(`{"id":"rfnd_1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r := &Refunder{client: &Client{http: srv.Client(), baseURL: srv.URL, keyID: "key"}}
+	ctx := context.Background()
+
+	if id, err := r.existingRefund(ctx, "pay_1"); err != nil || id != "" {
+		t.Fatalf("existingRefund before refunding = %q, %v", id, err)
+	}
+	if id, err := r.refund(ctx, "pay_1", 49900); err != nil || id != "rfnd_1" {
+		t.Fatalf("refund = %q, %v", id, err)
+	}
+	if id, err := r.existingRefund(ctx, "pay_1"); err != nil || id != "rfnd_1" {
+		t.Errorf("existingRefund after refunding = %q, %v", id, err)
+	}
+	if _, err := r.refund(ctx, "pay_2", 100); err == nil {
+		t.Error("refund of an unknown payment succeeded")
+	}
+}
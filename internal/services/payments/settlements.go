@@ -0,0 +1,46 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// settlementPageSize is the most entries Razorpay returns per request
+const settlementPageSize = 1000
+
+// SettlementEntry is a line of Razorpay's settlement reconciliation report.
+// Amounts are in the currency's smallest unit.
+type SettlementEntry struct {
+	EntityID      string `json:"entity_id"`
+	Type          string `json:"type"` // payment, refund, adjustment, ...
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	Fee           int64  `json:"fee"`
+	Tax           int64  `json:"tax"`
+	Settled       bool   `json:"settled"`
+	SettledAt     int64  `json:"settled_at"` // Unix time, 0 until settled
+	SettlementID  string `json:"settlement_id"`
+	SettlementUTR string `json:"settlement_utr"`
+	PaymentID     string `json:"payment_id"`
+}
+
+// SettlementRecon returns Razorpay's settlement reconciliation report for a
+// month: every payment, refund and adjustment in it and how it settled
+func (c *Client) SettlementRecon(ctx context.Context, year, month int) ([]SettlementEntry, error) {
+	var entries []SettlementEntry
+	for skip := 0; ; skip += settlementPageSize {
+		var page struct {
+			Items []SettlementEntry `json:"items"`
+		}
+		path := fmt.Sprintf("/settlements/recon/combined?year=%d&month=%d&count=%d&skip=%d",
+			year, month, settlementPageSize, skip)
+		if err := c.call(ctx, http.MethodGet, path, nil, &page); err != nil {
+			return nil, err
+		}
+		entries = append(entries, page.Items...)
+		if len(page.Items) < settlementPageSize {
+			return entries, nil
+		}
+	}
+}
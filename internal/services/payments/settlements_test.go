@@ -0,0 +1,45 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestSettlementRecon(t *testing.T) {
+	const total = settlementPageSize + 3
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/settlements/recon/combined" || q.Get("year") != "2026" || q.Get("month") != "9" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		skip, _ := strconv.Atoi(q.Get("skip"))
+		var page struct {
+			Items []SettlementEntry `json:"items"`
+		}
+		for i := skip; i < total && i < skip+settlementPageSize; i++ {
+			page.Items = append(page.Items, SettlementEntry{EntityID: "pay_" + strconv.Itoa(i), Type: "payment"})
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer srv.Close()
+
+	c := &Client{http: srv.Client(), baseURL: srv.URL, keyID: "key"}
+	entries, err := c.SettlementRecon(context.Background(), 2026, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != total {
+		t.Fatalf("got %d entries, want %d", len(entries), total)
+	}
+	if last := entries[total-1].EntityID; last != "pay_"+strconv.Itoa(total-1) {
+		t.Errorf("last entry = %s", last)
+	}
+	if _, err := c.SettlementRecon(context.Background(), 2026, 10); err == nil {
+		t.Error("SettlementRecon succeeded on an error response")
+	}
+}
@@ -0,0 +1,329 @@
+// Package security watches for suspicious activity with a few anomaly
+// rules: many failed logins to one account, a burst of sign-ups from one IP
+// address, and comment spam. A rule that trips raises an alert in the admin
+// security feed and, when automatic responses are on, locks the account's
+// password login, blocks sign-ups from the IP or mutes the commenter for a
+// while.
+package security
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/audit"
+	"github.com/yourusername/college-event-backend/internal/settings"
+)
+
+// Rules
+const (
+	RuleFailedLogins = "failed_logins"
+	RuleSignupBurst  = "signup_burst"
+	RuleCommentSpam  = "comment_spam"
+)
+
+// Responses to a tripped rule
+const (
+	ResponseNone    = "none"
+	ResponseLockout = "lockout"
+	ResponseMute    = "mute"
+)
+
+// Lockout kinds
+const (
+	LockoutLogin  = "login"  // An account's password login
+	LockoutSignup = "signup" // Sign-ups from an IP address
+)
+
+// Rules sets when each rule trips and how long its response lasts. A limit
+// of 0 disables the rule.
+type Rules struct {
+	// Failed password logins to one account within the window, since its
+	// last successful login
+	FailedLoginLimit  int
+	FailedLoginWindow time.Duration
+	LoginLockout      time.Duration
+
+	// Accounts created from one IP address within the window
+	SignupLimit   int
+	SignupWindow  time.Duration
+	SignupLockout time.Duration
+
+	// Comments by one user within the window
+	CommentLimit  int
+	CommentWindow time.Duration
+	CommentMute   time.Duration
+
+	// AutoRespond applies the lockouts and mutes; otherwise rules only
+	// raise alerts
+	AutoRespond bool
+}
+
+// DefaultRules returns the rules used unless configured otherwise
+func DefaultRules() Rules {
+	return Rules{
+		FailedLoginLimit:  5,
+		FailedLoginWindow: 15 * time.Minute,
+		LoginLockout:      15 * time.Minute,
+		SignupLimit:       5,
+		SignupWindow:      time.Hour,
+		SignupLockout:     time.Hour,
+		CommentLimit:      10,
+		CommentWindow:     5 * time.Minute,
+		CommentMute:       time.Hour,
+	}
+}
+
+// Detector applies the rules as activity happens
+type Detector struct {
+	db       *sql.DB
+	rules    Rules
+	settings *settings.Store
+}
+
+// NewDetector creates a detector
+func NewDetector(db *sql.DB, rules Rules) *Detector {
+	return &Detector{db: db, rules: rules}
+}
+
+// SetSettings makes the limits and automatic responses follow the runtime
+// settings, so admins can tune them without a restart
+func (d *Detector) SetSettings(store *settings.Store) {
+	d.settings = store
+}
+
+// currentRules returns the rules in force
+func (d *Detector) currentRules(ctx context.Context) Rules {
+	rules := d.rules
+	if d.settings != nil {
+		current := d.settings.Current(ctx)
+		rules.FailedLoginLimit = current.FailedLoginLimit
+		rules.SignupLimit = current.SignupLimit
+		rules.CommentLimit = current.CommentLimit
+		rules.AutoRespond = current.SecurityAutoRespond
+	}
+	return rules
+}
+
+// LoginLockedUntil returns when the account's password login lockout ends,
+// or nil if it isn't locked
+func (d *Detector) LoginLockedUntil(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
+	return d.lockedUntil(ctx, "kind = 'login' AND user_id = $1", userID)
+}
+
+// SignupBlockedUntil returns when sign-ups from the IP address are allowed
+// again, or nil if they are
+func (d *Detector) SignupBlockedUntil(ctx context.Context, ip string) (*time.Time, error) {
+	return d.lockedUntil(ctx, "kind = 'signup' AND ip_address = $1", ip)
+}
+
+func (d *Detector) lockedUntil(ctx context.Context, where string, arg interface{}) (*time.Time, error) {
+	var until time.Time
+	err := d.db.QueryRowContext(ctx, `
+		SELECT MAX(locked_until) FROM security_lockouts
+		WHERE `+where+` AND lifted_at IS NULL AND locked_until > NOW()
+		HAVING MAX(locked_until) IS NOT NULL
+	`, arg).Scan(&until)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &until, nil
+}
+
+// LoginFailed records a failed password login from ip, for the account
+// with email if there is one (userID)
+func (d *Detector) LoginFailed(ctx context.Context, userID *uuid.UUID, email, ip, requestID string) error {
+	rules := d.currentRules(ctx)
+	err := audit.Record(ctx, d.db, audit.Entry{
+		Action:        audit.ActionUserLoginFailed,
+		SubjectUserID: userID,
+		RequestID:     requestID,
+		IPAddress:     ip,
+		Details:       map[string]interface{}{"email": email},
+	})
+	if err != nil || userID == nil || rules.FailedLoginLimit <= 0 {
+		return err
+	}
+
+	var count int
+	err = d.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM audit_log
+		WHERE action = $1 AND subject_user_id = $2 AND created_at > NOW() - make_interval(secs => $3)
+		  AND created_at > COALESCE((
+			SELECT MAX(created_at) FROM audit_log WHERE action = $4 AND subject_user_id = $2
+		  ), '-infinity')
+	`, audit.ActionUserLoginFailed, *userID, rules.FailedLoginWindow.Seconds(), audit.ActionUserLogin).Scan(&count)
+	if err != nil || count < rules.FailedLoginLimit {
+		return err
+	}
+	return d.trip(ctx, trip{
+		rule:     RuleFailedLogins,
+		severity: "high",
+		userID:   userID,
+		ip:       ip,
+		count:    count,
+		window:   rules.FailedLoginWindow,
+		response: ResponseLockout,
+		lockout:  LockoutLogin,
+		duration: rules.LoginLockout,
+	})
+}
+
+// SignedUp records an account created from ip
+func (d *Detector) SignedUp(ctx context.Context, userID uuid.UUID, ip, requestID string) error {
+	rules := d.currentRules(ctx)
+	err := audit.Record(ctx, d.db, audit.Entry{
+		Action:        audit.ActionUserSignup,
+		ActorID:       &userID,
+		SubjectUserID: &userID,
+		RequestID:     requestID,
+		IPAddress:     ip,
+	})
+	if err != nil || ip == "" || rules.SignupLimit <= 0 {
+		return err
+	}
+
+	var count int
+	err = d.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM audit_log
+		WHERE action = $1 AND ip_address = $2 AND created_at > NOW() - make_interval(secs => $3)
+	`, audit.ActionUserSignup, ip, rules.SignupWindow.Seconds()).Scan(&count)
+	if err != nil || count < rules.SignupLimit {
+		return err
+	}
+	return d.trip(ctx, trip{
+		rule:     RuleSignupBurst,
+		severity: "medium",
+		ip:       ip,
+		count:    count,
+		window:   rules.SignupWindow,
+		response: ResponseLockout,
+		lockout:  LockoutSignup,
+		duration: rules.SignupLockout,
+	})
+}
+
+// Commented checks the user's commenting pace after a new comment
+func (d *Detector) Commented(ctx context.Context, userID uuid.UUID, ip string) error {
+	rules := d.currentRules(ctx)
+	if rules.CommentLimit <= 0 {
+		return nil
+	}
+	var count int
+	var isAdmin bool
+	err := d.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), EXISTS(SELECT 1 FROM users WHERE id = $1 AND role = 'admin')
+		FROM post_comments
+		WHERE user_id = $1 AND created_at > NOW() - make_interval(secs => $2)
+	`, userID, rules.CommentWindow.Seconds()).Scan(&count, &isAdmin)
+	if err != nil || count < rules.CommentLimit {
+		return err
+	}
+	t := trip{
+		rule:     RuleCommentSpam,
+		severity: "medium",
+		userID:   &userID,
+		ip:       ip,
+		count:    count,
+		window:   rules.CommentWindow,
+		response: ResponseMute,
+		duration: rules.CommentMute,
+	}
+	if isAdmin {
+		t.response = ResponseNone // Admins can't be muted
+	}
+	return d.trip(ctx, t)
+}
+
+// trip is a rule that tripped, and its response
+type trip struct {
+	rule     string
+	severity string
+	userID   *uuid.UUID
+	ip       string
+	count    int
+	window   time.Duration
+	response string
+	lockout  string // For a lockout response
+	duration time.Duration
+}
+
+// trip raises an alert, and applies the response if automatic responses are
+// on. A rule trips once per window for the same user or IP address, so a
+// burst raises one alert rather than one per event.
+func (d *Detector) trip(ctx context.Context, t trip) error {
+	rules := d.currentRules(ctx)
+	response := ResponseNone
+	var until *time.Time
+	if rules.AutoRespond && t.response != ResponseNone && t.duration > 0 {
+		response = t.response
+		u := time.Now().Add(t.duration)
+		until = &u
+	}
+	var ip *string
+	if t.ip != "" {
+		ip = &t.ip
+	}
+	// The IP address identifies the subject only when there's no user
+	subjectIP := ip
+	if t.userID != nil {
+		subjectIP = nil
+	}
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Serializes trips of a rule, so a burst can't raise two alerts
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", "security:"+t.rule); err != nil {
+		return err
+	}
+
+	var alertID uuid.UUID
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO security_alerts (rule, severity, user_id, ip_address, event_count, response, response_until)
+		SELECT $1, $2, $3, $4, $5, $6, $7
+		WHERE NOT EXISTS (
+			SELECT 1 FROM security_alerts
+			WHERE rule = $1 AND created_at > NOW() - make_interval(secs => $8)
+			  AND (user_id = $3 OR ($3::uuid IS NULL AND ip_address = $9))
+		)
+		RETURNING id
+	`, t.rule, t.severity, t.userID, ip, t.count, response, until, t.window.Seconds(), subjectIP).Scan(&alertID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil // Already alerted
+	}
+	if err != nil {
+		return fmt.Errorf("failed to raise %s alert: %w", t.rule, err)
+	}
+
+	switch response {
+	case ResponseLockout:
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO security_lockouts (kind, user_id, ip_address, locked_until, alert_id)
+			VALUES ($1, $2, $3, $4, $5)
+		`, t.lockout, t.userID, subjectIP, until, alertID)
+	case ResponseMute:
+		// Extends, never shortens, a mute set by an admin
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO user_mutes (user_id, muted_until, reason)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (user_id) DO UPDATE
+			SET muted_until = GREATEST(user_mutes.muted_until, EXCLUDED.muted_until),
+			    reason = CASE WHEN user_mutes.muted_until < EXCLUDED.muted_until
+			                  THEN EXCLUDED.reason ELSE user_mutes.reason END
+		`, *t.userID, until, fmt.Sprintf("Automatic: %d comments in %s", t.count, t.window))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply %s response: %w", t.rule, err)
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,126 @@
+package terms
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const dateLayout = "2006-01-02"
+
+// graduationDue reports whether students graduating in year have graduated
+// by today. A year with a term marked as ending its academic year graduates
+// once the last such term has ended; a year with none, once it is over.
+// markedEnds maps years to the end of the last marked term ending in them.
+func graduationDue(year int, markedEnds map[int]time.Time, today time.Time) bool {
+	if end, ok := markedEnds[year]; ok {
+		return end.Format(dateLayout) < today.Format(dateLayout)
+	}
+	return year < today.Year()
+}
+
+// Graduate makes alumni of the students whose graduation year is due (see
+// graduationDue). Graduates leave their houses and clubs and give up any
+// house roles. Returns how many graduated.
+func Graduate(ctx context.Context, db *sql.DB) (int, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	markedEnds := map[int]time.Time{}
+	rows, err := tx.QueryContext(ctx, `
+		SELECT EXTRACT(YEAR FROM ends_on)::int, MAX(ends_on)
+		FROM academic_terms
+		WHERE ends_academic_year
+		GROUP BY 1
+	`)
+	if err != nil {
+		return 0, err
+	}
+	for rows.Next() {
+		var year int
+		var end time.Time
+		if err := rows.Scan(&year, &end); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		markedEnds[year] = end
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	rows, err = tx.QueryContext(ctx, `
+		SELECT DISTINCT graduation_year FROM users
+		WHERE role = 'student' AND deleted_at IS NULL AND graduation_year IS NOT NULL
+	`)
+	if err != nil {
+		return 0, err
+	}
+	today := time.Now()
+	var due []int64
+	for rows.Next() {
+		var year int
+		if err := rows.Scan(&year); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if graduationDue(year, markedEnds, today) {
+			due = append(due, int64(year))
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(due) == 0 {
+		return 0, nil
+	}
+
+	rows, err = tx.QueryContext(ctx, `
+		UPDATE users SET role = 'alumni', updated_at = CURRENT_TIMESTAMP
+		WHERE role = 'student' AND deleted_at IS NULL AND graduation_year = ANY($1)
+		RETURNING id
+	`, pq.Array(due))
+	if err != nil {
+		return 0, err
+	}
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	for _, q := range []struct{ what, query string }{
+		{"house memberships", "DELETE FROM house_members WHERE user_id = ANY($1::uuid[])"},
+		{"house roles", "UPDATE house_roles SET user_id = NULL WHERE user_id = ANY($1::uuid[])"},
+		{"club memberships", "DELETE FROM club_members WHERE user_id = ANY($1::uuid[])"},
+	} {
+		if _, err := tx.ExecContext(ctx, q.query, pq.Array(ids)); err != nil {
+			return 0, fmt.Errorf("failed to clear %s: %w", q.what, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
@@ -0,0 +1,38 @@
+package terms
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGraduationDue(t *testing.T) {
+	date := func(s string) time.Time {
+		d, err := time.Parse(dateLayout, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	markedEnds := map[int]time.Time{2026: date("2026-05-31")}
+
+	tests := []struct {
+		name  string
+		year  int
+		today string
+		due   bool
+	}{
+		{"marked term not ended, though the year has turned", 2026, "2026-01-01", false},
+		{"marked term ends today", 2026, "2026-05-31", false},
+		{"marked term ended", 2026, "2026-06-01", true},
+		{"no marked term, year not over", 2027, "2027-06-01", false},
+		{"no marked term, year over", 2027, "2028-01-01", true},
+		{"no marked term, year long past", 2000, "2026-06-01", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := graduationDue(tt.year, markedEnds, date(tt.today)); got != tt.due {
+				t.Errorf("graduationDue(%d) on %s = %v, want %v", tt.year, tt.today, got, tt.due)
+			}
+		})
+	}
+}
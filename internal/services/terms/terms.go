@@ -0,0 +1,149 @@
+// Package terms manages academic terms. Records are tagged with their term
+// by database triggers as they are written; this package retags existing
+// records when terms are defined or moved, and archives terms once they end.
+package terms
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// StandingsUserLimit is how many users an archived term keeps on its final
+// leaderboard. Every house is kept.
+const StandingsUserLimit = 100
+
+// ErrArchived is returned when changing a term that has been archived
+var ErrArchived = errors.New("term is archived")
+
+// taggedTables lists each term-tagged table with the date that places a row
+// in a term. The triggers in migrations 028 and 053 must agree.
+var taggedTables = []struct {
+	table string
+	date  string
+}{
+	{"events", "start_date::date"},
+	{"club_awards", "COALESCE(awarded_date, created_at::date)"},
+	{"club_members", "joined_at::date"},
+	{"activity_points", "created_at::date"},
+	{"house_point_ledger", "created_at::date"},
+	{"house_competitions", "event_date"},
+}
+
+// Retag brings term tags up to date after term id was created or its dates
+// changed to startsOn..endsOn. Rows previously in the term and rows in its
+// new range are re-evaluated; nothing else can have moved.
+func Retag(ctx context.Context, tx database.Execer, termID uuid.UUID, startsOn, endsOn time.Time) error {
+	for _, t := range taggedTables {
+		_, err := tx.ExecContext(ctx, fmt.Sprintf(`
+			UPDATE %[1]s
+			SET term_id = academic_term_on(%[2]s)
+			WHERE (term_id = $1 OR %[2]s BETWEEN $2 AND $3)
+			  AND term_id IS DISTINCT FROM academic_term_on(%[2]s)
+		`, t.table, t.date), termID, startsOn, endsOn)
+		if err != nil {
+			return fmt.Errorf("failed to retag %s: %w", t.table, err)
+		}
+	}
+	return nil
+}
+
+// Archive snapshots a term's final house and user standings and marks it
+// archived. Archiving an archived term returns ErrArchived.
+func Archive(ctx context.Context, db *sql.DB, termID uuid.UUID) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var archivedAt *time.Time
+	err = tx.QueryRowContext(ctx,
+		"SELECT archived_at FROM academic_terms WHERE id = $1 FOR UPDATE", termID,
+	).Scan(&archivedAt)
+	if err != nil {
+		return err
+	}
+	if archivedAt != nil {
+		return ErrArchived
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO academic_term_standings (term_id, kind, subject_id, name, rank, points)
+		SELECT $1, 'house', h.id, h.name,
+		       RANK() OVER (ORDER BY COALESCE(SUM(l.points), 0) DESC), COALESCE(SUM(l.points), 0)
+		FROM houses h
+		LEFT JOIN house_point_ledger l ON l.house_id = h.id AND l.term_id = $1
+		WHERE h.deleted_at IS NULL
+		GROUP BY h.id, h.name
+	`, termID); err != nil {
+		return fmt.Errorf("failed to archive house standings: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO academic_term_standings (term_id, kind, subject_id, name, rank, points)
+		SELECT $1, 'user', u.id, u.full_name, s.rank, s.points
+		FROM (
+			SELECT user_id, SUM(points) AS points, RANK() OVER (ORDER BY SUM(points) DESC) AS rank
+			FROM activity_points
+			WHERE term_id = $1
+			GROUP BY user_id
+			ORDER BY points DESC
+			LIMIT $2
+		) s
+		JOIN users u ON u.id = s.user_id
+	`, termID, StandingsUserLimit); err != nil {
+		return fmt.Errorf("failed to archive user standings: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE academic_terms SET archived_at = NOW() WHERE id = $1", termID,
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ArchiveEnded archives every term that ended before today
+func ArchiveEnded(ctx context.Context, db *sql.DB) (int, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id FROM academic_terms
+		WHERE ends_on < CURRENT_DATE AND archived_at IS NULL
+		ORDER BY ends_on ASC
+	`)
+	if err != nil {
+		return 0, err
+	}
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	archived := 0
+	for _, id := range ids {
+		err := Archive(ctx, db, id)
+		if errors.Is(err, ErrArchived) {
+			// Another instance got there first
+			continue
+		}
+		if err != nil {
+			return archived, fmt.Errorf("term %s: %w", id, err)
+		}
+		archived++
+	}
+	return archived, nil
+}
@@ -0,0 +1,97 @@
+// Package wallet keeps users' campus credit balances. Credits and debits
+// are written in the caller's transaction, alongside what they pay for, and
+// each is recorded in the wallet's ledger.
+package wallet
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// Currency is the currency wallets hold
+const Currency = "INR"
+
+// Transaction kinds
+const (
+	KindCredit  = "credit"  // Granted by an admin
+	KindPayment = "payment" // Spent on an event
+	KindRefund  = "refund"  // A wallet payment given back
+)
+
+// ErrInsufficientFunds is returned by Debit when the balance doesn't cover
+// the amount
+var ErrInsufficientFunds = errors.New("insufficient wallet balance")
+
+// Entry is a single change to a wallet. Amount is always positive; Debit
+// takes it out.
+type Entry struct {
+	UserID      uuid.UUID
+	Amount      float64
+	Kind        string
+	Description string
+	PaymentID   *uuid.UUID // The event payment it paid for or refunded
+	CreatedBy   *uuid.UUID
+}
+
+// Credit adds an entry's amount to the user's wallet, opening it if need
+// be, and returns the new balance
+func Credit(ctx context.Context, tx database.Querier, e Entry) (float64, error) {
+	var balance float64
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO wallets (user_id, balance, currency)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET balance = wallets.balance + EXCLUDED.balance
+		RETURNING balance
+	`, e.UserID, e.Amount, Currency).Scan(&balance)
+	if err != nil {
+		return 0, fmt.Errorf("failed to credit wallet: %w", err)
+	}
+	return balance, record(ctx, tx, e, e.Amount, balance)
+}
+
+// Debit takes an entry's amount out of the user's wallet and returns the new
+// balance, or ErrInsufficientFunds if the balance is short
+func Debit(ctx context.Context, tx database.Querier, e Entry) (float64, error) {
+	var balance float64
+	err := tx.QueryRowContext(ctx, `
+		UPDATE wallets SET balance = balance - $2
+		WHERE user_id = $1 AND balance >= $2
+		RETURNING balance
+	`, e.UserID, e.Amount).Scan(&balance)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrInsufficientFunds
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to debit wallet: %w", err)
+	}
+	return balance, record(ctx, tx, e, -e.Amount, balance)
+}
+
+// Balance returns the user's balance; a user without a wallet has none
+func Balance(ctx context.Context, db database.Querier, userID uuid.UUID) (float64, error) {
+	var balance float64
+	err := db.QueryRowContext(ctx, "SELECT balance FROM wallets WHERE user_id = $1", userID).Scan(&balance)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return balance, err
+}
+
+// record writes a change of amount to the ledger
+func record(ctx context.Context, tx database.Querier, e Entry, amount, balance float64) error {
+	var id uuid.UUID
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO wallet_transactions (user_id, amount, balance_after, kind, description, payment_id, created_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7)
+		RETURNING id
+	`, e.UserID, amount, balance, e.Kind, e.Description, e.PaymentID, e.CreatedBy).Scan(&id)
+	if err != nil {
+		return fmt.Errorf("failed to record %s wallet transaction: %w", e.Kind, err)
+	}
+	return nil
+}
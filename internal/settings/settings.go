@@ -0,0 +1,237 @@
+// Package settings holds the runtime settings admins can change without a
+// restart: campus quiet hours, trash retention, the suspicious activity
+// limits and feature flags. Config provides the defaults; admins' overrides
+// are stored per setting in the database, so every instance follows them.
+package settings
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// cacheTTL is how long an instance trusts its copy of the settings, and so
+// how long a change takes to reach every instance
+const cacheTTL = 5 * time.Second
+
+// Features that can be switched off
+const (
+	FeatureChat        = "chat"
+	FeatureGraphQL     = "graphql"
+	FeatureLostFound   = "lost_found"
+	FeatureStories     = "stories"
+	FeatureSuggestions = "suggestions"
+)
+
+// Features lists the features that can be switched off
+var Features = []string{FeatureChat, FeatureGraphQL, FeatureLostFound, FeatureStories, FeatureSuggestions}
+
+// DefaultFeatures returns every feature switched on
+func DefaultFeatures() map[string]bool {
+	features := make(map[string]bool, len(Features))
+	for _, f := range Features {
+		features[f] = true
+	}
+	return features
+}
+
+// Settings are the runtime settings. Each JSON field is a setting that can
+// be overridden on its own; features are overridden per feature.
+type Settings struct {
+	// Campus-wide quiet hours, "HH:MM"; both empty for none
+	QuietHoursStart string `json:"quiet_hours_start"`
+	QuietHoursEnd   string `json:"quiet_hours_end"`
+
+	// Days deleted content stays in the trash
+	TrashRetentionDays int `json:"trash_retention_days"`
+
+	// Suspicious activity limits (0 disables a rule), and whether tripping
+	// one locks out or mutes automatically
+	FailedLoginLimit    int  `json:"failed_login_limit"`
+	SignupLimit         int  `json:"signup_limit"`
+	CommentLimit        int  `json:"comment_limit"`
+	SecurityAutoRespond bool `json:"security_auto_respond"`
+
+	// Features switched on or off
+	Features map[string]bool `json:"features"`
+}
+
+// Enabled reports whether a feature is on. Features are on unless switched
+// off.
+func (s Settings) Enabled(feature string) bool {
+	on, ok := s.Features[feature]
+	return !ok || on
+}
+
+// TrashRetention returns how long deleted content stays in the trash
+func (s Settings) TrashRetention() time.Duration {
+	return time.Duration(s.TrashRetentionDays) * 24 * time.Hour
+}
+
+// Validate checks the settings
+func (s Settings) Validate() error {
+	if (s.QuietHoursStart == "") != (s.QuietHoursEnd == "") {
+		return fmt.Errorf("quiet_hours_start and quiet_hours_end must be set together")
+	}
+	for _, clock := range []string{s.QuietHoursStart, s.QuietHoursEnd} {
+		if _, err := time.Parse("15:04", clock); clock != "" && err != nil {
+			return fmt.Errorf("quiet hours must be HH:MM, not %q", clock)
+		}
+	}
+	if s.TrashRetentionDays < 1 || s.TrashRetentionDays > 365 {
+		return fmt.Errorf("trash_retention_days must be between 1 and 365")
+	}
+	for name, limit := range map[string]int{
+		"failed_login_limit": s.FailedLoginLimit,
+		"signup_limit":       s.SignupLimit,
+		"comment_limit":      s.CommentLimit,
+	} {
+		if limit < 0 || limit > 1000 {
+			return fmt.Errorf("%s must be between 0 (off) and 1000", name)
+		}
+	}
+	for feature := range s.Features {
+		if !isFeature(feature) {
+			return fmt.Errorf("unknown feature %q", feature)
+		}
+	}
+	return nil
+}
+
+func isFeature(name string) bool {
+	for _, f := range Features {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply returns defaults with overrides, keyed by setting, applied on top.
+// Features overrides are merged with the default flags.
+func Apply(defaults Settings, overrides map[string]json.RawMessage) (Settings, error) {
+	s := defaults
+	s.Features = make(map[string]bool, len(defaults.Features))
+	for f, on := range defaults.Features {
+		s.Features[f] = on
+	}
+
+	b, _ := json.Marshal(s)
+	var fields map[string]json.RawMessage
+	json.Unmarshal(b, &fields)
+	for key, value := range overrides {
+		if _, ok := fields[key]; !ok {
+			return Settings{}, fmt.Errorf("unknown setting %q", key)
+		}
+		if key == "features" {
+			var flags map[string]bool
+			if err := json.Unmarshal(value, &flags); err != nil {
+				return Settings{}, fmt.Errorf("features must map feature names to true or false")
+			}
+			for f, on := range flags {
+				s.Features[f] = on
+			}
+			continue
+		}
+		fields[key] = value
+	}
+	features := s.Features
+
+	b, _ = json.Marshal(fields)
+	s = Settings{}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return Settings{}, fmt.Errorf("invalid setting value: %w", err)
+	}
+	s.Features = features
+	return s, s.Validate()
+}
+
+// Store serves the settings in force, rereading overrides from the
+// database at most every few seconds
+type Store struct {
+	db       *sql.DB
+	defaults Settings
+
+	mu        sync.Mutex
+	current   Settings
+	overrides map[string]json.RawMessage
+	fetchedAt time.Time
+}
+
+// NewStore creates the settings store. defaults, from config, apply where
+// admins haven't overridden a setting.
+func NewStore(db *sql.DB, defaults Settings) *Store {
+	return &Store{db: db, defaults: defaults, current: defaults}
+}
+
+// Defaults returns the settings from config
+func (s *Store) Defaults() Settings {
+	return s.defaults
+}
+
+// Current returns the settings in force. If the database cannot be read,
+// or holds settings no longer valid, the last known settings are used.
+func (s *Store) Current(ctx context.Context) Settings {
+	current, _ := s.load(ctx)
+	return current
+}
+
+// Overrides returns admins' overrides, keyed by setting
+func (s *Store) Overrides(ctx context.Context) map[string]json.RawMessage {
+	_, overrides := s.load(ctx)
+	return overrides
+}
+
+// Refresh drops the cached settings so the next use rereads them
+func (s *Store) Refresh() {
+	s.mu.Lock()
+	s.fetchedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+func (s *Store) load(ctx context.Context) (Settings, map[string]json.RawMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.fetchedAt) >= cacheTTL {
+		overrides, err := LoadOverrides(ctx, s.db)
+		if err == nil {
+			var current Settings
+			if current, err = Apply(s.defaults, overrides); err == nil {
+				s.current, s.overrides = current, overrides
+			}
+		}
+		if err != nil {
+			log.Printf("[SETTINGS] Failed to load runtime settings: %v", err)
+		}
+		// Failures are also cached so an unreachable database isn't hit on every use
+		s.fetchedAt = time.Now()
+	}
+	return s.current, s.overrides
+}
+
+// LoadOverrides reads admins' overrides, keyed by setting
+func LoadOverrides(ctx context.Context, q interface {
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+}) (map[string]json.RawMessage, error) {
+	rows, err := q.QueryContext(ctx, "SELECT key, value FROM runtime_settings")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	overrides := map[string]json.RawMessage{}
+	for rows.Next() {
+		var key string
+		var value []byte
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		overrides[key] = value
+	}
+	return overrides, rows.Err()
+}
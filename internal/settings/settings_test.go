@@ -0,0 +1,57 @@
+package settings
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func testDefaults() Settings {
+	return Settings{
+		QuietHoursStart:    "23:00",
+		QuietHoursEnd:      "07:00",
+		TrashRetentionDays: 30,
+		FailedLoginLimit:   5,
+		SignupLimit:        5,
+		CommentLimit:       10,
+		Features:           DefaultFeatures(),
+	}
+}
+
+func TestApply(t *testing.T) {
+	defaults := testDefaults()
+	s, err := Apply(defaults, map[string]json.RawMessage{
+		"trash_retention_days": json.RawMessage(`7`),
+		"quiet_hours_start":    json.RawMessage(`""`),
+		"quiet_hours_end":      json.RawMessage(`""`),
+		"features":             json.RawMessage(`{"chat": false}`),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.TrashRetentionDays != 7 || s.QuietHoursStart != "" || s.FailedLoginLimit != 5 {
+		t.Errorf("overrides applied wrongly: %+v", s)
+	}
+	if s.Enabled(FeatureChat) || !s.Enabled(FeatureStories) {
+		t.Errorf("features = %v, want only chat off", s.Features)
+	}
+	if !defaults.Enabled(FeatureChat) {
+		t.Error("Apply changed the default features")
+	}
+}
+
+func TestApplyRejectsInvalid(t *testing.T) {
+	for _, overrides := range []map[string]json.RawMessage{
+		{"trash_retention_days": json.RawMessage(`0`)},
+		{"trash_retention_days": json.RawMessage(`"7"`)},
+		{"quiet_hours_start": json.RawMessage(`"25:00"`)},
+		{"quiet_hours_end": json.RawMessage(`""`)},
+		{"comment_limit": json.RawMessage(`-1`)},
+		{"features": json.RawMessage(`{"polls": false}`)},
+		{"features": json.RawMessage(`{"chat": "off"}`)},
+		{"max_upload": json.RawMessage(`1`)},
+	} {
+		if _, err := Apply(testDefaults(), overrides); err == nil {
+			t.Errorf("Apply(%s) succeeded, want an error", overrides)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	_ "image/png" // Register PNG decoder
 	"io"
 	"mime/multipart"
+	"path"
 	"strings"
 
 	"cloud.google.com/go/storage"
@@ -105,6 +106,39 @@ func (s *GCSStorage) UploadImage(ctx context.Context, file multipart.File, filen
 	}, nil
 }
 
+// UploadFile uploads a file to GCS unchanged
+func (s *GCSStorage) UploadFile(ctx context.Context, r io.Reader, filename string, folder string, contentType string) (*UploadResult, error) {
+	uniqueFilename := uuid.New().String() + strings.ToLower(path.Ext(filename))
+	objectPath := fmt.Sprintf("%s/%s", folder, uniqueFilename)
+
+	wc := s.client.Bucket(s.bucketName).Object(objectPath).NewWriter(ctx)
+	wc.ContentType = contentType
+	wc.CacheControl = "public, max-age=31536000"
+
+	written, err := io.Copy(wc, r)
+	if err != nil {
+		wc.Close()
+		return nil, fmt.Errorf("failed to write to bucket: %w", err)
+	}
+	if err := wc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close GCS writer: %w", err)
+	}
+
+	return &UploadResult{
+		URL:       s.publicURL(objectPath),
+		Path:      objectPath,
+		SizeBytes: written,
+	}, nil
+}
+
+// publicURL is the URL an object is served from, via the CDN if configured
+func (s *GCSStorage) publicURL(objectPath string) string {
+	if s.cdnURL != "" {
+		return fmt.Sprintf("%s/%s", s.cdnURL, objectPath)
+	}
+	return fmt.Sprintf("https://storage.googleapis.com/%s/%s", s.bucketName, objectPath)
+}
+
 // Delete removes a file from GCS
 func (s *GCSStorage) Delete(ctx context.Context, path string) error {
 	obj := s.client.Bucket(s.bucketName).Object(path)
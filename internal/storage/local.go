@@ -11,6 +11,7 @@ import (
 	"mime/multipart"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/disintegration/imaging"
 	"github.com/google/uuid"
@@ -91,6 +92,33 @@ func (s *LocalStorage) UploadImage(ctx context.Context, file multipart.File, fil
 	}, nil
 }
 
+// UploadFile saves a file to the local filesystem unchanged
+func (s *LocalStorage) UploadFile(ctx context.Context, r io.Reader, filename string, folder string, contentType string) (*UploadResult, error) {
+	folderPath := filepath.Join(s.basePath, folder)
+	if err := os.MkdirAll(folderPath, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	uniqueFilename := uuid.New().String() + strings.ToLower(filepath.Ext(filename))
+	outFile, err := os.Create(filepath.Join(folderPath, uniqueFilename))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+	defer outFile.Close()
+
+	written, err := io.Copy(outFile, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	relativePath := fmt.Sprintf("%s/%s", folder, uniqueFilename)
+	return &UploadResult{
+		URL:       fmt.Sprintf("%s/%s", s.baseURL, relativePath),
+		Path:      relativePath,
+		SizeBytes: written,
+	}, nil
+}
+
 // Delete removes a file from local storage
 func (s *LocalStorage) Delete(ctx context.Context, path string) error {
 	fullPath := filepath.Join(s.basePath, path)
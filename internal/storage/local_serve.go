@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// inlineUploadExtensions are the file types browsers may show in place.
+// Anything else is served as a download, so an uploaded HTML or SVG file
+// can't run script on the API's origin.
+var inlineUploadExtensions = map[string]bool{
+	".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".webp": true,
+	".mp4": true, ".webm": true, ".mov": true, ".m4v": true,
+	".mp3": true, ".m4a": true, ".ogg": true, ".wav": true,
+	".pdf": true,
+}
+
+// Handler serves stored files under their storage paths, for local
+// development where there's no bucket or CDN in front. Stored files get
+// unique names and never change, so they are cached for good, with ETags
+// and byte ranges (for seeking in videos) handled by http.ServeContent.
+// Files outside the base directory, directories and dotfiles are not
+// served.
+func (s *LocalStorage) Handler() (http.Handler, error) {
+	if err := os.MkdirAll(s.basePath, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create uploads directory: %w", err)
+	}
+	// Opening files through the root keeps ".." and symlinks from
+	// reaching outside it
+	root, err := os.OpenRoot(s.basePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploads directory: %w", err)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		name, ok := uploadPath(r.URL.Path)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		f, err := root.Open(filepath.FromSlash(name))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			http.NotFound(w, r)
+			return
+		}
+
+		ext := strings.ToLower(path.Ext(name))
+		h := w.Header()
+		h.Set("Cache-Control", "public, max-age=31536000, immutable")
+		h.Set("ETag", fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()))
+		h.Set("X-Content-Type-Options", "nosniff")
+		if contentType := mime.TypeByExtension(ext); contentType != "" {
+			h.Set("Content-Type", contentType)
+		} else {
+			h.Set("Content-Type", "application/octet-stream")
+		}
+		if !inlineUploadExtensions[ext] {
+			h.Set("Content-Disposition", "attachment")
+		}
+		http.ServeContent(w, r, "", info.ModTime(), f)
+	}), nil
+}
+
+// uploadPath returns the storage path a request path names, relative to
+// the base directory, rejecting traversal, backslashes and dotfiles
+func uploadPath(urlPath string) (string, bool) {
+	if strings.ContainsAny(urlPath, "\\\x00") {
+		return "", false
+	}
+	for _, part := range strings.Split(urlPath, "/") {
+		if strings.HasPrefix(part, ".") {
+			return "", false
+		}
+	}
+	name := strings.TrimPrefix(path.Clean("/"+urlPath), "/")
+	if name == "" {
+		return "", false
+	}
+	return name, true
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalStorageHandler(t *testing.T) {
+	base := t.TempDir()
+	outside := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		p := filepath.Join(base, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("events/a.mp4", "0123456789")
+	write("chat/b.html", "<script>alert(1)</script>")
+	write(".env", "SECRET=1")
+	if err := os.WriteFile(filepath.Join(outside, "secret.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(outside, "secret.txt"), filepath.Join(base, "events", "link.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	handler, err := NewLocalStorage(base, "").Handler()
+	if err != nil {
+		t.Fatal(err)
+	}
+	get := func(path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.URL.Path = path
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/events/a.mp4", nil)
+	if w.Code != http.StatusOK || w.Body.String() != "0123456789" {
+		t.Fatalf("GET a.mp4 = %d %q", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Cache-Control") == "" || w.Header().Get("Content-Disposition") != "" {
+		t.Errorf("unexpected headers for a video: %v", w.Header())
+	}
+	etag := w.Header().Get("ETag")
+
+	if w := get("/events/a.mp4", http.Header{"Range": {"bytes=2-4"}}); w.Code != http.StatusPartialContent || w.Body.String() != "234" {
+		t.Errorf("range request = %d %q, want 206 \"234\"", w.Code, w.Body.String())
+	}
+	if w := get("/events/a.mp4", http.Header{"If-None-Match": {etag}}); w.Code != http.StatusNotModified {
+		t.Errorf("conditional request = %d, want 304", w.Code)
+	}
+	if w := get("/chat/b.html", nil); w.Header().Get("Content-Disposition") != "attachment" {
+		t.Errorf("HTML served inline")
+	}
+
+	for _, path := range []string{"/events/../../etc/passwd", "/.env", "/events", "/", "/events/link.txt", "/events\\a.mp4", "/missing.jpg"} {
+		if w := get(path, nil); w.Code != http.StatusNotFound {
+			t.Errorf("GET %s = %d, want 404", path, w.Code)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"mime/multipart"
 )
 
@@ -33,6 +35,10 @@ type StorageService interface {
 	// - imageType: determines resize dimensions
 	UploadImage(ctx context.Context, file multipart.File, filename string, folder string, imageType ImageType) (*UploadResult, error)
 
+	// UploadFile stores a file as-is, such as a video, keeping the
+	// extension of filename
+	UploadFile(ctx context.Context, r io.Reader, filename string, folder string, contentType string) (*UploadResult, error)
+
 	// Delete removes a file from storage
 	Delete(ctx context.Context, path string) error
 }
@@ -48,3 +54,16 @@ func (t ImageType) MaxDimension() int {
 		return 1920 // Reasonable max for "original"
 	}
 }
+
+// bytesFile is an in-memory multipart.File
+type bytesFile struct {
+	*bytes.Reader
+}
+
+func (bytesFile) Close() error { return nil }
+
+// BytesFile wraps an image generated on the server, such as an event poster,
+// for UploadImage
+func BytesFile(b []byte) multipart.File {
+	return bytesFile{bytes.NewReader(b)}
+}
@@ -0,0 +1,104 @@
+// Package uploads stages resumable uploads. Clients send a file in chunks
+// using the core tus protocol (https://tus.io/protocols/resumable-upload);
+// the chunks are appended to a staging file on disk until the upload is
+// complete and can be handed to storage.
+package uploads
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// TusVersion is the tus protocol version served
+const TusVersion = "1.0.0"
+
+// StagingDir is where uploads are staged: UPLOAD_STAGING_DIR, or a
+// directory under the system temp dir
+func StagingDir() string {
+	if dir := os.Getenv("UPLOAD_STAGING_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.TempDir(), "campus-uploads")
+}
+
+// Stage holds the partly received files of resumable uploads
+type Stage struct {
+	dir string
+}
+
+// NewStage creates a stage in dir
+func NewStage(dir string) *Stage {
+	return &Stage{dir: dir}
+}
+
+func (s *Stage) path(id uuid.UUID) string {
+	return filepath.Join(s.dir, id.String()+".part")
+}
+
+// Append writes up to limit bytes from r to the upload's staging file at
+// offset and returns how many were written. Those bytes are kept even if r
+// fails part way, as when a client's connection drops, so the upload can
+// resume from offset plus the count.
+func (s *Stage) Append(id uuid.UUID, offset int64, r io.Reader, limit int64) (int64, error) {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	f, err := os.OpenFile(s.path(id), os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open staged upload: %w", err)
+	}
+	defer f.Close()
+
+	// Drop anything past offset that was written but never recorded
+	if err := f.Truncate(offset); err != nil {
+		return 0, fmt.Errorf("failed to truncate staged upload: %w", err)
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to seek staged upload: %w", err)
+	}
+
+	n, copyErr := io.Copy(f, io.LimitReader(r, limit))
+	if err := f.Sync(); err != nil {
+		return 0, fmt.Errorf("failed to write staged upload: %w", err)
+	}
+	return n, copyErr
+}
+
+// Open opens an upload's staging file for reading
+func (s *Stage) Open(id uuid.UUID) (*os.File, error) {
+	return os.Open(s.path(id))
+}
+
+// Remove deletes an upload's staging file, if there is one
+func (s *Stage) Remove(id uuid.UUID) error {
+	if err := os.Remove(s.path(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// ParseMetadata decodes an Upload-Metadata header: comma-separated pairs of
+// a key and a base64 value, which may be left out
+func ParseMetadata(header string) (map[string]string, error) {
+	metadata := map[string]string{}
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, encoded, _ := strings.Cut(pair, " ")
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for metadata key %q", key)
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
@@ -0,0 +1,73 @@
+package uploads
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// failingReader returns its data, then fails as a dropped connection would
+type failingReader struct {
+	r io.Reader
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	if err == io.EOF {
+		return n, errors.New("connection reset")
+	}
+	return n, err
+}
+
+func TestStageResumes(t *testing.T) {
+	stage := NewStage(t.TempDir())
+	id := uuid.New()
+
+	n, err := stage.Append(id, 0, &failingReader{strings.NewReader("hello ")}, 11)
+	if err == nil || n != 6 {
+		t.Fatalf("interrupted append = %d, %v; want 6 and an error", n, err)
+	}
+
+	// Bytes past the recorded offset are dropped when the client resumes
+	n, err = stage.Append(id, 5, strings.NewReader(" world and more"), 6)
+	if err != nil || n != 6 {
+		t.Fatalf("resumed append = %d, %v", n, err)
+	}
+
+	f, err := stage.Open(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := io.ReadAll(f)
+	f.Close()
+	if string(got) != "hello world" {
+		t.Errorf("staged %q, want %q", got, "hello world")
+	}
+
+	if err := stage.Remove(id); err != nil {
+		t.Fatal(err)
+	}
+	if err := stage.Remove(id); err != nil {
+		t.Errorf("removing twice: %v", err)
+	}
+}
+
+func TestParseMetadata(t *testing.T) {
+	metadata, err := ParseMetadata("filename aGlnaGxpZ2h0cy5tcDQ=, filetype dmlkZW8vbXA0,is_confidential")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata["filename"] != "highlights.mp4" || metadata["filetype"] != "video/mp4" {
+		t.Errorf("got %v", metadata)
+	}
+	if v, ok := metadata["is_confidential"]; !ok || v != "" {
+		t.Errorf("key without a value = %q, %v", v, ok)
+	}
+
+	if _, err := ParseMetadata("filename not-base64!"); err == nil {
+		t.Error("expected an error for an invalid value")
+	}
+}
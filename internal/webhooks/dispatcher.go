@@ -0,0 +1,256 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+)
+
+const (
+	deliveryBatchSize    = 10
+	deliveryPollInterval = 5 * time.Second
+	deliveryTimeout      = 10 * time.Second
+	// MaxDeliveryAttempts is how many times a delivery is tried before it is
+	// marked failed; admins can still redeliver it by hand
+	MaxDeliveryAttempts = 8
+	maxDeliveryBackoff  = 6 * time.Hour
+	// maxResponseLog bounds how much of a subscriber's response is kept
+	maxResponseLog = 1024
+)
+
+// Dispatcher fans emitted events out to subscriptions and delivers them
+type Dispatcher struct {
+	db     *sql.DB
+	client *http.Client
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewDispatcher creates a new webhook dispatcher
+func NewDispatcher(db *sql.DB) *Dispatcher {
+	return &Dispatcher{
+		db:     db,
+		client: &http.Client{Timeout: deliveryTimeout},
+		stop:   make(chan struct{}),
+	}
+}
+
+// RegisterOutboxHandlers wires subscription fan-out into the outbox relay
+func (d *Dispatcher) RegisterOutboxHandlers(relay *outbox.Relay) {
+	relay.Register(TopicDispatch, d.handleDispatch)
+}
+
+// handleDispatch queues a delivery of the event for every active
+// subscription to its type. The outbox event ID becomes the envelope ID, so a
+// redelivered outbox event queues nothing new.
+func (d *Dispatcher) handleDispatch(ctx context.Context, event outbox.Event) error {
+	var p dispatchPayload
+	if err := json.Unmarshal(event.Payload, &p); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(Envelope{
+		ID:         event.ID,
+		Type:       p.Type,
+		OccurredAt: p.OccurredAt,
+		Data:       p.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.ExecContext(ctx, `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+		SELECT id, $1, $2, $3
+		FROM webhook_subscriptions
+		WHERE active AND $2 = ANY(event_types)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+	`, event.ID, p.Type, body)
+	return err
+}
+
+// Start begins delivering pending webhooks in the background
+func (d *Dispatcher) Start() {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		for {
+			n, err := d.deliverBatch()
+			if err != nil {
+				log.Printf("[WEBHOOK] Delivery batch failed: %v", err)
+			}
+			if n == deliveryBatchSize {
+				continue
+			}
+			select {
+			case <-d.stop:
+				return
+			case <-time.After(deliveryPollInterval):
+			}
+		}
+	}()
+	log.Println("[WEBHOOK] Dispatcher started")
+}
+
+// Stop waits for the in-flight batch to finish
+func (d *Dispatcher) Stop() {
+	close(d.stop)
+	d.wg.Wait()
+	log.Println("[WEBHOOK] Dispatcher stopped")
+}
+
+// deliveryBackoff returns the wait before retry number attempt
+func deliveryBackoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := 30 * time.Second
+	for i := 1; i < attempt && delay < maxDeliveryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxDeliveryBackoff {
+		delay = maxDeliveryBackoff
+	}
+	return delay
+}
+
+// deliverBatch sends one batch of due deliveries. As with the outbox, rows
+// stay locked for the batch so concurrent instances never send the same
+// delivery at once; the batch is small because each send may take up to
+// deliveryTimeout.
+func (d *Dispatcher) deliverBatch() (int, error) {
+	ctx := context.Background()
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Deliveries of paused subscriptions wait until they are reactivated
+	rows, err := tx.QueryContext(ctx, `
+		SELECT d.id, d.event_type, d.payload, d.attempts, s.url, s.secret
+		FROM webhook_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		WHERE d.status = 'pending' AND d.next_attempt_at <= NOW() AND s.active
+		ORDER BY d.next_attempt_at ASC
+		LIMIT $1
+		FOR UPDATE OF d SKIP LOCKED
+	`, deliveryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	type pending struct {
+		id        uuid.UUID
+		eventType string
+		payload   []byte
+		attempts  int
+		url       string
+		secret    string
+	}
+	var batch []pending
+	for rows.Next() {
+		var p pending
+		if err := rows.Scan(&p.id, &p.eventType, &p.payload, &p.attempts, &p.url, &p.secret); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		batch = append(batch, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, p := range batch {
+		attempts := p.attempts + 1
+		status, response, sendErr := d.send(ctx, p.id, p.eventType, p.url, p.secret, p.payload)
+
+		var errText *string
+		if sendErr != nil {
+			s := sendErr.Error()
+			errText = &s
+		}
+		var statusCode *int
+		if status != 0 {
+			statusCode = &status
+		}
+
+		switch {
+		case sendErr == nil:
+			_, err = tx.ExecContext(ctx, `
+				UPDATE webhook_deliveries
+				SET status = 'delivered', attempts = $2, last_status_code = $3, last_error = NULL,
+				    last_response = $4, last_attempt_at = NOW(), delivered_at = NOW()
+				WHERE id = $1
+			`, p.id, attempts, statusCode, response)
+		case attempts >= MaxDeliveryAttempts:
+			log.Printf("[WEBHOOK] Giving up on delivery %s after %d attempts: %v", p.id, attempts, sendErr)
+			_, err = tx.ExecContext(ctx, `
+				UPDATE webhook_deliveries
+				SET status = 'failed', attempts = $2, last_status_code = $3, last_error = $4,
+				    last_response = $5, last_attempt_at = NOW()
+				WHERE id = $1
+			`, p.id, attempts, statusCode, errText, response)
+		default:
+			_, err = tx.ExecContext(ctx, `
+				UPDATE webhook_deliveries
+				SET attempts = $2, last_status_code = $3, last_error = $4, last_response = $5,
+				    last_attempt_at = NOW(), next_attempt_at = $6
+				WHERE id = $1
+			`, p.id, attempts, statusCode, errText, response, time.Now().Add(deliveryBackoff(attempts)))
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(batch), tx.Commit()
+}
+
+// send POSTs a delivery. Any 2xx response is success. Returns the status
+// code (0 if no response was received) and the start of the response body.
+func (d *Dispatcher) send(ctx context.Context, deliveryID uuid.UUID, eventType, url, secret string, body []byte) (int, *string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "college-events-webhooks/1.0")
+	req.Header.Set(HeaderEvent, eventType)
+	req.Header.Set(HeaderDelivery, deliveryID.String())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseLog))
+	var response *string
+	if len(snippet) > 0 {
+		s := string(bytes.ToValidUTF8(snippet, nil))
+		response = &s
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, response, fmt.Errorf("subscriber responded %s", resp.Status)
+	}
+	return resp.StatusCode, response, nil
+}
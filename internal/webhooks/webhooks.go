@@ -0,0 +1,117 @@
+// Package webhooks delivers signed event notifications to external systems
+// (such as the college ERP) subscribed by admins.
+//
+// Events are emitted into the outbox in the caller's transaction. The relay
+// fans each one out into a delivery per matching subscription, and the
+// Dispatcher POSTs pending deliveries, retrying failures with backoff.
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/college-event-backend/internal/outbox"
+	"github.com/yourusername/college-event-backend/pkg/database"
+)
+
+// Event types external systems can subscribe to
+const (
+	EventCreated    = "event.created"
+	PaymentCaptured = "payment.captured"
+	UserRegistered  = "user.registered"
+)
+
+// EventTypes lists every event type
+var EventTypes = []string{EventCreated, PaymentCaptured, UserRegistered}
+
+// ValidEventType reports whether t is a known event type
+func ValidEventType(t string) bool {
+	for _, e := range EventTypes {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
+
+// TopicDispatch is the outbox topic that fans events out to subscriptions
+const TopicDispatch = "webhook.dispatch"
+
+// Headers sent with every delivery
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// dispatchPayload is the outbox record for an emitted event
+type dispatchPayload struct {
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Envelope is the JSON body POSTed to subscribers. ID is stable across
+// retries and redeliveries, so receivers can use it to discard duplicates.
+type Envelope struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// PaymentCapturedData is the data of a payment.captured event
+type PaymentCapturedData struct {
+	PaymentID         uuid.UUID  `json:"payment_id"`
+	Kind              string     `json:"kind"` // event_registration, fest_pass or donation
+	EventID           *uuid.UUID `json:"event_id,omitempty"`
+	FestID            *uuid.UUID `json:"fest_id,omitempty"`
+	CampaignID        *uuid.UUID `json:"campaign_id,omitempty"`
+	UserID            uuid.UUID  `json:"user_id"`
+	Amount            float64    `json:"amount"`
+	Currency          string     `json:"currency"`
+	Method            string     `json:"method"`                        // razorpay, cash or offline
+	RazorpayPaymentID string     `json:"razorpay_payment_id,omitempty"` // Set for razorpay payments
+}
+
+// UserRegisteredData is the data of a user.registered event
+type UserRegisteredData struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Email      string    `json:"email"`
+	FullName   string    `json:"full_name"`
+	Department *string   `json:"department,omitempty"`
+	Year       *int      `json:"year,omitempty"`
+	Method     string    `json:"method"` // password, google, ldap or oidc
+}
+
+// Emit records an event for delivery to its subscribers as part of the
+// caller's transaction
+func Emit(ctx context.Context, tx database.Execer, eventType string, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return outbox.Write(ctx, tx, TopicDispatch, dispatchPayload{
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       raw,
+	})
+}
+
+// Sign returns the signature of a delivery: the hex HMAC-SHA256, keyed by the
+// subscription secret, of the timestamp, a dot, and the raw body. Receivers
+// should recompute it and reject stale timestamps.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,76 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TestSendSignsBody verifies a receiver can check the signature it is sent
+func TestSendSignsBody(t *testing.T) {
+	const secret = "0123456789abcdef"
+	body := []byte(`{"id":"x","type":"event.created","data":{}}`)
+
+	var verified bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ := io.ReadAll(r.Body)
+		ts, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		if err != nil {
+			t.Errorf("bad timestamp header: %v", err)
+		}
+		verified = r.Header.Get(HeaderSignature) == Sign(secret, ts, got) &&
+			r.Header.Get(HeaderEvent) == EventCreated
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	d := NewDispatcher(nil)
+	status, response, err := d.send(context.Background(), uuid.New(), EventCreated, srv.URL, secret, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != http.StatusOK || response == nil || *response != "ok" {
+		t.Errorf("send = %d, %v; want 200, ok", status, response)
+	}
+	if !verified {
+		t.Error("receiver could not verify the signature")
+	}
+}
+
+// TestSendRejectsNon2xx verifies error responses are failures
+func TestSendRejectsNon2xx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	status, _, err := NewDispatcher(nil).send(context.Background(), uuid.New(), EventCreated, srv.URL, "s", []byte("{}"))
+	if err == nil || status != http.StatusServiceUnavailable {
+		t.Errorf("send = %d, %v; want 503 and an error", status, err)
+	}
+}
+
+// TestDeliveryBackoff verifies retry delays double per attempt and are capped
+func TestDeliveryBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 30 * time.Second},
+		{attempt: 2, want: time.Minute},
+		{attempt: 4, want: 4 * time.Minute},
+		{attempt: 20, want: 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := deliveryBackoff(tt.attempt); got != tt.want {
+			t.Errorf("deliveryBackoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+-- Migration 011: Club meeting attendance
+-- Leads open a check-in window with a short-lived code; members check in
+-- with it and the attendance history counts toward club activity points
+
+-- ============================================================================
+-- CLUB MEETINGS
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS club_meetings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    club_id UUID NOT NULL REFERENCES clubs(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    location VARCHAR(255),
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    checkin_code VARCHAR(6),
+    checkin_expires_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_club_meetings_club ON club_meetings(club_id, starts_at DESC);
+
+DROP TRIGGER IF EXISTS update_club_meetings_updated_at ON club_meetings;
+CREATE TRIGGER update_club_meetings_updated_at
+    BEFORE UPDATE ON club_meetings
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================================================
+-- CLUB MEETING ATTENDANCE
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS club_meeting_attendance (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    meeting_id UUID NOT NULL REFERENCES club_meetings(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    checked_in_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(meeting_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_club_meeting_attendance_user ON club_meeting_attendance(user_id);
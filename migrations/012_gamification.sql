@@ -0,0 +1,76 @@
+-- Migration 012: Activity points and badges
+-- Every point a user earns is a row in activity_points; badges are awarded
+-- when a user's points or activity counts cross an admin-defined threshold
+
+-- ============================================================================
+-- ACTIVITY POINTS (per-user ledger)
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS activity_points (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source VARCHAR(50) NOT NULL, -- event_attendance, meeting_attendance, post, competition_win
+    source_id UUID NOT NULL,     -- The event, meeting, post, ... the points were earned for
+    points INTEGER NOT NULL,
+    reason VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    -- Earning points for the same thing twice is a no-op
+    UNIQUE(user_id, source, source_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_activity_points_user ON activity_points(user_id, created_at DESC);
+
+-- ============================================================================
+-- HOUSE POINT LEDGER
+-- houses.points stays the running total; each change is recorded here
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS house_point_ledger (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    house_id UUID NOT NULL REFERENCES houses(id) ON DELETE CASCADE,
+    points INTEGER NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    source VARCHAR(50) NOT NULL,
+    source_id UUID,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL, -- Member who earned the points, if any
+    awarded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_house_point_ledger_house ON house_point_ledger(house_id, created_at DESC);
+
+-- ============================================================================
+-- BADGES
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS badges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    icon_url VARCHAR(500),
+    -- Awarded once the user has threshold activity_points rows from
+    -- rule_source, or threshold total points when rule_source is NULL
+    rule_source VARCHAR(50),
+    threshold INTEGER NOT NULL CHECK (threshold > 0),
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+DROP TRIGGER IF EXISTS update_badges_updated_at ON badges;
+CREATE TRIGGER update_badges_updated_at
+    BEFORE UPDATE ON badges
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS user_badges (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    badge_id UUID NOT NULL REFERENCES badges(id) ON DELETE CASCADE,
+    awarded_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, badge_id)
+);
+
+INSERT INTO badges (code, name, description, rule_source, threshold) VALUES
+    ('first_event', 'First Steps', 'Attended your first event', 'event_attendance', 1),
+    ('regular', 'Regular', 'Attended 10 events', 'event_attendance', 10),
+    ('committed', 'Committed', 'Checked in to 10 club meetings', 'meeting_attendance', 10),
+    ('champion', 'Champion', 'Won a competition', 'competition_win', 1),
+    ('centurion', 'Centurion', 'Earned 100 activity points', NULL, 100)
+ON CONFLICT (code) DO NOTHING;
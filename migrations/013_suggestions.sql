@@ -0,0 +1,26 @@
+-- Migration 013: Anonymous suggestion box
+-- Suggestions never store who submitted them. submitter_hash is a keyed
+-- hash of the submitter, used only for rate limiting and "my suggestions".
+
+CREATE TABLE IF NOT EXISTS suggestions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    club_id UUID REFERENCES clubs(id) ON DELETE CASCADE, -- NULL addresses the admins
+    subject VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'new' CHECK (status IN ('new', 'reviewing', 'resolved')),
+    submitter_hash VARCHAR(64) NOT NULL,
+    response TEXT,
+    response_public BOOLEAN NOT NULL DEFAULT false,
+    responded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_suggestions_club_status ON suggestions(club_id, status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_suggestions_submitter ON suggestions(submitter_hash, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_suggestions_public ON suggestions(created_at DESC) WHERE response_public;
+
+DROP TRIGGER IF EXISTS update_suggestions_updated_at ON suggestions;
+CREATE TRIGGER update_suggestions_updated_at
+    BEFORE UPDATE ON suggestions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,45 @@
+-- Migration 014: Lost and found
+-- Items are posted as lost or found, claimed by other users, and expire
+-- after 30 days through the cleanup job
+
+-- ============================================================================
+-- LOST AND FOUND ITEMS
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS lost_found_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('lost', 'found')),
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    location VARCHAR(255),
+    image_url VARCHAR(500),
+    image_path VARCHAR(500), -- Storage path, for deleting the photo on expiry
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'claimed', 'expired')),
+    posted_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP + INTERVAL '30 days'),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_lost_found_items_open ON lost_found_items(created_at DESC) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_lost_found_items_expiry ON lost_found_items(expires_at) WHERE status = 'open';
+
+DROP TRIGGER IF EXISTS update_lost_found_items_updated_at ON lost_found_items;
+CREATE TRIGGER update_lost_found_items_updated_at
+    BEFORE UPDATE ON lost_found_items
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================================================
+-- CLAIMS
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS lost_found_claims (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    item_id UUID NOT NULL REFERENCES lost_found_items(id) ON DELETE CASCADE,
+    claimant_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE(item_id, claimant_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_lost_found_claims_item ON lost_found_claims(item_id);
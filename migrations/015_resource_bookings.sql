@@ -0,0 +1,54 @@
+-- Migration 015: Resource booking
+-- Admin-managed resources (projectors, auditoriums, sound systems) that club
+-- leads request for a time slot, optionally tied to one of their events
+
+-- ============================================================================
+-- RESOURCES
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS resources (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(30) NOT NULL CHECK (kind IN ('projector', 'auditorium', 'sound_system', 'other')),
+    description TEXT,
+    location VARCHAR(255),
+    capacity INTEGER,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+DROP TRIGGER IF EXISTS update_resources_updated_at ON resources;
+CREATE TRIGGER update_resources_updated_at
+    BEFORE UPDATE ON resources
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================================================
+-- RESOURCE BOOKINGS
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS resource_bookings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    resource_id UUID NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+    club_id UUID NOT NULL REFERENCES clubs(id) ON DELETE CASCADE,
+    event_id UUID REFERENCES events(id) ON DELETE SET NULL,
+    requested_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    purpose TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected', 'cancelled')),
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_resource_bookings_resource_time ON resource_bookings(resource_id, starts_at, ends_at)
+    WHERE status IN ('pending', 'approved');
+CREATE INDEX IF NOT EXISTS idx_resource_bookings_club ON resource_bookings(club_id, starts_at DESC);
+CREATE INDEX IF NOT EXISTS idx_resource_bookings_pending ON resource_bookings(created_at) WHERE status = 'pending';
+
+DROP TRIGGER IF EXISTS update_resource_bookings_updated_at ON resource_bookings;
+CREATE TRIGGER update_resource_bookings_updated_at
+    BEFORE UPDATE ON resource_bookings
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,13 @@
+-- Migration 016: Event live updates
+-- Short posts organizers push while an event is running ("Round 2 starts at
+-- 3pm", "Venue changed"), delivered to registered users and shown on the event
+
+CREATE TABLE IF NOT EXISTS event_updates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    posted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_updates_event ON event_updates(event_id, created_at DESC);
@@ -0,0 +1,32 @@
+-- Migration 017: Competition results
+-- Placements recorded for an event, each won by an individual or a team.
+-- Recording results also writes the club award and house points they earn.
+
+-- ============================================================================
+-- EVENT RESULTS
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS event_results (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL CHECK (position > 0),
+    team_name VARCHAR(255),                                            -- NULL for individual placements
+    club_id UUID REFERENCES clubs(id) ON DELETE SET NULL,              -- Club the winner represented, if any
+    club_award_id UUID REFERENCES club_awards(id) ON DELETE SET NULL,  -- Award created for that club
+    house_points INTEGER NOT NULL DEFAULT 0 CHECK (house_points >= 0),
+    prize_amount DECIMAL(10,2),
+    recorded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_results_event ON event_results(event_id, position);
+
+-- ============================================================================
+-- EVENT RESULT MEMBERS (the individual, or each member of the team)
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS event_result_members (
+    result_id UUID NOT NULL REFERENCES event_results(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (result_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_result_members_user ON event_result_members(user_id);
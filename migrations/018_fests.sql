@@ -0,0 +1,65 @@
+-- Migration 018: Fests
+-- A fest groups many sub-events under one brand and date range, with an
+-- optional combined pass that registers the holder for every sub-event
+
+-- ============================================================================
+-- FESTS
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS fests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    tagline VARCHAR(255),
+    description TEXT,
+    logo_url VARCHAR(500),
+    banner_url VARCHAR(500),
+    color_hex VARCHAR(7),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    pass_amount DECIMAL(10,2), -- NULL when no combined pass is offered; 0 for a free pass
+    currency VARCHAR(3) DEFAULT 'INR',
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fests_dates ON fests(start_date DESC) WHERE deleted_at IS NULL;
+
+DROP TRIGGER IF EXISTS update_fests_updated_at ON fests;
+CREATE TRIGGER update_fests_updated_at
+    BEFORE UPDATE ON fests
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================================================
+-- SUB-EVENTS
+-- ============================================================================
+ALTER TABLE events ADD COLUMN IF NOT EXISTS fest_id UUID REFERENCES fests(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_events_fest ON events(fest_id) WHERE deleted_at IS NULL;
+
+-- ============================================================================
+-- FEST PASSES
+-- One per user and fest; paid passes go through Razorpay like event payments
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS fest_passes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    fest_id UUID NOT NULL REFERENCES fests(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    razorpay_order_id VARCHAR(50),
+    razorpay_payment_id VARCHAR(50),
+    razorpay_signature VARCHAR(255),
+    amount DECIMAL(10,2) NOT NULL DEFAULT 0,
+    currency VARCHAR(3) DEFAULT 'INR',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'paid', 'failed')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(fest_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fest_passes_order ON fest_passes(razorpay_order_id);
+
+DROP TRIGGER IF EXISTS update_fest_passes_updated_at ON fest_passes;
+CREATE TRIGGER update_fest_passes_updated_at
+    BEFORE UPDATE ON fest_passes
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,28 @@
+-- Migration 019: Sponsors
+-- Sponsors shown on an event or a fest, by tier and then in the organizers'
+-- chosen order. Sponsor visibility is a contractual requirement for fests.
+
+CREATE TABLE IF NOT EXISTS sponsors (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID REFERENCES events(id) ON DELETE CASCADE,
+    fest_id UUID REFERENCES fests(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    tier VARCHAR(20) NOT NULL CHECK (tier IN ('title', 'gold', 'silver')),
+    logo_url VARCHAR(500),
+    logo_path VARCHAR(500), -- Storage path, for deletion
+    link_url VARCHAR(500),
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    -- Attached to exactly one event or fest
+    CHECK ((event_id IS NULL) <> (fest_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_sponsors_event ON sponsors(event_id) WHERE event_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_sponsors_fest ON sponsors(fest_id) WHERE fest_id IS NOT NULL;
+
+DROP TRIGGER IF EXISTS update_sponsors_updated_at ON sponsors;
+CREATE TRIGGER update_sponsors_updated_at
+    BEFORE UPDATE ON sponsors
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,12 @@
+-- Migration 020: Registration forms
+-- Per-event extra registration fields (T-shirt size, dietary needs, GitHub
+-- URL, ...) and each registrant's answers
+
+-- JSON array of fields: [{"key", "label", "type", "required", "options", ...}]
+ALTER TABLE events ADD COLUMN IF NOT EXISTS registration_form JSONB NOT NULL DEFAULT '[]';
+
+-- Answers keyed by field key
+ALTER TABLE event_registrations ADD COLUMN IF NOT EXISTS form_responses JSONB;
+
+-- Held on the pending payment until it is verified and the registration is made
+ALTER TABLE event_payments ADD COLUMN IF NOT EXISTS form_responses JSONB;
@@ -0,0 +1,31 @@
+-- Migration 021: Weekly email digest
+-- Opt-in subscriptions with a per-user unsubscribe token, and one send
+-- record per user per week so a rerun never emails anyone twice
+
+CREATE TABLE IF NOT EXISTS digest_subscriptions (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    -- Unguessable token for the one-click unsubscribe link in each email
+    unsubscribe_token VARCHAR(64) NOT NULL UNIQUE,
+    subscribed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS digest_sends (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    week_start DATE NOT NULL, -- Monday of the digest's week
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'sending', 'sent', 'failed')),
+    error TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, week_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_digest_sends_week_status ON digest_sends(week_start, status);
+
+DROP TRIGGER IF EXISTS update_digest_sends_updated_at ON digest_sends;
+CREATE TRIGGER update_digest_sends_updated_at
+    BEFORE UPDATE ON digest_sends
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,29 @@
+-- Migration 023: Quiet hours
+-- Non-urgent pushes arriving during a user's quiet hours are held in
+-- deferred_pushes and sent as one summary once the window ends
+
+-- Per-user override of the campus-wide quiet hours. enabled = FALSE turns
+-- quiet hours off; NULL times use the campus-wide window.
+CREATE TABLE IF NOT EXISTS notification_quiet_hours (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    start_time TIME,
+    end_time TIME,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((start_time IS NULL) = (end_time IS NULL))
+);
+
+DROP TRIGGER IF EXISTS update_notification_quiet_hours_updated_at ON notification_quiet_hours;
+CREATE TRIGGER update_notification_quiet_hours_updated_at
+    BEFORE UPDATE ON notification_quiet_hours
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS deferred_pushes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_deferred_pushes_user ON deferred_pushes(user_id);
@@ -0,0 +1,23 @@
+-- Migration 024: Audit log
+-- Append-only record of security-relevant actions, starting with admin
+-- impersonation sessions and every request made under them
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    action VARCHAR(100) NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    subject_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    session_id UUID,
+    method VARCHAR(10),
+    path TEXT,
+    status INTEGER,
+    request_id VARCHAR(128),
+    ip_address VARCHAR(64),
+    details JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created ON audit_log(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log(actor_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_subject ON audit_log(subject_user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_session ON audit_log(session_id) WHERE session_id IS NOT NULL;
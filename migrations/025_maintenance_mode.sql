@@ -0,0 +1,19 @@
+-- Migration 025: Maintenance mode
+-- Single row shared by every API instance. While enabled, non-admin requests
+-- are answered with 503 (e.g. during semester-start migrations).
+
+CREATE TABLE IF NOT EXISTS maintenance_mode (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id), -- Only one row
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    message TEXT,
+    eta TIMESTAMP WITH TIME ZONE, -- Expected end, shown to clients
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO maintenance_mode (id) VALUES (TRUE) ON CONFLICT (id) DO NOTHING;
+
+DROP TRIGGER IF EXISTS update_maintenance_mode_updated_at ON maintenance_mode;
+CREATE TRIGGER update_maintenance_mode_updated_at
+    BEFORE UPDATE ON maintenance_mode
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
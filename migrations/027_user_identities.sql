@@ -0,0 +1,16 @@
+-- Migration 027: External identities
+-- Links accounts to users signed in through institutional SSO (OIDC). A
+-- first sign-in links to the existing account with the same email, or
+-- provisions a new one.
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    provider VARCHAR(255) NOT NULL, -- OIDC issuer URL
+    subject VARCHAR(255) NOT NULL,  -- The provider's stable user ID (sub)
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,    -- Email when last signed in
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_login_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_identities_user ON user_identities(user_id);
@@ -0,0 +1,108 @@
+-- Migration 028: Academic terms
+-- Events, club awards, club memberships, activity points and house points
+-- are tagged with the term they fall in, so leaderboards can reset each term
+-- while past terms stay queryable. Ended terms are archived with a snapshot
+-- of their final standings.
+
+CREATE TABLE IF NOT EXISTS academic_terms (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE, -- e.g. "2025-26 Odd Semester"
+    starts_on DATE NOT NULL,
+    ends_on DATE NOT NULL,             -- Inclusive
+    archived_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_on >= starts_on),
+    -- A day belongs to at most one term
+    EXCLUDE USING gist (daterange(starts_on, ends_on, '[]') WITH &&)
+);
+
+DROP TRIGGER IF EXISTS update_academic_terms_updated_at ON academic_terms;
+CREATE TRIGGER update_academic_terms_updated_at
+    BEFORE UPDATE ON academic_terms
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Final standings, written when a term is archived
+CREATE TABLE IF NOT EXISTS academic_term_standings (
+    term_id UUID NOT NULL REFERENCES academic_terms(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('house', 'user')),
+    subject_id UUID NOT NULL,  -- House or user
+    name VARCHAR(255) NOT NULL, -- As it was at archival
+    rank INTEGER NOT NULL,
+    points INTEGER NOT NULL,
+    PRIMARY KEY (term_id, kind, subject_id)
+);
+
+-- ============================================================================
+-- TERM TAGGING
+-- ============================================================================
+
+-- The term containing a day, or NULL if it falls between terms
+CREATE OR REPLACE FUNCTION academic_term_on(d DATE)
+RETURNS UUID AS $$
+    SELECT id FROM academic_terms WHERE d BETWEEN starts_on AND ends_on
+$$ LANGUAGE sql STABLE;
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS term_id UUID REFERENCES academic_terms(id) ON DELETE SET NULL;
+ALTER TABLE club_awards ADD COLUMN IF NOT EXISTS term_id UUID REFERENCES academic_terms(id) ON DELETE SET NULL;
+ALTER TABLE club_members ADD COLUMN IF NOT EXISTS term_id UUID REFERENCES academic_terms(id) ON DELETE SET NULL;
+ALTER TABLE activity_points ADD COLUMN IF NOT EXISTS term_id UUID REFERENCES academic_terms(id) ON DELETE SET NULL;
+ALTER TABLE house_point_ledger ADD COLUMN IF NOT EXISTS term_id UUID REFERENCES academic_terms(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_events_term ON events(term_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_club_awards_term ON club_awards(club_id, term_id);
+CREATE INDEX IF NOT EXISTS idx_club_members_term ON club_members(club_id, term_id);
+CREATE INDEX IF NOT EXISTS idx_activity_points_term ON activity_points(term_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_house_point_ledger_term ON house_point_ledger(term_id, house_id);
+
+-- Events belong to the term they start in
+CREATE OR REPLACE FUNCTION set_event_term()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.term_id := academic_term_on(NEW.start_date::date);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS set_events_term ON events;
+CREATE TRIGGER set_events_term
+    BEFORE INSERT OR UPDATE OF start_date ON events
+    FOR EACH ROW EXECUTE FUNCTION set_event_term();
+
+-- Awards belong to the term they were won in
+CREATE OR REPLACE FUNCTION set_club_award_term()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.term_id := academic_term_on(COALESCE(NEW.awarded_date, CURRENT_DATE));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS set_club_awards_term ON club_awards;
+CREATE TRIGGER set_club_awards_term
+    BEFORE INSERT OR UPDATE OF awarded_date ON club_awards
+    FOR EACH ROW EXECUTE FUNCTION set_club_award_term();
+
+-- Memberships and points belong to the term they were recorded in
+CREATE OR REPLACE FUNCTION set_current_term()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.term_id := academic_term_on(CURRENT_DATE);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS set_club_members_term ON club_members;
+CREATE TRIGGER set_club_members_term
+    BEFORE INSERT ON club_members
+    FOR EACH ROW EXECUTE FUNCTION set_current_term();
+
+DROP TRIGGER IF EXISTS set_activity_points_term ON activity_points;
+CREATE TRIGGER set_activity_points_term
+    BEFORE INSERT ON activity_points
+    FOR EACH ROW EXECUTE FUNCTION set_current_term();
+
+DROP TRIGGER IF EXISTS set_house_point_ledger_term ON house_point_ledger;
+CREATE TRIGGER set_house_point_ledger_term
+    BEFORE INSERT ON house_point_ledger
+    FOR EACH ROW EXECUTE FUNCTION set_current_term();
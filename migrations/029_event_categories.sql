@@ -0,0 +1,17 @@
+-- Migration 029: Event categories
+-- The categories clients offer when creating and filtering events. Events
+-- keep storing the category name as free text.
+
+CREATE TABLE IF NOT EXISTS event_categories (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT,
+    color_hex VARCHAR(7),
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+DROP TRIGGER IF EXISTS update_event_categories_updated_at ON event_categories;
+CREATE TRIGGER update_event_categories_updated_at BEFORE UPDATE ON event_categories
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,20 @@
+-- Migration 030: Event broadcasts
+-- Messages an event's organizers send to its registered attendees by push
+-- and email. Each row is the send log for one broadcast; rows in the last
+-- day also count towards the organizers' rate limits.
+
+CREATE TABLE IF NOT EXISTS event_broadcasts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    sent_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    segment VARCHAR(20) NOT NULL CHECK (segment IN ('all', 'paid')),
+    title VARCHAR(200) NOT NULL,
+    body TEXT NOT NULL,
+    send_email BOOLEAN NOT NULL DEFAULT TRUE,
+    recipient_count INTEGER,            -- Set once delivered
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_broadcasts_event ON event_broadcasts(event_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_event_broadcasts_sent_by ON event_broadcasts(sent_by, created_at DESC);
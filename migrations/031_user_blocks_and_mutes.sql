@@ -0,0 +1,22 @@
+-- Migration 031: User blocks and mutes
+-- A block hides the blocked user's comments from the blocker and stops them
+-- commenting on or liking the blocker's content. A mute, set by an admin,
+-- stops a user commenting anywhere until it expires.
+
+CREATE TABLE IF NOT EXISTS blocked_users (
+    blocker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_blocked_users_blocked ON blocked_users(blocked_id);
+
+CREATE TABLE IF NOT EXISTS user_mutes (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    muted_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason TEXT,
+    muted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
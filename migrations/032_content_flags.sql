@@ -0,0 +1,19 @@
+-- Migration 032: Content flags
+-- Comments and announcements the content filter found borderline. They are
+-- published, and wait here for a moderator to approve or remove them.
+
+CREATE TABLE IF NOT EXISTS content_flags (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    content_type VARCHAR(30) NOT NULL
+        CHECK (content_type IN ('post_comment', 'announcement_comment', 'announcement')),
+    content_id UUID NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE, -- Author
+    reasons TEXT[] NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'approved', 'removed')),
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_content_flags_status ON content_flags(status, created_at);
@@ -0,0 +1,10 @@
+-- Migration 033: Shadow bans
+-- A shadow banned user's posts and comments are hidden from everyone but
+-- themselves. Set and lifted by admins; both are recorded in the audit log.
+
+CREATE TABLE IF NOT EXISTS shadow_bans (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT,
+    banned_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
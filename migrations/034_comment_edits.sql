@@ -0,0 +1,19 @@
+-- Migration 034: Comment edits
+-- Users can edit their own comments for a short while after posting. Each
+-- edit keeps the content it replaced, and edited comments are marked with
+-- edited_at.
+
+ALTER TABLE post_comments ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP;
+ALTER TABLE announcement_comments ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS comment_edits (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    comment_type VARCHAR(30) NOT NULL
+        CHECK (comment_type IN ('post_comment', 'announcement_comment')),
+    comment_id UUID NOT NULL,
+    previous_content TEXT NOT NULL,
+    edited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    edited_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_edits_comment ON comment_edits(comment_type, comment_id, edited_at);
@@ -0,0 +1,62 @@
+-- Migration 035: Reactions
+-- Post and announcement likes become reactions: like, love, clap or fire,
+-- one per user. The tables keep their names, and existing rows become
+-- likes through the column default. posts.like_count now counts every
+-- reaction, and posts.reaction_counts counts them by type.
+
+ALTER TABLE post_likes ADD COLUMN IF NOT EXISTS reaction VARCHAR(10) NOT NULL DEFAULT 'like'
+    CHECK (reaction IN ('like', 'love', 'clap', 'fire'));
+ALTER TABLE announcement_likes ADD COLUMN IF NOT EXISTS reaction VARCHAR(10) NOT NULL DEFAULT 'like'
+    CHECK (reaction IN ('like', 'love', 'clap', 'fire'));
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS reaction_counts JSONB NOT NULL DEFAULT '{}';
+
+-- Recount from scratch, so rerunning this migration is harmless
+UPDATE posts p
+SET reaction_counts = COALESCE((
+    SELECT jsonb_object_agg(reaction, n)
+    FROM (SELECT reaction, COUNT(*) AS n FROM post_likes WHERE post_id = p.id GROUP BY reaction) r
+), '{}');
+
+-- add_reaction_count adds delta to one type's count, dropping types that
+-- reach zero
+CREATE OR REPLACE FUNCTION add_reaction_count(counts JSONB, reaction TEXT, delta INTEGER)
+RETURNS JSONB AS $$
+    SELECT CASE
+        WHEN COALESCE((counts->>reaction)::int, 0) + delta <= 0 THEN counts - reaction
+        ELSE jsonb_set(counts, ARRAY[reaction], to_jsonb(COALESCE((counts->>reaction)::int, 0) + delta))
+    END
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Replaces the like count trigger from migration 007, which also runs
+-- first on every migrate, so the name must stay the same
+CREATE OR REPLACE FUNCTION update_post_like_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF (TG_OP = 'INSERT') THEN
+        UPDATE posts
+        SET like_count = like_count + 1,
+            reaction_counts = add_reaction_count(reaction_counts, NEW.reaction, 1)
+        WHERE id = NEW.post_id;
+        RETURN NEW;
+    ELSIF (TG_OP = 'DELETE') THEN
+        UPDATE posts
+        SET like_count = like_count - 1,
+            reaction_counts = add_reaction_count(reaction_counts, OLD.reaction, -1)
+        WHERE id = OLD.post_id;
+        RETURN OLD;
+    ELSIF (TG_OP = 'UPDATE') THEN
+        UPDATE posts
+        SET reaction_counts = add_reaction_count(
+            add_reaction_count(reaction_counts, OLD.reaction, -1), NEW.reaction, 1)
+        WHERE id = NEW.post_id;
+        RETURN NEW;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_update_post_like_count ON post_likes;
+CREATE TRIGGER trigger_update_post_like_count
+    AFTER INSERT OR DELETE OR UPDATE OF reaction ON post_likes
+    FOR EACH ROW EXECUTE FUNCTION update_post_like_count();
@@ -0,0 +1,12 @@
+-- Migration 037: Following clubs and houses
+-- Students are notified of new events and posts from what they follow
+
+CREATE TABLE IF NOT EXISTS user_follows (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_type VARCHAR(20) NOT NULL CHECK (target_type IN ('club', 'house')),
+    target_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, target_type, target_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_follows_target ON user_follows(target_type, target_id);
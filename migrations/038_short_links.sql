@@ -0,0 +1,16 @@
+-- Migration 038: Short links to events
+-- Short URLs (/e/AB12CD) for posters and bios, each tagged with where it is
+-- shared so organizers can compare clicks per source
+
+CREATE TABLE IF NOT EXISTS short_links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(16) NOT NULL UNIQUE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    source VARCHAR(50) NOT NULL,
+    click_count INTEGER NOT NULL DEFAULT 0,
+    last_clicked_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_short_links_event ON short_links(event_id);
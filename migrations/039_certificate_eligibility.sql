@@ -0,0 +1,8 @@
+-- Migration 039: Certificate eligibility
+-- Organizers check attendees in at the door; events can require a check-in,
+-- not just a registration, for a participation certificate
+
+ALTER TABLE event_registrations ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE event_registrations ADD COLUMN IF NOT EXISTS checked_in_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS certificate_requires_check_in BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,10 @@
+-- Migration 040: Event cancellation
+-- Cancelled events keep their registrations for the record but take no new
+-- ones; paid registrations are refunded through Razorpay
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS cancellation_reason TEXT;
+
+-- Payment status gains refund_pending: queued for a refund not yet made
+ALTER TABLE event_payments ADD COLUMN IF NOT EXISTS razorpay_refund_id VARCHAR(50);
+ALTER TABLE event_payments ADD COLUMN IF NOT EXISTS refunded_at TIMESTAMP WITH TIME ZONE;
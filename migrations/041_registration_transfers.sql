@@ -0,0 +1,21 @@
+-- Migration 041: Registration transfers
+-- A registrant hands their slot (and payment) to another student: they
+-- create a transfer token, share it, and the recipient accepts it before the
+-- registration deadline. Completed transfers stay as the audit trail.
+
+CREATE TABLE IF NOT EXISTS registration_transfers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    from_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    to_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    -- Unguessable token the registrant shares with the recipient
+    token VARCHAR(64) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'cancelled')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- One open transfer per registration
+CREATE UNIQUE INDEX IF NOT EXISTS idx_registration_transfers_pending
+    ON registration_transfers(event_id, from_user_id) WHERE status = 'pending';
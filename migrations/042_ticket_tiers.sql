@@ -0,0 +1,28 @@
+-- Migration 042: Ticket tiers
+-- Paid events can sell several kinds of ticket (early bird, regular, VIP),
+-- each with its own price, quantity and sale window
+
+CREATE TABLE IF NOT EXISTS ticket_tiers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    price DECIMAL(10,2) NOT NULL CHECK (price >= 0),
+    quantity INTEGER CHECK (quantity > 0), -- NULL is unlimited
+    sold INTEGER NOT NULL DEFAULT 0,
+    sales_start TIMESTAMP WITH TIME ZONE,
+    sales_end TIMESTAMP WITH TIME ZONE,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_tiers_event ON ticket_tiers(event_id, sort_order);
+
+DROP TRIGGER IF EXISTS update_ticket_tiers_updated_at ON ticket_tiers;
+CREATE TRIGGER update_ticket_tiers_updated_at
+    BEFORE UPDATE ON ticket_tiers
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- The tier bought, on the order and on the registration it becomes
+ALTER TABLE event_payments ADD COLUMN IF NOT EXISTS tier_id UUID REFERENCES ticket_tiers(id) ON DELETE SET NULL;
+ALTER TABLE event_registrations ADD COLUMN IF NOT EXISTS tier_id UUID REFERENCES ticket_tiers(id) ON DELETE SET NULL;
@@ -0,0 +1,15 @@
+-- Migration 043: Offline payments
+-- Payments collected in cash or otherwise outside Razorpay are recorded by
+-- admins against the registration, with who collected them and the receipt
+-- issued
+
+-- Offline payments have no Razorpay order
+ALTER TABLE event_payments ALTER COLUMN razorpay_order_id DROP NOT NULL;
+
+ALTER TABLE event_payments ADD COLUMN IF NOT EXISTS method VARCHAR(20) NOT NULL DEFAULT 'razorpay'; -- razorpay, cash, offline
+ALTER TABLE event_payments ADD COLUMN IF NOT EXISTS collected_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE event_payments ADD COLUMN IF NOT EXISTS receipt_number VARCHAR(50);
+
+-- A receipt book is per event
+CREATE UNIQUE INDEX IF NOT EXISTS idx_event_payments_receipt
+    ON event_payments(event_id, receipt_number) WHERE receipt_number IS NOT NULL;
@@ -0,0 +1,50 @@
+-- Migration 045: Fundraising campaigns
+-- Clubs run donation drives towards a target; donations are paid through
+-- Razorpay and may be anonymous
+
+CREATE TABLE IF NOT EXISTS campaigns (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    club_id UUID NOT NULL REFERENCES clubs(id) ON DELETE CASCADE,
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    target_amount DECIMAL(12,2) NOT NULL CHECK (target_amount > 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'INR',
+    ends_at TIMESTAMP WITH TIME ZONE,
+    status VARCHAR(20) NOT NULL DEFAULT 'active', -- active, closed
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaigns_club ON campaigns(club_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_campaigns_status ON campaigns(status);
+
+DROP TRIGGER IF EXISTS update_campaigns_updated_at ON campaigns;
+CREATE TRIGGER update_campaigns_updated_at
+    BEFORE UPDATE ON campaigns
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS donations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    campaign_id UUID NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    amount DECIMAL(10,2) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'INR',
+    anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+    message TEXT,
+    razorpay_order_id VARCHAR(50) NOT NULL,
+    razorpay_payment_id VARCHAR(50),
+    razorpay_signature VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, paid, failed
+    paid_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_donations_campaign ON donations(campaign_id, status);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_donations_order ON donations(razorpay_order_id);
+
+DROP TRIGGER IF EXISTS update_donations_updated_at ON donations;
+CREATE TRIGGER update_donations_updated_at
+    BEFORE UPDATE ON donations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,34 @@
+-- Migration 046: Event photo galleries
+-- Organizers upload photos after an event for its attendees; a student can
+-- ask for a photo of them to be taken down
+
+CREATE TABLE IF NOT EXISTS event_photos (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    path TEXT NOT NULL,
+    thumbnail_url TEXT NOT NULL,
+    thumbnail_path TEXT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    uploaded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    removed_at TIMESTAMP WITH TIME ZONE, -- Taken down; the files are deleted
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_photos_event ON event_photos(event_id, created_at) WHERE removed_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS photo_takedown_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    photo_id UUID NOT NULL REFERENCES event_photos(id) ON DELETE CASCADE,
+    requested_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, approved, rejected
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(photo_id, requested_by)
+);
+
+CREATE INDEX IF NOT EXISTS idx_photo_takedown_requests_status ON photo_takedown_requests(status, created_at);
@@ -0,0 +1,28 @@
+-- Migration 047: Resumable upload sessions
+-- Large uploads are sent in chunks that can resume after a dropped
+-- connection. The bytes received so far are staged by the API; the session
+-- records how many.
+
+CREATE TABLE IF NOT EXISTS upload_sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    folder VARCHAR(100) NOT NULL,
+    image_type VARCHAR(20) NOT NULL DEFAULT 'banner', -- For images: thumbnail, banner, original
+    size BIGINT NOT NULL CHECK (size > 0),
+    received BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'uploading', -- uploading, completed
+    url TEXT,
+    path TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_upload_sessions_expiry ON upload_sessions(expires_at) WHERE status = 'uploading';
+
+DROP TRIGGER IF EXISTS update_upload_sessions_updated_at ON upload_sessions;
+CREATE TRIGGER update_upload_sessions_updated_at
+    BEFORE UPDATE ON upload_sessions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,10 @@
+-- Migration 048: Image moderation
+-- Posts and stories whose images fail the image check are held back from
+-- publishing and queued in content_flags for a moderator to review.
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS held_for_review BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS held_for_review BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE content_flags DROP CONSTRAINT IF EXISTS content_flags_content_type_check;
+ALTER TABLE content_flags ADD CONSTRAINT content_flags_content_type_check
+    CHECK (content_type IN ('post_comment', 'announcement_comment', 'announcement', 'post', 'story'));
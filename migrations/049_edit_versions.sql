@@ -0,0 +1,8 @@
+-- Migration 049: Edit versions
+-- Events, clubs and posts carry a version that each edit bumps. An edit may
+-- name the version it was made against; if someone else has edited since,
+-- it is refused with 409 instead of overwriting their changes.
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE clubs ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
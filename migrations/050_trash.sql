@@ -0,0 +1,10 @@
+-- Migration 050: Trash
+-- Deleted events, posts and stories stay restorable in the admin trash until
+-- the retention window passes and the cleanup job deletes them for good.
+-- Stories, which were only ever expired, gain a soft delete like the others.
+
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_events_trash ON events(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_posts_trash ON posts(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_stories_trash ON stories(deleted_at) WHERE deleted_at IS NOT NULL;
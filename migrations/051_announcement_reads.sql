@@ -0,0 +1,11 @@
+-- Migration 051: Club announcement read receipts
+-- A member has read an announcement once they've fetched the club's
+-- announcements or acknowledged it; club leads see what share of members
+-- have read each one
+
+CREATE TABLE IF NOT EXISTS club_announcement_reads (
+    announcement_id UUID NOT NULL REFERENCES club_announcements(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    read_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (announcement_id, user_id)
+);
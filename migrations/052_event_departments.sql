@@ -0,0 +1,7 @@
+-- Migration 052: Department events
+-- An event can be hosted by a department directly rather than through one of
+-- its clubs
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS department_id UUID REFERENCES departments(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_events_department ON events(department_id) WHERE department_id IS NOT NULL;
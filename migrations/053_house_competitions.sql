@@ -0,0 +1,53 @@
+-- Migration 053: Inter-house competitions
+-- A competition is contested by every house. Creating one gives each house
+-- its own linked house event to enroll in; entering the results scores each
+-- house and credits its points to the house point ledger.
+
+CREATE TABLE IF NOT EXISTS house_competitions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    event_date DATE NOT NULL,
+    venue VARCHAR(255),
+    term_id UUID REFERENCES academic_terms(id) ON DELETE SET NULL, -- The season it counts towards
+    results_recorded_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_house_competitions_term ON house_competitions(term_id, event_date);
+
+DROP TRIGGER IF EXISTS update_house_competitions_updated_at ON house_competitions;
+CREATE TRIGGER update_house_competitions_updated_at
+    BEFORE UPDATE ON house_competitions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Competitions belong to the term they are held in
+CREATE OR REPLACE FUNCTION set_house_competition_term()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.term_id := academic_term_on(NEW.event_date);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS set_house_competitions_term ON house_competitions;
+CREATE TRIGGER set_house_competitions_term
+    BEFORE INSERT OR UPDATE OF event_date ON house_competitions
+    FOR EACH ROW EXECUTE FUNCTION set_house_competition_term();
+
+-- Each house's event for a competition
+ALTER TABLE house_events ADD COLUMN IF NOT EXISTS competition_id UUID REFERENCES house_competitions(id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_house_events_competition
+    ON house_events(competition_id, house_id) WHERE competition_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS house_competition_scores (
+    competition_id UUID NOT NULL REFERENCES house_competitions(id) ON DELETE CASCADE,
+    house_id UUID NOT NULL REFERENCES houses(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL CHECK (position > 0),
+    score DECIMAL(10,2),                          -- Raw score in the competition, if it has one
+    points INTEGER NOT NULL CHECK (points >= 0),  -- House points awarded
+    PRIMARY KEY (competition_id, house_id)
+);
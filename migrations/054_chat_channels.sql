@@ -0,0 +1,66 @@
+-- Migration 054: House and club chat channels
+-- Every house and club gets a group channel its members can post in. Admins,
+-- and club leads in their club's channel, pin and remove messages; messages
+-- the content filter finds borderline go to the moderation queue. Each
+-- member's last read time gives their unread count.
+
+CREATE TABLE IF NOT EXISTS chat_channels (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    house_id UUID UNIQUE REFERENCES houses(id) ON DELETE CASCADE,
+    club_id UUID UNIQUE REFERENCES clubs(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (num_nonnulls(house_id, club_id) = 1)
+);
+
+INSERT INTO chat_channels (house_id) SELECT id FROM houses ON CONFLICT DO NOTHING;
+INSERT INTO chat_channels (club_id) SELECT id FROM clubs ON CONFLICT DO NOTHING;
+
+-- New houses and clubs get their channel as they are created
+CREATE OR REPLACE FUNCTION create_chat_channel()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_TABLE_NAME = 'houses' THEN
+        INSERT INTO chat_channels (house_id) VALUES (NEW.id) ON CONFLICT DO NOTHING;
+    ELSE
+        INSERT INTO chat_channels (club_id) VALUES (NEW.id) ON CONFLICT DO NOTHING;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS create_house_chat_channel ON houses;
+CREATE TRIGGER create_house_chat_channel
+    AFTER INSERT ON houses
+    FOR EACH ROW EXECUTE FUNCTION create_chat_channel();
+
+DROP TRIGGER IF EXISTS create_club_chat_channel ON clubs;
+CREATE TRIGGER create_club_chat_channel
+    AFTER INSERT ON clubs
+    FOR EACH ROW EXECUTE FUNCTION create_chat_channel();
+
+CREATE TABLE IF NOT EXISTS chat_channel_messages (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    channel_id UUID NOT NULL REFERENCES chat_channels(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL, -- Author
+    content TEXT NOT NULL,
+    pinned_at TIMESTAMP WITH TIME ZONE,
+    pinned_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_channel_messages_channel
+    ON chat_channel_messages(channel_id, created_at DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_chat_channel_messages_pinned
+    ON chat_channel_messages(channel_id, pinned_at DESC) WHERE pinned_at IS NOT NULL AND deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS chat_channel_reads (
+    channel_id UUID NOT NULL REFERENCES chat_channels(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_read_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (channel_id, user_id)
+);
+
+ALTER TABLE content_flags DROP CONSTRAINT IF EXISTS content_flags_content_type_check;
+ALTER TABLE content_flags ADD CONSTRAINT content_flags_content_type_check
+    CHECK (content_type IN ('post_comment', 'announcement_comment', 'announcement', 'post', 'story', 'chat_message'));
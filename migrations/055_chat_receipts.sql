@@ -0,0 +1,10 @@
+-- Migration 055: Chat delivery receipts
+-- Alongside when each member last read a channel, record up to which
+-- message their app has received it. A member's row can now exist for
+-- deliveries before they have read anything.
+
+ALTER TABLE chat_channel_reads ADD COLUMN IF NOT EXISTS last_delivered_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE chat_channel_reads ALTER COLUMN last_read_at DROP NOT NULL;
+ALTER TABLE chat_channel_reads ALTER COLUMN last_read_at DROP DEFAULT;
+
+UPDATE chat_channel_reads SET last_delivered_at = last_read_at WHERE last_delivered_at IS NULL;
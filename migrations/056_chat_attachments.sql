@@ -0,0 +1,26 @@
+-- Migration 056: Chat message attachments
+-- Images and files sent with channel messages. An attachment outlives its
+-- message and channel as a row with NULL references, so the cleanup job
+-- can still find and delete its files once the message is removed or the
+-- house or club (and with it the conversation) is deleted.
+
+CREATE TABLE IF NOT EXISTS chat_message_attachments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    message_id UUID REFERENCES chat_channel_messages(id) ON DELETE SET NULL,
+    channel_id UUID REFERENCES chat_channels(id) ON DELETE SET NULL,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('image', 'file')),
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    path VARCHAR(500) NOT NULL,
+    thumbnail_url VARCHAR(500), -- Images only
+    thumbnail_path VARCHAR(500),
+    width INTEGER,
+    height INTEGER,
+    size_bytes BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_message_attachments_message ON chat_message_attachments(message_id);
+CREATE INDEX IF NOT EXISTS idx_chat_message_attachments_orphaned
+    ON chat_message_attachments(created_at) WHERE message_id IS NULL OR channel_id IS NULL;
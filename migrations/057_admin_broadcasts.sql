@@ -0,0 +1,31 @@
+-- Migration 057: Admin broadcasts to cohorts
+-- Messages from admins to a cohort of users (all students, a department, a
+-- year, a house or an event's registrants), delivered as notifications,
+-- optionally by email and, for a house, to its chat channel. The counts
+-- record delivery once the broadcast has been fanned out.
+
+CREATE TABLE IF NOT EXISTS admin_broadcasts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    sent_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    cohort VARCHAR(30) NOT NULL
+        CHECK (cohort IN ('all_students', 'department', 'year', 'house', 'event_registrants')),
+    department VARCHAR(100), -- Matched against users.department
+    year INTEGER,
+    house_id UUID REFERENCES houses(id) ON DELETE SET NULL,
+    event_id UUID REFERENCES events(id) ON DELETE SET NULL,
+    title VARCHAR(200) NOT NULL,
+    body TEXT NOT NULL,
+    send_email BOOLEAN NOT NULL DEFAULT FALSE,
+    chat_message_id UUID REFERENCES chat_channel_messages(id) ON DELETE SET NULL,
+    recipient_count INTEGER, -- Null until delivered
+    delivered_count INTEGER,
+    failed_count INTEGER,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_broadcasts_created ON admin_broadcasts(created_at DESC);
+
+-- Read counts come from the broadcast's notifications
+CREATE INDEX IF NOT EXISTS idx_notifications_admin_broadcast
+    ON notifications((data->>'admin_broadcast_id')) WHERE data ? 'admin_broadcast_id';
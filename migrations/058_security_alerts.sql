@@ -0,0 +1,44 @@
+-- Migration 058: Suspicious activity alerts
+-- Alerts raised by the anomaly rules (many failed logins to an account,
+-- bursts of sign-ups from one IP, comment spam) for the admin security
+-- feed, and the temporary lockouts applied in response when automatic
+-- responses are enabled. Comment spam is answered with a mute instead.
+
+CREATE TABLE IF NOT EXISTS security_alerts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    rule VARCHAR(30) NOT NULL CHECK (rule IN ('failed_logins', 'signup_burst', 'comment_spam')),
+    severity VARCHAR(10) NOT NULL CHECK (severity IN ('medium', 'high')),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    ip_address VARCHAR(64),
+    event_count INTEGER NOT NULL,
+    -- What was done automatically: nothing, a lockout or a mute, and until when
+    response VARCHAR(10) NOT NULL DEFAULT 'none' CHECK (response IN ('none', 'lockout', 'mute')),
+    response_until TIMESTAMP WITH TIME ZONE,
+    acknowledged_at TIMESTAMP WITH TIME ZONE,
+    acknowledged_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_alerts_created ON security_alerts(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_security_alerts_open ON security_alerts(created_at DESC) WHERE acknowledged_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS security_lockouts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    -- login locks an account's password login; signup blocks sign-ups from an IP
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('login', 'signup')),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    ip_address VARCHAR(64),
+    locked_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    alert_id UUID REFERENCES security_alerts(id) ON DELETE SET NULL,
+    lifted_at TIMESTAMP WITH TIME ZONE,
+    lifted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((kind = 'login') = (user_id IS NOT NULL)),
+    CHECK ((kind = 'signup') = (ip_address IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_lockouts_user ON security_lockouts(user_id, locked_until) WHERE lifted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_security_lockouts_ip ON security_lockouts(ip_address, locked_until) WHERE lifted_at IS NULL;
+
+-- Failed logins and sign-ups are counted from the audit log
+CREATE INDEX IF NOT EXISTS idx_audit_log_action_ip ON audit_log(action, ip_address, created_at DESC);
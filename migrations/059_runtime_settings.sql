@@ -0,0 +1,16 @@
+-- Migration 059: Runtime settings
+-- Admins' overrides of settings that otherwise come from config (quiet
+-- hours, trash retention, suspicious activity limits, feature flags). Every
+-- API instance rereads them within a few seconds, so changes need no restart.
+
+CREATE TABLE IF NOT EXISTS runtime_settings (
+    key TEXT PRIMARY KEY,
+    value JSONB NOT NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+DROP TRIGGER IF EXISTS update_runtime_settings_updated_at ON runtime_settings;
+CREATE TRIGGER update_runtime_settings_updated_at
+    BEFORE UPDATE ON runtime_settings
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,24 @@
+-- Migration 060: Database backups
+-- One row per pg_dump taken by cmd/backup, on its schedule, when run by hand,
+-- or when an admin asks for one through the API. Admin requests wait here
+-- as 'requested' until cmd/backup picks them up. Backups beyond the
+-- retention are removed from storage and kept here as 'deleted'.
+
+CREATE TABLE IF NOT EXISTS backups (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    status VARCHAR(10) NOT NULL DEFAULT 'requested'
+        CHECK (status IN ('requested', 'running', 'completed', 'failed', 'deleted')),
+    source VARCHAR(10) NOT NULL CHECK (source IN ('schedule', 'manual', 'admin')),
+    requested_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    -- Where the dump is: the storage provider, and the path within it
+    storage_provider VARCHAR(20),
+    path TEXT,
+    size_bytes BIGINT,
+    error TEXT,
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_backups_created ON backups(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_backups_status ON backups(status, created_at);
@@ -0,0 +1,15 @@
+-- Migration 061: House roles linked to users, with permissions
+-- A role may name the user holding it, who then gets the role's
+-- permissions in the house: post_announcements lets a house captain post
+-- announcements, create_events lets them create house events. Roles
+-- without a user are display-only, as before.
+
+ALTER TABLE house_roles ADD COLUMN IF NOT EXISTS user_id UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE house_roles ADD COLUMN IF NOT EXISTS permissions TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE house_roles ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_house_roles_user ON house_roles(user_id) WHERE user_id IS NOT NULL;
+
+DROP TRIGGER IF EXISTS update_house_roles_updated_at ON house_roles;
+CREATE TRIGGER update_house_roles_updated_at BEFORE UPDATE ON house_roles
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
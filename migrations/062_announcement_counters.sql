@@ -0,0 +1,89 @@
+-- Migration 062: Announcement counters
+-- Reaction and comment counts are kept on house_announcements by triggers,
+-- as they are on posts, rather than counted for every announcement listed.
+-- comment_count counts comments that aren't deleted, so soft deleting or
+-- restoring a comment moves it.
+
+ALTER TABLE house_announcements ADD COLUMN IF NOT EXISTS like_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE house_announcements ADD COLUMN IF NOT EXISTS reaction_counts JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE house_announcements ADD COLUMN IF NOT EXISTS comment_count INTEGER NOT NULL DEFAULT 0;
+
+-- Pages of comments are read oldest first
+CREATE INDEX IF NOT EXISTS idx_announcement_comments_announcement_created
+    ON announcement_comments(announcement_id, created_at) WHERE deleted_at IS NULL;
+
+CREATE OR REPLACE FUNCTION update_announcement_like_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF (TG_OP = 'INSERT') THEN
+        UPDATE house_announcements
+        SET like_count = like_count + 1,
+            reaction_counts = add_reaction_count(reaction_counts, NEW.reaction, 1)
+        WHERE id = NEW.announcement_id;
+        RETURN NEW;
+    ELSIF (TG_OP = 'DELETE') THEN
+        UPDATE house_announcements
+        SET like_count = like_count - 1,
+            reaction_counts = add_reaction_count(reaction_counts, OLD.reaction, -1)
+        WHERE id = OLD.announcement_id;
+        RETURN OLD;
+    ELSIF (TG_OP = 'UPDATE') THEN
+        UPDATE house_announcements
+        SET reaction_counts = add_reaction_count(
+            add_reaction_count(reaction_counts, OLD.reaction, -1), NEW.reaction, 1)
+        WHERE id = NEW.announcement_id;
+        RETURN NEW;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_update_announcement_like_count ON announcement_likes;
+CREATE TRIGGER trigger_update_announcement_like_count
+    AFTER INSERT OR DELETE OR UPDATE OF reaction ON announcement_likes
+    FOR EACH ROW EXECUTE FUNCTION update_announcement_like_count();
+
+CREATE OR REPLACE FUNCTION update_announcement_comment_count()
+RETURNS TRIGGER AS $$
+DECLARE
+    delta INTEGER := 0;
+BEGIN
+    IF (TG_OP = 'INSERT') THEN
+        IF NEW.deleted_at IS NULL THEN delta := 1; END IF;
+    ELSIF (TG_OP = 'DELETE') THEN
+        IF OLD.deleted_at IS NULL THEN delta := -1; END IF;
+    ELSIF (OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL) THEN
+        delta := -1;
+    ELSIF (OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL) THEN
+        delta := 1;
+    END IF;
+    IF delta <> 0 THEN
+        UPDATE house_announcements SET comment_count = comment_count + delta
+        WHERE id = COALESCE(NEW.announcement_id, OLD.announcement_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_update_announcement_comment_count ON announcement_comments;
+CREATE TRIGGER trigger_update_announcement_comment_count
+    AFTER INSERT OR DELETE OR UPDATE OF deleted_at ON announcement_comments
+    FOR EACH ROW EXECUTE FUNCTION update_announcement_comment_count();
+
+-- Recount from scratch, so rerunning this migration is harmless and any
+-- drift is corrected. Only rows whose counts are off are written, so
+-- updated_at isn't touched on every deploy.
+UPDATE house_announcements ha
+SET like_count = c.like_count, reaction_counts = c.reaction_counts, comment_count = c.comment_count
+FROM (
+    SELECT a.id,
+        (SELECT COUNT(*) FROM announcement_likes WHERE announcement_id = a.id) AS like_count,
+        COALESCE((
+            SELECT jsonb_object_agg(reaction, n)
+            FROM (SELECT reaction, COUNT(*) AS n FROM announcement_likes WHERE announcement_id = a.id GROUP BY reaction) r
+        ), '{}') AS reaction_counts,
+        (SELECT COUNT(*) FROM announcement_comments WHERE announcement_id = a.id AND deleted_at IS NULL) AS comment_count
+    FROM house_announcements a
+) c
+WHERE ha.id = c.id
+  AND (ha.like_count, ha.reaction_counts, ha.comment_count) IS DISTINCT FROM (c.like_count, c.reaction_counts, c.comment_count);
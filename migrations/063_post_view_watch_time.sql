@@ -0,0 +1,8 @@
+-- Migration 063: Post view watch time
+-- A view may report how long the viewer watched (duration_watched_seconds,
+-- there since migration 007 but never filled) and how far through the
+-- video they got. For videos with a known length, completion is worked out
+-- from the watch time when the client doesn't send it.
+
+ALTER TABLE post_views ADD COLUMN IF NOT EXISTS completion_percent SMALLINT
+    CHECK (completion_percent BETWEEN 0 AND 100);
@@ -0,0 +1,24 @@
+-- Migration 064: Scheduled and targeted posts
+-- Posts can be saved as drafts, which stay hidden until an admin publishes
+-- or schedules them, or scheduled for publish_at, when the publisher job
+-- publishes them and notifies their followers and audience. Feeds are
+-- ordered by published_at, so a scheduled post appears when it goes out
+-- rather than when it was written.
+--
+-- A post may be targeted at a department, a year and a house; only users
+-- matching every one given see it. Untargeted posts are for everyone.
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS status VARCHAR(10) NOT NULL DEFAULT 'published'
+    CHECK (status IN ('draft', 'scheduled', 'published'));
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS published_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS audience_department VARCHAR(100);
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS audience_year INTEGER;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS audience_house_id UUID REFERENCES houses(id) ON DELETE SET NULL;
+
+-- Posts from before scheduling went out when they were created
+UPDATE posts SET published_at = created_at WHERE status = 'published' AND published_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_posts_published_at ON posts(published_at DESC) WHERE status = 'published';
+CREATE INDEX IF NOT EXISTS idx_posts_publish_due ON posts(publish_at) WHERE status = 'scheduled';
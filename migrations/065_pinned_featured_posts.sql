@@ -0,0 +1,11 @@
+-- Migration 065: Pinned and featured posts
+-- A pinned post stays at the top of the feed, most recently pinned first,
+-- until it is unpinned. Featured posts make up the carousel, in the order
+-- admins give them: featured_position numbers them 0, 1, 2... and is NULL
+-- for posts that aren't featured.
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS pinned_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS featured_position INTEGER CHECK (featured_position >= 0);
+
+CREATE INDEX IF NOT EXISTS idx_posts_pinned ON posts(pinned_at DESC) WHERE pinned_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_posts_featured ON posts(featured_position) WHERE featured_position IS NOT NULL;
@@ -0,0 +1,25 @@
+-- Migration 066: Featured events
+-- is_featured has been on events since migration 002 but nothing set it.
+-- Featured events now make up the home screen carousel, in the order admins
+-- give them: featured_position numbers them 0, 1, 2... and is NULL for
+-- events that aren't featured. An event may be featured only from
+-- featured_from and until featured_until. is_featured is kept for clients
+-- reading it and is set exactly when featured_position is.
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS featured_position INTEGER CHECK (featured_position >= 0);
+ALTER TABLE events ADD COLUMN IF NOT EXISTS featured_from TIMESTAMP WITH TIME ZONE;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS featured_until TIMESTAMP WITH TIME ZONE;
+
+-- Events flagged by hand before now go after any already ordered, soonest
+-- first
+UPDATE events e
+SET featured_position = o.pos
+FROM (
+    SELECT id, (SELECT COUNT(*) FROM events WHERE featured_position IS NOT NULL)
+        + ROW_NUMBER() OVER (ORDER BY start_date) - 1 AS pos
+    FROM events
+    WHERE is_featured AND featured_position IS NULL
+) o
+WHERE e.id = o.id;
+
+CREATE INDEX IF NOT EXISTS idx_events_featured ON events(featured_position) WHERE featured_position IS NOT NULL;
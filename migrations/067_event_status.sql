@@ -0,0 +1,12 @@
+-- Migration 067: Event status
+-- Events now move from upcoming to ongoing at their start and to completed
+-- at their end, set every minute by the event status job, which notifies
+-- their registrants. Events that started over an hour ago are brought up
+-- to date here instead, so their registrants aren't told of starts and ends
+-- long past; the job leaves those quiet too. Cancelled events are left be.
+
+UPDATE events
+SET status = CASE WHEN end_date <= NOW() - INTERVAL '1 hour' THEN 'completed' ELSE 'ongoing' END
+WHERE deleted_at IS NULL AND status IN ('upcoming', 'ongoing')
+  AND start_date <= NOW() - INTERVAL '1 hour'
+  AND status <> CASE WHEN end_date <= NOW() - INTERVAL '1 hour' THEN 'completed' ELSE 'ongoing' END;
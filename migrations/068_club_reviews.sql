@@ -0,0 +1,53 @@
+-- Migration 068: Club reviews
+-- Members of a club, and those registered for one of its events once it
+-- has started, can review it with one to five stars and some text. Each
+-- user has one review of a club, which they can edit or delete. A review
+-- the content filter flags is held back until a moderator approves it.
+--
+-- clubs.rating, there since migration 002 but never fed, is kept by a
+-- trigger as the average of the club's published reviews, or 0 without
+-- any.
+
+CREATE TABLE IF NOT EXISTS club_reviews (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    club_id UUID NOT NULL REFERENCES clubs(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    body TEXT NOT NULL DEFAULT '',
+    held_for_review BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- A review a moderator removed doesn't stop its author writing another
+CREATE UNIQUE INDEX IF NOT EXISTS idx_club_reviews_one_per_user
+    ON club_reviews(club_id, user_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_club_reviews_club_created
+    ON club_reviews(club_id, created_at DESC) WHERE deleted_at IS NULL AND NOT held_for_review;
+
+DROP TRIGGER IF EXISTS update_club_reviews_updated_at ON club_reviews;
+CREATE TRIGGER update_club_reviews_updated_at BEFORE UPDATE ON club_reviews
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE OR REPLACE FUNCTION update_club_rating()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE clubs
+    SET rating = COALESCE((
+        SELECT ROUND(AVG(rating), 2) FROM club_reviews
+        WHERE club_id = clubs.id AND deleted_at IS NULL AND NOT held_for_review
+    ), 0)
+    WHERE id = COALESCE(NEW.club_id, OLD.club_id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_update_club_rating ON club_reviews;
+CREATE TRIGGER trigger_update_club_rating
+    AFTER INSERT OR DELETE OR UPDATE OF rating, held_for_review, deleted_at ON club_reviews
+    FOR EACH ROW EXECUTE FUNCTION update_club_rating();
+
+ALTER TABLE content_flags DROP CONSTRAINT IF EXISTS content_flags_content_type_check;
+ALTER TABLE content_flags ADD CONSTRAINT content_flags_content_type_check
+    CHECK (content_type IN ('post_comment', 'announcement_comment', 'announcement', 'post', 'story', 'chat_message', 'club_review'));
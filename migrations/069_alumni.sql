@@ -0,0 +1,19 @@
+-- Migration 069: Alumni
+-- Students become alumni once their graduation year's academic year ends:
+-- after the term marked ends_academic_year that ends in graduation_year,
+-- or, when no term is marked, once that year is over. Alumni can follow
+-- events and register for those open to them, but belong to no house or
+-- club.
+-- An event's alumni_access is 'none' for students' events alumni may only
+-- view, 'open' for events alumni may register for alongside students, and
+-- 'only' for alumni events, which only alumni and staff see.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS graduation_year INTEGER;
+
+ALTER TABLE academic_terms ADD COLUMN IF NOT EXISTS ends_academic_year BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS alumni_access VARCHAR(10) NOT NULL DEFAULT 'none'
+    CHECK (alumni_access IN ('none', 'open', 'only'));
+
+CREATE INDEX IF NOT EXISTS idx_users_graduation_year ON users(graduation_year)
+    WHERE role = 'student' AND graduation_year IS NOT NULL AND deleted_at IS NULL;
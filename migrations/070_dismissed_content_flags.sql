@@ -0,0 +1,8 @@
+-- Migration 070: Dismissed content flags
+-- A flag is dismissed, without a moderator, when its author rewrites the
+-- flagged content: what it flagged is gone, and any new text is screened
+-- afresh.
+
+ALTER TABLE content_flags DROP CONSTRAINT IF EXISTS content_flags_status_check;
+ALTER TABLE content_flags ADD CONSTRAINT content_flags_status_check
+    CHECK (status IN ('pending', 'approved', 'removed', 'dismissed'));
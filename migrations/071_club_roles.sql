@@ -0,0 +1,14 @@
+-- Migration 071: Club roles
+-- Club roles come from a fixed set, since leads and the faculty advisor
+-- manage the club. Other roles become plain membership, keeping the old
+-- title as the member's position if they had none.
+
+UPDATE club_members
+SET position = COALESCE(position, role), role = 'member'
+WHERE role IS NULL OR role NOT IN ('member', 'president', 'vice_president', 'secretary', 'advisor');
+
+ALTER TABLE club_members ALTER COLUMN role SET NOT NULL;
+
+ALTER TABLE club_members DROP CONSTRAINT IF EXISTS club_members_role_check;
+ALTER TABLE club_members ADD CONSTRAINT club_members_role_check
+    CHECK (role IN ('member', 'president', 'vice_president', 'secretary', 'advisor'));
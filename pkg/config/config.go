@@ -2,16 +2,27 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/yourusername/college-event-backend/pkg/database"
 )
 
 type Config struct {
 	// Server
 	Port string
 	Env  string
+	// Serves Go's profiler at /debug/pprof to admins (off by default in
+	// production)
+	DebugEndpoints bool
+	// Public URL of the API, used for links in emails
+	PublicBaseURL string
+	// URL scheme the mobile app opens, for links from share pages
+	AppLinkScheme string
 
 	// Database
 	DBHost     string
@@ -20,11 +31,24 @@ type Config struct {
 	DBPassword string
 	DBName     string
 	DBSSLMode  string
+	// Comma-separated read replica DSNs; reads use the primary when empty
+	DBReplicaDSNs string
+	// Statements slower than this are logged; 0 disables
+	DBSlowQueryMs int
+
+	// Connection pool (applied to the primary and each replica)
+	DBMaxOpenConns           int
+	DBMaxIdleConns           int
+	DBConnMaxLifetimeMinutes int
+	DBConnMaxIdleTimeMinutes int
 
 	// Redis
 	RedisHost     string
 	RedisPort     string
 	RedisPassword string
+	// RedisRequired fails startup when Redis can't be reached, rather than
+	// relaying realtime events to this instance's clients only
+	RedisRequired bool
 
 	// JWT
 	JWTSecret              string
@@ -32,7 +56,7 @@ type Config struct {
 	RefreshTokenExpiryDays int
 
 	// Storage
-	StorageProvider string
+	StorageProvider string // local or gcs
 	GCSBucketName   string
 	GCSProjectID    string
 	GCSCdnURL       string // Optional CDN URL for image delivery
@@ -41,15 +65,166 @@ type Config struct {
 	AWSAccessKeyID  string
 	AWSSecretKey    string
 
+	// Database backups taken by cmd/backup: when (cron), how many to keep,
+	// and where. They go to the storage provider, but never to the public
+	// uploads bucket: gcs needs a private BackupGCSBucket, local writes to
+	// BackupDir.
+	BackupSchedule  string
+	BackupRetention int
+	BackupGCSBucket string
+	BackupDir       string
+
+	// Staging refreshes (cmd/refresh-staging), run with staging's config:
+	// the production database to copy, as a postgres:// URL, the key its
+	// personal data is scrambled with, and the password every copied
+	// account gets
+	RefreshSourceURL   string
+	RefreshScrambleKey string
+	RefreshPassword    string
+
+	// Razorpay API keys, needed once any event takes payments. The payment
+	// handlers read them from the environment too.
+	RazorpayKeyID     string
+	RazorpayKeySecret string
+
 	// CORS
 	CORSAllowedOrigins string
 
+	// Networks (comma-separated CIDRs) the admin API may be reached from,
+	// or empty for anywhere
+	AdminAllowedCIDRs string
+
+	// Proxies (IPs, CIDRs, or "loopback", "private" or "google" for their
+	// address ranges) whose X-Forwarded-For gives the client's address, or
+	// empty to take the peer's address. TrustedPlatform instead takes it
+	// from the header set by appengine, cloudflare or flyio.
+	TrustedProxies  string
+	TrustedPlatform string
+
+	// Cookie sessions with CSRF tokens for the web admin panel, alongside
+	// bearer tokens: the cookies' domain, whether they need HTTPS, and their
+	// SameSite mode (lax, strict or none)
+	SessionCookies        bool
+	SessionCookieDomain   string
+	SessionCookieSecure   bool
+	SessionCookieSameSite string
+
 	// Rate Limiting
 	RateLimitRequestsPerMinute int
 
 	// Admin
 	InitialAdminEmail    string
 	InitialAdminPassword string
+	// BootstrapFile is a YAML file of default houses, departments and event
+	// categories seeded on first run. Empty seeds nothing.
+	BootstrapFile string
+
+	// Background jobs
+	JobWorkers             int
+	JobPollIntervalSeconds int
+
+	// Email (SMTP). Emails are only logged when SMTPHost is empty.
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	// Weekly email digest
+	DigestEnabled bool
+
+	// Campus-wide notification quiet hours ("HH:MM", campus time). Both
+	// empty disables them; users can override with their own.
+	QuietHoursStart string
+	QuietHoursEnd   string
+
+	// Percentages of max_participants at which event creators are notified,
+	// comma-separated. Empty disables the alerts.
+	CapacityAlertThresholds string
+
+	// Content filter for comments and announcements: comma-separated words
+	// that are rejected and words that get content flagged for review, and
+	// how many links content may have before it is flagged (-1 disables)
+	ContentBannedWords  string
+	ContentFlaggedWords string
+	ContentMaxLinks     int
+
+	// Days soft-deleted events, posts and stories stay in the admin trash
+	// before they and their media are deleted for good
+	TrashRetentionDays int
+
+	// Suspicious activity rules: failed logins to one account in 15
+	// minutes, sign-ups from one IP in an hour and comments by one user in 5
+	// minutes that raise an admin alert (0 disables a rule), and whether
+	// alerts also lock the account, block the IP or mute the commenter
+	SecurityFailedLoginLimit int
+	SecuritySignupLimit      int
+	SecurityCommentLimit     int
+	SecurityAutoRespond      bool
+
+	// Largest request bodies accepted, in bytes: JSON APIs, single file
+	// uploads, bulk uploads, and resumable upload chunks
+	MaxJSONBodyBytes       int
+	MaxUploadBodyBytes     int
+	MaxBulkUploadBodyBytes int
+	MaxVideoBodyBytes      int
+
+	// Where JSON bodies with unknown fields are rejected: admin, all or off
+	StrictJSON string
+
+	// Image check for post and story images: "vision" (Cloud Vision
+	// SafeSearch, with VisionAPIKey), "hook" (a self-hosted model at
+	// ImageModerationHookURL), or empty to publish images unchecked
+	ImageModerationProvider string
+	VisionAPIKey            string
+	ImageModerationHookURL  string
+
+	// Holds the API in maintenance mode regardless of the admin toggle
+	MaintenanceMode bool
+
+	// Serves the read-only GraphQL API at /api/v1/graphql
+	GraphQLEnabled bool
+
+	// Internal gRPC API for other campus services (disabled when GRPCPort
+	// is empty)
+	GRPCPort      string
+	GRPCAuthToken string
+
+	// Native TLS, for deployments without a load balancer in front: a
+	// certificate and key, or certificates from Let's Encrypt for the
+	// comma-separated TLSAutocertDomains, kept in TLSAutocertCacheDir.
+	// HTTPRedirectPort also serves plain HTTP there, redirecting to HTTPS
+	// and answering ACME challenges. HTTP2Cleartext serves HTTP/2 without
+	// TLS (h2c), for load balancers that speak it to the backend.
+	TLSCertFile         string
+	TLSKeyFile          string
+	TLSAutocertDomains  string
+	TLSAutocertEmail    string
+	TLSAutocertCacheDir string
+	HTTPRedirectPort    string
+	HTTP2Cleartext      bool
+
+	// Comma-separated login methods to enable: password, google, ldap, oidc
+	AuthProviders string
+
+	// LDAP / Active Directory login (when AuthProviders includes ldap)
+	LDAPURL            string
+	LDAPStartTLS       bool   // upgrade ldap:// connections with StartTLS
+	LDAPAllowInsecure  bool   // allow plaintext binds over ldap://, for local test directories
+	LDAPBindDNPatterns string // semicolon-separated, %s is the username
+	LDAPBaseDN         string
+	LDAPUserAttr       string
+	LDAPEmailDomain    string
+	LDAPGroupRoles     string // semicolon-separated role:group pairs
+
+	// Institutional SSO through an OpenID Connect provider (when
+	// AuthProviders includes oidc)
+	OIDCIssuerURL    string
+	OIDCClientID     string
+	OIDCClientSecret string
+	OIDCRoleClaim    string
+	OIDCRoleMap      string // semicolon-separated role:value pairs
+	OIDCTrustEmail   bool
 }
 
 func Load() (*Config, error) {
@@ -65,9 +240,16 @@ func Load() (*Config, error) {
 		DBPassword:                 getEnv("DB_PASSWORD", ""),
 		DBName:                     getEnv("DB_NAME", "college_events"),
 		DBSSLMode:                  getEnv("DB_SSL_MODE", "disable"),
-		RedisHost:                  getEnv("REDIS_HOST", "localhost"),
+		DBReplicaDSNs:              getEnv("DB_REPLICA_DSNS", ""),
+		DBSlowQueryMs:              getEnvAsInt("DB_SLOW_QUERY_MS", 200),
+		DBMaxOpenConns:             getEnvAsInt("DB_MAX_OPEN_CONNS", 20),
+		DBMaxIdleConns:             getEnvAsInt("DB_MAX_IDLE_CONNS", 10),
+		DBConnMaxLifetimeMinutes:   getEnvAsInt("DB_CONN_MAX_LIFETIME_MINUTES", 60),
+		DBConnMaxIdleTimeMinutes:   getEnvAsInt("DB_CONN_MAX_IDLE_TIME_MINUTES", 5),
+		RedisHost:                  lookupEnv("REDIS_HOST", "localhost"), // Empty disables Redis
 		RedisPort:                  getEnv("REDIS_PORT", "6379"),
 		RedisPassword:              getEnv("REDIS_PASSWORD", ""),
+		RedisRequired:              getEnv("REDIS_REQUIRED", "false") == "true",
 		JWTSecret:                  getEnv("JWT_SECRET", ""),
 		JWTExpiryHours:             getEnvAsInt("JWT_EXPIRY_HOURS", 24),
 		RefreshTokenExpiryDays:     getEnvAsInt("REFRESH_TOKEN_EXPIRY_DAYS", 30),
@@ -79,11 +261,84 @@ func Load() (*Config, error) {
 		AWSBucketName:              getEnv("AWS_BUCKET_NAME", ""),
 		AWSAccessKeyID:             getEnv("AWS_ACCESS_KEY_ID", ""),
 		AWSSecretKey:               getEnv("AWS_SECRET_ACCESS_KEY", ""),
+		BackupSchedule:             getEnv("BACKUP_SCHEDULE", "0 1 * * *"),
+		BackupRetention:            getEnvAsInt("BACKUP_RETENTION", 14),
+		BackupGCSBucket:            getEnv("BACKUP_GCS_BUCKET", ""),
+		BackupDir:                  getEnv("BACKUP_DIR", "./backups"),
+		RefreshSourceURL:           getEnv("REFRESH_SOURCE_URL", ""),
+		RefreshScrambleKey:         getEnv("REFRESH_SCRAMBLE_KEY", ""),
+		RefreshPassword:            getEnv("REFRESH_PASSWORD", ""),
+		RazorpayKeyID:              getEnv("RAZORPAY_KEY_ID", ""),
+		RazorpayKeySecret:          getEnv("RAZORPAY_KEY_SECRET", ""),
 		CORSAllowedOrigins:         getEnv("CORS_ALLOWED_ORIGINS", "*"),
+		AdminAllowedCIDRs:          getEnv("ADMIN_ALLOWED_CIDRS", ""),
+		TrustedProxies:             getEnv("TRUSTED_PROXIES", ""),
+		TrustedPlatform:            getEnv("TRUSTED_PLATFORM", ""),
+		SessionCookies:             getEnv("SESSION_COOKIES", "false") == "true",
+		SessionCookieDomain:        getEnv("SESSION_COOKIE_DOMAIN", ""),
+		SessionCookieSameSite:      getEnv("SESSION_COOKIE_SAMESITE", "lax"),
 		RateLimitRequestsPerMinute: getEnvAsInt("RATE_LIMIT_REQUESTS_PER_MINUTE", 100),
 		InitialAdminEmail:          getEnv("INITIAL_ADMIN_EMAIL", "admin@college.edu"),
 		InitialAdminPassword:       getEnv("INITIAL_ADMIN_PASSWORD", ""),
+		BootstrapFile:              getEnv("BOOTSTRAP_FILE", ""),
+		JobWorkers:                 getEnvAsInt("JOB_WORKERS", 4),
+		JobPollIntervalSeconds:     getEnvAsInt("JOB_POLL_INTERVAL_SECONDS", 2),
+		SMTPHost:                   getEnv("SMTP_HOST", ""),
+		SMTPPort:                   getEnv("SMTP_PORT", "587"),
+		SMTPUsername:               getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:               getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:                   getEnv("SMTP_FROM", "noreply@college.edu"),
+		DigestEnabled:              getEnv("DIGEST_ENABLED", "true") == "true",
+		QuietHoursStart:            getEnv("QUIET_HOURS_START", "23:00"),
+		QuietHoursEnd:              getEnv("QUIET_HOURS_END", "07:00"),
+		CapacityAlertThresholds:    getEnv("CAPACITY_ALERT_THRESHOLDS", "50,90,100"),
+		ContentBannedWords:         getEnv("CONTENT_BANNED_WORDS", ""),
+		ContentFlaggedWords:        getEnv("CONTENT_FLAGGED_WORDS", ""),
+		ContentMaxLinks:            getEnvAsInt("CONTENT_MAX_LINKS", 2),
+		TrashRetentionDays:         getEnvAsInt("TRASH_RETENTION_DAYS", 30),
+		SecurityFailedLoginLimit:   getEnvAsInt("SECURITY_FAILED_LOGIN_LIMIT", 5),
+		SecuritySignupLimit:        getEnvAsInt("SECURITY_SIGNUP_LIMIT", 5),
+		SecurityCommentLimit:       getEnvAsInt("SECURITY_COMMENT_LIMIT", 10),
+		SecurityAutoRespond:        getEnv("SECURITY_AUTO_RESPOND", "false") == "true",
+		MaxJSONBodyBytes:           getEnvAsInt("MAX_JSON_BODY_BYTES", 1<<20),
+		MaxUploadBodyBytes:         getEnvAsInt("MAX_UPLOAD_BODY_BYTES", 10<<20),
+		MaxBulkUploadBodyBytes:     getEnvAsInt("MAX_BULK_UPLOAD_BODY_BYTES", 250<<20),
+		MaxVideoBodyBytes:          getEnvAsInt("MAX_VIDEO_BODY_BYTES", 2<<30),
+		StrictJSON:                 getEnv("STRICT_JSON", "admin"),
+		ImageModerationProvider:    getEnv("IMAGE_MODERATION_PROVIDER", ""),
+		VisionAPIKey:               getEnv("VISION_API_KEY", ""),
+		ImageModerationHookURL:     getEnv("IMAGE_MODERATION_HOOK_URL", ""),
+		MaintenanceMode:            getEnv("MAINTENANCE_MODE", "false") == "true",
+		GraphQLEnabled:             getEnv("GRAPHQL_ENABLED", "false") == "true",
+		GRPCPort:                   getEnv("GRPC_PORT", ""),
+		GRPCAuthToken:              getEnv("GRPC_AUTH_TOKEN", ""),
+		TLSCertFile:                getEnv("TLS_CERT_FILE", ""),
+		TLSKeyFile:                 getEnv("TLS_KEY_FILE", ""),
+		TLSAutocertDomains:         getEnv("TLS_AUTOCERT_DOMAINS", ""),
+		TLSAutocertEmail:           getEnv("TLS_AUTOCERT_EMAIL", ""),
+		TLSAutocertCacheDir:        getEnv("TLS_AUTOCERT_CACHE_DIR", "./certs"),
+		HTTPRedirectPort:           getEnv("HTTP_REDIRECT_PORT", ""),
+		HTTP2Cleartext:             getEnv("HTTP2_CLEARTEXT", "false") == "true",
+		AuthProviders:              getEnv("AUTH_PROVIDERS", "password,google"),
+		LDAPURL:                    getEnv("LDAP_URL", ""),
+		LDAPStartTLS:               getEnv("LDAP_START_TLS", "false") == "true",
+		LDAPAllowInsecure:          getEnv("LDAP_ALLOW_INSECURE", "false") == "true",
+		LDAPBindDNPatterns:         getEnv("LDAP_BIND_DN_PATTERNS", ""),
+		LDAPBaseDN:                 getEnv("LDAP_BASE_DN", ""),
+		LDAPUserAttr:               getEnv("LDAP_USER_ATTR", "uid"),
+		LDAPEmailDomain:            getEnv("LDAP_EMAIL_DOMAIN", ""),
+		LDAPGroupRoles:             getEnv("LDAP_GROUP_ROLES", ""),
+		OIDCIssuerURL:              getEnv("OIDC_ISSUER_URL", ""),
+		OIDCClientID:               getEnv("OIDC_CLIENT_ID", ""),
+		OIDCClientSecret:           getEnv("OIDC_CLIENT_SECRET", ""),
+		OIDCRoleClaim:              getEnv("OIDC_ROLE_CLAIM", "groups"),
+		OIDCRoleMap:                getEnv("OIDC_ROLE_MAP", ""),
+		OIDCTrustEmail:             getEnv("OIDC_TRUST_EMAIL", "false") == "true",
 	}
+	cfg.PublicBaseURL = getEnv("PUBLIC_BASE_URL", "http://localhost:"+cfg.Port)
+	cfg.AppLinkScheme = getEnv("APP_LINK_SCHEME", "collegeevents")
+	cfg.SessionCookieSecure = getEnv("SESSION_COOKIE_SECURE", strconv.FormatBool(cfg.Env == "production")) == "true"
+	cfg.DebugEndpoints = getEnv("DEBUG_ENDPOINTS", strconv.FormatBool(cfg.Env != "production")) == "true"
 
 	if err := cfg.Validate(); err != nil {
 		return nil, err
@@ -99,9 +354,176 @@ func (c *Config) Validate() error {
 	if c.DBPassword == "" && c.Env == "production" {
 		return fmt.Errorf("DB_PASSWORD is required in production")
 	}
+	if c.SessionCookies && strings.EqualFold(c.SessionCookieSameSite, "none") && !c.SessionCookieSecure {
+		return fmt.Errorf("SESSION_COOKIE_SAMESITE=none requires SESSION_COOKIE_SECURE=true")
+	}
+	if c.DBMaxOpenConns < 1 {
+		return fmt.Errorf("DB_MAX_OPEN_CONNS must be at least 1")
+	}
+	if c.DBMaxIdleConns > c.DBMaxOpenConns {
+		return fmt.Errorf("DB_MAX_IDLE_CONNS (%d) cannot exceed DB_MAX_OPEN_CONNS (%d)", c.DBMaxIdleConns, c.DBMaxOpenConns)
+	}
+	if c.GRPCPort != "" && len(c.GRPCAuthToken) < 32 {
+		return fmt.Errorf("GRPC_AUTH_TOKEN of at least 32 characters is required when GRPC_PORT is set")
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if c.TLSCertFile != "" && c.TLSAutocertDomains != "" {
+		return fmt.Errorf("set either TLS_CERT_FILE or TLS_AUTOCERT_DOMAINS, not both")
+	}
+	if c.HTTPRedirectPort != "" && !c.TLSEnabled() {
+		return fmt.Errorf("HTTP_REDIRECT_PORT requires TLS")
+	}
+	switch c.StorageProvider {
+	case "local":
+	case "gcs":
+		if c.GCSBucketName == "" {
+			return fmt.Errorf("GCS_BUCKET_NAME is required when STORAGE_PROVIDER=gcs")
+		}
+	default:
+		return fmt.Errorf("STORAGE_PROVIDER must be local or gcs, not %q", c.StorageProvider)
+	}
+	if c.BackupRetention < 1 {
+		return fmt.Errorf("BACKUP_RETENTION must be at least 1")
+	}
+	if err := validateCORSOrigins(c.CORSAllowedOrigins); err != nil {
+		return fmt.Errorf("CORS_ALLOWED_ORIGINS: %w", err)
+	}
+	if (c.RazorpayKeyID == "") != (c.RazorpayKeySecret == "") {
+		return fmt.Errorf("RAZORPAY_KEY_ID and RAZORPAY_KEY_SECRET must be set together")
+	}
+	if c.RedisRequired && c.RedisHost == "" {
+		return fmt.Errorf("REDIS_HOST is required when REDIS_REQUIRED=true")
+	}
+	if c.TrashRetentionDays < 1 {
+		return fmt.Errorf("TRASH_RETENTION_DAYS must be at least 1")
+	}
+	switch c.StrictJSON {
+	case "admin", "all", "off":
+	default:
+		return fmt.Errorf("STRICT_JSON must be admin, all or off")
+	}
+	providers := c.GetAuthProviders()
+	if len(providers) == 0 {
+		return fmt.Errorf("AUTH_PROVIDERS must enable at least one login method")
+	}
+	for _, p := range providers {
+		switch p {
+		case "password", "google":
+		case "ldap":
+			if c.LDAPURL == "" || c.LDAPBindDNPatterns == "" {
+				return fmt.Errorf("LDAP_URL and LDAP_BIND_DN_PATTERNS are required for ldap login")
+			}
+		case "oidc":
+			if c.OIDCIssuerURL == "" || c.OIDCClientID == "" {
+				return fmt.Errorf("OIDC_ISSUER_URL and OIDC_CLIENT_ID are required for oidc login")
+			}
+		default:
+			return fmt.Errorf("unknown auth provider %q in AUTH_PROVIDERS", p)
+		}
+	}
+	return nil
+}
+
+// validateCORSOrigins checks that origins is "*" or a comma-separated list
+// of origins such as https://admin.college.edu, without paths
+func validateCORSOrigins(origins string) error {
+	if origins == "*" {
+		return nil
+	}
+	for _, o := range strings.Split(origins, ",") {
+		o = strings.TrimSpace(o)
+		u, err := url.Parse(o)
+		switch {
+		case o == "*":
+			return fmt.Errorf("* must be used alone, to allow any origin")
+		case err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "":
+			return fmt.Errorf("%q is not an origin like https://admin.college.edu", o)
+		case u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil:
+			return fmt.Errorf("%q must be just scheme, host and port, as browsers send it", o)
+		}
+	}
 	return nil
 }
 
+// GinMode returns the gin mode for the environment: release (quiet, no
+// route dump) in production and staging, test under test, debug otherwise.
+// GIN_MODE, if set, wins.
+func (c *Config) GinMode() string {
+	mode := "debug"
+	switch c.Env {
+	case "production", "staging":
+		mode = "release"
+	case "test":
+		mode = "test"
+	}
+	return getEnv("GIN_MODE", mode)
+}
+
+// GetAuthProviders returns the enabled login methods
+func (c *Config) GetAuthProviders() []string {
+	var providers []string
+	for _, p := range strings.Split(c.AuthProviders, ",") {
+		if p = strings.TrimSpace(strings.ToLower(p)); p != "" {
+			providers = append(providers, p)
+		}
+	}
+	return providers
+}
+
+// TLSEnabled reports whether the server terminates TLS itself
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCertFile != "" || c.TLSAutocertDomains != ""
+}
+
+// GetTLSAutocertDomains returns the domains to get certificates for
+func (c *Config) GetTLSAutocertDomains() []string {
+	var domains []string
+	for _, d := range strings.Split(c.TLSAutocertDomains, ",") {
+		if d = strings.TrimSpace(strings.ToLower(d)); d != "" {
+			domains = append(domains, d)
+		}
+	}
+	return domains
+}
+
+// trustedProxyRanges are the address ranges of kinds of proxies
+var trustedProxyRanges = map[string][]string{
+	// A proxy on the same host, such as NGINX
+	"loopback": {"127.0.0.0/8", "::1/128"},
+	// Proxies on the internal network
+	"private": {"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7"},
+	// Google Cloud load balancer front ends
+	"google": {"35.191.0.0/16", "130.211.0.0/22"},
+}
+
+// GetTrustedProxies returns the trusted proxies' IPs and CIDRs, with named
+// ranges expanded
+func (c *Config) GetTrustedProxies() []string {
+	var proxies []string
+	for _, p := range strings.Split(c.TrustedProxies, ",") {
+		p = strings.TrimSpace(p)
+		if ranges, ok := trustedProxyRanges[strings.ToLower(p)]; ok {
+			proxies = append(proxies, ranges...)
+		} else if p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	return proxies
+}
+
+// GetLDAPBindDNPatterns returns the LDAP bind DN patterns in order
+func (c *Config) GetLDAPBindDNPatterns() []string {
+	var patterns []string
+	for _, p := range strings.Split(c.LDAPBindDNPatterns, ";") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
 func (c *Config) GetDatabaseDSN() string {
 	return fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
@@ -109,6 +531,16 @@ func (c *Config) GetDatabaseDSN() string {
 	)
 }
 
+// GetDatabasePoolConfig returns the connection pool settings
+func (c *Config) GetDatabasePoolConfig() database.PoolConfig {
+	return database.PoolConfig{
+		MaxOpenConns:    c.DBMaxOpenConns,
+		MaxIdleConns:    c.DBMaxIdleConns,
+		ConnMaxLifetime: time.Duration(c.DBConnMaxLifetimeMinutes) * time.Minute,
+		ConnMaxIdleTime: time.Duration(c.DBConnMaxIdleTimeMinutes) * time.Minute,
+	}
+}
+
 func (c *Config) GetRedisAddr() string {
 	return fmt.Sprintf("%s:%s", c.RedisHost, c.RedisPort)
 }
@@ -120,6 +552,14 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// lookupEnv is getEnv for settings that may be set to empty on purpose
+func lookupEnv(key, defaultValue string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
 func getEnvAsInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
 		if intVal, err := strconv.Atoi(value); err == nil {
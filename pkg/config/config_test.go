@@ -0,0 +1,49 @@
+package config
+
+import "testing"
+
+func TestValidateCORSOrigins(t *testing.T) {
+	valid := []string{
+		"*",
+		"https://admin.college.edu",
+		"http://localhost:3000, http://localhost:8081",
+	}
+	for _, origins := range valid {
+		if err := validateCORSOrigins(origins); err != nil {
+			t.Errorf("validateCORSOrigins(%q): %v", origins, err)
+		}
+	}
+
+	invalid := []string{
+		"admin.college.edu",
+		"https://admin.college.edu/",
+		"https://admin.college.edu/panel",
+		"ftp://files.college.edu",
+		"https://admin.college.edu,*",
+		"https://admin.college.edu,",
+	}
+	for _, origins := range invalid {
+		if err := validateCORSOrigins(origins); err == nil {
+			t.Errorf("validateCORSOrigins(%q) succeeded, want an error", origins)
+		}
+	}
+}
+
+func TestGinMode(t *testing.T) {
+	t.Setenv("GIN_MODE", "")
+	for env, want := range map[string]string{
+		"production":  "release",
+		"staging":     "release",
+		"test":        "test",
+		"development": "debug",
+	} {
+		if got := (&Config{Env: env}).GinMode(); got != want {
+			t.Errorf("GinMode() for %s = %q, want %q", env, got, want)
+		}
+	}
+
+	t.Setenv("GIN_MODE", "debug")
+	if got := (&Config{Env: "production"}).GinMode(); got != "debug" {
+		t.Errorf("GinMode() with GIN_MODE=debug = %q, want debug", got)
+	}
+}
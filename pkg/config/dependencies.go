@@ -0,0 +1,29 @@
+package config
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// CheckDependencies checks the settings that depend on what's in the
+// database, at startup rather than at the first request that needs them
+func (c *Config) CheckDependencies(ctx context.Context, db *sql.DB) error {
+	if c.RazorpayKeyID == "" {
+		var paid bool
+		err := db.QueryRowContext(ctx, `
+			SELECT EXISTS(
+				SELECT 1 FROM events e
+				WHERE e.deleted_at IS NULL
+				  AND (e.is_paid_event OR EXISTS(SELECT 1 FROM ticket_tiers t WHERE t.event_id = e.id AND t.price > 0))
+			)
+		`).Scan(&paid)
+		if err != nil {
+			return fmt.Errorf("failed to check for paid events (are migrations applied?): %w", err)
+		}
+		if paid {
+			return fmt.Errorf("RAZORPAY_KEY_ID and RAZORPAY_KEY_SECRET are required: there are paid events")
+		}
+	}
+	return nil
+}
@@ -4,25 +4,30 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync/atomic"
 	"time"
-
-	_ "github.com/lib/pq"
 )
 
 
-// DB wraps the database connection
+// DB wraps the database connection. The embedded *sql.DB is the primary;
+// read-only queries can be routed to replicas through Reader.
 type DB struct {
 	*sql.DB
+
+	pool        PoolConfig
+	replicas    []*replica
+	next        atomic.Uint32
+	stopMonitor chan struct{}
 }
 
 // Connect establishes a database connection with retry logic
-func Connect(dsn string) (*DB, error) {
+func Connect(dsn string, pool PoolConfig) (*DB, error) {
 	var db *sql.DB
 	var err error
 
 	// Retry connection up to 5 times
 	for i := 0; i < 5; i++ {
-		db, err = sql.Open("postgres", dsn)
+		db, err = sql.Open(driverName, dsn)
 		if err != nil {
 			time.Sleep(time.Second * 2)
 			continue
@@ -43,11 +48,9 @@ func Connect(dsn string) (*DB, error) {
 	}
 
 	// Configure connection pool
-	db.SetMaxOpenConns(100)
-	db.SetMaxIdleConns(10)
-	db.SetConnMaxLifetime(time.Hour)
+	pool.apply(db)
 
-	return &DB{db}, nil
+	return &DB{DB: db, pool: pool}, nil
 }
 
 // HealthCheck performs a database health check
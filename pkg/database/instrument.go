@@ -0,0 +1,196 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"log"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/yourusername/college-event-backend/pkg/metrics"
+)
+
+// driverName is the lib/pq driver wrapped with per-statement instrumentation
+const driverName = "postgres-instrumented"
+
+var (
+	queryDuration = metrics.NewHistogramVec(
+		"db_query_duration_seconds",
+		"Duration of database statements, including reading the result set.",
+		metrics.DefaultDurationBuckets,
+		"caller", "op",
+	)
+	queryRows = metrics.NewHistogramVec(
+		"db_query_rows",
+		"Rows returned or affected per database statement.",
+		[]float64{0, 1, 5, 10, 25, 50, 100, 250, 1000},
+		"caller", "op",
+	)
+
+	slowQueryThreshold atomic.Int64
+)
+
+func init() {
+	sql.Register(driverName, instrumentedDriver{&pq.Driver{}})
+	slowQueryThreshold.Store(int64(200 * time.Millisecond))
+}
+
+// SetSlowQueryThreshold sets the duration above which statements are logged.
+// A zero or negative value disables slow query logging.
+func SetSlowQueryThreshold(d time.Duration) {
+	slowQueryThreshold.Store(int64(d))
+}
+
+// observe records a finished statement
+func observe(caller, op, query string, start time.Time, rows int64) {
+	elapsed := time.Since(start)
+	queryDuration.Observe(elapsed.Seconds(), caller, op)
+	if rows >= 0 {
+		queryRows.Observe(float64(rows), caller, op)
+	}
+
+	if threshold := time.Duration(slowQueryThreshold.Load()); threshold > 0 && elapsed >= threshold {
+		log.Printf("[DB] Slow %s (%dms, %d rows) in %s: %s", op, elapsed.Milliseconds(), rows, caller, compactQuery(query))
+	}
+}
+
+// callerName finds the first frame outside database/sql and this package,
+// e.g. "handlers.(*PostsHandler).ListPosts"
+func callerName() string {
+	var pcs [32]uintptr
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		fn := frame.Function
+		if fn != "" &&
+			!strings.HasPrefix(fn, "database/sql") &&
+			!strings.Contains(fn, "/pkg/database.") {
+			if i := strings.LastIndex(fn, "/"); i >= 0 {
+				fn = fn[i+1:]
+			}
+			return fn
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// compactQuery collapses whitespace and truncates long statements for logging
+func compactQuery(query string) string {
+	query = strings.Join(strings.Fields(query), " ")
+	if len(query) > 300 {
+		query = query[:300] + "..."
+	}
+	return query
+}
+
+type instrumentedDriver struct {
+	driver.Driver
+}
+
+func (d instrumentedDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedConn{conn}, nil
+}
+
+// instrumentedConn wraps a pq connection. pq implements all of the optional
+// context interfaces, so they are forwarded unconditionally.
+type instrumentedConn struct {
+	driver.Conn
+}
+
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	caller, start := callerName(), time.Now()
+	rows, err := c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+	if err != nil {
+		observe(caller, "query", query, start, -1)
+		return nil, err
+	}
+	return &instrumentedRows{Rows: rows, caller: caller, query: query, start: start}, nil
+}
+
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	caller, start := callerName(), time.Now()
+	result, err := c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+	affected := int64(-1)
+	if err == nil {
+		if n, rerr := result.RowsAffected(); rerr == nil {
+			affected = n
+		}
+	}
+	observe(caller, "exec", query, start, affected)
+	return result, err
+}
+
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+}
+
+func (c *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+func (c *instrumentedConn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
+
+func (c *instrumentedConn) ResetSession(ctx context.Context) error {
+	return c.Conn.(driver.SessionResetter).ResetSession(ctx)
+}
+
+func (c *instrumentedConn) IsValid() bool {
+	return c.Conn.(driver.Validator).IsValid()
+}
+
+// instrumentedRows records the statement once the result set is closed, so
+// the duration covers streaming the rows to the caller
+type instrumentedRows struct {
+	driver.Rows
+	caller string
+	query  string
+	start  time.Time
+	count  int64
+	done   bool
+}
+
+func (r *instrumentedRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	if err == nil {
+		r.count++
+	} else if err == io.EOF {
+		r.finish()
+	}
+	return err
+}
+
+func (r *instrumentedRows) Close() error {
+	r.finish()
+	return r.Rows.Close()
+}
+
+func (r *instrumentedRows) finish() {
+	if r.done {
+		return
+	}
+	r.done = true
+	observe(r.caller, "query", r.query, r.start, r.count)
+}
+
+func (r *instrumentedRows) ColumnTypeScanType(index int) reflect.Type {
+	return r.Rows.(driver.RowsColumnTypeScanType).ColumnTypeScanType(index)
+}
+
+func (r *instrumentedRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.Rows.(driver.RowsColumnTypeDatabaseTypeName).ColumnTypeDatabaseTypeName(index)
+}
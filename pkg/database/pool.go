@@ -0,0 +1,74 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/yourusername/college-event-backend/pkg/metrics"
+)
+
+// PoolConfig controls the connection pool of the primary and each replica.
+// Every pool counts against the server's connection limit, as do the job
+// queue and outbox relay, which share the primary pool.
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+func (p PoolConfig) apply(db *sql.DB) {
+	db.SetMaxOpenConns(p.MaxOpenConns)
+	db.SetMaxIdleConns(p.MaxIdleConns)
+	db.SetConnMaxLifetime(p.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(p.ConnMaxIdleTime)
+}
+
+// PoolStats is a JSON-friendly snapshot of sql.DBStats
+type PoolStats struct {
+	MaxOpenConnections int     `json:"max_open_connections"`
+	OpenConnections    int     `json:"open_connections"`
+	InUse              int     `json:"in_use"`
+	Idle               int     `json:"idle"`
+	WaitCount          int64   `json:"wait_count"`
+	WaitDurationMs     float64 `json:"wait_duration_ms"`
+	MaxIdleClosed      int64   `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64   `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64   `json:"max_lifetime_closed"`
+}
+
+// PoolStats returns the primary pool's current statistics
+func (db *DB) PoolStats() PoolStats {
+	s := db.Stats()
+	return PoolStats{
+		MaxOpenConnections: s.MaxOpenConnections,
+		OpenConnections:    s.OpenConnections,
+		InUse:              s.InUse,
+		Idle:               s.Idle,
+		WaitCount:          s.WaitCount,
+		WaitDurationMs:     float64(s.WaitDuration) / float64(time.Millisecond),
+		MaxIdleClosed:      s.MaxIdleClosed,
+		MaxIdleTimeClosed:  s.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  s.MaxLifetimeClosed,
+	}
+}
+
+// RegisterPoolMetrics exports the primary pool's statistics on /metrics
+func (db *DB) RegisterPoolMetrics() {
+	stat := func(f func(sql.DBStats) float64) func() float64 {
+		return func() float64 { return f(db.Stats()) }
+	}
+
+	metrics.NewGaugeFunc("db_pool_max_open_connections", "Maximum number of open connections to the database.",
+		stat(func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) }))
+	metrics.NewGaugeFunc("db_pool_open_connections", "Number of established connections, in use and idle.",
+		stat(func(s sql.DBStats) float64 { return float64(s.OpenConnections) }))
+	metrics.NewGaugeFunc("db_pool_in_use_connections", "Number of connections currently in use.",
+		stat(func(s sql.DBStats) float64 { return float64(s.InUse) }))
+	metrics.NewGaugeFunc("db_pool_idle_connections", "Number of idle connections.",
+		stat(func(s sql.DBStats) float64 { return float64(s.Idle) }))
+	metrics.NewCounterFunc("db_pool_wait_count_total", "Total number of connections waited for.",
+		stat(func(s sql.DBStats) float64 { return float64(s.WaitCount) }))
+	metrics.NewCounterFunc("db_pool_wait_duration_seconds_total", "Total time blocked waiting for a new connection.",
+		stat(func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() }))
+}
@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const replicaHealthInterval = 5 * time.Second
+
+// replica is a read-only connection with a cached health flag
+type replica struct {
+	name    string
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// AttachReplicas opens read replicas from a comma-separated DSN list and starts
+// monitoring their health. Replicas that can't be reached at startup are still
+// attached and start serving reads once they recover.
+func (db *DB) AttachReplicas(dsns string) error {
+	for i, dsn := range strings.Split(dsns, ",") {
+		dsn = strings.TrimSpace(dsn)
+		if dsn == "" {
+			continue
+		}
+
+		conn, err := sql.Open(driverName, dsn)
+		if err != nil {
+			return fmt.Errorf("failed to open read replica %d: %w", i+1, err)
+		}
+		db.pool.apply(conn)
+
+		r := &replica{name: fmt.Sprintf("replica-%d", i+1), db: conn}
+		r.healthy.Store(ping(conn) == nil)
+		if !r.healthy.Load() {
+			log.Printf("[DB] Read %s unavailable at startup, reads fall back to primary", r.name)
+		}
+		db.replicas = append(db.replicas, r)
+	}
+
+	if len(db.replicas) > 0 {
+		db.stopMonitor = make(chan struct{})
+		go db.monitorReplicas()
+	}
+	return nil
+}
+
+// Reader returns a connection for read-only queries: a healthy replica when
+// one is attached, otherwise the primary. Reads that must observe the
+// caller's own writes should use the primary directly.
+func (db *DB) Reader() *sql.DB {
+	n := len(db.replicas)
+	if n == 0 {
+		return db.DB
+	}
+
+	start := int(db.next.Add(1))
+	for i := 0; i < n; i++ {
+		r := db.replicas[(start+i)%n]
+		if r.healthy.Load() {
+			return r.db
+		}
+	}
+	return db.DB
+}
+
+// Close closes the primary and all replicas
+func (db *DB) Close() error {
+	if db.stopMonitor != nil {
+		close(db.stopMonitor)
+	}
+	for _, r := range db.replicas {
+		r.db.Close()
+	}
+	return db.DB.Close()
+}
+
+func (db *DB) monitorReplicas() {
+	ticker := time.NewTicker(replicaHealthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-db.stopMonitor:
+			return
+		case <-ticker.C:
+			for _, r := range db.replicas {
+				err := ping(r.db)
+				healthy := err == nil
+				if r.healthy.Swap(healthy) != healthy {
+					if healthy {
+						log.Printf("[DB] Read %s recovered", r.name)
+					} else {
+						log.Printf("[DB] Read %s unhealthy, falling back: %v", r.name, err)
+					}
+				}
+			}
+		}
+	}
+}
+
+func ping(conn *sql.DB) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return conn.PingContext(ctx)
+}
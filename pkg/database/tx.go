@@ -0,0 +1,17 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Execer is satisfied by *sql.Tx and *sql.DB. Helpers that write alongside
+// a domain change take one so the write commits or rolls back with it.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Querier is satisfied by *sql.Tx and *sql.DB
+type Querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
@@ -0,0 +1,88 @@
+// Package ics writes iCalendar (RFC 5545) files, such as the calendar invite
+// attached to registration confirmations.
+package ics
+
+import (
+	"strings"
+	"time"
+)
+
+// dateTimeFormat is a UTC date-time, which every calendar app shows in the
+// reader's own time zone
+const dateTimeFormat = "20060102T150405Z"
+
+// Event is a calendar entry
+type Event struct {
+	// UID identifies the entry, so importing an invite again updates it
+	// instead of adding a copy
+	UID         string
+	Title       string
+	Description string
+	Location    string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	// Updated is when the entry last changed; calendars keep the newest
+	Updated time.Time
+}
+
+// Invite returns a calendar file holding one event
+func Invite(e Event) []byte {
+	var b strings.Builder
+	line := func(name, value string) {
+		fold(&b, name+":"+value)
+	}
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//College Events//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("BEGIN", "VEVENT")
+	line("UID", escape(e.UID))
+	line("DTSTAMP", e.Updated.UTC().Format(dateTimeFormat))
+	line("DTSTART", e.Start.UTC().Format(dateTimeFormat))
+	line("DTEND", e.End.UTC().Format(dateTimeFormat))
+	line("SUMMARY", escape(e.Title))
+	if e.Description != "" {
+		line("DESCRIPTION", escape(e.Description))
+	}
+	if e.Location != "" {
+		line("LOCATION", escape(e.Location))
+	}
+	if e.URL != "" {
+		line("URL", e.URL)
+	}
+	line("END", "VEVENT")
+	line("END", "VCALENDAR")
+	return []byte(b.String())
+}
+
+// escape escapes a text value
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// fold writes a content line, folded so no line is longer than 75 bytes
+// and never inside a UTF-8 sequence
+func fold(b *strings.Builder, s string) {
+	limit := 75
+	for len(s) > limit {
+		n := limit
+		for n > 0 && s[n]&0xC0 == 0x80 {
+			n--
+		}
+		b.WriteString(s[:n])
+		b.WriteString("\r\n ")
+		s = s[n:]
+		// Continuation lines start with a space
+		limit = 74
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+}
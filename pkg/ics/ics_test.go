@@ -0,0 +1,62 @@
+package ics
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInvite(t *testing.T) {
+	start := time.Date(2026, 3, 14, 10, 0, 0, 0, time.FixedZone("IST", 5*3600+1800))
+	got := string(Invite(Event{
+		UID:         "event-1@college-events",
+		Title:       "Robotics Workshop; Day 1, Hall A",
+		Description: "Bring a laptop.\nSnacks provided.",
+		Start:       start,
+		End:         start.Add(2 * time.Hour),
+		Updated:     start,
+	}))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:event-1@college-events\r\n",
+		"DTSTART:20260314T043000Z\r\n",
+		"DTEND:20260314T063000Z\r\n",
+		`SUMMARY:Robotics Workshop\; Day 1\, Hall A` + "\r\n",
+		`DESCRIPTION:Bring a laptop.\nSnacks provided.` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("invite lacks %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "LOCATION") {
+		t.Error("invite has a LOCATION without one set")
+	}
+}
+
+func TestFold(t *testing.T) {
+	var b strings.Builder
+	fold(&b, "DESCRIPTION:"+strings.Repeat("é", 100))
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	if len(lines) < 3 {
+		t.Fatalf("got %d lines, want the value folded", len(lines))
+	}
+	var unfolded strings.Builder
+	for i, l := range lines {
+		if len(l) > 75 {
+			t.Errorf("line %d is %d bytes", i, len(l))
+		}
+		if i > 0 {
+			if !strings.HasPrefix(l, " ") {
+				t.Errorf("continuation line %d doesn't start with a space", i)
+			}
+			l = l[1:]
+		}
+		unfolded.WriteString(l)
+	}
+	if unfolded.String() != "DESCRIPTION:"+strings.Repeat("é", 100) {
+		t.Error("unfolding doesn't restore the line")
+	}
+}
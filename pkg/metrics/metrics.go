@@ -0,0 +1,205 @@
+// Package metrics is a minimal in-process metrics registry exported in the
+// Prometheus text exposition format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Collector writes its samples in the Prometheus text format
+type Collector interface {
+	Collect(w io.Writer)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   []Collector
+)
+
+// Register adds a collector to the default registry
+func Register(c Collector) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, c)
+}
+
+// Handler serves every registered collector
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		WriteAll(w)
+	})
+}
+
+// WriteAll writes every registered collector to w
+func WriteAll(w io.Writer) {
+	registryMu.RLock()
+	collectors := append([]Collector(nil), registry...)
+	registryMu.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	for _, c := range collectors {
+		c.Collect(bw)
+	}
+	bw.Flush()
+}
+
+// DefaultDurationBuckets suit request and query latencies in seconds
+var DefaultDurationBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// HistogramVec is a histogram partitioned by label values
+type HistogramVec struct {
+	name    string
+	help    string
+	buckets []float64
+	labels  []string
+
+	mu     sync.Mutex
+	series map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64 // Per bucket, non-cumulative
+	sum         float64
+	count       uint64
+}
+
+// NewHistogramVec creates a histogram and registers it in the default registry
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	h := &HistogramVec{
+		name:    name,
+		help:    help,
+		buckets: buckets,
+		labels:  labels,
+		series:  make(map[string]*histogramSeries),
+	}
+	Register(h)
+	return h
+}
+
+// Observe records a value. labelValues must match the histogram's labels in order.
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	key := strings.Join(labelValues, "\xff")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{
+			labelValues: append([]string(nil), labelValues...),
+			counts:      make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = s
+	}
+
+	for i, upper := range h.buckets {
+		if value <= upper {
+			s.counts[i]++
+			break
+		}
+	}
+	s.sum += value
+	s.count++
+}
+
+// Collect implements Collector
+func (h *HistogramVec) Collect(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n", h.name, h.help)
+	fmt.Fprintf(w, "# TYPE %s histogram\n", h.name)
+
+	keys := make([]string, 0, len(h.series))
+	for k := range h.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		s := h.series[k]
+		labels := formatLabels(h.labels, s.labelValues)
+
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, withLabel(labels, "le", formatFloat(upper)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, withLabel(labels, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, labels, formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, labels, s.count)
+	}
+}
+
+// formatLabels renders {a="x",b="y"}, or "" when there are no labels
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		var v string
+		if i < len(values) {
+			v = values[i]
+		}
+		pairs[i] = name + `="` + escapeLabelValue(v) + `"`
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func withLabel(labels, name, value string) string {
+	pair := name + `="` + value + `"`
+	if labels == "" {
+		return "{" + pair + "}"
+	}
+	return labels[:len(labels)-1] + "," + pair + "}"
+}
+
+func escapeLabelValue(v string) string {
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `"`, `\"`)
+	return strings.ReplaceAll(v, "\n", `\n`)
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// funcMetric is a single unlabelled sample read at collection time
+type funcMetric struct {
+	name string
+	help string
+	typ  string
+	fn   func() float64
+}
+
+// NewGaugeFunc registers a gauge whose value is read from fn on each scrape
+func NewGaugeFunc(name, help string, fn func() float64) {
+	Register(&funcMetric{name: name, help: help, typ: "gauge", fn: fn})
+}
+
+// NewCounterFunc registers a counter whose value is read from fn on each scrape.
+// fn must be monotonically increasing.
+func NewCounterFunc(name, help string, fn func() float64) {
+	Register(&funcMetric{name: name, help: help, typ: "counter", fn: fn})
+}
+
+// Collect implements Collector
+func (m *funcMetric) Collect(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", m.name, m.help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", m.name, m.typ)
+	fmt.Fprintf(w, "%s %s\n", m.name, formatFloat(m.fn()))
+}
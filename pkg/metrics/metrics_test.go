@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestHistogramVecCollect(t *testing.T) {
+	h := &HistogramVec{
+		name:    "test_duration_seconds",
+		help:    "Test durations.",
+		buckets: []float64{0.1, 1},
+		labels:  []string{"caller"},
+		series:  make(map[string]*histogramSeries),
+	}
+	h.Observe(0.05, `handlers.(*X).Get`)
+	h.Observe(0.5, `handlers.(*X).Get`)
+	h.Observe(3, `handlers.(*X).Get`)
+
+	var buf bytes.Buffer
+	h.Collect(&buf)
+
+	want := `# HELP test_duration_seconds Test durations.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{caller="handlers.(*X).Get",le="0.1"} 1
+test_duration_seconds_bucket{caller="handlers.(*X).Get",le="1"} 2
+test_duration_seconds_bucket{caller="handlers.(*X).Get",le="+Inf"} 3
+test_duration_seconds_sum{caller="handlers.(*X).Get"} 3.55
+test_duration_seconds_count{caller="handlers.(*X).Get"} 3
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Collect() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`plain`, `plain`},
+		{`a"b`, `a\"b`},
+		{`a\b`, `a\\b`},
+		{"a\nb", `a\nb`},
+	}
+
+	for _, tt := range tests {
+		if got := escapeLabelValue(tt.in); got != tt.want {
+			t.Errorf("escapeLabelValue(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,497 @@
+// Package qrcode encodes short text, such as URLs, as QR codes.
+//
+// It implements the subset of ISO/IEC 18004 needed for links on printed
+// material: byte mode, error correction level M (about 15% of the code can
+// be damaged or covered) and versions 1 to 10, which hold up to 213 bytes.
+package qrcode
+
+import (
+	"errors"
+	"image"
+	"image/color"
+)
+
+// MaxLength is the most bytes a code can hold
+const MaxLength = 213
+
+// ErrTooLong is returned for text longer than MaxLength
+var ErrTooLong = errors.New("qrcode: text too long")
+
+// version describes the codewords of a QR code version at level M
+type version struct {
+	total     int   // Codewords, data and error correction
+	ecPer     int   // Error correction codewords per block
+	blocks    int   // Blocks the codewords are split into
+	alignment []int // Centers of alignment patterns, on both axes
+}
+
+var versions = []version{
+	1:  {total: 26, ecPer: 10, blocks: 1},
+	2:  {total: 44, ecPer: 16, blocks: 1, alignment: []int{6, 18}},
+	3:  {total: 70, ecPer: 26, blocks: 1, alignment: []int{6, 22}},
+	4:  {total: 100, ecPer: 18, blocks: 2, alignment: []int{6, 26}},
+	5:  {total: 134, ecPer: 24, blocks: 2, alignment: []int{6, 30}},
+	6:  {total: 172, ecPer: 16, blocks: 4, alignment: []int{6, 34}},
+	7:  {total: 196, ecPer: 18, blocks: 4, alignment: []int{6, 22, 38}},
+	8:  {total: 242, ecPer: 22, blocks: 4, alignment: []int{6, 24, 42}},
+	9:  {total: 292, ecPer: 22, blocks: 5, alignment: []int{6, 26, 46}},
+	10: {total: 346, ecPer: 26, blocks: 5, alignment: []int{6, 28, 50}},
+}
+
+// dataCapacity returns how many data codewords version v holds
+func (v version) dataCapacity() int {
+	return v.total - v.ecPer*v.blocks
+}
+
+// Code is an encoded QR code: a square of dark and light modules
+type Code struct {
+	Size    int // Modules per side
+	modules [][]bool
+	// function marks modules of the fixed patterns, which data and masks
+	// leave alone
+	function [][]bool
+}
+
+// Dark reports whether the module at column x, row y is dark
+func (c *Code) Dark(x, y int) bool {
+	return c.modules[y][x]
+}
+
+// Encode encodes text in the smallest version that holds it
+func Encode(text string) (*Code, error) {
+	data := []byte(text)
+	if len(data) > MaxLength {
+		return nil, ErrTooLong
+	}
+
+	v := 1
+	for ; v < len(versions); v++ {
+		if bitsNeeded(v, len(data)) <= versions[v].dataCapacity()*8 {
+			break
+		}
+	}
+	if v == len(versions) {
+		return nil, ErrTooLong
+	}
+
+	codewords := interleave(versions[v], dataCodewords(v, data))
+	c := newCode(v)
+	c.drawFunctionPatterns(v)
+	c.drawCodewords(codewords)
+
+	// Keep the mask that leaves the fewest patterns confusing to scanners
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormat(mask)
+		if p := c.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		c.applyMask(mask) // Masks are their own inverse
+	}
+	c.applyMask(best)
+	c.drawFormat(best)
+	return c, nil
+}
+
+// countBits returns the width of the byte count in version v
+func countBits(v int) int {
+	if v <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// bitsNeeded returns the bits encoding n bytes take in version v
+func bitsNeeded(v, n int) int {
+	return 4 + countBits(v) + 8*n
+}
+
+// bitBuffer is a sequence of bits, most significant first
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, bits int) {
+	for i := bits - 1; i >= 0; i-- {
+		*b = append(*b, value>>uint(i)&1 == 1)
+	}
+}
+
+// dataCodewords encodes data in byte mode, padded to version v's capacity
+func dataCodewords(v int, data []byte) []byte {
+	capacity := versions[v].dataCapacity()
+
+	var bits bitBuffer
+	bits.append(0x4, 4) // Byte mode
+	bits.append(len(data), countBits(v))
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	terminator := capacity*8 - len(bits)
+	if terminator > 4 {
+		terminator = 4
+	}
+	bits.append(0, terminator)
+	if r := len(bits) % 8; r != 0 {
+		bits.append(0, 8-r)
+	}
+
+	codewords := make([]byte, 0, capacity)
+	for i := 0; i < len(bits); i += 8 {
+		var b byte
+		for _, bit := range bits[i : i+8] {
+			b <<= 1
+			if bit {
+				b |= 1
+			}
+		}
+		codewords = append(codewords, b)
+	}
+	for pad := byte(0xEC); len(codewords) < capacity; pad ^= 0xEC ^ 0x11 {
+		codewords = append(codewords, pad)
+	}
+	return codewords
+}
+
+// interleave splits data into blocks, adds each block's error correction
+// and interleaves the blocks. Where blocks differ in length, the first are
+// a codeword shorter.
+func interleave(v version, data []byte) []byte {
+	short := v.blocks - v.total%v.blocks
+	shortLen := v.total/v.blocks - v.ecPer
+	divisor := rsDivisor(v.ecPer)
+
+	blocks := make([][]byte, v.blocks)
+	k := 0
+	for i := range blocks {
+		n := shortLen
+		if i >= short {
+			n++
+		}
+		dat := data[k : k+n]
+		k += n
+		block := append([]byte(nil), dat...)
+		if i < short {
+			// Placeholder, skipped when interleaving, to line up the
+			// error correction codewords
+			block = append(block, 0)
+		}
+		blocks[i] = append(block, rsRemainder(dat, divisor)...)
+	}
+
+	result := make([]byte, 0, v.total)
+	for i := range blocks[0] {
+		for j, block := range blocks {
+			if i != shortLen || j >= short {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// gfMul multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1
+func gfMul(x, y byte) byte {
+	var z byte
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x1D
+		z ^= (y >> uint(i) & 1) * x
+	}
+	return z
+}
+
+// rsDivisor returns the Reed-Solomon generator polynomial of a degree,
+// without its leading term, highest power first
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMul(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMul(root, 0x02)
+	}
+	return result
+}
+
+// rsRemainder returns the error correction codewords of data
+func rsRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= gfMul(d, factor)
+		}
+	}
+	return result
+}
+
+func newCode(v int) *Code {
+	size := 17 + 4*v
+	c := &Code{Size: size, modules: make([][]bool, size), function: make([][]bool, size)}
+	for i := range c.modules {
+		c.modules[i] = make([]bool, size)
+		c.function[i] = make([]bool, size)
+	}
+	return c
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.function[y][x] = true
+}
+
+func (c *Code) drawFunctionPatterns(v int) {
+	for i := 0; i < c.Size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	// Finder patterns, with their separators, in three corners
+	for _, center := range [][2]int{{3, 3}, {c.Size - 4, 3}, {3, c.Size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := center[0]+dx, center[1]+dy
+				if x < 0 || x >= c.Size || y < 0 || y >= c.Size {
+					continue
+				}
+				d := max(abs(dx), abs(dy))
+				c.setFunction(x, y, d != 2 && d != 4)
+			}
+		}
+	}
+
+	// Alignment patterns, except where they would overlap the finders
+	pos := versions[v].alignment
+	last := len(pos) - 1
+	for i, x := range pos {
+		for j, y := range pos {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					c.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// Reserve the format areas; the mask decides their content
+	c.drawFormat(0)
+
+	if v >= 7 {
+		bits := versionBits(v)
+		for i := 0; i < 18; i++ {
+			dark := bits>>uint(i)&1 == 1
+			a, b := c.Size-11+i%3, i/3
+			c.setFunction(a, b, dark)
+			c.setFunction(b, a, dark)
+		}
+	}
+}
+
+// versionBits returns the version information of version v, with its
+// BCH error correction
+func versionBits(v int) int {
+	rem := v
+	for i := 0; i < 12; i++ {
+		rem = rem<<1 ^ (rem>>11)*0x1F25
+	}
+	return v<<12 | rem
+}
+
+// formatBits returns the format information of a mask at level M, with its
+// BCH error correction, masked as the standard requires
+func formatBits(mask int) int {
+	const levelM = 0
+	data := levelM<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// drawFormat draws both copies of the format information
+func (c *Code) drawFormat(mask int) {
+	bits := formatBits(mask)
+	bit := func(i int) bool { return bits>>uint(i)&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(i))
+	}
+	c.setFunction(8, 7, bit(6))
+	c.setFunction(8, 8, bit(7))
+	c.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(i))
+	}
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.Size-15+i, bit(i))
+	}
+	c.setFunction(8, c.Size-8, true) // Always dark
+}
+
+// drawCodewords places the codewords in the zigzag of two-module columns,
+// from the bottom right
+func (c *Code) drawCodewords(codewords []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // Skip the vertical timing pattern
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < c.Size; vert++ {
+			y := vert
+			if upward {
+				y = c.Size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if c.function[y][x] || i >= len(codewords)*8 {
+					continue
+				}
+				c.modules[y][x] = codewords[i>>3]>>uint(7-i&7)&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+// applyMask flips the data modules selected by a mask pattern
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			var flip bool
+			switch mask {
+			case 0:
+				flip = (x+y)%2 == 0
+			case 1:
+				flip = y%2 == 0
+			case 2:
+				flip = x%3 == 0
+			case 3:
+				flip = (x+y)%3 == 0
+			case 4:
+				flip = (x/3+y/2)%2 == 0
+			case 5:
+				flip = x*y%2+x*y%3 == 0
+			case 6:
+				flip = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				flip = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if flip && !c.function[y][x] {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// finderLike is the 1:1:3:1:1 run of a finder pattern with four light
+// modules on one side, which scanners could mistake for a finder
+var finderLike = [][]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+// penalty scores how hard the code is to scan: long runs of one color,
+// 2x2 blocks, finder-like patterns and unbalanced dark and light
+func (c *Code) penalty() int {
+	n := c.Size
+	at := func(x, y int, vertical bool) bool {
+		if vertical {
+			return c.modules[x][y]
+		}
+		return c.modules[y][x]
+	}
+
+	score, dark := 0, 0
+	for _, vertical := range []bool{false, true} {
+		for y := 0; y < n; y++ {
+			run := 1
+			for x := 1; x <= n; x++ {
+				if x < n && at(x, y, vertical) == at(x-1, y, vertical) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += 3 + run - 5
+				}
+				run = 1
+			}
+			for x := 0; x+11 <= n; x++ {
+				for _, p := range finderLike {
+					match := true
+					for k, d := range p {
+						if at(x+k, y, vertical) != d {
+							match = false
+							break
+						}
+					}
+					if match {
+						score += 40
+					}
+				}
+			}
+		}
+	}
+
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+			if x+1 < n && y+1 < n {
+				d := c.modules[y][x]
+				if c.modules[y][x+1] == d && c.modules[y+1][x] == d && c.modules[y+1][x+1] == d {
+					score += 3
+				}
+			}
+		}
+	}
+
+	total := n * n
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	return score + k*10
+}
+
+// Image renders the code with scale pixels per module and the 4-module
+// light border scanners need
+func (c *Code) Image(scale int) image.Image {
+	const quiet = 4
+	side := (c.Size + 2*quiet) * scale
+	img := image.NewGray(image.Rect(0, 0, side, side))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if !c.modules[y][x] {
+				continue
+			}
+			for py := 0; py < scale; py++ {
+				for px := 0; px < scale; px++ {
+					img.SetGray((x+quiet)*scale+px, (y+quiet)*scale+py, color.Gray{})
+				}
+			}
+		}
+	}
+	return img
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
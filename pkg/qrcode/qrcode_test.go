@@ -0,0 +1,90 @@
+package qrcode
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestErrorCorrection(t *testing.T) {
+	// "HELLO WORLD" at 1-M, the worked example of the standard's annex
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := rsRemainder(data, rsDivisor(10)); !bytes.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFormatAndVersionBits(t *testing.T) {
+	for mask, want := range map[int]int{
+		0: 0b101010000010010,
+		1: 0b101000100100101,
+		4: 0b100010111111001,
+		7: 0b100101010100000,
+	} {
+		if got := formatBits(mask); got != want {
+			t.Errorf("formatBits(%d) = %015b, want %015b", mask, got, want)
+		}
+	}
+	if got, want := versionBits(7), 0b000111110010010100; got != want {
+		t.Errorf("versionBits(7) = %018b, want %018b", got, want)
+	}
+}
+
+func TestDataCodewordsArePadded(t *testing.T) {
+	got := dataCodewords(1, []byte("ab"))
+	// Byte mode, count 2, 'a', 'b', terminator, then alternating pad bytes
+	want := []byte{0x40, 0x26, 0x16, 0x20, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got % x, want % x", got, want)
+	}
+}
+
+func TestEncodePicksSmallestVersion(t *testing.T) {
+	for _, tc := range []struct {
+		text string
+		size int
+	}{
+		{"https://x.io", 21},                        // Version 1
+		{"https://api.example.edu/e/AB12CD", 29},    // Version 3
+		{strings.Repeat("a", MaxLength), 17 + 4*10}, // Version 10
+	} {
+		c, err := Encode(tc.text)
+		if err != nil {
+			t.Fatalf("Encode(%q): %v", tc.text, err)
+		}
+		if c.Size != tc.size {
+			t.Errorf("Encode(%q) is %d modules wide, want %d", tc.text, c.Size, tc.size)
+		}
+		// Finder pattern centers are dark, their separators light
+		for _, p := range [][2]int{{3, 3}, {c.Size - 4, 3}, {3, c.Size - 4}} {
+			if !c.Dark(p[0], p[1]) {
+				t.Errorf("finder at %v is not dark", p)
+			}
+		}
+		if c.Dark(7, 7) || !c.Dark(8, c.Size-8) {
+			t.Error("separator or dark module misplaced")
+		}
+	}
+
+	if _, err := Encode(strings.Repeat("a", MaxLength+1)); err != ErrTooLong {
+		t.Errorf("got %v, want ErrTooLong", err)
+	}
+}
+
+func TestImageHasQuietZone(t *testing.T) {
+	c, err := Encode("hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := c.Image(2)
+	if got, want := img.Bounds().Dx(), (c.Size+8)*2; got != want {
+		t.Fatalf("image is %d px wide, want %d", got, want)
+	}
+	if r, _, _, _ := img.At(7, 7).RGBA(); r == 0 {
+		t.Error("quiet zone is dark")
+	}
+	if r, _, _, _ := img.At(8, 8).RGBA(); r != 0 {
+		t.Error("finder corner is light")
+	}
+}
@@ -0,0 +1,79 @@
+// Package version identifies the running build. The values are set at build
+// time through ldflags (see the Makefile):
+//
+//	go build -ldflags "-X github.com/yourusername/college-event-backend/pkg/version.Version=v1.4.0 ..."
+//
+// Without a commit set, the commit, its time and whether the tree was
+// modified come from the VCS information Go embeds in binaries built inside
+// the repository.
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Set through ldflags
+var (
+	// Version is the release, e.g. v1.4.0, or git describe output
+	Version = "dev"
+	// Commit is the git SHA the binary was built from
+	Commit = ""
+	// BuildTime is when the binary was built, in RFC 3339
+	BuildTime = ""
+	// Migration is the latest database migration the build ships, e.g. 059
+	Migration = ""
+)
+
+// Info describes the running build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	Migration string `json:"migration,omitempty"`
+	Modified  bool   `json:"modified,omitempty"` // Built with uncommitted changes
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the running build's information
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		Migration: Migration,
+		GoVersion: runtime.Version(),
+	}
+	if build, ok := debug.ReadBuildInfo(); ok && info.Commit == "" {
+		for _, s := range build.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				info.Commit = s.Value
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = s.Value
+				}
+			case "vcs.modified":
+				info.Modified = s.Value == "true"
+			}
+		}
+	}
+	return info
+}
+
+// String returns the version with its short commit, e.g. "v1.4.0 (3f2c1ab)",
+// as shown in logs
+func String() string {
+	info := Get()
+	s := info.Version
+	if commit := info.Commit; commit != "" {
+		if len(commit) > 7 {
+			commit = commit[:7]
+		}
+		if info.Modified {
+			commit += "+dirty"
+		}
+		s += " (" + commit + ")"
+	}
+	return s
+}
@@ -0,0 +1,15 @@
+package version
+
+import "testing"
+
+func TestString(t *testing.T) {
+	defer func(v, c string) { Version, Commit = v, c }(Version, Commit)
+
+	Version, Commit = "v1.4.0", "3f2c1ab9d0e4c5b6a7f8e9d0c1b2a3f4e5d6c7b8"
+	if got := Get(); got.Version != "v1.4.0" || got.Commit != Commit {
+		t.Errorf("Get() = %+v, want the ldflags values", got)
+	}
+	if got := String(); got != "v1.4.0 (3f2c1ab)" {
+		t.Errorf("String() = %q, want %q", got, "v1.4.0 (3f2c1ab)")
+	}
+}
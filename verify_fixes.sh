@@ -77,6 +77,6 @@ echo "Next steps:"
 echo "1. Build the backend: go build ./cmd/api"
 echo "2. Run tests: go test ./internal/models -v"
 echo "3. Set up PostgreSQL with credentials in .env"
-echo "4. Run migrations: go run ./cmd/migrate/main.go"
+echo "4. Run migrations: go run ./cmd/migrate"
 echo "5. Start the server: go run ./cmd/api/main.go"
 echo "6. Test with Flutter app to create events"